@@ -0,0 +1,118 @@
+package framework
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned if a condition wasn't met within the given time.
+	ErrTimeout = errors.New("timeout")
+
+	apiClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// APIError is returned if the API of a node answered with an error.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Command sends the given command to the HTTP API of the node and decodes the response into result.
+func (n *Node) Command(command interface{}, result interface{}) error {
+	apiURL, err := n.APIURL()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-IOTA-API-Version", "1")
+
+	res, err := apiClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var errorReturn struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &errorReturn) != nil || errorReturn.Error == "" {
+			errorReturn.Error = string(data)
+		}
+		return &APIError{StatusCode: res.StatusCode, Message: errorReturn.Error}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+// NodeInfo is the part of the getNodeInfo response the tests need.
+type NodeInfo struct {
+	AppVersion                         string `json:"appVersion"`
+	LatestMilestoneIndex               uint32 `json:"latestMilestoneIndex"`
+	LatestSolidSubtangleMilestoneIndex uint32 `json:"latestSolidSubtangleMilestoneIndex"`
+	IsSynced                           bool   `json:"isSynced"`
+	Neighbors                          uint   `json:"neighbors"`
+}
+
+// Info returns the info of the node.
+func (n *Node) Info() (*NodeInfo, error) {
+	info := &NodeInfo{}
+	if err := n.Command(map[string]string{"command": "getNodeInfo"}, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// WaitForAPI waits until the HTTP API of the node answers.
+func (n *Node) WaitForAPI(timeout time.Duration) error {
+	return waitFor(timeout, func() (bool, error) {
+		_, err := n.Info()
+		return err == nil, nil
+	})
+}
+
+// waitFor polls the given condition until it is met, it fails or the timeout is reached.
+func waitFor(timeout time.Duration, condition func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		met, err := condition()
+		if err != nil {
+			return err
+		}
+		if met {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrTimeout
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
@@ -0,0 +1,306 @@
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+// the message types and commands of the dashboard WebSocket feed (see plugins/dashboard)
+const (
+	dashboardCmdRegister byte = 0
+
+	dashboardMsgTypeTPSMetric          byte = 1
+	dashboardMsgTypePeerMetric         byte = 6
+	dashboardMsgTypeConfirmedMsMetrics byte = 7
+
+	// the amount of events kept per type until they are consumed, newer events are dropped if it is reached
+	dashboardEventBufferSize = 100
+)
+
+var (
+	// ErrFeedClosed is returned if the dashboard feed was closed while waiting for an event.
+	ErrFeedClosed = errors.New("dashboard feed closed")
+)
+
+// TPSMetric is the transactions per second metric of the dashboard feed, it is sent every second.
+type TPSMetric struct {
+	Incoming uint32 `json:"incoming"`
+	New      uint32 `json:"new"`
+	Outgoing uint32 `json:"outgoing"`
+}
+
+// ConfirmedMilestone is sent by the dashboard feed for every confirmed milestone.
+type ConfirmedMilestone struct {
+	Index                  uint32  `json:"ms_index"`
+	TPS                    float64 `json:"tps"`
+	CTPS                   float64 `json:"ctps"`
+	ConfirmationRate       float64 `json:"conf_rate"`
+	TimeSinceLastMilestone float64 `json:"time_since_last_ms"`
+}
+
+// PeerChange is derived from the peer metrics of the dashboard feed if a peer was added, removed,
+// connected or disconnected.
+type PeerChange struct {
+	Identity  string
+	Alias     string
+	Added     bool
+	Removed   bool
+	Connected bool
+}
+
+type dashboardMsg struct {
+	Type byte            `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type dashboardPeerMetric struct {
+	Identity  string `json:"identity"`
+	Alias     string `json:"alias"`
+	Connected bool   `json:"connected"`
+}
+
+// DashboardFeed exposes the events of the dashboard WebSocket feed of a node as typed events.
+type DashboardFeed struct {
+	conn *websocket.Conn
+
+	// TPS receives the TPS metrics.
+	TPS chan *TPSMetric
+	// ConfirmedMilestones receives the confirmed milestones.
+	ConfirmedMilestones chan *ConfirmedMilestone
+	// PeerChanges receives the changes of the peers.
+	PeerChanges chan *PeerChange
+
+	closeLock syncutils.Mutex
+	closed    chan struct{}
+	errLock   syncutils.Mutex
+	err       error
+
+	// the connection state of the known peers by identity
+	peers map[string]bool
+}
+
+// DashboardFeed connects to the dashboard WebSocket feed of the node.
+func (n *Node) DashboardFeed() (*DashboardFeed, error) {
+	dashboardURL, err := n.DashboardURL()
+	if err != nil {
+		return nil, err
+	}
+	return ConnectDashboardFeed(dashboardURL, nil)
+}
+
+// ConnectDashboardFeed connects to the WebSocket feed of the dashboard at the given URL and subscribes to its metrics.
+// The header is sent with the handshake, e.g. the session cookie if the dashboard requires a login.
+func ConnectDashboardFeed(dashboardURL string, header http.Header) (*DashboardFeed, error) {
+	u, err := url.Parse(dashboardURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ws"
+
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), header)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &DashboardFeed{
+		conn:                conn,
+		TPS:                 make(chan *TPSMetric, dashboardEventBufferSize),
+		ConfirmedMilestones: make(chan *ConfirmedMilestone, dashboardEventBufferSize),
+		PeerChanges:         make(chan *PeerChange, dashboardEventBufferSize),
+		closed:              make(chan struct{}),
+		peers:               make(map[string]bool),
+	}
+
+	for _, topic := range []byte{dashboardMsgTypeTPSMetric, dashboardMsgTypePeerMetric, dashboardMsgTypeConfirmedMsMetrics} {
+		if err := conn.WriteMessage(websocket.BinaryMessage, []byte{dashboardCmdRegister, topic}); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	go feed.readLoop()
+
+	return feed, nil
+}
+
+// Err returns the error which ended the feed, if any.
+func (f *DashboardFeed) Err() error {
+	f.errLock.Lock()
+	defer f.errLock.Unlock()
+	return f.err
+}
+
+// Close closes the connection to the dashboard.
+func (f *DashboardFeed) Close() error {
+	f.closeLock.Lock()
+	defer f.closeLock.Unlock()
+
+	select {
+	case <-f.closed:
+		return nil
+	default:
+	}
+	close(f.closed)
+	return f.conn.Close()
+}
+
+func (f *DashboardFeed) readLoop() {
+	for {
+		var msg dashboardMsg
+		if err := f.conn.ReadJSON(&msg); err != nil {
+			select {
+			case <-f.closed:
+			default:
+				f.errLock.Lock()
+				f.err = err
+				f.errLock.Unlock()
+				_ = f.Close()
+			}
+			return
+		}
+
+		f.handle(&msg)
+	}
+}
+
+func (f *DashboardFeed) handle(msg *dashboardMsg) {
+	switch msg.Type {
+	case dashboardMsgTypeTPSMetric:
+		metric := &TPSMetric{}
+		if json.Unmarshal(msg.Data, metric) == nil {
+			select {
+			case f.TPS <- metric:
+			default:
+			}
+		}
+
+	case dashboardMsgTypeConfirmedMsMetrics:
+		// the initial value is the list of the recent milestones, later ones are single milestones
+		var milestones []*ConfirmedMilestone
+		if json.Unmarshal(msg.Data, &milestones) != nil {
+			single := &ConfirmedMilestone{}
+			if json.Unmarshal(msg.Data, single) != nil {
+				return
+			}
+			milestones = []*ConfirmedMilestone{single}
+		}
+		for _, ms := range milestones {
+			select {
+			case f.ConfirmedMilestones <- ms:
+			default:
+			}
+		}
+
+	case dashboardMsgTypePeerMetric:
+		var metrics []*dashboardPeerMetric
+		if json.Unmarshal(msg.Data, &metrics) != nil {
+			return
+		}
+		for _, change := range f.diffPeers(metrics) {
+			select {
+			case f.PeerChanges <- change:
+			default:
+			}
+		}
+	}
+}
+
+// diffPeers updates the known peers with the given metrics and returns the changes.
+func (f *DashboardFeed) diffPeers(metrics []*dashboardPeerMetric) []*PeerChange {
+	var changes []*PeerChange
+
+	seen := make(map[string]struct{}, len(metrics))
+	for _, metric := range metrics {
+		seen[metric.Identity] = struct{}{}
+
+		connected, known := f.peers[metric.Identity]
+		if known && connected == metric.Connected {
+			continue
+		}
+		f.peers[metric.Identity] = metric.Connected
+		changes = append(changes, &PeerChange{Identity: metric.Identity, Alias: metric.Alias, Added: !known, Connected: metric.Connected})
+	}
+
+	for identity := range f.peers {
+		if _, exists := seen[identity]; !exists {
+			delete(f.peers, identity)
+			changes = append(changes, &PeerChange{Identity: identity, Removed: true})
+		}
+	}
+
+	return changes
+}
+
+// WaitForTPS waits for the next TPS metric which fulfills the given condition.
+func (f *DashboardFeed) WaitForTPS(timeout time.Duration, condition func(*TPSMetric) bool) (*TPSMetric, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case metric := <-f.TPS:
+			if condition == nil || condition(metric) {
+				return metric, nil
+			}
+		case <-f.closed:
+			return nil, f.closedErr()
+		case <-timer.C:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+// WaitForConfirmedMilestone waits until a milestone with at least the given index was confirmed.
+func (f *DashboardFeed) WaitForConfirmedMilestone(index uint32, timeout time.Duration) (*ConfirmedMilestone, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case ms := <-f.ConfirmedMilestones:
+			if ms.Index >= index {
+				return ms, nil
+			}
+		case <-f.closed:
+			return nil, f.closedErr()
+		case <-timer.C:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+// WaitForPeerChange waits for the next change of the peer with the given identity which fulfills the given condition.
+// An empty identity matches all peers.
+func (f *DashboardFeed) WaitForPeerChange(identity string, timeout time.Duration, condition func(*PeerChange) bool) (*PeerChange, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case change := <-f.PeerChanges:
+			if (identity == "" || change.Identity == identity) && (condition == nil || condition(change)) {
+				return change, nil
+			}
+		case <-f.closed:
+			return nil, f.closedErr()
+		case <-timer.C:
+			return nil, ErrTimeout
+		}
+	}
+}
+
+func (f *DashboardFeed) closedErr() error {
+	if err := f.Err(); err != nil {
+		return err
+	}
+	return ErrFeedClosed
+}
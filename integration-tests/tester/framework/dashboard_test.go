@@ -0,0 +1,91 @@
+package framework
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDashboard serves a WebSocket feed like the dashboard, which sends the given messages once all topics are registered.
+func fakeDashboard(t *testing.T, messages []string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws", r.URL.Path)
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var topics []byte
+		for len(topics) < 3 {
+			_, data, err := conn.ReadMessage()
+			if !assert.NoError(t, err) || !assert.Len(t, data, 2) {
+				return
+			}
+			assert.Equal(t, dashboardCmdRegister, data[0])
+			topics = append(topics, data[1])
+		}
+		assert.ElementsMatch(t, []byte{dashboardMsgTypeTPSMetric, dashboardMsgTypePeerMetric, dashboardMsgTypeConfirmedMsMetrics}, topics)
+
+		for _, msg := range messages {
+			if !assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(msg))) {
+				return
+			}
+		}
+
+		// keep the connection open until the client leaves
+		_, _, _ = conn.ReadMessage()
+	}))
+}
+
+func TestDashboardFeed(t *testing.T) {
+	server := fakeDashboard(t, []string{
+		`{"type":7,"data":[{"ms_index":5,"tps":1.5,"ctps":1,"conf_rate":66.6,"time_since_last_ms":10}]}`,
+		`{"type":1,"data":{"incoming":3,"new":2,"outgoing":1}}`,
+		`{"type":6,"data":[{"identity":"a:15600","alias":"a","connected":false}]}`,
+		`{"type":6,"data":[{"identity":"a:15600","alias":"a","connected":true}]}`,
+		`{"type":7,"data":{"ms_index":6,"tps":2,"ctps":2,"conf_rate":100,"time_since_last_ms":10}}`,
+		`{"type":1,"data":{"incoming":10,"new":5,"outgoing":2}}`,
+		`{"type":6,"data":[]}`,
+	})
+	defer server.Close()
+
+	feed, err := ConnectDashboardFeed(server.URL, nil)
+	require.NoError(t, err)
+	defer feed.Close()
+
+	ms, err := feed.WaitForConfirmedMilestone(6, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, &ConfirmedMilestone{Index: 6, TPS: 2, CTPS: 2, ConfirmationRate: 100, TimeSinceLastMilestone: 10}, ms)
+
+	tps, err := feed.WaitForTPS(5*time.Second, func(metric *TPSMetric) bool { return metric.New >= 5 })
+	require.NoError(t, err)
+	assert.Equal(t, &TPSMetric{Incoming: 10, New: 5, Outgoing: 2}, tps)
+
+	change, err := feed.WaitForPeerChange("a:15600", 5*time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &PeerChange{Identity: "a:15600", Alias: "a", Added: true}, change)
+
+	change, err = feed.WaitForPeerChange("a:15600", 5*time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &PeerChange{Identity: "a:15600", Alias: "a", Connected: true}, change)
+
+	change, err = feed.WaitForPeerChange("a:15600", 5*time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &PeerChange{Identity: "a:15600", Removed: true}, change)
+
+	_, err = feed.WaitForTPS(100*time.Millisecond, nil)
+	assert.Equal(t, ErrTimeout, err)
+
+	require.NoError(t, feed.Close())
+	_, err = feed.WaitForTPS(time.Second, nil)
+	assert.Equal(t, ErrFeedClosed, err)
+}
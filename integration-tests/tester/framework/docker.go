@@ -0,0 +1,37 @@
+// Package framework runs networks of HORNET nodes in docker containers for the integration tests.
+//
+// The containers are controlled via the docker CLI, so the only requirement on the host
+// is a docker installation and an image of the node, e.g. built with:
+//
+//	docker build -f docker/Dockerfile.dev -t hornet:dev .
+package framework
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// dockerBinary is the docker CLI which controls the containers.
+var dockerBinary = "docker"
+
+// docker runs the docker CLI with the given arguments and returns its trimmed output.
+func docker(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(dockerBinary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ImageExists returns whether the docker CLI is available and the given image exists on the host.
+func ImageExists(image string) bool {
+	_, err := docker("image", "inspect", image)
+	return err == nil
+}
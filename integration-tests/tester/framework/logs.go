@@ -0,0 +1,218 @@
+package framework
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+// logLine is a line of the log of a node.
+type logLine struct {
+	timestamp time.Time
+	node      string
+	text      string
+}
+
+// LogAggregator follows the logs of the containers of a network and writes them
+// into a single file, interleaved by their timestamps and prefixed with the name of the node.
+// The file is written when the aggregator is closed, so that lines which arrive late are still sorted in.
+type LogAggregator struct {
+	syncutils.Mutex
+
+	path    string
+	lines   []*logLine
+	running map[*exec.Cmd]struct{}
+	closing chan struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewLogAggregator creates an aggregator which writes the merged log to the given file.
+func NewLogAggregator(path string) *LogAggregator {
+	return &LogAggregator{
+		path:    path,
+		running: make(map[*exec.Cmd]struct{}),
+		closing: make(chan struct{}),
+	}
+}
+
+// Follow follows the log of the given node until the aggregator is closed.
+// The log is followed again after a restart of the container.
+func (a *LogAggregator) Follow(node *Node) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		var since time.Time
+		for {
+			if last := a.followOnce(node, since); last.After(since) {
+				since = last
+			}
+
+			// the container was stopped or restarted
+			select {
+			case <-a.closing:
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+}
+
+// followOnce follows the log of the given node until the container stops and returns the timestamp of the last line.
+func (a *LogAggregator) followOnce(node *Node, since time.Time) time.Time {
+	args := []string{"logs", "--follow", "--timestamps"}
+	if !since.IsZero() {
+		args = append(args, "--since", since.Format(time.RFC3339Nano))
+	}
+	cmd := exec.Command(dockerBinary, append(args, node.ContainerName)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return since
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return since
+	}
+
+	a.Lock()
+	if a.closed {
+		a.Unlock()
+		return since
+	}
+	if err := cmd.Start(); err != nil {
+		a.Unlock()
+		return since
+	}
+	a.running[cmd] = struct{}{}
+	a.Unlock()
+
+	var last [2]time.Time
+	var readers sync.WaitGroup
+	for i, r := range []io.Reader{stdout, stderr} {
+		readers.Add(1)
+		go func(i int, r io.Reader) {
+			defer readers.Done()
+			last[i] = a.read(node.Name, r, since)
+		}(i, r)
+	}
+	readers.Wait()
+	_ = cmd.Wait()
+
+	a.Lock()
+	delete(a.running, cmd)
+	a.Unlock()
+
+	if last[1].After(last[0]) {
+		return last[1]
+	}
+	return last[0]
+}
+
+// read adds the timestamped lines of the given reader which are newer than since and returns the timestamp of the last line.
+func (a *LogAggregator) read(node string, r io.Reader, since time.Time) time.Time {
+	last := since
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := parseLogLine(node, scanner.Text())
+		if line == nil || !line.timestamp.After(since) {
+			continue
+		}
+
+		a.Lock()
+		a.lines = append(a.lines, line)
+		a.Unlock()
+
+		if line.timestamp.After(last) {
+			last = line.timestamp
+		}
+	}
+
+	return last
+}
+
+// parseLogLine parses a line of "docker logs --timestamps".
+func parseLogLine(node string, raw string) *logLine {
+	parts := strings.SplitN(raw, " ", 2)
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil
+	}
+
+	line := &logLine{timestamp: timestamp, node: node}
+	if len(parts) == 2 {
+		line.text = parts[1]
+	}
+	return line
+}
+
+// Close stops following the logs and writes the merged log file.
+func (a *LogAggregator) Close() error {
+	a.Lock()
+	if a.closed {
+		a.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.closing)
+	for cmd := range a.running {
+		_ = cmd.Process.Kill()
+	}
+	a.Unlock()
+
+	a.wg.Wait()
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(a.path)
+	if err != nil {
+		return err
+	}
+
+	a.Lock()
+	lines := a.lines
+	a.Unlock()
+
+	if err := writeMergedLog(f, lines); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeMergedLog writes the given lines sorted by their timestamps.
+// Lines with the same timestamp keep the order in which they were received.
+func writeMergedLog(w io.Writer, lines []*logLine) error {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+
+	var width int
+	for _, line := range lines {
+		if len(line.node) > width {
+			width = len(line.node)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(bw, "%s %-*s | %s\n", line.timestamp.UTC().Format("2006-01-02T15:04:05.000000000Z"), width, line.node, line.text); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
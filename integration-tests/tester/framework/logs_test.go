@@ -0,0 +1,78 @@
+package framework
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMergedLog(t *testing.T) {
+	aggregator := NewLogAggregator("")
+	aggregator.read("node1", strings.NewReader(strings.Join([]string{
+		"2020-09-01T12:00:00.000000001Z INFO Starting",
+		"2020-09-01T12:00:02.5Z INFO Synced",
+		"not a log line",
+	}, "\n")), time.Time{})
+	aggregator.read("coordinator", strings.NewReader(strings.Join([]string{
+		"2020-09-01T12:00:01Z INFO Issued milestone 1",
+		"2020-09-01T12:00:02.5Z INFO Issued milestone 2",
+	}, "\n")), time.Time{})
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMergedLog(&buf, aggregator.lines))
+
+	assert.Equal(t, strings.Join([]string{
+		"2020-09-01T12:00:00.000000001Z node1       | INFO Starting",
+		"2020-09-01T12:00:01.000000000Z coordinator | INFO Issued milestone 1",
+		"2020-09-01T12:00:02.500000000Z node1       | INFO Synced",
+		"2020-09-01T12:00:02.500000000Z coordinator | INFO Issued milestone 2",
+	}, "\n")+"\n", buf.String())
+}
+
+func TestLogAggregatorFollow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a fake docker CLI which prints the same log on every call, like "docker logs" of a restarted container without --since
+	script := filepath.Join(dir, "docker")
+	require.NoError(t, ioutil.WriteFile(script, []byte(`#!/bin/sh
+for last; do true; done
+echo "2020-09-01T12:00:00Z $last started"
+echo "2020-09-01T12:00:01Z $last failed" >&2
+`), 0755))
+
+	defer func(binary string) { dockerBinary = binary }(dockerBinary)
+	dockerBinary = script
+
+	path := filepath.Join(dir, "out", "test.log")
+	aggregator := NewLogAggregator(path)
+	aggregator.Follow(&Node{Name: "a", ContainerName: "net-a"})
+	aggregator.Follow(&Node{Name: "b", ContainerName: "net-b"})
+
+	// let the followers run more than once
+	time.Sleep(1500 * time.Millisecond)
+	require.NoError(t, aggregator.Close())
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	// the lines which were already seen are not added again when the log is followed again
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 4)
+	assert.ElementsMatch(t, []string{
+		"2020-09-01T12:00:00.000000000Z a | net-a started",
+		"2020-09-01T12:00:00.000000000Z b | net-b started",
+	}, lines[:2])
+	assert.ElementsMatch(t, []string{
+		"2020-09-01T12:00:01.000000000Z a | net-a failed",
+		"2020-09-01T12:00:01.000000000Z b | net-b failed",
+	}, lines[2:])
+}
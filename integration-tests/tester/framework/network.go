@@ -0,0 +1,187 @@
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+const (
+	// APIPort is the port of the HTTP API of the nodes.
+	APIPort = 14265
+	// DashboardPort is the port of the dashboard of the nodes.
+	DashboardPort = 8081
+	// MQTTPort is the port of the MQTT broker of the nodes.
+	MQTTPort = 1883
+	// GossipPort is the port of the gossip protocol of the nodes.
+	GossipPort = 15600
+
+	// DefaultImage is the image of the node which is used if no image is given.
+	DefaultImage = "hornet:dev"
+
+	// the path of the database inside the containers, it is kept in a volume so that it survives the removal of a container
+	containerDatabasePath = "/app/testdb"
+)
+
+// EndpointMode defines how the test process reaches the ports of the nodes.
+type EndpointMode int
+
+const (
+	// EndpointModeAuto uses the mode which works on the current host, see DefaultEndpointMode.
+	EndpointModeAuto EndpointMode = iota
+	// EndpointModeContainerIP reaches the nodes via the IP addresses of their containers.
+	// This only works on Linux hosts, where the container networks are routed to the host.
+	EndpointModeContainerIP
+	// EndpointModeHostPorts publishes the ports of the nodes on random ports of the host's loopback interface
+	// and reaches the nodes via these host-mapped ports, like it is needed with Docker Desktop on macOS and Windows.
+	EndpointModeHostPorts
+
+	// EndpointModeEnv overrides the default endpoint mode, it is either "container" or "host".
+	EndpointModeEnv = "INTEGRATION_ENDPOINT_MODE"
+)
+
+// the ports of the nodes which are published in EndpointModeHostPorts
+var publishedPorts = []int{APIPort, DashboardPort, MQTTPort, GossipPort}
+
+// DefaultEndpointMode returns the endpoint mode which works on the current host,
+// unless it is overridden by the INTEGRATION_ENDPOINT_MODE environment variable.
+func DefaultEndpointMode() EndpointMode {
+	switch os.Getenv(EndpointModeEnv) {
+	case "container":
+		return EndpointModeContainerIP
+	case "host":
+		return EndpointModeHostPorts
+	}
+
+	if runtime.GOOS == "linux" {
+		return EndpointModeContainerIP
+	}
+	return EndpointModeHostPorts
+}
+
+var (
+	// ErrNodeExists is returned if a node with the same name already exists in the network.
+	ErrNodeExists = errors.New("node already exists")
+)
+
+// NetworkOptions define how the containers of a network are run.
+type NetworkOptions struct {
+	// The image of the nodes.
+	Image string
+	// How the test process reaches the ports of the nodes.
+	EndpointMode EndpointMode
+	// If set, the logs of all nodes are merged into the file "<network name>.log" in this directory.
+	LogDir string
+}
+
+// Network is a docker network of HORNET nodes.
+type Network struct {
+	syncutils.Mutex
+
+	// The name of the docker network, it prefixes the names of the containers.
+	Name string
+
+	opts  NetworkOptions
+	nodes []*Node
+	logs  *LogAggregator
+}
+
+// NewNetwork creates a docker network with the given name.
+func NewNetwork(name string, opts NetworkOptions) (*Network, error) {
+	if opts.Image == "" {
+		opts.Image = DefaultImage
+	}
+	if opts.EndpointMode == EndpointModeAuto {
+		opts.EndpointMode = DefaultEndpointMode()
+	}
+
+	if _, err := docker("network", "create", name); err != nil {
+		return nil, err
+	}
+
+	network := &Network{Name: name, opts: opts}
+	if opts.LogDir != "" {
+		network.logs = NewLogAggregator(filepath.Join(opts.LogDir, name+".log"))
+	}
+	return network, nil
+}
+
+// EndpointMode returns how the test process reaches the ports of the nodes.
+func (n *Network) EndpointMode() EndpointMode {
+	return n.opts.EndpointMode
+}
+
+// Nodes returns the nodes of the network.
+func (n *Network) Nodes() []*Node {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]*Node(nil), n.nodes...)
+}
+
+// Node returns the node with the given name or nil if it doesn't exist.
+func (n *Network) Node(name string) *Node {
+	n.Lock()
+	defer n.Unlock()
+
+	for _, node := range n.nodes {
+		if node.Name == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// CreateNode creates and starts a node with the given config in the network.
+func (n *Network) CreateNode(cfg NodeConfig) (*Node, error) {
+	if n.Node(cfg.Name) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeExists, cfg.Name)
+	}
+
+	node := &Node{
+		Name:          cfg.Name,
+		ContainerName: n.Name + "-" + cfg.Name,
+		network:       n,
+		cfg:           cfg,
+	}
+
+	if err := node.createContainer(); err != nil {
+		return nil, err
+	}
+
+	n.Lock()
+	n.nodes = append(n.nodes, node)
+	n.Unlock()
+
+	if n.logs != nil {
+		n.logs.Follow(node)
+	}
+
+	return node, nil
+}
+
+// Shutdown removes all containers, their database volumes and the docker network,
+// and writes the merged log if it is enabled.
+func (n *Network) Shutdown() error {
+	var firstErr error
+	for _, node := range n.Nodes() {
+		if err := node.remove(true); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if n.logs != nil {
+		if err := n.logs.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if _, err := docker("network", "rm", n.Name); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
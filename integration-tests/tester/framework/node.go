@@ -0,0 +1,222 @@
+package framework
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+// DatabaseEngine is the engine which stores the databases of a node.
+type DatabaseEngine string
+
+const (
+	// DatabaseEngineBolt stores the databases in bbolt files.
+	DatabaseEngineBolt DatabaseEngine = "bolt"
+	// DatabaseEngineBadger stores the databases in BadgerDB directories.
+	DatabaseEngineBadger DatabaseEngine = "badger"
+)
+
+// DatabaseEngines are all engines a node supports, so that a scenario can be run on each of them.
+var DatabaseEngines = []DatabaseEngine{DatabaseEngineBolt, DatabaseEngineBadger}
+
+// DatabaseConfig defines the database settings of a node.
+type DatabaseConfig struct {
+	// The database engine, the default engine of the node is used if it is empty.
+	Engine DatabaseEngine
+	// The cache times in milliseconds by cache name (e.g. "transactions"), which override the ones of the profile.
+	CacheTimeMilliseconds map[string]int
+	// The maximum amount of objects by cache name (e.g. "transactions"),
+	// above which released objects are evicted without waiting for the cache time.
+	CacheSizes map[string]int
+}
+
+// args returns the command line arguments of the database settings.
+func (c DatabaseConfig) args() []string {
+	var args []string
+	if c.Engine != "" {
+		args = append(args, fmt.Sprintf("--%s=%s", config.CfgDatabaseEngine, c.Engine))
+	}
+	if len(c.CacheTimeMilliseconds) > 0 {
+		args = append(args, fmt.Sprintf("--%s=%s", config.CfgDatabaseCacheTimeMilliseconds, cacheSettingsArg(c.CacheTimeMilliseconds)))
+	}
+	if len(c.CacheSizes) > 0 {
+		args = append(args, fmt.Sprintf("--%s=%s", config.CfgDatabaseCacheSizes, cacheSettingsArg(c.CacheSizes)))
+	}
+	return args
+}
+
+// cacheSettingsArg formats the given settings by cache name as the value of a map flag, e.g. "approvers=100,transactions=200".
+func cacheSettingsArg(settings map[string]int) string {
+	values := make([]string, 0, len(settings))
+	for name, value := range settings {
+		values = append(values, fmt.Sprintf("%s=%d", name, value))
+	}
+	// sorted, so that the container is created the same way every time
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// NodeConfig defines the settings of a node.
+type NodeConfig struct {
+	// The name of the node, it is unique within its network.
+	Name string
+	// The database settings of the node, passed as command line arguments.
+	Database DatabaseConfig
+	// Additional settings of the node, by config key (e.g. "node.alias"), passed as HORNET_ environment variables.
+	Settings map[string]string
+	// Additional command line arguments.
+	Args []string
+}
+
+// Node is a HORNET node running in a docker container.
+type Node struct {
+	// The name of the node within its network.
+	Name string
+	// The name of the container of the node.
+	ContainerName string
+
+	network *Network
+	cfg     NodeConfig
+}
+
+// volumeName returns the name of the volume which holds the database of the node.
+func (n *Node) volumeName() string {
+	return n.ContainerName + "-db"
+}
+
+// runArgs returns the arguments of "docker run" which create the container of the node.
+func (n *Node) runArgs() []string {
+	args := []string{
+		"run", "--detach",
+		"--name", n.ContainerName,
+		"--hostname", n.Name,
+		"--network", n.network.Name,
+		"--volume", n.volumeName() + ":" + containerDatabasePath,
+	}
+
+	if n.network.opts.EndpointMode == EndpointModeHostPorts {
+		for _, port := range publishedPorts {
+			args = append(args, "--publish", fmt.Sprintf("127.0.0.1::%d", port))
+		}
+	}
+
+	settings := map[string]string{config.CfgDatabasePath: containerDatabasePath}
+	for key, value := range n.cfg.Settings {
+		settings[key] = value
+	}
+
+	// sorted, so that the container is created the same way every time
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--env", config.EnvName(key)+"="+settings[key])
+	}
+
+	args = append(args, n.network.opts.Image)
+	args = append(args, n.cfg.Database.args()...)
+	return append(args, n.cfg.Args...)
+}
+
+// createContainer creates and starts the container of the node.
+func (n *Node) createContainer() error {
+	_, err := docker(n.runArgs()...)
+	return err
+}
+
+// remove removes the container of the node and optionally the volume of its database.
+func (n *Node) remove(withDatabase bool) error {
+	if _, err := docker("rm", "--force", n.ContainerName); err != nil {
+		return err
+	}
+	if withDatabase {
+		if _, err := docker("volume", "rm", "--force", n.volumeName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start starts the stopped container of the node.
+func (n *Node) Start() error {
+	_, err := docker("start", n.ContainerName)
+	return err
+}
+
+// Stop stops the node with SIGTERM and kills it if it didn't shut down within the given timeout.
+func (n *Node) Stop(timeout time.Duration) error {
+	_, err := docker("stop", "--time", strconv.Itoa(int(timeout.Seconds())), n.ContainerName)
+	return err
+}
+
+// Endpoint returns the address on which the given port of the node is reachable from the test process.
+// In EndpointModeHostPorts, the host port changes with every restart of the container.
+func (n *Node) Endpoint(port int) (string, error) {
+	if n.network.opts.EndpointMode == EndpointModeHostPorts {
+		output, err := docker("port", n.ContainerName, fmt.Sprintf("%d/tcp", port))
+		if err != nil {
+			return "", err
+		}
+		return parseDockerPort(output)
+	}
+
+	ip, err := docker("inspect", "--format", fmt.Sprintf(`{{(index .NetworkSettings.Networks %q).IPAddress}}`, n.network.Name), n.ContainerName)
+	if err != nil {
+		return "", err
+	}
+	if net.ParseIP(ip) == nil {
+		return "", fmt.Errorf("container %s has no IP address in network %s", n.ContainerName, n.network.Name)
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(port)), nil
+}
+
+// parseDockerPort parses the output of "docker port" and returns the first published address.
+// Addresses bound to all interfaces are reached via the loopback interface.
+func parseDockerPort(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		host, port, err := net.SplitHostPort(strings.TrimSpace(line))
+		if err != nil {
+			continue
+		}
+
+		switch host {
+		case "0.0.0.0", "":
+			host = "127.0.0.1"
+		case "::":
+			host = "::1"
+		}
+		return net.JoinHostPort(host, port), nil
+	}
+	return "", fmt.Errorf("port is not published: %q", output)
+}
+
+// APIURL returns the URL of the HTTP API of the node.
+func (n *Node) APIURL() (string, error) {
+	endpoint, err := n.Endpoint(APIPort)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "http", Host: endpoint}).String(), nil
+}
+
+// DashboardURL returns the URL of the dashboard of the node.
+func (n *Node) DashboardURL() (string, error) {
+	endpoint, err := n.Endpoint(DashboardPort)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "http", Host: endpoint}).String(), nil
+}
+
+// MQTTAddress returns the address of the MQTT broker of the node.
+func (n *Node) MQTTAddress() (string, error) {
+	return n.Endpoint(MQTTPort)
+}
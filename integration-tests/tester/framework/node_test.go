@@ -0,0 +1,93 @@
+package framework
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerPort(t *testing.T) {
+	endpoint, err := parseDockerPort("127.0.0.1:49153")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:49153", endpoint)
+
+	endpoint, err = parseDockerPort("0.0.0.0:49154\n[::]:49154")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:49154", endpoint)
+
+	endpoint, err = parseDockerPort("[::]:49155")
+	require.NoError(t, err)
+	assert.Equal(t, "[::1]:49155", endpoint)
+
+	_, err = parseDockerPort("")
+	assert.Error(t, err)
+}
+
+func TestRunArgs(t *testing.T) {
+	network := &Network{Name: "test", opts: NetworkOptions{Image: "hornet:test", EndpointMode: EndpointModeContainerIP}}
+	node := &Node{Name: "node1", ContainerName: "test-node1", network: network, cfg: NodeConfig{
+		Name:     "node1",
+		Settings: map[string]string{"node.alias": "node1"},
+		Args:     []string{"--version"},
+	}}
+
+	assert.Equal(t, []string{
+		"run", "--detach",
+		"--name", "test-node1",
+		"--hostname", "node1",
+		"--network", "test",
+		"--volume", "test-node1-db:/app/testdb",
+		"--env", "HORNET_DB_PATH=/app/testdb",
+		"--env", "HORNET_NODE_ALIAS=node1",
+		"hornet:test",
+		"--version",
+	}, node.runArgs())
+
+	// the ports are only published if the nodes are reached via the host
+	network.opts.EndpointMode = EndpointModeHostPorts
+	assert.Subset(t, node.runArgs(), []string{
+		"--publish", "127.0.0.1::14265",
+		"--publish", "127.0.0.1::8081",
+		"--publish", "127.0.0.1::1883",
+		"--publish", "127.0.0.1::15600",
+	})
+}
+
+func TestRunArgsDatabase(t *testing.T) {
+	network := &Network{Name: "test", opts: NetworkOptions{Image: "hornet:test", EndpointMode: EndpointModeContainerIP}}
+	node := &Node{Name: "node1", ContainerName: "test-node1", network: network, cfg: NodeConfig{
+		Name: "node1",
+		Database: DatabaseConfig{
+			Engine:                DatabaseEngineBadger,
+			CacheTimeMilliseconds: map[string]int{"transactions": 60000, "approvers": 1000},
+			CacheSizes:            map[string]int{"transactions": 50000},
+		},
+		Args: []string{"--node.alias=node1"},
+	}}
+
+	args := node.runArgs()
+	assert.Equal(t, []string{
+		"hornet:test",
+		"--db.engine=badger",
+		"--db.cacheTimeMilliseconds=approvers=1000,transactions=60000",
+		"--db.cacheSizes=transactions=50000",
+		"--node.alias=node1",
+	}, args[len(args)-5:])
+
+	// the default engine of the node is used if none is given
+	node.cfg.Database = DatabaseConfig{}
+	args = node.runArgs()
+	assert.Equal(t, []string{"hornet:test", "--node.alias=node1"}, args[len(args)-2:])
+}
+
+func TestDefaultEndpointMode(t *testing.T) {
+	defer os.Unsetenv(EndpointModeEnv)
+
+	require.NoError(t, os.Setenv(EndpointModeEnv, "host"))
+	assert.Equal(t, EndpointModeHostPorts, DefaultEndpointMode())
+
+	require.NoError(t, os.Setenv(EndpointModeEnv, "container"))
+	assert.Equal(t, EndpointModeContainerIP, DefaultEndpointMode())
+}
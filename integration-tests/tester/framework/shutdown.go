@@ -0,0 +1,209 @@
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrStateMismatch is returned if the state of a node after a restart differs from the state before.
+	ErrStateMismatch = errors.New("state after restart differs")
+)
+
+// ShutdownMode is the way a node is shut down.
+type ShutdownMode int
+
+const (
+	// ShutdownGraceful stops the node with SIGTERM and gives it time to flush its database.
+	ShutdownGraceful ShutdownMode = iota
+	// ShutdownForced removes the container of the node immediately, only the volume of the database is kept.
+	ShutdownForced
+)
+
+// ShutdownModes are all shutdown modes.
+var ShutdownModes = []ShutdownMode{ShutdownGraceful, ShutdownForced}
+
+func (m ShutdownMode) String() string {
+	switch m {
+	case ShutdownGraceful:
+		return "graceful"
+	case ShutdownForced:
+		return "forced"
+	default:
+		return fmt.Sprintf("ShutdownMode(%d)", int(m))
+	}
+}
+
+// Restart shuts the node down in the given mode and starts it again with the same database.
+// The timeout is the time a graceful shutdown may take before the node is killed.
+func (n *Node) Restart(mode ShutdownMode, timeout time.Duration) error {
+	switch mode {
+	case ShutdownGraceful:
+		if err := n.Stop(timeout); err != nil {
+			return err
+		}
+		return n.Start()
+
+	case ShutdownForced:
+		if err := n.remove(false); err != nil {
+			return err
+		}
+		return n.createContainer()
+
+	default:
+		return fmt.Errorf("unknown shutdown mode %d", mode)
+	}
+}
+
+// NodeState is the state of a node which must be the same before and after a restart.
+type NodeState struct {
+	// The milestone index of the ledger state.
+	LedgerIndex uint32
+	// The balances of the ledger state by address.
+	Balances map[string]uint64
+}
+
+// ledgerStatePage is a page of the getLedgerState response.
+type ledgerStatePage struct {
+	Balances          map[string]uint64 `json:"balances"`
+	MilestoneIndex    uint32            `json:"milestoneIndex"`
+	ContinuationToken string            `json:"continuationToken"`
+}
+
+// State returns the state of the node at the given milestone index.
+func (n *Node) State(index uint32) (*NodeState, error) {
+	return fetchState(n.Command, index)
+}
+
+// fetchState fetches all pages of the ledger state at the given milestone index.
+func fetchState(command func(command interface{}, result interface{}) error, index uint32) (*NodeState, error) {
+	state := &NodeState{LedgerIndex: index, Balances: make(map[string]uint64)}
+
+	var token string
+	for {
+		page := &ledgerStatePage{}
+		if err := command(map[string]interface{}{"command": "getLedgerState", "targetIndex": index, "continuationToken": token}, page); err != nil {
+			return nil, err
+		}
+		if page.MilestoneIndex != index {
+			return nil, fmt.Errorf("requested the ledger state at %d, got %d", index, page.MilestoneIndex)
+		}
+
+		for address, balance := range page.Balances {
+			state.Balances[address] = balance
+		}
+
+		if page.ContinuationToken == "" {
+			return state, nil
+		}
+		token = page.ContinuationToken
+	}
+}
+
+// Diff returns the differences between the state and the given other state, sorted by address.
+func (s *NodeState) Diff(other *NodeState) []string {
+	var diffs []string
+	if s.LedgerIndex != other.LedgerIndex {
+		diffs = append(diffs, fmt.Sprintf("ledger index %d != %d", s.LedgerIndex, other.LedgerIndex))
+	}
+
+	for address, balance := range s.Balances {
+		if otherBalance, exists := other.Balances[address]; !exists {
+			diffs = append(diffs, fmt.Sprintf("%s: %d != missing", address, balance))
+		} else if balance != otherBalance {
+			diffs = append(diffs, fmt.Sprintf("%s: %d != %d", address, balance, otherBalance))
+		}
+	}
+	for address, balance := range other.Balances {
+		if _, exists := s.Balances[address]; !exists {
+			diffs = append(diffs, fmt.Sprintf("%s: missing != %d", address, balance))
+		}
+	}
+
+	sort.Strings(diffs)
+	return diffs
+}
+
+// ShutdownMatrixOptions define the timeouts of a shutdown matrix.
+type ShutdownMatrixOptions struct {
+	// The time a graceful shutdown may take before the node is killed.
+	StopTimeout time.Duration
+	// The time the node may take to become solid again after the restart.
+	RecoveryTimeout time.Duration
+}
+
+// ShutdownMatrixResult is the outcome of a restart in one shutdown mode.
+type ShutdownMatrixResult struct {
+	Mode ShutdownMode
+	// The state of the node after the restart.
+	State *NodeState
+	// The differences to the state before the shutdown.
+	Diffs []string
+}
+
+// RunShutdownMatrix runs the scenario on the network and then restarts the given node once in every shutdown mode.
+// The ledger state at the latest solid milestone after the scenario is compared with the state at the same index
+// after every restart. ErrStateMismatch is returned together with all results if any of the states differ.
+func RunShutdownMatrix(network *Network, nodeName string, scenario func(*Network) error, opts ShutdownMatrixOptions) ([]*ShutdownMatrixResult, error) {
+	node := network.Node(nodeName)
+	if node == nil {
+		return nil, fmt.Errorf("node %s doesn't exist", nodeName)
+	}
+
+	if err := scenario(network); err != nil {
+		return nil, fmt.Errorf("scenario failed: %w", err)
+	}
+
+	info, err := node.Info()
+	if err != nil {
+		return nil, err
+	}
+	index := info.LatestSolidSubtangleMilestoneIndex
+
+	before, err := node.State(index)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*ShutdownMatrixResult
+	var mismatches []string
+	for _, mode := range ShutdownModes {
+		if err := node.Restart(mode, opts.StopTimeout); err != nil {
+			return results, fmt.Errorf("%s restart failed: %w", mode, err)
+		}
+
+		if err := node.WaitForSolidMilestone(index, opts.RecoveryTimeout); err != nil {
+			return results, fmt.Errorf("node didn't recover after %s restart: %w", mode, err)
+		}
+
+		after, err := node.State(index)
+		if err != nil {
+			return results, err
+		}
+
+		result := &ShutdownMatrixResult{Mode: mode, State: after, Diffs: before.Diff(after)}
+		results = append(results, result)
+		if len(result.Diffs) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s", mode, strings.Join(result.Diffs, ", ")))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return results, fmt.Errorf("%w: %s", ErrStateMismatch, strings.Join(mismatches, "; "))
+	}
+	return results, nil
+}
+
+// WaitForSolidMilestone waits until the API of the node answers and its latest solid milestone is at least the given index.
+func (n *Node) WaitForSolidMilestone(index uint32, timeout time.Duration) error {
+	return waitFor(timeout, func() (bool, error) {
+		info, err := n.Info()
+		if err != nil {
+			return false, nil
+		}
+		return info.LatestSolidSubtangleMilestoneIndex >= index, nil
+	})
+}
@@ -0,0 +1,53 @@
+package framework
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchState(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"balances":{"A":10,"B":20},"milestoneIndex":7,"truncated":true,"continuationToken":"t1"}`,
+		"t1": `{"balances":{"C":0},"milestoneIndex":7}`,
+	}
+
+	var requests int
+	var targetIndex interface{}
+	command := func(command interface{}, result interface{}) error {
+		requests++
+		cmd := command.(map[string]interface{})
+		assert.Equal(t, "getLedgerState", cmd["command"])
+		targetIndex = cmd["targetIndex"]
+		return json.Unmarshal([]byte(pages[cmd["continuationToken"].(string)]), result)
+	}
+
+	state, err := fetchState(command, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, uint32(7), targetIndex)
+	assert.Equal(t, &NodeState{LedgerIndex: 7, Balances: map[string]uint64{"A": 10, "B": 20, "C": 0}}, state)
+
+	// the node answered with a different ledger state
+	_, err = fetchState(command, 8)
+	assert.Error(t, err)
+}
+
+func TestNodeStateDiff(t *testing.T) {
+	before := &NodeState{LedgerIndex: 7, Balances: map[string]uint64{"A": 10, "B": 20, "C": 5}}
+
+	assert.Empty(t, before.Diff(&NodeState{LedgerIndex: 7, Balances: map[string]uint64{"A": 10, "B": 20, "C": 5}}))
+	assert.Equal(t, []string{
+		"A: 10 != 15",
+		"C: 5 != missing",
+		"D: missing != 1",
+		"ledger index 7 != 8",
+	}, before.Diff(&NodeState{LedgerIndex: 8, Balances: map[string]uint64{"A": 15, "B": 20, "D": 1}}))
+}
+
+func TestShutdownModeString(t *testing.T) {
+	assert.Equal(t, "graceful", ShutdownGraceful.String())
+	assert.Equal(t, "forced", ShutdownForced.String())
+}
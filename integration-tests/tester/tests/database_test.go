@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/integration-tests/tester/framework"
+)
+
+// TestDatabaseEngines runs the shutdown matrix on a node with every database engine,
+// so that the engines are flushed and reopened the same way.
+func TestDatabaseEngines(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if !framework.ImageExists(framework.DefaultImage) {
+		t.Skipf("image %s is not available, build it with docker/Dockerfile.dev", framework.DefaultImage)
+	}
+
+	for _, engine := range framework.DatabaseEngines {
+		engine := engine
+		t.Run(string(engine), func(t *testing.T) {
+			network, err := framework.NewNetwork("hornet-test-db-"+string(engine), framework.NetworkOptions{})
+			require.NoError(t, err)
+			defer network.Shutdown()
+
+			node, err := network.CreateNode(framework.NodeConfig{
+				Name: "node1",
+				Database: framework.DatabaseConfig{
+					Engine: engine,
+					// small caches, so that the objects are evicted and read from the database again
+					CacheTimeMilliseconds: map[string]int{"transactions": 100, "bundles": 100},
+					CacheSizes:            map[string]int{"transactions": 1000, "bundles": 1000},
+				},
+			})
+			require.NoError(t, err)
+			require.NoError(t, node.WaitForAPI(time.Minute))
+
+			scenario := func(*framework.Network) error {
+				info, err := node.Info()
+				if err != nil {
+					return err
+				}
+				// let the node solidify some milestones, so that there is something to flush
+				return node.WaitForSolidMilestone(info.LatestSolidSubtangleMilestoneIndex+5, 10*time.Minute)
+			}
+
+			results, err := framework.RunShutdownMatrix(network, "node1", scenario, framework.ShutdownMatrixOptions{
+				StopTimeout:     time.Minute,
+				RecoveryTimeout: 5 * time.Minute,
+			})
+			require.NoError(t, err)
+			assert.Len(t, results, len(framework.ShutdownModes))
+		})
+	}
+}
@@ -7,19 +7,26 @@ import (
 	"github.com/gohornet/hornet/pkg/toolset"
 	"github.com/gohornet/hornet/plugins/autopeering"
 	"github.com/gohornet/hornet/plugins/cli"
+	"github.com/gohornet/hornet/plugins/continuousprofiling"
 	"github.com/gohornet/hornet/plugins/coordinator"
 	"github.com/gohornet/hornet/plugins/dashboard"
 	"github.com/gohornet/hornet/plugins/database"
+	"github.com/gohornet/hornet/plugins/externalplugins"
+	"github.com/gohornet/hornet/plugins/faucet"
 	"github.com/gohornet/hornet/plugins/gossip"
 	"github.com/gohornet/hornet/plugins/gracefulshutdown"
+	"github.com/gohornet/hornet/plugins/grpc"
+	"github.com/gohornet/hornet/plugins/health"
 	"github.com/gohornet/hornet/plugins/metrics"
 	"github.com/gohornet/hornet/plugins/mqtt"
+	"github.com/gohornet/hornet/plugins/notifications"
 	"github.com/gohornet/hornet/plugins/peering"
 	"github.com/gohornet/hornet/plugins/pow"
 	"github.com/gohornet/hornet/plugins/profiling"
 	"github.com/gohornet/hornet/plugins/prometheus"
 	"github.com/gohornet/hornet/plugins/snapshot"
 	"github.com/gohornet/hornet/plugins/spammer"
+	"github.com/gohornet/hornet/plugins/systemd"
 	"github.com/gohornet/hornet/plugins/tangle"
 	"github.com/gohornet/hornet/plugins/urts"
 	"github.com/gohornet/hornet/plugins/warpsync"
@@ -38,9 +45,11 @@ func main() {
 		cli.PLUGIN,
 		gracefulshutdown.PLUGIN,
 		profiling.PLUGIN,
+		continuousprofiling.PLUGIN,
 		database.PLUGIN,
 		autopeering.PLUGIN,
 		webapi.PLUGIN,
+		health.PLUGIN,
 	}
 
 	if !config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
@@ -56,11 +65,18 @@ func main() {
 			dashboard.PLUGIN,
 			zmq.PLUGIN,
 			mqtt.PLUGIN,
+			notifications.PLUGIN,
+			grpc.PLUGIN,
+			externalplugins.PLUGIN,
 			spammer.PLUGIN,
+			faucet.PLUGIN,
 			coordinator.PLUGIN,
 			prometheus.PLUGIN,
 		}...)
 	}
 
+	// the systemd plugin signals the readiness, so it has to run after all other plugins
+	plugins = append(plugins, systemd.PLUGIN)
+
 	node.Run(node.Plugins(plugins...))
 }
@@ -0,0 +1,194 @@
+package bandwidth
+
+import (
+	"math/rand"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+// Class defines how essential gossip traffic is for the node to stay in sync.
+type Class int
+
+const (
+	// ClassEssential is traffic which is never throttled, e.g. heartbeats, requests, own transactions
+	// and replies to requests of recent transactions, which the neighbors need to stay in sync.
+	ClassEssential Class = iota
+	// ClassReply is traffic replying to stale requests of neighbors, i.e. requests of transactions which are no longer broadcasted.
+	// It is throttled progressively once the throttle threshold of the budget is reached.
+	ClassReply
+	// ClassRelay is the relaying of received transactions to neighbors.
+	// It is throttled once the budget is used up.
+	ClassRelay
+)
+
+// Options defines the monthly bandwidth budget.
+type Options struct {
+	// The maximum amount of bytes received and sent per accounting period, the budget is disabled if zero.
+	MonthlyBudgetBytes uint64
+	// The day of the month on which a new accounting period starts.
+	ResetDay int
+	// The percentage of the budget from which on replies to stale requests are throttled.
+	ThrottleThresholdPercentage int
+}
+
+// Usage is a snapshot of the traffic of the current accounting period.
+type Usage struct {
+	// The start of the accounting period.
+	PeriodStart time.Time
+	// The start of the next accounting period.
+	PeriodEnd time.Time
+	// The amount of bytes received in the accounting period.
+	Received uint64
+	// The amount of bytes sent in the accounting period.
+	Sent uint64
+	// The budget of the accounting period, zero if the budget is disabled.
+	Budget uint64
+	// The amount of messages which were not sent because of the budget since the start of the node.
+	Throttled uint64
+}
+
+// Total returns the amount of bytes received and sent in the accounting period.
+func (u *Usage) Total() uint64 {
+	return u.Received + u.Sent
+}
+
+// Ratio returns the ratio of the used budget, 0 if the budget is disabled.
+func (u *Usage) Ratio() float64 {
+	if u.Budget == 0 {
+		return 0
+	}
+	return float64(u.Total()) / float64(u.Budget)
+}
+
+// Budget accounts the gossip traffic of the node against a monthly budget
+// and decides whether non-essential traffic is throttled.
+// The traffic is accounted without locking, the lock is only taken when a new accounting period starts.
+type Budget struct {
+	syncutils.Mutex
+
+	Opts Options
+
+	periodStart time.Time
+	// the end of the accounting period in unix nanoseconds, used to detect the rollover without locking.
+	periodEnd atomic.Int64
+	received  atomic.Uint64
+	sent      atomic.Uint64
+	throttled atomic.Uint64
+	random    *rand.Rand
+}
+
+// NewBudget creates a new Budget with the given options.
+func NewBudget(opts Options) *Budget {
+	b := &Budget{
+		Opts:        opts,
+		periodStart: PeriodStart(time.Now(), opts.ResetDay),
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	b.periodEnd.Store(b.periodStart.AddDate(0, 1, 0).UnixNano())
+	return b
+}
+
+// PeriodStart returns the start of the accounting period the given time belongs to.
+func PeriodStart(t time.Time, resetDay int) time.Time {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), resetDay, 0, 0, 0, 0, time.UTC)
+	if start.After(t) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start
+}
+
+// Restore restores the traffic of an accounting period, e.g. after a restart of the node.
+// The traffic is ignored if it belongs to a past accounting period.
+func (b *Budget) Restore(periodStart time.Time, received uint64, sent uint64) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.rolloverWithoutLocking()
+	if !periodStart.Equal(b.periodStart) {
+		return
+	}
+	b.received.Add(received)
+	b.sent.Add(sent)
+}
+
+// AddReceived accounts the given amount of received bytes.
+func (b *Budget) AddReceived(n int) {
+	b.rollover()
+	b.received.Add(uint64(n))
+}
+
+// AddSent accounts the given amount of sent bytes.
+func (b *Budget) AddSent(n int) {
+	b.rollover()
+	b.sent.Add(uint64(n))
+}
+
+// Usage returns the traffic of the current accounting period.
+func (b *Budget) Usage() *Usage {
+	b.Lock()
+	defer b.Unlock()
+
+	b.rolloverWithoutLocking()
+	return &Usage{
+		PeriodStart: b.periodStart,
+		PeriodEnd:   b.periodStart.AddDate(0, 1, 0),
+		Received:    b.received.Load(),
+		Sent:        b.sent.Load(),
+		Budget:      b.Opts.MonthlyBudgetBytes,
+		Throttled:   b.throttled.Load(),
+	}
+}
+
+// Allow tells whether traffic of the given class may be sent now.
+// Replies are dropped with a probability rising from 0 at the throttle threshold to 1 when the budget is used up.
+func (b *Budget) Allow(class Class) bool {
+	if class == ClassEssential || b.Opts.MonthlyBudgetBytes == 0 {
+		return true
+	}
+
+	b.rollover()
+	ratio := float64(b.received.Load()+b.sent.Load()) / float64(b.Opts.MonthlyBudgetBytes)
+
+	allowed := ratio < 1
+	if class == ClassReply && allowed {
+		threshold := float64(b.Opts.ThrottleThresholdPercentage) / 100
+		if ratio > threshold {
+			b.Lock()
+			allowed = b.random.Float64() >= (ratio-threshold)/(1-threshold)
+			b.Unlock()
+		}
+	}
+
+	if !allowed {
+		b.throttled.Inc()
+	}
+	return allowed
+}
+
+// starts a new accounting period if the current one is over.
+func (b *Budget) rollover() {
+	if time.Now().UnixNano() < b.periodEnd.Load() {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.rolloverWithoutLocking()
+}
+
+// the lock must be held.
+func (b *Budget) rolloverWithoutLocking() {
+	now := time.Now()
+	if now.UnixNano() < b.periodEnd.Load() {
+		return
+	}
+	b.periodStart = PeriodStart(now, b.Opts.ResetDay)
+	b.periodEnd.Store(b.periodStart.AddDate(0, 1, 0).UnixNano())
+	b.received.Store(0)
+	b.sent.Store(0)
+}
@@ -0,0 +1,99 @@
+package bandwidth_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/bandwidth"
+)
+
+func TestPeriodStart(t *testing.T) {
+	assert.Equal(t, time.Date(2020, 9, 15, 0, 0, 0, 0, time.UTC), bandwidth.PeriodStart(time.Date(2020, 9, 20, 12, 0, 0, 0, time.UTC), 15))
+	assert.Equal(t, time.Date(2020, 9, 15, 0, 0, 0, 0, time.UTC), bandwidth.PeriodStart(time.Date(2020, 10, 14, 23, 0, 0, 0, time.UTC), 15))
+	assert.Equal(t, time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), bandwidth.PeriodStart(time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), 1))
+	assert.Equal(t, time.Date(2019, 12, 28, 0, 0, 0, 0, time.UTC), bandwidth.PeriodStart(time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), 28))
+}
+
+func TestBudgetUsage(t *testing.T) {
+	budget := bandwidth.NewBudget(bandwidth.Options{MonthlyBudgetBytes: 1000, ResetDay: 1, ThrottleThresholdPercentage: 50})
+
+	budget.AddReceived(100)
+	budget.AddSent(50)
+
+	usage := budget.Usage()
+	assert.Equal(t, uint64(100), usage.Received)
+	assert.Equal(t, uint64(50), usage.Sent)
+	assert.Equal(t, 0.15, usage.Ratio())
+	assert.Equal(t, usage.PeriodStart.AddDate(0, 1, 0), usage.PeriodEnd)
+
+	// traffic of the current period is restored, traffic of past periods is ignored
+	budget.Restore(usage.PeriodStart, 10, 20)
+	budget.Restore(usage.PeriodStart.AddDate(0, -1, 0), 500, 500)
+
+	usage = budget.Usage()
+	assert.Equal(t, uint64(110), usage.Received)
+	assert.Equal(t, uint64(70), usage.Sent)
+}
+
+func TestBudgetAllow(t *testing.T) {
+	budget := bandwidth.NewBudget(bandwidth.Options{MonthlyBudgetBytes: 1000, ResetDay: 1, ThrottleThresholdPercentage: 50})
+
+	// below the threshold nothing is throttled
+	budget.AddReceived(400)
+	for i := 0; i < 100; i++ {
+		assert.True(t, budget.Allow(bandwidth.ClassReply))
+		assert.True(t, budget.Allow(bandwidth.ClassRelay))
+	}
+
+	// between the threshold and the budget, replies are throttled progressively
+	budget.AddReceived(350)
+	var allowed int
+	for i := 0; i < 1000; i++ {
+		if budget.Allow(bandwidth.ClassReply) {
+			allowed++
+		}
+		assert.True(t, budget.Allow(bandwidth.ClassRelay))
+	}
+	assert.InDelta(t, 500, allowed, 100)
+
+	// once the budget is used up, only essential traffic is allowed
+	budget.AddSent(250)
+	assert.False(t, budget.Allow(bandwidth.ClassReply))
+	assert.False(t, budget.Allow(bandwidth.ClassRelay))
+	assert.True(t, budget.Allow(bandwidth.ClassEssential))
+	assert.Equal(t, uint64(1000-allowed+2), budget.Usage().Throttled)
+}
+
+func TestBudgetDisabled(t *testing.T) {
+	budget := bandwidth.NewBudget(bandwidth.Options{ResetDay: 1, ThrottleThresholdPercentage: 50})
+
+	budget.AddSent(1 << 40)
+	assert.True(t, budget.Allow(bandwidth.ClassReply))
+	assert.True(t, budget.Allow(bandwidth.ClassRelay))
+	assert.Equal(t, float64(0), budget.Usage().Ratio())
+}
+
+func TestBudgetConcurrentAccounting(t *testing.T) {
+	budget := bandwidth.NewBudget(bandwidth.Options{MonthlyBudgetBytes: 1 << 30, ResetDay: 1, ThrottleThresholdPercentage: 50})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				budget.AddReceived(1)
+				budget.AddSent(2)
+				budget.Allow(bandwidth.ClassReply)
+			}
+		}()
+	}
+	wg.Wait()
+
+	usage := budget.Usage()
+	assert.Equal(t, uint64(10000), usage.Received)
+	assert.Equal(t, uint64(20000), usage.Sent)
+}
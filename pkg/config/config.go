@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	flag "github.com/spf13/pflag"
@@ -22,11 +24,14 @@ var (
 	defaultProfilesConfigName = "profiles"
 
 	// flags
-	configName         = flag.StringP("config", "c", defaultConfigName, "Filename of the config file without the file extension")
-	peeringConfigName  = flag.StringP("peeringConfig", "n", defaultPeeringConfigName, "Filename of the peering config file without the file extension")
-	profilesConfigName = flag.String("profilesConfig", defaultProfilesConfigName, "Filename of the profiles config file without the file extension")
+	configName         = flag.StringP("config", "c", defaultConfigName, "Filename of the config file, the file extension (.json, .yaml, .yml or .toml) is optional")
+	peeringConfigName  = flag.StringP("peeringConfig", "n", defaultPeeringConfigName, "Filename of the peering config file, the file extension (.json, .yaml, .yml or .toml) is optional")
+	profilesConfigName = flag.String("profilesConfig", defaultProfilesConfigName, "Filename of the profiles config file, the file extension (.json, .yaml, .yml or .toml) is optional")
 	configDirPath      = flag.StringP("config-dir", "d", ".", "Path to the directory containing the config file")
 
+	// the supported formats of the config files, in the order they are searched for if the file extension is omitted
+	configExtensions = []string{"json", "toml", "yaml", "yml"}
+
 	// Viper
 	NodeConfig     = viper.New()
 	PeeringConfig  = viper.New()
@@ -37,8 +42,10 @@ var (
 
 	// a list of flags which should be printed via --help
 	nonHiddenFlags = map[string]struct{}{
+		"check-config":        {},
 		"config":              {},
 		"config-dir":          {},
+		"network.profile":     {},
 		"node.disablePlugins": {},
 		"node.enablePlugins":  {},
 		"overwriteCooAddress": {},
@@ -60,7 +67,8 @@ func HideConfigFlags() {
 // FetchConfig fetches config values from a dir defined via CLI flag --config-dir (or the current working dir if not set).
 //
 // It automatically reads in a single config file starting with "config" (can be changed via the --config CLI flag)
-// and ending with: .json, .toml, .yaml or .yml (in this sequence).
+// and ending with: .json, .toml, .yaml or .yml (in this sequence). The format is detected by the file extension,
+// which can also be part of the given name to select a specific file.
 func FetchConfig() error {
 
 	// replace dots with underscores in env
@@ -74,17 +82,17 @@ func FetchConfig() error {
 	PeeringConfig.AutomaticEnv()
 	ProfilesConfig.AutomaticEnv()
 
-	err := parameter.LoadConfigFile(NodeConfig, *configDirPath, *configName, true, !hasFlag(defaultConfigName))
+	err := loadConfigFile(NodeConfig, *configName, true, !hasFlag(defaultConfigName))
 	if err != nil {
 		return err
 	}
 
-	err = parameter.LoadConfigFile(PeeringConfig, *configDirPath, *peeringConfigName, true, !hasFlag(defaultPeeringConfigName))
+	err = loadConfigFile(PeeringConfig, *peeringConfigName, true, !hasFlag(defaultPeeringConfigName))
 	if err != nil {
 		return err
 	}
 
-	err = parameter.LoadConfigFile(ProfilesConfig, *configDirPath, *profilesConfigName, false, !hasFlag(defaultProfilesConfigName))
+	err = loadConfigFile(ProfilesConfig, *profilesConfigName, false, !hasFlag(defaultProfilesConfigName))
 	if err != nil {
 		return err
 	}
@@ -92,6 +100,57 @@ func FetchConfig() error {
 	return nil
 }
 
+// loadConfigFile reads the config file with the given name in the config directory into the given viper instance.
+// If the name has no file extension, the first file with one of the supported extensions is used.
+func loadConfigFile(config *viper.Viper, name string, bindFlags bool, loadDefault bool) error {
+	if !HasConfigExtension(name) {
+		if found := findConfigFiles(*configDirPath, name); len(found) > 1 {
+			log.Printf("Found multiple config files %s, using %s. Add the file extension to the name to select another one.", strings.Join(found, ", "), found[0])
+		}
+		return parameter.LoadConfigFile(config, *configDirPath, name, bindFlags, loadDefault)
+	}
+
+	flag.Parse()
+	if bindFlags {
+		if err := config.BindPFlags(flag.CommandLine); err != nil {
+			return err
+		}
+	}
+
+	config.SetConfigFile(filepath.Join(*configDirPath, name))
+	if err := config.ReadInConfig(); err != nil {
+		if os.IsNotExist(err) && loadDefault {
+			log.Printf("No config file found via '%s'. Loading default settings.", filepath.Join(*configDirPath, name))
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// HasConfigExtension returns whether the given file name ends with the extension of a supported config format.
+func HasConfigExtension(name string) bool {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	for _, configExt := range configExtensions {
+		if ext == configExt {
+			return true
+		}
+	}
+	return false
+}
+
+// findConfigFiles returns the config files with the given name and one of the supported extensions in the given directory.
+func findConfigFiles(directory string, name string) []string {
+	var found []string
+	for _, ext := range configExtensions {
+		if _, err := os.Stat(filepath.Join(directory, name+"."+ext)); err == nil {
+			found = append(found, "'"+name+"."+ext+"'")
+		}
+	}
+	return found
+}
+
 func PrintConfig(ignoreSettingsAtPrint ...[]string) {
 	parameter.PrintConfig(NodeConfig, ignoreSettingsAtPrint...)
 	parameter.PrintConfig(PeeringConfig)
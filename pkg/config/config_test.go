@@ -0,0 +1,50 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFileFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"config.json": `{"coordinator": {"mwm": 1}}`,
+		"config.yaml": "coordinator:\n  # comments are allowed\n  mwm: 2\n",
+		"config.toml": "[coordinator]\n# comments are allowed\nmwm = 3\n",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	defer func(configDir string) {
+		*configDirPath = configDir
+	}(*configDirPath)
+	*configDirPath = dir
+
+	for name, mwm := range map[string]int{"config": 1, "config.json": 1, "config.yaml": 2, "config.toml": 3} {
+		settings := viper.New()
+		require.NoError(t, loadConfigFile(settings, name, false, false))
+		assert.Equal(t, mwm, settings.GetInt(CfgCoordinatorMWM), name)
+	}
+
+	// a missing file with extension is only accepted if the default settings should be loaded
+	assert.NoError(t, loadConfigFile(viper.New(), "missing.yml", false, true))
+	assert.Error(t, loadConfigFile(viper.New(), "missing.yml", false, false))
+}
+
+func TestHasConfigExtension(t *testing.T) {
+	assert.True(t, HasConfigExtension("config.json"))
+	assert.True(t, HasConfigExtension("config.YML"))
+	assert.True(t, HasConfigExtension("config.toml"))
+	assert.False(t, HasConfigExtension("config"))
+	assert.False(t, HasConfigExtension("config.mainnet"))
+}
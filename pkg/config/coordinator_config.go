@@ -24,7 +24,7 @@ const (
 	CfgCoordinatorMilestoneMerkleTreeHashFunc = "coordinator.milestoneMerkleTreeHashFunc"
 	// the maximum amount of known bundle tails for milestone tipselection
 	// if this limit is exceeded, a new checkpoint is issued
-	CfgCoordinatorCheckpointsMaxTrackedTails = "coordinator.checkpoints.maxTrackedTransactions"
+	CfgCoordinatorCheckpointsMaxTrackedTails = "coordinator.checkpoints.maxTrackedTails"
 	// the minimum threshold of unconfirmed transactions in the heaviest branch for milestone tipselection
 	// if the value falls below that threshold, no more heaviest branch tips are picked
 	CfgCoordinatorTipselectMinHeaviestBranchUnconfirmedTransactionsThreshold = "coordinator.tipsel.minHeaviestBranchUnconfirmedTransactionsThreshold"
@@ -36,6 +36,20 @@ const (
 	CfgCoordinatorTipselectRandomTipsPerCheckpoint = "coordinator.tipsel.randomTipsPerCheckpoint"
 	// the maximum duration to select the heaviest branch tips in milliseconds
 	CfgCoordinatorTipselectHeaviestBranchSelectionDeadlineMilliseconds = "coordinator.tipsel.heaviestBranchSelectionDeadlineMilliseconds"
+	// the signer of the milestones. 'local' signs with the seed in COO_SEED, 'remote' requests the signatures from a remote signer
+	CfgCoordinatorSignerType = "coordinator.signer.type"
+	// the address of the remote signer
+	CfgCoordinatorSignerRemoteAddress = "coordinator.signer.remoteAddress"
+	// the path to the CA certificate to verify the TLS connection to the remote signer (no TLS if empty)
+	CfgCoordinatorSignerTLSCertificatePath = "coordinator.signer.tlsCertificatePath"
+	// the API URLs of the nodes which have to agree on the ledger mutations before a milestone is issued (no quorum if empty)
+	CfgCoordinatorQuorumNodes = "coordinator.quorum.nodes"
+	// the JWT used to authenticate at the API of the quorum nodes
+	CfgCoordinatorQuorumJWT = "coordinator.quorum.jwt"
+	// the minimum amount of quorum nodes that have to agree (0 = all nodes)
+	CfgCoordinatorQuorumMinAgreements = "coordinator.quorum.minAgreements"
+	// the timeout for the answers of the quorum nodes in milliseconds
+	CfgCoordinatorQuorumTimeoutMilliseconds = "coordinator.quorum.timeoutMilliseconds"
 )
 
 func init() {
@@ -53,4 +67,11 @@ func init() {
 	flag.Int(CfgCoordinatorTipselectMaxHeaviestBranchTipsPerCheckpoint, 10, "maximum amount of checkpoint transactions with heaviest branch tips")
 	flag.Int(CfgCoordinatorTipselectRandomTipsPerCheckpoint, 3, "amount of checkpoint transactions with random tips")
 	flag.Int(CfgCoordinatorTipselectHeaviestBranchSelectionDeadlineMilliseconds, 100, "the maximum duration to select the heaviest branch tips in milliseconds")
+	flag.String(CfgCoordinatorSignerType, "local", "the signer of the milestones. 'local' signs with the seed in COO_SEED, 'remote' requests the signatures from a remote signer")
+	flag.String(CfgCoordinatorSignerRemoteAddress, "localhost:9091", "the address of the remote signer")
+	flag.String(CfgCoordinatorSignerTLSCertificatePath, "", "the path to the CA certificate to verify the TLS connection to the remote signer (no TLS if empty)")
+	flag.StringSlice(CfgCoordinatorQuorumNodes, []string{}, "the API URLs of the nodes which have to agree on the ledger mutations before a milestone is issued (no quorum if empty)")
+	flag.String(CfgCoordinatorQuorumJWT, "", "the JWT used to authenticate at the API of the quorum nodes")
+	flag.Int(CfgCoordinatorQuorumMinAgreements, 0, "the minimum amount of quorum nodes that have to agree (0 = all nodes)")
+	flag.Int(CfgCoordinatorQuorumTimeoutMilliseconds, 2000, "the timeout for the answers of the quorum nodes in milliseconds")
 }
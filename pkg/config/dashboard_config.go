@@ -7,10 +7,22 @@ import (
 const (
 	// the bind address on which the dashboard can be access from
 	CfgDashboardBindAddress = "dashboard.bindAddress"
+	// the networks (CIDR) or addresses which are allowed to connect to the dashboard (empty = all)
+	CfgDashboardIPFilterAllowedNetworks = "dashboard.ipFilter.allowedNetworks"
+	// the networks (CIDR) or addresses which are not allowed to connect to the dashboard
+	CfgDashboardIPFilterDeniedNetworks = "dashboard.ipFilter.deniedNetworks"
 	// whether to run the dashboard in dev mode
 	CfgDashboardDevMode = "dashboard.dev"
 	// the theme for the dashboard to use (default or dark)
 	CfgDashboardTheme = "dashboard.theme"
+	// whether the dashboard is served via TLS
+	CfgDashboardTLSEnabled = "dashboard.tls.enabled"
+	// the path to the TLS certificate of the dashboard
+	CfgDashboardTLSCertPath = "dashboard.tls.certPath"
+	// the path to the TLS private key of the dashboard
+	CfgDashboardTLSKeyPath = "dashboard.tls.keyPath"
+	// the amount of recent vertices that are sent to the visualizer when it is opened
+	CfgDashboardVisualizerHistorySize = "dashboard.visualizer.historySize"
 	// whether to use HTTP basic auth
 	CfgDashboardBasicAuthEnabled = "dashboard.basicAuth.enabled"
 	// the HTTP basic auth username
@@ -19,14 +31,38 @@ const (
 	CfgDashboardBasicAuthPasswordHash = "dashboard.basicauth.passwordhash" // config key must be lower cased (for hiding passwords in PrintConfig)
 	// the HTTP basic auth salt used for hashing the password
 	CfgDashboardBasicAuthPasswordSalt = "dashboard.basicauth.passwordsalt" // config key must be lower cased (for hiding passwords in PrintConfig)
+	// whether the write actions of the dashboard require a login
+	CfgDashboardAuthEnabled = "dashboard.auth.enabled"
+	// the username of the dashboard login
+	CfgDashboardAuthUsername = "dashboard.auth.username"
+	// the password+salt of the dashboard login as a sha256 hash
+	CfgDashboardAuthPasswordHash = "dashboard.auth.passwordhash" // config key must be lower cased (for hiding passwords in PrintConfig)
+	// the salt used for hashing the password of the dashboard login
+	CfgDashboardAuthPasswordSalt = "dashboard.auth.passwordsalt" // config key must be lower cased (for hiding passwords in PrintConfig)
+	// the time after which an unused login session of the dashboard expires
+	CfgDashboardAuthSessionTimeoutMinutes = "dashboard.auth.sessionTimeoutMinutes"
+	// whether the read-only panels of the dashboard can be viewed without a login
+	CfgDashboardAuthPublicReadAccess = "dashboard.auth.publicReadAccess"
 )
 
 func init() {
 	flag.String(CfgDashboardBindAddress, "localhost:8081", "the bind address on which the dashboard can be access from")
 	flag.Bool(CfgDashboardDevMode, false, "whether to run the dashboard in dev mode")
+	flag.Bool(CfgDashboardTLSEnabled, false, "whether the dashboard is served via TLS")
+	flag.String(CfgDashboardTLSCertPath, "tls/cert.pem", "the path to the TLS certificate of the dashboard")
+	flag.String(CfgDashboardTLSKeyPath, "tls/key.pem", "the path to the TLS private key of the dashboard")
+	flag.Int(CfgDashboardVisualizerHistorySize, 1000, "the amount of recent vertices that are sent to the visualizer when it is opened")
 	flag.Bool(CfgDashboardBasicAuthEnabled, false, "whether to use HTTP basic auth")
 	flag.String(CfgDashboardBasicAuthUsername, "", "the HTTP basic auth username")
 	flag.String(CfgDashboardBasicAuthPasswordHash, "", "the HTTP basic auth username")
 	flag.String(CfgDashboardBasicAuthPasswordSalt, "", "the HTTP basic auth password+salt as a sha256 hash")
+	flag.Bool(CfgDashboardAuthEnabled, false, "whether the write actions of the dashboard require a login")
+	flag.String(CfgDashboardAuthUsername, "", "the username of the dashboard login")
+	flag.String(CfgDashboardAuthPasswordHash, "", "the password+salt of the dashboard login as a sha256 hash")
+	flag.String(CfgDashboardAuthPasswordSalt, "", "the salt used for hashing the password of the dashboard login")
+	flag.Int(CfgDashboardAuthSessionTimeoutMinutes, 1440, "the time after which an unused login session of the dashboard expires")
+	flag.Bool(CfgDashboardAuthPublicReadAccess, true, "whether the read-only panels of the dashboard can be viewed without a login")
 	flag.String(CfgDashboardTheme, "default", "the theme for the dashboard to use (default or dark)")
+	flag.StringSlice(CfgDashboardIPFilterAllowedNetworks, []string{}, "the networks (CIDR) or addresses which are allowed to connect to the dashboard (empty = all)")
+	flag.StringSlice(CfgDashboardIPFilterDeniedNetworks, []string{}, "the networks (CIDR) or addresses which are not allowed to connect to the dashboard")
 }
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables which set the settings of the node config.
+	EnvPrefix = "HORNET_"
+)
+
+var (
+	// the flags which only control how the config is loaded and are no settings of the node config
+	commandLineOnlyFlags = map[string]struct{}{
+		"config":         {},
+		"config-dir":     {},
+		"peeringConfig":  {},
+		"profilesConfig": {},
+		"check-config":   {},
+		"version":        {},
+		"help":           {},
+	}
+
+	// the variables which Kubernetes and Docker links set for a service or container whose name starts with "hornet",
+	// e.g. HORNET_SERVICE_HOST, HORNET_PORT or HORNET_PORT_14265_TCP_ADDR
+	serviceLinkVariable = regexp.MustCompile(`^[A-Z0-9_]+_(SERVICE_HOST|SERVICE_PORT(_[A-Z0-9_]+)?|PORT(_[0-9]+_(TCP|UDP|SCTP)(_(PROTO|PORT|ADDR))?)?)$`)
+)
+
+// EnvName returns the name of the environment variable which sets the given setting,
+// e.g. HORNET_HTTPAPI_BINDADDRESS for "httpAPI.bindAddress".
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// envSettings returns the settings which are set by the given environment variables, mapped to the names of the variables,
+// and the names of the variables with the prefix which don't match any setting.
+// The variables of Kubernetes services and Docker links are no settings and therefore never returned as unknown.
+func envSettings(environ []string) (settings map[string]string, unknown []string) {
+	names := make(map[string]string)
+	flag.VisitAll(func(f *flag.Flag) {
+		if _, commandLineOnly := commandLineOnlyFlags[f.Name]; !commandLineOnly {
+			names[EnvName(f.Name)] = f.Name
+		}
+	})
+	for _, key := range additionalSettings {
+		names[EnvName(key)] = key
+	}
+
+	settings = make(map[string]string)
+	for _, variable := range environ {
+		name := strings.SplitN(variable, "=", 2)[0]
+		if !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+
+		key, exists := names[name]
+		if !exists {
+			if !serviceLinkVariable.MatchString(name) {
+				unknown = append(unknown, name)
+			}
+			continue
+		}
+		settings[key] = name
+	}
+
+	return settings, unknown
+}
+
+// LoadEnvironment applies the settings of the environment variables with the HORNET_ prefix (see EnvName).
+//
+// The settings are applied with the following precedence, from highest to lowest:
+// CLI flags, environment variables, config file, network profile, defaults.
+// Lists are separated by commas, maps are given as "key=value" pairs separated by commas,
+// and JSON arrays and objects are accepted for lists, maps and settings with nested values.
+// The environment variables without prefix (e.g. HTTPAPI_BINDADDRESS) are still supported with the same precedence,
+// but they are ignored in nested settings like the network profiles.
+func LoadEnvironment() error {
+	return loadEnvironment(NodeConfig)
+}
+
+// loadEnvironment applies the settings of the environment variables with the HORNET_ prefix to the given config.
+func loadEnvironment(config *viper.Viper) error {
+	settings, _ := envSettings(os.Environ())
+
+	// the settings which were set explicitly
+	changedFlags := make(map[string]struct{})
+	flag.Visit(func(f *flag.Flag) {
+		changedFlags[f.Name] = struct{}{}
+	})
+
+	for key, name := range settings {
+		if _, changed := changedFlags[key]; changed {
+			continue
+		}
+
+		value, err := parseEnvValue(key, os.Getenv(name))
+		if err != nil {
+			return err
+		}
+		config.Set(key, value)
+	}
+
+	return nil
+}
+
+// parseEnvValue converts the value of an environment variable to the type of the given setting.
+func parseEnvValue(key string, value string) (interface{}, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+			return nil, &ValidationError{Env: EnvName(key), Key: key, Message: "contains invalid JSON: " + err.Error()}
+		}
+		return parsed, nil
+	}
+
+	f := flag.Lookup(key)
+	if f == nil {
+		return value, nil
+	}
+
+	switch f.Value.Type() {
+	case "stringSlice":
+		return splitEnvList(value), nil
+
+	case "stringToString":
+		values := make(map[string]interface{})
+		for _, pair := range splitEnvList(value) {
+			keyValue := strings.SplitN(pair, "=", 2)
+			if len(keyValue) != 2 {
+				return nil, &ValidationError{Env: EnvName(key), Key: key, Message: "contains an invalid pair '" + pair + "', expected key=value"}
+			}
+			values[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+		return values, nil
+
+	default:
+		return value, nil
+	}
+}
+
+func splitEnvList(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "HORNET_HTTPAPI_BINDADDRESS", EnvName(CfgWebAPIBindAddress))
+	assert.Equal(t, "HORNET_SNAPSHOTS_LOCAL_DELTAPATH", EnvName(CfgLocalSnapshotsDeltaPath))
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	env := map[string]string{
+		EnvName(CfgWebAPIBindAddress):                          "127.0.0.1:14266",
+		EnvName(CfgCoordinatorMWM):                             "5",
+		EnvName(CfgWebAPIPermitRemoteAccess):                   "getNodeInfo, getTips",
+		EnvName(CfgDatabaseCacheTimeMilliseconds):              "transactions=1000,bundles=2000",
+		EnvName(CfgNotificationsWebhooks):                      `[{"url": "http://localhost"}]`,
+		EnvName(CfgNetAutopeeringEntryNodes):                   `["a", "b"]`,
+		EnvName(CfgLocalSnapshotsDeltaSizeThresholdPercentage): "12.5",
+	}
+	for name, value := range env {
+		require.NoError(t, os.Setenv(name, value))
+	}
+	defer func() {
+		for name := range env {
+			os.Unsetenv(name)
+		}
+	}()
+
+	// the settings which are set are not reset by reading the config again
+	defer func(nodeConfig *viper.Viper) {
+		NodeConfig = nodeConfig
+	}(NodeConfig)
+	NodeConfig = viper.New()
+
+	loadTestNodeConfig(t, "")
+	require.NoError(t, LoadEnvironment())
+
+	assert.Equal(t, "127.0.0.1:14266", NodeConfig.GetString(CfgWebAPIBindAddress))
+	assert.Equal(t, 5, NodeConfig.GetInt(CfgCoordinatorMWM))
+	assert.Equal(t, []string{"getNodeInfo", "getTips"}, NodeConfig.GetStringSlice(CfgWebAPIPermitRemoteAccess))
+	assert.Equal(t, map[string]string{"transactions": "1000", "bundles": "2000"}, NodeConfig.GetStringMapString(CfgDatabaseCacheTimeMilliseconds))
+	assert.Equal(t, []string{"a", "b"}, NodeConfig.GetStringSlice(CfgNetAutopeeringEntryNodes))
+	assert.Equal(t, 12.5, NodeConfig.GetFloat64(CfgLocalSnapshotsDeltaSizeThresholdPercentage))
+
+	var webhooks []WebhookConfig
+	require.NoError(t, NodeConfig.UnmarshalKey(CfgNotificationsWebhooks, &webhooks))
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, "http://localhost", webhooks[0].URL)
+
+	// the environment variables take precedence over the network profile
+	NodeConfig.Set(CfgNetProfile, "private-tangle")
+	require.NoError(t, LoadNetworkProfile())
+	assert.Equal(t, 5, NodeConfig.GetInt(CfgCoordinatorMWM))
+	// and so does the config file
+	assert.Equal(t, "CONFIGURED", NodeConfig.GetString(CfgCoordinatorAddress))
+}
+
+func TestLoadEnvironmentInvalidJSON(t *testing.T) {
+	require.NoError(t, os.Setenv(EnvName(CfgNetAutopeeringEntryNodes), `["a", `))
+	defer os.Unsetenv(EnvName(CfgNetAutopeeringEntryNodes))
+
+	err := LoadEnvironment()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HORNET_NETWORK_AUTOPEERING_ENTRYNODES")
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the paths to the executables of the out-of-process plugins
+	CfgExternalPluginsExecutables = "externalPlugins.executables"
+	// the time to wait for the handshake of a plugin after it was started
+	CfgExternalPluginsStartupTimeoutSeconds = "externalPlugins.startupTimeoutSeconds"
+	// the timeout for the API requests which are passed to the plugins
+	CfgExternalPluginsRequestTimeoutMilliseconds = "externalPlugins.requestTimeoutMilliseconds"
+	// the amount of events which are queued for each plugin before new events are dropped
+	CfgExternalPluginsEventQueueSize = "externalPlugins.eventQueueSize"
+)
+
+func init() {
+	flag.StringSlice(CfgExternalPluginsExecutables, []string{}, "the paths to the executables of the out-of-process plugins")
+	flag.Int(CfgExternalPluginsStartupTimeoutSeconds, 10, "the time to wait for the handshake of a plugin after it was started")
+	flag.Int(CfgExternalPluginsRequestTimeoutMilliseconds, 5000, "the timeout for the API requests which are passed to the plugins")
+	flag.Int(CfgExternalPluginsEventQueueSize, 1000, "the amount of events which are queued for each plugin before new events are dropped")
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the amount of tokens which is sent to an address per request
+	CfgFaucetAmount = "faucet.amount"
+	// the security level of the addresses of the faucet seed
+	CfgFaucetSecurityLevel = "faucet.securityLevel"
+	// the time an address has to wait until it can request tokens again
+	CfgFaucetAddressCooldownMinutes = "faucet.addressCooldownMinutes"
+	// the maximum amount of requests which are waiting to be paid out
+	CfgFaucetMaxPendingRequests = "faucet.maxPendingRequests"
+	// the maximum amount of addresses which are paid out in a single bundle
+	CfgFaucetMaxOutputsPerBundle = "faucet.maxOutputsPerBundle"
+	// the amount of milestones after which an unconfirmed faucet bundle is reattached
+	CfgFaucetReattachAfterMilestones = "faucet.reattachAfterMilestones"
+	// the tag of the faucet transactions
+	CfgFaucetTag = "faucet.tag"
+	// the bind address on which the faucet website and API listen on
+	CfgFaucetBindAddress = "faucet.bindAddress"
+	// the path to the state file of the faucet, which holds the pending bundle
+	CfgFaucetStateFilePath = "faucet.stateFilePath"
+)
+
+func init() {
+	flag.Int64(CfgFaucetAmount, 1000000, "the amount of tokens which is sent to an address per request")
+	flag.Int(CfgFaucetSecurityLevel, 2, "the security level of the addresses of the faucet seed")
+	flag.Int(CfgFaucetAddressCooldownMinutes, 60, "the time an address has to wait until it can request tokens again")
+	flag.Int(CfgFaucetMaxPendingRequests, 1000, "the maximum amount of requests which are waiting to be paid out")
+	flag.Int(CfgFaucetMaxOutputsPerBundle, 10, "the maximum amount of addresses which are paid out in a single bundle")
+	flag.Int(CfgFaucetReattachAfterMilestones, 5, "the amount of milestones after which an unconfirmed faucet bundle is reattached")
+	flag.String(CfgFaucetTag, "HORNET99FAUCET", "the tag of the faucet transactions")
+	flag.String(CfgFaucetBindAddress, "localhost:8091", "the bind address on which the faucet website and API listen on")
+	flag.String(CfgFaucetStateFilePath, "faucet.state", "the path to the state file of the faucet, which holds the pending bundle")
+}
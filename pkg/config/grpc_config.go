@@ -0,0 +1,17 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the bind address on which the gRPC API listens on
+	CfgGRPCBindAddress = "grpc.bindAddress"
+	// the size of the send buffer of each confirmed transactions stream
+	CfgGRPCStreamBufferSize = "grpc.streamBufferSize"
+)
+
+func init() {
+	flag.String(CfgGRPCBindAddress, "localhost:14266", "the bind address on which the gRPC API listens on")
+	flag.Int(CfgGRPCStreamBufferSize, 1000, "the size of the send buffer of each confirmed transactions stream")
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the bind address on which the health and readiness endpoints listen on
+	CfgHealthBindAddress = "health.bindAddress"
+	// the minimum number of connected neighbors for the node to be ready
+	CfgHealthMinConnectedNeighbors = "health.minConnectedNeighbors"
+)
+
+func init() {
+	flag.String(CfgHealthBindAddress, "localhost:14267", "the bind address on which the health and readiness endpoints listen on")
+	flag.Int(CfgHealthMinConnectedNeighbors, 1, "the minimum number of connected neighbors for the node to be ready")
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the size in megabytes after which the log files in the output paths are rotated (0 = disable)
+	CfgLoggerRotationMaxSizeMB = "logger.rotation.maxSizeMB"
+	// the interval in hours after which the log files in the output paths are rotated (0 = disable)
+	CfgLoggerRotationIntervalHours = "logger.rotation.intervalHours"
+	// the amount of rotated log files which are kept (0 = keep all)
+	CfgLoggerRotationMaxBackups = "logger.rotation.maxBackups"
+)
+
+func init() {
+	flag.Int(CfgLoggerRotationMaxSizeMB, 0, "the size in megabytes after which the log files in the output paths are rotated (0 = disable)")
+	flag.Int(CfgLoggerRotationIntervalHours, 0, "the interval in hours after which the log files in the output paths are rotated (0 = disable)")
+	flag.Int(CfgLoggerRotationMaxBackups, 10, "the amount of rotated log files which are kept (0 = keep all)")
+}
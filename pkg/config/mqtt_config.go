@@ -7,8 +7,62 @@ import (
 const (
 	// path to the MQTT broker config file
 	CfgMQTTConfig = "mqtt.config"
+	// whether the MQTT broker listens via TLS (overrides the TLS settings of the MQTT broker config file)
+	CfgMQTTTLSEnabled = "mqtt.tls.enabled"
+	// the bind address on which the MQTT broker listens on via TLS
+	CfgMQTTTLSBindAddress = "mqtt.tls.bindAddress"
+	// the path to the TLS certificate of the MQTT broker
+	CfgMQTTTLSCertPath = "mqtt.tls.certPath"
+	// the path to the TLS private key of the MQTT broker
+	CfgMQTTTLSKeyPath = "mqtt.tls.keyPath"
+	// the path to the CA certificate used to verify client certificates (empty = no client certificates required)
+	CfgMQTTTLSCAPath = "mqtt.tls.caPath"
+	// the networks (CIDR) or addresses which are allowed to connect to the MQTT broker (empty = all)
+	CfgMQTTIPFilterAllowedNetworks = "mqtt.ipFilter.allowedNetworks"
+	// the networks (CIDR) or addresses which are not allowed to connect to the MQTT broker
+	CfgMQTTIPFilterDeniedNetworks = "mqtt.ipFilter.deniedNetworks"
+	// whether clients must authenticate with username and password
+	CfgMQTTAuthEnabled = "mqtt.auth.enabled"
+	// the username of the MQTT clients
+	CfgMQTTAuthUsername = "mqtt.auth.username"
+	// the MQTT client password+salt as a sha256 hash
+	CfgMQTTAuthPasswordHash = "mqtt.auth.passwordhash" // must be lower cased
+	// the MQTT client salt used for hashing the password
+	CfgMQTTAuthPasswordSalt = "mqtt.auth.passwordsalt" // must be lower cased
+	// whether all published messages are forwarded to an external MQTT broker
+	CfgMQTTBridgeEnabled = "mqtt.bridge.enabled"
+	// the URL of the external MQTT broker (e.g. tcp://example.com:1883 or ssl://example.com:8883)
+	CfgMQTTBridgeBrokerURL = "mqtt.bridge.brokerURL"
+	// the client ID used to connect to the external MQTT broker
+	CfgMQTTBridgeClientID = "mqtt.bridge.clientID"
+	// the username used to connect to the external MQTT broker
+	CfgMQTTBridgeUsername = "mqtt.bridge.username"
+	// the password used to connect to the external MQTT broker
+	CfgMQTTBridgePassword = "mqtt.bridge.password" // must be lower cased
+	// the prefix which is added to the topics of the forwarded messages
+	CfgMQTTBridgeTopicPrefix = "mqtt.bridge.topicPrefix"
+	// the QoS level of the forwarded messages
+	CfgMQTTBridgeQoS = "mqtt.bridge.qos"
 )
 
 func init() {
 	flag.String(CfgMQTTConfig, "mqtt_config.json", "path to the MQTT broker config file")
+	flag.Bool(CfgMQTTTLSEnabled, false, "whether the MQTT broker listens via TLS (overrides the TLS settings of the MQTT broker config file)")
+	flag.String(CfgMQTTTLSBindAddress, "0.0.0.0:8883", "the bind address on which the MQTT broker listens on via TLS")
+	flag.String(CfgMQTTTLSCertPath, "tls/cert.pem", "the path to the TLS certificate of the MQTT broker")
+	flag.String(CfgMQTTTLSKeyPath, "tls/key.pem", "the path to the TLS private key of the MQTT broker")
+	flag.String(CfgMQTTTLSCAPath, "", "the path to the CA certificate used to verify client certificates (empty = no client certificates required)")
+	flag.Bool(CfgMQTTAuthEnabled, false, "whether clients must authenticate with username and password")
+	flag.String(CfgMQTTAuthUsername, "", "the username of the MQTT clients")
+	flag.String(CfgMQTTAuthPasswordHash, "", "the MQTT client password+salt as a sha256 hash")
+	flag.String(CfgMQTTAuthPasswordSalt, "", "the MQTT client salt used for hashing the password")
+	flag.Bool(CfgMQTTBridgeEnabled, false, "whether all published messages are forwarded to an external MQTT broker")
+	flag.String(CfgMQTTBridgeBrokerURL, "", "the URL of the external MQTT broker (e.g. tcp://example.com:1883 or ssl://example.com:8883)")
+	flag.String(CfgMQTTBridgeClientID, "hornet", "the client ID used to connect to the external MQTT broker")
+	flag.String(CfgMQTTBridgeUsername, "", "the username used to connect to the external MQTT broker")
+	flag.String(CfgMQTTBridgePassword, "", "the password used to connect to the external MQTT broker")
+	flag.String(CfgMQTTBridgeTopicPrefix, "", "the prefix which is added to the topics of the forwarded messages")
+	flag.Int(CfgMQTTBridgeQoS, 0, "the QoS level of the forwarded messages")
+	flag.StringSlice(CfgMQTTIPFilterAllowedNetworks, []string{}, "the networks (CIDR) or addresses which are allowed to connect to the MQTT broker (empty = all)")
+	flag.StringSlice(CfgMQTTIPFilterDeniedNetworks, []string{}, "the networks (CIDR) or addresses which are not allowed to connect to the MQTT broker")
 }
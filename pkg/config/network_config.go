@@ -9,15 +9,71 @@ type PeerConfig struct {
 	ID         string `json:"identity" mapstructure:"identity"`
 	Alias      string `json:"alias" mapstructure:"alias"`
 	PreferIPv6 bool   `json:"preferIPv6" mapstructure:"preferIPv6"`
+	// the hex encoded ed25519 identity key the peer has to authenticate with if the connections are secured
+	PublicKey string `json:"publicKey,omitempty" mapstructure:"publicKey"`
+	// the transport of the gossip with the peer, "tcp" or "quic" (empty = tcp)
+	Transport string `json:"transport,omitempty" mapstructure:"transport"`
 }
 
 const (
 	// Defines if IPv6 is preferred for peers added through the API
 	CfgNetPreferIPv6 = "network.preferIPv6"
+	// the URL of the SOCKS5 or HTTP proxy for outbound connections to neighbors, snapshot downloads and remote PoW (empty = direct)
+	CfgNetProxyURL = "network.proxyURL"
 	// the bind address of the gossip TCP server
 	CfgNetGossipBindAddress = "network.gossip.bindAddress"
 	// the number of seconds to wait before trying to reconnect to a disconnected peer
 	CfgNetGossipReconnectAttemptIntervalSeconds = "network.gossip.reconnectAttemptIntervalSeconds"
+	// the maximum amount of transactions per second received from a single neighbor (0 = unlimited)
+	CfgNetGossipLimitsInboundTransactionsPerSecond = "network.gossip.limits.inboundTransactionsPerSecond"
+	// the maximum amount of bytes per second received from a single neighbor (0 = unlimited)
+	CfgNetGossipLimitsInboundBytesPerSecond = "network.gossip.limits.inboundBytesPerSecond"
+	// the maximum amount of transactions per second sent to a single neighbor (0 = unlimited)
+	CfgNetGossipLimitsOutboundTransactionsPerSecond = "network.gossip.limits.outboundTransactionsPerSecond"
+	// the maximum amount of bytes per second sent to a single neighbor (0 = unlimited)
+	CfgNetGossipLimitsOutboundBytesPerSecond = "network.gossip.limits.outboundBytesPerSecond"
+	// whether neighbors are scored on their behavior and banned if their score drops to the ban threshold
+	CfgNetGossipReputationEnabled = "network.gossip.reputation.enabled"
+	// the score a neighbor loses for an invalid transaction
+	CfgNetGossipReputationInvalidTransactionPenalty = "network.gossip.reputation.invalidTransactionPenalty"
+	// the score a neighbor loses for a heartbeat with a stale milestone
+	CfgNetGossipReputationStaleMilestonePenalty = "network.gossip.reputation.staleMilestonePenalty"
+	// the score a neighbor loses for a protocol violation
+	CfgNetGossipReputationProtocolViolationPenalty = "network.gossip.reputation.protocolViolationPenalty"
+	// the amount of milestones the latest milestone of a neighbor may be behind our solid milestone before it is considered stale
+	CfgNetGossipReputationStaleMilestoneThreshold = "network.gossip.reputation.staleMilestoneThreshold"
+	// the score at which a neighbor gets dropped and banned
+	CfgNetGossipReputationBanThreshold = "network.gossip.reputation.banThreshold"
+	// the number of minutes a neighbor gets banned for
+	CfgNetGossipReputationBanDurationMinutes = "network.gossip.reputation.banDurationMinutes"
+	// the score a neighbor regains every minute
+	CfgNetGossipReputationRecoveryPerMinute = "network.gossip.reputation.recoveryPerMinute"
+	// the maximum age (in seconds) of the latest heartbeat of a neighbor before it is considered stale (0 = disable)
+	CfgNetGossipStalenessMaxHeartbeatAgeSeconds = "network.gossip.staleness.maxHeartbeatAgeSeconds"
+	// the maximum percentage of stale transactions received from a neighbor in one check before it is considered stale (0 = disable)
+	CfgNetGossipStalenessMaxStaleTransactionsPercentage = "network.gossip.staleness.maxStaleTransactionsPercentage"
+	// the amount of consecutive checks (every minute) a neighbor has to be stale before a warning is logged (0 = disable)
+	CfgNetGossipStalenessWarnAfterChecks = "network.gossip.staleness.warnAfterChecks"
+	// the interval (in seconds) in which locally submitted transactions which are not solid yet are broadcasted again
+	CfgNetGossipPendingBroadcastsRebroadcastIntervalSeconds = "network.gossip.pendingBroadcasts.rebroadcastIntervalSeconds"
+	// the number of minutes after which a locally submitted transaction which is not solid yet is no longer broadcasted
+	CfgNetGossipPendingBroadcastsMaxAgeMinutes = "network.gossip.pendingBroadcasts.maxAgeMinutes"
+	// the monthly budget (in megabytes) of the received and sent gossip traffic (0 = disable)
+	CfgNetGossipBandwidthMonthlyBudgetMegabytes = "network.gossip.bandwidth.monthlyBudgetMegabytes"
+	// the day of the month on which the bandwidth budget is reset
+	CfgNetGossipBandwidthResetDay = "network.gossip.bandwidth.resetDay"
+	// the percentage of the bandwidth budget from which on replies to stale requests of neighbors are throttled
+	CfgNetGossipBandwidthThrottleThresholdPercentage = "network.gossip.bandwidth.throttleThresholdPercentage"
+	// the networks (CIDR) or addresses which are allowed to connect to the gossip server (empty = all)
+	CfgNetGossipIPFilterAllowedNetworks = "network.gossip.ipFilter.allowedNetworks"
+	// the networks (CIDR) or addresses which are not allowed to connect to the gossip server
+	CfgNetGossipIPFilterDeniedNetworks = "network.gossip.ipFilter.deniedNetworks"
+	// whether the connections to peers with a pinned public key are secured with TLS
+	CfgNetGossipTLSEnabled = "network.gossip.tls.enabled"
+	// the hex encoded ed25519 private key of the node identity used to secure the connections
+	CfgNetGossipTLSPrivateKey = "network.gossip.tls.privateKey"
+	// whether the gossip via QUIC is accepted on the UDP port of the bind address
+	CfgNetGossipQUICEnabled = "network.gossip.quic.enabled"
 
 	// enable inbound connections from unknown peers
 	CfgPeeringAcceptAnyConnection = "acceptAnyConnection"
@@ -28,6 +84,8 @@ const (
 
 	// list of autopeering entry nodes to use
 	CfgNetAutopeeringEntryNodes = "network.autopeering.entryNodes"
+	// the interval (in minutes) in which the addresses of the entry nodes are resolved again (0 = disable)
+	CfgNetAutopeeringEntryNodesResolveIntervalMinutes = "network.autopeering.entryNodesResolveIntervalMinutes"
 	// bind address for global services such as autopeering and gossip
 	CfgNetAutopeeringBindAddr = "network.autopeering.bindAddress"
 	// private key seed used to derive the node identity; optional Base64 encoded 256-bit string
@@ -40,16 +98,58 @@ const (
 	CfgNetAutopeeringOutboundPeers = "network.autopeering.outboundPeers"
 	// lifetime (in minutes) of the private and public local salt
 	CfgNetAutopeeringSaltLifetime = "network.autopeering.saltLifetime"
-	// maximum percentage of dropped packets in one minute before an autopeered neighbor gets dropped
+	// maximum percentage of dropped packets in one stale check interval before an autopeered neighbor gets dropped
 	CfgNetAutopeeringMaxDroppedPacketsPercentage = "network.autopeering.maxDroppedPacketsPercentage"
+	// the interval (in seconds) in which autopeered neighbors are checked for dropped packets
+	CfgNetAutopeeringStaleCheckIntervalSeconds = "network.autopeering.staleCheckIntervalSeconds"
+	// the interval (in seconds) in which the outbound autopeers are checked
+	CfgNetAutopeeringOutboundUpdateIntervalSeconds = "network.autopeering.outboundUpdateIntervalSeconds"
+	// the interval (in seconds) in which the outbound autopeers are updated if all outbound slots are filled
+	CfgNetAutopeeringFullOutboundUpdateIntervalSeconds = "network.autopeering.fullOutboundUpdateIntervalSeconds"
+	// whether all autopeers are dropped when the salt is updated
+	CfgNetAutopeeringDropNeighborsOnSaltUpdate = "network.autopeering.dropNeighborsOnSaltUpdate"
+	// the interval (in seconds) after which the next discovered peer is reverified
+	CfgNetAutopeeringReverifyIntervalSeconds = "network.autopeering.reverifyIntervalSeconds"
+	// the interval (in seconds) after which discovered peers are queried for new peers
+	CfgNetAutopeeringQueryIntervalSeconds = "network.autopeering.queryIntervalSeconds"
+	// the maximum number of discovered peers which are managed
+	CfgNetAutopeeringMaxManagedPeers = "network.autopeering.maxManagedPeers"
+	// the maximum number of discovered peers kept in the replacement list
+	CfgNetAutopeeringMaxReplacementPeers = "network.autopeering.maxReplacementPeers"
 )
 
 func init() {
 
 	// gossip
 	flag.Bool(CfgNetPreferIPv6, false, "defines if IPv6 is preferred for peers added through the API")
+	flag.String(CfgNetProxyURL, "", "the URL of the SOCKS5 or HTTP proxy for outbound connections to neighbors, snapshot downloads and remote PoW (empty = direct)")
 	flag.String(CfgNetGossipBindAddress, "0.0.0.0:15600", "the bind address of the gossip TCP server")
 	flag.Int(CfgNetGossipReconnectAttemptIntervalSeconds, 60, "the number of seconds to wait before trying to reconnect to a disconnected peer")
+	flag.Float64(CfgNetGossipLimitsInboundTransactionsPerSecond, 0, "the maximum amount of transactions per second received from a single neighbor (0 = unlimited)")
+	flag.Int(CfgNetGossipLimitsInboundBytesPerSecond, 0, "the maximum amount of bytes per second received from a single neighbor (0 = unlimited)")
+	flag.Float64(CfgNetGossipLimitsOutboundTransactionsPerSecond, 0, "the maximum amount of transactions per second sent to a single neighbor (0 = unlimited)")
+	flag.Int(CfgNetGossipLimitsOutboundBytesPerSecond, 0, "the maximum amount of bytes per second sent to a single neighbor (0 = unlimited)")
+	flag.Bool(CfgNetGossipReputationEnabled, true, "whether neighbors are scored on their behavior and banned if their score drops to the ban threshold")
+	flag.Int(CfgNetGossipReputationInvalidTransactionPenalty, 100, "the score a neighbor loses for an invalid transaction")
+	flag.Int(CfgNetGossipReputationStaleMilestonePenalty, 2, "the score a neighbor loses for a heartbeat with a stale milestone")
+	flag.Int(CfgNetGossipReputationProtocolViolationPenalty, 50, "the score a neighbor loses for a protocol violation")
+	flag.Int(CfgNetGossipReputationStaleMilestoneThreshold, 50, "the amount of milestones the latest milestone of a neighbor may be behind our solid milestone before it is considered stale")
+	flag.Int(CfgNetGossipReputationBanThreshold, -100, "the score at which a neighbor gets dropped and banned")
+	flag.Int(CfgNetGossipReputationBanDurationMinutes, 30, "the number of minutes a neighbor gets banned for")
+	flag.Int(CfgNetGossipReputationRecoveryPerMinute, 1, "the score a neighbor regains every minute")
+	flag.Int(CfgNetGossipStalenessMaxHeartbeatAgeSeconds, 600, "the maximum age (in seconds) of the latest heartbeat of a neighbor before it is considered stale (0 = disable)")
+	flag.Int(CfgNetGossipStalenessMaxStaleTransactionsPercentage, 50, "the maximum percentage of stale transactions received from a neighbor in one check before it is considered stale (0 = disable)")
+	flag.Int(CfgNetGossipStalenessWarnAfterChecks, 5, "the amount of consecutive checks (every minute) a neighbor has to be stale before a warning is logged (0 = disable)")
+	flag.Int(CfgNetGossipPendingBroadcastsRebroadcastIntervalSeconds, 60, "the interval (in seconds) in which locally submitted transactions which are not solid yet are broadcasted again")
+	flag.Int(CfgNetGossipPendingBroadcastsMaxAgeMinutes, 60, "the number of minutes after which a locally submitted transaction which is not solid yet is no longer broadcasted")
+	flag.Int(CfgNetGossipBandwidthMonthlyBudgetMegabytes, 0, "the monthly budget (in megabytes) of the received and sent gossip traffic (0 = disable)")
+	flag.Int(CfgNetGossipBandwidthResetDay, 1, "the day of the month (1-28, UTC) on which the bandwidth budget is reset")
+	flag.Int(CfgNetGossipBandwidthThrottleThresholdPercentage, 80, "the percentage of the bandwidth budget from which on replies to stale requests of neighbors are throttled progressively, relaying transactions stops once the budget is used up")
+	flag.StringSlice(CfgNetGossipIPFilterAllowedNetworks, []string{}, "the networks (CIDR) or addresses which are allowed to connect to the gossip server (empty = all)")
+	flag.StringSlice(CfgNetGossipIPFilterDeniedNetworks, []string{}, "the networks (CIDR) or addresses which are not allowed to connect to the gossip server")
+	flag.Bool(CfgNetGossipTLSEnabled, false, "whether the connections to peers with a pinned public key are secured with TLS")
+	flag.String(CfgNetGossipTLSPrivateKey, "", "the hex encoded ed25519 private key of the node identity used to secure the connections")
+	flag.Bool(CfgNetGossipQUICEnabled, false, "whether the gossip via QUIC is accepted on the UDP port of the bind address")
 
 	// peering
 	flag.Bool(CfgPeeringAcceptAnyConnection, false, "enable inbound connections from unknown peers")
@@ -62,12 +162,21 @@ func init() {
 		"EkSLZ4uvSTED1x6KaGzqxoGxjbytt2rPVfbJk1LRLCGL@enter.manapotion.io:18626",
 		"2GHfjJhTqRaKCGBJJvS5RWty61XhjX7FtbVDhg7s8J1x@entrynode.tanglebay.org:14626",
 		"iotaMk9Rg8wWo1DDeG7fwV9iJ41hvkwFX8w6MyTQgDu@enter.thetangle.org:14627",
-	}, "list of autopeering entry nodes to use")
+	}, "list of autopeering entry nodes to use (pubKey@host:port, dns:name for TXT records or srv:name for SRV records)")
+	flag.Int(CfgNetAutopeeringEntryNodesResolveIntervalMinutes, 60, "the interval (in minutes) in which the addresses of the entry nodes are resolved again (0 = disable)")
 	flag.String(CfgNetAutopeeringBindAddr, "0.0.0.0:14626", "bind address for global services such as autopeering and gossip")
 	flag.String(CfgNetAutopeeringSeed, "", "private key seed used to derive the node identity; optional Base64 encoded 256-bit string")
 	flag.Bool(CfgNetAutopeeringRunAsEntryNode, false, "whether the node should act as an autopeering entry node")
 	flag.Int(CfgNetAutopeeringInboundPeers, 2, "the number of inbound autopeers")
 	flag.Int(CfgNetAutopeeringOutboundPeers, 2, "the number of outbound autopeers")
 	flag.Int(CfgNetAutopeeringSaltLifetime, 30, "lifetime (in minutes) of the private and public local salt")
-	flag.Int(CfgNetAutopeeringMaxDroppedPacketsPercentage, 0, "maximum percentage of dropped packets in one minute before an autopeered neighbor gets dropped (0 = disable)")
+	flag.Int(CfgNetAutopeeringMaxDroppedPacketsPercentage, 0, "maximum percentage of dropped packets in one stale check interval before an autopeered neighbor gets dropped (0 = disable)")
+	flag.Int(CfgNetAutopeeringStaleCheckIntervalSeconds, 60, "the interval (in seconds) in which autopeered neighbors are checked for dropped packets")
+	flag.Int(CfgNetAutopeeringOutboundUpdateIntervalSeconds, 30, "the interval (in seconds) in which the outbound autopeers are checked")
+	flag.Int(CfgNetAutopeeringFullOutboundUpdateIntervalSeconds, 30, "the interval (in seconds) in which the outbound autopeers are updated if all outbound slots are filled")
+	flag.Bool(CfgNetAutopeeringDropNeighborsOnSaltUpdate, false, "whether all autopeers are dropped when the salt is updated")
+	flag.Int(CfgNetAutopeeringReverifyIntervalSeconds, 10, "the interval (in seconds) after which the next discovered peer is reverified")
+	flag.Int(CfgNetAutopeeringQueryIntervalSeconds, 60, "the interval (in seconds) after which discovered peers are queried for new peers")
+	flag.Int(CfgNetAutopeeringMaxManagedPeers, 1000, "the maximum number of discovered peers which are managed")
+	flag.Int(CfgNetAutopeeringMaxReplacementPeers, 10, "the maximum number of discovered peers kept in the replacement list")
 }
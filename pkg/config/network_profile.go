@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the network profile which sets the network specific settings, e.g. "mainnet", "comnet", "devnet" or a custom one (empty = use the configured settings)
+	CfgNetProfile = "network.profile"
+	// the custom network profiles, e.g. for private tangles. each profile contains the settings to apply in the structure of the config file.
+	CfgNetProfiles = "network.profiles"
+)
+
+// NetworkProfile bundles the settings which differ between the IOTA networks by their config keys.
+type NetworkProfile map[string]interface{}
+
+var (
+	// NetworkProfileMainnet are the settings of the mainnet.
+	NetworkProfileMainnet = NetworkProfile{
+		CfgCoordinatorAddress:         "UDYXTZBE9GZGPM9SSQV9LTZNDLJIZMPUVVXYXFYVBLIEUHLSEWFTKZZLXYRHHWVQV9MNNX9KZC9D9UZWZ",
+		CfgCoordinatorSecurityLevel:   2,
+		CfgCoordinatorMerkleTreeDepth: 24,
+		CfgCoordinatorMWM:             14,
+		CfgNetGossipBindAddress:       "0.0.0.0:15600",
+		CfgNetAutopeeringBindAddr:     "0.0.0.0:14626",
+		CfgDatabasePath:               "mainnetdb",
+		CfgLocalSnapshotsPath:         "snapshots/mainnet/export.bin",
+		CfgLocalSnapshotsDeltaPath:    "snapshots/mainnet/delta_export.bin",
+		CfgGlobalSnapshotPath:         "snapshotMainnet.txt",
+		CfgGlobalSnapshotIndex:        1050000,
+		CfgLocalSnapshotsDownloadURLs: []string{
+			"https://ls.manapotion.io/export.bin",
+			"https://x-vps.com/export.bin",
+			"https://dbfiles.iota.org/mainnet/hornet/latest-export.bin",
+		},
+		CfgGlobalSnapshotSpentAddressesPaths: []string{
+			"previousEpochsSpentAddresses1.txt",
+			"previousEpochsSpentAddresses2.txt",
+			"previousEpochsSpentAddresses3.txt",
+		},
+		CfgNetAutopeeringEntryNodes: []string{
+			"FvfwJuCMoWJvcJLSYww7whPxouZ9WFJ55uyxTxKxJ1ez@enter.hornet.zone:14626",
+			"EkSLZ4uvSTED1x6KaGzqxoGxjbytt2rPVfbJk1LRLCGL@enter.manapotion.io:18626",
+			"iotaMk9Rg8wWo1DDeG7fwV9iJ41hvkwFX8w6MyTQgDu@enter.thetangle.org:14627",
+			"12w9FrzMdDQ42aBgFrv1siHuJMhuZ4SMVHRFSS7Zb72W@entrynode.iotatoken.nl:14626",
+			"DboTc1v61Xdyvggj8VRszy92ScUTLgfwZaHvXsU8zr7e@entrynode.tanglebay.org:14626",
+			"31Tz9meznQMm7qSDUgyMmYVeHUCGA7za5Suvbom5hpE9@bender.iota.autopeering.com:14626",
+		},
+	}
+
+	// NetworkProfileComnet are the settings of the comnet.
+	NetworkProfileComnet = NetworkProfile{
+		CfgCoordinatorAddress:                "UOMFQOULWQLXQQHFMFRQQTRDKDHVMRFFEGZ9LDU9TFZZ9CHDLZSIAHA9MXNSLYOERCHDUVDFEEZAEOBEW",
+		CfgCoordinatorSecurityLevel:          2,
+		CfgCoordinatorMerkleTreeDepth:        23,
+		CfgCoordinatorMWM:                    10,
+		CfgNetGossipBindAddress:              "0.0.0.0:15600",
+		CfgNetAutopeeringBindAddr:            "0.0.0.0:14626",
+		CfgDatabasePath:                      "comnetdb",
+		CfgLocalSnapshotsPath:                "snapshots/comnet/export.bin",
+		CfgLocalSnapshotsDeltaPath:           "snapshots/comnet/delta_export.bin",
+		CfgLocalSnapshotsDownloadURLs:        []string{"https://ls.manapotion.io/comnet/export.bin"},
+		CfgGlobalSnapshotPath:                "snapshot.csv",
+		CfgGlobalSnapshotSpentAddressesPaths: []string{},
+		CfgGlobalSnapshotIndex:               0,
+		CfgNetAutopeeringEntryNodes: []string{
+			"iotaCrvEWGfaeA1HutcULjD4uZnPhEnD5xNGfGs8vhe@enter.comnet.thetangle.org:14647",
+			"GLZAWBGqvm6ZRT7jGMFAKyUJNPdvx4i5A1GPRZbGS6C9@enter.comnet.hornet.zone:14627",
+			"J1Hn5r9pS5FkLeYqXWstC2Zyjxj73grEWvjuene3qjM9@entrynode.comnet.tanglebay.org:14636",
+		},
+	}
+
+	// NetworkProfileDevnet are the settings of the devnet.
+	NetworkProfileDevnet = NetworkProfile{
+		CfgCoordinatorAddress:                "GYISMBVRKSCEXXTUPBWTIHRCZIKIRPDYAHAYKMNTPZSCSDNADDWAEUNHKUERZCTVAYJCNFXGTNUH9OGTW",
+		CfgCoordinatorSecurityLevel:          2,
+		CfgCoordinatorMerkleTreeDepth:        22,
+		CfgCoordinatorMWM:                    9,
+		CfgNetGossipBindAddress:              "0.0.0.0:15600",
+		CfgNetAutopeeringBindAddr:            "0.0.0.0:14626",
+		CfgDatabasePath:                      "devnetdb",
+		CfgLocalSnapshotsPath:                "snapshots/devnet/export.bin",
+		CfgLocalSnapshotsDeltaPath:           "snapshots/devnet/delta_export.bin",
+		CfgLocalSnapshotsDownloadURLs:        []string{"https://dbfiles.iota.org/devnet/hornet/latest-export.bin"},
+		CfgGlobalSnapshotPath:                "",
+		CfgGlobalSnapshotSpentAddressesPaths: []string{},
+		CfgGlobalSnapshotIndex:               0,
+		CfgNetAutopeeringEntryNodes:          []string{"iotaDvNxMP5EPQPbHNzMTZK5ipd4BGZfjZBomenmyk3@enter.devnet.thetangle.org:14637"},
+	}
+
+	// the network profiles which are known without being defined in the config
+	builtinNetworkProfiles = map[string]NetworkProfile{
+		"mainnet": NetworkProfileMainnet,
+		"comnet":  NetworkProfileComnet,
+		"devnet":  NetworkProfileDevnet,
+	}
+)
+
+func init() {
+	flag.String(CfgNetProfile, "", "the network profile which sets the network specific settings, e.g. \"mainnet\", \"comnet\", \"devnet\" or a custom one (empty = use the configured settings)")
+}
+
+// LoadNetworkProfile applies the settings of the network profile set in the config as defaults.
+// Settings given in the config file, as environment variable or as CLI flag take precedence over the ones of the profile.
+// The configured settings which differ from the profile are logged.
+func LoadNetworkProfile() error {
+	name := strings.ToLower(NodeConfig.GetString(CfgNetProfile))
+	if name == "" {
+		return nil
+	}
+
+	profile, err := networkProfile(name)
+	if err != nil {
+		return err
+	}
+
+	if overridden := applyNetworkProfile(profile); len(overridden) > 0 {
+		log.Printf("The configured settings %s override the ones of the network profile '%s'.", strings.Join(overridden, ", "), name)
+	}
+
+	return nil
+}
+
+// applyNetworkProfile sets the settings of the given profile as defaults of the node config.
+// It returns the sorted keys of the settings which are configured with a different value than the one of the profile.
+func applyNetworkProfile(profile NetworkProfile) []string {
+	var overridden []string
+	for key, value := range profile {
+		// the defaults of the flags are not considered as set
+		if NodeConfig.IsSet(key) && fmt.Sprint(NodeConfig.Get(key)) != fmt.Sprint(value) {
+			overridden = append(overridden, key)
+		}
+		NodeConfig.SetDefault(key, value)
+	}
+
+	sort.Strings(overridden)
+	return overridden
+}
+
+// networkProfile returns the custom network profile with the given name, or the built-in one if there is no custom profile.
+func networkProfile(name string) (NetworkProfile, error) {
+	key := CfgNetProfiles + "." + name
+	if !NodeConfig.IsSet(key) {
+		profile, exists := builtinNetworkProfiles[name]
+		if !exists {
+			return nil, fmt.Errorf("network profile '%s' is not defined in '%s'", name, CfgNetProfiles)
+		}
+		return profile, nil
+	}
+
+	settings := NodeConfig.Sub(key)
+	if settings == nil {
+		return nil, fmt.Errorf("network profile '%s' in '%s' is invalid", name, CfgNetProfiles)
+	}
+
+	profile := make(NetworkProfile)
+	for _, settingKey := range settings.AllKeys() {
+		profile[settingKey] = settings.Get(settingKey)
+	}
+	return profile, nil
+}
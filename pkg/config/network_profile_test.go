@@ -0,0 +1,99 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeConfig = `{
+  "coordinator": {
+    "address": "CONFIGURED",
+    "mwm": 14
+  },
+  "db": {
+    "path": "mainnetdb"
+  },
+  "network": {
+    "profile": "",
+    "profiles": {
+      "private-tangle": {
+        "coordinator": {
+          "address": "PRIVATE",
+          "mwm": 5
+        },
+        "snapshots": {
+          "local": {
+            "downloadURLs": []
+          }
+        }
+      }
+    }
+  }
+}`
+
+func loadTestNodeConfig(t *testing.T, profile string) {
+	NodeConfig.SetConfigType("json")
+	require.NoError(t, NodeConfig.ReadConfig(strings.NewReader(testNodeConfig)))
+	NodeConfig.Set(CfgNetProfile, profile)
+}
+
+func TestLoadNetworkProfile(t *testing.T) {
+	// the settings of a profile are not reset by reading the config again
+	defer func(nodeConfig *viper.Viper) {
+		NodeConfig = nodeConfig
+	}(NodeConfig)
+	NodeConfig = viper.New()
+
+	loadTestNodeConfig(t, "")
+	require.NoError(t, LoadNetworkProfile())
+	assert.Equal(t, "CONFIGURED", NodeConfig.GetString(CfgCoordinatorAddress))
+
+	// built-in profile
+	NodeConfig = viper.New()
+	loadTestNodeConfig(t, "DevNet")
+	require.NoError(t, LoadNetworkProfile())
+	assert.Equal(t, 22, NodeConfig.GetInt(CfgCoordinatorMerkleTreeDepth))
+	assert.Equal(t, "0.0.0.0:15600", NodeConfig.GetString(CfgNetGossipBindAddress))
+	assert.Equal(t, "snapshots/devnet/export.bin", NodeConfig.GetString(CfgLocalSnapshotsPath))
+	// the global snapshot of the mainnet is not used
+	assert.Equal(t, "", NodeConfig.GetString(CfgGlobalSnapshotPath))
+	assert.Equal(t, []string{}, NodeConfig.GetStringSlice(CfgGlobalSnapshotSpentAddressesPaths))
+	assert.Equal(t, 0, NodeConfig.GetInt(CfgGlobalSnapshotIndex))
+
+	// custom profile, settings which are set explicitly take precedence
+	NodeConfig = viper.New()
+	loadTestNodeConfig(t, "private-tangle")
+	// like an environment variable applied by LoadEnvironment
+	NodeConfig.Set(CfgCoordinatorMWM, 7)
+	require.NoError(t, LoadNetworkProfile())
+	assert.Equal(t, "CONFIGURED", NodeConfig.GetString(CfgCoordinatorAddress))
+	assert.Equal(t, 7, NodeConfig.GetInt(CfgCoordinatorMWM))
+	assert.Len(t, NodeConfig.GetStringSlice(CfgLocalSnapshotsDownloadURLs), 0)
+
+	NodeConfig = viper.New()
+	loadTestNodeConfig(t, "unknown")
+	assert.Error(t, LoadNetworkProfile())
+}
+
+func TestNetworkProfileConfigFileWins(t *testing.T) {
+	defer func(nodeConfig *viper.Viper) {
+		NodeConfig = nodeConfig
+	}(NodeConfig)
+	NodeConfig = viper.New()
+	loadTestNodeConfig(t, "devnet")
+
+	// the settings of the config file keep their values and are reported, equal values are not
+	NodeConfig.Set(CfgCoordinatorMWM, 9)
+	assert.Equal(t, []string{CfgCoordinatorAddress, CfgDatabasePath}, applyNetworkProfile(NetworkProfileDevnet))
+	assert.Equal(t, "CONFIGURED", NodeConfig.GetString(CfgCoordinatorAddress))
+	assert.Equal(t, "mainnetdb", NodeConfig.GetString(CfgDatabasePath))
+	assert.Equal(t, 9, NodeConfig.GetInt(CfgCoordinatorMWM))
+
+	// the settings of the profile are only used for the settings which are not configured
+	assert.Equal(t, NetworkProfileDevnet[CfgCoordinatorMerkleTreeDepth], NodeConfig.GetInt(CfgCoordinatorMerkleTreeDepth))
+	assert.Equal(t, NetworkProfileDevnet[CfgNetAutopeeringBindAddr], NodeConfig.GetString(CfgNetAutopeeringBindAddr))
+}
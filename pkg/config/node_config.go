@@ -9,9 +9,26 @@ const (
 	CfgNodeAlias = "node.alias"
 	// CfgNodeShowAliasInGetNodeInfo defines whether to show the alias in getNodeInfo
 	CfgNodeShowAliasInGetNodeInfo = "node.showAliasInGetNodeInfo"
+	// CfgNodeShutdownReadinessDelaySeconds defines how long the node reports not to be ready before it shuts down,
+	// so load balancers can take it out of rotation
+	CfgNodeShutdownReadinessDelaySeconds = "node.shutdown.readinessDelaySeconds"
+	// CfgNodeShutdownDrainTimeoutSeconds defines the maximum time to finish in-flight API requests
+	// and to flush the gossip send queues on shutdown
+	CfgNodeShutdownDrainTimeoutSeconds = "node.shutdown.drainTimeoutSeconds"
+	// CfgNodePluginStatePath defines the path to the file which stores the plugins that were started or stopped at runtime
+	CfgNodePluginStatePath = "node.pluginStatePath"
+	// CfgNodeUpdateCheckEnabled defines whether the node checks for new releases on GitHub
+	CfgNodeUpdateCheckEnabled = "node.updateCheck.enabled"
+	// CfgNodeUpdateCheckIntervalMinutes defines the interval (in minutes) in which the node checks for new releases
+	CfgNodeUpdateCheckIntervalMinutes = "node.updateCheck.intervalMinutes"
 )
 
 func init() {
 	flag.String(CfgNodeAlias, "", "set an alias to identify a node")
 	flag.Bool(CfgNodeShowAliasInGetNodeInfo, false, "defines whether to show the alias in getNodeInfo")
+	flag.Int(CfgNodeShutdownReadinessDelaySeconds, 0, "defines how long the node reports not to be ready before it shuts down, so load balancers can take it out of rotation")
+	flag.Int(CfgNodeShutdownDrainTimeoutSeconds, 30, "defines the maximum time to finish in-flight API requests and to flush the gossip send queues on shutdown")
+	flag.String(CfgNodePluginStatePath, "pluginstate.json", "defines the path to the file which stores the plugins that were started or stopped at runtime")
+	flag.Bool(CfgNodeUpdateCheckEnabled, false, "defines whether the node checks for new releases on GitHub")
+	flag.Int(CfgNodeUpdateCheckIntervalMinutes, 60, "defines the interval (in minutes) in which the node checks for new releases")
 }
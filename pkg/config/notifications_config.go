@@ -0,0 +1,38 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+// WebhookConfig holds the configuration of a webhook of the notifications plugin.
+type WebhookConfig struct {
+	// the URL the events are posted to
+	URL string `json:"url" mapstructure:"url"`
+	// the events which are posted (empty = all)
+	Events []string `json:"events" mapstructure:"events"`
+	// additional headers of the requests, e.g. for authentication
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+	// the text/template of the JSON body of the requests (empty = the event itself)
+	Payload string `json:"payload" mapstructure:"payload"`
+}
+
+const (
+	// the webhooks the events are posted to
+	CfgNotificationsWebhooks = "notifications.webhooks"
+	// the addresses for which an event is posted if a milestone changes their balance
+	CfgNotificationsAddresses = "notifications.addresses"
+	// the timeout (in seconds) of the requests to the webhooks
+	CfgNotificationsTimeoutSeconds = "notifications.timeoutSeconds"
+	// how often a failed request is retried if the webhook is unreachable or answers with 429 or 5xx
+	CfgNotificationsRetries = "notifications.retries"
+	// the delay (in seconds) before the first retry, it doubles with every further retry
+	CfgNotificationsRetryDelaySeconds = "notifications.retryDelaySeconds"
+)
+
+func init() {
+	NodeConfig.SetDefault(CfgNotificationsWebhooks, []WebhookConfig{})
+	flag.StringSlice(CfgNotificationsAddresses, []string{}, "the addresses for which an event is posted if a milestone changes their balance")
+	flag.Int(CfgNotificationsTimeoutSeconds, 10, "the timeout (in seconds) of the requests to the webhooks")
+	flag.Int(CfgNotificationsRetries, 3, "how often a failed request is retried if the webhook is unreachable or answers with 429 or 5xx")
+	flag.Int(CfgNotificationsRetryDelaySeconds, 5, "the delay (in seconds) before the first retry, it doubles with every further retry")
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// the amount of local PoW requests of the API which are calculated at the same time
+	// the PoW of the node itself (coordinator, spammer, faucet) has the same amount of workers on its own
+	CfgPoWWorkers = "pow.workers"
+	// the amount of threads used by a single local PoW request (0 = all cores)
+	CfgPoWParallelism = "pow.parallelism"
+	// the maximum amount of local PoW requests of the API waiting for a free worker (0 = no limit)
+	// the PoW of the node itself is never rejected
+	CfgPoWMaxQueueSize = "pow.maxQueueSize"
+	// the endpoint of the remote proof-of-work service (empty = disable)
+	// the API key of the service is read from the POW_REMOTE_API_KEY environment variable
+	CfgPoWRemoteEndpoint = "pow.remote.endpoint"
+	// the timeout of a PoW request to the remote proof-of-work service in milliseconds
+	CfgPoWRemoteTimeoutMilliseconds = "pow.remote.timeoutMilliseconds"
+	// whether to fall back to local PoW if the remote proof-of-work service fails
+	CfgPoWRemoteLocalFallback = "pow.remote.localFallback"
+)
+
+func init() {
+	flag.Int(CfgPoWWorkers, 1, "the amount of local PoW requests of the API which are calculated at the same time")
+	flag.Int(CfgPoWParallelism, 0, "the amount of threads used by a single local PoW request (0 = all cores)")
+	flag.Int(CfgPoWMaxQueueSize, 100, "the maximum amount of local PoW requests of the API waiting for a free worker (0 = no limit)")
+	flag.String(CfgPoWRemoteEndpoint, "", "the endpoint of the remote proof-of-work service (empty = disable)")
+	flag.Int(CfgPoWRemoteTimeoutMilliseconds, 10000, "the timeout of a PoW request to the remote proof-of-work service in milliseconds")
+	flag.Bool(CfgPoWRemoteLocalFallback, true, "whether to fall back to local PoW if the remote proof-of-work service fails")
+}
@@ -7,8 +7,27 @@ import (
 const (
 	// the bind address on which the profiler listens on
 	CfgProfilingBindAddress = "profiling.bindAddress"
+	// the interval in which the profiles are captured by the continuous profiling plugin
+	CfgProfilingContinuousIntervalSeconds = "profiling.continuous.intervalSeconds"
+	// the duration for which the CPU is profiled
+	CfgProfilingContinuousCPUDurationSeconds = "profiling.continuous.cpuDurationSeconds"
+	// the profiles which are captured ("cpu" or the name of a runtime profile, e.g. "heap", "goroutine", "mutex")
+	CfgProfilingContinuousProfiles = "profiling.continuous.profiles"
+	// the directory in which the captured profiles are stored
+	CfgProfilingContinuousDirectory = "profiling.continuous.directory"
+	// the time in hours after which stored profiles are removed (0 = keep all)
+	CfgProfilingContinuousRetentionHours = "profiling.continuous.retentionHours"
+	// the endpoint to which the captured profiles are pushed instead of storing them (empty = store locally)
+	// the API key of the endpoint is read from the PROFILING_PUSH_API_KEY environment variable
+	CfgProfilingContinuousPushEndpoint = "profiling.continuous.pushEndpoint"
 )
 
 func init() {
 	flag.String(CfgProfilingBindAddress, "localhost:6060", "the bind address on which the profiler listens on")
+	flag.Int(CfgProfilingContinuousIntervalSeconds, 600, "the interval in which the profiles are captured by the continuous profiling plugin")
+	flag.Int(CfgProfilingContinuousCPUDurationSeconds, 10, "the duration for which the CPU is profiled")
+	flag.StringSlice(CfgProfilingContinuousProfiles, []string{"cpu", "heap", "goroutine"}, "the profiles which are captured (\"cpu\" or the name of a runtime profile, e.g. \"heap\", \"goroutine\", \"mutex\")")
+	flag.String(CfgProfilingContinuousDirectory, "profiles", "the directory in which the captured profiles are stored")
+	flag.Int(CfgProfilingContinuousRetentionHours, 72, "the time in hours after which stored profiles are removed (0 = keep all)")
+	flag.String(CfgProfilingContinuousPushEndpoint, "", "the endpoint to which the captured profiles are pushed instead of storing them (empty = store locally)")
 }
@@ -19,6 +19,36 @@ const (
 	CfgPrometheusFileServiceDiscoveryPath = "prometheus.fileServiceDiscovery.path"
 	// the target to write into the 'file SD' file
 	CfgPrometheusFileServiceDiscoveryTarget = "prometheus.fileServiceDiscovery.target"
+	// the interval in which the metrics are pushed
+	CfgPrometheusPushIntervalSeconds = "prometheus.push.intervalSeconds"
+	// the maximum amount of samples sent in a single request
+	CfgPrometheusPushBatchSize = "prometheus.push.batchSize"
+	// the maximum amount of samples kept per target while it is unreachable
+	CfgPrometheusPushMaxBufferedSamples = "prometheus.push.maxBufferedSamples"
+	// how often a failed push is retried before the samples are kept for the next interval
+	CfgPrometheusPushMaxRetries = "prometheus.push.maxRetries"
+	// the timeout of a push request
+	CfgPrometheusPushTimeoutSeconds = "prometheus.push.timeoutSeconds"
+	// the value of the "instance" label added to all pushed metrics (defaults to the hostname)
+	CfgPrometheusPushInstance = "prometheus.push.instance"
+	// whether the metrics are pushed to an InfluxDB
+	CfgPrometheusPushInfluxDBEnabled = "prometheus.push.influxdb.enabled"
+	// the URL of the InfluxDB
+	CfgPrometheusPushInfluxDBURL = "prometheus.push.influxdb.url"
+	// the database the metrics are written to
+	CfgPrometheusPushInfluxDBDatabase = "prometheus.push.influxdb.database"
+	// the username for the InfluxDB
+	CfgPrometheusPushInfluxDBUsername = "prometheus.push.influxdb.username"
+	// the password for the InfluxDB
+	CfgPrometheusPushInfluxDBPassword = "prometheus.push.influxdb.password" // must be lower cased
+	// whether the metrics are pushed to a Prometheus remote write endpoint
+	CfgPrometheusPushRemoteWriteEnabled = "prometheus.push.remotewrite.enabled"
+	// the URL of the remote write endpoint
+	CfgPrometheusPushRemoteWriteURL = "prometheus.push.remotewrite.url"
+	// the username for the remote write endpoint
+	CfgPrometheusPushRemoteWriteUsername = "prometheus.push.remotewrite.username"
+	// the password for the remote write endpoint
+	CfgPrometheusPushRemoteWritePassword = "prometheus.push.remotewrite.password" // must be lower cased
 )
 
 func init() {
@@ -29,4 +59,19 @@ func init() {
 	flag.Bool(CfgPrometheusFileServiceDiscoveryEnabled, false, "whether the plugin should write a Prometheus 'file SD' file")
 	flag.String(CfgPrometheusFileServiceDiscoveryPath, "target.json", "the path where to write the 'file SD' file to")
 	flag.String(CfgPrometheusFileServiceDiscoveryTarget, "localhost:9311", "the target to write into the 'file SD' file")
+	flag.Int(CfgPrometheusPushIntervalSeconds, 15, "the interval in which the metrics are pushed")
+	flag.Int(CfgPrometheusPushBatchSize, 2000, "the maximum amount of samples sent in a single request")
+	flag.Int(CfgPrometheusPushMaxBufferedSamples, 100000, "the maximum amount of samples kept per target while it is unreachable")
+	flag.Int(CfgPrometheusPushMaxRetries, 3, "how often a failed push is retried before the samples are kept for the next interval")
+	flag.Int(CfgPrometheusPushTimeoutSeconds, 10, "the timeout of a push request")
+	flag.String(CfgPrometheusPushInstance, "", "the value of the \"instance\" label added to all pushed metrics (defaults to the hostname)")
+	flag.Bool(CfgPrometheusPushInfluxDBEnabled, false, "whether the metrics are pushed to an InfluxDB")
+	flag.String(CfgPrometheusPushInfluxDBURL, "http://localhost:8086", "the URL of the InfluxDB")
+	flag.String(CfgPrometheusPushInfluxDBDatabase, "hornet", "the database the metrics are written to")
+	flag.String(CfgPrometheusPushInfluxDBUsername, "", "the username for the InfluxDB")
+	flag.String(CfgPrometheusPushInfluxDBPassword, "", "the password for the InfluxDB")
+	flag.Bool(CfgPrometheusPushRemoteWriteEnabled, false, "whether the metrics are pushed to a Prometheus remote write endpoint")
+	flag.String(CfgPrometheusPushRemoteWriteURL, "http://localhost:9090/api/v1/write", "the URL of the remote write endpoint")
+	flag.String(CfgPrometheusPushRemoteWriteUsername, "", "the username for the remote write endpoint")
+	flag.String(CfgPrometheusPushRemoteWritePassword, "", "the password for the remote write endpoint")
 }
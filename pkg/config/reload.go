@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+var (
+	// Events are the events of the node configuration.
+	Events = ConfigEvents{
+		Reloaded: events.NewEvent(events.CallbackCaller),
+	}
+
+	// the settings of the node config which are applied while the node is running
+	reloadableNodeSettings = []string{
+		logger.ViperKeyLevel,
+		CfgWebAPIRateLimitEnabled,
+		CfgWebAPIRateLimitRequestsPerSecond,
+		CfgWebAPIRateLimitBurst,
+		CfgWebAPIRateLimitCommands,
+		CfgWebAPIIPFilterAllowedNetworks,
+		CfgWebAPIIPFilterDeniedNetworks,
+		CfgDashboardIPFilterAllowedNetworks,
+		CfgDashboardIPFilterDeniedNetworks,
+		CfgMQTTIPFilterAllowedNetworks,
+		CfgMQTTIPFilterDeniedNetworks,
+		CfgNetGossipIPFilterAllowedNetworks,
+		CfgNetGossipIPFilterDeniedNetworks,
+		CfgSpammerAddress,
+		CfgSpammerMessage,
+		CfgSpammerTag,
+		CfgSpammerTagSemiLazy,
+		CfgSpammerCPUMaxUsage,
+		CfgSpammerTPSRateLimit,
+		CfgSpammerBundleSize,
+		CfgSpammerValueSpam,
+		CfgSpammerMaxRequestQueueSize,
+	}
+
+	// the settings of the peering config which are applied while the node is running
+	reloadablePeeringSettings = []string{
+		CfgPeeringAcceptAnyConnection,
+		CfgPeers,
+	}
+
+	// the current *reloadableConfig, nil until the config was reloaded for the first time
+	reloadable atomic.Value
+
+	reloadLock syncutils.Mutex
+)
+
+// ConfigEvents are the events of the node configuration.
+type ConfigEvents struct {
+	// Reloaded is fired after the config files were read again.
+	// The plugins apply the settings which can be changed while the node is running.
+	Reloaded *events.Event
+}
+
+// reloadableConfig holds the settings which are applied while the node is running.
+// The viper instances are never modified after they were stored, so they can be read without locking.
+type reloadableConfig struct {
+	node    *viper.Viper
+	peering *viper.Viper
+}
+
+// ReloadableNodeConfig returns the settings of the node config which are applied while the node is running:
+// the log level, the HTTP API rate limits, the IP filters and the spammer settings.
+// NodeConfig is not changed by a reload, so these settings have to be read from the returned config.
+func ReloadableNodeConfig() *viper.Viper {
+	if c, ok := reloadable.Load().(*reloadableConfig); ok {
+		return c.node
+	}
+	return NodeConfig
+}
+
+// ReloadablePeeringConfig returns the settings of the peering config which are applied while the node is running:
+// the static neighbors and whether to accept any connection.
+// PeeringConfig is not changed by a reload, so these settings have to be read from the returned config.
+func ReloadablePeeringConfig() *viper.Viper {
+	if c, ok := reloadable.Load().(*reloadableConfig); ok {
+		return c.peering
+	}
+	return PeeringConfig
+}
+
+// Reload reads the node and the peering config files again and triggers the Reloaded event.
+// The files are read into new configs and validated, afterwards the reloadable settings are replaced at once
+// (see ReloadableNodeConfig and ReloadablePeeringConfig). All other settings still need a restart of the node.
+func Reload() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	nodeConfig, err := readConfigFile(NodeConfig.ConfigFileUsed())
+	if err != nil {
+		return fmt.Errorf("unable to read the node config: %w", err)
+	}
+
+	if err := loadEnvironment(nodeConfig); err != nil {
+		return fmt.Errorf("unable to read the node config: %w", err)
+	}
+
+	var problems []string
+	for _, err := range validateConfig(nodeConfig, NodeConfig.ConfigFileUsed(), os.Environ(), nil) {
+		if !err.Warning {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the node config contains %d problems: %s", len(problems), strings.Join(problems, "; "))
+	}
+
+	peeringConfig, err := readConfigFile(PeeringConfig.ConfigFileUsed())
+	if err != nil {
+		return fmt.Errorf("unable to read the peering config: %w", err)
+	}
+
+	reloadable.Store(&reloadableConfig{
+		node:    copySettings(nodeConfig, reloadableNodeSettings),
+		peering: copySettings(peeringConfig, reloadablePeeringSettings),
+	})
+
+	Events.Reloaded.Trigger()
+	return nil
+}
+
+// ReloadPeeringConfig reads the peering config file again and replaces the reloadable settings of the peering config.
+// In contrast to Reload, the Reloaded event is not triggered.
+func ReloadPeeringConfig() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	peeringConfig, err := readConfigFile(PeeringConfig.ConfigFileUsed())
+	if err != nil {
+		return fmt.Errorf("unable to read the peering config: %w", err)
+	}
+
+	reloadable.Store(&reloadableConfig{
+		node:    ReloadableNodeConfig(),
+		peering: copySettings(peeringConfig, reloadablePeeringSettings),
+	})
+	return nil
+}
+
+// StorePeers replaces the peers in the peering config file and applies them to the reloadable settings of the peering config.
+// The other settings of the file are written back unchanged.
+func StorePeers(peers []map[string]interface{}) error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	configFile := PeeringConfig.ConfigFileUsed()
+	if configFile == "" {
+		return errors.New("no peering config file was loaded")
+	}
+
+	// the file is read without the CLI flags and the environment variables, so that only its own content is written back
+	fileConfig := viper.New()
+	fileConfig.SetConfigFile(configFile)
+	if err := fileConfig.ReadInConfig(); err != nil {
+		return err
+	}
+
+	fileConfig.Set(CfgPeers, peers)
+	if err := fileConfig.WriteConfig(); err != nil {
+		return err
+	}
+
+	peeringConfig, err := readConfigFile(configFile)
+	if err != nil {
+		return err
+	}
+
+	reloadable.Store(&reloadableConfig{
+		node:    ReloadableNodeConfig(),
+		peering: copySettings(peeringConfig, reloadablePeeringSettings),
+	})
+	return nil
+}
+
+// readConfigFile reads the given config file into a new config, which also contains the settings
+// of the CLI flags and the environment variables without prefix, like the configs read at startup.
+func readConfigFile(configFile string) (*viper.Viper, error) {
+	if configFile == "" {
+		return nil, errors.New("no config file was loaded at startup")
+	}
+
+	settings := viper.New()
+	settings.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	settings.AutomaticEnv()
+	if err := settings.BindPFlags(flag.CommandLine); err != nil {
+		return nil, err
+	}
+
+	settings.SetConfigFile(configFile)
+	if err := settings.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// copySettings returns a new config which only contains the given settings of the given config.
+func copySettings(settings *viper.Viper, keys []string) *viper.Viper {
+	c := viper.New()
+	for _, key := range keys {
+		if value := settings.Get(key); value != nil {
+			c.Set(key, value)
+		}
+	}
+	return c
+}
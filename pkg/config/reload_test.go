@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadTestNodeConfig = `{
+  "logger": {
+    "level": "%s"
+  },
+  "httpAPI": {
+    "bindAddress": "0.0.0.0:14265"
+  },
+  "spammer": {
+    "cpuMaxUsage": %v
+  }
+}`
+
+const reloadTestPeeringConfig = `{
+  "acceptAnyConnection": false,
+  "peers": [
+    {
+      "identity": "%s",
+      "alias": "",
+      "preferIPv6": false
+    }
+  ]
+}`
+
+// setupReloadTest writes the test config files and loads them like at startup.
+// The returned function restores the previous configs.
+func setupReloadTest(t *testing.T) (writeConfigs func(level string, cpuMaxUsage float64, peer string), cleanup func()) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+
+	nodeConfigFile := filepath.Join(dir, "config.json")
+	peeringConfigFile := filepath.Join(dir, "peering.json")
+	writeConfigs = func(level string, cpuMaxUsage float64, peer string) {
+		require.NoError(t, ioutil.WriteFile(nodeConfigFile, []byte(fmt.Sprintf(reloadTestNodeConfig, level, cpuMaxUsage)), 0600))
+		require.NoError(t, ioutil.WriteFile(peeringConfigFile, []byte(fmt.Sprintf(reloadTestPeeringConfig, peer)), 0600))
+	}
+	writeConfigs("info", 0.5, "example1.neighbor.com:15600")
+
+	nodeConfig, peeringConfig := NodeConfig, PeeringConfig
+	NodeConfig, PeeringConfig = viper.New(), viper.New()
+	NodeConfig.SetConfigFile(nodeConfigFile)
+	require.NoError(t, NodeConfig.ReadInConfig())
+	PeeringConfig.SetConfigFile(peeringConfigFile)
+	require.NoError(t, PeeringConfig.ReadInConfig())
+
+	return writeConfigs, func() {
+		NodeConfig, PeeringConfig = nodeConfig, peeringConfig
+		reloadable = atomic.Value{}
+		os.RemoveAll(dir)
+	}
+}
+
+func reloadablePeers(t *testing.T) []PeerConfig {
+	var peers []PeerConfig
+	require.NoError(t, ReloadablePeeringConfig().UnmarshalKey(CfgPeers, &peers))
+	return peers
+}
+
+func TestReload(t *testing.T) {
+	writeConfigs, cleanup := setupReloadTest(t)
+	defer cleanup()
+
+	var reloaded int
+	closure := events.NewClosure(func() { reloaded++ })
+	Events.Reloaded.Attach(closure)
+	defer Events.Reloaded.Detach(closure)
+
+	assert.Equal(t, "info", ReloadableNodeConfig().GetString(logger.ViperKeyLevel))
+
+	writeConfigs("debug", 0.8, "example2.neighbor.com:15600")
+	require.NoError(t, Reload())
+	assert.Equal(t, 1, reloaded)
+
+	// only the reloadable settings are applied, the configs read at startup are not changed
+	assert.Equal(t, "debug", ReloadableNodeConfig().GetString(logger.ViperKeyLevel))
+	assert.Equal(t, 0.8, ReloadableNodeConfig().GetFloat64(CfgSpammerCPUMaxUsage))
+	assert.False(t, ReloadableNodeConfig().IsSet(CfgWebAPIBindAddress))
+	assert.Equal(t, "example2.neighbor.com:15600", reloadablePeers(t)[0].ID)
+	assert.Equal(t, "info", NodeConfig.GetString(logger.ViperKeyLevel))
+
+	// an invalid config is not applied
+	writeConfigs("warn", 5, "example3.neighbor.com:15600")
+	assert.Error(t, Reload())
+	assert.Equal(t, 1, reloaded)
+	assert.Equal(t, "debug", ReloadableNodeConfig().GetString(logger.ViperKeyLevel))
+	assert.Equal(t, "example2.neighbor.com:15600", reloadablePeers(t)[0].ID)
+}
+
+func TestReloadWhileReading(t *testing.T) {
+	writeConfigs, cleanup := setupReloadTest(t)
+	defer cleanup()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				// a reader sees either the previous or the reloaded settings, never a mix of both
+				settings := ReloadableNodeConfig()
+				level := settings.GetString(logger.ViperKeyLevel)
+				cpuMaxUsage := settings.GetFloat64(CfgSpammerCPUMaxUsage)
+				assert.True(t, (level == "info" && cpuMaxUsage == 0.5) || (level == "debug" && cpuMaxUsage == 0.8), "%s %v", level, cpuMaxUsage)
+
+				var peers []PeerConfig
+				assert.NoError(t, ReloadablePeeringConfig().UnmarshalKey(CfgPeers, &peers))
+				assert.Len(t, peers, 1)
+
+				assert.Equal(t, "0.0.0.0:14265", NodeConfig.GetString(CfgWebAPIBindAddress))
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if i%2 == 0 {
+			writeConfigs("debug", 0.8, "example2.neighbor.com:15600")
+		} else {
+			writeConfigs("info", 0.5, "example1.neighbor.com:15600")
+		}
+		require.NoError(t, Reload())
+	}
+
+	close(done)
+	wg.Wait()
+}
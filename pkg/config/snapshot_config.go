@@ -13,10 +13,18 @@ const (
 	CfgLocalSnapshotsIntervalSynced = "snapshots.local.intervalSynced"
 	// interval, in milestone transactions, at which snapshot files are created if the ledger is not fully synchronized
 	CfgLocalSnapshotsIntervalUnsynced = "snapshots.local.intervalUnsynced"
+	// the cron expressions or times of day (HH:MM) at which snapshot files are created instead of the intervals
+	CfgLocalSnapshotsSchedule = "snapshots.local.schedule"
 	// path to the local snapshot file
 	CfgLocalSnapshotsPath = "snapshots.local.path"
+	// path to the delta local snapshot file, which contains the changes since the local snapshot file
+	CfgLocalSnapshotsDeltaPath = "snapshots.local.deltaPath"
+	// the size of the ledger changes relative to the ledger of the local snapshot file, at which a new full local snapshot is created instead of a delta (0 = no delta snapshots)
+	CfgLocalSnapshotsDeltaSizeThresholdPercentage = "snapshots.local.deltaSizeThresholdPercentage"
 	// URL to load the local snapshot file from
 	CfgLocalSnapshotsDownloadURLs = "snapshots.local.downloadURLs"
+	// hex encoded ed25519 public keys of which one has to sign the checksum of downloaded local snapshot files
+	CfgLocalSnapshotsDownloadPublicKeys = "snapshots.local.downloadPublicKeys"
 	// path to the global snapshot file containing the ledger state
 	CfgGlobalSnapshotPath = "snapshots.global.path"
 	// paths to the spent addresses files
@@ -25,8 +33,16 @@ const (
 	CfgGlobalSnapshotIndex = "snapshots.global.index"
 	// whether to delete old transaction data from the database
 	CfgPruningEnabled = "snapshots.pruning.enabled"
-	// amount of milestone transactions to keep in the database
+	// amount of milestone transactions to keep in the database (0 = no retention by milestones)
 	CfgPruningDelay = "snapshots.pruning.delay"
+	// the age in hours after which milestones are deleted from the database (0 = no retention by age)
+	CfgPruningMaxAgeHours = "snapshots.pruning.maxAgeHours"
+	// the size of the data in the database in megabytes, above which old milestones are deleted from the database (0 = no retention by size)
+	CfgPruningTargetDatabaseSizeMB = "snapshots.pruning.targetDatabaseSizeMB"
+	// amount of the newest milestones which are never deleted by the retention by age or size
+	CfgPruningMinDelay = "snapshots.pruning.minDelay"
+	// the cron expressions or times of day (HH:MM) at which the database is pruned instead of at every solid milestone
+	CfgPruningSchedule = "snapshots.pruning.schedule"
 	// enable support for wereAddressesSpentFrom (needed for Trinity, but local snapshots are much bigger)
 	CfgSpentAddressesEnabled = "spentAddresses.enabled"
 )
@@ -36,8 +52,12 @@ func init() {
 	flag.Int(CfgLocalSnapshotsDepth, 50, "the depth, respectively the starting point, at which a local snapshot of the ledger is generated")
 	flag.Int(CfgLocalSnapshotsIntervalSynced, 50, "interval, in milestone transactions, at which snapshot files are created if the ledger is fully synchronized")
 	flag.Int(CfgLocalSnapshotsIntervalUnsynced, 1000, "interval, in milestone transactions, at which snapshot files are created if the ledger is not fully synchronized")
+	flag.StringSlice(CfgLocalSnapshotsSchedule, []string{}, "the cron expressions or times of day (HH:MM) in local time at which snapshot files are created instead of the intervals (e.g. \"03:00\" or \"30 2 * * 6,0\")")
 	flag.String(CfgLocalSnapshotsPath, "snapshots/mainnet/export.bin", "path to the local snapshot file")
+	flag.String(CfgLocalSnapshotsDeltaPath, "snapshots/mainnet/delta_export.bin", "path to the delta local snapshot file, which contains the changes since the local snapshot file")
+	flag.Float64(CfgLocalSnapshotsDeltaSizeThresholdPercentage, 50.0, "the size of the ledger changes relative to the ledger of the local snapshot file, at which a new full local snapshot is created instead of a delta (0 = no delta snapshots)")
 	flag.StringSlice(CfgLocalSnapshotsDownloadURLs, []string{}, "URLs to load the local snapshot file from. Provide multiple URLs as fall back sources")
+	flag.StringSlice(CfgLocalSnapshotsDownloadPublicKeys, []string{}, "hex encoded ed25519 public keys of which one has to sign the checksum of downloaded local snapshot files (no signature check if empty)")
 	flag.String(CfgGlobalSnapshotPath, "snapshotMainnet.txt", "path to the global snapshot file containing the ledger state")
 	flag.StringSlice(CfgGlobalSnapshotSpentAddressesPaths, []string{
 		"previousEpochsSpentAddresses1.txt",
@@ -46,6 +66,10 @@ func init() {
 	}, "paths to the spent addresses files")
 	flag.Int(CfgGlobalSnapshotIndex, 1050000, "milestone index of the global snapshot")
 	flag.Bool(CfgPruningEnabled, true, "whether to delete old transaction data from the database")
-	flag.Int(CfgPruningDelay, 40000, "amount of milestone transactions to keep in the database")
+	flag.Int(CfgPruningDelay, 40000, "amount of milestone transactions to keep in the database (0 = no retention by milestones)")
+	flag.Int(CfgPruningMaxAgeHours, 0, "the age in hours after which milestones are deleted from the database (0 = no retention by age)")
+	flag.Int(CfgPruningTargetDatabaseSizeMB, 0, "the size of the data in the database in megabytes, above which old milestones are deleted from the database (0 = no retention by size)")
+	flag.Int(CfgPruningMinDelay, 1000, "amount of the newest milestones which are never deleted by the retention by age or size")
+	flag.StringSlice(CfgPruningSchedule, []string{}, "the cron expressions or times of day (HH:MM) in local time at which the database is pruned instead of at every solid milestone")
 	flag.Bool(CfgSpentAddressesEnabled, true, "enable support for wereAddressesSpentFrom (needed for Trinity, but local snapshots are much bigger)")
 }
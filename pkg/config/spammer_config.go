@@ -16,7 +16,12 @@ const (
 	// workers remains idle for a while when cpu usage gets over this limit (0 = disable)
 	CfgSpammerCPUMaxUsage = "spammer.cpuMaxUsage"
 	// the rate limit for the spammer (0 = no limit)
+	// the rate is reduced automatically while the node is unhealthy
 	CfgSpammerTPSRateLimit = "spammer.tpsRateLimit"
+	// the size of the request queue above which the spammer reduces its rate (0 = disable)
+	CfgSpammerMaxRequestQueueSize = "spammer.maxRequestQueueSize"
+	// whether the spammer starts together with the node, otherwise it has to be started via the API
+	CfgSpammerAutostart = "spammer.autostart"
 	// the size of the spam bundles
 	CfgSpammerBundleSize = "spammer.bundleSize"
 	// should be spammed with value bundles
@@ -35,6 +40,8 @@ func init() {
 	flag.String(CfgSpammerTagSemiLazy, "", "the tag of the transaction if the semi-lazy pool is used (uses \"tag\" if empty)")
 	flag.Float64(CfgSpammerCPUMaxUsage, 0.50, "workers remains idle for a while when cpu usage gets over this limit (0 = disable)")
 	flag.Float64(CfgSpammerTPSRateLimit, 0.10, "the rate limit for the spammer (0 = no limit)")
+	flag.Int(CfgSpammerMaxRequestQueueSize, 1000, "the size of the request queue above which the spammer reduces its rate (0 = disable)")
+	flag.Bool(CfgSpammerAutostart, true, "whether the spammer starts together with the node, otherwise it has to be started via the API")
 	flag.Int(CfgSpammerBundleSize, 1, "the size of the spam bundles")
 	flag.Bool(CfgSpammerValueSpam, false, "should be spammed with value bundles")
 	flag.Int(CfgSpammerWorkers, 1, "the amount of parallel running spammers")
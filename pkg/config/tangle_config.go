@@ -7,11 +7,29 @@ import (
 const (
 	// the path to the database folder
 	CfgDatabasePath = "db.path"
+	// the used database engine (bolt or badger)
+	CfgDatabaseEngine = "db.engine"
+	// the storage medium the database is stored on, used to tune the database engine (ssd or hdd)
+	CfgDatabaseStorageMedium = "db.storageMedium"
+	// the pause between the garbage collection rounds of an online database compaction in milliseconds
+	CfgDatabaseCompactionThrottleMilliseconds = "db.compaction.throttleMilliseconds"
+	// the path to the folder the online database backups are written to
+	CfgDatabaseBackupPath = "db.backup.path"
 	// ignore the check for corrupted databases (should only be used for debug reasons)
 	CfgDatabaseDebug = "db.debug"
+	// the cache times in milliseconds per cache, which override the ones of the profile (e.g. transactions=60000)
+	CfgDatabaseCacheTimeMilliseconds = "db.cacheTimeMilliseconds"
+	// the maximum amount of objects per cache, above which released objects are evicted without waiting for the cache time (e.g. transactions=50000)
+	CfgDatabaseCacheSizes = "db.cacheSizes"
 )
 
 func init() {
 	flag.String(CfgDatabasePath, "mainnetdb", "the path to the database folder")
+	flag.String(CfgDatabaseEngine, "bolt", "the used database engine (bolt or badger)")
+	flag.String(CfgDatabaseStorageMedium, "ssd", "the storage medium the database is stored on, used to tune the database engine (ssd or hdd)")
+	flag.Int(CfgDatabaseCompactionThrottleMilliseconds, 500, "the pause between the garbage collection rounds of an online database compaction in milliseconds")
+	flag.String(CfgDatabaseBackupPath, "backups", "the path to the folder the online database backups are written to")
 	flag.Bool(CfgDatabaseDebug, false, "ignore the check for corrupted databases (should only be used for debug reasons)")
+	flag.StringToString(CfgDatabaseCacheTimeMilliseconds, map[string]string{}, "the cache times in milliseconds per cache, which override the ones of the profile (e.g. transactions=60000)")
+	flag.StringToString(CfgDatabaseCacheSizes, map[string]string{}, "the maximum amount of objects per cache, above which released objects are evicted without waiting for the cache time (e.g. transactions=50000)")
 }
@@ -5,6 +5,11 @@ import (
 )
 
 const (
+	// CfgTipSelAlgorithm is the tip-selection algorithm used for new transactions ("urts" or "walk").
+	CfgTipSelAlgorithm = "tipsel.algorithm"
+	// CfgTipSelWalkDefaultDepth is the amount of milestones below the LSMI the walk starts at
+	// if no depth was requested.
+	CfgTipSelWalkDefaultDepth = "tipsel.walk.defaultDepth"
 	// CfgTipSelMaxDeltaTxYoungestRootSnapshotIndexToLSMI is the maximum allowed delta
 	// value for the YTRSI of a given transaction in relation to the current LSMI before it gets lazy.
 	CfgTipSelMaxDeltaTxYoungestRootSnapshotIndexToLSMI = "tipsel.maxDeltaTxYoungestRootSnapshotIndexToLSMI"
@@ -31,6 +36,8 @@ const (
 )
 
 func init() {
+	flag.String(CfgTipSelAlgorithm, "urts", "the tip-selection algorithm used for new transactions (\"urts\" or \"walk\")")
+	flag.Int(CfgTipSelWalkDefaultDepth, 3, "the amount of milestones below the LSMI the walk starts at if no depth was requested")
 	flag.Int(CfgTipSelMaxDeltaTxYoungestRootSnapshotIndexToLSMI, 8, "the maximum allowed delta "+
 		"value for the YTRSI of a given transaction in relation to the current LSMI before it gets lazy")
 	flag.Int(CfgTipSelMaxDeltaTxOldestRootSnapshotIndexToLSMI, 13, "the maximum allowed delta "+
@@ -0,0 +1,397 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/gohornet/hornet/pkg/schedule"
+)
+
+var (
+	// the settings which are read from the config file, but are not available as CLI flag
+	additionalSettings = []string{
+		logger.ViperKeyLevel,
+		logger.ViperKeyDisableCaller,
+		logger.ViperKeyDisableStacktrace,
+		logger.ViperKeyEncoding,
+		logger.ViperKeyOutputPaths,
+		logger.ViperKeyDisableEvents,
+		CfgNotificationsWebhooks,
+	}
+
+	// the settings which contain user defined keys
+	freeFormSettings = []string{
+		CfgNetProfiles,
+	}
+
+	// the allowed ranges of numeric settings
+	valueRanges = map[string]valueRange{
+		CfgCoordinatorSecurityLevel:                             between(1, 3),
+		CfgCoordinatorMWM:                                       between(1, 243),
+		CfgCoordinatorIntervalSeconds:                           atLeast(1),
+		CfgFaucetSecurityLevel:                                  between(1, 3),
+		CfgFaucetMaxOutputsPerBundle:                            atLeast(1),
+		CfgHealthMinConnectedNeighbors:                          atLeast(0),
+		CfgMQTTBridgeQoS:                                        between(0, 2),
+		CfgPeeringMaxPeers:                                      atLeast(0),
+		CfgNetAutopeeringMaxDroppedPacketsPercentage:            between(0, 100),
+		CfgNetGossipStalenessMaxHeartbeatAgeSeconds:             atLeast(0),
+		CfgNetGossipStalenessMaxStaleTransactionsPercentage:     between(0, 100),
+		CfgNetGossipStalenessWarnAfterChecks:                    atLeast(0),
+		CfgNetGossipPendingBroadcastsRebroadcastIntervalSeconds: atLeast(1),
+		CfgNetGossipPendingBroadcastsMaxAgeMinutes:              atLeast(1),
+		CfgNetGossipBandwidthMonthlyBudgetMegabytes:             atLeast(0),
+		CfgNetGossipBandwidthResetDay:                           between(1, 28),
+		CfgNetGossipBandwidthThrottleThresholdPercentage:        between(0, 100),
+		CfgNetGossipReconnectAttemptIntervalSeconds:             atLeast(1),
+		CfgPoWWorkers:                                           atLeast(1),
+		CfgPoWParallelism:                                       atLeast(0),
+		CfgLocalSnapshotsDepth:                                  atLeast(1),
+		CfgLocalSnapshotsIntervalSynced:                         atLeast(1),
+		CfgLocalSnapshotsIntervalUnsynced:                       atLeast(1),
+		CfgLocalSnapshotsDeltaSizeThresholdPercentage:           between(0, 100),
+		CfgPruningDelay:                                         atLeast(0),
+		CfgPruningMinDelay:                                      atLeast(0),
+		CfgSpammerCPUMaxUsage:                                   between(0, 1),
+		CfgSpammerBundleSize:                                    atLeast(1),
+		CfgTipSelWalkDefaultDepth:                               atLeast(1),
+		CfgTipSelBelowMaxDepth:                                  atLeast(1),
+		CfgWarpSyncAdvancementRange:                             atLeast(1),
+		CfgWebAPIRateLimitRequestsPerSecond:                     atLeast(0),
+		CfgWebAPIRateLimitBurst:                                 atLeast(1),
+		CfgWebAPILimitsMaxBodyLengthBytes:                       atLeast(1),
+		CfgWebAPILimitsMaxFindTransactions:                      atLeast(1),
+		CfgWebAPILimitsMaxGetTrytes:                             atLeast(1),
+		CfgWebAPILimitsMaxRequestsList:                          atLeast(1),
+		CfgDatabaseCompactionThrottleMilliseconds:               atLeast(0),
+		CfgNodeShutdownDrainTimeoutSeconds:                      atLeast(0),
+		CfgNodeUpdateCheckIntervalMinutes:                       atLeast(1),
+		CfgNodeShutdownReadinessDelaySeconds:                    atLeast(0),
+		CfgNotificationsTimeoutSeconds:                          atLeast(1),
+		CfgWebAPISubscriptionsMaxEvents:                         atLeast(1),
+		CfgLoggerRotationMaxBackups:                             atLeast(0),
+		CfgNetGossipLimitsInboundTransactionsPerSecond:          atLeast(0),
+		CfgPrometheusPushIntervalSeconds:                        atLeast(1),
+		CfgPrometheusPushBatchSize:                              atLeast(1),
+		CfgPrometheusPushMaxBufferedSamples:                     atLeast(1),
+		CfgPrometheusPushMaxRetries:                             atLeast(0),
+		CfgPrometheusPushTimeoutSeconds:                         atLeast(1),
+	}
+
+	// the allowed values of settings with a fixed set of options
+	allowedValues = map[string][]string{
+		CfgDatabaseStorageMedium: {"ssd", "hdd"},
+	}
+
+	// the settings which contain cron expressions or times of day
+	scheduleSettings = map[string]struct{}{
+		CfgLocalSnapshotsSchedule: {},
+		CfgPruningSchedule:        {},
+	}
+)
+
+// valueRange is the allowed range of a numeric setting.
+type valueRange struct {
+	min    float64
+	max    float64
+	hasMax bool
+}
+
+func atLeast(min float64) valueRange {
+	return valueRange{min: min}
+}
+
+func between(min float64, max float64) valueRange {
+	return valueRange{min: min, max: max, hasMax: true}
+}
+
+func (r valueRange) contains(value float64) bool {
+	return value >= r.min && (!r.hasMax || value <= r.max)
+}
+
+func (r valueRange) String() string {
+	if !r.hasMax {
+		return fmt.Sprintf("at least %v", r.min)
+	}
+	return fmt.Sprintf("between %v and %v", r.min, r.max)
+}
+
+// ValidationError is a problem in the configuration of the node.
+type ValidationError struct {
+	// The environment variable which sets the setting, empty if it was not set by an environment variable.
+	Env string
+	// The config file which contains the setting, empty if it was not set in a config file.
+	File string
+	// The line of the setting in the config file, 0 if unknown.
+	Line int
+	// The key of the setting.
+	Key string
+	// The description of the problem.
+	Message string
+	// Whether the problem is only reported, but doesn't prevent the node from starting.
+	Warning bool
+}
+
+func (e *ValidationError) Error() string {
+	switch {
+	case e.Env != "" && e.Key != "":
+		return fmt.Sprintf("environment variable %s ('%s') %s", e.Env, e.Key, e.Message)
+	case e.Env != "":
+		return fmt.Sprintf("environment variable %s %s", e.Env, e.Message)
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%s:%d: '%s' %s", e.File, e.Line, e.Key, e.Message)
+	case e.File != "":
+		return fmt.Sprintf("%s: '%s' %s", e.File, e.Key, e.Message)
+	default:
+		return fmt.Sprintf("'%s' %s", e.Key, e.Message)
+	}
+}
+
+// ValidateConfig checks the node config file, the environment variables and the CLI flags for unknown settings,
+// values of the wrong type or out of range, and for plugins which are unknown or enabled and disabled at the same time.
+// knownPlugins are the identifiers of all plugins of the node.
+func ValidateConfig(knownPlugins []string) []*ValidationError {
+	return validateConfig(NodeConfig, NodeConfig.ConfigFileUsed(), os.Environ(), knownPlugins)
+}
+
+func validateConfig(settings *viper.Viper, configFile string, environ []string, knownPlugins []string) []*ValidationError {
+	v := &validator{settings: settings}
+
+	var unknownEnv []string
+	v.envSettings, unknownEnv = envSettings(environ)
+	for _, name := range unknownEnv {
+		// other software may use variables with the same prefix, e.g. for a container called "hornet"
+		v.errors = append(v.errors, &ValidationError{Env: name, Message: "is an unknown setting", Warning: true})
+	}
+
+	if configFile != "" {
+		if err := v.loadConfigFile(configFile); err != nil {
+			return []*ValidationError{{File: configFile, Message: err.Error()}}
+		}
+	}
+
+	v.checkUnknownSettings()
+	v.checkValues()
+	v.checkPlugins(knownPlugins)
+
+	sort.SliceStable(v.errors, func(i int, j int) bool {
+		if v.errors[i].Line == 0 || v.errors[j].Line == 0 {
+			return v.errors[i].Line > v.errors[j].Line
+		}
+		return v.errors[i].Line < v.errors[j].Line
+	})
+
+	return v.errors
+}
+
+// validator collects the problems of a configuration.
+type validator struct {
+	settings *viper.Viper
+
+	// the settings which are set by environment variables
+	envSettings map[string]string
+
+	// the config file and its content
+	file      string
+	lines     []string
+	fileKeys  []string
+	fileValue *viper.Viper
+
+	errors []*ValidationError
+}
+
+func (v *validator) loadConfigFile(configFile string) error {
+	content, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+
+	fileValue := viper.New()
+	fileValue.SetConfigType(strings.TrimPrefix(filepath.Ext(configFile), "."))
+	if err := fileValue.ReadConfig(strings.NewReader(string(content))); err != nil {
+		return fmt.Errorf("unable to parse the config file: %w", err)
+	}
+
+	v.file = configFile
+	v.lines = strings.Split(string(content), "\n")
+	v.fileValue = fileValue
+	v.fileKeys = fileValue.AllKeys()
+	sort.Strings(v.fileKeys)
+	return nil
+}
+
+func (v *validator) add(key string, format string, args ...interface{}) {
+	err := &ValidationError{Key: key, Message: fmt.Sprintf(format, args...)}
+	if name, exists := v.envSettings[key]; exists {
+		err.Env = name
+	} else if v.fileValue != nil && v.fileValue.IsSet(key) {
+		err.File = v.file
+		err.Line, err.Key = findKeyLine(v.lines, key)
+	}
+	v.errors = append(v.errors, err)
+}
+
+// checkUnknownSettings reports the settings in the config file which are not used by the node, e.g. because of a typo.
+func (v *validator) checkUnknownSettings() {
+	known := make(map[string]struct{})
+	flag.VisitAll(func(f *flag.Flag) {
+		known[strings.ToLower(f.Name)] = struct{}{}
+	})
+	for _, key := range additionalSettings {
+		known[strings.ToLower(key)] = struct{}{}
+	}
+
+	freeForm := make(map[string]struct{})
+	for _, key := range freeFormSettings {
+		freeForm[strings.ToLower(key)] = struct{}{}
+	}
+
+	for _, key := range v.fileKeys {
+		if isKnownSetting(key, known, freeForm) {
+			continue
+		}
+		v.add(key, "is an unknown setting")
+	}
+}
+
+// isKnownSetting checks whether the key or one of its parents is a known setting,
+// the children of settings like maps are not known in advance.
+func isKnownSetting(key string, known map[string]struct{}, freeForm map[string]struct{}) bool {
+	parts := strings.Split(key, ".")
+	for i := len(parts); i > 0; i-- {
+		prefix := strings.Join(parts[:i], ".")
+		if _, exists := known[prefix]; exists {
+			return true
+		}
+		if _, exists := freeForm[prefix]; exists && i < len(parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkValues reports values which can't be converted to the type of the setting or are out of range.
+func (v *validator) checkValues() {
+	flag.VisitAll(func(f *flag.Flag) {
+		if !v.settings.IsSet(f.Name) {
+			return
+		}
+		value := v.settings.Get(f.Name)
+
+		var err error
+		var number *float64
+		switch f.Value.Type() {
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+			var i int64
+			if i, err = cast.ToInt64E(value); err == nil {
+				n := float64(i)
+				number = &n
+			}
+		case "float32", "float64":
+			var n float64
+			if n, err = cast.ToFloat64E(value); err == nil {
+				number = &n
+			}
+		case "bool":
+			_, err = cast.ToBoolE(value)
+		case "duration":
+			_, err = cast.ToDurationE(value)
+		case "stringSlice":
+			_, err = cast.ToStringSliceE(value)
+		case "stringToString":
+			_, err = cast.ToStringMapStringE(value)
+		case "string":
+			_, err = cast.ToStringE(value)
+		}
+
+		if err != nil {
+			v.add(f.Name, "has an invalid value %v, expected a value of type %s", value, f.Value.Type())
+			return
+		}
+
+		if r, exists := valueRanges[f.Name]; exists && number != nil && !r.contains(*number) {
+			v.add(f.Name, "is out of range, it is %v but must be %s", *number, r)
+		}
+
+		if allowed, exists := allowedValues[f.Name]; exists && !containsIgnoreCase(allowed, cast.ToString(value)) {
+			v.add(f.Name, "has an invalid value '%v', allowed values are %s", value, strings.Join(allowed, ", "))
+		}
+
+		if _, isSchedule := scheduleSettings[f.Name]; isSchedule {
+			if _, err := schedule.Parse(cast.ToStringSlice(value)); err != nil {
+				v.add(f.Name, "contains an %v", err)
+			}
+		}
+	})
+}
+
+// checkPlugins reports unknown plugins and plugins which are enabled and disabled at the same time.
+func (v *validator) checkPlugins(knownPlugins []string) {
+	enabled := v.settings.GetStringSlice(node.CFG_ENABLE_PLUGINS)
+	disabled := v.settings.GetStringSlice(node.CFG_DISABLE_PLUGINS)
+
+	if len(knownPlugins) > 0 {
+		for _, plugin := range enabled {
+			if !containsIgnoreCase(knownPlugins, plugin) {
+				v.add(node.CFG_ENABLE_PLUGINS, "contains the unknown plugin '%s'", plugin)
+			}
+		}
+		for _, plugin := range disabled {
+			if !containsIgnoreCase(knownPlugins, plugin) {
+				v.add(node.CFG_DISABLE_PLUGINS, "contains the unknown plugin '%s'", plugin)
+			}
+		}
+	}
+
+	for _, plugin := range enabled {
+		if containsIgnoreCase(disabled, plugin) {
+			v.add(node.CFG_DISABLE_PLUGINS, "disables the plugin '%s', which is enabled in '%s' as well", plugin, node.CFG_ENABLE_PLUGINS)
+		}
+	}
+}
+
+func containsIgnoreCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// findKeyLine returns the line of the given key in the lines of a JSON, YAML or TOML config file, or 0 if it was not found.
+// The parts of the key are searched in order, so that settings with the same name in different sections are distinguished.
+// The key is returned as written in the file, since the keys are case insensitive.
+func findKeyLine(lines []string, key string) (int, string) {
+	line := 0
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		// matches `"part":` (JSON), `part:` (YAML), `part =` and `[section.part]` (TOML)
+		partRegex := regexp.MustCompile(`(?i)(?:^|[\s"'\[.{,])(` + regexp.QuoteMeta(part) + `)(?:["']?\s*[:=]|[\].])`)
+
+		found := false
+		for ; line < len(lines); line++ {
+			if match := partRegex.FindStringSubmatch(lines[line]); match != nil {
+				parts[i] = match[1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, key
+		}
+	}
+	return line + 1, strings.Join(parts, ".")
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidNodeConfig = `{
+  "httpAPI": {
+    "bindAddres": "0.0.0.0:14265",
+    "limits": {
+      "getTrytes": "many"
+    }
+  },
+  "db": {
+    "storageMedium": "tape"
+  },
+  "coordinator": {
+    "mwm": 300
+  },
+  "network": {
+    "profiles": {
+      "private-tangle": {
+        "coordinator": {
+          "mwm": 5
+        }
+      }
+    }
+  },
+  "node": {
+    "enablePlugins": ["Spammer", "Unknown"],
+    "disablePlugins": ["spammer"]
+  }
+}`
+
+func TestValidateConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(invalidNodeConfig), 0600))
+
+	settings := viper.New()
+	settings.SetConfigFile(configFile)
+	require.NoError(t, settings.ReadInConfig())
+
+	var messages []string
+	for _, err := range validateConfig(settings, configFile, nil, []string{"spammer", "coordinator"}) {
+		assert.Equal(t, configFile, err.File)
+		messages = append(messages, err.Error()[len(configFile):])
+	}
+
+	assert.Equal(t, []string{
+		":3: 'httpAPI.bindAddres' is an unknown setting",
+		":5: 'httpAPI.limits.getTrytes' has an invalid value many, expected a value of type int",
+		":9: 'db.storageMedium' has an invalid value 'tape', allowed values are ssd, hdd",
+		":12: 'coordinator.mwm' is out of range, it is 300 but must be between 1 and 243",
+		":24: 'node.enablePlugins' contains the unknown plugin 'Unknown'",
+		":25: 'node.disablePlugins' disables the plugin 'Spammer', which is enabled in 'node.enablePlugins' as well",
+	}, messages)
+}
+
+func TestFindKeyLine(t *testing.T) {
+	yaml := []string{
+		"coordinator:",
+		"  mwm: 14",
+		"snapshots:",
+		"  local:",
+		"    path: export.bin",
+		"  global:",
+		"    path: snapshot.txt",
+	}
+	line, key := findKeyLine(yaml, "snapshots.global.path")
+	assert.Equal(t, 7, line)
+	assert.Equal(t, "snapshots.global.path", key)
+
+	toml := []string{
+		"[coordinator]",
+		"mwm = 14",
+		"[snapshots.local]",
+		"path = \"export.bin\"",
+	}
+	line, _ = findKeyLine(toml, "snapshots.local.path")
+	assert.Equal(t, 4, line)
+
+	line, key = findKeyLine(toml, "snapshots.global.path")
+	assert.Equal(t, 0, line)
+	assert.Equal(t, "snapshots.global.path", key)
+}
+
+func TestValidateConfigEnvironment(t *testing.T) {
+	settings := viper.New()
+	settings.Set(CfgCoordinatorMWM, "many")
+
+	environ := []string{
+		"HORNET_COORDINATOR_MWM=many",
+		"HORNET_COORDINATOR_MVM=14",
+		"PATH=/bin",
+		// set by Kubernetes for a service called "hornet"
+		"HORNET_SERVICE_HOST=10.0.0.1",
+		"HORNET_SERVICE_PORT=14265",
+		"HORNET_SERVICE_PORT_API=14265",
+		"HORNET_PORT=tcp://10.0.0.1:14265",
+		"HORNET_PORT_14265_TCP=tcp://10.0.0.1:14265",
+		"HORNET_PORT_14265_TCP_PROTO=tcp",
+		"HORNET_PORT_14265_TCP_PORT=14265",
+		"HORNET_PORT_14265_TCP_ADDR=10.0.0.1",
+		"HORNET_DASHBOARD_SERVICE_HOST=10.0.0.2",
+	}
+
+	var messages []string
+	var warnings []string
+	for _, err := range validateConfig(settings, "", environ, nil) {
+		if err.Warning {
+			warnings = append(warnings, err.Error())
+			continue
+		}
+		messages = append(messages, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"environment variable HORNET_COORDINATOR_MWM ('coordinator.mwm') has an invalid value many, expected a value of type int",
+	}, messages)
+
+	// unknown variables with the prefix are no reason to not start the node
+	assert.Equal(t, []string{
+		"environment variable HORNET_COORDINATOR_MVM is an unknown setting",
+	}, warnings)
+}
+
+func TestValidateConfigSchedule(t *testing.T) {
+	settings := viper.New()
+	settings.Set(CfgLocalSnapshotsSchedule, []string{"03:00", "30 2 * * 6,0"})
+	settings.Set(CfgPruningSchedule, []string{"3pm"})
+
+	var messages []string
+	for _, err := range validateConfig(settings, "", nil, nil) {
+		messages = append(messages, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"'snapshots.pruning.schedule' contains an invalid schedule '3pm': expected a time of day (HH:MM) or a cron expression with 5 fields",
+	}, messages)
+}
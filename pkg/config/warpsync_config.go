@@ -7,8 +7,20 @@ import (
 const (
 	// the used advancement range per warpsync checkpoint
 	CfgWarpSyncAdvancementRange = "warpsync.advancementRange"
+	// whether to request whole milestone cones from neighbors which support it instead of single transactions
+	CfgWarpSyncBulkRequestsEnabled = "warpsync.bulkRequests.enabled"
+	// the amount of milestones whose cones are requested with a single request
+	CfgWarpSyncBulkRequestsBatchSize = "warpsync.bulkRequests.batchSize"
+	// the maximum amount of milestone cone requests which are pending at the same time
+	CfgWarpSyncBulkRequestsParallelRequests = "warpsync.bulkRequests.parallelRequests"
+	// the time in seconds after which the milestones of an incomplete milestone cone request are requested transaction by transaction
+	CfgWarpSyncBulkRequestsTimeoutSeconds = "warpsync.bulkRequests.timeoutSeconds"
 )
 
 func init() {
 	flag.Int(CfgWarpSyncAdvancementRange, 50, "the used advancement range per warpsync checkpoint")
+	flag.Bool(CfgWarpSyncBulkRequestsEnabled, false, "whether to request whole milestone cones from neighbors which support it instead of single transactions")
+	flag.Int(CfgWarpSyncBulkRequestsBatchSize, 5, "the amount of milestones whose cones are requested with a single request")
+	flag.Int(CfgWarpSyncBulkRequestsParallelRequests, 4, "the maximum amount of milestone cone requests which are pending at the same time")
+	flag.Int(CfgWarpSyncBulkRequestsTimeoutSeconds, 30, "the time in seconds after which the milestones of an incomplete milestone cone request are requested transaction by transaction")
 }
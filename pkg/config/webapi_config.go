@@ -11,6 +11,10 @@ const (
 	CfgWebAPIPermitRemoteAccess = "httpAPI.permitRemoteAccess"
 	// the whitelist of addresses which are allowed to access the HTTP API
 	CfgWebAPIWhitelistedAddresses = "httpAPI.whitelistedAddresses"
+	// the networks (CIDR) or addresses which are allowed to connect to the HTTP API (empty = all)
+	CfgWebAPIIPFilterAllowedNetworks = "httpAPI.ipFilter.allowedNetworks"
+	// the networks (CIDR) or addresses which are not allowed to connect to the HTTP API
+	CfgWebAPIIPFilterDeniedNetworks = "httpAPI.ipFilter.deniedNetworks"
 	// whether to allow the health check route anyways
 	CfgWebAPIExcludeHealthCheckFromAuth = "httpAPI.excludeHealthCheckFromAuth"
 	// whether to use HTTP basic auth for the HTTP API
@@ -21,14 +25,58 @@ const (
 	CfgWebAPIBasicAuthPasswordHash = "httpapi.basicauth.passwordhash" // must be lower cased
 	// the HTTP basic auth salt used for hashing the password
 	CfgWebAPIBasicAuthPasswordSalt = "httpapi.basicauth.passwordsalt" // must be lower cased
+	// whether to use JWT auth for the protected HTTP API commands
+	CfgWebAPIJWTAuthEnabled = "httpAPI.jwtAuth.enabled"
+	// the secret used to sign the issued JWTs
+	CfgWebAPIJWTAuthSecret = "httpapi.jwtauth.secret" // must be lower cased
+	// the validity of the issued JWTs in minutes (0 = no expiry)
+	CfgWebAPIJWTAuthSessionTimeoutMinutes = "httpAPI.jwtAuth.sessionTimeoutMinutes"
+	// the HTTP API commands which can only be called with a valid JWT or from a whitelisted address
+	CfgWebAPIJWTAuthProtectedCommands = "httpAPI.jwtAuth.protectedCommands"
+	// whether the HTTP API is served via TLS
+	CfgWebAPITLSEnabled = "httpAPI.tls.enabled"
+	// the path to the TLS certificate of the HTTP API
+	CfgWebAPITLSCertPath = "httpAPI.tls.certPath"
+	// the path to the TLS private key of the HTTP API
+	CfgWebAPITLSKeyPath = "httpAPI.tls.keyPath"
+	// whether to rate limit the requests of non whitelisted addresses
+	CfgWebAPIRateLimitEnabled = "httpAPI.rateLimit.enabled"
+	// the allowed requests per second per IP address
+	CfgWebAPIRateLimitRequestsPerSecond = "httpAPI.rateLimit.requestsPerSecond"
+	// the maximum burst of requests per IP address
+	CfgWebAPIRateLimitBurst = "httpAPI.rateLimit.burst"
+	// the allowed calls per second per IP address of specific HTTP API commands
+	CfgWebAPIRateLimitCommands = "httpAPI.rateLimit.commands"
+	// whether to serve the public WebSocket event stream
+	CfgWebAPIWebSocketEnabled = "httpAPI.webSocket.enabled"
+	// the maximum number of concurrently connected WebSocket clients
+	CfgWebAPIWebSocketMaxClients = "httpAPI.webSocket.maxClients"
+	// the maximum number of address and tag filters per WebSocket subscription
+	CfgWebAPIWebSocketMaxFilters = "httpAPI.webSocket.maxFilters"
+	// the maximum number of queued messages per WebSocket client before messages get dropped
+	CfgWebAPIWebSocketSendQueueSize = "httpAPI.webSocket.sendQueueSize"
+	// whether clients can register address and tag subscriptions and fetch their missed events
+	CfgWebAPISubscriptionsEnabled = "httpAPI.subscriptions.enabled"
+	// the maximum number of registered subscriptions
+	CfgWebAPISubscriptionsMaxSubscriptions = "httpAPI.subscriptions.maxSubscriptions"
+	// the maximum number of address and tag filters per subscription
+	CfgWebAPISubscriptionsMaxFilters = "httpAPI.subscriptions.maxFilters"
+	// the maximum number of events retained per subscription
+	CfgWebAPISubscriptionsMaxEvents = "httpAPI.subscriptions.maxEvents"
+	// the time in minutes after which subscriptions whose events were not fetched are removed
+	CfgWebAPISubscriptionsExpiryMinutes = "httpAPI.subscriptions.expiryMinutes"
 	// the maximum number of characters that the body of an API call may contain
 	CfgWebAPILimitsMaxBodyLengthBytes = "httpAPI.limits.bodyLengthBytes"
 	// the maximum number of transactions that may be returned by the findTransactions endpoint
 	CfgWebAPILimitsMaxFindTransactions = "httpAPI.limits.findTransactions"
+	// the maximum number of transactions that may be streamed by the findTransactions endpoint
+	CfgWebAPILimitsMaxFindTransactionsStream = "httpAPI.limits.findTransactionsStream"
 	// the maximum number of trytes that may be returned by the getTrytes endpoint
 	CfgWebAPILimitsMaxGetTrytes = "httpAPI.limits.getTrytes"
 	// the maximum number of parameters in an API call
 	CfgWebAPILimitsMaxRequestsList = "httpAPI.limits.requestsList"
+	// the maximum number of results of specific HTTP API commands, truncated results can be continued (0 = unlimited)
+	CfgWebAPILimitsResults = "httpAPI.limits.results"
 )
 
 func init() {
@@ -47,13 +95,66 @@ func init() {
 			"getTrytes",
 		}, "the allowed HTTP API calls which can be called from non whitelisted addresses")
 	flag.StringSlice(CfgWebAPIWhitelistedAddresses, []string{}, "the whitelist of addresses which are allowed to access the HTTP API")
+	flag.StringSlice(CfgWebAPIIPFilterAllowedNetworks, []string{}, "the networks (CIDR) or addresses which are allowed to connect to the HTTP API (empty = all)")
+	flag.StringSlice(CfgWebAPIIPFilterDeniedNetworks, []string{}, "the networks (CIDR) or addresses which are not allowed to connect to the HTTP API")
 	flag.Bool(CfgWebAPIExcludeHealthCheckFromAuth, false, "whether to allow the health check route anyways")
 	flag.Bool(CfgWebAPIBasicAuthEnabled, false, "whether to use HTTP basic auth for the HTTP API")
 	flag.String(CfgWebAPIBasicAuthUsername, "", "the username of the HTTP basic auth")
 	flag.String(CfgWebAPIBasicAuthPasswordHash, "", "the HTTP basic auth password+salt as a sha256 hash")
 	flag.String(CfgWebAPIBasicAuthPasswordSalt, "", "the HTTP basic auth salt used for hashing the password")
+	flag.Bool(CfgWebAPIJWTAuthEnabled, false, "whether to use JWT auth for the protected HTTP API commands")
+	flag.String(CfgWebAPIJWTAuthSecret, "", "the secret used to sign the issued JWTs")
+	flag.Int(CfgWebAPIJWTAuthSessionTimeoutMinutes, 60, "the validity of the issued JWTs in minutes (0 = no expiry)")
+	flag.StringSlice(CfgWebAPIJWTAuthProtectedCommands,
+		[]string{
+			"addNeighbors",
+			"removeNeighbors",
+			"getNeighbors",
+			"getNodeStats",
+			"createSnapshotFile",
+			"pruneDatabase",
+			"compactDatabase",
+			"createDatabaseBackup",
+			"exportSpentAddresses",
+			"importSpentAddresses",
+			"spammer",
+			"startPlugin",
+			"stopPlugin",
+			"reloadConfig",
+			"auditLedger",
+			"getBandwidthUsage",
+		}, "the HTTP API commands which can only be called with a valid JWT or from a whitelisted address")
+	flag.Bool(CfgWebAPITLSEnabled, false, "whether the HTTP API is served via TLS")
+	flag.String(CfgWebAPITLSCertPath, "tls/cert.pem", "the path to the TLS certificate of the HTTP API")
+	flag.String(CfgWebAPITLSKeyPath, "tls/key.pem", "the path to the TLS private key of the HTTP API")
+	flag.Bool(CfgWebAPIRateLimitEnabled, false, "whether to rate limit the requests of non whitelisted addresses")
+	flag.Float64(CfgWebAPIRateLimitRequestsPerSecond, 20, "the allowed requests per second per IP address")
+	flag.Int(CfgWebAPIRateLimitBurst, 40, "the maximum burst of requests per IP address")
+	flag.StringToString(CfgWebAPIRateLimitCommands,
+		map[string]string{
+			"attachToTangle":      "1",
+			"promoteTransaction":  "1",
+			"reattachTransaction": "1",
+			"findTransactions":    "5",
+			"getBalances":         "5",
+		}, "the allowed calls per second per IP address of specific HTTP API commands")
+	flag.Bool(CfgWebAPIWebSocketEnabled, false, "whether to serve the public WebSocket event stream")
+	flag.Int(CfgWebAPIWebSocketMaxClients, 100, "the maximum number of concurrently connected WebSocket clients")
+	flag.Int(CfgWebAPIWebSocketMaxFilters, 100, "the maximum number of address and tag filters per WebSocket subscription")
+	flag.Int(CfgWebAPIWebSocketSendQueueSize, 1000, "the maximum number of queued messages per WebSocket client before messages get dropped")
+	flag.Bool(CfgWebAPISubscriptionsEnabled, false, "whether clients can register address and tag subscriptions and fetch their missed events")
+	flag.Int(CfgWebAPISubscriptionsMaxSubscriptions, 100, "the maximum number of registered subscriptions")
+	flag.Int(CfgWebAPISubscriptionsMaxFilters, 100, "the maximum number of address and tag filters per subscription")
+	flag.Int(CfgWebAPISubscriptionsMaxEvents, 1000, "the maximum number of events retained per subscription")
+	flag.Int(CfgWebAPISubscriptionsExpiryMinutes, 1440, "the time in minutes after which subscriptions whose events were not fetched are removed")
 	flag.Int(CfgWebAPILimitsMaxBodyLengthBytes, 1000000, "the maximum number of characters that the body of an API call may contain")
 	flag.Int(CfgWebAPILimitsMaxFindTransactions, 1000, "the maximum number of transactions that may be returned by the findTransactions endpoint")
+	flag.Int(CfgWebAPILimitsMaxFindTransactionsStream, 100000, "the maximum number of transactions that may be streamed by the findTransactions endpoint")
 	flag.Int(CfgWebAPILimitsMaxGetTrytes, 1000, "the maximum number of trytes that may be returned by the getTrytes endpoint")
 	flag.Int(CfgWebAPILimitsMaxRequestsList, 1000, "the maximum number of parameters in an API call")
+	flag.StringToString(CfgWebAPILimitsResults,
+		map[string]string{
+			"getLedgerDiff":  "0",
+			"getLedgerState": "0",
+		}, "the maximum number of results of specific HTTP API commands, truncated results can be continued (0 = unlimited)")
 }
@@ -9,9 +9,27 @@ const (
 	CfgZMQBindAddress = "zmq.bindAddress"
 	// the bind address of the ZMQ feed
 	CfgZMQProtocol = "zmq.protocol"
+	// the enabled topics of the ZMQ feed (address topics are always enabled)
+	CfgZMQTopics = "zmq.topics"
+	// the maximum number of queued messages for the subscribers before new messages get dropped (0 = unlimited)
+	CfgZMQHighWaterMark = "zmq.highWaterMark"
 )
 
 func init() {
 	flag.String(CfgZMQProtocol, "tcp", "protocol used to connect to the zmq feed [unix, tcp, udp, inproc]")
 	flag.String(CfgZMQBindAddress, "localhost:5556", "the bind address of the ZMQ feed")
+	flag.StringSlice(CfgZMQTopics,
+		[]string{
+			"lmi",
+			"lmsi",
+			"lmhs",
+			"lm",
+			"lsm",
+			"sn",
+			"conf_trytes",
+			"trytes",
+			"tx",
+			"spent_address",
+		}, "the enabled topics of the ZMQ feed (address topics are always enabled)")
+	flag.Int(CfgZMQHighWaterMark, 1000, "the maximum number of queued messages for the subscribers before new messages get dropped (0 = unlimited)")
 }
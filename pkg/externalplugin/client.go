@@ -0,0 +1,135 @@
+package externalplugin
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+)
+
+// Client is the connection of the node to a running plugin executable.
+type Client struct {
+	grpcapi.PluginClient
+
+	cmd    *exec.Cmd
+	conn   *grpc.ClientConn
+	exited chan struct{}
+}
+
+// Start starts the plugin executable and connects to its gRPC server.
+// Every line the plugin writes to stderr is passed to the given log function.
+func Start(executable string, startupTimeout time.Duration, logFunc func(line string)) (*Client, error) {
+	cmd := exec.Command(executable)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", MagicCookieKey, MagicCookieValue))
+
+	// the output is passed through pipes, so that waiting for the process also waits until all output was read
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	c := &Client{cmd: cmd, exited: make(chan struct{})}
+
+	go forwardLines(stderrReader, logFunc)
+
+	handshakeChan := make(chan *handshake, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(stdoutReader)
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			errChan <- fmt.Errorf("%w: %v", ErrInvalidHandshake, err)
+		} else if hs, err := parseHandshake(line); err != nil {
+			errChan <- err
+		} else {
+			handshakeChan <- hs
+		}
+
+		// everything else on stdout is logged as well
+		forwardLines(reader, logFunc)
+	}()
+
+	go func() {
+		cmd.Wait()
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		close(c.exited)
+	}()
+
+	var hs *handshake
+	select {
+	case hs = <-handshakeChan:
+	case err := <-errChan:
+		c.kill()
+		return nil, err
+	case <-c.exited:
+		return nil, fmt.Errorf("plugin exited before the handshake: %s", cmd.ProcessState)
+	case <-time.After(startupTimeout):
+		c.kill()
+		return nil, fmt.Errorf("%w: no handshake within %v", ErrInvalidHandshake, startupTimeout)
+	}
+
+	conn, err := grpc.Dial(hs.address, grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, hs.network, address)
+	}))
+	if err != nil {
+		c.kill()
+		return nil, err
+	}
+
+	c.conn = conn
+	c.PluginClient = grpcapi.NewPluginClient(conn)
+
+	return c, nil
+}
+
+// Exited is closed as soon as the plugin process exited.
+func (c *Client) Exited() <-chan struct{} {
+	return c.exited
+}
+
+// Close closes the connection and asks the plugin to shut down.
+// The plugin is killed if it does not exit within the given timeout.
+func (c *Client) Close(timeout time.Duration) error {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+
+	if err := interrupt(c.cmd.Process); err != nil {
+		c.kill()
+		return err
+	}
+
+	select {
+	case <-c.exited:
+		return nil
+	case <-time.After(timeout):
+		c.kill()
+		return fmt.Errorf("plugin did not exit within %v and was killed", timeout)
+	}
+}
+
+func (c *Client) kill() {
+	c.cmd.Process.Kill()
+	<-c.exited
+}
+
+func forwardLines(r io.Reader, logFunc func(line string)) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		logFunc(scanner.Text())
+	}
+}
@@ -0,0 +1,95 @@
+package externalplugin_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/externalplugin"
+	"github.com/gohornet/hornet/pkg/grpcapi"
+)
+
+const helperEnvKey = "HORNET_PLUGIN_TEST_HELPER"
+
+type testPlugin struct {
+	grpcapi.UnimplementedPluginServer
+}
+
+func (p *testPlugin) Describe(_ context.Context, req *grpcapi.DescribeRequest) (*grpcapi.PluginDescription, error) {
+	return &grpcapi.PluginDescription{
+		Name:    "test",
+		Version: req.GetNodeAppVersion(),
+		Events:  []grpcapi.PluginEventType{grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_SOLID_MILESTONE},
+		Routes:  []*grpcapi.PluginRoute{{Method: "GET", Path: "/echo/:value"}},
+	}, nil
+}
+
+func (p *testPlugin) HandleRequest(_ context.Context, req *grpcapi.PluginRequest) (*grpcapi.PluginResponse, error) {
+	return &grpcapi.PluginResponse{StatusCode: 200, Body: []byte(req.GetParams()["value"])}, nil
+}
+
+// TestMain runs the test binary as a plugin if it was started by the tests.
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnvKey) != "" {
+		fmt.Fprintln(os.Stderr, "plugin started")
+		if err := externalplugin.Serve(&testPlugin{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestServeWithoutNode(t *testing.T) {
+	assert.Equal(t, externalplugin.ErrNotStartedByNode, externalplugin.Serve(&testPlugin{}))
+}
+
+func TestStart(t *testing.T) {
+	os.Setenv(helperEnvKey, "1")
+	defer os.Unsetenv(helperEnvKey)
+
+	lines := make(chan string, 10)
+	client, err := externalplugin.Start(os.Args[0], 10*time.Second, func(line string) {
+		lines <- line
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	description, err := client.Describe(ctx, &grpcapi.DescribeRequest{NodeAppVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "test", description.GetName())
+	assert.Equal(t, "1.0.0", description.GetVersion())
+	assert.Len(t, description.GetRoutes(), 1)
+
+	res, err := client.HandleRequest(ctx, &grpcapi.PluginRequest{Params: map[string]string{"value": "hello"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(200), res.GetStatusCode())
+	assert.Equal(t, "hello", string(res.GetBody()))
+
+	// the events are not implemented by the test plugin
+	_, err = client.HandleEvent(ctx, &grpcapi.PluginEvent{})
+	assert.Error(t, err)
+
+	require.NoError(t, client.Close(5*time.Second))
+
+	select {
+	case <-client.Exited():
+	default:
+		t.Fatal("plugin did not exit")
+	}
+
+	assert.Equal(t, "plugin started", <-lines)
+}
+
+func TestStartInvalidExecutable(t *testing.T) {
+	_, err := externalplugin.Start("/nonexistent/plugin", time.Second, func(string) {})
+	assert.Error(t, err)
+}
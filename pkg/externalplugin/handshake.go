@@ -0,0 +1,75 @@
+// Package externalplugin implements the protocol between the node and its out-of-process plugins.
+//
+// The node starts a plugin executable with the magic cookie in its environment.
+// The plugin starts a gRPC server which implements grpcapi.PluginServer and writes the handshake
+// "<protocol version>|<network>|<address>" as the first line to stdout. The node connects to that address,
+// asks the plugin for its description and from then on delivers the subscribed events and API requests.
+// Everything the plugin writes to stderr ends up in the log of the node.
+package externalplugin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ProtocolVersion is the version of the plugin protocol.
+	ProtocolVersion = 1
+
+	// MagicCookieKey is the environment variable the node passes to the plugins.
+	// It protects against starting a plugin executable by accident.
+	MagicCookieKey = "HORNET_PLUGIN_MAGIC_COOKIE"
+	// MagicCookieValue is the expected value of the magic cookie.
+	MagicCookieValue = "d8f3a1c5e94b4f0c8a2e7b6d13c9f5a0"
+)
+
+var (
+	// ErrInvalidHandshake is returned if the handshake of a plugin can't be parsed.
+	ErrInvalidHandshake = errors.New("invalid plugin handshake")
+	// ErrIncompatibleProtocolVersion is returned if the plugin speaks another version of the protocol.
+	ErrIncompatibleProtocolVersion = errors.New("incompatible plugin protocol version")
+	// ErrNotStartedByNode is returned if a plugin executable was not started by the node.
+	ErrNotStartedByNode = errors.New("plugins have to be started by the node")
+)
+
+// handshake is the first line a plugin writes to stdout.
+type handshake struct {
+	protocolVersion int
+	network         string
+	address         string
+}
+
+func (h *handshake) String() string {
+	return fmt.Sprintf("%d|%s|%s", h.protocolVersion, h.network, h.address)
+}
+
+// parseHandshake parses the handshake line of a plugin.
+func parseHandshake(line string) (*handshake, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHandshake, line)
+	}
+
+	protocolVersion, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHandshake, line)
+	}
+
+	if protocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("%w: %d, expected: %d", ErrIncompatibleProtocolVersion, protocolVersion, ProtocolVersion)
+	}
+
+	switch parts[1] {
+	case "unix", "tcp":
+	default:
+		return nil, fmt.Errorf("%w: unsupported network %q", ErrInvalidHandshake, parts[1])
+	}
+
+	if parts[2] == "" {
+		return nil, fmt.Errorf("%w: empty address", ErrInvalidHandshake)
+	}
+
+	return &handshake{protocolVersion: protocolVersion, network: parts[1], address: parts[2]}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package externalplugin
+
+import (
+	"os"
+)
+
+// interrupt asks the process to shut down gracefully.
+func interrupt(process *os.Process) error {
+	return process.Signal(os.Interrupt)
+}
@@ -0,0 +1,11 @@
+package externalplugin
+
+import (
+	"os"
+)
+
+// interrupt asks the process to shut down.
+// Windows does not support sending interrupts to other processes, so the process is killed.
+func interrupt(process *os.Process) error {
+	return process.Kill()
+}
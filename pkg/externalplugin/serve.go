@@ -0,0 +1,81 @@
+package externalplugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+)
+
+// Serve is called by the plugin executables. It serves the given plugin implementation to the node
+// until the process is interrupted by the node.
+func Serve(impl grpcapi.PluginServer) error {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		return ErrNotStartedByNode
+	}
+
+	listener, err := listen()
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	server := grpc.NewServer()
+	grpcapi.RegisterPluginServer(server, impl)
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+
+	go func() {
+		<-signalChan
+		server.GracefulStop()
+	}()
+
+	hs := &handshake{protocolVersion: ProtocolVersion, network: listener.Addr().Network(), address: listener.Addr().String()}
+	if _, err := fmt.Fprintln(os.Stdout, hs.String()); err != nil {
+		return err
+	}
+
+	return server.Serve(listener)
+}
+
+// listen opens a unix socket in a temporary directory, or a local TCP port on Windows.
+func listen() (net.Listener, error) {
+	if runtime.GOOS == "windows" {
+		return net.Listen("tcp", "127.0.0.1:0")
+	}
+
+	dir, err := ioutil.TempDir("", "hornet-plugin")
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "plugin.sock"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &removingListener{Listener: listener, dir: dir}, nil
+}
+
+// removingListener removes the directory of the unix socket when it is closed.
+type removingListener struct {
+	net.Listener
+	dir string
+}
+
+func (l *removingListener) Close() error {
+	err := l.Listener.Close()
+	os.RemoveAll(l.dir)
+	return err
+}
@@ -0,0 +1,5 @@
+// Package grpcapi contains the protobuf definitions and the generated code of the gRPC API of the node,
+// of the remote milestone signer of the coordinator and of the out-of-process plugins.
+package grpcapi
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. hornet.proto signer.proto plugin.proto
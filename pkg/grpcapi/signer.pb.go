@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        (unknown)
+// source: signer.proto
+
+package grpcapi
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type SignMilestoneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneIndex uint32 `protobuf:"varint,1,opt,name=milestone_index,json=milestoneIndex,proto3" json:"milestone_index,omitempty"`
+	SecurityLevel  uint32 `protobuf:"varint,2,opt,name=security_level,json=securityLevel,proto3" json:"security_level,omitempty"`
+	Hash           string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *SignMilestoneRequest) Reset() {
+	*x = SignMilestoneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignMilestoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignMilestoneRequest) ProtoMessage() {}
+
+func (x *SignMilestoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignMilestoneRequest.ProtoReflect.Descriptor instead.
+func (*SignMilestoneRequest) Descriptor() ([]byte, []int) {
+	return file_signer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignMilestoneRequest) GetMilestoneIndex() uint32 {
+	if x != nil {
+		return x.MilestoneIndex
+	}
+	return 0
+}
+
+func (x *SignMilestoneRequest) GetSecurityLevel() uint32 {
+	if x != nil {
+		return x.SecurityLevel
+	}
+	return 0
+}
+
+func (x *SignMilestoneRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type SignMilestoneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignatureFragments []string `protobuf:"bytes,1,rep,name=signature_fragments,json=signatureFragments,proto3" json:"signature_fragments,omitempty"`
+}
+
+func (x *SignMilestoneResponse) Reset() {
+	*x = SignMilestoneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignMilestoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignMilestoneResponse) ProtoMessage() {}
+
+func (x *SignMilestoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignMilestoneResponse.ProtoReflect.Descriptor instead.
+func (*SignMilestoneResponse) Descriptor() ([]byte, []int) {
+	return file_signer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignMilestoneResponse) GetSignatureFragments() []string {
+	if x != nil {
+		return x.SignatureFragments
+	}
+	return nil
+}
+
+var File_signer_proto protoreflect.FileDescriptor
+
+var file_signer_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x22, 0x7a, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x69,
+	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27,
+	0x0a, 0x0f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x22, 0x48, 0x0a, 0x15, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74,
+	0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x32, 0x5f, 0x0a, 0x0f,
+	0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12,
+	0x4c, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65,
+	0x12, 0x1c, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x69,
+	0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a,
+	0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x68, 0x6f,
+	0x72, 0x6e, 0x65, 0x74, 0x2f, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_signer_proto_rawDescOnce sync.Once
+	file_signer_proto_rawDescData = file_signer_proto_rawDesc
+)
+
+func file_signer_proto_rawDescGZIP() []byte {
+	file_signer_proto_rawDescOnce.Do(func() {
+		file_signer_proto_rawDescData = protoimpl.X.CompressGZIP(file_signer_proto_rawDescData)
+	})
+	return file_signer_proto_rawDescData
+}
+
+var file_signer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_signer_proto_goTypes = []interface{}{
+	(*SignMilestoneRequest)(nil),  // 0: hornet.SignMilestoneRequest
+	(*SignMilestoneResponse)(nil), // 1: hornet.SignMilestoneResponse
+}
+var file_signer_proto_depIdxs = []int32{
+	0, // 0: hornet.MilestoneSigner.SignMilestone:input_type -> hornet.SignMilestoneRequest
+	1, // 1: hornet.MilestoneSigner.SignMilestone:output_type -> hornet.SignMilestoneResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_signer_proto_init() }
+func file_signer_proto_init() {
+	if File_signer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_signer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignMilestoneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_signer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignMilestoneResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_signer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_signer_proto_goTypes,
+		DependencyIndexes: file_signer_proto_depIdxs,
+		MessageInfos:      file_signer_proto_msgTypes,
+	}.Build()
+	File_signer_proto = out.File
+	file_signer_proto_rawDesc = nil
+	file_signer_proto_goTypes = nil
+	file_signer_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// MilestoneSignerClient is the client API for MilestoneSigner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MilestoneSignerClient interface {
+	// SignMilestone returns the signature fragments of the given milestone hash, signed with the key of the Merkle tree leaf of the milestone index.
+	// Every milestone index is only signed once.
+	SignMilestone(ctx context.Context, in *SignMilestoneRequest, opts ...grpc.CallOption) (*SignMilestoneResponse, error)
+}
+
+type milestoneSignerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMilestoneSignerClient(cc grpc.ClientConnInterface) MilestoneSignerClient {
+	return &milestoneSignerClient{cc}
+}
+
+func (c *milestoneSignerClient) SignMilestone(ctx context.Context, in *SignMilestoneRequest, opts ...grpc.CallOption) (*SignMilestoneResponse, error) {
+	out := new(SignMilestoneResponse)
+	err := c.cc.Invoke(ctx, "/hornet.MilestoneSigner/SignMilestone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MilestoneSignerServer is the server API for MilestoneSigner service.
+type MilestoneSignerServer interface {
+	// SignMilestone returns the signature fragments of the given milestone hash, signed with the key of the Merkle tree leaf of the milestone index.
+	// Every milestone index is only signed once.
+	SignMilestone(context.Context, *SignMilestoneRequest) (*SignMilestoneResponse, error)
+}
+
+// UnimplementedMilestoneSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedMilestoneSignerServer struct {
+}
+
+func (*UnimplementedMilestoneSignerServer) SignMilestone(context.Context, *SignMilestoneRequest) (*SignMilestoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMilestone not implemented")
+}
+
+func RegisterMilestoneSignerServer(s *grpc.Server, srv MilestoneSignerServer) {
+	s.RegisterService(&_MilestoneSigner_serviceDesc, srv)
+}
+
+func _MilestoneSigner_SignMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMilestoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneSignerServer).SignMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hornet.MilestoneSigner/SignMilestone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneSignerServer).SignMilestone(ctx, req.(*SignMilestoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MilestoneSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hornet.MilestoneSigner",
+	HandlerType: (*MilestoneSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignMilestone",
+			Handler:    _MilestoneSigner_SignMilestone_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
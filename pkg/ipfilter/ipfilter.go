@@ -0,0 +1,203 @@
+// Package ipfilter restricts the remote addresses which are allowed to connect to a listener by CIDR based allow and deny lists.
+package ipfilter
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+// Filter decides by allow and deny lists of networks whether a remote address may connect.
+// An address is denied if it is part of a denied network, or if the allow list is not empty
+// and the address is not part of an allowed network.
+type Filter struct {
+	lock    sync.RWMutex
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+
+	// the connections accepted by the listeners of the filter
+	connsLock sync.Mutex
+	conns     map[*filteredConn]struct{}
+}
+
+// New creates a filter with the given networks in CIDR notation, single IP addresses are allowed too.
+func New(allowed []string, denied []string) (*Filter, error) {
+	f := &Filter{conns: make(map[*filteredConn]struct{})}
+	if err := f.Update(allowed, denied); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// NewFromConfig creates a filter with the networks of the given config keys.
+// The filter is updated whenever the config is reloaded, invalid networks in the reloaded config are logged and ignored.
+func NewFromConfig(allowedKey string, deniedKey string, log *logger.Logger) (*Filter, error) {
+	f, err := New(config.NodeConfig.GetStringSlice(allowedKey), config.NodeConfig.GetStringSlice(deniedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	config.Events.Reloaded.Attach(events.NewClosure(func() {
+		settings := config.ReloadableNodeConfig()
+		if err := f.Update(settings.GetStringSlice(allowedKey), settings.GetStringSlice(deniedKey)); err != nil {
+			log.Warnf("Keeping the previous IP filter: %s", err)
+		}
+	}))
+
+	return f, nil
+}
+
+// Update replaces the networks of the filter. The filter is not changed if a network is invalid.
+// Connections accepted by the listeners of the filter, whose addresses are denied now, are closed.
+func (f *Filter) Update(allowed []string, denied []string) error {
+	allowedNetworks, err := parseNetworks(allowed)
+	if err != nil {
+		return err
+	}
+
+	deniedNetworks, err := parseNetworks(denied)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	f.allowed = allowedNetworks
+	f.denied = deniedNetworks
+	f.lock.Unlock()
+
+	f.closeDeniedConns()
+	return nil
+}
+
+// closeDeniedConns closes the accepted connections whose addresses are denied.
+func (f *Filter) closeDeniedConns() {
+	f.connsLock.Lock()
+	var denied []*filteredConn
+	for conn := range f.conns {
+		if !f.AllowedAddr(conn.RemoteAddr()) {
+			denied = append(denied, conn)
+		}
+	}
+	f.connsLock.Unlock()
+
+	for _, conn := range denied {
+		_ = conn.Close()
+	}
+}
+
+// Allowed returns whether the given IP address may connect.
+func (f *Filter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for _, network := range f.denied {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(f.allowed) == 0 {
+		return true
+	}
+
+	for _, network := range f.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowedAddr returns whether the given remote address may connect.
+func (f *Filter) AllowedAddr(addr net.Addr) bool {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return f.Allowed(a.IP)
+	case *net.UDPAddr:
+		return f.Allowed(a.IP)
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	return f.Allowed(net.ParseIP(host))
+}
+
+// Listener wraps the given listener, so connections of denied addresses are closed right after they were accepted.
+// The accepted connections are closed as soon as their addresses are denied by an update of the filter.
+func (f *Filter) Listener(listener net.Listener) net.Listener {
+	return &filteredListener{Listener: listener, filter: f}
+}
+
+type filteredListener struct {
+	net.Listener
+	filter *Filter
+}
+
+func (l *filteredListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if !l.filter.AllowedAddr(conn.RemoteAddr()) {
+			_ = conn.Close()
+			continue
+		}
+
+		c := &filteredConn{Conn: conn, filter: l.filter}
+		l.filter.connsLock.Lock()
+		l.filter.conns[c] = struct{}{}
+		l.filter.connsLock.Unlock()
+		return c, nil
+	}
+}
+
+// filteredConn is a connection accepted by a filtered listener, which is tracked until it is closed.
+type filteredConn struct {
+	net.Conn
+	filter *Filter
+}
+
+func (c *filteredConn) Close() error {
+	c.filter.connsLock.Lock()
+	delete(c.filter.conns, c)
+	c.filter.connsLock.Unlock()
+	return c.Conn.Close()
+}
+
+// parseNetworks parses the given networks in CIDR notation or single IP addresses.
+func parseNetworks(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid network '%s'", entry)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = 8 * net.IPv4len
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks, nil
+}
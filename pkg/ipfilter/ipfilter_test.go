@@ -0,0 +1,128 @@
+package ipfilter_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/ipfilter"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := ipfilter.New(nil, nil)
+	require.NoError(t, err)
+	// everything is allowed without networks
+	assert.True(t, f.Allowed(net.ParseIP("192.0.2.1")))
+	assert.True(t, f.Allowed(net.ParseIP("2001:db8::1")))
+
+	require.NoError(t, f.Update([]string{"10.8.0.0/16", "2001:db8::/32", "127.0.0.1"}, []string{"10.8.1.0/24"}))
+	assert.True(t, f.Allowed(net.ParseIP("10.8.0.1")))
+	assert.True(t, f.Allowed(net.ParseIP("2001:db8::1")))
+	assert.True(t, f.Allowed(net.ParseIP("127.0.0.1")))
+	assert.False(t, f.Allowed(net.ParseIP("127.0.0.2")))
+	assert.False(t, f.Allowed(net.ParseIP("192.0.2.1")))
+	// the deny list has precedence
+	assert.False(t, f.Allowed(net.ParseIP("10.8.1.1")))
+	// IPv4 in IPv6 notation
+	assert.True(t, f.Allowed(net.ParseIP("::ffff:10.8.0.1")))
+
+	// an invalid network keeps the previous ones
+	assert.Error(t, f.Update([]string{"10.9.0.0/16", "vpn"}, nil))
+	assert.True(t, f.Allowed(net.ParseIP("10.8.0.1")))
+	assert.False(t, f.Allowed(net.ParseIP("10.9.0.1")))
+
+	// only deny list
+	require.NoError(t, f.Update(nil, []string{"192.0.2.0/24"}))
+	assert.False(t, f.Allowed(net.ParseIP("192.0.2.1")))
+	assert.True(t, f.Allowed(net.ParseIP("198.51.100.1")))
+
+	_, err = ipfilter.New([]string{"300.0.0.1"}, nil)
+	assert.Error(t, err)
+}
+
+func TestFilterListener(t *testing.T) {
+	f, err := ipfilter.New(nil, []string{"127.0.0.1"})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	filtered := f.Listener(listener)
+	defer filtered.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := filtered.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	// the denied connection is closed by the listener
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	select {
+	case <-accepted:
+		t.Fatal("denied connection was accepted")
+	default:
+	}
+
+	// allowed after the update
+	require.NoError(t, f.Update(nil, nil))
+	conn2, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	select {
+	case c := <-accepted:
+		c.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("allowed connection was not accepted")
+	}
+}
+
+func TestFilterClosesDeniedConnections(t *testing.T) {
+	f, err := ipfilter.New(nil, nil)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	filtered := f.Listener(listener)
+	defer filtered.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := filtered.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	select {
+	case c := <-accepted:
+		defer c.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("allowed connection was not accepted")
+	}
+
+	// the established connection is closed once its address is denied
+	require.NoError(t, f.Update(nil, []string{"127.0.0.1"}))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	netErr, isNetErr := err.(net.Error)
+	assert.False(t, isNetErr && netErr.Timeout(), "connection was not closed")
+}
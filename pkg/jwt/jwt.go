@@ -0,0 +1,68 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var (
+	// ErrInvalidJWT is returned if a JWT could not be verified.
+	ErrInvalidJWT = errors.New("invalid JWT")
+)
+
+// JWTAuth issues and verifies JSON web tokens which are signed with a node-configured secret.
+type JWTAuth struct {
+	subject        string
+	sessionTimeout time.Duration
+	secret         []byte
+}
+
+// New creates a new JWTAuth. A session timeout of zero issues tokens which never expire.
+func New(subject string, sessionTimeout time.Duration, secret string) *JWTAuth {
+	return &JWTAuth{
+		subject:        subject,
+		sessionTimeout: sessionTimeout,
+		secret:         []byte(secret),
+	}
+}
+
+// IssueJWT issues a new JWT for the given audience.
+func (j *JWTAuth) IssueJWT(audience string) (string, error) {
+	now := time.Now()
+
+	claims := &jwt.StandardClaims{
+		Subject:   j.subject,
+		Audience:  audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+	}
+
+	if j.sessionTimeout > 0 {
+		claims.ExpiresAt = now.Add(j.sessionTimeout).Unix()
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secret)
+}
+
+// VerifyJWT checks the signature and the claims of the given JWT and returns the audience it was issued for.
+func (j *JWTAuth) VerifyJWT(token string) (string, error) {
+	claims := &jwt.StandardClaims{}
+
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidJWT
+		}
+		return j.secret, nil
+	})
+	if err != nil || !parsed.Valid {
+		return "", ErrInvalidJWT
+	}
+
+	if claims.Subject != j.subject {
+		return "", ErrInvalidJWT
+	}
+
+	return claims.Audience, nil
+}
@@ -0,0 +1,51 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/jwt"
+)
+
+func TestJWTAuth(t *testing.T) {
+	auth := jwt.New("HORNET", time.Minute, "0123456789abcdef0123456789abcdef")
+
+	token, err := auth.IssueJWT("admin")
+	assert.NoError(t, err)
+
+	audience, err := auth.VerifyJWT(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", audience)
+
+	// a token signed with another secret must be rejected
+	otherAuth := jwt.New("HORNET", time.Minute, "fedcba9876543210fedcba9876543210")
+	_, err = otherAuth.VerifyJWT(token)
+	assert.Equal(t, jwt.ErrInvalidJWT, err)
+
+	// a token issued for another subject must be rejected
+	otherSubjectAuth := jwt.New("OTHER", time.Minute, "0123456789abcdef0123456789abcdef")
+	_, err = otherSubjectAuth.VerifyJWT(token)
+	assert.Equal(t, jwt.ErrInvalidJWT, err)
+
+	_, err = auth.VerifyJWT("not-a-token")
+	assert.Equal(t, jwt.ErrInvalidJWT, err)
+}
+
+func TestJWTAuthExpired(t *testing.T) {
+	auth := jwt.New("HORNET", time.Minute, "0123456789abcdef0123456789abcdef")
+
+	token, err := auth.IssueJWT("admin")
+	assert.NoError(t, err)
+
+	// verify the token after the session timeout
+	jwtgo.TimeFunc = func() time.Time {
+		return time.Now().Add(2 * time.Minute)
+	}
+	defer func() { jwtgo.TimeFunc = time.Now }()
+
+	_, err = auth.VerifyJWT(token)
+	assert.Equal(t, jwt.ErrInvalidJWT, err)
+}
@@ -0,0 +1,189 @@
+// Package logrotate provides a log file writer which rotates the file by size and age.
+package logrotate
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SinkScheme is the URL scheme of the rotating log files in the output paths of the logger.
+	SinkScheme = "rotate"
+
+	// the suffix of rotated log files
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// Writer writes to a log file, which is renamed and replaced by a new one
+// if it exceeds the maximum size or the rotation interval passed.
+type Writer struct {
+	lock sync.Mutex
+
+	filePath   string
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// New creates a writer for the log file at the given path.
+// The file is rotated if it would exceed maxSize bytes (0 = disable) or if it is older than interval (0 = disable).
+// Only the latest maxBackups rotated files are kept (0 = keep all).
+func New(filePath string, maxSize int64, interval time.Duration, maxBackups int) (*Writer, error) {
+	w := &Writer{
+		filePath:   filePath,
+		maxSize:    maxSize,
+		interval:   interval,
+		maxBackups: maxBackups,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// open opens or creates the log file and appends to it.
+func (w *Writer) open() error {
+	if dir := filepath.Dir(w.filePath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(w.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+// rotate renames the current log file, opens a new one and removes the oldest backups.
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(w.filePath, w.filePath+"."+time.Now().Format(backupTimeFormat)); err != nil {
+		return err
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	return w.removeOldBackups()
+}
+
+// removeOldBackups removes the oldest rotated log files which exceed the maximum amount of backups.
+func (w *Writer) removeOldBackups() error {
+	if w.maxBackups == 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(w.filePath + ".*")
+	if err != nil {
+		return err
+	}
+
+	// the time format sorts the backups from the oldest to the latest
+	sort.Strings(backups)
+
+	for len(backups) > w.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// Write writes the log record to the file and rotates the file beforehand if needed.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	sizeExceeded := w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize
+	intervalPassed := w.interval > 0 && time.Since(w.openedAt) >= w.interval
+
+	if sizeExceeded || intervalPassed {
+		if err := w.rotate(); err != nil {
+			return 0, fmt.Errorf("unable to rotate the log file: %w", err)
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync flushes the log file to disk.
+func (w *Writer) Sync() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.file.Sync()
+}
+
+// Close closes the log file.
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.file.Close()
+}
+
+// RegisterSink registers the rotating log files as zap sink with the given rotation settings.
+// The output paths of the logger can then use rotating files in the form "rotate:<path>".
+func RegisterSink(maxSize int64, interval time.Duration, maxBackups int) error {
+	return zap.RegisterSink(SinkScheme, func(u *url.URL) (zap.Sink, error) {
+		filePath := u.Opaque
+		if filePath == "" {
+			filePath = u.Path
+		}
+		return New(filePath, maxSize, interval, maxBackups)
+	})
+}
+
+// RotatedOutputPaths replaces the file paths in the given logger output paths with rotating log files.
+// Standard output, standard error and the other sinks are kept.
+func RotatedOutputPaths(outputPaths []string) []string {
+	rotated := make([]string, len(outputPaths))
+	for i, outputPath := range outputPaths {
+		if outputPath == "stdout" || outputPath == "stderr" {
+			rotated[i] = outputPath
+			continue
+		}
+
+		// keep all other sinks, single letter schemes are windows drive letters
+		if u, err := url.Parse(outputPath); err == nil && len(u.Scheme) > 1 {
+			rotated[i] = outputPath
+			continue
+		}
+
+		rotated[i] = SinkScheme + ":" + filepath.ToSlash(outputPath)
+	}
+	return rotated
+}
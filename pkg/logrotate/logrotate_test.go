@@ -0,0 +1,68 @@
+package logrotate_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gohornet/hornet/pkg/logrotate"
+)
+
+func TestWriterRotatesBySize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "hornet.log")
+	w, err := logrotate.New(filePath, 10, 0, 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := w.Write([]byte("0123456789"))
+		require.NoError(t, err)
+		// the backups are distinguished by their timestamp
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, w.Close())
+
+	backups, err := filepath.Glob(filePath + ".*")
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(content))
+}
+
+func TestRotatedOutputPaths(t *testing.T) {
+	assert.Equal(t,
+		[]string{"stdout", "stderr", "rotate:logs/hornet.log", "rotate:/var/log/hornet.log", "rotate:C:/hornet.log", "syslog:", "journald:", "rotate:logs/hornet.log"},
+		logrotate.RotatedOutputPaths([]string{"stdout", "stderr", "logs/hornet.log", "/var/log/hornet.log", "C:/hornet.log", "syslog:", "journald:", "rotate:logs/hornet.log"}),
+	)
+}
+
+func TestRegisterSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, logrotate.RegisterSink(1024, time.Hour, 1))
+
+	filePath := filepath.Join(dir, "hornet.log")
+	sink, _, err := zap.Open(logrotate.RotatedOutputPaths([]string{filePath})...)
+	require.NoError(t, err)
+
+	_, err = sink.Write([]byte("test"))
+	require.NoError(t, err)
+	require.NoError(t, sink.Sync())
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(content))
+}
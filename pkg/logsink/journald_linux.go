@@ -0,0 +1,85 @@
+package logsink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// the socket of the native journald protocol
+	journaldSocket = "/run/systemd/journal/socket"
+)
+
+// journaldSink writes the log records to journald via its native protocol.
+type journaldSink struct {
+	conn       *net.UnixConn
+	identifier string
+}
+
+// newJournaldSink connects to the journald socket.
+func newJournaldSink(identifier string) (zap.Sink, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journaldSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &journaldSink{conn: conn, identifier: identifier}, nil
+}
+
+// journaldPriority maps the log level to the syslog priority used by journald.
+func journaldPriority(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 7
+	case zapcore.WarnLevel:
+		return 4
+	case zapcore.ErrorLevel:
+		return 3
+	case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel:
+		return 2
+	default:
+		return 6
+	}
+}
+
+// appendJournaldField appends a field in the native journald format.
+// Values which contain a newline are written with their length in front.
+func appendJournaldField(buf *bytes.Buffer, key string, value []byte) {
+	buf.WriteString(key)
+	if !bytes.ContainsRune(value, '\n') {
+		buf.WriteByte('=')
+		buf.Write(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.Write(value)
+	buf.WriteByte('\n')
+}
+
+func (s *journaldSink) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	appendJournaldField(&buf, "MESSAGE", bytes.TrimRight(p, "\n"))
+	appendJournaldField(&buf, "PRIORITY", []byte(strconv.Itoa(journaldPriority(levelOfRecord(p)))))
+	appendJournaldField(&buf, "SYSLOG_IDENTIFIER", []byte(s.identifier))
+
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *journaldSink) Sync() error {
+	return nil
+}
+
+func (s *journaldSink) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package logsink
+
+import (
+	"go.uber.org/zap"
+)
+
+func newJournaldSink(_ string) (zap.Sink, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,59 @@
+// Package logsink provides log outputs to the system log services, which can be used in the output paths of the logger.
+package logsink
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// SyslogScheme is the URL scheme of the syslog output, e.g. "syslog:" or "syslog://localhost:514?network=udp&tag=hornet".
+	SyslogScheme = "syslog"
+	// JournaldScheme is the URL scheme of the journald output, e.g. "journald:" or "journald:?identifier=hornet".
+	JournaldScheme = "journald"
+
+	// the default tag of the log records
+	defaultTag = "hornet"
+)
+
+var (
+	// ErrNotSupported is returned if the log output is not supported on this operating system.
+	ErrNotSupported = errors.New("log output not supported on this operating system")
+)
+
+// RegisterSinks registers the syslog and journald outputs as zap sinks.
+func RegisterSinks() error {
+	if err := zap.RegisterSink(SyslogScheme, func(u *url.URL) (zap.Sink, error) {
+		return newSyslogSink(u.Query().Get("network"), u.Host, tagFromURL(u, "tag"))
+	}); err != nil {
+		return err
+	}
+
+	return zap.RegisterSink(JournaldScheme, func(u *url.URL) (zap.Sink, error) {
+		return newJournaldSink(tagFromURL(u, "identifier"))
+	})
+}
+
+func tagFromURL(u *url.URL, key string) string {
+	if tag := u.Query().Get(key); tag != "" {
+		return tag
+	}
+	return defaultTag
+}
+
+// levelOfRecord extracts the level of an encoded log record.
+// The sinks only get the encoded records, so the level is searched in the console and the JSON format.
+func levelOfRecord(record []byte) zapcore.Level {
+	for _, level := range []zapcore.Level{zapcore.FatalLevel, zapcore.PanicLevel, zapcore.DPanicLevel, zapcore.ErrorLevel, zapcore.WarnLevel, zapcore.DebugLevel} {
+		name := []byte(level.CapitalString())
+		if bytes.Contains(record, append(append([]byte("\t"), name...), '\t')) ||
+			bytes.Contains(record, append(append([]byte(`"level":"`), name...), '"')) {
+			return level
+		}
+	}
+	return zapcore.InfoLevel
+}
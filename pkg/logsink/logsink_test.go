@@ -0,0 +1,15 @@
+package logsink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelOfRecord(t *testing.T) {
+	assert.Equal(t, zapcore.WarnLevel, levelOfRecord([]byte("2020-09-01T12:00:00Z\tWARN\tTangle\tsolidifier took too long\n")))
+	assert.Equal(t, zapcore.ErrorLevel, levelOfRecord([]byte(`{"level":"ERROR","ts":"2020-09-01T12:00:00Z","logger":"Tangle","msg":"INFO"}`+"\n")))
+	assert.Equal(t, zapcore.DebugLevel, levelOfRecord([]byte("2020-09-01T12:00:00Z\tDEBUG\tURTS\tUpdateScores finished\n")))
+	assert.Equal(t, zapcore.InfoLevel, levelOfRecord([]byte("2020-09-01T12:00:00Z\tINFO\tCLI\tLoading plugins ...\n")))
+}
@@ -0,0 +1,62 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package logsink
+
+import (
+	"bytes"
+	"log/syslog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogSink writes the log records to the local or a remote syslog daemon.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// newSyslogSink connects to the syslog daemon at the given address (empty = local daemon).
+func newSyslogSink(network string, address string, tag string) (zap.Sink, error) {
+	if address != "" && network == "" {
+		network = "udp"
+	}
+
+	writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Write(p []byte) (int, error) {
+	msg := string(bytes.TrimRight(p, "\n"))
+
+	var err error
+	switch levelOfRecord(p) {
+	case zapcore.DebugLevel:
+		err = s.writer.Debug(msg)
+	case zapcore.WarnLevel:
+		err = s.writer.Warning(msg)
+	case zapcore.ErrorLevel:
+		err = s.writer.Err(msg)
+	case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel:
+		err = s.writer.Crit(msg)
+	default:
+		err = s.writer.Info(msg)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *syslogSink) Sync() error {
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
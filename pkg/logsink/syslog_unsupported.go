@@ -0,0 +1,12 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package logsink
+
+import (
+	"go.uber.org/zap"
+)
+
+func newSyslogSink(_ string, _ string, _ string) (zap.Sink, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,130 @@
+package merkletree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+const (
+	// the magic bytes at the beginning of a checkpoint file
+	checkpointMagic = "HMTC"
+	// the version of the checkpoint file format
+	checkpointVersion byte = 1
+	// the size of the header: magic, version, security level, count and the first address
+	checkpointHeaderSize = len(checkpointMagic) + 1 + 1 + 4 + 49
+	// the size of an address in the checkpoint file
+	checkpointAddressSize = 49
+)
+
+var (
+	// ErrCheckpointMismatch is returned if the checkpoint file belongs to another Merkle tree.
+	ErrCheckpointMismatch = errors.New("checkpoint file belongs to another Merkle tree")
+)
+
+// checkpoint is a file which contains the addresses of the leaves that were already calculated.
+type checkpoint struct {
+	file *os.File
+}
+
+// checkpointHeader returns the header of the checkpoint file of the given tree.
+// The first address is used to recognize the seed without storing it.
+func checkpointHeader(securityLvl consts.SecurityLevel, count uint32, firstAddress trinary.Hash) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(checkpointMagic)
+	buf.WriteByte(checkpointVersion)
+	buf.WriteByte(byte(securityLvl))
+	binary.Write(&buf, binary.LittleEndian, count)
+	buf.Write(hornet.HashFromAddressTrytes(firstAddress))
+	return buf.Bytes()
+}
+
+// openCheckpoint opens the checkpoint file at the given path, or creates it if it doesn't exist.
+// The addresses of an existing checkpoint are appended to the given addresses.
+// An incompletely written address at the end of the file is discarded.
+func openCheckpoint(filePath string, securityLvl consts.SecurityLevel, count uint32, firstAddress trinary.Hash, addresses []trinary.Hash) (*checkpoint, []trinary.Hash, error) {
+
+	header := checkpointHeader(securityLvl, count, firstAddress)
+
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if info.Size() == 0 {
+		if _, err := file.Write(header); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return &checkpoint{file: file}, addresses, nil
+	}
+
+	reader := bufio.NewReader(file)
+
+	existingHeader := make([]byte, checkpointHeaderSize)
+	if _, err := io.ReadFull(reader, existingHeader); err != nil || !bytes.Equal(existingHeader, header) {
+		file.Close()
+		return nil, nil, fmt.Errorf("%w: %s", ErrCheckpointMismatch, filePath)
+	}
+
+	stored := (info.Size() - int64(checkpointHeaderSize)) / checkpointAddressSize
+	if stored > int64(count) {
+		file.Close()
+		return nil, nil, fmt.Errorf("%w: %s", ErrCheckpointMismatch, filePath)
+	}
+
+	address := make(hornet.Hash, checkpointAddressSize)
+	for i := int64(0); i < stored; i++ {
+		if _, err := io.ReadFull(reader, address); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		addresses = append(addresses, address.Trytes())
+	}
+
+	// drop an incompletely written address and continue at the end of the complete ones
+	end := int64(checkpointHeaderSize) + stored*checkpointAddressSize
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &checkpoint{file: file}, addresses, nil
+}
+
+// append writes the given addresses to the checkpoint file and syncs it to disk.
+func (c *checkpoint) append(addresses []trinary.Hash) error {
+	buf := make([]byte, 0, len(addresses)*checkpointAddressSize)
+	for _, address := range addresses {
+		buf = append(buf, hornet.HashFromAddressTrytes(address)...)
+	}
+
+	if _, err := c.file.Write(buf); err != nil {
+		return err
+	}
+	return c.file.Sync()
+}
+
+func (c *checkpoint) close() error {
+	return c.file.Close()
+}
@@ -0,0 +1,218 @@
+// Package merkletree creates the Merkle tree of the coordinator.
+// In contrast to merkle.CreateMerkleTree of iota.go, the calculated addresses can be checkpointed to a file,
+// so that the creation of big trees can be resumed after it was interrupted.
+package merkletree
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/kerl"
+	"github.com/iotaledger/iota.go/merkle"
+	"github.com/iotaledger/iota.go/signing"
+	"github.com/iotaledger/iota.go/signing/key"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+const (
+	// the amount of addresses each worker calculates before the batch is checkpointed
+	addressesPerWorkerAndBatch = 256
+)
+
+var (
+	// ErrInvalidDepth is returned if the depth of the Merkle tree is out of range.
+	ErrInvalidDepth = errors.New("depth must be between 1 and 32")
+)
+
+// Options define the optional settings for the creation of a Merkle tree.
+type Options struct {
+	// The number of addresses calculated in parallel, defaults to the number of CPUs.
+	Parallelism int
+	// The path of the file in which the calculated addresses are checkpointed (no checkpoints if empty).
+	// If the file already exists, the calculation is resumed.
+	CheckpointFilePath string
+	// ResumeCallback is called with the amount of addresses loaded from an existing checkpoint file.
+	ResumeCallback func(resumed uint32, count uint32)
+	// ProgressCallback is called after every batch of calculated addresses.
+	ProgressCallback func(calculated uint32, count uint32)
+	// LayersCallback is called before each layer of the tree is calculated.
+	LayersCallback func(level int)
+}
+
+// Create creates the Merkle tree of the given depth with the addresses of the given seed.
+// The result is the same as the one of merkle.CreateMerkleTree of iota.go.
+func Create(seed trinary.Hash, securityLvl consts.SecurityLevel, depth int, opts Options) (*merkle.MerkleTree, error) {
+	if depth < 1 || depth > 32 {
+		return nil, ErrInvalidDepth
+	}
+
+	if !guards.IsTransactionHash(seed) {
+		return nil, consts.ErrInvalidSeed
+	}
+
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = runtime.NumCPU()
+	}
+
+	addresses, err := calculateAddresses(seed, securityLvl, uint32(1<<uint(depth)), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mt := &merkle.MerkleTree{
+		Depth: depth,
+		// depth+1 because it has to include the root at [0]
+		Layers: make([]*merkle.MerkleTreeLayer, depth+1),
+	}
+
+	mt.Layers[depth] = &merkle.MerkleTreeLayer{Level: depth, Hashes: addresses}
+	for level := depth - 1; level >= 0; level-- {
+		if opts.LayersCallback != nil {
+			opts.LayersCallback(level)
+		}
+		mt.Layers[level] = &merkle.MerkleTreeLayer{Level: level, Hashes: calculateNextLayer(mt.Layers[level+1].Hashes, opts.Parallelism)}
+	}
+	mt.Root = mt.Layers[0].Hashes[0]
+
+	return mt, nil
+}
+
+// calculateAddresses calculates the addresses of the leaves in batches.
+// Every finished batch is appended to the checkpoint file.
+func calculateAddresses(seed trinary.Hash, securityLvl consts.SecurityLevel, count uint32, opts Options) ([]trinary.Hash, error) {
+
+	addresses := make([]trinary.Hash, 0, count)
+
+	var cp *checkpoint
+	if opts.CheckpointFilePath != "" {
+		firstAddress, err := computeAddress(seed, 0, securityLvl)
+		if err != nil {
+			return nil, err
+		}
+
+		if cp, addresses, err = openCheckpoint(opts.CheckpointFilePath, securityLvl, count, firstAddress, addresses); err != nil {
+			return nil, err
+		}
+		defer cp.close()
+
+		if len(addresses) > 0 && opts.ResumeCallback != nil {
+			opts.ResumeCallback(uint32(len(addresses)), count)
+		}
+	}
+
+	batchSize := uint32(opts.Parallelism * addressesPerWorkerAndBatch)
+
+	for start := uint32(len(addresses)); start < count; start += batchSize {
+		end := start + batchSize
+		if end > count {
+			end = count
+		}
+
+		batch := make([]trinary.Hash, end-start)
+
+		var wg sync.WaitGroup
+		var computeErr error
+		var computeErrOnce sync.Once
+
+		input := make(chan uint32)
+		wg.Add(opts.Parallelism)
+		for i := 0; i < opts.Parallelism; i++ {
+			go func() {
+				defer wg.Done()
+
+				for index := range input {
+					address, err := computeAddress(seed, index, securityLvl)
+					if err != nil {
+						computeErrOnce.Do(func() { computeErr = err })
+						continue
+					}
+					batch[index-start] = address
+				}
+			}()
+		}
+
+		for index := start; index < end; index++ {
+			input <- index
+		}
+		close(input)
+		wg.Wait()
+
+		if computeErr != nil {
+			return nil, computeErr
+		}
+
+		if cp != nil {
+			if err := cp.append(batch); err != nil {
+				return nil, err
+			}
+		}
+
+		addresses = append(addresses, batch...)
+
+		if opts.ProgressCallback != nil {
+			opts.ProgressCallback(uint32(len(addresses)), count)
+		}
+	}
+
+	return addresses, nil
+}
+
+// calculateNextLayer calculates the nodes of the next layer of the tree by hashing two nodes of the given layer.
+func calculateNextLayer(lastLayer []trinary.Hash, parallelism int) []trinary.Hash {
+
+	result := make([]trinary.Hash, len(lastLayer)/2)
+
+	var wg sync.WaitGroup
+	input := make(chan int)
+	wg.Add(parallelism)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer wg.Done()
+
+			for index := range input {
+				sp := kerl.NewKerl()
+				sp.MustAbsorbTrytes(lastLayer[index*2])
+				sp.MustAbsorbTrytes(lastLayer[index*2+1])
+				result[index] = sp.MustSqueezeTrytes(consts.HashTrinarySize)
+			}
+		}()
+	}
+
+	for index := range result {
+		input <- index
+	}
+	close(input)
+	wg.Wait()
+
+	return result
+}
+
+// computeAddress generates the address of the given index with the SHAKE256 key derivation of the coordinator.
+func computeAddress(seed trinary.Hash, index uint32, securityLvl consts.SecurityLevel) (trinary.Hash, error) {
+	k := kerl.NewKerl()
+
+	subSeedTrits, err := signing.Subseed(seed, uint64(index), k)
+	if err != nil {
+		return "", err
+	}
+
+	keyTrits, err := key.Shake(subSeedTrits, securityLvl)
+	if err != nil {
+		return "", err
+	}
+
+	digestsTrits, err := signing.Digests(keyTrits, k)
+	if err != nil {
+		return "", err
+	}
+
+	addressTrits, err := signing.Address(digestsTrits, k)
+	if err != nil {
+		return "", err
+	}
+
+	return trinary.TritsToTrytes(addressTrits)
+}
@@ -0,0 +1,80 @@
+package merkletree
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/merkle"
+)
+
+const (
+	testSeed  = "QWERTYUIOPASDFGHJKLZXCVBNMQWERTYUIOPASDFGHJKLZXCVBNMQWERTYUIOPASDFGHJKLZXCVBNM999"
+	testDepth = 4
+)
+
+func TestCreateMatchesIotaGo(t *testing.T) {
+	expected, err := merkle.CreateMerkleTree(testSeed, consts.SecurityLevelMedium, testDepth)
+	require.NoError(t, err)
+
+	mt, err := Create(testSeed, consts.SecurityLevelMedium, testDepth, Options{Parallelism: 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Root, mt.Root)
+	assert.Equal(t, expected.Layers, mt.Layers)
+}
+
+func TestCreateResumesFromCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "merkletree")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	checkpointPath := filepath.Join(dir, "tree.checkpoint")
+	count := uint32(1 << testDepth)
+
+	firstAddress, err := computeAddress(testSeed, 0, consts.SecurityLevelMedium)
+	require.NoError(t, err)
+
+	// checkpoint the first addresses and simulate an interrupted write
+	cp, _, err := openCheckpoint(checkpointPath, consts.SecurityLevelMedium, count, firstAddress, nil)
+	require.NoError(t, err)
+	var partial []string
+	for i := uint32(0); i < 5; i++ {
+		address, err := computeAddress(testSeed, i, consts.SecurityLevelMedium)
+		require.NoError(t, err)
+		partial = append(partial, address)
+	}
+	require.NoError(t, cp.append(partial))
+	_, err = cp.file.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, cp.close())
+
+	var progress []uint32
+	mt, err := Create(testSeed, consts.SecurityLevelMedium, testDepth, Options{
+		Parallelism:        2,
+		CheckpointFilePath: checkpointPath,
+		ProgressCallback: func(calculated uint32, _ uint32) {
+			progress = append(progress, calculated)
+		},
+	})
+	require.NoError(t, err)
+
+	expected, err := merkle.CreateMerkleTree(testSeed, consts.SecurityLevelMedium, testDepth)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Root, mt.Root)
+
+	// only the missing addresses were calculated
+	require.NotEmpty(t, progress)
+	assert.Equal(t, count, progress[len(progress)-1])
+
+	// the checkpoint of another seed is rejected
+	_, err = Create(strings.Replace(testSeed, "Q", "9", 1), consts.SecurityLevelMedium, testDepth, Options{CheckpointFilePath: checkpointPath})
+	assert.True(t, errors.Is(err, ErrCheckpointMismatch))
+}
@@ -52,8 +52,16 @@ type ServerMetrics struct {
 	ValidatedBundles atomic.Uint32
 	// The number of seen spent addresses.
 	SeenSpentAddresses atomic.Uint32
+	// The number of pruned milestones.
+	PrunedMilestones atomic.Uint32
+	// The number of pruned transactions.
+	PrunedTransactions atomic.Uint32
 	// The number of non-lazy tips.
 	TipsNonLazy atomic.Uint32
 	// The number of semi-lazy tips.
 	TipsSemiLazy atomic.Uint32
+	// The number of bytes received from neighbors.
+	ReceivedBytes atomic.Uint64
+	// The number of bytes sent to neighbors.
+	SentBytes atomic.Uint64
 }
@@ -0,0 +1,96 @@
+package metricspush
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// InfluxDB pushes samples to the write endpoint of an InfluxDB in the line protocol.
+type InfluxDB struct {
+	writeURL string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewInfluxDB creates a sink which writes into the given database of the InfluxDB at the given URL.
+// The write endpoint of InfluxDB 1.x is used, which is also supported by InfluxDB 2.x.
+func NewInfluxDB(serverURL string, database string, username string, password string, client *http.Client) (*InfluxDB, error) {
+	u, err := parseHTTPURL(serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/write"
+	query := u.Query()
+	query.Set("db", database)
+	query.Set("precision", "ns")
+	u.RawQuery = query.Encode()
+
+	return &InfluxDB{writeURL: u.String(), username: username, password: password, client: client}, nil
+}
+
+// Name returns the name of the sink.
+func (i *InfluxDB) Name() string {
+	return "InfluxDB"
+}
+
+// Send writes the given samples into the database.
+func (i *InfluxDB) Send(samples []*Sample) error {
+	req, err := http.NewRequest(http.MethodPost, i.writeURL, bytes.NewReader(EncodeLineProtocol(samples)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if i.username != "" {
+		req.SetBasicAuth(i.username, i.password)
+	}
+
+	return doRequest(i.client, req)
+}
+
+// EncodeLineProtocol encodes the given samples in the InfluxDB line protocol.
+// The metric name is used as the measurement, the labels as tags and the value as the "value" field.
+// Samples which are not a number or infinite are skipped, since InfluxDB can't store them.
+func EncodeLineProtocol(samples []*Sample) []byte {
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+			continue
+		}
+
+		buf.WriteString(measurementEscaper.Replace(sample.Name))
+		for _, label := range sample.Labels {
+			// empty tag values are not allowed
+			if label.Value == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, ",%s=%s", tagEscaper.Replace(label.Name), tagEscaper.Replace(label.Value))
+		}
+		buf.WriteString(" value=")
+		buf.WriteString(strconv.FormatFloat(sample.Value, 'g', -1, 64))
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatInt(sample.Timestamp.UnixNano(), 10))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// parseHTTPURL parses the given URL and checks whether it is an absolute HTTP(S) URL.
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidURL, rawURL)
+	}
+	return u, nil
+}
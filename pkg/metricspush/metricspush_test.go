@@ -0,0 +1,246 @@
+package metricspush_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/gohornet/hornet/pkg/metricspush"
+)
+
+var timestamp = time.Unix(1600000000, 123000000)
+
+func testSample(name string, value float64, labels ...metricspush.Label) *metricspush.Sample {
+	return &metricspush.Sample{Name: name, Labels: labels, Value: value, Timestamp: timestamp}
+}
+
+func TestSamplesFromMetricFamilies(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "iota_test_gauge"}, []string{"type"})
+	gauge.WithLabelValues("a").Set(3)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "iota_test_histogram", Buckets: []float64{1, 5}})
+	histogram.Observe(2)
+	registry.MustRegister(gauge, histogram)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	samples := metricspush.SamplesFromMetricFamilies(families, timestamp, map[string]string{"instance": "node"})
+
+	var lines []string
+	for _, sample := range samples {
+		lines = append(lines, string(metricspush.EncodeLineProtocol([]*metricspush.Sample{sample})))
+	}
+	assert.Equal(t, []string{
+		"iota_test_gauge,instance=node,type=a value=3 1600000000123000000\n",
+		"iota_test_histogram_bucket,instance=node,le=1 value=0 1600000000123000000\n",
+		"iota_test_histogram_bucket,instance=node,le=5 value=1 1600000000123000000\n",
+		"iota_test_histogram_bucket,instance=node,le=+Inf value=1 1600000000123000000\n",
+		"iota_test_histogram_sum,instance=node value=2 1600000000123000000\n",
+		"iota_test_histogram_count,instance=node value=1 1600000000123000000\n",
+	}, lines)
+}
+
+func TestEncodeLineProtocol(t *testing.T) {
+	encoded := metricspush.EncodeLineProtocol([]*metricspush.Sample{
+		testSample("iota test,metric", 1.5, metricspush.Label{Name: "empty"}, metricspush.Label{Name: "name", Value: "a b=c,d"}),
+		testSample("iota_nan", math.NaN()),
+	})
+	assert.Equal(t, "iota\\ test\\,metric,name=a\\ b\\=c\\,d value=1.5 1600000000123000000\n", string(encoded))
+}
+
+// decodeWriteRequest decodes a WriteRequest into a map of label sets to their sample values and timestamps.
+func decodeWriteRequest(t *testing.T, data []byte) map[string][][2]float64 {
+	result := make(map[string][][2]float64)
+
+	forEachField := func(data []byte, f func(num protowire.Number, typ protowire.Type, data []byte)) {
+		for len(data) > 0 {
+			num, typ, n := protowire.ConsumeTag(data)
+			require.GreaterOrEqual(t, n, 0)
+			data = data[n:]
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			require.GreaterOrEqual(t, n, 0)
+			f(num, typ, data[:n])
+			data = data[n:]
+		}
+	}
+
+	forEachField(data, func(_ protowire.Number, _ protowire.Type, field []byte) {
+		timeSeries, _ := protowire.ConsumeBytes(field)
+
+		var labels []string
+		var samples [][2]float64
+		forEachField(timeSeries, func(num protowire.Number, _ protowire.Type, field []byte) {
+			message, _ := protowire.ConsumeBytes(field)
+			switch num {
+			case 1:
+				var pair []string
+				forEachField(message, func(_ protowire.Number, _ protowire.Type, field []byte) {
+					value, _ := protowire.ConsumeString(field)
+					pair = append(pair, value)
+				})
+				labels = append(labels, strings.Join(pair, "="))
+			case 2:
+				var sample [2]float64
+				forEachField(message, func(num protowire.Number, _ protowire.Type, field []byte) {
+					switch num {
+					case 1:
+						value, _ := protowire.ConsumeFixed64(field)
+						sample[0] = math.Float64frombits(value)
+					case 2:
+						value, _ := protowire.ConsumeVarint(field)
+						sample[1] = float64(value)
+					}
+				})
+				samples = append(samples, sample)
+			}
+		})
+		result[strings.Join(labels, ",")] = samples
+	})
+
+	return result
+}
+
+func TestRemoteWrite(t *testing.T) {
+	var received map[string][][2]float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", username)
+		assert.Equal(t, "secret", password)
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		data, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		received = decodeWriteRequest(t, data)
+	}))
+	defer server.Close()
+
+	sink, err := metricspush.NewRemoteWrite(server.URL+"/api/v1/write", "user", "secret", server.Client())
+	require.NoError(t, err)
+
+	later := testSample("iota_a", 2, metricspush.Label{Name: "type", Value: "x"})
+	later.Timestamp = timestamp.Add(time.Second)
+
+	require.NoError(t, sink.Send([]*metricspush.Sample{
+		testSample("iota_a", 1, metricspush.Label{Name: "type", Value: "x"}),
+		testSample("iota_b", 5, metricspush.Label{Name: "instance", Value: "node"}),
+		later,
+	}))
+
+	assert.Equal(t, map[string][][2]float64{
+		"__name__=iota_a,type=x":        {{1, 1600000000123}, {2, 1600000001123}},
+		"__name__=iota_b,instance=node": {{5, 1600000000123}},
+	}, received)
+}
+
+func TestNewSinkInvalidURL(t *testing.T) {
+	_, err := metricspush.NewInfluxDB("localhost:8086", "hornet", "", "", http.DefaultClient)
+	assert.True(t, errors.Is(err, metricspush.ErrInvalidURL))
+
+	_, err = metricspush.NewRemoteWrite("ftp://localhost/write", "", "", http.DefaultClient)
+	assert.True(t, errors.Is(err, metricspush.ErrInvalidURL))
+}
+
+func TestPusherRetry(t *testing.T) {
+	var requests int
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/influx/write", r.URL.Path)
+		assert.Equal(t, "hornet", r.URL.Query().Get("db"))
+
+		// every first attempt fails
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		lines = append(lines, strings.Split(strings.TrimSpace(string(body)), "\n")...)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := metricspush.NewInfluxDB(server.URL+"/influx/", "hornet", "", "", server.Client())
+	require.NoError(t, err)
+
+	pusher := metricspush.NewPusher(sink, metricspush.Options{BatchSize: 2, MaxBufferedSamples: 10, MaxRetries: 1, RetryBackoff: time.Millisecond})
+	pusher.Add([]*metricspush.Sample{testSample("iota_a", 1), testSample("iota_b", 2), testSample("iota_c", 3)})
+
+	require.NoError(t, pusher.Flush(nil))
+	assert.Equal(t, 4, requests)
+	assert.Len(t, lines, 3)
+	assert.Equal(t, 0, pusher.Buffered())
+
+	sent, dropped, failed := pusher.Stats()
+	assert.Equal(t, uint64(3), sent)
+	assert.Equal(t, uint64(0), dropped)
+	assert.Equal(t, uint64(0), failed)
+}
+
+func TestPusherBuffer(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := metricspush.NewInfluxDB(server.URL, "hornet", "", "", server.Client())
+	require.NoError(t, err)
+
+	pusher := metricspush.NewPusher(sink, metricspush.Options{BatchSize: 2, MaxBufferedSamples: 3, MaxRetries: 0})
+
+	// unreachable sinks keep the samples, the oldest ones are dropped if the buffer is full
+	pusher.Add([]*metricspush.Sample{testSample("iota_a", 1), testSample("iota_b", 2)})
+	assert.True(t, errors.Is(pusher.Flush(nil), metricspush.ErrUnexpectedStatus))
+	pusher.Add([]*metricspush.Sample{testSample("iota_c", 3), testSample("iota_d", 4)})
+	assert.Equal(t, 3, pusher.Buffered())
+
+	// permanently rejected samples are discarded
+	status = http.StatusBadRequest
+	assert.True(t, errors.Is(pusher.Flush(nil), metricspush.ErrUnexpectedStatus))
+	assert.Equal(t, 1, pusher.Buffered())
+
+	sent, dropped, failed := pusher.Stats()
+	assert.Equal(t, uint64(0), sent)
+	assert.Equal(t, uint64(1), dropped)
+	assert.Equal(t, uint64(2), failed)
+}
+
+func TestPusherFlushAbort(t *testing.T) {
+	var requests int
+	abortSignal := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the deadline of the flush is reached while the first batch is sent
+		close(abortSignal)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := metricspush.NewInfluxDB(server.URL, "hornet", "", "", server.Client())
+	require.NoError(t, err)
+
+	pusher := metricspush.NewPusher(sink, metricspush.Options{BatchSize: 1, MaxBufferedSamples: 10})
+	pusher.Add([]*metricspush.Sample{testSample("iota_a", 1), testSample("iota_b", 2), testSample("iota_c", 3)})
+
+	// no further batches are sent after the abort
+	assert.True(t, errors.Is(pusher.Flush(abortSignal), metricspush.ErrAborted))
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 2, pusher.Buffered())
+}
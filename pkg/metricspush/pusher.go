@@ -0,0 +1,210 @@
+package metricspush
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+var (
+	// ErrInvalidURL is returned if the URL of a sink is not an absolute HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid push URL")
+	// ErrUnexpectedStatus is returned if the receiver didn't answer with a 2xx status code.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+	// ErrAborted is returned if a flush was aborted before all samples were sent.
+	ErrAborted = errors.New("push aborted")
+)
+
+// StatusError is returned if the receiver answered with a non 2xx status code.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrUnexpectedStatus, e.StatusCode)
+}
+
+// Unwrap allows errors.Is(err, ErrUnexpectedStatus).
+func (e *StatusError) Unwrap() error {
+	return ErrUnexpectedStatus
+}
+
+// Retryable tells whether sending the same samples again might succeed.
+// Client errors except for rate limiting are caused by the samples or the configuration and won't go away by retrying.
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode < 400 || e.StatusCode > 499
+}
+
+// Sink is a monitoring system the samples are pushed to.
+type Sink interface {
+	// Name returns the name of the sink.
+	Name() string
+	// Send sends the given samples to the monitoring system.
+	Send(samples []*Sample) error
+}
+
+// Options define how samples are batched and retried.
+type Options struct {
+	// The maximum amount of samples sent in a single request.
+	BatchSize int
+	// The maximum amount of samples buffered while the sink is unreachable, the oldest samples are dropped first.
+	MaxBufferedSamples int
+	// How often a failed request is retried per flush.
+	MaxRetries int
+	// The delay before the first retry, it is doubled for every further retry.
+	RetryBackoff time.Duration
+}
+
+// Pusher buffers samples for a sink and sends them in batches.
+type Pusher struct {
+	syncutils.Mutex
+
+	sink    Sink
+	opts    Options
+	buffer  []*Sample
+	sent    uint64
+	dropped uint64
+	failed  uint64
+}
+
+// NewPusher creates a pusher which sends samples to the given sink.
+func NewPusher(sink Sink, opts Options) *Pusher {
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 1
+	}
+	if opts.MaxBufferedSamples < opts.BatchSize {
+		opts.MaxBufferedSamples = opts.BatchSize
+	}
+	return &Pusher{sink: sink, opts: opts}
+}
+
+// Sink returns the sink of the pusher.
+func (p *Pusher) Sink() Sink {
+	return p.sink
+}
+
+// Add buffers the given samples until the next flush.
+func (p *Pusher) Add(samples []*Sample) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.buffer = append(p.buffer, samples...)
+	p.trimWithoutLocking()
+}
+
+// trimWithoutLocking drops the oldest samples if the buffer exceeds its maximum size.
+func (p *Pusher) trimWithoutLocking() {
+	if overflow := len(p.buffer) - p.opts.MaxBufferedSamples; overflow > 0 {
+		p.dropped += uint64(overflow)
+		p.buffer = append([]*Sample(nil), p.buffer[overflow:]...)
+	}
+}
+
+// Buffered returns the amount of samples waiting to be sent.
+func (p *Pusher) Buffered() int {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.buffer)
+}
+
+// Stats returns the amount of samples that were sent, dropped because the buffer was full or rejected by the sink.
+func (p *Pusher) Stats() (sent uint64, dropped uint64, failed uint64) {
+	p.Lock()
+	defer p.Unlock()
+	return p.sent, p.dropped, p.failed
+}
+
+// Flush sends all buffered samples in batches.
+// Failed requests are retried with an exponential backoff. If a batch still can't be sent,
+// it is kept in the buffer for the next flush, unless the sink rejected the samples permanently.
+// The abort signal stops the backoff and the sending of further batches and leaves the remaining samples in the buffer.
+func (p *Pusher) Flush(abortSignal <-chan struct{}) error {
+	for {
+		p.Lock()
+		if len(p.buffer) == 0 {
+			p.Unlock()
+			return nil
+		}
+		select {
+		case <-abortSignal:
+			buffered := len(p.buffer)
+			p.Unlock()
+			return fmt.Errorf("%w: %d samples for %s left", ErrAborted, buffered, p.sink.Name())
+		default:
+		}
+		batchSize := p.opts.BatchSize
+		if batchSize > len(p.buffer) {
+			batchSize = len(p.buffer)
+		}
+		batch := p.buffer[:batchSize]
+		p.buffer = p.buffer[batchSize:]
+		p.Unlock()
+
+		err := p.send(batch, abortSignal)
+
+		p.Lock()
+		if err == nil {
+			p.sent += uint64(len(batch))
+			p.Unlock()
+			continue
+		}
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !statusErr.Retryable() {
+			p.failed += uint64(len(batch))
+			p.Unlock()
+			return fmt.Errorf("%s rejected %d samples: %w", p.sink.Name(), len(batch), err)
+		}
+
+		// keep the batch in front of the samples that were added in the meantime
+		p.buffer = append(append(make([]*Sample, 0, len(batch)+len(p.buffer)), batch...), p.buffer...)
+		p.trimWithoutLocking()
+		p.Unlock()
+		return fmt.Errorf("sending %d samples to %s failed: %w", len(batch), p.sink.Name(), err)
+	}
+}
+
+// send sends the given batch and retries it with an exponential backoff.
+func (p *Pusher) send(batch []*Sample, abortSignal <-chan struct{}) error {
+	backoff := p.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := p.sink.Send(batch)
+		if err == nil {
+			return nil
+		}
+
+		var statusErr *StatusError
+		if attempt >= p.opts.MaxRetries || (errors.As(err, &statusErr) && !statusErr.Retryable()) {
+			return err
+		}
+
+		select {
+		case <-abortSignal:
+			return fmt.Errorf("%w: %v", ErrAborted, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// doRequest executes the given request and checks the status code of the response.
+func doRequest(client *http.Client, req *http.Request) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// drain the body, so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &StatusError{StatusCode: res.StatusCode}
+	}
+	return nil
+}
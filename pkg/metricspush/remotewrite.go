@@ -0,0 +1,120 @@
+package metricspush
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RemoteWrite pushes samples to an endpoint supporting the Prometheus remote write protocol,
+// e.g. Prometheus itself, Cortex, Thanos or VictoriaMetrics.
+type RemoteWrite struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewRemoteWrite creates a sink which pushes to the remote write endpoint at the given URL.
+func NewRemoteWrite(writeURL string, username string, password string, client *http.Client) (*RemoteWrite, error) {
+	if _, err := parseHTTPURL(writeURL); err != nil {
+		return nil, err
+	}
+	return &RemoteWrite{url: writeURL, username: username, password: password, client: client}, nil
+}
+
+// Name returns the name of the sink.
+func (r *RemoteWrite) Name() string {
+	return "Prometheus remote write"
+}
+
+// Send pushes the given samples to the remote write endpoint.
+func (r *RemoteWrite) Send(samples []*Sample) error {
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(snappy.Encode(nil, EncodeWriteRequest(samples))))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	return doRequest(r.client, req)
+}
+
+// EncodeWriteRequest encodes the given samples as a protobuf WriteRequest of the remote write protocol.
+// Samples of the same time series are grouped, the samples of a series must be in chronological order.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label        { string name = 1; string value = 2; }
+//	message Sample       { double value = 1; int64 timestamp = 2; }
+func EncodeWriteRequest(samples []*Sample) []byte {
+	type series struct {
+		first   *Sample
+		samples []*Sample
+	}
+
+	var ordered []*series
+	seriesByKey := make(map[string]*series)
+	for _, sample := range samples {
+		key := sample.seriesKey()
+		s, exists := seriesByKey[key]
+		if !exists {
+			s = &series{first: sample}
+			seriesByKey[key] = s
+			ordered = append(ordered, s)
+		}
+		s.samples = append(s.samples, sample)
+	}
+
+	var writeRequest []byte
+	for _, s := range ordered {
+		var timeSeries []byte
+
+		// the metric name is a label as well, the labels must be sorted by name
+		nameAdded := false
+		for _, label := range s.first.Labels {
+			if !nameAdded && label.Name > MetricNameLabel {
+				timeSeries = appendLabel(timeSeries, MetricNameLabel, s.first.Name)
+				nameAdded = true
+			}
+			timeSeries = appendLabel(timeSeries, label.Name, label.Value)
+		}
+		if !nameAdded {
+			timeSeries = appendLabel(timeSeries, MetricNameLabel, s.first.Name)
+		}
+
+		for _, sample := range s.samples {
+			var encodedSample []byte
+			encodedSample = protowire.AppendTag(encodedSample, 1, protowire.Fixed64Type)
+			encodedSample = protowire.AppendFixed64(encodedSample, math.Float64bits(sample.Value))
+			encodedSample = protowire.AppendTag(encodedSample, 2, protowire.VarintType)
+			encodedSample = protowire.AppendVarint(encodedSample, uint64(sample.Timestamp.UnixNano()/1e6))
+
+			timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, encodedSample)
+		}
+
+		writeRequest = protowire.AppendTag(writeRequest, 1, protowire.BytesType)
+		writeRequest = protowire.AppendBytes(writeRequest, timeSeries)
+	}
+
+	return writeRequest
+}
+
+func appendLabel(b []byte, name string, value string) []byte {
+	var label []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, name)
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, value)
+
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, label)
+}
@@ -0,0 +1,108 @@
+// Package metricspush pushes the metrics of the node to central monitoring systems,
+// for nodes which can't be scraped, e.g. because they are behind a NAT or only run temporarily.
+package metricspush
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// MetricNameLabel is the label which holds the name of the metric in the Prometheus data model.
+	MetricNameLabel = "__name__"
+)
+
+// Label is a name/value pair which identifies a time series together with the metric name.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single value of a time series at a point in time.
+type Sample struct {
+	// The name of the metric.
+	Name string
+	// The labels of the time series, sorted by name.
+	Labels []Label
+	// The value of the sample.
+	Value float64
+	// The time the value was collected.
+	Timestamp time.Time
+}
+
+// seriesKey returns a key which is unique for the time series of the sample.
+func (s *Sample) seriesKey() string {
+	var b strings.Builder
+	b.WriteString(s.Name)
+	for _, label := range s.Labels {
+		b.WriteByte(0)
+		b.WriteString(label.Name)
+		b.WriteByte(0)
+		b.WriteString(label.Value)
+	}
+	return b.String()
+}
+
+// SamplesFromMetricFamilies converts the gathered metric families into samples with the given timestamp.
+// Histograms and summaries are split into their buckets/quantiles, sums and counts like in the Prometheus exposition format.
+// The given extra labels are added to every sample, e.g. to identify the node.
+func SamplesFromMetricFamilies(families []*dto.MetricFamily, timestamp time.Time, extraLabels map[string]string) []*Sample {
+	var samples []*Sample
+
+	add := func(name string, metric *dto.Metric, value float64, additional ...Label) {
+		labels := make([]Label, 0, len(metric.GetLabel())+len(extraLabels)+len(additional))
+		for _, pair := range metric.GetLabel() {
+			labels = append(labels, Label{Name: pair.GetName(), Value: pair.GetValue()})
+		}
+		for name, value := range extraLabels {
+			labels = append(labels, Label{Name: name, Value: value})
+		}
+		labels = append(labels, additional...)
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+		samples = append(samples, &Sample{Name: name, Labels: labels, Value: value, Timestamp: timestamp})
+	}
+
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, metric, metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, metric, metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, metric, metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add(name, metric, quantile.GetValue(), Label{Name: "quantile", Value: formatFloat(quantile.GetQuantile())})
+				}
+				add(name+"_sum", metric, summary.GetSampleSum())
+				add(name+"_count", metric, float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				for _, bucket := range histogram.GetBucket() {
+					add(name+"_bucket", metric, float64(bucket.GetCumulativeCount()), Label{Name: "le", Value: formatFloat(bucket.GetUpperBound())})
+				}
+				add(name+"_bucket", metric, float64(histogram.GetSampleCount()), Label{Name: "le", Value: "+Inf"})
+				add(name+"_sum", metric, histogram.GetSampleSum())
+				add(name+"_count", metric, float64(histogram.GetSampleCount()))
+			}
+		}
+	}
+
+	return samples
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -1,6 +1,7 @@
 package coordinator
 
 import (
+	"bytes"
 	"crypto"
 	"fmt"
 	"os"
@@ -36,6 +37,8 @@ var (
 	ErrNoTipsGiven = errors.New("no tips given")
 	// ErrNetworkBootstrapped is returned when the flag for bootstrap network was given, but a state file already exists.
 	ErrNetworkBootstrapped = errors.New("network already bootstrapped")
+	// ErrStateConflict is returned when the state file of the coordinator does not match the milestones in the database.
+	ErrStateConflict = errors.New("coordinator state does not match the database")
 )
 
 // CoordinatorEvents are the events issued by the coordinator.
@@ -44,6 +47,10 @@ type CoordinatorEvents struct {
 	IssuedCheckpointTransaction *events.Event
 	// Fired when a milestone is issued.
 	IssuedMilestone *events.Event
+	// Fired when the state was reconstructed from the latest milestone in the database.
+	RecoveredState *events.Event
+	// Fired when a node of the quorum did not answer.
+	QuorumNodeError *events.Event
 }
 
 // Coordinator is used to issue signed transactions, called "milestones" to secure an IOTA network and prevent double spends.
@@ -51,7 +58,7 @@ type Coordinator struct {
 	milestoneLock syncutils.Mutex
 
 	// config options
-	seed                    trinary.Hash
+	signer                  MilestoneSigner
 	securityLvl             consts.SecurityLevel
 	merkleTreeDepth         int
 	minWeightMagnitude      int
@@ -65,6 +72,7 @@ type Coordinator struct {
 	state        *State
 	merkleTree   *merkle.MerkleTree
 	bootstrapped bool
+	quorum       *quorum
 
 	// events of the coordinator
 	Events *CoordinatorEvents
@@ -95,9 +103,9 @@ func MilestoneMerkleTreeHashFuncWithName(name string) crypto.Hash {
 }
 
 // New creates a new coordinator instance.
-func New(seed trinary.Hash, securityLvl consts.SecurityLevel, merkleTreeDepth int, minWeightMagnitude int, stateFilePath string, milestoneIntervalSec int, powHandler *pow.Handler, sendBundleFunc SendBundleFunc, milestoneMerkleHashFunc crypto.Hash) *Coordinator {
+func New(signer MilestoneSigner, securityLvl consts.SecurityLevel, merkleTreeDepth int, minWeightMagnitude int, stateFilePath string, milestoneIntervalSec int, powHandler *pow.Handler, sendBundleFunc SendBundleFunc, milestoneMerkleHashFunc crypto.Hash) *Coordinator {
 	result := &Coordinator{
-		seed:                    seed,
+		signer:                  signer,
 		securityLvl:             securityLvl,
 		merkleTreeDepth:         merkleTreeDepth,
 		minWeightMagnitude:      minWeightMagnitude,
@@ -109,6 +117,8 @@ func New(seed trinary.Hash, securityLvl consts.SecurityLevel, merkleTreeDepth in
 		Events: &CoordinatorEvents{
 			IssuedCheckpointTransaction: events.NewEvent(CheckpointCaller),
 			IssuedMilestone:             events.NewEvent(MilestoneCaller),
+			RecoveredState:              events.NewEvent(MilestoneCaller),
+			QuorumNodeError:             events.NewEvent(QuorumNodeErrorCaller),
 		},
 	}
 
@@ -135,6 +145,16 @@ func (coo *Coordinator) InitMerkleTree(filePath string, cooAddress trinary.Hash)
 	return nil
 }
 
+// InitQuorum enables the verification of the ledger state of every milestone with the given nodes before it is issued.
+// if minAgreements is zero, all nodes have to agree.
+func (coo *Coordinator) InitQuorum(nodes []string, jwt string, minAgreements int, timeout time.Duration) {
+	if len(nodes) == 0 {
+		coo.quorum = nil
+		return
+	}
+	coo.quorum = newQuorum(nodes, jwt, minAgreements, timeout)
+}
+
 // InitState loads an existing state file or bootstraps the network.
 func (coo *Coordinator) InitState(bootstrap bool, startIndex milestone.Index) error {
 
@@ -194,22 +214,78 @@ func (coo *Coordinator) InitState(bootstrap bool, startIndex milestone.Index) er
 		return err
 	}
 
-	if latestMilestoneFromDatabase != coo.state.LatestMilestoneIndex {
-		return fmt.Errorf("previous milestone does not match latest milestone in database. previous: %d, database: %d", coo.state.LatestMilestoneIndex, latestMilestoneFromDatabase)
+	if err := coo.recoverState(latestMilestoneFromDatabase); err != nil {
+		return err
+	}
+
+	coo.bootstrapped = true
+	return nil
+}
+
+// recoverState checks the loaded state against the latest milestone in the database.
+// If the coordinator crashed after a milestone was issued, but before the state file was stored,
+// the state is reconstructed from the milestone in the database.
+// Any other mismatch between the state and the database is returned as an error.
+func (coo *Coordinator) recoverState(latestMilestoneFromDatabase milestone.Index) error {
+
+	switch {
+	case latestMilestoneFromDatabase == coo.state.LatestMilestoneIndex+1:
+		// the latest milestone was issued, but the state was not stored anymore
+		cachedBndl := tangle.GetMilestoneOrNil(latestMilestoneFromDatabase) // bundle +1
+		if cachedBndl == nil {
+			return fmt.Errorf("latest milestone (%d) not found in database. database is corrupt", latestMilestoneFromDatabase)
+		}
+		defer cachedBndl.Release(true) // bundle -1
+
+		cachedTailTx := cachedBndl.GetBundle().GetTail() // tx +1
+		defer cachedTailTx.Release(true)                 // tx -1
+
+		// milestones always reference the previous milestone with the trunk of the head transaction
+		if trunkHash := cachedBndl.GetBundle().GetTrunkHash(true); !bytes.Equal(trunkHash, coo.state.LatestMilestoneHash) {
+			return errors.Wrapf(ErrStateConflict, "milestone %d in the database does not reference the milestone of the state: %v != %v", latestMilestoneFromDatabase, trunkHash.Trytes(), coo.state.LatestMilestoneHash.Trytes())
+		}
+
+		coo.state.LatestMilestoneIndex = latestMilestoneFromDatabase
+		coo.state.LatestMilestoneHash = cachedBndl.GetBundle().GetTailHash()
+		coo.state.LatestMilestoneTime = cachedTailTx.GetTransaction().GetTimestamp()
+		coo.state.LatestMilestoneTransactions = cachedBndl.GetBundle().GetTxHashes()
+		coo.state.LatestCheckpointIndex = 0
+		coo.state.LatestCheckpointHash = coo.state.LatestMilestoneHash
+
+		if err := coo.state.storeStateFile(coo.stateFilePath); err != nil {
+			return err
+		}
+
+		coo.Events.RecoveredState.Trigger(coo.state.LatestMilestoneIndex, coo.state.LatestMilestoneHash)
+		return nil
+
+	case latestMilestoneFromDatabase != coo.state.LatestMilestoneIndex:
+		return errors.Wrapf(ErrStateConflict, "previous milestone does not match latest milestone in database. previous: %d, database: %d", coo.state.LatestMilestoneIndex, latestMilestoneFromDatabase)
 	}
 
-	cachedBndl := tangle.GetMilestoneOrNil(latestMilestoneFromDatabase)
+	cachedBndl := tangle.GetMilestoneOrNil(latestMilestoneFromDatabase) // bundle +1
 	if cachedBndl == nil {
 		return fmt.Errorf("latest milestone (%d) not found in database. database is corrupt", latestMilestoneFromDatabase)
 	}
-	cachedBndl.Release()
+	defer cachedBndl.Release(true) // bundle -1
+
+	if tailHash := cachedBndl.GetBundle().GetTailHash(); !bytes.Equal(tailHash, coo.state.LatestMilestoneHash) {
+		return errors.Wrapf(ErrStateConflict, "hash of milestone %d does not match. state: %v, database: %v", latestMilestoneFromDatabase, coo.state.LatestMilestoneHash.Trytes(), tailHash.Trytes())
+	}
+
+	if !bytes.Equal(coo.state.LatestCheckpointHash, coo.state.LatestMilestoneHash) && !tangle.ContainsTransaction(coo.state.LatestCheckpointHash) {
+		// the checkpoint was not stored in the database anymore, continue the checkpoints at the latest milestone
+		coo.state.LatestCheckpointIndex = 0
+		coo.state.LatestCheckpointHash = coo.state.LatestMilestoneHash
+	}
 
-	coo.bootstrapped = true
 	return nil
 }
 
 // createAndSendMilestone creates a milestone, sends it to the network and stores a new coordinator state file.
-func (coo *Coordinator) createAndSendMilestone(trunkHash hornet.Hash, branchHash hornet.Hash, newMilestoneIndex milestone.Index) error {
+// If checkQuorum is set and a quorum is configured, the milestone is only issued if the quorum agrees on the ledger mutations.
+// Quorum errors are returned as the second, non-critical error.
+func (coo *Coordinator) createAndSendMilestone(trunkHash hornet.Hash, branchHash hornet.Hash, newMilestoneIndex milestone.Index, checkQuorum bool) (error, error) {
 
 	cachedTxMetas := make(map[string]*tangle.CachedMetadata)
 	cachedBundles := make(map[string]*tangle.CachedBundle)
@@ -231,16 +307,25 @@ func (coo *Coordinator) createAndSendMilestone(trunkHash hornet.Hash, branchHash
 	// compute merkle tree root
 	mutations, err := whiteflag.ComputeWhiteFlagMutations(cachedTxMetas, cachedBundles, coo.milestoneMerkleHashFunc, trunkHash, branchHash)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if checkQuorum && coo.quorum != nil {
+		// ask the quorum nodes whether they compute the same ledger mutations, otherwise the milestone is not issued
+		if err := coo.quorum.checkMerkleTreeHash(mutations.MerkleTreeHash, newMilestoneIndex, trunkHash, branchHash, func(nodeURL string, err error) {
+			coo.Events.QuorumNodeError.Trigger(nodeURL, err)
+		}); err != nil {
+			return err, nil
+		}
 	}
 
-	b, err := createMilestone(coo.seed, newMilestoneIndex, coo.securityLvl, trunkHash, branchHash, coo.minWeightMagnitude, coo.merkleTree, mutations.MerkleTreeHash, coo.powHandler)
+	b, err := createMilestone(coo.signer, newMilestoneIndex, coo.securityLvl, trunkHash, branchHash, coo.minWeightMagnitude, coo.merkleTree, mutations.MerkleTreeHash, coo.powHandler)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := coo.sendBundleFunc(b, true); err != nil {
-		return err
+		return nil, err
 	}
 
 	txHashes := hornet.Hashes{}
@@ -257,14 +342,16 @@ func (coo *Coordinator) createAndSendMilestone(trunkHash hornet.Hash, branchHash
 	coo.state.LatestMilestoneIndex = newMilestoneIndex
 	coo.state.LatestMilestoneTime = int64(tailTx.Timestamp)
 	coo.state.LatestMilestoneTransactions = txHashes
+	coo.state.LatestCheckpointIndex = 0
+	coo.state.LatestCheckpointHash = latestMilestoneHash
 
 	if err := coo.state.storeStateFile(coo.stateFilePath); err != nil {
-		return err
+		return nil, err
 	}
 
 	coo.Events.IssuedMilestone.Trigger(coo.state.LatestMilestoneIndex, coo.state.LatestMilestoneHash)
 
-	return nil
+	return nil, nil
 }
 
 // Bootstrap creates the first milestone, if the network was not bootstrapped yet.
@@ -277,7 +364,8 @@ func (coo *Coordinator) Bootstrap() (hornet.Hash, error) {
 	if !coo.bootstrapped {
 		// create first milestone to bootstrap the network
 		// trunk and branch reference the last known milestone or NullHash if startIndex = 1 (see InitState)
-		if err := coo.createAndSendMilestone(coo.state.LatestMilestoneHash, coo.state.LatestMilestoneHash, coo.state.LatestMilestoneIndex+1); err != nil {
+		// the quorum is not asked, because there are no mutations to agree on.
+		if _, err := coo.createAndSendMilestone(coo.state.LatestMilestoneHash, coo.state.LatestMilestoneHash, coo.state.LatestMilestoneIndex+1, false); err != nil {
 			// creating milestone failed => critical error
 			return nil, err
 		}
@@ -317,6 +405,13 @@ func (coo *Coordinator) IssueCheckpoint(checkpointIndex int, lastCheckpointHash
 
 		lastCheckpointHash = hornet.HashFromHashTrytes(b[0].Hash)
 
+		// persist the checkpoint, so the chain of checkpoints can be continued after a restart
+		coo.state.LatestCheckpointIndex = uint32(checkpointIndex)
+		coo.state.LatestCheckpointHash = lastCheckpointHash
+		if err := coo.state.storeStateFile(coo.stateFilePath); err != nil {
+			return nil, err
+		}
+
 		coo.Events.IssuedCheckpointTransaction.Trigger(checkpointIndex, i, len(tips), lastCheckpointHash)
 	}
 
@@ -335,10 +430,15 @@ func (coo *Coordinator) IssueMilestone(trunkHash hornet.Hash, branchHash hornet.
 		return nil, tangle.ErrNodeNotSynced, nil
 	}
 
-	if err := coo.createAndSendMilestone(trunkHash, branchHash, coo.state.LatestMilestoneIndex+1); err != nil {
+	quorumErr, err := coo.createAndSendMilestone(trunkHash, branchHash, coo.state.LatestMilestoneIndex+1, true)
+	if err != nil {
 		// creating milestone failed => critical error
 		return nil, nil, err
 	}
+	if quorumErr != nil {
+		// the quorum did not agree => the milestone was not issued, but the database is not affected
+		return nil, quorumErr, nil
+	}
 
 	return coo.state.LatestMilestoneHash, nil, nil
 }
@@ -14,3 +14,8 @@ func CheckpointCaller(handler interface{}, params ...interface{}) {
 func MilestoneCaller(handler interface{}, params ...interface{}) {
 	handler.(func(index milestone.Index, tailTxHash hornet.Hash))(params[0].(milestone.Index), params[1].(hornet.Hash))
 }
+
+// QuorumNodeErrorCaller is used to signal errors of quorum nodes.
+func QuorumNodeErrorCaller(handler interface{}, params ...interface{}) {
+	handler.(func(nodeURL string, err error))(params[0].(string), params[1].(error))
+}
@@ -0,0 +1,144 @@
+package coordinator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+var (
+	// ErrQuorumMerkleTreeHashMismatch is returned when a node of the quorum computed a different merkle tree hash for the next milestone.
+	ErrQuorumMerkleTreeHashMismatch = errors.New("merkle tree hash of a quorum node does not match")
+	// ErrQuorumNotReached is returned when not enough nodes of the quorum answered in time.
+	ErrQuorumNotReached = errors.New("not enough quorum nodes answered in time")
+)
+
+// quorumNodeResponse is the response of the "whiteFlag" command of a quorum node.
+type quorumNodeResponse struct {
+	MerkleTreeHash string `json:"merkleTreeHash"`
+	Error          string `json:"error"`
+}
+
+// quorum asks other nodes for the merkle tree hash of the white-flag mutations of the next milestone,
+// to make sure that the ledger state of the coordinator is consistent with the rest of the network.
+type quorum struct {
+	nodes         []string
+	jwt           string
+	minAgreements int
+	timeout       time.Duration
+	client        *http.Client
+}
+
+// newQuorum creates a new quorum with the given node API URLs.
+// if minAgreements is zero, all nodes have to agree.
+func newQuorum(nodes []string, jwt string, minAgreements int, timeout time.Duration) *quorum {
+	if minAgreements <= 0 || minAgreements > len(nodes) {
+		minAgreements = len(nodes)
+	}
+
+	return &quorum{
+		nodes:         nodes,
+		jwt:           jwt,
+		minAgreements: minAgreements,
+		timeout:       timeout,
+		client:        &http.Client{Timeout: timeout + time.Second},
+	}
+}
+
+// queryNode asks a single node for the merkle tree hash of the cone of the next milestone.
+func (q *quorum) queryNode(nodeURL string, milestoneIndex milestone.Index, trunkHash hornet.Hash, branchHash hornet.Hash) ([]byte, error) {
+
+	body, err := json.Marshal(map[string]interface{}{
+		"command":             "whiteFlag",
+		"trunkTransaction":    trunkHash.Trytes(),
+		"branchTransaction":   branchHash.Trytes(),
+		"milestoneIndex":      milestoneIndex,
+		"timeoutMilliseconds": q.timeout.Milliseconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(nodeURL, "/"), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-IOTA-API-Version", "1")
+	if q.jwt != "" {
+		req.Header.Set("Authorization", "Bearer "+q.jwt)
+	}
+
+	res, err := q.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	response := &quorumNodeResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+
+	return hex.DecodeString(response.MerkleTreeHash)
+}
+
+// checkMerkleTreeHash asks all nodes of the quorum for the merkle tree hash of the cone of the next milestone
+// and compares it to the hash computed by the coordinator.
+// Every mismatch aborts the milestone issuance, nodes that don't answer in time are only counted as missing agreements.
+func (q *quorum) checkMerkleTreeHash(merkleTreeHash []byte, milestoneIndex milestone.Index, trunkHash hornet.Hash, branchHash hornet.Hash, onNodeError func(nodeURL string, err error)) error {
+
+	type nodeResult struct {
+		nodeURL        string
+		merkleTreeHash []byte
+		err            error
+	}
+
+	results := make(chan *nodeResult, len(q.nodes))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(q.nodes))
+	for _, nodeURL := range q.nodes {
+		go func(nodeURL string) {
+			defer wg.Done()
+			hash, err := q.queryNode(nodeURL, milestoneIndex, trunkHash, branchHash)
+			results <- &nodeResult{nodeURL: nodeURL, merkleTreeHash: hash, err: err}
+		}(nodeURL)
+	}
+	wg.Wait()
+	close(results)
+
+	agreements := 0
+	for result := range results {
+		if result.err != nil {
+			if onNodeError != nil {
+				onNodeError(result.nodeURL, result.err)
+			}
+			continue
+		}
+
+		if !bytes.Equal(result.merkleTreeHash, merkleTreeHash) {
+			return errors.Wrapf(ErrQuorumMerkleTreeHashMismatch, "node: %s, milestone: %d, expected: %s, got: %s", result.nodeURL, milestoneIndex, hex.EncodeToString(merkleTreeHash), hex.EncodeToString(result.merkleTreeHash))
+		}
+		agreements++
+	}
+
+	if agreements < q.minAgreements {
+		return errors.Wrapf(ErrQuorumNotReached, "milestone: %d, agreements: %d/%d", milestoneIndex, agreements, q.minAgreements)
+	}
+
+	return nil
+}
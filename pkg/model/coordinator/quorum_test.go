@@ -0,0 +1,54 @@
+package coordinator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+func newQuorumTestNode(t *testing.T, merkleTreeHash []byte, errorMessage string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := make(map[string]interface{})
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "whiteFlag", request["command"])
+
+		json.NewEncoder(w).Encode(&quorumNodeResponse{MerkleTreeHash: hex.EncodeToString(merkleTreeHash), Error: errorMessage})
+	}))
+}
+
+func TestQuorumCheckMerkleTreeHash(t *testing.T) {
+	merkleTreeHash := []byte{1, 2, 3, 4}
+
+	agreeingNode := newQuorumTestNode(t, merkleTreeHash, "")
+	defer agreeingNode.Close()
+
+	disagreeingNode := newQuorumTestNode(t, []byte{4, 3, 2, 1}, "")
+	defer disagreeingNode.Close()
+
+	failingNode := newQuorumTestNode(t, nil, "cone of milestone 2 did not become solid in time")
+	defer failingNode.Close()
+
+	check := func(q *quorum) error {
+		return q.checkMerkleTreeHash(merkleTreeHash, 2, hornet.NullHashBytes, hornet.NullHashBytes, nil)
+	}
+
+	// all nodes agree
+	assert.NoError(t, check(newQuorum([]string{agreeingNode.URL, agreeingNode.URL}, "", 0, time.Second)))
+
+	// a single mismatch aborts the issuance, even if enough nodes agree
+	err := check(newQuorum([]string{agreeingNode.URL, disagreeingNode.URL}, "", 1, time.Second))
+	assert.True(t, errors.Is(err, ErrQuorumMerkleTreeHashMismatch))
+
+	// failing nodes only count as missing agreements
+	assert.NoError(t, check(newQuorum([]string{agreeingNode.URL, failingNode.URL}, "", 1, time.Second)))
+	err = check(newQuorum([]string{agreeingNode.URL, failingNode.URL}, "", 0, time.Second))
+	assert.True(t, errors.Is(err, ErrQuorumNotReached))
+}
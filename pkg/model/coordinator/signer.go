@@ -0,0 +1,236 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/merkle"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+const (
+	// the timeout for a signing request to the remote signer
+	remoteSignerTimeout = 30 * time.Second
+	// the metadata key of the token which authenticates the coordinator at the remote signer
+	remoteSignerAuthorizationKey = "authorization"
+)
+
+var (
+	// ErrMilestoneIndexAlreadySigned is returned when the remote signer is asked to sign a milestone index for a second time.
+	ErrMilestoneIndexAlreadySigned = errors.New("milestone index was already signed")
+)
+
+// MilestoneSigner signs the hash of a milestone with the key of the Merkle tree leaf of the milestone index.
+type MilestoneSigner interface {
+	// SignatureFragments returns the signature fragments of the given hash.
+	SignatureFragments(index milestone.Index, securityLvl consts.SecurityLevel, hashToSign trinary.Hash) ([]trinary.Trytes, error)
+}
+
+// seedSigner signs the milestones with the coordinator seed on the node itself.
+type seedSigner struct {
+	seed trinary.Hash
+}
+
+// NewSeedSigner creates a MilestoneSigner which signs the milestones with the given seed.
+func NewSeedSigner(seed trinary.Hash) MilestoneSigner {
+	return &seedSigner{seed: seed}
+}
+
+func (s *seedSigner) SignatureFragments(index milestone.Index, securityLvl consts.SecurityLevel, hashToSign trinary.Hash) ([]trinary.Trytes, error) {
+	return merkle.SignatureFragments(s.seed, uint32(index), securityLvl, hashToSign)
+}
+
+// tokenCredentials adds the token to every request to the remote signer.
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (t *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{remoteSignerAuthorizationKey: "Bearer " + t.token}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
+
+// remoteSigner requests the signatures of the milestones from a remote signer via gRPC.
+type remoteSigner struct {
+	client grpcapi.MilestoneSignerClient
+}
+
+// NewRemoteSigner creates a MilestoneSigner which requests the signatures from the remote signer at the given address.
+// If a CA certificate path is given, the connection is secured with TLS. The token is used to authenticate at the remote signer.
+func NewRemoteSigner(address string, caCertificatePath string, token string) (MilestoneSigner, error) {
+
+	opts := []grpc.DialOption{}
+
+	if caCertificatePath != "" {
+		creds, err := credentials.NewClientTLSFromFile(caCertificatePath, "")
+		if err != nil {
+			return nil, fmt.Errorf("loading the certificate of the remote signer failed: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&tokenCredentials{token: token, requireTLS: caCertificatePath != ""}))
+	}
+
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteSigner{client: grpcapi.NewMilestoneSignerClient(conn)}, nil
+}
+
+func (s *remoteSigner) SignatureFragments(index milestone.Index, securityLvl consts.SecurityLevel, hashToSign trinary.Hash) ([]trinary.Trytes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer cancel()
+
+	res, err := s.client.SignMilestone(ctx, &grpcapi.SignMilestoneRequest{
+		MilestoneIndex: uint32(index),
+		SecurityLevel:  uint32(securityLvl),
+		Hash:           hashToSign,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+
+	if len(res.GetSignatureFragments()) != int(securityLvl) {
+		return nil, fmt.Errorf("remote signer returned %d signature fragments, expected: %d", len(res.GetSignatureFragments()), securityLvl)
+	}
+
+	return res.GetSignatureFragments(), nil
+}
+
+// SignerServer implements the grpcapi.MilestoneSignerServer interface.
+// It keeps track of the latest signed milestone index, so that no key of the Merkle tree is used twice.
+type SignerServer struct {
+	sync.Mutex
+
+	signer        MilestoneSigner
+	token         string
+	stateFilePath string
+	latestIndex   milestone.Index
+}
+
+// NewSignerServer creates a SignerServer which signs the milestones with the given seed.
+// The latest signed milestone index is persisted in the given state file.
+// If a token is given, the requests have to be authenticated with it.
+func NewSignerServer(seed trinary.Hash, token string, stateFilePath string) (*SignerServer, error) {
+
+	server := &SignerServer{
+		signer:        NewSeedSigner(seed),
+		token:         token,
+		stateFilePath: stateFilePath,
+	}
+
+	data, err := ioutil.ReadFile(stateFilePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	case len(data) != 4:
+		return nil, fmt.Errorf("invalid signer state file: %s", stateFilePath)
+	default:
+		server.latestIndex = milestone.Index(binary.LittleEndian.Uint32(data))
+	}
+
+	return server, nil
+}
+
+// LatestIndex returns the latest signed milestone index.
+func (s *SignerServer) LatestIndex() milestone.Index {
+	s.Lock()
+	defer s.Unlock()
+	return s.latestIndex
+}
+
+// storeLatestIndex persists the latest signed milestone index before the signature leaves the signer.
+func (s *SignerServer) storeLatestIndex(index milestone.Index) error {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, uint32(index))
+
+	tempFilePath := s.stateFilePath + "_tmp"
+	if err := ioutil.WriteFile(tempFilePath, data, 0660); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, s.stateFilePath)
+}
+
+func (s *SignerServer) authorize(ctx context.Context) error {
+	if s.token == "" {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	for _, value := range md.Get(remoteSignerAuthorizationKey) {
+		if value == "Bearer "+s.token {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid token")
+}
+
+func (s *SignerServer) SignMilestone(ctx context.Context, req *grpcapi.SignMilestoneRequest) (*grpcapi.SignMilestoneResponse, error) {
+
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if !guards.IsTrytesOfExactLength(req.GetHash(), consts.HashTrytesSize) {
+		return nil, status.Error(codes.InvalidArgument, "invalid hash")
+	}
+
+	securityLvl := consts.SecurityLevel(req.GetSecurityLevel())
+	if securityLvl < consts.SecurityLevelLow || securityLvl > consts.SecurityLevelHigh {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid security level: %d", securityLvl)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	index := milestone.Index(req.GetMilestoneIndex())
+	if index <= s.latestIndex {
+		return nil, status.Error(codes.FailedPrecondition, errors.Wrapf(ErrMilestoneIndexAlreadySigned, "index: %d, latest: %d", index, s.latestIndex).Error())
+	}
+
+	fragments, err := s.signer.SignatureFragments(index, securityLvl, req.GetHash())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := s.storeLatestIndex(index); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.latestIndex = index
+
+	return &grpcapi.SignMilestoneResponse{SignatureFragments: fragments}, nil
+}
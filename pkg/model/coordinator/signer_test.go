@@ -0,0 +1,69 @@
+package coordinator_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/model/coordinator"
+)
+
+const (
+	testSeed = "TESTSEED9999999999999999999999999999999999999999999999999999999999999999999999999"
+	testHash = "TESTHASH9999999999999999999999999999999999999999999999999999999999999999999999999"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "coo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stateFilePath := filepath.Join(dir, "signer.state")
+
+	signerServer, err := coordinator.NewSignerServer(testSeed, "secret", stateFilePath)
+	assert.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	server := grpc.NewServer()
+	grpcapi.RegisterMilestoneSignerServer(server, signerServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	remoteSigner, err := coordinator.NewRemoteSigner(listener.Addr().String(), "", "secret")
+	assert.NoError(t, err)
+
+	// the remote signer has to create the same signature as the seed on the node
+	fragments, err := remoteSigner.SignatureFragments(2, consts.SecurityLevelLow, testHash)
+	assert.NoError(t, err)
+
+	expected, err := coordinator.NewSeedSigner(testSeed).SignatureFragments(2, consts.SecurityLevelLow, testHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fragments)
+
+	// a milestone index must never be signed twice
+	_, err = remoteSigner.SignatureFragments(2, consts.SecurityLevelLow, testHash)
+	assert.Error(t, err)
+	_, err = remoteSigner.SignatureFragments(1, consts.SecurityLevelLow, testHash)
+	assert.Error(t, err)
+
+	// requests without the token are rejected
+	unauthorizedSigner, err := coordinator.NewRemoteSigner(listener.Addr().String(), "", "")
+	assert.NoError(t, err)
+	_, err = unauthorizedSigner.SignatureFragments(3, consts.SecurityLevelLow, testHash)
+	assert.Error(t, err)
+
+	// the latest signed index survives a restart of the signer
+	restartedServer, err := coordinator.NewSignerServer(testSeed, "secret", stateFilePath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, restartedServer.LatestIndex())
+}
@@ -1,6 +1,7 @@
 package coordinator
 
 import (
+	"bytes"
 	"encoding"
 	"encoding/binary"
 	"fmt"
@@ -11,6 +12,14 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 )
 
+const (
+	// the magic bytes at the beginning of a versioned state file.
+	// state files without the magic bytes are parsed in the legacy format.
+	stateFileMagic = "HCOS"
+	// the version of the state file format
+	stateFileVersion byte = 1
+)
+
 // State stores the latest state of the coordinator.
 type State struct {
 	encoding.BinaryMarshaler
@@ -22,25 +31,43 @@ type State struct {
 
 	// LatestMilestoneTransactions are the transaction hashes of the latest milestone
 	LatestMilestoneTransactions hornet.Hashes
+
+	// LatestCheckpointIndex is the index of the latest checkpoint since the latest milestone
+	LatestCheckpointIndex uint32
+	// LatestCheckpointHash is the hash of the latest issued checkpoint transaction, or the hash of the latest milestone
+	LatestCheckpointHash hornet.Hash
 }
 
 // MarshalBinary returns the binary representation of the coordinator state.
 func (cs *State) MarshalBinary() (data []byte, err error) {
 
 	/*
+		 4 bytes     			    Magic
+		 1 byte  uint8              Version
 		 4 bytes uint32 			LatestMilestoneIndex
 		49 bytes     			    LatestMilestoneHash
 		 8 bytes uint64 			LatestMilestoneTime
+		 4 bytes uint32 			LatestCheckpointIndex
+		49 bytes     			    LatestCheckpointHash
 		49 bytes                    LatestMilestoneTransactions	(x latestMilestoneTransactionsCount)
 	*/
 
-	data = make([]byte, 4+49+8+(49*len(cs.LatestMilestoneTransactions)))
+	latestCheckpointHash := cs.LatestCheckpointHash
+	if latestCheckpointHash == nil {
+		latestCheckpointHash = cs.LatestMilestoneHash
+	}
 
-	binary.LittleEndian.PutUint32(data[0:4], uint32(cs.LatestMilestoneIndex))
-	copy(data[4:53], cs.LatestMilestoneHash)
-	binary.LittleEndian.PutUint64(data[53:61], uint64(cs.LatestMilestoneTime))
+	data = make([]byte, 5+4+49+8+4+49+(49*len(cs.LatestMilestoneTransactions)))
 
-	offset := 61
+	copy(data[0:4], stateFileMagic)
+	data[4] = stateFileVersion
+	binary.LittleEndian.PutUint32(data[5:9], uint32(cs.LatestMilestoneIndex))
+	copy(data[9:58], cs.LatestMilestoneHash)
+	binary.LittleEndian.PutUint64(data[58:66], uint64(cs.LatestMilestoneTime))
+	binary.LittleEndian.PutUint32(data[66:70], cs.LatestCheckpointIndex)
+	copy(data[70:119], latestCheckpointHash)
+
+	offset := 119
 	for _, txHash := range cs.LatestMilestoneTransactions {
 		copy(data[offset:offset+49], txHash)
 		offset += 49
@@ -52,6 +79,36 @@ func (cs *State) MarshalBinary() (data []byte, err error) {
 // UnmarshalBinary parses the binary encoded representation of the coordinator state.
 func (cs *State) UnmarshalBinary(data []byte) error {
 
+	if len(data) < 5 || !bytes.Equal(data[0:4], []byte(stateFileMagic)) {
+		return cs.unmarshalLegacyBinary(data)
+	}
+
+	if data[4] != stateFileVersion {
+		return fmt.Errorf("unsupported state file version: %d", data[4])
+	}
+
+	if len(data) < 119 || (len(data)-119)%49 != 0 {
+		return fmt.Errorf("invalid length of the state, expected: 119 + n*49, got: %d", len(data))
+	}
+
+	cs.LatestMilestoneIndex = milestone.Index(binary.LittleEndian.Uint32(data[5:9]))
+	cs.LatestMilestoneHash = hornet.Hash(data[9:58])
+	cs.LatestMilestoneTime = int64(binary.LittleEndian.Uint64(data[58:66]))
+	cs.LatestCheckpointIndex = binary.LittleEndian.Uint32(data[66:70])
+	cs.LatestCheckpointHash = hornet.Hash(data[70:119])
+	cs.LatestMilestoneTransactions = make(hornet.Hashes, 0)
+
+	for offset := 119; offset < len(data); offset += 49 {
+		cs.LatestMilestoneTransactions = append(cs.LatestMilestoneTransactions, hornet.Hash(data[offset:offset+49]))
+	}
+
+	return nil
+}
+
+// unmarshalLegacyBinary parses the state in the format that was used before the state file was versioned.
+// The checkpoints were not persisted in that format, so the latest milestone is used as the latest checkpoint.
+func (cs *State) unmarshalLegacyBinary(data []byte) error {
+
 	/*
 		 4 bytes uint32 			LatestMilestoneIndex
 		49 bytes     			    LatestMilestoneHash
@@ -66,6 +123,8 @@ func (cs *State) UnmarshalBinary(data []byte) error {
 	cs.LatestMilestoneIndex = milestone.Index(binary.LittleEndian.Uint32(data[0:4]))
 	cs.LatestMilestoneHash = hornet.Hash(data[4:53])
 	cs.LatestMilestoneTime = int64(binary.LittleEndian.Uint64(data[53:61]))
+	cs.LatestCheckpointIndex = 0
+	cs.LatestCheckpointHash = cs.LatestMilestoneHash
 	cs.LatestMilestoneTransactions = make(hornet.Hashes, 0)
 
 	latestMilestoneTransactionsCount := (len(data) - 61) / 49
@@ -102,6 +161,8 @@ func loadStateFile(filePath string) (*State, error) {
 }
 
 // storeStateFile stores the state file for the coordinator in binary format.
+// The state is written to a temporary file first, which replaces the state file afterwards,
+// so that a crash while writing never leaves a corrupted state file behind.
 func (cs *State) storeStateFile(filePath string) error {
 
 	data, err := cs.MarshalBinary()
@@ -109,19 +170,26 @@ func (cs *State) storeStateFile(filePath string) error {
 		return err
 	}
 
-	stateFile, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0660)
+	tempFilePath := filePath + "_tmp"
+
+	stateFile, err := os.OpenFile(tempFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0660)
 	if err != nil {
 		return err
 	}
-	defer stateFile.Close()
 
 	if _, err := stateFile.Write(data); err != nil {
+		stateFile.Close()
 		return err
 	}
 
 	if err := stateFile.Sync(); err != nil {
+		stateFile.Close()
 		return err
 	}
 
-	return nil
+	if err := stateFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
 }
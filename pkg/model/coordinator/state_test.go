@@ -0,0 +1,64 @@
+package coordinator_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/model/coordinator"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+func hashWithByte(b byte) hornet.Hash {
+	hash := make(hornet.Hash, 49)
+	for i := range hash {
+		hash[i] = b
+	}
+	return hash
+}
+
+func TestStateMarshalUnmarshal(t *testing.T) {
+	state := &coordinator.State{
+		LatestMilestoneIndex:        1337,
+		LatestMilestoneHash:         hashWithByte(1),
+		LatestMilestoneTime:         1600000000,
+		LatestMilestoneTransactions: hornet.Hashes{hashWithByte(1), hashWithByte(2)},
+		LatestCheckpointIndex:       3,
+		LatestCheckpointHash:        hashWithByte(3),
+	}
+
+	data, err := state.MarshalBinary()
+	assert.NoError(t, err)
+
+	restored := &coordinator.State{}
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, state.LatestMilestoneIndex, restored.LatestMilestoneIndex)
+	assert.Equal(t, state.LatestMilestoneHash, restored.LatestMilestoneHash)
+	assert.Equal(t, state.LatestMilestoneTime, restored.LatestMilestoneTime)
+	assert.Equal(t, state.LatestMilestoneTransactions, restored.LatestMilestoneTransactions)
+	assert.Equal(t, state.LatestCheckpointIndex, restored.LatestCheckpointIndex)
+	assert.Equal(t, state.LatestCheckpointHash, restored.LatestCheckpointHash)
+
+	// a truncated state must be rejected
+	assert.Error(t, restored.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestStateUnmarshalLegacy(t *testing.T) {
+	data := make([]byte, 61+49)
+	binary.LittleEndian.PutUint32(data[0:4], 42)
+	copy(data[4:53], hashWithByte(1))
+	binary.LittleEndian.PutUint64(data[53:61], 1600000000)
+	copy(data[61:110], hashWithByte(2))
+
+	state := &coordinator.State{}
+	assert.NoError(t, state.UnmarshalBinary(data))
+	assert.EqualValues(t, 42, state.LatestMilestoneIndex)
+	assert.Equal(t, hashWithByte(1), state.LatestMilestoneHash)
+	assert.EqualValues(t, 1600000000, state.LatestMilestoneTime)
+	assert.Equal(t, hornet.Hashes{hashWithByte(2)}, state.LatestMilestoneTransactions)
+
+	// the checkpoints continue at the latest milestone
+	assert.EqualValues(t, 0, state.LatestCheckpointIndex)
+	assert.Equal(t, state.LatestMilestoneHash, state.LatestCheckpointHash)
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/gohornet/hornet/pkg/profile"
 )
 
-var addressesStorage *objectstorage.ObjectStorage
+var addressesStorage *meteredCache
 
 type CachedAddress struct {
 	objectstorage.CachedObject
@@ -53,7 +53,9 @@ func GetAddressesStorageSize() int {
 
 func configureAddressesStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	addressesStorage = objectstorage.New(
+	addressesStorage = newMeteredCache(
+		"addresses",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixAddresses}),
 		addressFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -94,6 +96,22 @@ func GetTransactionHashesForAddress(address hornet.Hash, valueOnly bool, forceRe
 	return txHashes
 }
 
+// ForEachTransactionHashForAddress loops over the hashes of all transactions of the given address
+// without collecting them, so that the results can be paginated or streamed.
+// The loop starts after the given cursor, the cursor of every result is passed to the consumer.
+func ForEachTransactionHashForAddress(address hornet.Hash, valueOnly bool, startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+
+	searchPrefix := databaseKeyPrefixForAddress(address)
+	if valueOnly {
+		var isValueByte byte = hornet.AddressTxIsValue
+		searchPrefix = append(searchPrefix, isValueByte)
+	}
+
+	forEachKeyAfterCursor(addressesStorage, searchPrefix, startAfter, func(key []byte, cursor []byte) bool {
+		return consumer(key[50:99], cursor)
+	})
+}
+
 // AddressConsumer consumes the given address during looping through all addresses in the persistence layer.
 type AddressConsumer func(address hornet.Hash, txHash hornet.Hash, isValue bool) bool
 
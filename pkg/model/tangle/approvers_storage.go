@@ -10,7 +10,7 @@ import (
 	"github.com/gohornet/hornet/pkg/profile"
 )
 
-var approversStorage *objectstorage.ObjectStorage
+var approversStorage *meteredCache
 
 type CachedApprover struct {
 	objectstorage.CachedObject
@@ -39,7 +39,9 @@ func GetApproversStorageSize() int {
 
 func configureApproversStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	approversStorage = objectstorage.New(
+	approversStorage = newMeteredCache(
+		"approvers",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixApprovers}),
 		approversFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -73,6 +75,14 @@ func GetApproverHashes(txHash hornet.Hash, maxFind ...int) hornet.Hashes {
 	return approverHashes
 }
 
+// ForEachApproverHash loops over the hashes of all approvers of the given transaction.
+// The loop starts after the given cursor, the cursor of every result is passed to the consumer.
+func ForEachApproverHash(txHash hornet.Hash, startAfter []byte, consumer func(approverHash hornet.Hash, cursor []byte) bool) {
+	forEachKeyAfterCursor(approversStorage, txHash, startAfter, func(key []byte, cursor []byte) bool {
+		return consumer(key[49:98], cursor)
+	})
+}
+
 // ApproverConsumer consumes the given approver during looping through all approvers in the persistence layer.
 type ApproverConsumer func(txHash hornet.Hash, approverHash hornet.Hash) bool
 
@@ -0,0 +1,429 @@
+package tangle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+const (
+	// BackupInfoFilename is the name of the file which describes a backup.
+	// It is written last, so a backup without it is incomplete.
+	BackupInfoFilename = "backup.json"
+
+	// the extension of the backup files of the databases
+	backupFileExtension = ".bak"
+	// the version of the backup format
+	backupVersion = 1
+	// the amount of entries after which the abort signal is checked and a batch is committed
+	backupBatchSize = 10000
+
+	backupEntryMarker = 1
+	backupEndMarker   = 0
+)
+
+var (
+	// ErrBackupDirectoryNotEmpty is returned if the target directory of a backup already contains files.
+	ErrBackupDirectoryNotEmpty = errors.New("backup directory is not empty")
+	// ErrInvalidBackup is returned if a backup is incomplete or corrupted.
+	ErrInvalidBackup = errors.New("invalid backup")
+	// ErrDatabaseExists is returned if a backup should be restored to a directory which already contains databases.
+	ErrDatabaseExists = errors.New("database already exists")
+
+	backupFileMagic = []byte("HORNETDB")
+)
+
+// BackupInfo describes a backup of the databases.
+type BackupInfo struct {
+	// The version of the backup format.
+	Version int `json:"version"`
+	// The unix timestamp the backup was taken.
+	Timestamp int64 `json:"timestamp"`
+	// The database engine the backup was taken from, the backup can only be restored with the same engine.
+	Engine string `json:"engine"`
+	// The solid milestone index at the time the backup was taken.
+	SolidMilestoneIndex milestone.Index `json:"solidMilestoneIndex"`
+	// The snapshot index at the time the backup was taken.
+	SnapshotIndex milestone.Index `json:"snapshotIndex"`
+	// The pruning index at the time the backup was taken.
+	PruningIndex milestone.Index `json:"pruningIndex"`
+	// The amount of entries per database.
+	Entries map[string]int64 `json:"entries"`
+	// The names of the additional files (e.g. local snapshot files) in the backup.
+	Files []string `json:"files"`
+	// The size of the backup in bytes.
+	Size int64 `json:"size"`
+}
+
+// BackupDatabases writes a consistent backup of all databases, and copies of the given files, to the given directory,
+// while the node keeps running.
+// The snapshots of the databases are taken while no milestone is confirmed, and the content of the caches which
+// is not persisted yet is not part of the backup. Therefore a restored backup is revalidated at the start of the node.
+func BackupDatabases(directory string, files []string, abortSignal <-chan struct{}) (info *BackupInfo, err error) {
+	if entries, err := ioutil.ReadDir(directory); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBackupDirectoryNotEmpty, directory)
+	}
+
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			// don't leave an incomplete backup behind
+			_ = os.RemoveAll(directory)
+		}
+	}()
+
+	databases := []struct {
+		name     string
+		database Database
+	}{
+		{TangleDbFilename, tangleDb},
+		{SnapshotDbFilename, snapshotDb},
+		{SpentAddressesDbFilename, spentDb},
+	}
+
+	info = &BackupInfo{
+		Version:   backupVersion,
+		Timestamp: time.Now().Unix(),
+		Engine:    databaseEngine,
+		Entries:   make(map[string]int64),
+		Files:     []string{},
+	}
+
+	// take the snapshots of all databases at the same state of the ledger
+	snapshots := make([]DatabaseSnapshot, 0, len(databases))
+	defer func() {
+		for _, snapshot := range snapshots {
+			snapshot.Release()
+		}
+	}()
+
+	ReadLockLedger()
+	ReadLockSolidEntryPoints()
+	ReadLockSpentAddresses()
+	for _, db := range databases {
+		snapshot, snapshotErr := db.database.Snapshot()
+		if snapshotErr != nil {
+			err = fmt.Errorf("taking the snapshot of %s failed: %w", db.name, snapshotErr)
+			break
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	info.SolidMilestoneIndex = GetSolidMilestoneIndex()
+	if snapshotInfo := GetSnapshotInfo(); snapshotInfo != nil {
+		info.SnapshotIndex = snapshotInfo.SnapshotIndex
+		info.PruningIndex = snapshotInfo.PruningIndex
+	}
+	ReadUnlockSpentAddresses()
+	ReadUnlockSolidEntryPoints()
+	ReadUnlockLedger()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, db := range databases {
+		entries, err := writeBackupFile(path.Join(directory, db.name+backupFileExtension), snapshots[i], abortSignal)
+		if err != nil {
+			return nil, fmt.Errorf("backup of %s failed: %w", db.name, err)
+		}
+		info.Entries[db.name] = entries
+	}
+
+	for _, file := range files {
+		copied, err := copyFileIfExists(file, path.Join(directory, path.Base(file)))
+		if err != nil {
+			return nil, fmt.Errorf("copying %s failed: %w", file, err)
+		}
+		if copied {
+			info.Files = append(info.Files, path.Base(file))
+		}
+	}
+
+	dirEntries, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range dirEntries {
+		info.Size += entry.Size()
+	}
+
+	infoJSON, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(path.Join(directory, BackupInfoFilename), infoJSON, 0600); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ReadBackupInfo reads the description of the backup in the given directory.
+func ReadBackupInfo(backupDirectory string) (*BackupInfo, error) {
+	infoJSON, err := ioutil.ReadFile(path.Join(backupDirectory, BackupInfoFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s is missing, the backup is incomplete", ErrInvalidBackup, BackupInfoFilename)
+		}
+		return nil, err
+	}
+
+	info := &BackupInfo{}
+	if err := json.Unmarshal(infoJSON, info); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+
+	if info.Version != backupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, info.Version)
+	}
+
+	return info, nil
+}
+
+// RestoreDatabases restores the databases of the backup in the given directory to the given database directory,
+// which must not contain any databases. The node must not be running.
+// The additional files of the backup are not restored.
+func RestoreDatabases(backupDirectory string, databaseDirectory string, storageMedium string) (*BackupInfo, error) {
+	info, err := ReadBackupInfo(backupDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{TangleDbFilename, SnapshotDbFilename, SpentAddressesDbFilename}
+	for _, name := range names {
+		if _, err := os.Stat(path.Join(databaseDirectory, name)); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrDatabaseExists, path.Join(databaseDirectory, name))
+		}
+	}
+
+	for _, name := range names {
+		db, err := openDatabase(info.Engine, databaseDirectory, name, storageMedium)
+		if err != nil {
+			return nil, fmt.Errorf("opening database %s failed: %w", name, err)
+		}
+
+		entries, err := readBackupFile(path.Join(backupDirectory, name+backupFileExtension), db.KVStore())
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("restoring %s failed: %w", name, err)
+		}
+
+		if entries != info.Entries[name] {
+			return nil, fmt.Errorf("%w: %s contains %d entries instead of %d", ErrInvalidBackup, name, entries, info.Entries[name])
+		}
+	}
+
+	return info, nil
+}
+
+// writeBackupFile writes all entries of the snapshot to the given file and returns the amount of entries.
+//
+// The file starts with the magic bytes and the version, followed by the entries:
+//
+//	entry marker (1 byte), realm length (uvarint), realm, key length (uvarint), key, value length (uvarint), value
+//
+// and ends with the end marker (1 byte) and the amount of entries (uint64).
+func writeBackupFile(filePath string, snapshot DatabaseSnapshot, abortSignal <-chan struct{}) (int64, error) {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	if _, err := w.Write(backupFileMagic); err != nil {
+		return 0, err
+	}
+	if err := w.WriteByte(backupVersion); err != nil {
+		return 0, err
+	}
+
+	var entries int64
+	lengthBuf := make([]byte, binary.MaxVarintLen64)
+	writeBytes := func(data []byte) error {
+		if _, err := w.Write(lengthBuf[:binary.PutUvarint(lengthBuf, uint64(len(data)))]); err != nil {
+			return err
+		}
+		_, err := w.Write(data)
+		return err
+	}
+
+	if err := snapshot.ForEach(func(realm []byte, key []byte, value []byte) error {
+		if entries%backupBatchSize == 0 {
+			select {
+			case <-abortSignal:
+				return ErrOperationAborted
+			default:
+			}
+		}
+
+		if err := w.WriteByte(backupEntryMarker); err != nil {
+			return err
+		}
+		for _, data := range [][]byte{realm, key, value} {
+			if err := writeBytes(data); err != nil {
+				return err
+			}
+		}
+
+		entries++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := w.WriteByte(backupEndMarker); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint64(entries)); err != nil {
+		return 0, err
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+
+	return entries, file.Sync()
+}
+
+// readBackupFile writes all entries of the given backup file to the store and returns the amount of entries.
+func readBackupFile(filePath string, store kvstore.KVStore) (int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	header := make([]byte, len(backupFileMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header[:len(backupFileMagic)], backupFileMagic) {
+		return 0, fmt.Errorf("%w: %s is not a database backup", ErrInvalidBackup, filePath)
+	}
+	if header[len(backupFileMagic)] != backupVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, header[len(backupFileMagic)])
+	}
+
+	readBytes := func() ([]byte, error) {
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, length)
+		_, err = io.ReadFull(r, data)
+		return data, err
+	}
+
+	realmStores := make(map[string]kvstore.KVStore)
+	batches := make(map[string]kvstore.BatchedMutations)
+	commit := func() error {
+		for realm, batch := range batches {
+			if err := batch.Commit(); err != nil {
+				return err
+			}
+			delete(batches, realm)
+		}
+		return nil
+	}
+
+	var entries int64
+	for {
+		marker, err := r.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s is truncated", ErrInvalidBackup, filePath)
+		}
+
+		if marker == backupEndMarker {
+			break
+		}
+		if marker != backupEntryMarker {
+			return 0, fmt.Errorf("%w: %s is corrupted", ErrInvalidBackup, filePath)
+		}
+
+		var fields [3][]byte
+		for i := range fields {
+			if fields[i], err = readBytes(); err != nil {
+				return 0, fmt.Errorf("%w: %s is truncated", ErrInvalidBackup, filePath)
+			}
+		}
+		realm, key, value := fields[0], fields[1], fields[2]
+
+		batch, exists := batches[string(realm)]
+		if !exists {
+			realmStore, exists := realmStores[string(realm)]
+			if !exists {
+				realmStore = store
+				if len(realm) > 0 {
+					realmStore = store.WithRealm(realm)
+				}
+				realmStores[string(realm)] = realmStore
+			}
+			batch = realmStore.Batched()
+			batches[string(realm)] = batch
+		}
+
+		if err := batch.Set(key, value); err != nil {
+			return 0, err
+		}
+
+		entries++
+		if entries%backupBatchSize == 0 {
+			if err := commit(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var expectedEntries uint64
+	if err := binary.Read(r, binary.LittleEndian, &expectedEntries); err != nil {
+		return 0, fmt.Errorf("%w: %s is truncated", ErrInvalidBackup, filePath)
+	}
+	if uint64(entries) != expectedEntries {
+		return 0, fmt.Errorf("%w: %s contains %d entries instead of %d", ErrInvalidBackup, filePath, entries, expectedEntries)
+	}
+
+	return entries, commit()
+}
+
+// copyFileIfExists copies the given file to the target path and returns whether the file existed.
+func copyFileIfExists(source string, target string) (bool, error) {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer sourceFile.Close()
+
+	targetFile, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer targetFile.Close()
+
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		return false, err
+	}
+
+	return true, targetFile.Sync()
+}
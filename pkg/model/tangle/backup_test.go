@@ -0,0 +1,109 @@
+package tangle_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+func init() {
+	// the caches of the databases are sized by the profile
+	config.NodeConfig.Set(profile.CfgUseProfile, "2gb")
+}
+
+func testBackupAndRestore(t *testing.T, engine string) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	databaseDir := filepath.Join(dir, "db")
+	backupDir := filepath.Join(dir, "backup")
+	restoreDir := filepath.Join(dir, "restored")
+	snapshotFile := filepath.Join(dir, "export.bin")
+	require.NoError(t, ioutil.WriteFile(snapshotFile, []byte("snapshot"), 0600))
+
+	tangle.ConfigureDatabases(databaseDir, engine, "ssd")
+	for i := byte(1); i <= 10; i++ {
+		tangle.MarkAddressAsSpent(testAddress(i))
+	}
+	tangle.FlushSpentAddressesStorage()
+
+	info, err := tangle.BackupDatabases(backupDir, []string{snapshotFile, filepath.Join(dir, "missing.bin")}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, engine, info.Engine)
+	assert.EqualValues(t, 10, info.Entries[tangle.SpentAddressesDbFilename])
+	assert.Equal(t, []string{"export.bin"}, info.Files)
+
+	// writes after the snapshot are not part of the backup
+	tangle.MarkAddressAsSpent(testAddress(11))
+
+	// a backup is never written to a directory which contains files
+	_, err = tangle.BackupDatabases(backupDir, nil, nil)
+	assert.True(t, errors.Is(err, tangle.ErrBackupDirectoryNotEmpty))
+
+	tangle.ShutdownStorages()
+	require.NoError(t, tangle.CloseDatabases())
+
+	_, err = tangle.RestoreDatabases(backupDir, databaseDir, "ssd")
+	assert.True(t, errors.Is(err, tangle.ErrDatabaseExists))
+
+	restored, err := tangle.RestoreDatabases(backupDir, restoreDir, "ssd")
+	require.NoError(t, err)
+	assert.Equal(t, info.Entries, restored.Entries)
+
+	tangle.ConfigureDatabases(restoreDir, engine, "ssd")
+	for i := byte(1); i <= 10; i++ {
+		assert.True(t, tangle.WasAddressSpentFrom(testAddress(i)))
+	}
+	assert.False(t, tangle.WasAddressSpentFrom(testAddress(11)))
+	tangle.ShutdownStorages()
+	require.NoError(t, tangle.CloseDatabases())
+}
+
+func TestBackupAndRestoreBolt(t *testing.T) {
+	testBackupAndRestore(t, tangle.EngineBolt)
+}
+
+func TestBackupAndRestoreBadger(t *testing.T) {
+	testBackupAndRestore(t, tangle.EngineBadger)
+}
+
+func TestRestoreRejectsTruncatedBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir := filepath.Join(dir, "backup")
+
+	tangle.ConfigureDatabases(filepath.Join(dir, "db"), tangle.EngineBolt, "ssd")
+	tangle.MarkAddressAsSpent(testAddress(1))
+	tangle.FlushSpentAddressesStorage()
+
+	_, err = tangle.BackupDatabases(backupDir, nil, nil)
+	require.NoError(t, err)
+	tangle.ShutdownStorages()
+	require.NoError(t, tangle.CloseDatabases())
+
+	// cut off the end of the backup of the spent addresses
+	backupFile := filepath.Join(backupDir, tangle.SpentAddressesDbFilename+".bak")
+	data, err := ioutil.ReadFile(backupFile)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(backupFile, data[:len(data)-5], 0600))
+
+	_, err = tangle.RestoreDatabases(backupDir, filepath.Join(dir, "restored"), "ssd")
+	assert.True(t, errors.Is(err, tangle.ErrInvalidBackup))
+
+	// a backup without its description is incomplete
+	require.NoError(t, os.Remove(filepath.Join(backupDir, tangle.BackupInfoFilename)))
+	_, err = tangle.ReadBackupInfo(backupDir)
+	assert.True(t, errors.Is(err, tangle.ErrInvalidBackup))
+}
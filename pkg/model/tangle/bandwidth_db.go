@@ -0,0 +1,56 @@
+package tangle
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+const (
+	bandwidthUsageKey = "bandwidthUsage"
+)
+
+var (
+	// bandwidthUsageStore holds the gossip traffic of the current accounting period,
+	// so that the monthly bandwidth budget survives restarts of the node.
+	bandwidthUsageStore kvstore.KVStore
+)
+
+func configureBandwidthUsageStore(store kvstore.KVStore) {
+	bandwidthUsageStore = store.WithRealm([]byte{StorePrefixBandwidthUsage})
+}
+
+// StoreBandwidthUsage stores the received and sent bytes of the accounting period starting at the given time.
+func StoreBandwidthUsage(periodStart time.Time, received uint64, sent uint64) error {
+	value := make([]byte, 24)
+	binary.LittleEndian.PutUint64(value[:8], uint64(periodStart.Unix()))
+	binary.LittleEndian.PutUint64(value[8:16], received)
+	binary.LittleEndian.PutUint64(value[16:], sent)
+
+	if err := bandwidthUsageStore.Set([]byte(bandwidthUsageKey), value); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store bandwidth usage")
+	}
+	return nil
+}
+
+// GetBandwidthUsage returns the stored start of the accounting period and its received and sent bytes.
+// The returned period start is zero if no bandwidth usage was stored yet.
+func GetBandwidthUsage() (periodStart time.Time, received uint64, sent uint64, err error) {
+	value, err := bandwidthUsageStore.Get([]byte(bandwidthUsageKey))
+	if err != nil {
+		if err == kvstore.ErrKeyNotFound {
+			return time.Time{}, 0, 0, nil
+		}
+		return time.Time{}, 0, 0, errors.Wrap(NewDatabaseError(err), "failed to retrieve bandwidth usage")
+	}
+
+	if len(value) != 24 {
+		return time.Time{}, 0, 0, errors.Wrap(NewDatabaseError(errors.New("invalid length")), "failed to retrieve bandwidth usage")
+	}
+
+	periodStart = time.Unix(int64(binary.LittleEndian.Uint64(value[:8])), 0).UTC()
+	return periodStart, binary.LittleEndian.Uint64(value[8:16]), binary.LittleEndian.Uint64(value[16:]), nil
+}
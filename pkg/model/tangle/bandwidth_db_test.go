@@ -0,0 +1,28 @@
+package tangle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func TestBandwidthUsage(t *testing.T) {
+	configureTestStorages()
+
+	periodStart, _, _, err := tangle.GetBandwidthUsage()
+	require.NoError(t, err)
+	assert.True(t, periodStart.IsZero())
+
+	start := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, tangle.StoreBandwidthUsage(start, 100, 200))
+
+	periodStart, received, sent, err := tangle.GetBandwidthUsage()
+	require.NoError(t, err)
+	assert.Equal(t, start, periodStart)
+	assert.Equal(t, uint64(100), received)
+	assert.Equal(t, uint64(200), sent)
+}
@@ -279,8 +279,9 @@ func (bundle *Bundle) isComplete() bool {
 	return uint64(len(bundle.txs)) == bundle.lastIndex+1
 }
 
-// Checks if a bundle is syntactically valid and has valid signatures
-func (bundle *Bundle) validate() bool {
+// Checks if a bundle is syntactically valid and has valid signatures.
+// The result of the semantics and signature checks is taken from the given validated bundles if the bundle was already checked.
+func (bundle *Bundle) validate(validatedBundles ValidatedBundles) bool {
 
 	// Because the bundle is already complete when this function gets called, the amount of tx has to be correct,
 	// otherwise the bundle was not constructed correctly
@@ -309,7 +310,12 @@ func (bundle *Bundle) validate() bool {
 	}
 
 	// validate bundle semantics and signatures
-	if iotagobundle.ValidBundle(iotaGoBundle) != nil {
+	valid, validated := validatedBundles[string(bundle.tailTx)]
+	if !validated {
+		valid = iotagobundle.ValidBundle(iotaGoBundle) == nil
+	}
+
+	if !valid {
 		bundle.setValid(false)
 		bundle.setValidStrictSemantics(false)
 		return false
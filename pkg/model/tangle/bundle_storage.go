@@ -17,7 +17,7 @@ import (
 )
 
 var (
-	bundleStorage *objectstorage.ObjectStorage
+	bundleStorage *meteredCache
 )
 
 func databaseKeyForBundle(tailTxHash hornet.Hash) []byte {
@@ -37,7 +37,9 @@ func GetBundleStorageSize() int {
 
 func configureBundleStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	bundleStorage = objectstorage.New(
+	bundleStorage = newMeteredCache(
+		"bundles",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixBundles}),
 		bundleFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -321,7 +323,7 @@ func AddTransactionToStorage(hornetTx *hornet.Transaction, latestMilestoneIndex
 	// If the transaction is part of a milestone, the bundle must be created here
 	// Otherwise, bundles are created if tailTx becomes solid
 	if IsMaybeMilestoneTx(cachedTx.Retain()) { // tx pass +1
-		tryConstructBundle(cachedTx.Retain(), false)
+		tryConstructBundle(cachedTx.Retain(), false, nil)
 	}
 
 	return cachedTx, false
@@ -329,7 +331,8 @@ func AddTransactionToStorage(hornetTx *hornet.Transaction, latestMilestoneIndex
 
 // tryConstructBundle tries to construct a bundle (maybe txs are still missing in the DB)
 // isSolidTail should only be false for possible milestone txs
-func tryConstructBundle(cachedTx *CachedTransaction, isSolidTail bool) {
+// validatedBundles holds the results of already done semantics and signature checks and may be nil
+func tryConstructBundle(cachedTx *CachedTransaction, isSolidTail bool, validatedBundles ValidatedBundles) {
 	defer cachedTx.Release() // tx -1
 
 	if ContainsBundle(cachedTx.GetTransaction().GetTxHash()) {
@@ -346,8 +349,8 @@ func tryConstructBundle(cachedTx *CachedTransaction, isSolidTail bool) {
 				continue
 			}
 
-			tryConstructBundle(cachedTailTx.Retain(), false) // tx pass +1
-			cachedTailTx.Release()                           // tx -1
+			tryConstructBundle(cachedTailTx.Retain(), false, nil) // tx pass +1
+			cachedTailTx.Release()                                // tx -1
 		}
 		return
 	}
@@ -396,7 +399,7 @@ func tryConstructBundle(cachedTx *CachedTransaction, isSolidTail bool) {
 	cachedObj := bundleStorage.ComputeIfAbsent(bndl.ObjectStorageKey(), func(key []byte) objectstorage.StorableObject { // bundle +1
 		newlyAdded = true
 
-		if bndl.validate() {
+		if bndl.validate(validatedBundles) {
 			bndl.calcLedgerChanges()
 		}
 
@@ -477,6 +480,7 @@ func constructBundle(bndl *Bundle, cachedStartTxMeta *CachedMetadata) bool {
 }
 
 // Create a new bundle instance as soon as a tailTx gets solid
-func OnTailTransactionSolid(cachedTx *CachedTransaction) {
-	tryConstructBundle(cachedTx, true) // tx +-0 (it has +1 and will be released in tryConstructBundle)
+// validatedBundles holds the results of the semantics and signature checks done by ValidateBundles and may be nil
+func OnTailTransactionSolid(cachedTx *CachedTransaction, validatedBundles ValidatedBundles) {
+	tryConstructBundle(cachedTx, true, validatedBundles) // tx +-0 (it has +1 and will be released in tryConstructBundle)
 }
@@ -17,7 +17,7 @@ const (
 )
 
 var (
-	bundleTransactionsStorage *objectstorage.ObjectStorage
+	bundleTransactionsStorage *meteredCache
 )
 
 func databaseKeyPrefixForBundleHash(bundleHash hornet.Hash) []byte {
@@ -52,7 +52,9 @@ func GetBundleTransactionsStorageSize() int {
 
 func configureBundleTransactionsStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	bundleTransactionsStorage = objectstorage.New(
+	bundleTransactionsStorage = newMeteredCache(
+		"bundleTransactions",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixBundleTransactions}),
 		bundleTransactionFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -162,6 +164,14 @@ func GetBundleTransactionHashes(bundleHash hornet.Hash, forceRelease bool, maxFi
 	return bundleTransactionHashes
 }
 
+// ForEachBundleTransactionHash loops over the hashes of all transactions of the given bundle hash.
+// The loop starts after the given cursor, the cursor of every result is passed to the consumer.
+func ForEachBundleTransactionHash(bundleHash hornet.Hash, startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+	forEachKeyAfterCursor(bundleTransactionsStorage, databaseKeyPrefixForBundleHash(bundleHash), startAfter, func(key []byte, cursor []byte) bool {
+		return consumer(key[50:99], cursor)
+	})
+}
+
 // bundleTx +1
 func GetAllBundleTransactionHashes(maxFind ...int) hornet.Hashes {
 	var bundleTransactionHashes hornet.Hashes
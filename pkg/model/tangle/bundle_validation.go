@@ -0,0 +1,105 @@
+package tangle
+
+import (
+	"bytes"
+	"sync"
+
+	iotagobundle "github.com/iotaledger/iota.go/bundle"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+// ValidatedBundles holds the results of the semantics and signature checks of bundles by the hash of their tail transaction.
+type ValidatedBundles map[string]bool
+
+// ValidateBundles checks the semantics and signatures of the bundles of the given tail transactions in parallel.
+// Bundles which already exist or whose transactions are not all stored are skipped, they are validated on construction.
+// The checks don't depend on each other, only the construction of the bundles has to be done in the order the
+// tail transactions become solid, therefore the results are passed to OnTailTransactionSolid.
+func ValidateBundles(tailTxHashes hornet.Hashes, parallelism int) ValidatedBundles {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make(ValidatedBundles, len(tailTxHashes))
+	var resultsLock sync.Mutex
+
+	var wg sync.WaitGroup
+	input := make(chan hornet.Hash)
+	wg.Add(parallelism)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer wg.Done()
+
+			for tailTxHash := range input {
+				iotaGoBundle := loadIotaGoBundleOrNil(tailTxHash)
+				if iotaGoBundle == nil {
+					continue
+				}
+
+				valid := iotagobundle.ValidBundle(iotaGoBundle) == nil
+
+				resultsLock.Lock()
+				results[string(tailTxHash)] = valid
+				resultsLock.Unlock()
+			}
+		}()
+	}
+
+	for _, tailTxHash := range tailTxHashes {
+		if ContainsBundle(tailTxHash) {
+			continue
+		}
+		input <- tailTxHash
+	}
+	close(input)
+	wg.Wait()
+
+	return results
+}
+
+// loads the transactions of the bundle with the given tail transaction by following the trunk transactions.
+// returns nil if a transaction is missing or the bundle would not be complete on construction.
+func loadIotaGoBundleOrNil(tailTxHash hornet.Hash) iotagobundle.Bundle {
+	cachedCurrentTx := GetCachedTransactionOrNil(tailTxHash) // tx +1
+	if cachedCurrentTx == nil {
+		return nil
+	}
+
+	if !cachedCurrentTx.GetTransaction().IsTail() {
+		cachedCurrentTx.Release(true) // tx -1
+		return nil
+	}
+
+	bundleHash := cachedCurrentTx.GetTransaction().GetBundleHash()
+	lastIndex := int(cachedCurrentTx.GetTransaction().Tx.LastIndex)
+
+	iotaGoBundle := make(iotagobundle.Bundle, lastIndex+1)
+	iotaGoBundle[0] = *cachedCurrentTx.GetTransaction().Tx
+
+	for i := 1; i < lastIndex+1; i++ {
+		currentTx := cachedCurrentTx.GetTransaction()
+		if currentTx.Tx.CurrentIndex == currentTx.Tx.LastIndex || bytes.Equal(currentTx.GetTxHash(), currentTx.GetTrunkHash()) {
+			// the bundle ends before its last index
+			cachedCurrentTx.Release(true) // tx -1
+			return nil
+		}
+
+		cachedTrunkTx := GetCachedTransactionOrNil(currentTx.GetTrunkHash()) // tx +1
+		cachedCurrentTx.Release(true)                                        // tx -1
+		if cachedTrunkTx == nil {
+			return nil
+		}
+
+		if !bytes.Equal(cachedTrunkTx.GetTransaction().GetBundleHash(), bundleHash) {
+			cachedTrunkTx.Release(true) // tx -1
+			return nil
+		}
+
+		iotaGoBundle[i] = *cachedTrunkTx.GetTransaction().Tx
+		cachedCurrentTx = cachedTrunkTx
+	}
+	cachedCurrentTx.Release(true) // tx -1
+
+	return iotaGoBundle
+}
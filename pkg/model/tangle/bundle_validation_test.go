@@ -0,0 +1,80 @@
+package tangle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/compressed"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// storeZeroValueBundle stores a zero value bundle with the given amount of transactions and returns the hash of its tail transaction.
+func storeZeroValueBundle(t *testing.T, address trinary.Trytes, length int, tamper bool) hornet.Hash {
+	fragments := make([]trinary.Trytes, length)
+	for i := range fragments {
+		fragments[i] = trinary.MustPad("", consts.SignatureMessageFragmentSizeInTrytes)
+	}
+
+	b := bundle.AddEntry(bundle.Bundle{}, bundle.BundleEntry{
+		Address:                   trinary.MustPad(address, consts.AddressTrinarySize/3),
+		Length:                    uint64(length),
+		Timestamp:                 uint64(time.Now().Unix()),
+		SignatureMessageFragments: fragments,
+	})
+	b, err := bundle.Finalize(b)
+	require.NoError(t, err)
+
+	// link the transactions via their trunk, starting at the head
+	trunk := consts.NullHashTrytes
+	for i := len(b) - 1; i >= 0; i-- {
+		tx := &b[i]
+		tx.TrunkTransaction = trunk
+		tx.BranchTransaction = consts.NullHashTrytes
+		if tamper && i == 0 {
+			// changes the bundle essence without updating the bundle hash
+			tx.Value = 1
+		}
+		tx.Hash = transaction.TransactionHash(tx)
+		trunk = tx.Hash
+
+		txTrits, err := transaction.TransactionToTrits(tx)
+		require.NoError(t, err)
+
+		cachedTx, _ := tangle.StoreTransactionIfAbsent(hornet.NewTransactionFromTx(tx, compressed.TruncateTx(trinary.MustTritsToBytes(txTrits))))
+		cachedTx.Release(true)
+	}
+
+	return hornet.HashFromHashTrytes(b[0].Hash)
+}
+
+func TestValidateBundles(t *testing.T) {
+	configureTestStorages()
+
+	validTail := storeZeroValueBundle(t, "VALID", 3, false)
+	invalidTail := storeZeroValueBundle(t, "INVALID", 2, true)
+	unknownTail := hornet.HashFromHashTrytes(trinary.MustPad("UNKNOWN", consts.HashTrytesSize))
+
+	validatedBundles := tangle.ValidateBundles(hornet.Hashes{validTail, invalidTail, unknownTail}, 2)
+	assert.Len(t, validatedBundles, 2)
+
+	valid, validated := validatedBundles[string(validTail)]
+	assert.True(t, validated)
+	assert.True(t, valid)
+
+	valid, validated = validatedBundles[string(invalidTail)]
+	assert.True(t, validated)
+	assert.False(t, valid)
+
+	// transactions which are not stored are left for the validation on construction
+	_, validated = validatedBundles[string(unknownTail)]
+	assert.False(t, validated)
+}
@@ -0,0 +1,139 @@
+package tangle
+
+import (
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/objectstorage"
+)
+
+// CacheMetrics are the metrics of the cache of an object storage.
+type CacheMetrics struct {
+	// the name of the cache
+	Name string
+	// the amount of objects in the cache
+	Size int
+	// the amount of lookups of single objects
+	Requests uint64
+	// the amount of lookups which had to read from the database
+	Misses uint64
+	// the amount of objects which were evicted from the cache after their cache time.
+	// caches with partitioned keys don't report evictions.
+	Evictions uint64
+}
+
+// Hits returns the amount of lookups which were answered by the cache.
+func (m CacheMetrics) Hits() uint64 {
+	// a lookup may read the database more than once in rare cases
+	if m.Misses > m.Requests {
+		return 0
+	}
+	return m.Requests - m.Misses
+}
+
+type cacheCounters struct {
+	requests  uint64
+	misses    uint64
+	evictions uint64
+}
+
+// cacheMissStore is the store of a cache, which counts the reads of single objects as cache misses.
+type cacheMissStore struct {
+	kvstore.KVStore
+	counters *cacheCounters
+}
+
+func (s *cacheMissStore) Get(key kvstore.Key) (kvstore.Value, error) {
+	atomic.AddUint64(&s.counters.misses, 1)
+	return s.KVStore.Get(key)
+}
+
+func (s *cacheMissStore) Has(key kvstore.Key) (bool, error) {
+	atomic.AddUint64(&s.counters.misses, 1)
+	return s.KVStore.Has(key)
+}
+
+// meteredCache is an object storage which counts the lookups, misses and evictions of its cache
+// and limits the amount of objects in its cache.
+type meteredCache struct {
+	*objectstorage.ObjectStorage
+	name     string
+	maxSize  int
+	counters *cacheCounters
+}
+
+func newMeteredCache(name string, maxSize int, store kvstore.KVStore, objectFactory objectstorage.StorableObjectFromKey, optionalOptions ...objectstorage.Option) *meteredCache {
+	counters := &cacheCounters{}
+
+	storage := objectstorage.New(&cacheMissStore{KVStore: store, counters: counters}, objectFactory, optionalOptions...)
+	storage.Events.ObjectEvicted.Attach(events.NewClosure(func(_ []byte, _ objectstorage.StorableObject) {
+		atomic.AddUint64(&counters.evictions, 1)
+	}))
+
+	return &meteredCache{ObjectStorage: storage, name: name, maxSize: maxSize, counters: counters}
+}
+
+func (c *meteredCache) Load(key []byte) objectstorage.CachedObject {
+	atomic.AddUint64(&c.counters.requests, 1)
+	return LimitCacheSize(c.ObjectStorage, c.maxSize, c.ObjectStorage.Load(key))
+}
+
+func (c *meteredCache) Store(object objectstorage.StorableObject) objectstorage.CachedObject {
+	return LimitCacheSize(c.ObjectStorage, c.maxSize, c.ObjectStorage.Store(object))
+}
+
+func (c *meteredCache) ComputeIfAbsent(key []byte, remappingFunction func(key []byte) objectstorage.StorableObject) objectstorage.CachedObject {
+	atomic.AddUint64(&c.counters.requests, 1)
+	return LimitCacheSize(c.ObjectStorage, c.maxSize, c.ObjectStorage.ComputeIfAbsent(key, remappingFunction))
+}
+
+func (c *meteredCache) Contains(key []byte) bool {
+	atomic.AddUint64(&c.counters.requests, 1)
+	return c.ObjectStorage.Contains(key)
+}
+
+func (c *meteredCache) StoreIfAbsent(object objectstorage.StorableObject) (objectstorage.CachedObject, bool) {
+	atomic.AddUint64(&c.counters.requests, 1)
+	cachedObject, stored := c.ObjectStorage.StoreIfAbsent(object)
+	if stored {
+		// the object storage checks the database a second time before a new object is stored
+		atomic.AddUint64(&c.counters.requests, 1)
+	}
+	return LimitCacheSize(c.ObjectStorage, c.maxSize, cachedObject), stored
+}
+
+func (c *meteredCache) metrics() CacheMetrics {
+	return CacheMetrics{
+		Name:      c.name,
+		Size:      c.GetSize(),
+		Requests:  atomic.LoadUint64(&c.counters.requests),
+		Misses:    atomic.LoadUint64(&c.counters.misses),
+		Evictions: atomic.LoadUint64(&c.counters.evictions),
+	}
+}
+
+// GetCacheMetrics returns the metrics of the caches of all object storages.
+func GetCacheMetrics() []CacheMetrics {
+	caches := []*meteredCache{
+		txStorage,
+		metadataStorage,
+		bundleTransactionsStorage,
+		bundleStorage,
+		approversStorage,
+		tagsStorage,
+		addressesStorage,
+		milestoneStorage,
+		unconfirmedTxStorage,
+		spentAddressesStorage,
+	}
+
+	metrics := make([]CacheMetrics, 0, len(caches))
+	for _, cache := range caches {
+		if cache == nil {
+			continue
+		}
+		metrics = append(metrics, cache.metrics())
+	}
+	return metrics
+}
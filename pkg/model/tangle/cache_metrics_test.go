@@ -0,0 +1,44 @@
+package tangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+func TestCacheMetrics(t *testing.T) {
+	caches := profile.Profile2GB.Caches
+	caches.SpentAddresses.CacheTimeMs = 60000
+
+	store := mapdb.NewMapDB()
+	tangle.ConfigureStorages(store.WithRealm([]byte("tangle")), store.WithRealm([]byte("snapshot")), store.WithRealm([]byte("spent")), caches)
+	defer tangle.ShutdownSpentAddressesStorage()
+
+	// not cached, checked in the database before it is stored
+	assert.True(t, tangle.MarkAddressAsSpent(testAddress(1)))
+	// cached
+	assert.True(t, tangle.WasAddressSpentFrom(testAddress(1)))
+	// not cached, looked up in the database
+	assert.False(t, tangle.WasAddressSpentFrom(testAddress(2)))
+
+	var spentAddressesMetrics *tangle.CacheMetrics
+	for _, metrics := range tangle.GetCacheMetrics() {
+		if metrics.Name == "spentAddresses" {
+			m := metrics
+			spentAddressesMetrics = &m
+		}
+	}
+	require.NotNil(t, spentAddressesMetrics)
+
+	// storing checks the database twice
+	assert.EqualValues(t, 4, spentAddressesMetrics.Requests)
+	assert.EqualValues(t, 3, spentAddressesMetrics.Misses)
+	assert.EqualValues(t, 1, spentAddressesMetrics.Hits())
+	assert.Equal(t, 1, spentAddressesMetrics.Size)
+}
@@ -0,0 +1,43 @@
+package tangle
+
+import (
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/typeutils"
+)
+
+// sizeLimitedCachedObject is a cached object which is evicted immediately when it is released
+// while its object storage holds more objects than allowed.
+type sizeLimitedCachedObject struct {
+	objectstorage.CachedObject
+	storage *objectstorage.ObjectStorage
+	maxSize int
+}
+
+func (c *sizeLimitedCachedObject) overLimit() bool {
+	return c.storage.GetSize() > c.maxSize
+}
+
+func (c *sizeLimitedCachedObject) Release(force ...bool) {
+	if c.overLimit() {
+		c.CachedObject.Release(true)
+		return
+	}
+	c.CachedObject.Release(force...)
+}
+
+func (c *sizeLimitedCachedObject) Consume(consumer func(objectstorage.StorableObject), forceRelease ...bool) bool {
+	if c.overLimit() {
+		return c.CachedObject.Consume(consumer, true)
+	}
+	return c.CachedObject.Consume(consumer, forceRelease...)
+}
+
+// LimitCacheSize returns the cached object of the given storage, which is not kept for the cache time after it
+// was released if the storage holds more than maxSize objects. So the cache shrinks to the limit after the
+// cache times of the objects which are already released ran out. A maxSize of 0 disables the limit.
+func LimitCacheSize(storage *objectstorage.ObjectStorage, maxSize int, cachedObject objectstorage.CachedObject) objectstorage.CachedObject {
+	if maxSize == 0 || typeutils.IsInterfaceNil(cachedObject) {
+		return cachedObject
+	}
+	return &sizeLimitedCachedObject{CachedObject: cachedObject, storage: storage, maxSize: maxSize}
+}
@@ -0,0 +1,35 @@
+package tangle
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/hive.go/objectstorage"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+func TestLimitCacheSize(t *testing.T) {
+	// objects which are not persisted are only evicted after their cache time, like the work units of the processor
+	storage := objectstorage.New(nil, approversFactory,
+		objectstorage.CacheTime(time.Hour),
+		objectstorage.PersistenceEnabled(false),
+	)
+	defer storage.Shutdown()
+
+	txHash := hornet.Hash(bytes.Repeat([]byte{1}, 49))
+	for i := byte(2); i <= 6; i++ {
+		LimitCacheSize(storage, 2, storage.Put(hornet.NewApprover(txHash, bytes.Repeat([]byte{i}, 49)))).Release()
+	}
+
+	// the objects which were released while the cache was full are evicted without waiting for the cache time
+	assert.Eventually(t, func() bool { return storage.GetSize() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// without a limit the objects stay in the cache
+	LimitCacheSize(storage, 0, storage.Put(hornet.NewApprover(txHash, bytes.Repeat([]byte{7}, 49)))).Release()
+	time.Sleep(time.Second)
+	assert.Equal(t, 3, storage.GetSize())
+}
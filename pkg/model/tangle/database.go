@@ -0,0 +1,328 @@
+package tangle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+	"go.etcd.io/bbolt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	badgerstore "github.com/iotaledger/hive.go/kvstore/badger"
+	"github.com/iotaledger/hive.go/kvstore/bolt"
+)
+
+const (
+	// StorageMediumSSD tunes the database engine for solid state drives.
+	StorageMediumSSD = "ssd"
+	// StorageMediumHDD tunes the database engine for hard disk drives.
+	StorageMediumHDD = "hdd"
+)
+
+var (
+	ErrUnknownStorageMedium = errors.New("unknown storage medium")
+)
+
+// Database is a single database of a database engine, which is used as the persistence layer of the storages.
+type Database interface {
+	// KVStore returns the key value store of the database.
+	KVStore() kvstore.KVStore
+	// Sync writes all pending changes of the database to disk.
+	Sync() error
+	// Close syncs the database to disk and closes it.
+	Close() error
+	// SupportsCleanup tells whether the database needs a regular garbage collection.
+	SupportsCleanup() bool
+	// CleanupStep runs a single round of the garbage collection of the database and returns whether anything was cleaned up.
+	// It has to be called until nothing is left to clean up.
+	CleanupStep() (bool, error)
+	// Size returns the size of the database on disk in bytes.
+	Size() int64
+	// UsedSize returns the size of the data in the database in bytes.
+	// It doesn't contain space which was freed for reuse by the database but not returned to the file system.
+	UsedSize() int64
+	// Snapshot returns a consistent read-only view of the current state of the database.
+	Snapshot() (DatabaseSnapshot, error)
+}
+
+// DatabaseSnapshot is a consistent read-only view of a database, which is not affected by later writes.
+type DatabaseSnapshot interface {
+	// ForEach calls the consumer with every entry of the database and stops at the first error.
+	// The realm is the bucket of the entry for engines which store realms separately, otherwise it is nil.
+	ForEach(consumer func(realm []byte, key []byte, value []byte) error) error
+	// Release releases the resources of the snapshot.
+	Release()
+}
+
+// DatabaseFactory opens the database with the given name in the given directory, tuned for the given storage medium.
+type DatabaseFactory func(directory string, name string, storageMedium string) (Database, error)
+
+var (
+	// the available database engines, keyed by their name
+	databaseEngines = map[string]DatabaseFactory{
+		EngineBolt:   newBoltDatabase,
+		EngineBadger: newBadgerDatabase,
+	}
+)
+
+// RegisterDatabaseEngine registers an additional database engine which can be selected by its name.
+// It has to be called before the databases are configured.
+func RegisterDatabaseEngine(engine string, factory DatabaseFactory) {
+	databaseEngines[engine] = factory
+}
+
+// openDatabase opens the database with the given name with the given engine.
+func openDatabase(engine string, directory string, name string, storageMedium string) (Database, error) {
+	switch storageMedium {
+	case StorageMediumSSD, StorageMediumHDD:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorageMedium, storageMedium)
+	}
+
+	factory, exists := databaseEngines[engine]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+
+	return factory(directory, name, storageMedium)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// boltDatabase is a database of the bbolt engine.
+type boltDatabase struct {
+	db       *bbolt.DB
+	store    kvstore.KVStore
+	filePath string
+}
+
+func newBoltDatabase(directory string, name string, _ string) (Database, error) {
+	opts := &bbolt.Options{
+		NoSync: true,
+	}
+	db, err := bolt.CreateDB(directory, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltDatabase{
+		db:       db,
+		store:    bolt.New(db),
+		filePath: path.Join(directory, name),
+	}, nil
+}
+
+func (b *boltDatabase) KVStore() kvstore.KVStore {
+	return b.store
+}
+
+func (b *boltDatabase) Sync() error {
+	// the database is opened with NoSync, so the changes are only written to disk on an explicit sync
+	return b.db.Sync()
+}
+
+func (b *boltDatabase) Close() error {
+	if err := b.db.Sync(); err != nil {
+		return err
+	}
+	return b.db.Close()
+}
+
+func (b *boltDatabase) SupportsCleanup() bool {
+	// Bolt does not support cleaning up anything
+	return false
+}
+
+func (b *boltDatabase) CleanupStep() (bool, error) {
+	return false, nil
+}
+
+func (b *boltDatabase) Size() int64 {
+	dbFile, err := os.Stat(b.filePath)
+	if err != nil {
+		return 0
+	}
+	return dbFile.Size()
+}
+
+func (b *boltDatabase) UsedSize() int64 {
+	// bolt never shrinks its file, deleted pages are only added to the freelist
+	size := b.Size() - int64(b.db.Stats().FreeAlloc)
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+func (b *boltDatabase) Snapshot() (DatabaseSnapshot, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return &boltSnapshot{tx: tx}, nil
+}
+
+// boltSnapshot is a read-only transaction of the bbolt engine.
+// Writers which have to grow the database file are blocked until the snapshot is released.
+type boltSnapshot struct {
+	tx *bbolt.Tx
+}
+
+func (s *boltSnapshot) ForEach(consumer func(realm []byte, key []byte, value []byte) error) error {
+	return s.tx.ForEach(func(bucketName []byte, bucket *bbolt.Bucket) error {
+		return bucket.ForEach(func(key []byte, value []byte) error {
+			// nested buckets are not used by the key value stores
+			if value == nil {
+				return nil
+			}
+			return consumer(bucketName, key, value)
+		})
+	})
+}
+
+func (s *boltSnapshot) Release() {
+	_ = s.tx.Rollback()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// badgerDatabase is a database of the BadgerDB engine, which is based on a LSM tree.
+type badgerDatabase struct {
+	db    *badger.DB
+	store kvstore.KVStore
+}
+
+// badgerOptions returns the BadgerDB options for the given directory tuned for the given storage medium.
+func badgerOptions(directory string, storageMedium string) badger.Options {
+	opts := badger.DefaultOptions(directory)
+	opts.Logger = nil
+
+	opts.LevelOneSize = 256 << 20
+	opts.LevelSizeMultiplier = 10
+	opts.MaxLevels = 7
+	opts.MaxTableSize = 64 << 20
+	opts.NumLevelZeroTables = 5
+	opts.NumLevelZeroTablesStall = 10
+	opts.NumMemtables = 5
+	opts.SyncWrites = true
+	opts.NumVersionsToKeep = 1
+	opts.CompactL0OnClose = true
+
+	opts.ValueLogFileSize = 1<<30 - 1
+	opts.ValueLogMaxEntries = 1000000
+	opts.Truncate = false
+	opts.LogRotatesToFlush = 2
+
+	switch storageMedium {
+	case StorageMediumHDD:
+		// random reads are expensive on hard disks, so the values are kept in the LSM tree next to the keys,
+		// and the compactions, which cause a lot of seeks, are limited to a single one at a time.
+		opts.TableLoadingMode = options.MemoryMap
+		opts.ValueLogLoadingMode = options.FileIO
+		opts.ValueThreshold = 1 << 10
+		opts.NumCompactors = 1
+
+	default:
+		// solid state drives handle parallel random reads well, so small values are separated into the value log
+		// to keep the LSM tree small, and more compactions are run in parallel.
+		opts.TableLoadingMode = options.MemoryMap
+		opts.ValueLogLoadingMode = options.MemoryMap
+		opts.ValueThreshold = 32
+		opts.NumCompactors = 4
+	}
+
+	if runtime.GOOS == "windows" {
+		opts = opts.WithTruncate(true)
+	}
+
+	return opts
+}
+
+func newBadgerDatabase(directory string, name string, storageMedium string) (Database, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	dbPath := path.Join(directory, name)
+	db, err := badgerstore.CreateDB(dbPath, badgerOptions(dbPath, storageMedium))
+	if err != nil {
+		return nil, err
+	}
+
+	return &badgerDatabase{
+		db:    db,
+		store: badgerstore.New(db),
+	}, nil
+}
+
+func (b *badgerDatabase) KVStore() kvstore.KVStore {
+	return b.store
+}
+
+func (b *badgerDatabase) Sync() error {
+	return b.db.Sync()
+}
+
+func (b *badgerDatabase) Close() error {
+	return b.db.Close()
+}
+
+func (b *badgerDatabase) SupportsCleanup() bool {
+	// Badger needs value log garbage collection
+	return true
+}
+
+func (b *badgerDatabase) CleanupStep() (bool, error) {
+	// rewrite a single value log file
+	if err := b.db.RunValueLogGC(0.7); err != nil {
+		if err == badger.ErrNoRewrite {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *badgerDatabase) Size() int64 {
+	lsm, vlog := b.db.Size()
+	return lsm + vlog
+}
+
+func (b *badgerDatabase) UsedSize() int64 {
+	// the files of badger shrink after the garbage collection
+	return b.Size()
+}
+
+func (b *badgerDatabase) Snapshot() (DatabaseSnapshot, error) {
+	return &badgerSnapshot{txn: b.db.NewTransaction(false)}, nil
+}
+
+// badgerSnapshot is a read-only transaction of the BadgerDB engine.
+type badgerSnapshot struct {
+	txn *badger.Txn
+}
+
+func (s *badgerSnapshot) ForEach(consumer func(realm []byte, key []byte, value []byte) error) error {
+	it := s.txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if err := consumer(nil, item.KeyCopy(nil), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *badgerSnapshot) Release() {
+	s.txn.Discard()
+}
@@ -0,0 +1,98 @@
+package tangle
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// The database operations reported to the DatabaseLatencyObserver.
+const (
+	DatabaseOperationRead    = "read"
+	DatabaseOperationWrite   = "write"
+	DatabaseOperationDelete  = "delete"
+	DatabaseOperationIterate = "iterate"
+	DatabaseOperationCommit  = "commit"
+)
+
+// DatabaseLatencyObserver is called with the duration of every database operation.
+type DatabaseLatencyObserver func(operation string, duration time.Duration)
+
+var (
+	databaseLatencyObserver atomic.Value
+)
+
+// SetDatabaseLatencyObserver sets the observer which gets the duration of every database operation.
+func SetDatabaseLatencyObserver(observer DatabaseLatencyObserver) {
+	databaseLatencyObserver.Store(observer)
+}
+
+// observeDatabaseLatency reports the duration since start to the observer, if one is set.
+func observeDatabaseLatency(operation string, start time.Time) {
+	if observer, ok := databaseLatencyObserver.Load().(DatabaseLatencyObserver); ok && observer != nil {
+		observer(operation, time.Since(start))
+	}
+}
+
+// meteredStore is a kvstore.KVStore which reports the latencies of its operations.
+type meteredStore struct {
+	kvstore.KVStore
+}
+
+func newMeteredStore(store kvstore.KVStore) kvstore.KVStore {
+	return &meteredStore{KVStore: store}
+}
+
+func (s *meteredStore) WithRealm(realm kvstore.Realm) kvstore.KVStore {
+	return newMeteredStore(s.KVStore.WithRealm(realm))
+}
+
+func (s *meteredStore) Iterate(prefix kvstore.KeyPrefix, kvConsumerFunc kvstore.IteratorKeyValueConsumerFunc) error {
+	defer observeDatabaseLatency(DatabaseOperationIterate, time.Now())
+	return s.KVStore.Iterate(prefix, kvConsumerFunc)
+}
+
+func (s *meteredStore) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	defer observeDatabaseLatency(DatabaseOperationIterate, time.Now())
+	return s.KVStore.IterateKeys(prefix, consumerFunc)
+}
+
+func (s *meteredStore) Get(key kvstore.Key) (kvstore.Value, error) {
+	defer observeDatabaseLatency(DatabaseOperationRead, time.Now())
+	return s.KVStore.Get(key)
+}
+
+func (s *meteredStore) Set(key kvstore.Key, value kvstore.Value) error {
+	defer observeDatabaseLatency(DatabaseOperationWrite, time.Now())
+	return s.KVStore.Set(key, value)
+}
+
+func (s *meteredStore) Has(key kvstore.Key) (bool, error) {
+	defer observeDatabaseLatency(DatabaseOperationRead, time.Now())
+	return s.KVStore.Has(key)
+}
+
+func (s *meteredStore) Delete(key kvstore.Key) error {
+	defer observeDatabaseLatency(DatabaseOperationDelete, time.Now())
+	return s.KVStore.Delete(key)
+}
+
+func (s *meteredStore) DeletePrefix(prefix kvstore.KeyPrefix) error {
+	defer observeDatabaseLatency(DatabaseOperationDelete, time.Now())
+	return s.KVStore.DeletePrefix(prefix)
+}
+
+func (s *meteredStore) Batched() kvstore.BatchedMutations {
+	return &meteredBatchedMutations{BatchedMutations: s.KVStore.Batched()}
+}
+
+// meteredBatchedMutations reports the latency of the commit of the batched mutations.
+type meteredBatchedMutations struct {
+	kvstore.BatchedMutations
+}
+
+func (b *meteredBatchedMutations) Commit() error {
+	defer observeDatabaseLatency(DatabaseOperationCommit, time.Now())
+	return b.BatchedMutations.Commit()
+}
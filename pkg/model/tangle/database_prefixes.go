@@ -18,4 +18,6 @@ const (
 	StorePrefixUnconfirmedTransactions byte = 14
 	StorePrefixSpentAddresses          byte = 15
 	StorePrefixAutopeering             byte = 16
+	StorePrefixPendingBroadcasts       byte = 17
+	StorePrefixBandwidthUsage          byte = 18
 )
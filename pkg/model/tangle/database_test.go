@@ -0,0 +1,37 @@
+package tangle
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltDatabaseUsedSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bolt")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := newBoltDatabase(dir, "test.db", StorageMediumSSD)
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := db.KVStore().WithRealm([]byte("test"))
+	value := bytes.Repeat([]byte{1}, 1024)
+	for i := 0; i < 10000; i++ {
+		require.NoError(t, store.Set([]byte{byte(i >> 8), byte(i)}, value))
+	}
+
+	sizeBefore := db.Size()
+	usedSizeBefore := db.UsedSize()
+	assert.True(t, usedSizeBefore <= sizeBefore)
+
+	require.NoError(t, store.Clear())
+
+	// the file doesn't shrink, but the data does
+	assert.Equal(t, sizeBefore, db.Size())
+	assert.True(t, db.UsedSize() < usedSizeBefore/2, "used size %d, before %d", db.UsedSize(), usedSizeBefore)
+}
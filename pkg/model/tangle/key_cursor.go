@@ -0,0 +1,19 @@
+package tangle
+
+import (
+	"bytes"
+)
+
+// forEachKeyAfterCursor loops over the keys with the given prefix in the order of the database and skips all keys
+// up to and including the given cursor, so that results can be paginated without counting the skipped keys.
+// The cursor of a key is the part of the key after the prefix. It is passed to the consumer together with the key.
+// The cache is skipped, since only the database keeps the keys in order.
+func forEachKeyAfterCursor(storage *meteredCache, prefix []byte, startAfter []byte, consumer func(key []byte, cursor []byte) bool) {
+	storage.ForEachKeyOnly(func(key []byte) bool {
+		cursor := key[len(prefix):]
+		if startAfter != nil && bytes.Compare(cursor, startAfter) <= 0 {
+			return true
+		}
+		return consumer(key, cursor)
+	}, true, prefix)
+}
@@ -0,0 +1,60 @@
+package tangle
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+func TestForEachApproverHashAfterCursor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cursor")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the cursors rely on the order of the keys, which is kept by the database engines
+	db, err := newBoltDatabase(dir, "tangle.db", StorageMediumSSD)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ConfigureStorages(db.KVStore().WithRealm([]byte("tangle")), db.KVStore().WithRealm([]byte("snapshot")), db.KVStore().WithRealm([]byte("spent")), profile.Profile2GB.Caches)
+
+	txHash := hornet.Hash(bytes.Repeat([]byte{1}, 49))
+	for _, b := range []byte{5, 3, 9, 7} {
+		StoreApprover(txHash, bytes.Repeat([]byte{b}, 49)).Release(true)
+	}
+	FlushStorages()
+
+	page := func(startAfter []byte, limit int) ([]byte, []byte) {
+		var approvers []byte
+		var last []byte
+		ForEachApproverHash(txHash, startAfter, func(approverHash hornet.Hash, cursor []byte) bool {
+			if len(approvers) == limit {
+				return false
+			}
+			approvers = append(approvers, approverHash[0])
+			last = append([]byte{}, cursor...)
+			return true
+		})
+		return approvers, last
+	}
+
+	approvers, cursor := page(nil, 2)
+	assert.Equal(t, []byte{3, 5}, approvers)
+
+	// a new approver before the cursor doesn't shift the next page
+	StoreApprover(txHash, bytes.Repeat([]byte{2}, 49)).Release(true)
+	FlushStorages()
+
+	approvers, cursor = page(cursor, 2)
+	assert.Equal(t, []byte{7, 9}, approvers)
+
+	approvers, _ = page(cursor, 2)
+	assert.Empty(t, approvers)
+}
@@ -0,0 +1,186 @@
+package tangle
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+// LedgerAuditMismatch is an address whose balance in the ledger state
+// differs from the balance recomputed from the snapshot and the ledger diffs.
+type LedgerAuditMismatch struct {
+	Address           hornet.Hash
+	Balance           uint64
+	RecomputedBalance uint64
+}
+
+// LedgerAuditResult is the result of a ledger audit.
+type LedgerAuditResult struct {
+	// the index of the snapshot the balances were recomputed from
+	SnapshotIndex milestone.Index
+	// the index of the audited ledger state
+	LedgerIndex milestone.Index
+	// the sum of all balances in the snapshot
+	SnapshotTotal uint64
+	// the sum of all balances in the ledger state
+	LedgerTotal uint64
+	// the amount of addresses which were recomputed
+	AddressesChecked int
+	// the milestones whose ledger diff doesn't sum up to zero
+	InvalidDiffs []milestone.Index
+	// the addresses whose balance doesn't match the recomputed balance
+	Mismatches []*LedgerAuditMismatch
+}
+
+// TotalSupplyValid tells whether the snapshot and the ledger state both hold the total supply.
+func (r *LedgerAuditResult) TotalSupplyValid() bool {
+	return r.SnapshotTotal == consts.TotalSupply && r.LedgerTotal == consts.TotalSupply
+}
+
+// Valid tells whether the audit didn't find any inconsistencies.
+func (r *LedgerAuditResult) Valid() bool {
+	return r.TotalSupplyValid() && len(r.InvalidDiffs) == 0 && len(r.Mismatches) == 0
+}
+
+// AuditLedger verifies the current ledger state against the balances of the last snapshot
+// with the ledger diffs of all milestones since then applied.
+// If addresses are given, only the balances of these addresses are recomputed, otherwise all of them.
+func AuditLedger(addresses hornet.Hashes, abortSignal <-chan struct{}) (*LedgerAuditResult, error) {
+
+	ReadLockLedger()
+	defer ReadUnlockLedger()
+
+	snapshotBalances, snapshotIndex, err := readSnapshotBalances(abortSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotIndex > ledgerMilestoneIndex {
+		return nil, fmt.Errorf("snapshot index is newer than the ledger index: %d > %d", snapshotIndex, ledgerMilestoneIndex)
+	}
+
+	ledgerBalances, err := readLedgerBalancesWithoutLocking(abortSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LedgerAuditResult{
+		SnapshotIndex: snapshotIndex,
+		LedgerIndex:   ledgerMilestoneIndex,
+		SnapshotTotal: sumBalances(snapshotBalances),
+		LedgerTotal:   sumBalances(ledgerBalances),
+	}
+
+	var recomputed map[string]int64
+	if len(addresses) == 0 {
+		recomputed, err = recomputeAllBalancesWithoutLocking(result, snapshotBalances, abortSignal)
+	} else {
+		recomputed, err = recomputeBalancesWithoutLocking(result, addresses, snapshotBalances, abortSignal)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for address, balance := range recomputed {
+		if balance < 0 || uint64(balance) != ledgerBalances[address] {
+			mismatch := &LedgerAuditMismatch{Address: hornet.Hash(address), Balance: ledgerBalances[address]}
+			if balance > 0 {
+				mismatch.RecomputedBalance = uint64(balance)
+			}
+			result.Mismatches = append(result.Mismatches, mismatch)
+		}
+	}
+
+	if len(addresses) == 0 {
+		// addresses which only exist in the ledger state
+		for address, balance := range ledgerBalances {
+			if _, exists := recomputed[address]; !exists {
+				result.Mismatches = append(result.Mismatches, &LedgerAuditMismatch{Address: hornet.Hash(address), Balance: balance})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// recomputeAllBalancesWithoutLocking applies the ledger diffs of all milestones since the snapshot to the snapshot balances.
+// ReadLockLedger must be held while entering this function.
+func recomputeAllBalancesWithoutLocking(result *LedgerAuditResult, snapshotBalances map[string]uint64, abortSignal <-chan struct{}) (map[string]int64, error) {
+
+	balances := make(map[string]int64, len(snapshotBalances))
+	for address, balance := range snapshotBalances {
+		balances[address] = int64(balance)
+	}
+
+	for msIndex := result.SnapshotIndex + 1; msIndex <= result.LedgerIndex; msIndex++ {
+		diff, err := readLedgerDiffWithoutLocking(msIndex, abortSignal)
+		if err != nil {
+			return nil, err
+		}
+
+		var diffSum int64
+		for address, change := range diff {
+			balances[address] += change
+			diffSum += change
+		}
+
+		if diffSum != 0 {
+			result.InvalidDiffs = append(result.InvalidDiffs, msIndex)
+		}
+	}
+
+	for address, balance := range balances {
+		if balance == 0 {
+			delete(balances, address)
+		}
+	}
+
+	result.AddressesChecked = len(balances)
+	return balances, nil
+}
+
+// recomputeBalancesWithoutLocking applies the ledger diffs of all milestones since the snapshot to the snapshot balances of the given addresses.
+// ReadLockLedger must be held while entering this function.
+func recomputeBalancesWithoutLocking(result *LedgerAuditResult, addresses hornet.Hashes, snapshotBalances map[string]uint64, abortSignal <-chan struct{}) (map[string]int64, error) {
+
+	balances := make(map[string]int64, len(addresses))
+	for _, address := range addresses {
+		balances[string(address[:49])] = int64(snapshotBalances[string(address[:49])])
+	}
+
+	for msIndex := result.SnapshotIndex + 1; msIndex <= result.LedgerIndex; msIndex++ {
+		select {
+		case <-abortSignal:
+			return nil, ErrOperationAborted
+		default:
+		}
+
+		for address := range balances {
+			value, err := ledgerDiffStore.Get(databaseKeyForLedgerDiffAndAddress(msIndex, hornet.Hash(address)))
+			if err != nil {
+				if err == kvstore.ErrKeyNotFound {
+					continue
+				}
+				return nil, errors.Wrap(NewDatabaseError(err), "failed to retrieve ledger diff")
+			}
+			balances[address] += diffFromBytes(value)
+		}
+	}
+
+	result.AddressesChecked = len(balances)
+	return balances, nil
+}
+
+func sumBalances(balances map[string]uint64) uint64 {
+	var total uint64
+	for _, balance := range balances {
+		total += balance
+	}
+	return total
+}
@@ -0,0 +1,114 @@
+package tangle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func configureAuditTestLedger(t *testing.T) {
+	configureTestStorages()
+
+	balances := map[string]uint64{
+		string(testAddress(1)): consts.TotalSupply - 100,
+		string(testAddress(2)): 100,
+	}
+	require.NoError(t, tangle.StoreSnapshotBalancesInDatabase(balances, 1))
+	require.NoError(t, tangle.StoreLedgerBalancesInDatabase(balances, 1))
+
+	tangle.WriteLockLedger()
+	defer tangle.WriteUnlockLedger()
+
+	require.NoError(t, tangle.ApplyLedgerDiffWithoutLocking(map[string]int64{
+		string(testAddress(2)): -100,
+		string(testAddress(3)): 100,
+	}, 2, 3))
+
+	require.NoError(t, tangle.ApplyLedgerDiffWithoutLocking(map[string]int64{
+		string(testAddress(1)): -50,
+		string(testAddress(3)): 50,
+	}, 3, 0))
+}
+
+func conflictingBundlesCount(t *testing.T, index milestone.Index) (int, bool) {
+	tangle.ReadLockLedger()
+	defer tangle.ReadUnlockLedger()
+
+	count, exists, err := tangle.GetConflictingBundlesCountWithoutLocking(index)
+	require.NoError(t, err)
+	return count, exists
+}
+
+func TestAuditLedger(t *testing.T) {
+	configureAuditTestLedger(t)
+
+	result, err := tangle.AuditLedger(nil, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Valid())
+	assert.Equal(t, milestone.Index(1), result.SnapshotIndex)
+	assert.Equal(t, milestone.Index(3), result.LedgerIndex)
+	assert.Equal(t, uint64(consts.TotalSupply), result.LedgerTotal)
+	assert.Equal(t, 2, result.AddressesChecked)
+
+	result, err = tangle.AuditLedger(hornet.Hashes{testAddress(2), testAddress(3)}, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Valid())
+	assert.Equal(t, 2, result.AddressesChecked)
+
+	count, exists := conflictingBundlesCount(t, 2)
+	assert.True(t, exists)
+	assert.Equal(t, 3, count)
+	count, exists = conflictingBundlesCount(t, 3)
+	assert.True(t, exists)
+	assert.Equal(t, 0, count)
+
+	// the count of a milestone confirmed before the count was stored is unknown
+	_, exists = conflictingBundlesCount(t, 4)
+	assert.False(t, exists)
+
+	// the count doesn't show up as a change of the ledger
+	diff, err := tangle.GetLedgerDiffForMilestone(2, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{string(testAddress(2)): -100, string(testAddress(3)): 100}, diff)
+
+	require.NoError(t, tangle.DeleteLedgerDiffForMilestone(2))
+	_, exists = conflictingBundlesCount(t, 2)
+	assert.False(t, exists)
+}
+
+func TestAuditLedgerMismatch(t *testing.T) {
+	configureAuditTestLedger(t)
+
+	// corrupt the ledger state
+	require.NoError(t, tangle.StoreLedgerBalancesInDatabase(map[string]uint64{
+		string(testAddress(1)): consts.TotalSupply - 150,
+		string(testAddress(3)): 100,
+		string(testAddress(4)): 10,
+	}, 3))
+
+	result, err := tangle.AuditLedger(nil, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+	assert.False(t, result.TotalSupplyValid())
+	assert.Equal(t, uint64(consts.TotalSupply-40), result.LedgerTotal)
+
+	mismatches := make(map[string]*tangle.LedgerAuditMismatch)
+	for _, mismatch := range result.Mismatches {
+		mismatches[string(mismatch.Address)] = mismatch
+	}
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, &tangle.LedgerAuditMismatch{Address: testAddress(3), Balance: 100, RecomputedBalance: 150}, mismatches[string(testAddress(3))])
+	assert.Equal(t, &tangle.LedgerAuditMismatch{Address: testAddress(4), Balance: 10}, mismatches[string(testAddress(4))])
+
+	result, err = tangle.AuditLedger(hornet.Hashes{testAddress(1)}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.Mismatches)
+	assert.False(t, result.Valid())
+}
@@ -0,0 +1,34 @@
+package tangle
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+func bytesFromConflictingBundlesCount(count int) []byte {
+	bytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bytes, uint32(count))
+	return bytes
+}
+
+// GetConflictingBundlesCountWithoutLocking returns the amount of conflicting bundles which were ignored by the given milestone.
+// The count is stored together with the ledger diff of the milestone, so it doesn't exist for milestones
+// which were confirmed before the count was introduced.
+// ReadLockLedger must be held while entering this function.
+func GetConflictingBundlesCountWithoutLocking(index milestone.Index) (count int, exists bool, err error) {
+
+	value, err := ledgerDiffStore.Get(databaseKeyForMilestoneIndex(index))
+	if err != nil {
+		if err == kvstore.ErrKeyNotFound {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(NewDatabaseError(err), "failed to retrieve conflicting bundles count")
+	}
+
+	return int(binary.LittleEndian.Uint32(value)), true, nil
+}
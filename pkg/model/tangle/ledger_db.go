@@ -138,6 +138,27 @@ func DeleteLedgerDiffForMilestone(index milestone.Index) error {
 // ReadLockLedger must be held while entering this function.
 func GetLedgerDiffForMilestoneWithoutLocking(index milestone.Index, abortSignal <-chan struct{}) (map[string]int64, error) {
 
+	diff, err := readLedgerDiffWithoutLocking(index, abortSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffSum int64
+	for _, change := range diff {
+		diffSum += change
+	}
+
+	if diffSum != 0 {
+		panic(fmt.Sprintf("GetLedgerDiffForMilestone(): Ledger diff for milestone %d does not sum up to zero", index))
+	}
+
+	return diff, nil
+}
+
+// readLedgerDiffWithoutLocking reads the ledger changes of that specific milestone without verifying them.
+// ReadLockLedger must be held while entering this function.
+func readLedgerDiffWithoutLocking(index milestone.Index, abortSignal <-chan struct{}) (map[string]int64, error) {
+
 	diff := make(map[string]int64)
 
 	keyPrefix := databaseKeyForMilestoneIndex(index)
@@ -150,6 +171,10 @@ func GetLedgerDiffForMilestoneWithoutLocking(index milestone.Index, abortSignal
 			return false
 		default:
 		}
+		if len(key) == len(keyPrefix) {
+			// the conflicting bundles count of the milestone
+			return true
+		}
 		// Remove prefix from key
 		diff[string(key[len(keyPrefix):len(keyPrefix)+49])] = diffFromBytes(value)
 		return true
@@ -163,15 +188,6 @@ func GetLedgerDiffForMilestoneWithoutLocking(index milestone.Index, abortSignal
 		return nil, ErrOperationAborted
 	}
 
-	var diffSum int64
-	for _, change := range diff {
-		diffSum += change
-	}
-
-	if diffSum != 0 {
-		panic(fmt.Sprintf("GetLedgerDiffForMilestone(): Ledger diff for milestone %d does not sum up to zero", index))
-	}
-
 	return diff, nil
 }
 
@@ -181,6 +197,10 @@ type LedgerDiffHashConsumer func(msIndex milestone.Index, address hornet.Hash) b
 // ForEachLedgerDiffHash loops over all ledger diffs.
 func ForEachLedgerDiffHash(consumer LedgerDiffHashConsumer, skipCache bool) {
 	ledgerDiffStore.IterateKeys([]byte{}, func(key kvstore.Key) bool {
+		if len(key) == 4 {
+			// the conflicting bundles count of the milestone
+			return true
+		}
 		return consumer(milestone.Index(binary.LittleEndian.Uint32(key[:4])), key[4:53])
 	})
 }
@@ -260,8 +280,9 @@ func GetLedgerStateForMilestone(targetIndex milestone.Index, abortSignal <-chan
 }
 
 // ApplyLedgerDiffWithoutLocking applies the changes to the ledger.
+// The amount of conflicting bundles ignored by the milestone is stored together with the diff.
 // WriteLockLedger must be held while entering this function.
-func ApplyLedgerDiffWithoutLocking(diff map[string]int64, index milestone.Index) error {
+func ApplyLedgerDiffWithoutLocking(diff map[string]int64, index milestone.Index, conflictingBundlesCount int) error {
 
 	balanceBatch := ledgerBalanceStore.Batched()
 	diffBatch := ledgerDiffStore.Batched()
@@ -297,6 +318,8 @@ func ApplyLedgerDiffWithoutLocking(diff map[string]int64, index milestone.Index)
 		panic(fmt.Sprintf("Ledger diff for milestone %d does not sum up to zero", index))
 	}
 
+	diffBatch.Set(databaseKeyForMilestoneIndex(index), bytesFromConflictingBundlesCount(conflictingBundlesCount))
+
 	if err := diffBatch.Commit(); err != nil {
 		return errors.Wrap(NewDatabaseError(err), "failed to store ledger diff")
 	}
@@ -349,6 +372,27 @@ func StoreLedgerBalancesInDatabase(balances map[string]uint64, index milestone.I
 // ReadLockLedger must be held while entering this function.
 func GetLedgerStateForLSMIWithoutLocking(abortSignal <-chan struct{}) (map[string]uint64, milestone.Index, error) {
 
+	balances, err := readLedgerBalancesWithoutLocking(abortSignal)
+	if err != nil {
+		return nil, ledgerMilestoneIndex, err
+	}
+
+	var total uint64
+	for _, value := range balances {
+		total += value
+	}
+
+	if total != consts.TotalSupply {
+		panic(fmt.Sprintf("total does not match supply: %d != %d", total, consts.TotalSupply))
+	}
+
+	return balances, ledgerMilestoneIndex, nil
+}
+
+// readLedgerBalancesWithoutLocking reads all balances of the current ledger state without verifying them.
+// ReadLockLedger must be held while entering this function.
+func readLedgerBalancesWithoutLocking(abortSignal <-chan struct{}) (map[string]uint64, error) {
+
 	balances := make(map[string]uint64)
 
 	aborted := false
@@ -364,23 +408,14 @@ func GetLedgerStateForLSMIWithoutLocking(abortSignal <-chan struct{}) (map[strin
 		return true
 	})
 	if err != nil {
-		return nil, ledgerMilestoneIndex, err
+		return nil, err
 	}
 
 	if aborted {
-		return nil, ledgerMilestoneIndex, ErrOperationAborted
-	}
-
-	var total uint64
-	for _, value := range balances {
-		total += value
-	}
-
-	if total != consts.TotalSupply {
-		panic(fmt.Sprintf("total does not match supply: %d != %d", total, consts.TotalSupply))
+		return nil, ErrOperationAborted
 	}
 
-	return balances, ledgerMilestoneIndex, err
+	return balances, nil
 }
 
 // GetLedgerStateForLSMI returns all balances for the current solid milestone.
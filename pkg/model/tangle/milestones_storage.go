@@ -14,7 +14,7 @@ import (
 )
 
 var (
-	milestoneStorage *objectstorage.ObjectStorage
+	milestoneStorage *meteredCache
 )
 
 func databaseKeyForMilestoneIndex(milestoneIndex milestone.Index) []byte {
@@ -39,7 +39,9 @@ func GetMilestoneStorageSize() int {
 
 func configureMilestoneStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	milestoneStorage = objectstorage.New(
+	milestoneStorage = newMeteredCache(
+		"milestones",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixMilestones}),
 		milestoneFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
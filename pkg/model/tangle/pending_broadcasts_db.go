@@ -0,0 +1,63 @@
+package tangle
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+var (
+	// pendingBroadcastsStore holds the locally submitted transactions which are not solid yet,
+	// so that their broadcast can be resumed after a restart.
+	pendingBroadcastsStore kvstore.KVStore
+)
+
+// PendingBroadcastConsumer is a function that consumes a pending broadcast.
+// Returning false from this function indicates to abort the iteration.
+type PendingBroadcastConsumer func(txHash hornet.Hash, submitted time.Time, txData []byte) bool
+
+func configurePendingBroadcastsStore(store kvstore.KVStore) {
+	pendingBroadcastsStore = store.WithRealm([]byte{StorePrefixPendingBroadcasts})
+}
+
+// StorePendingBroadcast stores the given compressed transaction data of a locally submitted transaction.
+func StorePendingBroadcast(txHash hornet.Hash, submitted time.Time, txData []byte) error {
+	value := make([]byte, 8, 8+len(txData))
+	binary.LittleEndian.PutUint64(value, uint64(submitted.Unix()))
+	value = append(value, txData...)
+
+	if err := pendingBroadcastsStore.Set(txHash, value); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store pending broadcast")
+	}
+	return nil
+}
+
+// DeletePendingBroadcast removes the given transaction from the pending broadcasts.
+func DeletePendingBroadcast(txHash hornet.Hash) error {
+	if err := pendingBroadcastsStore.Delete(txHash); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete pending broadcast")
+	}
+	return nil
+}
+
+// ForEachPendingBroadcast calls the consumer for every pending broadcast.
+func ForEachPendingBroadcast(consumer PendingBroadcastConsumer) error {
+	var innerErr error
+	if err := pendingBroadcastsStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		if len(value) < 8 {
+			innerErr = errors.Wrapf(NewDatabaseError(errors.New("value too short")), "invalid pending broadcast %s", hornet.Hash(key).Trytes())
+			return false
+		}
+
+		submitted := time.Unix(int64(binary.LittleEndian.Uint64(value[:8])), 0)
+		return consumer(hornet.Hash(key), submitted, value[8:])
+	}); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to iterate over pending broadcasts")
+	}
+	return innerErr
+}
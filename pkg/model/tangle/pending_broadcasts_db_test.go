@@ -0,0 +1,34 @@
+package tangle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func TestPendingBroadcasts(t *testing.T) {
+	configureTestStorages()
+
+	submitted := time.Unix(1600000000, 0)
+	require.NoError(t, tangle.StorePendingBroadcast(testAddress(1), submitted, []byte{1, 2, 3}))
+	require.NoError(t, tangle.StorePendingBroadcast(testAddress(2), submitted.Add(time.Minute), []byte{4, 5}))
+	require.NoError(t, tangle.DeletePendingBroadcast(testAddress(1)))
+
+	type entry struct {
+		txHash    hornet.Hash
+		submitted time.Time
+		txData    []byte
+	}
+	var entries []entry
+	require.NoError(t, tangle.ForEachPendingBroadcast(func(txHash hornet.Hash, submitted time.Time, txData []byte) bool {
+		entries = append(entries, entry{txHash, submitted, txData})
+		return true
+	}))
+
+	assert.Equal(t, []entry{{testAddress(2), submitted.Add(time.Minute), []byte{4, 5}}}, entries)
+}
@@ -126,9 +126,77 @@ func StoreSnapshotBalancesInDatabase(balances map[string]uint64, index milestone
 	return nil
 }
 
+// ApplyLedgerDiffToSnapshotBalances applies the accumulated ledger changes since the last snapshot index
+// to the stored snapshot ledger state and sets the new snapshot index.
+// In contrast to StoreSnapshotBalancesInDatabase only the changed addresses are touched.
+func ApplyLedgerDiffToSnapshotBalances(diff map[string]int64, index milestone.Index) error {
+
+	batch := snapshotLedgerStore.Batched()
+
+	for address, change := range diff {
+		if change == 0 {
+			continue
+		}
+
+		var balance uint64
+		value, err := snapshotLedgerStore.Get(hornet.Hash(address))
+		if err != nil {
+			if err != kvstore.ErrKeyNotFound {
+				return errors.Wrap(NewDatabaseError(err), "failed to retrieve snapshot balance")
+			}
+		} else {
+			balance = balanceFromBytes(value)
+		}
+
+		newBalance := int64(balance) + change
+
+		if newBalance < 0 {
+			return fmt.Errorf("ledger diff creates negative snapshot balance for address %s: current %d, diff %d", hornet.Hash(address).Trytes(), balance, change)
+		} else if newBalance == 0 {
+			if err := batch.Delete(hornet.Hash(address)); err != nil {
+				return errors.Wrap(NewDatabaseError(err), "failed to delete the balance")
+			}
+		} else {
+			if err := batch.Set(hornet.Hash(address), bytesFromBalance(uint64(newBalance))); err != nil {
+				return errors.Wrap(NewDatabaseError(err), "failed to set the balance")
+			}
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store snapshot ledger state")
+	}
+
+	if err := snapshotStore.Set([]byte(snapshotMilestoneIndexKey), bytesFromMilestoneIndex(index)); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store new snapshot index")
+	}
+
+	return nil
+}
+
 // GetAllSnapshotBalances returns all balances for the snapshot milestone.
 func GetAllSnapshotBalances(abortSignal <-chan struct{}) (map[string]uint64, milestone.Index, error) {
 
+	balances, snapshotMilestoneIndex, err := readSnapshotBalances(abortSignal)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total uint64
+	for _, value := range balances {
+		total += value
+	}
+
+	if total != consts.TotalSupply {
+		panic(fmt.Sprintf("GetAllSnapshotBalances() Total does not match supply: %d != %d", total, consts.TotalSupply))
+	}
+
+	return balances, snapshotMilestoneIndex, nil
+}
+
+// readSnapshotBalances reads all balances of the snapshot milestone without verifying them.
+func readSnapshotBalances(abortSignal <-chan struct{}) (map[string]uint64, milestone.Index, error) {
+
 	balances := make(map[string]uint64)
 
 	value, err := snapshotStore.Get([]byte(snapshotMilestoneIndexKey))
@@ -138,9 +206,11 @@ func GetAllSnapshotBalances(abortSignal <-chan struct{}) (map[string]uint64, mil
 
 	snapshotMilestoneIndex := milestoneIndexFromBytes(value)
 
+	aborted := false
 	err = snapshotLedgerStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
 		select {
 		case <-abortSignal:
+			aborted = true
 			return false
 		default:
 		}
@@ -153,14 +223,9 @@ func GetAllSnapshotBalances(abortSignal <-chan struct{}) (map[string]uint64, mil
 		return nil, 0, err
 	}
 
-	var total uint64
-	for _, value := range balances {
-		total += value
-	}
-
-	if total != consts.TotalSupply {
-		panic(fmt.Sprintf("GetAllSnapshotBalances() Total does not match supply: %d != %d", total, consts.TotalSupply))
+	if aborted {
+		return nil, 0, ErrOperationAborted
 	}
 
-	return balances, snapshotMilestoneIndex, err
+	return balances, snapshotMilestoneIndex, nil
 }
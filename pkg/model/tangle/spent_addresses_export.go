@@ -0,0 +1,201 @@
+package tangle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	// the magic bytes at the beginning of a spent addresses export
+	spentAddressesExportMagic = "HSPA"
+	// the version of the spent addresses export format
+	spentAddressesExportVersion byte = 1
+)
+
+var (
+	// ErrInvalidSpentAddressesExport is returned if a spent addresses export is malformed or its checksum doesn't match.
+	ErrInvalidSpentAddressesExport = errors.New("invalid spent addresses export")
+)
+
+// ExportSpentAddresses writes all spent addresses in the compact export format to the given writer.
+// The export is gzip compressed and contains a header (magic, version, count), the 49 byte addresses
+// and the sha256 checksum of the uncompressed header and addresses.
+// The spent addresses are locked during the export, so the writer must not block (e.g. write into a local file
+// instead of a network connection), otherwise no addresses can be marked as spent in the meantime.
+func ExportSpentAddresses(w io.Writer, abortSignal <-chan struct{}) (int32, error) {
+
+	ReadLockSpentAddresses()
+	defer ReadUnlockSpentAddresses()
+
+	var count int32
+	spentAddressesStorage.ForEachKeyOnly(func(key []byte) bool {
+		count++
+		return true
+	}, false)
+
+	gzipWriter := gzip.NewWriter(w)
+	checksum := sha256.New()
+	writer := io.MultiWriter(gzipWriter, checksum)
+
+	if _, err := writer.Write([]byte(spentAddressesExportMagic)); err != nil {
+		return 0, err
+	}
+
+	if err := binary.Write(writer, binary.LittleEndian, spentAddressesExportVersion); err != nil {
+		return 0, err
+	}
+
+	if err := binary.Write(writer, binary.LittleEndian, count); err != nil {
+		return 0, err
+	}
+
+	var addressesWritten int32
+	var writeErr error
+	wasAborted := false
+	spentAddressesStorage.ForEachKeyOnly(func(key []byte) bool {
+		select {
+		case <-abortSignal:
+			wasAborted = true
+			return false
+		default:
+		}
+
+		if addressesWritten == count {
+			return false
+		}
+
+		if _, writeErr = writer.Write(key[:49]); writeErr != nil {
+			return false
+		}
+		addressesWritten++
+		return true
+	}, false)
+
+	if wasAborted {
+		return 0, ErrOperationAborted
+	}
+
+	if writeErr != nil {
+		return 0, writeErr
+	}
+
+	if addressesWritten != count {
+		return 0, fmt.Errorf("spent addresses changed during the export: %d/%d", addressesWritten, count)
+	}
+
+	if _, err := gzipWriter.Write(checksum.Sum(nil)); err != nil {
+		return 0, err
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return 0, err
+	}
+
+	return addressesWritten, nil
+}
+
+// readSpentAddressesExport reads a spent addresses export and passes every address to the consumer.
+// The checksum is only verified after all addresses were passed.
+func readSpentAddressesExport(r io.Reader, consumer func(address []byte) error, abortSignal <-chan struct{}) (int32, error) {
+
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSpentAddressesExport, err)
+	}
+	defer gzipReader.Close()
+
+	checksum := sha256.New()
+	reader := io.TeeReader(gzipReader, checksum)
+
+	magic := make([]byte, len(spentAddressesExportMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != spentAddressesExportMagic {
+		return 0, fmt.Errorf("%w: wrong magic bytes", ErrInvalidSpentAddressesExport)
+	}
+
+	var version byte
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil || version != spentAddressesExportVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidSpentAddressesExport, version)
+	}
+
+	var count int32
+	if err := binary.Read(reader, binary.LittleEndian, &count); err != nil || count < 0 {
+		return 0, fmt.Errorf("%w: invalid count", ErrInvalidSpentAddressesExport)
+	}
+
+	address := make([]byte, 49)
+	for i := int32(0); i < count; i++ {
+		select {
+		case <-abortSignal:
+			return 0, ErrOperationAborted
+		default:
+		}
+
+		if _, err := io.ReadFull(reader, address); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidSpentAddressesExport, err)
+		}
+
+		if err := consumer(address); err != nil {
+			return 0, err
+		}
+	}
+
+	expectedChecksum := checksum.Sum(nil)
+
+	fileChecksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(gzipReader, fileChecksum); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSpentAddressesExport, err)
+	}
+
+	if !bytes.Equal(expectedChecksum, fileChecksum) {
+		return 0, fmt.Errorf("%w: checksum mismatch", ErrInvalidSpentAddressesExport)
+	}
+
+	// reading until the end also verifies the integrity of the compressed stream
+	if trailing, err := io.Copy(ioutil.Discard, gzipReader); err != nil || trailing != 0 {
+		return 0, fmt.Errorf("%w: corrupted end of the export", ErrInvalidSpentAddressesExport)
+	}
+
+	return count, nil
+}
+
+// ImportSpentAddressesFromFile imports the spent addresses of the export at the given path.
+// The whole export is verified before any address is marked as spent.
+func ImportSpentAddressesFromFile(filePath string, abortSignal <-chan struct{}) (int32, error) {
+
+	readExport := func(consumer func(address []byte) error) (int32, error) {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+
+		return readSpentAddressesExport(file, consumer, abortSignal)
+	}
+
+	// verify the export first
+	if _, err := readExport(func(_ []byte) error { return nil }); err != nil {
+		return 0, err
+	}
+
+	WriteLockSpentAddresses()
+	defer WriteUnlockSpentAddresses()
+
+	var imported int32
+	if _, err := readExport(func(address []byte) error {
+		if MarkAddressAsSpentWithoutLocking(append([]byte{}, address...)) {
+			imported++
+		}
+		return nil
+	}); err != nil {
+		return imported, err
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,89 @@
+package tangle_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+func configureTestStorages() {
+	store := mapdb.NewMapDB()
+	tangle.ConfigureStorages(
+		store.WithRealm([]byte("tangle")),
+		store.WithRealm([]byte("snapshot")),
+		store.WithRealm([]byte("spent")),
+		profile.Profile2GB.Caches,
+	)
+}
+
+func testAddress(b byte) hornet.Hash {
+	return bytes.Repeat([]byte{b}, 49)
+}
+
+func TestSpentAddressesExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	exportPath := filepath.Join(dir, "spent.bin.gz")
+
+	configureTestStorages()
+	for i := byte(1); i <= 10; i++ {
+		tangle.MarkAddressAsSpent(testAddress(i))
+	}
+
+	exportFile, err := os.Create(exportPath)
+	require.NoError(t, err)
+	exported, err := tangle.ExportSpentAddresses(exportFile, nil)
+	require.NoError(t, err)
+	require.NoError(t, exportFile.Close())
+	assert.EqualValues(t, 10, exported)
+	tangle.ShutdownSpentAddressesStorage()
+
+	configureTestStorages()
+	tangle.MarkAddressAsSpent(testAddress(1))
+
+	imported, err := tangle.ImportSpentAddressesFromFile(exportPath, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, imported)
+
+	for i := byte(1); i <= 10; i++ {
+		assert.True(t, tangle.WasAddressSpentFrom(testAddress(i)))
+	}
+	assert.False(t, tangle.WasAddressSpentFrom(testAddress(11)))
+	tangle.ShutdownSpentAddressesStorage()
+}
+
+func TestSpentAddressesImportRejectsCorruptedExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	exportPath := filepath.Join(dir, "spent.bin.gz")
+
+	configureTestStorages()
+	tangle.MarkAddressAsSpent(testAddress(1))
+
+	var buf bytes.Buffer
+	_, err = tangle.ExportSpentAddresses(&buf, nil)
+	require.NoError(t, err)
+	tangle.ShutdownSpentAddressesStorage()
+
+	// cut off the end of the compressed stream
+	require.NoError(t, ioutil.WriteFile(exportPath, buf.Bytes()[:buf.Len()-10], 0600))
+
+	configureTestStorages()
+	_, err = tangle.ImportSpentAddressesFromFile(exportPath, nil)
+	assert.Error(t, err)
+	assert.False(t, tangle.WasAddressSpentFrom(testAddress(1)))
+	tangle.ShutdownSpentAddressesStorage()
+}
@@ -14,7 +14,7 @@ import (
 )
 
 var (
-	spentAddressesStorage *objectstorage.ObjectStorage
+	spentAddressesStorage *meteredCache
 	spentAddressesLock    sync.RWMutex
 )
 
@@ -53,7 +53,9 @@ func GetSpentAddressesStorageSize() int {
 
 func configureSpentAddressesStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	spentAddressesStorage = objectstorage.New(
+	spentAddressesStorage = newMeteredCache(
+		"spentAddresses",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixSpentAddresses}),
 		spentAddressFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
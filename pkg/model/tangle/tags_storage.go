@@ -10,7 +10,7 @@ import (
 	"github.com/gohornet/hornet/pkg/profile"
 )
 
-var tagsStorage *objectstorage.ObjectStorage
+var tagsStorage *meteredCache
 
 type CachedTag struct {
 	objectstorage.CachedObject
@@ -40,7 +40,9 @@ func GetTagsStorageSize() int {
 
 func configureTagsStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	tagsStorage = objectstorage.New(
+	tagsStorage = newMeteredCache(
+		"tags",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixTags}),
 		tagsFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -74,6 +76,14 @@ func GetTagHashes(txTag hornet.Hash, forceRelease bool, maxFind ...int) hornet.H
 	return tagHashes
 }
 
+// ForEachTagHash loops over the hashes of all transactions with the given tag.
+// The loop starts after the given cursor, the cursor of every result is passed to the consumer.
+func ForEachTagHash(txTag hornet.Hash, startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+	forEachKeyAfterCursor(tagsStorage, txTag, startAfter, func(key []byte, cursor []byte) bool {
+		return consumer(hornet.Hash(key[17:66]), cursor)
+	})
+}
+
 // TagConsumer consumes the given tag during looping through all tags in the persistence layer.
 type TagConsumer func(txTag hornet.Hash, txHash hornet.Hash) bool
 
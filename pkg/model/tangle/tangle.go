@@ -2,13 +2,9 @@ package tangle
 
 import (
 	"errors"
-	"os"
-	"path"
-
-	"go.etcd.io/bbolt"
+	"fmt"
 
 	"github.com/iotaledger/hive.go/kvstore"
-	"github.com/iotaledger/hive.go/kvstore/bolt"
 
 	"github.com/gohornet/hornet/pkg/profile"
 )
@@ -17,42 +13,43 @@ const (
 	TangleDbFilename         = "tangle.db"
 	SnapshotDbFilename       = "snapshot.db"
 	SpentAddressesDbFilename = "spent.db"
+
+	// EngineBolt is the name of the bbolt database engine.
+	EngineBolt = "bolt"
+	// EngineBadger is the name of the BadgerDB database engine.
+	EngineBadger = "badger"
 )
 
 var (
-	dbDir      string
-	tangleDb   *bbolt.DB
-	snapshotDb *bbolt.DB
-	spentDb    *bbolt.DB
+	tangleDb   Database
+	snapshotDb Database
+	spentDb    Database
+
+	// the name of the engine of the databases
+	databaseEngine string
 
 	ErrNothingToCleanUp = errors.New("Nothing to clean up in the databases")
+	ErrUnknownEngine    = errors.New("unknown database engine")
 )
 
-func boltDB(directory string, filename string) *bbolt.DB {
-	opts := &bbolt.Options{
-		NoSync: true,
-	}
-	db, err := bolt.CreateDB(directory, filename, opts)
-	if err != nil {
-		panic(err)
-	}
-	return db
-}
-
-func ConfigureDatabases(directory string) {
-
-	dbDir = directory
-	tangleDb = boltDB(directory, TangleDbFilename)
+// ConfigureDatabases opens the databases in the given directory with the given engine,
+// tuned for the given storage medium (ssd or hdd).
+func ConfigureDatabases(directory string, engine string, storageMedium string) {
 
-	tangleStore := bolt.New(tangleDb)
-
-	snapshotDb = boltDB(directory, SnapshotDbFilename)
-	snapshotStore := bolt.New(snapshotDb)
+	openDb := func(name string) Database {
+		db, err := openDatabase(engine, directory, name, storageMedium)
+		if err != nil {
+			panic(fmt.Errorf("opening database %s failed: %w", name, err))
+		}
+		return db
+	}
 
-	spentDb = boltDB(directory, SpentAddressesDbFilename)
-	spentStore := bolt.New(spentDb)
+	databaseEngine = engine
+	tangleDb = openDb(TangleDbFilename)
+	snapshotDb = openDb(SnapshotDbFilename)
+	spentDb = openDb(SpentAddressesDbFilename)
 
-	ConfigureStorages(tangleStore, snapshotStore, spentStore, profile.LoadProfile().Caches)
+	ConfigureStorages(newMeteredStore(tangleDb.KVStore()), newMeteredStore(snapshotDb.KVStore()), newMeteredStore(spentDb.KVStore()), profile.LoadProfile().Caches)
 }
 
 func ConfigureStorages(tangleStore kvstore.KVStore, snapshotStore kvstore.KVStore, spentStore kvstore.KVStore, caches profile.Caches) {
@@ -67,6 +64,8 @@ func ConfigureStorages(tangleStore kvstore.KVStore, snapshotStore kvstore.KVStor
 	configureMilestoneStorage(tangleStore, caches.Milestones)
 	configureUnconfirmedTxStorage(tangleStore, caches.UnconfirmedTx)
 	configureLedgerStore(tangleStore)
+	configurePendingBroadcastsStore(tangleStore)
+	configureBandwidthUsageStore(tangleStore)
 
 	configureSnapshotStore(snapshotStore)
 
@@ -103,58 +102,79 @@ func LoadInitialValuesFromDatabase() {
 	loadSolidEntryPoints()
 }
 
-func CloseDatabases() error {
-
-	if err := tangleDb.Sync(); err != nil {
-		return err
-	}
-
-	if err := tangleDb.Close(); err != nil {
-		return err
-	}
-
-	if err := snapshotDb.Sync(); err != nil {
-		return err
-	}
+// SyncDatabases writes all pending changes of the databases to disk.
+func SyncDatabases() error {
 
-	if err := snapshotDb.Close(); err != nil {
-		return err
+	for _, db := range []Database{tangleDb, snapshotDb, spentDb} {
+		if err := db.Sync(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if err := spentDb.Sync(); err != nil {
-		return err
-	}
+func CloseDatabases() error {
 
-	if err := spentDb.Close(); err != nil {
-		return err
+	for _, db := range []Database{tangleDb, snapshotDb, spentDb} {
+		if err := db.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func DatabaseSupportsCleanup() bool {
-	// Bolt does not support cleaning up anything
-	return false
+	return tangleDb.SupportsCleanup()
 }
 
 func CleanupDatabases() error {
-	// Bolt does not support cleaning up anything
-	return ErrNothingToCleanUp
+	return CleanupDatabasesStepwise(nil)
 }
 
-// GetDatabaseSizes returns the size of the different databases.
-func GetDatabaseSizes() (tangle int64, snapshot int64, spent int64) {
-
-	if tangleDbFile, err := os.Stat(path.Join(dbDir, TangleDbFilename)); err == nil {
-		tangle = tangleDbFile.Size()
+// CleanupDatabasesStepwise runs the garbage collection of the databases round by round until there is nothing left to clean up.
+// The optional beforeRound function is called with the name of the database before every round and aborts the cleanup by returning false.
+func CleanupDatabasesStepwise(beforeRound func(database string) bool) error {
+	if !DatabaseSupportsCleanup() {
+		return ErrNothingToCleanUp
 	}
 
-	if snapshotDbFile, err := os.Stat(path.Join(dbDir, SnapshotDbFilename)); err == nil {
-		snapshot = snapshotDbFile.Size()
+	cleaned := false
+	for _, db := range []struct {
+		name     string
+		database Database
+	}{
+		{TangleDbFilename, tangleDb},
+		{SnapshotDbFilename, snapshotDb},
+		{SpentAddressesDbFilename, spentDb},
+	} {
+		for {
+			if beforeRound != nil && !beforeRound(db.name) {
+				return ErrOperationAborted
+			}
+
+			dbCleaned, err := db.database.CleanupStep()
+			if err != nil {
+				return err
+			}
+			if !dbCleaned {
+				break
+			}
+			cleaned = true
+		}
 	}
 
-	if spentDbFile, err := os.Stat(path.Join(dbDir, SpentAddressesDbFilename)); err == nil {
-		spent = spentDbFile.Size()
+	if !cleaned {
+		return ErrNothingToCleanUp
 	}
+	return nil
+}
+
+// GetDatabaseSizes returns the size of the different databases.
+func GetDatabaseSizes() (tangle int64, snapshot int64, spent int64) {
+	return tangleDb.Size(), snapshotDb.Size(), spentDb.Size()
+}
 
-	return
+// GetDatabaseUsedSize returns the size of the data in all databases, without the space which is free for reuse.
+func GetDatabaseUsedSize() int64 {
+	return tangleDb.UsedSize() + snapshotDb.UsedSize() + spentDb.UsedSize()
 }
@@ -13,8 +13,8 @@ import (
 )
 
 var (
-	txStorage       *objectstorage.ObjectStorage
-	metadataStorage *objectstorage.ObjectStorage
+	txStorage       *meteredCache
+	metadataStorage *meteredCache
 )
 
 func TransactionCaller(handler interface{}, params ...interface{}) {
@@ -153,7 +153,9 @@ func GetTransactionStorageSize() int {
 
 func configureTransactionStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	txStorage = objectstorage.New(
+	txStorage = newMeteredCache(
+		"transactions",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixTransactions}),
 		transactionFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -166,7 +168,9 @@ func configureTransactionStorage(store kvstore.KVStore, opts profile.CacheOpts)
 			}),
 	)
 
-	metadataStorage = objectstorage.New(
+	metadataStorage = newMeteredCache(
+		"metadata",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixTransactionMetadata}),
 		metadataFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
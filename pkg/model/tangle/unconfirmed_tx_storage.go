@@ -12,7 +12,7 @@ import (
 	"github.com/gohornet/hornet/pkg/profile"
 )
 
-var unconfirmedTxStorage *objectstorage.ObjectStorage
+var unconfirmedTxStorage *meteredCache
 
 type CachedUnconfirmedTx struct {
 	objectstorage.CachedObject
@@ -42,7 +42,9 @@ func GetUnconfirmedTxStorageSize() int {
 
 func configureUnconfirmedTxStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
-	unconfirmedTxStorage = objectstorage.New(
+	unconfirmedTxStorage = newMeteredCache(
+		"unconfirmedTx",
+		opts.CacheSize,
 		store.WithRealm([]byte{StorePrefixUnconfirmedTransactions}),
 		unconfirmedTxFactory,
 		objectstorage.CacheTime(time.Duration(opts.CacheTimeMs)*time.Millisecond),
@@ -0,0 +1,23 @@
+package peering
+
+import (
+	"github.com/gohornet/hornet/pkg/bandwidth"
+	"github.com/gohornet/hornet/pkg/metrics"
+)
+
+// Bandwidth returns the budget the gossip traffic is accounted against.
+func (m *Manager) Bandwidth() *bandwidth.Budget {
+	return m.bandwidth
+}
+
+// AccountSent accounts the given amount of bytes sent to a peer.
+func (m *Manager) AccountSent(n int) {
+	metrics.SharedServerMetrics.SentBytes.Add(uint64(n))
+	m.bandwidth.AddSent(n)
+}
+
+// accounts the given amount of bytes received from a peer.
+func (m *Manager) accountReceived(n int) {
+	metrics.SharedServerMetrics.ReceivedBytes.Add(uint64(n))
+	m.bandwidth.AddReceived(n)
+}
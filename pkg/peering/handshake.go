@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
 	"github.com/gohornet/hornet/pkg/protocol"
 	"github.com/gohornet/hornet/pkg/protocol/handshake"
 )
@@ -93,6 +94,18 @@ func (m *Manager) verifyHandshake(p *peer.Peer, handshakeMsg *handshake.Handshak
 		}
 	}
 
+	// peers with a pinned identity key have to authenticate with it
+	if m.Opts.Identity != nil {
+		if pinnedKey := m.PinnedKey(p.ID); pinnedKey != nil {
+			if p.PublicKey == nil {
+				return errors.Wrapf(ErrPeerNotSecured, p.ID)
+			}
+			if !bytes.Equal(p.PublicKey, pinnedKey) {
+				return errors.Wrapf(secure.ErrNonMatchingPublicKey, p.ID)
+			}
+		}
+	}
+
 	// check whether the peer is already connected by checking each peer's IP addresses
 	m.Lock()
 	for _, connectedPeer := range m.connected {
@@ -133,6 +146,7 @@ func (m *Manager) verifyHandshake(p *peer.Peer, handshakeMsg *handshake.Handshak
 	m.Unlock()
 
 	p.Protocol.FeatureSet = byte(version)
+	p.Protocol.Capabilities = handshakeMsg.Capabilities & protocol.SupportedCapabilities
 	p.Protocol.Handshaked()
 	return nil
 }
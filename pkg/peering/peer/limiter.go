@@ -0,0 +1,126 @@
+package peer
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// the minimum burst of the bandwidth limits, so that bigger chunks of data don't need to be split up.
+	minBandwidthBurst = 64 * 1024
+)
+
+// LimitOptions defines the limits of the gossip with a single peer.
+// A limit of zero disables the corresponding limit.
+type LimitOptions struct {
+	// The maximum amount of transactions per second received from the peer.
+	InboundTransactionsPerSecond float64 `json:"inboundTransactionsPerSecond"`
+	// The maximum amount of bytes per second received from the peer.
+	InboundBytesPerSecond int `json:"inboundBytesPerSecond"`
+	// The maximum amount of transactions per second sent to the peer.
+	OutboundTransactionsPerSecond float64 `json:"outboundTransactionsPerSecond"`
+	// The maximum amount of bytes per second sent to the peer.
+	OutboundBytesPerSecond int `json:"outboundBytesPerSecond"`
+}
+
+// Limiter limits the transaction rate and the bandwidth of the gossip with a peer.
+type Limiter struct {
+	Opts LimitOptions
+
+	inboundTransactions  *rate.Limiter
+	inboundBytes         *rate.Limiter
+	outboundTransactions *rate.Limiter
+	outboundBytes        *rate.Limiter
+}
+
+// NewLimiter creates a new Limiter with the given limits.
+func NewLimiter(opts LimitOptions) *Limiter {
+	return &Limiter{
+		Opts:                 opts,
+		inboundTransactions:  newTransactionLimiter(opts.InboundTransactionsPerSecond),
+		inboundBytes:         newBandwidthLimiter(opts.InboundBytesPerSecond),
+		outboundTransactions: newTransactionLimiter(opts.OutboundTransactionsPerSecond),
+		outboundBytes:        newBandwidthLimiter(opts.OutboundBytesPerSecond),
+	}
+}
+
+func newTransactionLimiter(transactionsPerSecond float64) *rate.Limiter {
+	if transactionsPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(transactionsPerSecond), int(math.Max(1, math.Ceil(transactionsPerSecond))))
+}
+
+func newBandwidthLimiter(bytesPerSecond int) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	burst := bytesPerSecond
+	if burst < minBandwidthBurst {
+		burst = minBandwidthBurst
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), burst)
+}
+
+// AllowInboundTransaction reports whether a transaction received from the peer may be processed now.
+func (l *Limiter) AllowInboundTransaction() bool {
+	return l == nil || l.inboundTransactions == nil || l.inboundTransactions.Allow()
+}
+
+// AllowOutboundTransaction reports whether a transaction may be sent to the peer now.
+func (l *Limiter) AllowOutboundTransaction() bool {
+	return l == nil || l.outboundTransactions == nil || l.outboundTransactions.Allow()
+}
+
+// WaitInbound blocks until n received bytes fit into the inbound bandwidth limit.
+// Returns false if the abort signal was closed in the meantime.
+func (l *Limiter) WaitInbound(n int, abortSignal <-chan struct{}) bool {
+	if l == nil {
+		return true
+	}
+	return waitN(l.inboundBytes, n, abortSignal)
+}
+
+// WaitOutbound blocks until n bytes to send fit into the outbound bandwidth limit.
+// Returns false if the abort signal was closed in the meantime.
+func (l *Limiter) WaitOutbound(n int, abortSignal <-chan struct{}) bool {
+	if l == nil {
+		return true
+	}
+	return waitN(l.outboundBytes, n, abortSignal)
+}
+
+// waitN blocks until n tokens are available in the given limiter, or the abort signal is closed.
+func waitN(limiter *rate.Limiter, n int, abortSignal <-chan struct{}) bool {
+	if limiter == nil {
+		return true
+	}
+
+	for n > 0 {
+		// reservations bigger than the burst are never possible
+		chunk := n
+		if chunk > limiter.Burst() {
+			chunk = limiter.Burst()
+		}
+		n -= chunk
+
+		reservation := limiter.ReserveN(time.Now(), chunk)
+		delay := reservation.Delay()
+		if delay == 0 {
+			continue
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-abortSignal:
+			timer.Stop()
+			reservation.Cancel()
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,45 @@
+package peer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+func TestLimiterTransactions(t *testing.T) {
+	l := peer.NewLimiter(peer.LimitOptions{InboundTransactionsPerSecond: 2})
+
+	assert.True(t, l.AllowInboundTransaction())
+	assert.True(t, l.AllowInboundTransaction())
+	assert.False(t, l.AllowInboundTransaction())
+
+	// outbound is unlimited
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.AllowOutboundTransaction())
+	}
+}
+
+func TestLimiterBandwidthAbort(t *testing.T) {
+	l := peer.NewLimiter(peer.LimitOptions{OutboundBytesPerSecond: 1024})
+
+	abortSignal := make(chan struct{})
+
+	// the burst is allowed right away
+	assert.True(t, l.WaitOutbound(64*1024, abortSignal))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(abortSignal)
+	}()
+
+	// exceeding the limit blocks until the abort signal is closed
+	assert.False(t, l.WaitOutbound(64*1024, abortSignal))
+
+	// a nil limiter doesn't limit anything
+	var nilLimiter *peer.Limiter
+	assert.True(t, nilLimiter.AllowInboundTransaction())
+	assert.True(t, nilLimiter.WaitInbound(1<<20, nil))
+}
@@ -1,6 +1,8 @@
 package peer
 
 import (
+	"crypto/ed25519"
+	"fmt"
 	"net"
 	"strconv"
 	"strings"
@@ -31,6 +33,13 @@ const (
 	Outbound
 )
 
+const (
+	// TransportTCP is the transport of the gossip via TCP.
+	TransportTCP = "tcp"
+	// TransportQUIC is the transport of the gossip via a stream of a QUIC connection.
+	TransportQUIC = "quic"
+)
+
 const (
 	// SendQueueSize defines the size of the send queue of every created peer.
 	SendQueueSize = 1500
@@ -51,10 +60,28 @@ func IdentityCaller(handler interface{}, params ...interface{}) {
 	handler.(func(identity.ID))(params[0].(identity.ID))
 }
 
+// ParseTransport checks the given transport of the gossip, an empty transport is TCP.
+func ParseTransport(transport string) (string, error) {
+	switch strings.ToLower(transport) {
+	case "", TransportTCP:
+		return TransportTCP, nil
+	case TransportQUIC:
+		return TransportQUIC, nil
+	default:
+		return "", fmt.Errorf("unknown transport '%s'", transport)
+	}
+}
+
 // NewInboundPeer creates a new peer instance which is marked as being inbound.
-func NewInboundPeer(remoteAddr net.Addr) *Peer {
+func NewInboundPeer(remoteAddr net.Addr, transport string) *Peer {
 	addresses := iputils.NewIPAddresses()
-	primaryAddr := net.ParseIP(remoteAddr.(*net.TCPAddr).IP.String())
+	var primaryAddr net.IP
+	switch addr := remoteAddr.(type) {
+	case *net.TCPAddr:
+		primaryAddr = net.ParseIP(addr.IP.String())
+	case *net.UDPAddr:
+		primaryAddr = net.ParseIP(addr.IP.String())
+	}
 	addresses.Add(primaryAddr)
 
 	// InitAddress and ID are set after handshaking
@@ -62,6 +89,7 @@ func NewInboundPeer(remoteAddr net.Addr) *Peer {
 		PrimaryAddress:   primaryAddr,
 		Addresses:        addresses,
 		ConnectionOrigin: Inbound,
+		Transport:        transport,
 		SendQueue:        make(chan []byte, SendQueueSize),
 		Events: Events{
 			HeartbeatUpdated: events.NewEvent(sting.HeartbeatCaller),
@@ -70,7 +98,7 @@ func NewInboundPeer(remoteAddr net.Addr) *Peer {
 }
 
 // NewOutboundPeer creates a new peer instance which is marked as being outbound.
-func NewOutboundPeer(originAddr *iputils.OriginAddress, primaryAddr net.IP, port uint16, addresses *iputils.IPAddresses) *Peer {
+func NewOutboundPeer(originAddr *iputils.OriginAddress, primaryAddr net.IP, port uint16, addresses *iputils.IPAddresses, transport string) *Peer {
 	return &Peer{
 		InitAddress:             originAddr,
 		ID:                      NewID(primaryAddr.String(), port),
@@ -78,6 +106,7 @@ func NewOutboundPeer(originAddr *iputils.OriginAddress, primaryAddr net.IP, port
 		Addresses:               addresses,
 		MoveBackToReconnectPool: true,
 		ConnectionOrigin:        Outbound,
+		Transport:               transport,
 		SendQueue:               make(chan []byte, SendQueueSize),
 		Events: Events{
 			HeartbeatUpdated: events.NewEvent(sting.HeartbeatCaller),
@@ -108,6 +137,8 @@ type Peer struct {
 	Metrics Metrics
 	// Whether the connection for this peer was handled inbound or was created outbound.
 	ConnectionOrigin ConnectionOrigin
+	// The transport of the gossip with the peer, TransportTCP or TransportQUIC.
+	Transport string
 	// Whether to place this peer back into the reconnect pool when the connection is closed.
 	MoveBackToReconnectPool bool
 	// Whether the peer is a duplicate, as it is already connected.
@@ -116,8 +147,12 @@ type Peer struct {
 	LatestHeartbeat *sting.Heartbeat
 	// Holds the autopeering info if this peer was added via autopeering.
 	Autopeering *peer.Peer
+	// The identity key the peer authenticated with, nil if the connection is not secured.
+	PublicKey ed25519.PublicKey
 	// A channel which contains messages to be sent to the given peer.
 	SendQueue chan []byte
+	// Limits the transaction rate and bandwidth of the gossip with the peer.
+	Limiter *Limiter
 	// Whether this peer is marked as disconnected.
 	// Used to suppress errors stemming from connection closure.
 	Disconnected bool
@@ -127,6 +162,8 @@ type Peer struct {
 	staledAutopeerCheckLastSentPackets uint32
 	// The last amount of dropped packets at the last autopeer stale check
 	staledAutopeerCheckLastDroppedPackets uint32
+	// The state of the staleness checks
+	staleness staleness
 }
 
 // IsInbound tells whether the peer's connection was inbound.
@@ -181,38 +218,84 @@ func (p *Peer) EnqueueForSending(data []byte) {
 	}
 }
 
+// EnqueueForSendingTimeout enqueues the given data to be sent to the peer.
+// If the send queue is over capacity, it waits up to the given timeout for free space before the message gets dropped.
+// Returns whether the message was enqueued.
+func (p *Peer) EnqueueForSendingTimeout(data []byte, timeout time.Duration) bool {
+	select {
+	case p.SendQueue <- data:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case p.SendQueue <- data:
+		return true
+	case <-timer.C:
+		metrics.SharedServerMetrics.DroppedMessages.Inc()
+		p.Metrics.DroppedPackets.Inc()
+		return false
+	}
+}
+
 // Info returns a snapshot of the peer in time of calling Info().
 func (p *Peer) Info() *Info {
 	info := &Info{
-		Peer:                           p,
-		Address:                        p.ID,
-		Port:                           p.InitAddress.Port,
-		Domain:                         p.InitAddress.Addr,
-		DomainWithPort:                 p.InitAddress.String(),
-		Alias:                          p.InitAddress.Alias,
-		PreferIPv6:                     p.InitAddress.PreferIPv6,
-		NumberOfAllTransactions:        p.Metrics.ReceivedTransactions.Load(),
-		NumberOfNewTransactions:        p.Metrics.NewTransactions.Load(),
-		NumberOfKnownTransactions:      p.Metrics.KnownTransactions.Load(),
-		NumberOfStaleTransactions:      p.Metrics.StaleTransactions.Load(),
-		NumberOfReceivedTransactionReq: p.Metrics.ReceivedTransactionRequests.Load(),
-		NumberOfReceivedMilestoneReq:   p.Metrics.ReceivedMilestoneRequests.Load(),
-		NumberOfReceivedHeartbeats:     p.Metrics.ReceivedHeartbeats.Load(),
-		NumberOfSentPackets:            p.Metrics.SentPackets.Load(),
-		NumberOfSentTransactions:       p.Metrics.SentTransactions.Load(),
-		NumberOfSentTransactionsReq:    p.Metrics.SentTransactionRequests.Load(),
-		NumberOfSentMilestoneReq:       p.Metrics.SentMilestoneRequests.Load(),
-		NumberOfSentHeartbeats:         p.Metrics.SentHeartbeats.Load(),
-		NumberOfDroppedSentPackets:     p.Metrics.DroppedPackets.Load(),
-		ConnectionType:                 "tcp",
-		Connected:                      false,
-		Autopeered:                     false,
-		AutopeeringID:                  "",
+		Peer:                                    p,
+		Address:                                 p.ID,
+		Port:                                    p.InitAddress.Port,
+		Domain:                                  p.InitAddress.Addr,
+		DomainWithPort:                          p.InitAddress.String(),
+		Alias:                                   p.InitAddress.Alias,
+		PreferIPv6:                              p.InitAddress.PreferIPv6,
+		NumberOfAllTransactions:                 p.Metrics.ReceivedTransactions.Load(),
+		NumberOfNewTransactions:                 p.Metrics.NewTransactions.Load(),
+		NumberOfKnownTransactions:               p.Metrics.KnownTransactions.Load(),
+		NumberOfStaleTransactions:               p.Metrics.StaleTransactions.Load(),
+		NumberOfReceivedTransactionReq:          p.Metrics.ReceivedTransactionRequests.Load(),
+		NumberOfReceivedMilestoneReq:            p.Metrics.ReceivedMilestoneRequests.Load(),
+		NumberOfReceivedHeartbeats:              p.Metrics.ReceivedHeartbeats.Load(),
+		NumberOfSentPackets:                     p.Metrics.SentPackets.Load(),
+		NumberOfSentTransactions:                p.Metrics.SentTransactions.Load(),
+		NumberOfSentTransactionsReq:             p.Metrics.SentTransactionRequests.Load(),
+		NumberOfSentMilestoneReq:                p.Metrics.SentMilestoneRequests.Load(),
+		NumberOfSentHeartbeats:                  p.Metrics.SentHeartbeats.Load(),
+		NumberOfDroppedSentPackets:              p.Metrics.DroppedPackets.Load(),
+		NumberOfRateLimitedReceivedTransactions: p.Metrics.RateLimitedReceivedTransactions.Load(),
+		NumberOfRateLimitedSentTransactions:     p.Metrics.RateLimitedSentTransactions.Load(),
+		NewTransactionsRatio:                    p.NewTransactionsRatio(),
+		StaleChecks:                             p.StaleChecks(),
+		ConnectionType:                          "tcp",
+		Connected:                               false,
+		Autopeered:                              false,
+		AutopeeringID:                           "",
 	}
 	if p.Autopeering != nil {
 		info.Autopeered = true
 		info.AutopeeringID = p.Autopeering.ID().String()
 	}
+	if p.PublicKey != nil {
+		info.ConnectionType = "tls"
+	}
+	if p.Transport == TransportQUIC {
+		info.ConnectionType = TransportQUIC
+	}
+	if p.Conn != nil {
+		info.NumberOfReceivedBytes = p.Conn.BytesRead()
+		info.NumberOfSentBytes = p.Conn.BytesWritten()
+		if rtt, ok := connRTT(p.Conn.Conn); ok {
+			info.LatencyMilliseconds = float64(rtt.Microseconds()) / 1000
+		}
+	}
+	if p.Limiter != nil {
+		info.Limits = &p.Limiter.Opts
+	}
+	if heartbeatAge, received := p.LatestHeartbeatAge(); received {
+		info.LatestHeartbeatAgeSeconds = heartbeatAge.Seconds()
+	}
 	return info
 }
 
@@ -275,32 +358,49 @@ type Metrics struct {
 	SentHeartbeats atomic.Uint32
 	// The number of dropped packets.
 	DroppedPackets atomic.Uint32
+	// The number of received transactions which were dropped because of the inbound rate limit.
+	RateLimitedReceivedTransactions atomic.Uint32
+	// The number of transactions which were not sent because of the outbound rate limit.
+	RateLimitedSentTransactions atomic.Uint32
 }
 
 // Info acts as a static snapshot of information about a peer.
 type Info struct {
-	Peer                           *Peer  `json:"-"`
-	Address                        string `json:"address"`
-	Port                           uint16 `json:"port,omitempty"`
-	Domain                         string `json:"domain,omitempty"`
-	DomainWithPort                 string `json:"-"`
-	Alias                          string `json:"alias,omitempty"`
-	PreferIPv6                     bool   `json:"-"`
-	NumberOfAllTransactions        uint32 `json:"numberOfAllTransactions"`
-	NumberOfNewTransactions        uint32 `json:"numberOfNewTransactions"`
-	NumberOfKnownTransactions      uint32 `json:"numberOfKnownTransactions"`
-	NumberOfStaleTransactions      uint32 `json:"numberOfStaleTransactions"`
-	NumberOfReceivedTransactionReq uint32 `json:"numberOfReceivedTransactionReq"`
-	NumberOfReceivedMilestoneReq   uint32 `json:"numberOfReceivedMilestoneReq"`
-	NumberOfReceivedHeartbeats     uint32 `json:"numberOfReceivedHeartbeats"`
-	NumberOfSentPackets            uint32 `json:"numberOfSentPackets"`
-	NumberOfSentTransactions       uint32 `json:"numberOfSentTransactions"`
-	NumberOfSentTransactionsReq    uint32 `json:"numberOfSentTransactionsReq"`
-	NumberOfSentMilestoneReq       uint32 `json:"numberOfSentMilestoneReq"`
-	NumberOfSentHeartbeats         uint32 `json:"numberOfSentHeartbeats"`
-	NumberOfDroppedSentPackets     uint32 `json:"numberOfDroppedSentPackets"`
-	ConnectionType                 string `json:"connectionType"`
-	Connected                      bool   `json:"connected"`
-	Autopeered                     bool   `json:"autopeered"`
-	AutopeeringID                  string `json:"autopeeringId,omitempty"`
+	Peer                                    *Peer         `json:"-"`
+	Address                                 string        `json:"address"`
+	Port                                    uint16        `json:"port,omitempty"`
+	Domain                                  string        `json:"domain,omitempty"`
+	DomainWithPort                          string        `json:"-"`
+	Alias                                   string        `json:"alias,omitempty"`
+	PreferIPv6                              bool          `json:"-"`
+	NumberOfAllTransactions                 uint32        `json:"numberOfAllTransactions"`
+	NumberOfNewTransactions                 uint32        `json:"numberOfNewTransactions"`
+	NumberOfKnownTransactions               uint32        `json:"numberOfKnownTransactions"`
+	NumberOfStaleTransactions               uint32        `json:"numberOfStaleTransactions"`
+	NumberOfReceivedTransactionReq          uint32        `json:"numberOfReceivedTransactionReq"`
+	NumberOfReceivedMilestoneReq            uint32        `json:"numberOfReceivedMilestoneReq"`
+	NumberOfReceivedHeartbeats              uint32        `json:"numberOfReceivedHeartbeats"`
+	NumberOfSentPackets                     uint32        `json:"numberOfSentPackets"`
+	NumberOfSentTransactions                uint32        `json:"numberOfSentTransactions"`
+	NumberOfSentTransactionsReq             uint32        `json:"numberOfSentTransactionsReq"`
+	NumberOfSentMilestoneReq                uint32        `json:"numberOfSentMilestoneReq"`
+	NumberOfSentHeartbeats                  uint32        `json:"numberOfSentHeartbeats"`
+	NumberOfDroppedSentPackets              uint32        `json:"numberOfDroppedSentPackets"`
+	NumberOfRateLimitedReceivedTransactions uint32        `json:"numberOfRateLimitedReceivedTransactions"`
+	NumberOfRateLimitedSentTransactions     uint32        `json:"numberOfRateLimitedSentTransactions"`
+	NumberOfReceivedBytes                   uint64        `json:"numberOfReceivedBytes"`
+	NumberOfSentBytes                       uint64        `json:"numberOfSentBytes"`
+	Limits                                  *LimitOptions `json:"limits,omitempty"`
+	ConnectionType                          string        `json:"connectionType"`
+	Connected                               bool          `json:"connected"`
+	Autopeered                              bool          `json:"autopeered"`
+	LatencyMilliseconds                     float64       `json:"latencyMilliseconds,omitempty"`
+	LatestHeartbeatAgeSeconds               float64       `json:"latestHeartbeatAgeSeconds,omitempty"`
+	NewTransactionsRatio                    float64       `json:"newTransactionsRatio"`
+	StaleChecks                             int           `json:"staleChecks"`
+	PersistentlyStale                       bool          `json:"persistentlyStale"`
+	AutopeeringID                           string        `json:"autopeeringId,omitempty"`
+	PublicKey                               string        `json:"publicKey,omitempty"`
+	ReputationScore                         int           `json:"reputationScore"`
+	Banned                                  bool          `json:"banned"`
 }
@@ -0,0 +1,32 @@
+package peer
+
+import (
+	"net"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// connRTT returns the smoothed round trip time of the TCP connection, as measured by the kernel.
+func connRTT(conn net.Conn) (time.Duration, bool) {
+	sysConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return 0, false
+	}
+
+	rawConn, err := sysConn.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+
+	var tcpInfo *unix.TCPInfo
+	var sockErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		tcpInfo, sockErr = unix.GetsockoptTCPInfo(int(fd), unix.IPPROTO_TCP, unix.TCP_INFO)
+	}); err != nil || sockErr != nil {
+		return 0, false
+	}
+
+	return time.Duration(tcpInfo.Rtt) * time.Microsecond, true
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package peer
+
+import (
+	"net"
+	"time"
+)
+
+// connRTT is only supported on Linux.
+func connRTT(_ net.Conn) (time.Duration, bool) {
+	return 0, false
+}
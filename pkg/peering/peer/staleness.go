@@ -0,0 +1,118 @@
+package peer
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/gohornet/hornet/pkg/protocol/sting"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+const (
+	// StalenessCheckInterval is the interval in which the neighbors are checked whether they are stale.
+	StalenessCheckInterval = 60 * time.Second
+)
+
+// StalenessOptions defines the thresholds at which a peer is considered stale.
+// A threshold of zero disables the corresponding check.
+type StalenessOptions struct {
+	// The maximum age of the latest heartbeat of the peer.
+	MaxHeartbeatAge time.Duration
+	// The maximum percentage of stale transactions in the received transactions since the last check.
+	MaxStaleTransactionsPercentage int
+	// The amount of consecutive checks a peer has to be stale before it is considered persistently stale.
+	WarnAfterChecks int
+}
+
+// staleness holds the state of the staleness checks of a peer.
+type staleness struct {
+	// the time the latest heartbeat was received, in unix nanoseconds
+	latestHeartbeatTime atomic.Int64
+	// the time of the first check, used as the heartbeat age if no heartbeat was received yet
+	firstCheck time.Time
+	// the amounts of received and stale transactions at the last check
+	lastReceivedTransactions uint32
+	lastStaleTransactions    uint32
+	// the amount of consecutive checks the peer was stale
+	checks atomic.Uint32
+}
+
+// SetLatestHeartbeat stores the given heartbeat as the latest heartbeat of the peer.
+func (p *Peer) SetLatestHeartbeat(heartbeat *sting.Heartbeat) {
+	p.LatestHeartbeat = heartbeat
+	p.staleness.latestHeartbeatTime.Store(time.Now().UnixNano())
+}
+
+// LatestHeartbeatAge returns the time since the latest heartbeat was received.
+// Returns false if no heartbeat was received yet.
+func (p *Peer) LatestHeartbeatAge() (time.Duration, bool) {
+	latestHeartbeatTime := p.staleness.latestHeartbeatTime.Load()
+	if latestHeartbeatTime == 0 {
+		return 0, false
+	}
+	return time.Since(time.Unix(0, latestHeartbeatTime)), true
+}
+
+// NewTransactionsRatio returns the ratio of new transactions to all new and known transactions received from the peer.
+// Returns 0 if no transactions were received yet.
+func (p *Peer) NewTransactionsRatio() float64 {
+	newTxs := float64(p.Metrics.NewTransactions.Load())
+	knownTxs := float64(p.Metrics.KnownTransactions.Load())
+	if newTxs+knownTxs == 0 {
+		return 0
+	}
+	return newTxs / (newTxs + knownTxs)
+}
+
+// StaleChecks returns the amount of consecutive staleness checks the peer was stale.
+func (p *Peer) StaleChecks() int {
+	return int(p.staleness.checks.Load())
+}
+
+// IsPersistentlyStale tells whether the peer was stale in at least the given amount of consecutive checks.
+func (p *Peer) IsPersistentlyStale(opts *StalenessOptions) bool {
+	return opts.WarnAfterChecks > 0 && p.StaleChecks() >= opts.WarnAfterChecks
+}
+
+// CheckStaleness checks whether the peer was stale since the last check and returns the reason if so.
+// It is meant to be called every StalenessCheckInterval and must not be called concurrently.
+func (p *Peer) CheckStaleness(opts *StalenessOptions) (bool, string) {
+	if p.staleness.firstCheck.IsZero() {
+		p.staleness.firstCheck = time.Now()
+	}
+
+	receivedTxs := utils.GetUint32Diff(p.Metrics.ReceivedTransactions.Load(), p.staleness.lastReceivedTransactions)
+	staleTxs := utils.GetUint32Diff(p.Metrics.StaleTransactions.Load(), p.staleness.lastStaleTransactions)
+
+	// store for next check
+	p.staleness.lastReceivedTransactions = p.Metrics.ReceivedTransactions.Load()
+	p.staleness.lastStaleTransactions = p.Metrics.StaleTransactions.Load()
+
+	var reason string
+	heartbeatAge, received := p.LatestHeartbeatAge()
+	if !received {
+		heartbeatAge = time.Since(p.staleness.firstCheck)
+	}
+
+	switch {
+	case opts.MaxHeartbeatAge > 0 && heartbeatAge > opts.MaxHeartbeatAge:
+		if received {
+			reason = fmt.Sprintf("the latest heartbeat was received %v ago", heartbeatAge.Truncate(time.Second))
+		} else {
+			reason = fmt.Sprintf("no heartbeat was received within %v", heartbeatAge.Truncate(time.Second))
+		}
+
+	case opts.MaxStaleTransactionsPercentage > 0 && receivedTxs > 0 && float32(staleTxs)/float32(receivedTxs)*100.0 >= float32(opts.MaxStaleTransactionsPercentage):
+		reason = fmt.Sprintf("%0.2f%% of the received transactions since the last check were stale", float32(staleTxs)/float32(receivedTxs)*100.0)
+	}
+
+	if reason == "" {
+		p.staleness.checks.Store(0)
+		return false, ""
+	}
+
+	p.staleness.checks.Inc()
+	return true, reason
+}
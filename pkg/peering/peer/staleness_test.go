@@ -0,0 +1,84 @@
+package peer_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/iputils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/protocol/sting"
+)
+
+func newTestPeer() *peer.Peer {
+	ip := net.ParseIP("192.0.2.1")
+	addresses := iputils.NewIPAddresses()
+	addresses.Add(ip)
+	return peer.NewOutboundPeer(&iputils.OriginAddress{Addr: ip.String(), Port: 15600}, ip, 15600, addresses, peer.TransportTCP)
+}
+
+func TestStalenessHeartbeatAge(t *testing.T) {
+	opts := &peer.StalenessOptions{MaxHeartbeatAge: 20 * time.Millisecond, WarnAfterChecks: 2}
+	p := newTestPeer()
+
+	_, received := p.LatestHeartbeatAge()
+	assert.False(t, received)
+
+	// the age is measured from the first check if no heartbeat was received yet
+	stale, _ := p.CheckStaleness(opts)
+	assert.False(t, stale)
+
+	time.Sleep(30 * time.Millisecond)
+	stale, reason := p.CheckStaleness(opts)
+	assert.True(t, stale)
+	assert.Contains(t, reason, "no heartbeat")
+	assert.False(t, p.IsPersistentlyStale(opts))
+
+	stale, _ = p.CheckStaleness(opts)
+	assert.True(t, stale)
+	assert.Equal(t, 2, p.StaleChecks())
+	assert.True(t, p.IsPersistentlyStale(opts))
+
+	p.SetLatestHeartbeat(&sting.Heartbeat{})
+	age, received := p.LatestHeartbeatAge()
+	assert.True(t, received)
+	assert.True(t, age < opts.MaxHeartbeatAge)
+
+	// a fresh heartbeat resets the consecutive checks
+	stale, _ = p.CheckStaleness(opts)
+	assert.False(t, stale)
+	assert.Equal(t, 0, p.StaleChecks())
+	assert.False(t, p.IsPersistentlyStale(opts))
+}
+
+func TestStalenessStaleTransactions(t *testing.T) {
+	opts := &peer.StalenessOptions{MaxStaleTransactionsPercentage: 50, WarnAfterChecks: 1}
+	p := newTestPeer()
+
+	p.Metrics.ReceivedTransactions.Add(10)
+	p.Metrics.StaleTransactions.Add(6)
+	stale, reason := p.CheckStaleness(opts)
+	assert.True(t, stale)
+	assert.Contains(t, reason, "60.00%")
+
+	// only the transactions since the last check are considered
+	p.Metrics.ReceivedTransactions.Add(10)
+	p.Metrics.StaleTransactions.Add(1)
+	stale, _ = p.CheckStaleness(opts)
+	assert.False(t, stale)
+
+	// no received transactions are not stale
+	stale, _ = p.CheckStaleness(opts)
+	assert.False(t, stale)
+}
+
+func TestNewTransactionsRatio(t *testing.T) {
+	p := newTestPeer()
+	assert.Equal(t, 0.0, p.NewTransactionsRatio())
+
+	p.Metrics.NewTransactions.Add(1)
+	p.Metrics.KnownTransactions.Add(3)
+	assert.Equal(t, 0.25, p.NewTransactionsRatio())
+}
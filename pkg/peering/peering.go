@@ -1,6 +1,8 @@
 package peering
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -15,19 +17,26 @@ import (
 	"github.com/iotaledger/hive.go/network"
 	"github.com/iotaledger/hive.go/network/tcp"
 	"github.com/labstack/gommon/log"
+	"github.com/quic-go/quic-go"
 	"go.uber.org/atomic"
 
+	"github.com/gohornet/hornet/pkg/bandwidth"
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ipfilter"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
 	"github.com/gohornet/hornet/pkg/protocol"
 	"github.com/gohornet/hornet/pkg/protocol/handshake"
+	"github.com/gohornet/hornet/pkg/protocol/message"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
+	"github.com/gohornet/hornet/pkg/protocol/tlv"
 )
 
 const (
 	isNeighborSyncedThreshold        = 2
 	updateNeighborsCountCooldownTime = time.Duration(2 * time.Second)
+	flushSendQueuesCheckInterval     = 10 * time.Millisecond
 )
 
 var (
@@ -47,6 +56,8 @@ var (
 	ErrPeerAlreadyInReconnect = errors.New("peer is already in the reconnect pool")
 	// ErrManagerIsShutdown is returned when the manager is shutdown.
 	ErrManagerIsShutdown = errors.New("peering manager is shutdown")
+	// ErrPeerNotSecured is returned when a peer with a pinned identity key connected without authenticating.
+	ErrPeerNotSecured = errors.New("peer didn't authenticate with its pinned identity key")
 )
 
 // NewManager creates a new manager instance with the given Options and moves the given peers
@@ -68,13 +79,21 @@ func NewManager(opts Options, peers ...*config.PeerConfig) *Manager {
 			AutopeeredPeerBecameStatic:            events.NewEvent(peer.IdentityCaller),
 			IPLookupError:                         events.NewEvent(events.ErrorCaller),
 			Shutdown:                              events.NewEvent(events.CallbackCaller),
+			PeerPenalized:                         events.NewEvent(PenalizedCaller),
+			PeerBanned:                            events.NewEvent(peer.Caller),
+			PeerStale:                             events.NewEvent(StaleCaller),
+			PeerNoLongerStale:                     events.NewEvent(peer.Caller),
 			Error:                                 events.NewEvent(events.ErrorCaller),
 		},
 		tcpServer: tcp.NewServer(),
 		connected: map[string]*peer.Peer{},
 		reconnect: map[string]*reconnectinfo{},
 		whitelist: map[string]*autopeering.Peer{},
+		pinned:    map[string]ed25519.PublicKey{},
 		blacklist: map[string]struct{}{},
+		scores:    map[string]int{},
+		banned:    map[string]time.Time{},
+		bandwidth: bandwidth.NewBudget(opts.Bandwidth),
 		Opts:      opts,
 	}
 	m.moveInitialPeersToReconnectPool(peers)
@@ -95,16 +114,30 @@ type Manager struct {
 
 	// the TCP server instance used to handle incoming connections.
 	tcpServer *tcp.TCPServer
+	// the listener of incoming QUIC connections, nil if QUIC is disabled.
+	quicListener *quic.Listener
+	// the identity which secures the QUIC connections if there is no configured identity.
+	randomIdentity *secure.Identity
+	quicIdentityMu sync.Mutex
 	// holds currently connected peers.
 	connected map[string]*peer.Peer
 	// holds peers to which we want to connect to.
 	reconnect map[string]*reconnectinfo
 	// defines the set of allowed peer identities.
-	whitelist   map[string]*autopeering.Peer
+	whitelist map[string]*autopeering.Peer
+	// holds the pinned identity keys of the whitelisted peer identities.
+	pinned      map[string]ed25519.PublicKey
 	whitelistMu sync.Mutex
 	// defines a set of blacklisted IP addresses.
 	blacklist   map[string]struct{}
 	blacklistMu sync.Mutex
+	// holds the reputation scores of the peers by IP address.
+	scores map[string]int
+	// holds the banned IP addresses and until when they are banned.
+	banned       map[string]time.Time
+	reputationMu sync.Mutex
+	// accounts the gossip traffic against the monthly bandwidth budget.
+	bandwidth *bandwidth.Budget
 	// used to enforce one handshake verification at a time.
 	handshakeVerifyMu sync.Mutex
 
@@ -119,6 +152,8 @@ type reconnectinfo struct {
 	OriginAddr  *iputils.OriginAddress `json:"origin_addr"`
 	CachedIPs   *iputils.IPAddresses   `json:"cached_ips"`
 	Autopeering *autopeering.Peer      `json:"peer"`
+	PublicKey   ed25519.PublicKey      `json:"public_key"`
+	Transport   string                 `json:"transport"`
 }
 
 // Options defines options for the Manager.
@@ -130,6 +165,20 @@ type Options struct {
 	AcceptAnyPeer bool
 	// Inbound connection bind address.
 	BindAddress string
+	// The reputation options.
+	Reputation ReputationOptions
+	// The limits of the gossip with each peer.
+	Limits peer.LimitOptions
+	// The thresholds at which peers are considered stale.
+	Staleness peer.StalenessOptions
+	// The monthly bandwidth budget of the gossip.
+	Bandwidth bandwidth.Options
+	// The filter of the addresses of inbound connections, all addresses are allowed if nil.
+	IPFilter *ipfilter.Filter
+	// The identity used to secure the connections to peers with a pinned identity key, connections are not secured if nil.
+	Identity *secure.Identity
+	// Whether to accept the gossip via QUIC on the UDP port of the bind address.
+	QUIC bool
 }
 
 // Events defines events fired regarding peering.
@@ -162,6 +211,14 @@ type Events struct {
 	ReconnectRemovedAlreadyConnected *events.Event
 	// Fired when the manager has been successfully shutdown.
 	Shutdown *events.Event
+	// Fired when a peer was penalized for a misbehavior.
+	PeerPenalized *events.Event
+	// Fired when a peer was banned because of a bad reputation.
+	PeerBanned *events.Event
+	// Fired when a peer was stale in WarnAfterChecks consecutive staleness checks.
+	PeerStale *events.Event
+	// Fired when a persistently stale peer is no longer stale.
+	PeerNoLongerStale *events.Event
 	// Fired when internal errors occur.
 	Error *events.Event
 }
@@ -255,9 +312,32 @@ func (m *Manager) Whitelist(ips []string, port uint16, autopeeringPeer ...*autop
 func (m *Manager) WhitelistRemove(id string) {
 	m.whitelistMu.Lock()
 	delete(m.whitelist, id)
+	delete(m.pinned, id)
 	m.whitelistMu.Unlock()
 }
 
+// PinnedKey returns the identity key the peer with the given ID has to authenticate with, nil if none is pinned.
+func (m *Manager) PinnedKey(id string) ed25519.PublicKey {
+	m.whitelistMu.Lock()
+	defer m.whitelistMu.Unlock()
+	return m.pinned[id]
+}
+
+// Pin pins the given identity key for all possible IDs for the given IP addresses/port combination.
+// A nil key removes any pinned key.
+func (m *Manager) Pin(ips []string, port uint16, publicKey ed25519.PublicKey) {
+	m.whitelistMu.Lock()
+	defer m.whitelistMu.Unlock()
+	for _, ip := range ips {
+		id := peer.NewID(ip, port)
+		if publicKey == nil {
+			delete(m.pinned, id)
+			continue
+		}
+		m.pinned[id] = publicKey
+	}
+}
+
 // PeerConsumerFunc is a function which consumes a peer.
 // If it returns false, it signals that no further calls should be made to the function.
 type PeerConsumerFunc func(p *peer.Peer) bool
@@ -325,6 +405,13 @@ func (m *Manager) PeerInfos() []*peer.Info {
 	for _, p := range m.connected {
 		info := p.Info()
 		info.Connected = true
+		if pinnedKey := m.PinnedKey(p.ID); pinnedKey != nil {
+			info.PublicKey = hex.EncodeToString(pinnedKey)
+		}
+		if p.PrimaryAddress != nil {
+			info.ReputationScore = m.Score(p.PrimaryAddress.String())
+		}
+		info.PersistentlyStale = p.IsPersistentlyStale(&m.Opts.Staleness)
 		infos = append(infos, info)
 	}
 	for _, reconnectInfo := range m.reconnect {
@@ -335,7 +422,7 @@ func (m *Manager) PeerInfos() []*peer.Info {
 			Domain:         originAddr.Addr,
 			DomainWithPort: addrStr,
 			Alias:          originAddr.Alias,
-			ConnectionType: "tcp",
+			ConnectionType: reconnectInfo.Transport,
 			Connected:      false,
 			Autopeered:     false,
 			PreferIPv6:     originAddr.PreferIPv6,
@@ -344,6 +431,19 @@ func (m *Manager) PeerInfos() []*peer.Info {
 			info.Autopeered = true
 			info.AutopeeringID = reconnectInfo.Autopeering.ID().String()
 		}
+		if reconnectInfo.PublicKey != nil {
+			info.PublicKey = hex.EncodeToString(reconnectInfo.PublicKey)
+		}
+		if reconnectInfo.CachedIPs != nil {
+			for ip := range reconnectInfo.CachedIPs.IPs {
+				if m.Banned(ip.String()) {
+					info.Banned = true
+				}
+				if score := m.Score(ip.String()); score < info.ReputationScore {
+					info.ReputationScore = score
+				}
+			}
+		}
 		infos = append(infos, info)
 	}
 	return infos
@@ -419,7 +519,20 @@ func (m *Manager) SlotsFilled() bool {
 // SetupEventHandlers inits the event handlers for handshaking, the underlying connection and errors.
 func (m *Manager) SetupEventHandlers(p *peer.Peer) {
 
-	onProtocolReceive := events.NewClosure(p.Protocol.Receive)
+	p.Limiter = peer.NewLimiter(m.Opts.Limits)
+
+	// closed as soon as the connection is closed to abort waiting for the inbound bandwidth limit
+	connectionClosed := make(chan struct{})
+
+	onProtocolReceive := events.NewClosure(func(data []byte) {
+		m.accountReceived(len(data))
+
+		// block reading from the connection if the peer exceeds its inbound bandwidth
+		if !p.Limiter.WaitInbound(len(data), connectionClosed) {
+			return
+		}
+		p.Protocol.Receive(data)
+	})
 
 	onConnectionError := events.NewClosure(func(err error) {
 		if p.Disconnected {
@@ -436,9 +549,16 @@ func (m *Manager) SetupEventHandlers(p *peer.Peer) {
 		if closeErr := p.Conn.Close(); closeErr != nil {
 			m.Events.Error.Trigger(closeErr)
 		}
+
+		// malformed messages lower the reputation of the peer
+		if m.Opts.Reputation.Enabled && (errors.Is(err, tlv.ErrInvalidMessageLength) || errors.Is(err, message.ErrUnknownType)) {
+			m.Penalize(p, MisbehaviorProtocolViolation)
+		}
 	})
 
 	onConnectionClose := events.NewClosure(func() {
+		close(connectionClosed)
+
 		m.Lock()
 		m.moveFromConnectedToReconnectPool(p)
 		m.Unlock()
@@ -465,13 +585,20 @@ func (m *Manager) SetupEventHandlers(p *peer.Peer) {
 
 // Add adds a new peer to the reconnect pool and immediately invokes a connection attempt.
 // The peer is not added if it is already connected or the given address is invalid.
-func (m *Manager) Add(addr string, preferIPv6 bool, alias string, autoPeer ...*autopeering.Peer) error {
+// If a public key is given, the peer has to authenticate with it if the connections are secured.
+// The transport of the gossip with the peer is "tcp" or "quic", an empty transport is TCP.
+func (m *Manager) Add(addr string, preferIPv6 bool, alias string, publicKey ed25519.PublicKey, transport string, autoPeer ...*autopeering.Peer) error {
 
 	originAddr, err := iputils.ParseOriginAddress(addr)
 	if err != nil {
 		return fmt.Errorf("invalid peer address '%s': %w", addr, err)
 	}
 
+	transport, err = peer.ParseTransport(transport)
+	if err != nil {
+		return fmt.Errorf("invalid transport of peer '%s': %w", addr, err)
+	}
+
 	originAddr.PreferIPv6 = preferIPv6
 	originAddr.Alias = alias
 
@@ -534,7 +661,7 @@ func (m *Manager) Add(addr string, preferIPv6 bool, alias string, autoPeer ...*a
 	}
 
 	// construct reconnect info
-	reconnectInfo := &reconnectinfo{OriginAddr: originAddr, CachedIPs: possibleIPs}
+	reconnectInfo := &reconnectinfo{OriginAddr: originAddr, CachedIPs: possibleIPs, PublicKey: publicKey, Transport: transport}
 	if isAutopeer {
 		reconnectInfo.Autopeering = autoPeer[0]
 	}
@@ -623,8 +750,7 @@ func (m *Manager) Listen() error {
 	}
 
 	m.tcpServer.Events.Connect.Attach(events.NewClosure(func(conn *network.ManagedConnection) {
-		tcpConn := conn.RemoteAddr().(*net.TCPAddr)
-		if m.Blacklisted(tcpConn.IP.String()) {
+		if !m.inboundAllowed(conn.RemoteAddr().(*net.TCPAddr).IP) {
 			if err := conn.Close(); err != nil {
 				log.Error(err)
 			}
@@ -633,24 +759,70 @@ func (m *Manager) Listen() error {
 
 		m.Events.PeerHandshakingIncoming.Trigger(conn.RemoteAddr().String())
 
-		// init peer
-		p := peer.NewInboundPeer(conn.Conn.RemoteAddr())
-		p.Conn = conn
-		p.Protocol = protocol.New(conn)
-		m.SetupEventHandlers(p)
+		conn, publicKey, err := m.secureInbound(conn)
+		if err != nil {
+			m.Events.Error.Trigger(err)
+			return
+		}
 
-		// kick off protocol
-		go p.Protocol.Start()
+		m.setupInboundPeer(conn, publicKey, peer.TransportTCP)
 	}))
 
 	m.tcpServer.Events.Error.Attach(events.NewClosure(func(err error) {
 		m.Events.Error.Trigger(err)
 	}))
 
+	if m.Opts.QUIC {
+		if err := m.listenQUIC(); err != nil {
+			return err
+		}
+	}
+
 	m.tcpServer.Listen(addr, port)
 	return nil
 }
 
+// inboundAllowed tells whether inbound connections from the given IP address are accepted.
+func (m *Manager) inboundAllowed(ip net.IP) bool {
+	if m.Blacklisted(ip.String()) || m.Banned(ip.String()) {
+		return false
+	}
+	return m.Opts.IPFilter == nil || m.Opts.IPFilter.Allowed(ip)
+}
+
+// setupInboundPeer initializes the peer of the given inbound connection and kicks off the protocol.
+func (m *Manager) setupInboundPeer(conn *network.ManagedConnection, publicKey ed25519.PublicKey, transport string) {
+	p := peer.NewInboundPeer(conn.Conn.RemoteAddr(), transport)
+	p.PublicKey = publicKey
+	p.Conn = conn
+	p.Protocol = protocol.New(conn)
+	m.SetupEventHandlers(p)
+
+	go p.Protocol.Start()
+}
+
+// FlushSendQueues waits until the send queues of all connected peers are empty or the timeout is reached.
+// It returns whether all send queues were flushed.
+func (m *Manager) FlushSendQueues(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		queued := 0
+		m.ForAllConnected(func(p *peer.Peer) bool {
+			queued += len(p.SendQueue)
+			return true
+		})
+
+		if queued == 0 {
+			return true
+		}
+
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(flushSendQueuesCheckInterval)
+	}
+}
+
 // Shutdown shuts down the peering server and disconnect all connected peers.
 func (m *Manager) Shutdown() {
 	m.Lock()
@@ -659,6 +831,9 @@ func (m *Manager) Shutdown() {
 
 	// stop listening for incoming connections
 	m.tcpServer.Shutdown()
+	if m.quicListener != nil {
+		_ = m.quicListener.Close()
+	}
 
 	// clear reconnect entries
 	for k := range m.reconnect {
@@ -699,15 +874,21 @@ func (m *Manager) moveFromConnectedToReconnectPool(p *peer.Peer) {
 	}
 	delete(m.connected, p.ID)
 
-	// prevent non handshaked, manually removed or autopeering peers to be put back into the reconnect pool
-	if !p.MoveBackToReconnectPool || p.Autopeering != nil {
+	// autopeered peers are never put back into the reconnect pool, they are removed completely
+	if p.Autopeering != nil {
+		m.Events.PeerDisconnected.Trigger(p)
+		return
+	}
+
+	// prevent non handshaked or manually removed peers to be put back into the reconnect pool
+	if !p.MoveBackToReconnectPool {
 		return
 	}
 
 	// remove any other excess reconnect entry
 	m.removeFromReconnectPool(p)
 
-	m.reconnect[p.InitAddress.String()] = &reconnectinfo{OriginAddr: p.InitAddress, CachedIPs: p.Addresses}
+	m.reconnect[p.InitAddress.String()] = &reconnectinfo{OriginAddr: p.InitAddress, CachedIPs: p.Addresses, PublicKey: m.PinnedKey(p.ID), Transport: p.Transport}
 	m.Events.PeerMovedFromConnectedToReconnectPool.Trigger(p)
 }
 
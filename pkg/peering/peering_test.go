@@ -0,0 +1,58 @@
+package peering
+
+import (
+	"net"
+	"testing"
+
+	autopeering "github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/iputils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+func TestMoveFromConnectedToReconnectPool(t *testing.T) {
+	m := NewManager(Options{})
+
+	var disconnected []*peer.Peer
+	m.Events.PeerDisconnected.Attach(events.NewClosure(func(p *peer.Peer) {
+		disconnected = append(disconnected, p)
+	}))
+	var movedToReconnectPool []*peer.Peer
+	m.Events.PeerMovedFromConnectedToReconnectPool.Attach(events.NewClosure(func(p *peer.Peer) {
+		movedToReconnectPool = append(movedToReconnectPool, p)
+	}))
+
+	newPeer := func(ip string) *peer.Peer {
+		addresses := iputils.NewIPAddresses()
+		addresses.Add(net.ParseIP(ip))
+		p := peer.NewOutboundPeer(&iputils.OriginAddress{Addr: ip, Port: 15600}, net.ParseIP(ip), 15600, addresses, peer.TransportTCP)
+		p.MoveBackToReconnectPool = true
+		m.connected[p.ID] = p
+		return p
+	}
+
+	static := newPeer("192.0.2.1")
+	m.moveFromConnectedToReconnectPool(static)
+	assert.Equal(t, []*peer.Peer{static}, movedToReconnectPool)
+	assert.Empty(t, disconnected)
+	assert.Contains(t, m.reconnect, static.InitAddress.String())
+
+	// the connection of an autopeered peer was lost, it is removed completely
+	autopeered := newPeer("192.0.2.2")
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 14626)
+	autopeered.Autopeering = autopeering.NewPeer(identity.GenerateIdentity(), net.ParseIP("192.0.2.2"), services)
+	m.moveFromConnectedToReconnectPool(autopeered)
+	assert.Equal(t, []*peer.Peer{autopeered}, disconnected)
+	assert.Len(t, movedToReconnectPool, 1)
+	assert.NotContains(t, m.reconnect, autopeered.InitAddress.String())
+	assert.NotContains(t, m.connected, autopeered.ID)
+
+	// peers which were already removed don't fire any event
+	m.moveFromConnectedToReconnectPool(autopeered)
+	assert.Len(t, disconnected, 1)
+}
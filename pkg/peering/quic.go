@@ -0,0 +1,173 @@
+package peering
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/iotaledger/hive.go/network"
+	"github.com/quic-go/quic-go"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
+	"github.com/gohornet/hornet/pkg/proxy"
+)
+
+const (
+	// the ALPN protocol of the gossip via QUIC.
+	quicProtocol = "hornet-gossip"
+	// the time to open a QUIC connection to a peer, including the TLS handshake.
+	quicDialTimeout = 5 * time.Second
+	// the interval in which keep-alive packets are sent, so that NAT gateways don't drop idle connections.
+	quicKeepAlivePeriod = 15 * time.Second
+)
+
+var (
+	// ErrQUICViaProxy is returned when a peer should be connected via QUIC while the connections are sent through a proxy.
+	ErrQUICViaProxy = errors.New("QUIC connections can't be sent through the proxy")
+
+	quicConfig = &quic.Config{
+		HandshakeIdleTimeout: secureHandshakeTimeout,
+		KeepAlivePeriod:      quicKeepAlivePeriod,
+	}
+)
+
+// quicIdentity returns the identity which secures the QUIC connections.
+// QUIC connections are always encrypted, so a random identity is used if the node has no configured identity.
+func (m *Manager) quicIdentity() (*secure.Identity, error) {
+	if m.Opts.Identity != nil {
+		return m.Opts.Identity, nil
+	}
+
+	m.quicIdentityMu.Lock()
+	defer m.quicIdentityMu.Unlock()
+
+	if m.randomIdentity == nil {
+		identity, err := secure.NewRandomIdentity()
+		if err != nil {
+			return nil, err
+		}
+		m.randomIdentity = identity
+	}
+	return m.randomIdentity, nil
+}
+
+// listenQUIC accepts the gossip via QUIC on the UDP port of the bind address.
+func (m *Manager) listenQUIC() error {
+	identity, err := m.quicIdentity()
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := identity.ServerConfig()
+	tlsConfig.NextProtos = []string{quicProtocol}
+
+	listener, err := quic.ListenAddr(m.Opts.BindAddress, tlsConfig, quicConfig)
+	if err != nil {
+		return fmt.Errorf("can't listen for QUIC connections on %s: %w", m.Opts.BindAddress, err)
+	}
+	m.quicListener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept(context.Background())
+			if err != nil {
+				// the listener was closed
+				return
+			}
+			go m.acceptQUIC(conn)
+		}
+	}()
+	return nil
+}
+
+// acceptQUIC accepts the stream of the gossip of the given inbound QUIC connection.
+func (m *Manager) acceptQUIC(conn quic.Connection) {
+	if !m.inboundAllowed(conn.RemoteAddr().(*net.UDPAddr).IP) {
+		_ = conn.CloseWithError(0, "")
+		return
+	}
+
+	m.Events.PeerHandshakingIncoming.Trigger(conn.RemoteAddr().String())
+
+	// the peer opens the stream by sending its handshake
+	ctx, cancel := context.WithTimeout(context.Background(), secureHandshakeTimeout)
+	stream, err := conn.AcceptStream(ctx)
+	cancel()
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		m.Events.Error.Trigger(fmt.Errorf("can't accept the gossip stream of %s: %w", conn.RemoteAddr(), err))
+		return
+	}
+
+	m.setupInboundPeer(network.NewManagedConnection(newQUICConn(conn, stream)), quicPublicKey(conn), peer.TransportQUIC)
+}
+
+// dialQUIC opens a QUIC connection to the given address and the stream of the gossip.
+// The peer with the given ID has to authenticate with its pinned identity key if there is one.
+// returns the identity key the peer authenticated with.
+func (m *Manager) dialQUIC(addr string, id string) (*network.ManagedConnection, ed25519.PublicKey, error) {
+	if !proxy.Direct() {
+		return nil, nil, ErrQUICViaProxy
+	}
+
+	identity, err := m.quicIdentity()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := identity.ClientConfig(m.PinnedKey(id))
+	tlsConfig.NextProtos = []string{quicProtocol}
+
+	ctx, cancel := context.WithTimeout(context.Background(), quicDialTimeout)
+	defer cancel()
+
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, quicConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, nil, err
+	}
+
+	return network.NewManagedConnection(newQUICConn(conn, stream)), quicPublicKey(conn), nil
+}
+
+// quicPublicKey returns the identity key the remote side of the given QUIC connection authenticated with.
+func quicPublicKey(conn quic.Connection) ed25519.PublicKey {
+	certificates := conn.ConnectionState().TLS.PeerCertificates
+	if len(certificates) == 0 {
+		return nil
+	}
+	publicKey, _ := certificates[0].PublicKey.(ed25519.PublicKey)
+	return publicKey
+}
+
+// quicConn is the stream of the gossip with a peer over a QUIC connection.
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+func newQUICConn(conn quic.Connection, stream quic.Stream) *quicConn {
+	return &quicConn{Stream: stream, conn: conn}
+}
+
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close closes the whole QUIC connection, closing the stream would only end its sending direction.
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
@@ -0,0 +1,87 @@
+package peering
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/quic-go/quic-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
+	"github.com/gohornet/hornet/pkg/proxy"
+)
+
+// startQUICEchoServer starts a QUIC listener which echoes the data of the first stream of every connection.
+func startQUICEchoServer(t *testing.T, identity *secure.Identity) *quic.Listener {
+	tlsConfig := identity.ServerConfig()
+	tlsConfig.NextProtos = []string{quicProtocol}
+
+	listener, err := quic.ListenAddr("127.0.0.1:0", tlsConfig, quicConfig)
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			go func() {
+				stream, err := conn.AcceptStream(context.Background())
+				if err != nil {
+					return
+				}
+				_, _ = io.Copy(stream, stream)
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func TestDialQUIC(t *testing.T) {
+	serverIdentity, err := secure.NewRandomIdentity()
+	require.NoError(t, err)
+
+	listener := startQUICEchoServer(t, serverIdentity)
+	defer listener.Close()
+
+	addr := listener.Addr().(*net.UDPAddr)
+	id := peer.NewID("127.0.0.1", uint16(addr.Port))
+
+	// without a pinned key any identity is accepted, the connection is encrypted anyway
+	m := NewManager(Options{})
+	conn, publicKey, err := m.dialQUIC(addr.String(), id)
+	require.NoError(t, err)
+	assert.Equal(t, serverIdentity.PublicKey(), publicKey)
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	echo := make([]byte, 5)
+	_, err = io.ReadFull(conn.Conn, echo)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(echo))
+	assert.Equal(t, addr.String(), conn.RemoteAddr().String())
+	require.NoError(t, conn.Close())
+
+	// the peer has to authenticate with its pinned key
+	m.Pin([]string{"127.0.0.1"}, uint16(addr.Port), serverIdentity.PublicKey())
+	conn, _, err = m.dialQUIC(addr.String(), id)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	otherIdentity, err := secure.NewRandomIdentity()
+	require.NoError(t, err)
+	m.Pin([]string{"127.0.0.1"}, uint16(addr.Port), otherIdentity.PublicKey())
+	_, _, err = m.dialQUIC(addr.String(), id)
+	assert.Error(t, err)
+
+	// QUIC connections can't be sent through a proxy
+	require.NoError(t, proxy.Configure("socks5://127.0.0.1:1080"))
+	defer proxy.Configure("")
+	_, _, err = m.dialQUIC(addr.String(), id)
+	assert.Equal(t, ErrQUICViaProxy, err)
+}
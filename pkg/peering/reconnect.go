@@ -1,6 +1,7 @@
 package peering
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"net"
 	"time"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
 	"github.com/gohornet/hornet/pkg/protocol"
+	"github.com/gohornet/hornet/pkg/proxy"
 )
 
 // Reconnect instructs the manager to initiate connections to all peers residing in the reconnect pool.
@@ -41,6 +44,13 @@ next:
 		reconnectInfo.CachedIPs = peerAddrs
 		reconnectInfo.mu.Unlock()
 
+		// keep banned peers in the reconnect pool until their ban expired
+		for ip := range peerAddrs.IPs {
+			if m.Banned(ip.String()) {
+				continue next
+			}
+		}
+
 		prefIP := peerAddrs.GetPreferredAddress(originAddr.PreferIPv6)
 
 		// don't do any new connection attempts if the peer is already connected
@@ -57,9 +67,10 @@ next:
 
 		// whitelist all possible combinations for this peer ID
 		m.Whitelist(ips, reconnectInfo.OriginAddr.Port)
+		m.Pin(ips, reconnectInfo.OriginAddr.Port, reconnectInfo.PublicKey)
 
 		// create a new outbound peer and inject autopeering metadata if available
-		p := peer.NewOutboundPeer(originAddr, prefIP, originAddr.Port, peerAddrs)
+		p := peer.NewOutboundPeer(originAddr, prefIP, originAddr.Port, peerAddrs, reconnectInfo.Transport)
 		if reconnectInfo.Autopeering != nil {
 			p.Autopeering = reconnectInfo.Autopeering
 		}
@@ -107,20 +118,47 @@ func (m *Manager) moveInitialPeersToReconnectPool(peers []*config.PeerConfig) {
 		originAddr.PreferIPv6 = peerConf.PreferIPv6
 		originAddr.Alias = peerConf.Alias
 
+		var publicKey ed25519.PublicKey
+		if peerConf.PublicKey != "" {
+			if publicKey, err = secure.ParsePublicKey(peerConf.PublicKey); err != nil {
+				panic(errors.Wrapf(err, "invalid public key of peer %s", peerConf.ID))
+			}
+		}
+
+		transport, err := peer.ParseTransport(peerConf.Transport)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid transport of peer %s", peerConf.ID))
+		}
+
 		// no need to lock the manager in the configure stage
-		m.moveToReconnectPool(&reconnectinfo{OriginAddr: originAddr})
+		m.moveToReconnectPool(&reconnectinfo{OriginAddr: originAddr, PublicKey: publicKey, Transport: transport})
 	}
 }
 
 // creates and initiates the connection to the given peer.
 func (m *Manager) connect(p *peer.Peer) error {
 	addr := fmt.Sprintf("%s:%d", iputils.IPToString(p.PrimaryAddress), p.InitAddress.Port)
-	conn, err := net.DialTimeout("tcp", addr, time.Duration(2)*time.Second)
-	if err != nil {
-		return fmt.Errorf("can't connect to %s: %w", p.ID, err)
+
+	var managedConn *network.ManagedConnection
+	var publicKey ed25519.PublicKey
+	if p.Transport == peer.TransportQUIC {
+		var err error
+		if managedConn, publicKey, err = m.dialQUIC(addr, p.ID); err != nil {
+			return fmt.Errorf("can't connect to %s via QUIC: %w", p.ID, err)
+		}
+	} else {
+		conn, err := proxy.DialTimeout("tcp", addr, time.Duration(2)*time.Second)
+		if err != nil {
+			return fmt.Errorf("can't connect to %s: %w", p.ID, err)
+		}
+
+		if managedConn, publicKey, err = m.secureOutbound(conn, p.ID); err != nil {
+			return fmt.Errorf("can't secure the connection to %s: %w", p.ID, err)
+		}
 	}
 
-	p.Conn = network.NewManagedConnection(conn)
+	p.PublicKey = publicKey
+	p.Conn = managedConn
 	p.Protocol = protocol.New(p.Conn)
 	return nil
 }
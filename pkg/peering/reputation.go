@@ -0,0 +1,177 @@
+package peering
+
+import (
+	"time"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+// Misbehavior defines a kind of misbehavior which lowers the reputation of a peer.
+type Misbehavior byte
+
+const (
+	// MisbehaviorInvalidTransaction is a transaction with an invalid structure or proof of work.
+	MisbehaviorInvalidTransaction Misbehavior = iota
+	// MisbehaviorStaleMilestone is a heartbeat advertising a latest milestone far behind our solid milestone.
+	MisbehaviorStaleMilestone
+	// MisbehaviorProtocolViolation is a malformed message or any other violation of the protocol.
+	MisbehaviorProtocolViolation
+)
+
+// String returns the name of the misbehavior.
+func (mb Misbehavior) String() string {
+	switch mb {
+	case MisbehaviorInvalidTransaction:
+		return "invalid transaction"
+	case MisbehaviorStaleMilestone:
+		return "stale milestone"
+	case MisbehaviorProtocolViolation:
+		return "protocol violation"
+	default:
+		return "unknown"
+	}
+}
+
+// ReputationOptions defines the options for the reputation of peers.
+// Every peer starts with a score of zero which is lowered by each misbehavior.
+// Peers whose score drops to the BanThreshold are dropped and banned for the BanDuration.
+type ReputationOptions struct {
+	// Whether the reputation is enabled. If disabled, peers are dropped on the first invalid message.
+	Enabled bool
+	// The penalty for an invalid transaction.
+	InvalidTransactionPenalty int
+	// The penalty for a stale milestone.
+	StaleMilestonePenalty int
+	// The penalty for a protocol violation.
+	ProtocolViolationPenalty int
+	// The amount of milestones a peer's latest milestone may be behind our solid milestone before it is considered stale.
+	StaleMilestoneThreshold int
+	// The score at which a peer gets banned.
+	BanThreshold int
+	// The duration a peer gets banned for.
+	BanDuration time.Duration
+	// The score a peer regains every minute.
+	RecoveryPerMinute int
+}
+
+// penalty returns the penalty for the given misbehavior.
+func (opts *ReputationOptions) penalty(mb Misbehavior) int {
+	switch mb {
+	case MisbehaviorInvalidTransaction:
+		return opts.InvalidTransactionPenalty
+	case MisbehaviorStaleMilestone:
+		return opts.StaleMilestonePenalty
+	default:
+		return opts.ProtocolViolationPenalty
+	}
+}
+
+// PenalizedCaller is the caller of the PeerPenalized event.
+func PenalizedCaller(handler interface{}, params ...interface{}) {
+	handler.(func(p *peer.Peer, mb Misbehavior, score int))(params[0].(*peer.Peer), params[1].(Misbehavior), params[2].(int))
+}
+
+// Penalize lowers the reputation of the given peer because of the given misbehavior.
+// The peer is dropped and banned if its score reaches the ban threshold.
+func (m *Manager) Penalize(p *peer.Peer, mb Misbehavior) {
+	if !m.Opts.Reputation.Enabled {
+		// without the reputation, the connection is dropped on any invalid data right away
+		if mb != MisbehaviorStaleMilestone && p.ID != "" {
+			_ = m.Remove(p.ID)
+		}
+		return
+	}
+
+	if p.PrimaryAddress == nil {
+		return
+	}
+	ip := p.PrimaryAddress.String()
+
+	m.reputationMu.Lock()
+	score := m.scores[ip] - m.Opts.Reputation.penalty(mb)
+	m.scores[ip] = score
+	m.reputationMu.Unlock()
+
+	m.Events.PeerPenalized.Trigger(p, mb, score)
+
+	if score > m.Opts.Reputation.BanThreshold {
+		return
+	}
+
+	m.ban(p)
+}
+
+// ban bans all IP addresses of the given peer and drops the connection.
+// static peers are put back into the reconnect pool and are reconnected after the ban expired.
+func (m *Manager) ban(p *peer.Peer) {
+	bannedUntil := time.Now().Add(m.Opts.Reputation.BanDuration)
+
+	m.reputationMu.Lock()
+	m.banned[p.PrimaryAddress.String()] = bannedUntil
+	if p.Addresses != nil {
+		for ip := range p.Addresses.IPs {
+			m.banned[ip.String()] = bannedUntil
+		}
+	}
+	m.reputationMu.Unlock()
+
+	m.Events.PeerBanned.Trigger(p)
+
+	if p.Autopeering != nil {
+		// autopeered peers are replaced by the autopeering
+		_ = m.Remove(p.ID)
+		return
+	}
+
+	p.Disconnected = true
+	if p.Conn != nil {
+		_ = p.Conn.Close()
+	}
+}
+
+// Banned tells whether the given IP address is currently banned.
+func (m *Manager) Banned(ip string) bool {
+	m.reputationMu.Lock()
+	defer m.reputationMu.Unlock()
+
+	bannedUntil, banned := m.banned[ip]
+	return banned && time.Now().Before(bannedUntil)
+}
+
+// Score returns the reputation score of the given IP address.
+func (m *Manager) Score(ip string) int {
+	m.reputationMu.Lock()
+	defer m.reputationMu.Unlock()
+	return m.scores[ip]
+}
+
+// RecoverReputations lets every peer regain the score of one minute and lifts expired bans.
+// It is meant to be called every minute.
+func (m *Manager) RecoverReputations() {
+	m.reputationMu.Lock()
+	defer m.reputationMu.Unlock()
+
+	now := time.Now()
+	for ip, bannedUntil := range m.banned {
+		if now.Before(bannedUntil) {
+			continue
+		}
+		delete(m.banned, ip)
+
+		// give the peer a fresh start
+		delete(m.scores, ip)
+	}
+
+	for ip, score := range m.scores {
+		if _, banned := m.banned[ip]; banned {
+			continue
+		}
+
+		score += m.Opts.Reputation.RecoveryPerMinute
+		if score >= 0 {
+			delete(m.scores, ip)
+			continue
+		}
+		m.scores[ip] = score
+	}
+}
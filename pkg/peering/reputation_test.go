@@ -0,0 +1,52 @@
+package peering_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/iputils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/peering"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+func TestReputation(t *testing.T) {
+	m := peering.NewManager(peering.Options{
+		Reputation: peering.ReputationOptions{
+			Enabled:                   true,
+			InvalidTransactionPenalty: 60,
+			StaleMilestonePenalty:     2,
+			ProtocolViolationPenalty:  30,
+			BanThreshold:              -100,
+			BanDuration:               time.Hour,
+			RecoveryPerMinute:         10,
+		},
+	})
+
+	ip := net.ParseIP("192.0.2.1")
+	addresses := iputils.NewIPAddresses()
+	addresses.Add(ip)
+	p := peer.NewOutboundPeer(&iputils.OriginAddress{Addr: ip.String(), Port: 15600}, ip, 15600, addresses, peer.TransportTCP)
+
+	m.Penalize(p, peering.MisbehaviorInvalidTransaction)
+	assert.Equal(t, -60, m.Score(ip.String()))
+	assert.False(t, m.Banned(ip.String()))
+
+	// the peer regains reputation over time
+	m.RecoverReputations()
+	assert.Equal(t, -50, m.Score(ip.String()))
+
+	m.Penalize(p, peering.MisbehaviorProtocolViolation)
+	assert.Equal(t, -80, m.Score(ip.String()))
+	assert.False(t, m.Banned(ip.String()))
+
+	m.Penalize(p, peering.MisbehaviorProtocolViolation)
+	assert.True(t, m.Banned(ip.String()))
+
+	// the score doesn't recover while banned
+	m.RecoverReputations()
+	assert.Equal(t, -110, m.Score(ip.String()))
+	assert.True(t, m.Banned(ip.String()))
+}
@@ -0,0 +1,249 @@
+package secure
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// the first byte of a TLS record which contains a handshake message.
+	recordTypeHandshake = 0x16
+	// the validity of the self-signed certificate, the certificate is only a container for the identity key.
+	certificateValidity = 100 * 365 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidPrivateKey is returned when a private key is not a hex encoded ed25519 private key.
+	ErrInvalidPrivateKey = errors.New("invalid ed25519 private key")
+	// ErrInvalidPublicKey is returned when a public key is not a hex encoded ed25519 public key.
+	ErrInvalidPublicKey = errors.New("invalid ed25519 public key")
+	// ErrNoCertificate is returned when the remote side of a connection didn't present a certificate.
+	ErrNoCertificate = errors.New("no certificate presented")
+	// ErrNonMatchingPublicKey is returned when the remote side of a connection authenticated with a different key than the pinned one.
+	ErrNonMatchingPublicKey = errors.New("public key doesn't match the pinned key")
+	// ErrNoSyscallConn is returned when the underlying connection doesn't provide access to its socket.
+	ErrNoSyscallConn = errors.New("underlying connection doesn't provide access to its socket")
+)
+
+// ParsePrivateKey parses a hex encoded ed25519 private key.
+func ParsePrivateKey(hexKey string) (ed25519.PrivateKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidPrivateKey
+	}
+	return ed25519.PrivateKey(key), nil
+}
+
+// ParsePublicKey parses a hex encoded ed25519 public key.
+func ParsePublicKey(hexKey string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Identity secures the connections to neighbors with TLS 1.3, where both sides authenticate
+// with a self-signed certificate of their ed25519 identity key.
+// There is no certificate authority, instead the identity keys of the neighbors are pinned.
+type Identity struct {
+	certificate tls.Certificate
+}
+
+// NewIdentity creates a new Identity for the given ed25519 private key.
+func NewIdentity(privateKey ed25519.PrivateKey) (*Identity, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "hornet"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		certificate: tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  privateKey,
+		},
+	}, nil
+}
+
+// PublicKey returns the public key of the identity.
+func (id *Identity) PublicKey() ed25519.PublicKey {
+	return id.certificate.PrivateKey.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
+}
+
+// NewRandomIdentity creates a new Identity with a random ed25519 private key.
+// It is used for transports which are always encrypted, if the node has no configured identity.
+func NewRandomIdentity() (*Identity, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewIdentity(privateKey)
+}
+
+// Client secures the given outbound connection. The handshake fails if the
+// remote side doesn't authenticate with the given public key.
+func (id *Identity) Client(conn net.Conn, expected ed25519.PublicKey) *Conn {
+	return newConn(conn, tls.Client(conn, id.ClientConfig(expected)))
+}
+
+// ClientConfig returns the TLS config of outbound connections. The handshake fails if the
+// remote side doesn't authenticate with the given public key, any ed25519 key is accepted if it is nil.
+func (id *Identity) ClientConfig(expected ed25519.PublicKey) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{id.certificate},
+		MinVersion:   tls.VersionTLS13,
+		// the certificate is verified against the pinned key instead of a certificate authority
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			publicKey, err := publicKeyFromCertificates(rawCerts)
+			if err != nil {
+				return err
+			}
+			if expected != nil && !bytes.Equal(publicKey, expected) {
+				return ErrNonMatchingPublicKey
+			}
+			return nil
+		},
+	}
+}
+
+// Server secures the given inbound connection. The remote side has to authenticate
+// with an ed25519 key, which has to be checked against the pinned key by the caller
+// once the remote side is identified.
+func (id *Identity) Server(conn net.Conn) *Conn {
+	return newConn(conn, tls.Server(conn, id.ServerConfig()))
+}
+
+// ServerConfig returns the TLS config of inbound connections, see Server.
+func (id *Identity) ServerConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{id.certificate},
+		MinVersion:   tls.VersionTLS13,
+		ClientAuth:   tls.RequireAnyClientCert,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, err := publicKeyFromCertificates(rawCerts)
+			return err
+		},
+	}
+}
+
+// Conn is a TLS connection to a neighbor.
+type Conn struct {
+	*tls.Conn
+	// the underlying connection
+	raw net.Conn
+}
+
+func newConn(raw net.Conn, conn *tls.Conn) *Conn {
+	return &Conn{Conn: conn, raw: raw}
+}
+
+// SyscallConn gives access to the socket of the underlying connection, e.g. to measure the round trip time.
+func (c *Conn) SyscallConn() (syscall.RawConn, error) {
+	return syscallConn(c.raw)
+}
+
+// Authenticate runs the TLS handshake within the given timeout
+// and returns the public key the remote side authenticated with.
+func (c *Conn) Authenticate(timeout time.Duration) (ed25519.PublicKey, error) {
+	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if err := c.Handshake(); err != nil {
+		return nil, err
+	}
+	if err := c.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	state := c.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, ErrNoCertificate
+	}
+	return state.PeerCertificates[0].PublicKey.(ed25519.PublicKey), nil
+}
+
+// Sniff reads the first byte of the given inbound connection within the given timeout
+// and tells whether the remote side started a TLS handshake.
+// The returned connection has to be used instead of the given one, as it still contains the read byte.
+func Sniff(conn net.Conn, timeout time.Duration) (net.Conn, bool, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, false, err
+	}
+
+	reader := bufio.NewReaderSize(conn, 16)
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, false, err
+	}
+
+	return &sniffedConn{Conn: conn, reader: reader}, first[0] == recordTypeHandshake, nil
+}
+
+// sniffedConn is a net.Conn which first returns the data buffered while sniffing.
+type sniffedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *sniffedConn) Read(p []byte) (int, error) {
+	if c.reader.Buffered() > 0 {
+		return c.reader.Read(p)
+	}
+	return c.Conn.Read(p)
+}
+
+func (c *sniffedConn) SyscallConn() (syscall.RawConn, error) {
+	return syscallConn(c.Conn)
+}
+
+func syscallConn(conn net.Conn) (syscall.RawConn, error) {
+	sysConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return nil, ErrNoSyscallConn
+	}
+	return sysConn.SyscallConn()
+}
+
+// extracts the ed25519 public key of the leaf certificate.
+func publicKeyFromCertificates(rawCerts [][]byte) (ed25519.PublicKey, error) {
+	if len(rawCerts) == 0 {
+		return nil, ErrNoCertificate
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := cert.PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrInvalidPublicKey
+	}
+	return publicKey, nil
+}
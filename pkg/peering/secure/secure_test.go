@@ -0,0 +1,127 @@
+package secure_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/peering/secure"
+)
+
+const handshakeTimeout = 5 * time.Second
+
+func newIdentity(t *testing.T) (*secure.Identity, ed25519.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	identity, err := secure.NewIdentity(privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, identity.PublicKey())
+
+	return identity, publicKey
+}
+
+// returns both sides of a TCP connection over the loopback interface.
+func loopback(t *testing.T) (net.Conn, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	serverConn, err := listener.Accept()
+	require.NoError(t, err)
+
+	return clientConn, serverConn
+}
+
+// connects a client and a server and returns the keys both sides authenticated with.
+func connect(t *testing.T, client *secure.Identity, server *secure.Identity, expected ed25519.PublicKey) (ed25519.PublicKey, ed25519.PublicKey, error, error) {
+	clientConn, serverConn := loopback(t)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	type result struct {
+		publicKey ed25519.PublicKey
+		err       error
+	}
+	serverResult := make(chan result, 1)
+
+	go func() {
+		sniffedConn, secured, err := secure.Sniff(serverConn, handshakeTimeout)
+		if err != nil {
+			serverResult <- result{err: err}
+			return
+		}
+		assert.True(t, secured)
+
+		secureConn := server.Server(sniffedConn)
+		publicKey, err := secureConn.Authenticate(handshakeTimeout)
+		serverResult <- result{publicKey, err}
+	}()
+
+	secureConn := client.Client(clientConn, expected)
+	clientKey, clientErr := secureConn.Authenticate(handshakeTimeout)
+	if clientErr != nil {
+		// the server waits for the certificate of the client otherwise
+		_ = clientConn.Close()
+	}
+
+	res := <-serverResult
+	return clientKey, res.publicKey, clientErr, res.err
+}
+
+func TestIdentity_Authenticate(t *testing.T) {
+	client, clientPublicKey := newIdentity(t)
+	server, serverPublicKey := newIdentity(t)
+
+	authenticatedServer, authenticatedClient, clientErr, serverErr := connect(t, client, server, serverPublicKey)
+	require.NoError(t, clientErr)
+	require.NoError(t, serverErr)
+	assert.Equal(t, serverPublicKey, authenticatedServer)
+	assert.Equal(t, clientPublicKey, authenticatedClient)
+}
+
+func TestIdentity_AuthenticateNonMatchingKey(t *testing.T) {
+	client, _ := newIdentity(t)
+	server, _ := newIdentity(t)
+	_, otherPublicKey := newIdentity(t)
+
+	_, _, clientErr, serverErr := connect(t, client, server, otherPublicKey)
+	assert.Error(t, clientErr)
+	assert.Error(t, serverErr)
+}
+
+func TestSniff(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	go func() {
+		_, _ = clientConn.Write([]byte{1, 2, 3})
+	}()
+
+	sniffedConn, secured, err := secure.Sniff(serverConn, handshakeTimeout)
+	require.NoError(t, err)
+	assert.False(t, secured)
+
+	// the sniffed byte is not lost
+	buf := make([]byte, 3)
+	n, err := sniffedConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+}
+
+func TestParseKeys(t *testing.T) {
+	_, err := secure.ParsePublicKey("abcd")
+	assert.Equal(t, secure.ErrInvalidPublicKey, err)
+
+	_, err = secure.ParsePrivateKey("zz")
+	assert.Equal(t, secure.ErrInvalidPrivateKey, err)
+}
@@ -0,0 +1,46 @@
+package peering
+
+import (
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+// StaleCaller is the caller of the PeerStale event.
+func StaleCaller(handler interface{}, params ...interface{}) {
+	handler.(func(p *peer.Peer, checks int, reason string))(params[0].(*peer.Peer), params[1].(int), params[2].(string))
+}
+
+// CheckStaleness checks all connected peers whether they were stale since the last check.
+// PeerStale is fired for persistently stale peers every WarnAfterChecks consecutive checks,
+// PeerNoLongerStale is fired once a persistently stale peer recovered.
+// It is meant to be called every peer.StalenessCheckInterval.
+func (m *Manager) CheckStaleness() {
+	opts := &m.Opts.Staleness
+	if opts.WarnAfterChecks <= 0 {
+		return
+	}
+
+	var stalePeers, recoveredPeers []*peer.Peer
+	reasons := make(map[*peer.Peer]string)
+
+	m.ForAllConnected(func(p *peer.Peer) bool {
+		wasPersistentlyStale := p.IsPersistentlyStale(opts)
+
+		stale, reason := p.CheckStaleness(opts)
+		switch {
+		case stale && p.StaleChecks()%opts.WarnAfterChecks == 0:
+			stalePeers = append(stalePeers, p)
+			reasons[p] = reason
+		case !stale && wasPersistentlyStale:
+			recoveredPeers = append(recoveredPeers, p)
+		}
+		return true
+	})
+
+	// the events are triggered outside of the lock, so that the handlers are free to modify the peers
+	for _, p := range stalePeers {
+		m.Events.PeerStale.Trigger(p, p.StaleChecks(), reasons[p])
+	}
+	for _, p := range recoveredPeers {
+		m.Events.PeerNoLongerStale.Trigger(p)
+	}
+}
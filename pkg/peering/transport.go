@@ -0,0 +1,64 @@
+package peering
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/iotaledger/hive.go/network"
+
+	"github.com/gohornet/hornet/pkg/peering/secure"
+)
+
+const (
+	// the time the TLS handshake with a peer may take.
+	secureHandshakeTimeout = 5 * time.Second
+)
+
+// secures the given outbound connection if connections are secured and the peer with the given ID has a pinned identity key.
+// returns the identity key the peer authenticated with, nil if the connection is not secured.
+func (m *Manager) secureOutbound(conn net.Conn, id string) (*network.ManagedConnection, ed25519.PublicKey, error) {
+	pinnedKey := m.PinnedKey(id)
+	if m.Opts.Identity == nil || pinnedKey == nil {
+		return network.NewManagedConnection(conn), nil, nil
+	}
+
+	secureConn := m.Opts.Identity.Client(conn, pinnedKey)
+	publicKey, err := secureConn.Authenticate(secureHandshakeTimeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	return network.NewManagedConnection(secureConn), publicKey, nil
+}
+
+// secures the given inbound connection if connections are secured and the peer started a TLS handshake.
+// the identity key the peer authenticated with is checked against the pinned key once the peer sent its handshake.
+// returns the identity key the peer authenticated with, nil if the connection is not secured.
+func (m *Manager) secureInbound(conn *network.ManagedConnection) (*network.ManagedConnection, ed25519.PublicKey, error) {
+	if m.Opts.Identity == nil {
+		return conn, nil, nil
+	}
+
+	// plain connections are still accepted, e.g. from autopeered peers or peers without a pinned identity key
+	sniffedConn, secured, err := secure.Sniff(conn.Conn, secureHandshakeTimeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("can't read from incoming connection %s: %w", conn.RemoteAddr(), err)
+	}
+
+	if !secured {
+		return network.NewManagedConnection(sniffedConn), nil, nil
+	}
+
+	secureConn := m.Opts.Identity.Server(sniffedConn)
+	publicKey, err := secureConn.Authenticate(secureHandshakeTimeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("can't secure incoming connection %s: %w", conn.RemoteAddr(), err)
+	}
+
+	return network.NewManagedConnection(secureConn), publicKey, nil
+}
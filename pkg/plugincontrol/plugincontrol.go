@@ -0,0 +1,350 @@
+// Package plugincontrol starts and stops plugins of the node at runtime, without restarting the node.
+//
+// A plugin which supports this registers itself via Register and adds its background workers via Plugin.BackgroundWorker,
+// so that they are shut down if the plugin is stopped. Starting a plugin triggers its run callback again,
+// a plugin which was not loaded at startup is configured first.
+// The plugins which were started or stopped at runtime are remembered in a state file, see LoadState.
+package plugincontrol
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"go.uber.org/atomic"
+)
+
+var (
+	// ErrPluginNotControllable is returned if the plugin can't be started or stopped at runtime.
+	ErrPluginNotControllable = errors.New("plugin can't be started or stopped at runtime")
+	// ErrPluginRunning is returned if the plugin is already running.
+	ErrPluginRunning = errors.New("plugin is already running")
+	// ErrPluginNotRunning is returned if the plugin is not running.
+	ErrPluginNotRunning = errors.New("plugin is not running")
+	// ErrNodeShuttingDown is returned if plugins are started while the node shuts down.
+	ErrNodeShuttingDown = errors.New("node is shutting down")
+)
+
+var (
+	// the plugins which can be started and stopped at runtime, mapped by their identifiers
+	plugins = make(map[string]*Plugin)
+
+	stateLock sync.Mutex
+	statePath string
+	// the plugins which were started (true) or stopped (false) at runtime
+	state = make(map[string]bool)
+	// the plugins whose state in the state file differs from the config
+	overrides = make(map[string]bool)
+)
+
+const (
+	// the interval in which Stop checks whether the daemon marked the workers of a plugin as stopped
+	workerStoppedPollInterval = 5 * time.Millisecond
+)
+
+// Plugin is a plugin which can be started and stopped at runtime.
+type Plugin struct {
+	plugin *node.Plugin
+
+	// serializes the starts and stops of the plugin
+	controlLock sync.Mutex
+	configured  atomic.Bool
+	running     atomic.Bool
+
+	signalLock  sync.RWMutex
+	stopSignal  chan struct{}
+	workers     sync.WaitGroup
+	workerNames map[string]struct{}
+}
+
+// Status is the state of a plugin which can be started and stopped at runtime.
+type Status struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier"`
+	Running    bool   `json:"running"`
+}
+
+// Register allows the given plugin to be started and stopped at runtime. It has to be called before the node is started.
+func Register(plugin *node.Plugin) *Plugin {
+	identifier := node.GetPluginIdentifier(plugin.Name)
+	if _, exists := plugins[identifier]; exists {
+		panic("plugin \"" + plugin.Name + "\" was registered already")
+	}
+
+	p := &Plugin{
+		plugin:      plugin,
+		stopSignal:  make(chan struct{}),
+		workerNames: make(map[string]struct{}),
+	}
+
+	// a plugin which is configured by the node is run afterwards
+	plugin.Events.Configure.Attach(events.NewClosure(func(_ *node.Plugin) {
+		p.configured.Store(true)
+		p.running.Store(true)
+	}))
+
+	plugins[identifier] = p
+	return p
+}
+
+// BackgroundWorker adds a background worker of the plugin to the daemon.
+// The shutdown signal of the worker is closed if the node shuts down or if the plugin is stopped.
+func (p *Plugin) BackgroundWorker(name string, handler daemon.WorkerFunc, priority ...int) error {
+	p.signalLock.Lock()
+	stopSignal := p.stopSignal
+	p.workerNames[name] = struct{}{}
+	p.signalLock.Unlock()
+
+	p.workers.Add(1)
+	err := daemon.BackgroundWorker(name, func(shutdownSignal <-chan struct{}) {
+		defer p.workers.Done()
+
+		signal := make(chan struct{})
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-shutdownSignal:
+			case <-stopSignal:
+			case <-done:
+				return
+			}
+			close(signal)
+		}()
+
+		handler(signal)
+	}, priority...)
+	if err != nil {
+		p.workers.Done()
+	}
+
+	return err
+}
+
+// IsRunning returns whether the plugin is running.
+func (p *Plugin) IsRunning() bool {
+	return p.running.Load()
+}
+
+func (p *Plugin) start() error {
+	p.controlLock.Lock()
+	defer p.controlLock.Unlock()
+
+	if daemon.IsStopped() {
+		return ErrNodeShuttingDown
+	}
+
+	if p.running.Load() {
+		return ErrPluginRunning
+	}
+
+	if !p.configured.Load() {
+		// the plugin was not loaded at startup
+		p.plugin.Events.Configure.Trigger(p.plugin)
+	}
+	p.running.Store(true)
+	p.plugin.Events.Run.Trigger(p.plugin)
+
+	return nil
+}
+
+func (p *Plugin) stop() error {
+	p.controlLock.Lock()
+	defer p.controlLock.Unlock()
+
+	if !p.running.Load() {
+		return ErrPluginNotRunning
+	}
+
+	p.signalLock.Lock()
+	close(p.stopSignal)
+	p.stopSignal = make(chan struct{})
+	p.signalLock.Unlock()
+
+	// wait until all background workers of the plugin are shut down, so they can be added again at the next start
+	p.workers.Wait()
+	p.waitForStoppedWorkers()
+	p.running.Store(false)
+
+	return nil
+}
+
+// waitForStoppedWorkers waits until the daemon marked all background workers of the plugin as stopped.
+// The daemon does this only after the handlers returned, adding a worker with the same name before fails.
+func (p *Plugin) waitForStoppedWorkers() {
+	p.signalLock.RLock()
+	defer p.signalLock.RUnlock()
+
+	for {
+		stopped := true
+		for _, name := range daemon.GetRunningBackgroundWorkers() {
+			if _, exists := p.workerNames[name]; exists {
+				stopped = false
+				break
+			}
+		}
+		if stopped {
+			return
+		}
+		time.Sleep(workerStoppedPollInterval)
+	}
+}
+
+// IsSkipped returns whether the plugin is not running.
+// For plugins which can't be started or stopped at runtime this is the same as node.IsSkipped.
+func IsSkipped(plugin *node.Plugin) bool {
+	if p, exists := plugins[node.GetPluginIdentifier(plugin.Name)]; exists {
+		return !p.IsRunning()
+	}
+	return node.IsSkipped(plugin)
+}
+
+// Plugins returns the state of all plugins which can be started and stopped at runtime, sorted by their names.
+func Plugins() []Status {
+	result := make([]Status, 0, len(plugins))
+	for identifier, p := range plugins {
+		result = append(result, Status{
+			Name:       p.plugin.Name,
+			Identifier: identifier,
+			Running:    p.IsRunning(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func lookup(name string) (*Plugin, error) {
+	p, exists := plugins[node.GetPluginIdentifier(name)]
+	if !exists {
+		return nil, ErrPluginNotControllable
+	}
+	return p, nil
+}
+
+// Start starts the plugin with the given name and remembers it in the state file.
+func Start(name string) error {
+	p, err := lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	return storeState(node.GetPluginIdentifier(name), true)
+}
+
+// Stop stops the plugin with the given name and remembers it in the state file.
+func Stop(name string) error {
+	p, err := lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if err := p.stop(); err != nil {
+		return err
+	}
+
+	return storeState(node.GetPluginIdentifier(name), false)
+}
+
+// LoadState reads the plugins which were started or stopped at runtime from the given state file
+// and enables or disables them for the startup of the node. The state overrides node.enablePlugins and node.disablePlugins.
+// A missing state file is no error, the changes of the state are written to the file.
+func LoadState(path string) error {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	statePath = path
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	loaded := make(map[string]bool)
+	if err := json.Unmarshal(content, &loaded); err != nil {
+		return err
+	}
+
+	for identifier, running := range loaded {
+		p, exists := plugins[identifier]
+		if !exists {
+			// plugins which are not controllable anymore are dropped from the state
+			continue
+		}
+		state[identifier] = running
+
+		if running == node.IsSkipped(p.plugin) {
+			overrides[p.plugin.Name] = running
+		}
+
+		if running {
+			delete(node.DisabledPlugins, identifier)
+			node.EnabledPlugins[identifier] = true
+		} else {
+			delete(node.EnabledPlugins, identifier)
+			node.DisabledPlugins[identifier] = true
+		}
+	}
+
+	return nil
+}
+
+// Overrides returns the names of the plugins whose state in the state file overrides node.enablePlugins
+// and node.disablePlugins, mapped to whether they are started.
+func Overrides() map[string]bool {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	result := make(map[string]bool, len(overrides))
+	for name, running := range overrides {
+		result[name] = running
+	}
+	return result
+}
+
+// storeState remembers the state of the plugin in the state file.
+func storeState(identifier string, running bool) error {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	state[identifier] = running
+
+	if statePath == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(statePath); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+
+	// the state is written to a temporary file first, so a crash doesn't leave a truncated state file
+	tmpPath := statePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, statePath)
+}
@@ -0,0 +1,106 @@
+package plugincontrol
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestStartStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugincontrol")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	statePath := filepath.Join(dir, "pluginstate.json")
+	require.NoError(t, LoadState(statePath))
+
+	configured := atomic.NewInt32(0)
+	runs := atomic.NewInt32(0)
+	workerRunning := atomic.NewBool(false)
+
+	var control *Plugin
+	plugin := node.NewPlugin("Test Plugin", node.Disabled, func(_ *node.Plugin) {
+		configured.Inc()
+	}, func(_ *node.Plugin) {
+		runs.Inc()
+		require.NoError(t, control.BackgroundWorker("Test Plugin Worker", func(shutdownSignal <-chan struct{}) {
+			workerRunning.Store(true)
+			<-shutdownSignal
+			workerRunning.Store(false)
+		}))
+	})
+	control = Register(plugin)
+
+	daemon.Start()
+
+	assert.True(t, IsSkipped(plugin))
+	assert.Equal(t, ErrPluginNotRunning, Stop("testplugin"))
+	assert.Equal(t, ErrPluginNotControllable, Start("unknown"))
+
+	// the plugin is configured at the first start
+	require.NoError(t, Start("Test Plugin"))
+	assert.False(t, IsSkipped(plugin))
+	assert.Equal(t, ErrPluginRunning, Start("testplugin"))
+	assert.Eventually(t, workerRunning.Load, time.Second, 10*time.Millisecond)
+
+	// the workers are shut down before Stop returns
+	require.NoError(t, Stop("testplugin"))
+	assert.False(t, workerRunning.Load())
+	assert.True(t, IsSkipped(plugin))
+
+	// the worker can be added again right after the stop
+	for i := 0; i < 20; i++ {
+		require.NoError(t, Start("testplugin"))
+		assert.Eventually(t, workerRunning.Load, time.Second, time.Millisecond)
+		require.NoError(t, Stop("testplugin"))
+	}
+	require.NoError(t, Start("testplugin"))
+	assert.Eventually(t, workerRunning.Load, time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 1, configured.Load())
+	assert.EqualValues(t, 22, runs.Load())
+
+	assert.Equal(t, []Status{{Name: "Test Plugin", Identifier: "testplugin", Running: true}}, Plugins())
+
+	content, err := ioutil.ReadFile(statePath)
+	require.NoError(t, err)
+	state := make(map[string]bool)
+	require.NoError(t, json.Unmarshal(content, &state))
+	assert.Equal(t, map[string]bool{"testplugin": true}, state)
+
+	require.NoError(t, Stop("testplugin"))
+}
+
+func TestLoadState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugincontrol")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	Register(node.NewPlugin("Enabled Plugin", node.Disabled, func(_ *node.Plugin) {}))
+	Register(node.NewPlugin("Disabled Plugin", node.Enabled, func(_ *node.Plugin) {}))
+	node.DisabledPlugins["enabledplugin"] = true
+
+	statePath := filepath.Join(dir, "pluginstate.json")
+	require.NoError(t, ioutil.WriteFile(statePath, []byte(`{"enabledplugin": true, "disabledplugin": false, "removedplugin": true}`), 0600))
+	require.NoError(t, LoadState(statePath))
+
+	// the state overrides the enabled and disabled plugins of the config
+	assert.True(t, node.EnabledPlugins["enabledplugin"])
+	assert.False(t, node.DisabledPlugins["enabledplugin"])
+	assert.True(t, node.DisabledPlugins["disabledplugin"])
+	assert.False(t, node.EnabledPlugins["removedplugin"])
+
+	// only the plugins whose state differs from the config are reported
+	assert.Equal(t, map[string]bool{"Enabled Plugin": true, "Disabled Plugin": false}, Overrides())
+
+	require.NoError(t, ioutil.WriteFile(statePath, []byte(`{`), 0600))
+	assert.Error(t, LoadState(statePath))
+}
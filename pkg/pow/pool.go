@@ -0,0 +1,106 @@
+package pow
+
+import (
+	"errors"
+	"time"
+
+	"github.com/iotaledger/iota.go/trinary"
+	"go.uber.org/atomic"
+)
+
+const (
+	// the weight of the latest PoW duration in the moving average
+	averageDurationWeight = 0.1
+)
+
+var (
+	// ErrQueueFull is returned if too many local PoW requests are waiting already.
+	ErrQueueFull = errors.New("PoW queue is full")
+)
+
+// Metrics are the metrics of the local PoW worker pool.
+type Metrics struct {
+	// the amount of local PoW requests waiting for a free worker
+	QueueSize int32 `json:"queueSize"`
+	// the amount of local PoW requests currently running
+	Running int32 `json:"running"`
+	// the amount of finished PoW requests
+	Finished uint64 `json:"finished"`
+	// the moving average of the duration of the PoW requests
+	AverageDuration time.Duration `json:"averageDuration"`
+}
+
+// localPool limits the concurrent local PoW requests and the requests waiting for a free worker.
+type localPool struct {
+	semaphore    chan struct{}
+	queued       atomic.Int32
+	maxQueueSize int32
+}
+
+func newLocalPool(workerCount int, maxQueueSize int) *localPool {
+	return &localPool{
+		semaphore:    make(chan struct{}, workerCount),
+		maxQueueSize: int32(maxQueueSize),
+	}
+}
+
+type poolMetrics struct {
+	queued          atomic.Int32
+	running         atomic.Int32
+	finished        atomic.Uint64
+	averageDuration atomic.Float64
+}
+
+func (m *poolMetrics) addDuration(duration time.Duration) {
+	for {
+		average := m.averageDuration.Load()
+
+		newAverage := float64(duration)
+		if average != 0 {
+			newAverage = average + averageDurationWeight*(float64(duration)-average)
+		}
+
+		if m.averageDuration.CAS(average, newAverage) {
+			break
+		}
+	}
+	m.finished.Inc()
+}
+
+// Metrics returns the metrics of the local PoW worker pools of the node and the API.
+func (h *Handler) Metrics() *Metrics {
+	return &Metrics{
+		QueueSize:       h.metrics.queued.Load(),
+		Running:         h.metrics.running.Load(),
+		Finished:        h.metrics.finished.Load(),
+		AverageDuration: time.Duration(h.metrics.averageDuration.Load()),
+	}
+}
+
+// doLocalPoW waits for a free worker and calculates the PoW with the local PoW function.
+// The parallelism is limited to the configured parallelism of the workers.
+func (h *Handler) doLocalPoW(pool *localPool, trytes trinary.Trytes, mwm int, parallelism ...int) (string, error) {
+
+	if queued := pool.queued.Inc(); pool.maxQueueSize != 0 && queued > pool.maxQueueSize {
+		pool.queued.Dec()
+		return "", ErrQueueFull
+	}
+	h.metrics.queued.Inc()
+
+	pool.semaphore <- struct{}{}
+	pool.queued.Dec()
+	h.metrics.queued.Dec()
+	h.metrics.running.Inc()
+
+	defer func() {
+		h.metrics.running.Dec()
+		<-pool.semaphore
+	}()
+
+	threads := h.localParallelism
+	if len(parallelism) > 0 && parallelism[0] > 0 && parallelism[0] < threads {
+		threads = parallelism[0]
+	}
+
+	return h.localPoWFunc(trytes, mwm, threads)
+}
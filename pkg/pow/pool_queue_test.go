@@ -0,0 +1,55 @@
+package pow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueLimitOnlyAppliesToAPI(t *testing.T) {
+	handler := New(nil, Options{WorkerCount: 1, MaxQueueSize: 1})
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	handler.localPoWFunc = func(_ trinary.Trytes, _ int, _ ...int) (trinary.Trytes, error) {
+		started <- struct{}{}
+		<-release
+		return "NONCE", nil
+	}
+
+	apiResults := make(chan error, 2)
+	doAPIPoW := func() {
+		_, err := handler.DoAPIPoW("", 1)
+		apiResults <- err
+	}
+
+	// one API request is running, one is waiting
+	go doAPIPoW()
+	<-started
+	go doAPIPoW()
+	require.Eventually(t, func() bool { return handler.apiPool.queued.Load() == 1 }, time.Second, time.Millisecond)
+
+	// the queue of the API is full
+	_, err := handler.DoAPIPoW("", 1)
+	assert.Equal(t, ErrQueueFull, err)
+
+	// the PoW of the node has its own worker and doesn't wait for the API requests
+	nodeResult := make(chan error, 1)
+	go func() {
+		_, err := handler.DoPoW("", 1)
+		nodeResult <- err
+	}()
+	<-started
+
+	assert.EqualValues(t, 2, handler.Metrics().Running)
+	assert.EqualValues(t, 1, handler.Metrics().QueueSize)
+
+	close(release)
+	assert.NoError(t, <-nodeResult)
+	assert.NoError(t, <-apiResults)
+	assert.NoError(t, <-apiResults)
+	assert.EqualValues(t, 3, handler.Metrics().Finished)
+}
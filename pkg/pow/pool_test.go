@@ -0,0 +1,28 @@
+package pow_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	powpackage "github.com/gohornet/hornet/pkg/pow"
+)
+
+func TestLocalPoWMetrics(t *testing.T) {
+	handler := powpackage.New(nil, powpackage.Options{PowsrvInitCooldown: time.Minute, WorkerCount: 2, Parallelism: 1, MaxQueueSize: 10})
+
+	for i := 0; i < 3; i++ {
+		_, err := handler.DoPoW(strings.Repeat("9", consts.TransactionTrytesSize), testMWM)
+		require.NoError(t, err)
+	}
+
+	metrics := handler.Metrics()
+	assert.EqualValues(t, 0, metrics.QueueSize)
+	assert.EqualValues(t, 0, metrics.Running)
+	assert.EqualValues(t, 3, metrics.Finished)
+	assert.True(t, metrics.AverageDuration > 0)
+}
@@ -1,6 +1,7 @@
 package pow
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/iotaledger/iota.go/pow"
@@ -12,11 +13,16 @@ import (
 	powsrvio "gitlab.com/powsrv.io/go/client"
 )
 
-// Handler handles PoW requests of the node and tunnels them to powsrv.io
-// or uses local PoW if no API key was specified or the connection failed.
+// Handler handles PoW requests of the node and tunnels them to a remote proof-of-work service or powsrv.io
+// or uses local PoW if neither was specified or the connection failed.
 type Handler struct {
 	log *logger.Logger
 
+	remotePoW           *RemotePoW
+	remoteLocalFallback bool
+	remoteLock          syncutils.RWMutex
+	remoteLastFailure   time.Time
+
 	powsrvClient       *powsrvio.PowClient
 	powsrvLock         syncutils.RWMutex
 	powsrvInitCooldown time.Duration
@@ -26,10 +32,39 @@ type Handler struct {
 
 	localPoWFunc pow.ProofOfWorkFunc
 	localPowType string
+
+	// the local PoW requests of the node itself and of the API have separate workers,
+	// so that API requests can't delay or reject the PoW of the node (e.g. of the coordinator)
+	nodePool         *localPool
+	apiPool          *localPool
+	localParallelism int
+
+	metrics *poolMetrics
+}
+
+// Options are the settings of a PoW handler.
+type Options struct {
+	// The API key of powsrv.io, powsrv.io is not used if it is empty.
+	PowsrvAPIKey string
+	// The time after which the connection to powsrv.io is retried after an error.
+	// It is also the time the remote proof-of-work service is skipped after an error if RemoteLocalFallback is enabled.
+	PowsrvInitCooldown time.Duration
+	// The remote proof-of-work service, which is preferred over powsrv.io and local PoW (optional).
+	RemotePoW *RemotePoW
+	// Whether local PoW is used if the remote proof-of-work service fails.
+	RemoteLocalFallback bool
+	// The maximum amount of local PoW requests of the API which run at the same time.
+	// The PoW of the node itself has the same amount of workers on its own.
+	WorkerCount int
+	// The maximum amount of threads used by a single local PoW request (0 = all cores).
+	Parallelism int
+	// The maximum amount of local PoW requests of the API which wait for a free worker (0 = no limit).
+	// Further requests are rejected with ErrQueueFull. The PoW of the node itself is never rejected.
+	MaxQueueSize int
 }
 
-// New creates a new PoW handler instance.
-func New(log *logger.Logger, powsrvAPIKey string, powsrvInitCooldown time.Duration) *Handler {
+// New creates a new PoW handler instance with the given options.
+func New(log *logger.Logger, opts Options) *Handler {
 
 	// Get the fastest available local PoW func
 	localPoWType, localPoWFunc := pow.GetFastestProofOfWorkUnsyncImpl()
@@ -37,23 +72,44 @@ func New(log *logger.Logger, powsrvAPIKey string, powsrvInitCooldown time.Durati
 	var powsrvClient *powsrvio.PowClient
 
 	// Check if powsrv.io API key is set
-	if powsrvAPIKey != "" {
+	if opts.PowsrvAPIKey != "" {
 		powsrvClient = &powsrvio.PowClient{
-			APIKey:        powsrvAPIKey,
+			APIKey:        opts.PowsrvAPIKey,
 			ReadTimeOutMs: 3000,
 			Verbose:       false,
 		}
 	}
 
+	workerCount := opts.WorkerCount
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 || parallelism > runtime.NumCPU() {
+		parallelism = runtime.NumCPU()
+	}
+
+	maxQueueSize := opts.MaxQueueSize
+	if maxQueueSize < 0 {
+		maxQueueSize = 0
+	}
+
 	return &Handler{
-		log:                log,
-		powsrvClient:       powsrvClient,
-		powsrvInitCooldown: powsrvInitCooldown,
-		powsrvLastInit:     time.Time{},
-		powsrvConnected:    false,
-		powsrvErrorHandled: false,
-		localPoWFunc:       localPoWFunc,
-		localPowType:       localPoWType,
+		log:                 log,
+		remotePoW:           opts.RemotePoW,
+		remoteLocalFallback: opts.RemoteLocalFallback,
+		powsrvClient:        powsrvClient,
+		powsrvInitCooldown:  opts.PowsrvInitCooldown,
+		powsrvLastInit:      time.Time{},
+		powsrvConnected:     false,
+		powsrvErrorHandled:  false,
+		localPoWFunc:        localPoWFunc,
+		localPowType:        localPoWType,
+		nodePool:            newLocalPool(workerCount, 0),
+		apiPool:             newLocalPool(workerCount, maxQueueSize),
+		localParallelism:    parallelism,
+		metrics:             &poolMetrics{},
 	}
 }
 
@@ -127,8 +183,28 @@ func (h *Handler) disconnectPowsrv() {
 	h.powsrvClient.Close()
 }
 
+// useRemotePoW returns whether PoW requests are forwarded to the remote proof-of-work service.
+func (h *Handler) useRemotePoW() bool {
+	if h.remotePoW == nil {
+		return false
+	}
+
+	if !h.remoteLocalFallback {
+		return true
+	}
+
+	h.remoteLock.RLock()
+	defer h.remoteLock.RUnlock()
+
+	return time.Since(h.remoteLastFailure) >= h.powsrvInitCooldown
+}
+
 // GetPoWType returns the fastest available PoW type which gets used for PoW requests
 func (h *Handler) GetPoWType() string {
+	if h.useRemotePoW() {
+		return "remote"
+	}
+
 	h.powsrvLock.RLock()
 	defer h.powsrvLock.RUnlock()
 
@@ -139,9 +215,52 @@ func (h *Handler) GetPoWType() string {
 	return h.localPowType
 }
 
-// DoPoW calculates the PoW
-// Either with the fastest available local PoW function or with the help of powsrv.io (optional, POWSRV_API_KEY env var must be available)
+// DoPoW calculates the PoW for the node itself (e.g. the coordinator, the spammer or the faucet).
+// Either with the fastest available local PoW function, with the help of a remote proof-of-work service (optional)
+// or with the help of powsrv.io (optional, POWSRV_API_KEY env var must be available)
+// Local PoW requests of the node have their own workers and are never rejected because of the queue size.
 func (h *Handler) DoPoW(trytes trinary.Trytes, mwm int, parallelism ...int) (nonce string, err error) {
+	return h.measurePoW(h.nodePool, trytes, mwm, parallelism...)
+}
+
+// DoAPIPoW calculates the PoW for a request of the API like DoPoW.
+// Local PoW requests of the API are rejected with ErrQueueFull if too many of them are waiting for a free worker already.
+func (h *Handler) DoAPIPoW(trytes trinary.Trytes, mwm int, parallelism ...int) (nonce string, err error) {
+	return h.measurePoW(h.apiPool, trytes, mwm, parallelism...)
+}
+
+func (h *Handler) measurePoW(pool *localPool, trytes trinary.Trytes, mwm int, parallelism ...int) (nonce string, err error) {
+	ts := time.Now()
+
+	nonce, err = h.doPoW(pool, trytes, mwm, parallelism...)
+	if err != nil {
+		return "", err
+	}
+
+	h.metrics.addDuration(time.Since(ts))
+	return nonce, nil
+}
+
+func (h *Handler) doPoW(pool *localPool, trytes trinary.Trytes, mwm int, parallelism ...int) (nonce string, err error) {
+
+	if h.useRemotePoW() {
+		nonce, err := h.remotePoW.DoPoW(trytes, mwm)
+		if err == nil {
+			return nonce, nil
+		}
+
+		if !h.remoteLocalFallback {
+			return "", err
+		}
+
+		h.remoteLock.Lock()
+		h.remoteLastFailure = time.Now()
+		h.remoteLock.Unlock()
+
+		if h.log != nil {
+			h.log.Warnf("Error during PoW via the remote service, falling back to local PoW: %s", err)
+		}
+	}
 
 	if h.connectPowsrv() {
 		// connected to powsrv.io
@@ -168,7 +287,7 @@ func (h *Handler) DoPoW(trytes trinary.Trytes, mwm int, parallelism ...int) (non
 	}
 
 	// Local PoW
-	return h.localPoWFunc(trytes, mwm, parallelism...)
+	return h.doLocalPoW(pool, trytes, mwm, parallelism...)
 }
 
 // Close closes the PoW handler
@@ -0,0 +1,122 @@
+package pow
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/proxy"
+)
+
+var (
+	// ErrRemotePoWFailed is returned if the remote proof-of-work service did not return a valid nonce.
+	ErrRemotePoWFailed = errors.New("remote PoW failed")
+)
+
+// remotePoWRequest is the request sent to the remote proof-of-work service.
+type remotePoWRequest struct {
+	Trytes             trinary.Trytes `json:"trytes"`
+	MinWeightMagnitude int            `json:"minWeightMagnitude"`
+}
+
+// remotePoWResponse is the response of the remote proof-of-work service.
+type remotePoWResponse struct {
+	Nonce trinary.Trytes `json:"nonce"`
+	Error string         `json:"error,omitempty"`
+}
+
+// RemotePoW forwards PoW requests to a remote proof-of-work service.
+// The trytes of a single transaction and the minimum weight magnitude are posted as JSON to the endpoint,
+// which has to answer with the nonce of the transaction.
+type RemotePoW struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewRemotePoW creates a new client for the remote proof-of-work service at the given endpoint.
+// The API key is sent as bearer token if it is not empty.
+func NewRemotePoW(endpoint string, apiKey string, timeout time.Duration) *RemotePoW {
+	return &RemotePoW{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   proxy.HTTPClient(timeout),
+	}
+}
+
+// DoPoW requests the nonce for the given transaction trytes from the remote service and verifies it.
+func (r *RemotePoW) DoPoW(trytes trinary.Trytes, mwm int) (trinary.Trytes, error) {
+
+	body, err := json.Marshal(&remotePoWRequest{Trytes: trytes, MinWeightMagnitude: mwm})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRemotePoWFailed, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRemotePoWFailed, err)
+	}
+
+	response := &remotePoWResponse{}
+	if err := json.Unmarshal(resBody, response); err != nil {
+		return "", fmt.Errorf("%w: status %d, %v", ErrRemotePoWFailed, res.StatusCode, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d, %s", ErrRemotePoWFailed, res.StatusCode, response.Error)
+	}
+
+	// never trust the remote service
+	if err := verifyNonce(trytes, response.Nonce, mwm); err != nil {
+		return "", err
+	}
+
+	return response.Nonce, nil
+}
+
+// verifyNonce checks whether the transaction with the given nonce fulfills the minimum weight magnitude.
+func verifyNonce(trytes trinary.Trytes, nonce trinary.Trytes, mwm int) error {
+
+	if len(nonce) != consts.NonceTrinarySize/3 || !guards.IsTrytes(nonce) {
+		return fmt.Errorf("%w: invalid nonce", ErrRemotePoWFailed)
+	}
+
+	if len(trytes) != consts.TransactionTrytesSize {
+		return fmt.Errorf("%w: invalid transaction trytes", ErrRemotePoWFailed)
+	}
+
+	tx, err := transaction.AsTransactionObject(trytes[:len(trytes)-len(nonce)] + nonce)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRemotePoWFailed, err)
+	}
+
+	if !transaction.HasValidNonce(tx, uint64(mwm)) {
+		return fmt.Errorf("%w: nonce does not fulfill the minimum weight magnitude", ErrRemotePoWFailed)
+	}
+
+	return nil
+}
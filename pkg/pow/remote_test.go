@@ -0,0 +1,87 @@
+package pow_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/pow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	powpackage "github.com/gohornet/hornet/pkg/pow"
+)
+
+const (
+	testMWM    = 5
+	testAPIKey = "secret"
+)
+
+func newRemotePoWServer(t *testing.T, nonceFunc func(trytes string, mwm int) string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+			return
+		}
+
+		request := &struct {
+			Trytes             string `json:"trytes"`
+			MinWeightMagnitude int    `json:"minWeightMagnitude"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"nonce": nonceFunc(request.Trytes, request.MinWeightMagnitude)})
+	}))
+}
+
+func TestRemotePoW(t *testing.T) {
+	trytes := strings.Repeat("9", consts.TransactionTrytesSize)
+
+	_, localPoWFunc := pow.GetFastestProofOfWorkUnsyncImpl()
+	server := newRemotePoWServer(t, func(trytes string, mwm int) string {
+		nonce, err := localPoWFunc(trytes, mwm)
+		require.NoError(t, err)
+		return nonce
+	})
+	defer server.Close()
+
+	nonce, err := powpackage.NewRemotePoW(server.URL, testAPIKey, time.Second).DoPoW(trytes, testMWM)
+	require.NoError(t, err)
+	assert.Len(t, nonce, consts.NonceTrinarySize/3)
+
+	_, err = powpackage.NewRemotePoW(server.URL, "wrong", time.Second).DoPoW(trytes, testMWM)
+	assert.True(t, errors.Is(err, powpackage.ErrRemotePoWFailed))
+}
+
+func TestRemotePoWInvalidNonce(t *testing.T) {
+	trytes := strings.Repeat("9", consts.TransactionTrytesSize)
+
+	server := newRemotePoWServer(t, func(_ string, _ int) string {
+		return "ABCDEFGHIJKLMNOPQRSTUVWXYZ9"
+	})
+	defer server.Close()
+
+	remotePoW := powpackage.NewRemotePoW(server.URL, testAPIKey, time.Second)
+
+	_, err := remotePoW.DoPoW(trytes, 14)
+	assert.True(t, errors.Is(err, powpackage.ErrRemotePoWFailed))
+
+	// without the fallback the error of the remote service is returned
+	_, err = powpackage.New(nil, powpackage.Options{PowsrvInitCooldown: time.Minute, RemotePoW: remotePoW}).DoPoW(trytes, 14)
+	assert.True(t, errors.Is(err, powpackage.ErrRemotePoWFailed))
+
+	// with the fallback the nonce is calculated locally
+	handler := powpackage.New(nil, powpackage.Options{PowsrvInitCooldown: time.Minute, RemotePoW: remotePoW, RemoteLocalFallback: true})
+	assert.Equal(t, "remote", handler.GetPoWType())
+
+	nonce, err := handler.DoPoW(trytes, testMWM)
+	require.NoError(t, err)
+	assert.Len(t, nonce, consts.NonceTrinarySize/3)
+	assert.NotEqual(t, "remote", handler.GetPoWType())
+}
@@ -3,6 +3,7 @@ package profile
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -78,10 +79,78 @@ func LoadProfile() *Profile {
 			p.Name = profileName
 			profile = p
 		}
+
+		// copy the profile, so the predefined ones are not modified by the overrides
+		p := *profile
+		if err := p.Caches.applyCacheTimes(config.NodeConfig.GetStringMapString(config.CfgDatabaseCacheTimeMilliseconds)); err != nil {
+			panic(err)
+		}
+		if err := p.Caches.applyCacheSizes(config.NodeConfig.GetStringMapString(config.CfgDatabaseCacheSizes)); err != nil {
+			panic(err)
+		}
+		profile = &p
 	})
 	return profile
 }
 
+// cacheOpts returns the options of the caches by their lower cased names.
+func (c *Caches) cacheOpts() map[string]*CacheOpts {
+	return map[string]*CacheOpts{
+		"addresses":                 &c.Addresses,
+		"bundles":                   &c.Bundles,
+		"bundletransactions":        &c.BundleTransactions,
+		"approvers":                 &c.Approvers,
+		"tags":                      &c.Tags,
+		"milestones":                &c.Milestones,
+		"transactions":              &c.Transactions,
+		"incomingtransactionfilter": &c.IncomingTransactionFilter,
+		"unconfirmedtx":             &c.UnconfirmedTx,
+		"spentaddresses":            &c.SpentAddresses,
+	}
+}
+
+// applyCacheTimes overrides the cache times of the given caches in milliseconds.
+// The names of the caches are case insensitive.
+func (c *Caches) applyCacheTimes(cacheTimes map[string]string) error {
+	caches := c.cacheOpts()
+
+	for name, cacheTime := range cacheTimes {
+		opts, exists := caches[strings.ToLower(name)]
+		if !exists {
+			return fmt.Errorf("unknown cache '%s' in '%s'", name, config.CfgDatabaseCacheTimeMilliseconds)
+		}
+
+		cacheTimeMs, err := strconv.ParseUint(cacheTime, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid cache time '%s' of cache '%s' in '%s'", cacheTime, name, config.CfgDatabaseCacheTimeMilliseconds)
+		}
+		opts.CacheTimeMs = cacheTimeMs
+	}
+
+	return nil
+}
+
+// applyCacheSizes overrides the maximum amount of objects of the given caches.
+// The names of the caches are case insensitive.
+func (c *Caches) applyCacheSizes(cacheSizes map[string]string) error {
+	caches := c.cacheOpts()
+
+	for name, cacheSize := range cacheSizes {
+		opts, exists := caches[strings.ToLower(name)]
+		if !exists {
+			return fmt.Errorf("unknown cache '%s' in '%s'", name, config.CfgDatabaseCacheSizes)
+		}
+
+		size, err := strconv.ParseUint(cacheSize, 10, 31)
+		if err != nil {
+			return fmt.Errorf("invalid cache size '%s' of cache '%s' in '%s'", cacheSize, name, config.CfgDatabaseCacheSizes)
+		}
+		opts.CacheSize = int(size)
+	}
+
+	return nil
+}
+
 var Profile8GB = &Profile{
 	Caches: Caches{
 		Addresses: CacheOpts{
@@ -442,6 +511,7 @@ type Caches struct {
 
 type CacheOpts struct {
 	CacheTimeMs          uint64            `mapstructure:"cacheTimeMs"`
+	CacheSize            int               `mapstructure:"cacheSize"`
 	LeakDetectionOptions LeakDetectionOpts `mapstructure:"leakDetection"`
 }
 
@@ -0,0 +1,32 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCacheTimes(t *testing.T) {
+	caches := Profile2GB.Caches
+
+	// the names are lower-cased by the config
+	assert.NoError(t, caches.applyCacheTimes(map[string]string{"transactions": "60000", "spentaddresses": "0"}))
+	assert.EqualValues(t, 60000, caches.Transactions.CacheTimeMs)
+	assert.EqualValues(t, 0, caches.SpentAddresses.CacheTimeMs)
+	assert.Equal(t, Profile2GB.Caches.Approvers, caches.Approvers)
+
+	assert.Error(t, caches.applyCacheTimes(map[string]string{"unknown": "1000"}))
+	assert.Error(t, caches.applyCacheTimes(map[string]string{"approvers": "-1"}))
+}
+
+func TestApplyCacheSizes(t *testing.T) {
+	caches := Profile2GB.Caches
+
+	assert.NoError(t, caches.applyCacheSizes(map[string]string{"transactions": "50000", "incomingtransactionfilter": "1000"}))
+	assert.Equal(t, 50000, caches.Transactions.CacheSize)
+	assert.Equal(t, 1000, caches.IncomingTransactionFilter.CacheSize)
+	assert.Equal(t, 0, caches.Approvers.CacheSize)
+
+	assert.Error(t, caches.applyCacheSizes(map[string]string{"unknown": "1000"}))
+	assert.Error(t, caches.applyCacheSizes(map[string]string{"approvers": "-1"}))
+}
@@ -0,0 +1,213 @@
+// Package profiling captures runtime profiles of the node, so rare slowdowns can be analyzed after the fact.
+// The profiles are either kept in a directory with a retention time or pushed to a remote endpoint.
+package profiling
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ProfileCPU is the name of the CPU profile, all other names are looked up in the runtime profiles (heap, goroutine, ...).
+	ProfileCPU = "cpu"
+
+	// the time format in the file names of stored profiles
+	fileTimeFormat = "2006-01-02T15-04-05"
+	// the extension of stored profiles, the pprof format is gzip compressed protobuf
+	fileExtension = ".pb.gz"
+)
+
+var (
+	// ErrUnknownProfile is returned if the name of a profile is neither "cpu" nor a runtime profile.
+	ErrUnknownProfile = errors.New("unknown profile")
+	// ErrPushFailed is returned if a profile couldn't be pushed to the remote endpoint.
+	ErrPushFailed = errors.New("pushing the profile failed")
+)
+
+// ValidateProfile checks whether a profile with the given name can be captured.
+func ValidateProfile(name string) error {
+	if name == ProfileCPU || pprof.Lookup(name) != nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+}
+
+// Capture captures the profile with the given name in the pprof format.
+// The CPU profile is recorded for the given duration or until the abort signal is received.
+func Capture(name string, cpuDuration time.Duration, abortSignal <-chan struct{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if name == ProfileCPU {
+		// fails if the CPU is already profiled, e.g. by a request to the pprof endpoint
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(cpuDuration)
+		select {
+		case <-timer.C:
+		case <-abortSignal:
+			timer.Stop()
+		}
+		pprof.StopCPUProfile()
+
+		return buf.Bytes(), nil
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+	}
+
+	if err := profile.WriteTo(&buf, 0); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Storage keeps captured profiles as files in a directory.
+type Storage struct {
+	directory string
+	retention time.Duration
+}
+
+// NewStorage creates a storage for the profiles in the given directory.
+// Profiles older than the retention are removed by RemoveExpired (0 = keep all).
+func NewStorage(directory string, retention time.Duration) (*Storage, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Storage{directory: directory, retention: retention}, nil
+}
+
+// Store writes the profile with the given name and capture time to a file and returns its path.
+func (s *Storage) Store(name string, ts time.Time, data []byte) (string, error) {
+	filePath := filepath.Join(s.directory, name+"_"+ts.UTC().Format(fileTimeFormat)+fileExtension)
+
+	// write to a temporary file first, so no incomplete profiles are left behind
+	tmpFilePath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, data, 0600); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// RemoveExpired removes all stored profiles which were captured before the retention and returns their amount.
+// Files which were not written by the storage are kept.
+func (s *Storage) RemoveExpired(now time.Time) (int, error) {
+	if s.retention == 0 {
+		return 0, nil
+	}
+
+	files, err := ioutil.ReadDir(s.directory)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		ts, ok := profileTime(file.Name())
+		if !ok || now.Sub(ts) <= s.retention {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(s.directory, file.Name())); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// profileTime parses the capture time of a stored profile from its file name.
+func profileTime(fileName string) (time.Time, bool) {
+	if !strings.HasSuffix(fileName, fileExtension) {
+		return time.Time{}, false
+	}
+
+	separator := strings.LastIndex(fileName, "_")
+	if separator == -1 {
+		return time.Time{}, false
+	}
+
+	ts, err := time.Parse(fileTimeFormat, strings.TrimSuffix(fileName[separator+1:], fileExtension))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return ts, true
+}
+
+// Pusher pushes captured profiles to a remote endpoint.
+// Every profile is posted in the pprof format, the name of the profile, the alias of the node
+// and the capture time (unix seconds) are passed as query parameters.
+type Pusher struct {
+	endpoint  string
+	apiKey    string
+	nodeAlias string
+	client    *http.Client
+}
+
+// NewPusher creates a new pusher for the given endpoint.
+// The API key is sent as bearer token if it is not empty.
+func NewPusher(endpoint string, apiKey string, nodeAlias string, timeout time.Duration) *Pusher {
+	return &Pusher{
+		endpoint:  endpoint,
+		apiKey:    apiKey,
+		nodeAlias: nodeAlias,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Push posts the profile with the given name and capture time to the endpoint.
+func (p *Pusher) Push(name string, ts time.Time, data []byte) error {
+	endpoint, err := url.Parse(p.endpoint)
+	if err != nil {
+		return err
+	}
+
+	query := endpoint.Query()
+	query.Set("profile", name)
+	query.Set("node", p.nodeAlias)
+	query.Set("time", strconv.FormatInt(ts.Unix(), 10))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPushFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%w: status %d", ErrPushFailed, res.StatusCode)
+	}
+
+	return nil
+}
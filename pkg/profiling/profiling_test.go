@@ -0,0 +1,100 @@
+package profiling_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/profiling"
+)
+
+func TestCapture(t *testing.T) {
+	for _, name := range []string{profiling.ProfileCPU, "heap", "goroutine"} {
+		require.NoError(t, profiling.ValidateProfile(name))
+
+		data, err := profiling.Capture(name, 50*time.Millisecond, nil)
+		require.NoError(t, err)
+		// the pprof format is gzip compressed
+		require.True(t, len(data) > 2)
+		assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+	}
+
+	assert.True(t, errors.Is(profiling.ValidateProfile("unknown"), profiling.ErrUnknownProfile))
+	_, err := profiling.Capture("unknown", 0, nil)
+	assert.True(t, errors.Is(err, profiling.ErrUnknownProfile))
+}
+
+func TestCaptureCPUAborted(t *testing.T) {
+	abortSignal := make(chan struct{})
+	close(abortSignal)
+
+	ts := time.Now()
+	_, err := profiling.Capture(profiling.ProfileCPU, time.Minute, abortSignal)
+	require.NoError(t, err)
+	assert.True(t, time.Since(ts) < time.Minute)
+}
+
+func TestStorageRetention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	storage, err := profiling.NewStorage(dir, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	oldPath, err := storage.Store("heap", now.Add(-2*time.Hour), []byte{1})
+	require.NoError(t, err)
+	newPath, err := storage.Store("heap", now.Add(-time.Minute), []byte{2})
+	require.NoError(t, err)
+
+	// files of others are kept
+	otherPath := filepath.Join(dir, "notes.txt")
+	require.NoError(t, ioutil.WriteFile(otherPath, []byte{3}, 0600))
+
+	removed, err := storage.RemoveExpired(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, err = os.Stat(oldPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(newPath)
+	assert.NoError(t, err)
+	_, err = os.Stat(otherPath)
+	assert.NoError(t, err)
+}
+
+func TestPusher(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "heap", r.URL.Query().Get("profile"))
+		assert.Equal(t, "node1", r.URL.Query().Get("node"))
+		assert.Equal(t, "1600000000", r.URL.Query().Get("time"))
+
+		var err error
+		received, err = ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	ts := time.Unix(1600000000, 0)
+
+	err := profiling.NewPusher(server.URL, "secret", "node1", time.Second).Push("heap", ts, []byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, received)
+
+	err = profiling.NewPusher(server.URL, "wrong", "node1", time.Second).Push("heap", ts, []byte{1, 2, 3})
+	assert.True(t, errors.Is(err, profiling.ErrPushFailed))
+}
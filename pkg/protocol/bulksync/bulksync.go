@@ -0,0 +1,87 @@
+package bulksync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/protocol/message"
+	"github.com/gohornet/hornet/pkg/protocol/tlv"
+)
+
+var (
+	// ErrInvalidSourceLength is returned when an invalid source byte slice for extraction of certain data is passed.
+	ErrInvalidSourceLength = errors.New("invalid source byte slice")
+	// ErrInvalidMilestoneRange is returned when the requested milestone range is empty or too big.
+	ErrInvalidMilestoneRange = errors.New("invalid milestone range")
+)
+
+// Capability denotes the capability bit for milestone cone requests support.
+// It is announced in the handshake independently of the protocol version, so that older nodes are not affected by it.
+const Capability = 1 << 0
+
+// CapabilityName is the name of the capability.
+const CapabilityName = "Milestone-Cones"
+
+func init() {
+	if err := message.RegisterType(MessageTypeMilestoneConeRequest, MilestoneConeRequestMessageDefinition); err != nil {
+		panic(err)
+	}
+}
+
+const (
+	MessageTypeMilestoneConeRequest message.Type = 7
+)
+
+const (
+	// The amount of bytes used for the requested milestone range.
+	RequestedMilestoneRangeMsgBytesLength = 8
+
+	// The maximum amount of milestones whose cones can be requested with a single request.
+	MaxRequestedMilestones = 20
+)
+
+var (
+	// The requested milestone range packet.
+	// Contains the first and the last index of the milestones whose cones are requested.
+	MilestoneConeRequestMessageDefinition = &message.Definition{
+		ID:             MessageTypeMilestoneConeRequest,
+		MaxBytesLength: RequestedMilestoneRangeMsgBytesLength,
+		VariableLength: false,
+	}
+)
+
+// NewMilestoneConeRequestMessage creates a new request for the cones of the milestones from start to end (inclusive).
+func NewMilestoneConeRequestMessage(start milestone.Index, end milestone.Index) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, tlv.HeaderMessageDefinition.MaxBytesLength+MilestoneConeRequestMessageDefinition.MaxBytesLength))
+	if err := tlv.WriteHeader(buf, MessageTypeMilestoneConeRequest, MilestoneConeRequestMessageDefinition.MaxBytesLength); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(buf, binary.BigEndian, start); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(buf, binary.BigEndian, end); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExtractRequestedMilestoneRange extracts the requested milestone range from the given source.
+func ExtractRequestedMilestoneRange(source []byte) (start milestone.Index, end milestone.Index, err error) {
+	if len(source) != RequestedMilestoneRangeMsgBytesLength {
+		return 0, 0, ErrInvalidSourceLength
+	}
+
+	start = milestone.Index(binary.BigEndian.Uint32(source[:4]))
+	end = milestone.Index(binary.BigEndian.Uint32(source[4:]))
+
+	if start == 0 || end < start || end-start >= MaxRequestedMilestones {
+		return 0, 0, ErrInvalidMilestoneRange
+	}
+
+	return start, end, nil
+}
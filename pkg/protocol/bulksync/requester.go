@@ -0,0 +1,175 @@
+package bulksync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+// Batch is a range of milestones whose cones are requested from a single peer.
+type Batch struct {
+	// The first milestone of the batch.
+	Start milestone.Index
+	// The last milestone of the batch.
+	End milestone.Index
+	// The time at which the batch was requested, zero if it was not yet requested.
+	RequestedAt time.Time
+}
+
+// SendFunc sends a request for the given batch to a peer.
+// It returns false if there is no peer which is able to serve the batch.
+type SendFunc func(b *Batch) bool
+
+// FallbackFunc requests the milestones of the given batch in case the batch could not be requested or timed out.
+type FallbackFunc func(b *Batch)
+
+// ContainsMilestoneFunc tells whether the given milestone is already stored.
+type ContainsMilestoneFunc func(index milestone.Index) bool
+
+// Options define the batching of the Requester.
+type Options struct {
+	// The amount of milestones per requested batch.
+	BatchSize int
+	// The maximum amount of batches which are requested at the same time.
+	MaxInFlight int
+	// The time after which a batch which is not completed is requested via the fallback.
+	Timeout time.Duration
+}
+
+// Requester splits milestone ranges into batches and requests them with a limited amount of parallel requests.
+// A batch is completed as soon as all of its milestones are stored.
+type Requester struct {
+	mu                sync.Mutex
+	opts              Options
+	send              SendFunc
+	fallback          FallbackFunc
+	containsMilestone ContainsMilestoneFunc
+	queued            []*Batch
+	inFlight          []*Batch
+}
+
+// NewRequester creates a new Requester.
+func NewRequester(opts Options, send SendFunc, fallback FallbackFunc, containsMilestone ContainsMilestoneFunc) *Requester {
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 1
+	}
+	if opts.BatchSize > MaxRequestedMilestones {
+		opts.BatchSize = MaxRequestedMilestones
+	}
+	if opts.MaxInFlight < 1 {
+		opts.MaxInFlight = 1
+	}
+	return &Requester{
+		opts:              opts,
+		send:              send,
+		fallback:          fallback,
+		containsMilestone: containsMilestone,
+	}
+}
+
+// Add queues batches for the milestones from start to end (inclusive) which are not yet stored.
+// Returns the amount of milestones which were queued.
+func (r *Requester) Add(start milestone.Index, end milestone.Index) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var queued int
+	var current *Batch
+	for index := start; index <= end; index++ {
+		if r.containsMilestone(index) || r.isQueuedOrInFlight(index) {
+			current = nil
+			continue
+		}
+
+		queued++
+		if current == nil || int(index-current.Start) >= r.opts.BatchSize {
+			current = &Batch{Start: index, End: index}
+			r.queued = append(r.queued, current)
+			continue
+		}
+		current.End = index
+	}
+
+	return queued
+}
+
+// Update completes the batches whose milestones are stored, passes timed out batches to the fallback
+// and requests queued batches as long as the maximum amount of parallel requests isn't reached.
+func (r *Requester) Update(now time.Time) {
+	r.mu.Lock()
+
+	var failed []*Batch
+	inFlight := r.inFlight[:0]
+	for _, b := range r.inFlight {
+		if r.isCompleted(b) {
+			continue
+		}
+		if now.Sub(b.RequestedAt) >= r.opts.Timeout {
+			failed = append(failed, b)
+			continue
+		}
+		inFlight = append(inFlight, b)
+	}
+	r.inFlight = inFlight
+
+	for len(r.queued) > 0 && len(r.inFlight) < r.opts.MaxInFlight {
+		b := r.queued[0]
+		r.queued = r.queued[1:]
+
+		if r.isCompleted(b) {
+			continue
+		}
+
+		if !r.send(b) {
+			failed = append(failed, b)
+			continue
+		}
+		b.RequestedAt = now
+		r.inFlight = append(r.inFlight, b)
+	}
+
+	r.mu.Unlock()
+
+	// the fallback is called without holding the lock, since it may take a while
+	for _, b := range failed {
+		r.fallback(b)
+	}
+}
+
+// Pending returns the amount of batches which are queued or requested.
+func (r *Requester) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.queued) + len(r.inFlight)
+}
+
+// Clear removes all queued and requested batches.
+func (r *Requester) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queued = nil
+	r.inFlight = nil
+}
+
+// checks whether all milestones of the given batch are stored.
+func (r *Requester) isCompleted(b *Batch) bool {
+	for index := b.Start; index <= b.End; index++ {
+		if !r.containsMilestone(index) {
+			return false
+		}
+	}
+	return true
+}
+
+// checks whether the given milestone is part of a queued or requested batch.
+func (r *Requester) isQueuedOrInFlight(index milestone.Index) bool {
+	for _, batches := range [][]*Batch{r.queued, r.inFlight} {
+		for _, b := range batches {
+			if index >= b.Start && index <= b.End {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package bulksync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
+)
+
+type fakeNode struct {
+	stored    map[milestone.Index]bool
+	sent      []bulksync.Batch
+	fallbacks []bulksync.Batch
+	canSend   bool
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{stored: make(map[milestone.Index]bool), canSend: true}
+}
+
+func (n *fakeNode) requester(opts bulksync.Options) *bulksync.Requester {
+	return bulksync.NewRequester(opts,
+		func(b *bulksync.Batch) bool {
+			if !n.canSend {
+				return false
+			}
+			n.sent = append(n.sent, *b)
+			return true
+		},
+		func(b *bulksync.Batch) {
+			n.fallbacks = append(n.fallbacks, *b)
+		},
+		func(index milestone.Index) bool {
+			return n.stored[index]
+		})
+}
+
+func TestRequester_Add(t *testing.T) {
+	n := newFakeNode()
+	n.stored[104] = true
+	r := n.requester(bulksync.Options{BatchSize: 3, MaxInFlight: 10, Timeout: time.Minute})
+
+	// 101-103, 105-107, 108-110
+	assert.Equal(t, 9, r.Add(101, 110))
+	assert.Equal(t, 3, r.Pending())
+
+	// already queued milestones are not added again
+	assert.Equal(t, 2, r.Add(109, 112))
+	assert.Equal(t, 4, r.Pending())
+
+	r.Update(time.Now())
+	require.Len(t, n.sent, 4)
+	assert.Equal(t, bulksync.Batch{Start: 101, End: 103}, bulksync.Batch{Start: n.sent[0].Start, End: n.sent[0].End})
+	assert.Equal(t, bulksync.Batch{Start: 105, End: 107}, bulksync.Batch{Start: n.sent[1].Start, End: n.sent[1].End})
+	assert.Equal(t, bulksync.Batch{Start: 108, End: 110}, bulksync.Batch{Start: n.sent[2].Start, End: n.sent[2].End})
+	assert.Equal(t, bulksync.Batch{Start: 111, End: 112}, bulksync.Batch{Start: n.sent[3].Start, End: n.sent[3].End})
+}
+
+func TestRequester_Update(t *testing.T) {
+	n := newFakeNode()
+	r := n.requester(bulksync.Options{BatchSize: 5, MaxInFlight: 2, Timeout: time.Minute})
+
+	assert.Equal(t, 20, r.Add(1, 20))
+
+	now := time.Now()
+	r.Update(now)
+	assert.Len(t, n.sent, 2)
+
+	// nothing completed, the amount of parallel requests is limited
+	r.Update(now.Add(time.Second))
+	assert.Len(t, n.sent, 2)
+
+	// the first batch completed
+	for index := milestone.Index(1); index <= 5; index++ {
+		n.stored[index] = true
+	}
+	r.Update(now.Add(2 * time.Second))
+	assert.Len(t, n.sent, 3)
+	assert.Empty(t, n.fallbacks)
+
+	// the remaining batches time out
+	r.Update(now.Add(2 * time.Minute))
+	assert.Len(t, n.fallbacks, 2)
+	assert.Len(t, n.sent, 4)
+	assert.Equal(t, 1, r.Pending())
+
+	r.Clear()
+	assert.Equal(t, 0, r.Pending())
+}
+
+func TestRequester_Fallback(t *testing.T) {
+	n := newFakeNode()
+	n.canSend = false
+	r := n.requester(bulksync.Options{BatchSize: 5, MaxInFlight: 2, Timeout: time.Minute})
+
+	assert.Equal(t, 10, r.Add(1, 10))
+
+	// batches which can't be sent are passed to the fallback immediately
+	r.Update(time.Now())
+	assert.Empty(t, n.sent)
+	assert.Len(t, n.fallbacks, 2)
+	assert.Equal(t, 0, r.Pending())
+}
+
+func TestExtractRequestedMilestoneRange(t *testing.T) {
+	msg, err := bulksync.NewMilestoneConeRequestMessage(100, 119)
+	require.NoError(t, err)
+
+	// skip the TLV header
+	start, end, err := bulksync.ExtractRequestedMilestoneRange(msg[3:])
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, start)
+	assert.EqualValues(t, 119, end)
+
+	msg, err = bulksync.NewMilestoneConeRequestMessage(100, 120)
+	require.NoError(t, err)
+	_, _, err = bulksync.ExtractRequestedMilestoneRange(msg[3:])
+	assert.Equal(t, bulksync.ErrInvalidMilestoneRange, err)
+
+	_, _, err = bulksync.ExtractRequestedMilestoneRange(msg[3:6])
+	assert.Equal(t, bulksync.ErrInvalidSourceLength, err)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/willf/bitset"
@@ -31,6 +32,7 @@ var (
 	// - own used MWM (1 byte)
 	// - supported protocol versions. we need up to 32 bytes to represent 256 possible protocol
 	//   versions. only up to N bytes are used to communicate the highest supported version.
+	// - own supported capabilities (1 byte). older nodes don't send this byte and ignore it.
 	HandshakeMessageDefinition = &message.Definition{
 		ID:             MessageTypeHandshake,
 		MaxBytesLength: 92,
@@ -43,6 +45,12 @@ type HeaderState int32
 const (
 	// The amount of bytes used for the coo address sent in a handshake packet.
 	ByteEncodedCooAddressBytesLength = 49
+
+	// The amount of bytes used for the capabilities sent in a handshake packet.
+	CapabilitiesBytesLength = 1
+
+	// The amount of bytes used for the length of the supported versions bitset.
+	supportedVersionsLengthBytesLength = 8
 )
 
 var (
@@ -56,6 +64,9 @@ type Handshake struct {
 	ByteEncodedCooAddress []byte
 	MWM                   byte
 	SupportedVersions     []byte
+	// Capabilities are announced independently of the protocol version, so that nodes which don't know them
+	// still negotiate the same protocol version. Zero if the peer didn't announce any capabilities.
+	Capabilities byte
 }
 
 // SupportedVersion returns the highest supported protocol version.
@@ -86,7 +97,7 @@ func (hs Handshake) SupportedVersion(ownSupportedMessagesBitset *bitset.BitSet)
 }
 
 // NewHandshakeMessage creates a new handshake message.
-func NewHandshakeMessage(ownSupportedMessagesBitset *bitset.BitSet, ownSourcePort uint16, ownByteEncodedCooAddress []byte, ownUsedMWM byte) ([]byte, error) {
+func NewHandshakeMessage(ownSupportedMessagesBitset *bitset.BitSet, ownCapabilities byte, ownSourcePort uint16, ownByteEncodedCooAddress []byte, ownUsedMWM byte) ([]byte, error) {
 
 	maxLength := HandshakeMessageDefinition.MaxBytesLength
 
@@ -95,7 +106,7 @@ func NewHandshakeMessage(ownSupportedMessagesBitset *bitset.BitSet, ownSourcePor
 		return nil, err
 	}
 
-	payloadLengthBytes := maxLength - (maxLength - 60) + uint16(len(supportedMessageTypes))
+	payloadLengthBytes := maxLength - (maxLength - 60) + uint16(len(supportedMessageTypes)) + CapabilitiesBytesLength
 	buf := bytes.NewBuffer(make([]byte, 0, tlv.HeaderMessageDefinition.MaxBytesLength+payloadLengthBytes))
 
 	if err := tlv.WriteHeader(buf, MessageTypeHandshake, payloadLengthBytes); err != nil {
@@ -122,6 +133,10 @@ func NewHandshakeMessage(ownSupportedMessagesBitset *bitset.BitSet, ownSourcePor
 		return nil, err
 	}
 
+	if err := binary.Write(buf, binary.BigEndian, ownCapabilities); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -131,7 +146,6 @@ func ParseHandshake(msg []byte) (*Handshake, error) {
 	var sentTimestamp uint64
 	byteEncodedCooAddress := make([]byte, ByteEncodedCooAddressBytesLength)
 	var mwm byte
-	supportedVersions := make([]byte, 8)
 
 	r := bytes.NewReader(msg)
 
@@ -151,10 +165,31 @@ func ParseHandshake(msg []byte) (*Handshake, error) {
 		return nil, err
 	}
 
-	if _, err := r.Read(supportedVersions); err != nil {
+	// the supported versions are a marshaled bitset: its length in bits followed by the words holding the bits
+	supportedVersionsLength := make([]byte, supportedVersionsLengthBytesLength)
+	if _, err := r.Read(supportedVersionsLength); err != nil {
 		return nil, err
 	}
 
-	hs := &Handshake{ServerSocketPort: serverSocketPort, SentTimestamp: sentTimestamp, ByteEncodedCooAddress: byteEncodedCooAddress, MWM: mwm, SupportedVersions: supportedVersions}
+	supportedVersionsWordsBytesLength := (binary.BigEndian.Uint64(supportedVersionsLength) + 63) / 64 * 8
+	if supportedVersionsWordsBytesLength > uint64(r.Len()) {
+		supportedVersionsWordsBytesLength = uint64(r.Len())
+	}
+
+	supportedVersions := make([]byte, supportedVersionsLengthBytesLength+supportedVersionsWordsBytesLength)
+	copy(supportedVersions, supportedVersionsLength)
+	if _, err := r.Read(supportedVersions[supportedVersionsLengthBytesLength:]); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	// older nodes don't announce any capabilities
+	var capabilities byte
+	if r.Len() >= CapabilitiesBytesLength {
+		if err := binary.Read(r, binary.BigEndian, &capabilities); err != nil {
+			return nil, err
+		}
+	}
+
+	hs := &Handshake{ServerSocketPort: serverSocketPort, SentTimestamp: sentTimestamp, ByteEncodedCooAddress: byteEncodedCooAddress, MWM: mwm, SupportedVersions: supportedVersions, Capabilities: capabilities}
 	return hs, nil
 }
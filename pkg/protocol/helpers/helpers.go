@@ -4,6 +4,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
 )
 
@@ -50,3 +51,13 @@ func SendMilestoneRequest(p *peer.Peer, index milestone.Index) {
 func SendLatestMilestoneRequest(p *peer.Peer) {
 	SendMilestoneRequest(p, sting.LatestMilestoneRequestIndex)
 }
+
+// SendMilestoneConeRequest sends a request for the cones of the milestones from start to end (inclusive) to the given peer.
+func SendMilestoneConeRequest(p *peer.Peer, start milestone.Index, end milestone.Index) {
+	if !p.Protocol.HasCapability(bulksync.Capability) {
+		return
+	}
+
+	coneRequestData, _ := bulksync.NewMilestoneConeRequestMessage(start, end)
+	p.EnqueueForSending(coneRequestData)
+}
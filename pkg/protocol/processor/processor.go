@@ -14,15 +14,19 @@ import (
 	"github.com/iotaledger/iota.go/transaction"
 	"github.com/iotaledger/iota.go/trinary"
 
+	"github.com/gohornet/hornet/pkg/bandwidth"
 	"github.com/gohornet/hornet/pkg/compressed"
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/profile"
 	"github.com/gohornet/hornet/pkg/protocol/bqueue"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
 	"github.com/gohornet/hornet/pkg/protocol/message"
 	"github.com/gohornet/hornet/pkg/protocol/rqueue"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
@@ -30,6 +34,14 @@ import (
 
 const (
 	WorkerQueueSize = 50000
+
+	// the amount of workers and the queue size for milestone cone requests.
+	// serving a cone request is expensive, therefore only a few are processed in parallel.
+	ConeRequestWorkerCount     = 2
+	ConeRequestWorkerQueueSize = 100
+
+	// the time to wait for free space in the send queue of a peer while replying to a milestone cone request.
+	ConeRequestSendTimeout = 5 * time.Second
 )
 
 var (
@@ -45,9 +57,11 @@ func New(requestQueue rqueue.Queue, peerManager *peering.Manager, opts *Options)
 		Events: Events{
 			TransactionProcessed: events.NewEvent(TransactionProcessedCaller),
 			BroadcastTransaction: events.NewEvent(BroadcastCaller),
+			TransactionSubmitted: events.NewEvent(TransactionCaller),
 		},
 	}
 	wuCacheOpts := opts.WorkUnitCacheOpts
+	proc.workUnitsMaxSize = wuCacheOpts.CacheSize
 	proc.workUnits = objectstorage.New(
 		nil,
 		workUnitFactory,
@@ -78,6 +92,11 @@ func New(requestQueue rqueue.Queue, peerManager *peering.Manager, opts *Options)
 		task.Return(nil)
 	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(WorkerQueueSize))
 
+	proc.coneRequestWp = workerpool.New(func(task workerpool.Task) {
+		proc.processMilestoneConeRequest(task.Param(0).(*peer.Peer), task.Param(1).([]byte))
+		task.Return(nil)
+	}, workerpool.WorkerCount(ConeRequestWorkerCount), workerpool.QueueSize(ConeRequestWorkerQueueSize))
+
 	return proc
 }
 
@@ -85,6 +104,10 @@ func TransactionProcessedCaller(handler interface{}, params ...interface{}) {
 	handler.(func(tx *hornet.Transaction, request *rqueue.Request, p *peer.Peer))(params[0].(*hornet.Transaction), params[1].(*rqueue.Request), params[2].(*peer.Peer))
 }
 
+func TransactionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(tx *hornet.Transaction))(params[0].(*hornet.Transaction))
+}
+
 func BroadcastCaller(handler interface{}, params ...interface{}) {
 	handler.(func(b *bqueue.Broadcast))(params[0].(*bqueue.Broadcast))
 }
@@ -95,16 +118,22 @@ type Events struct {
 	TransactionProcessed *events.Event
 	// Fired when a transaction is meant to be broadcasted.
 	BroadcastTransaction *events.Event
+	// Fired when a transaction was submitted via ValidateTransactionTrytesAndEmit, before it is processed and broadcasted.
+	TransactionSubmitted *events.Event
 }
 
 // Processor processes submitted messages in parallel and fires appropriate completion events.
 type Processor struct {
-	Events       Events
-	pm           *peering.Manager
-	wp           *workerpool.WorkerPool
-	requestQueue rqueue.Queue
-	workUnits    *objectstorage.ObjectStorage
-	opts         Options
+	Events Events
+	pm     *peering.Manager
+	wp     *workerpool.WorkerPool
+	// the worker pool which replies to milestone cone requests
+	coneRequestWp *workerpool.WorkerPool
+	requestQueue  rqueue.Queue
+	workUnits     *objectstorage.ObjectStorage
+	// the maximum amount of cached work units, see tangle.LimitCacheSize
+	workUnitsMaxSize int
+	opts             Options
 }
 
 // The Options for the Processor.
@@ -116,18 +145,25 @@ type Options struct {
 // Run runs the processor and blocks until the shutdown signal is triggered.
 func (proc *Processor) Run(shutdownSignal <-chan struct{}) {
 	proc.wp.Start()
+	proc.coneRequestWp.Start()
 	<-shutdownSignal
+	proc.coneRequestWp.StopAndWait()
 	proc.wp.StopAndWait()
 }
 
 // Process submits the given message to the processor for processing.
+// Milestone cone requests are dropped if too many of them are pending.
 func (proc *Processor) Process(p *peer.Peer, msgType message.Type, data []byte) {
+	if msgType == bulksync.MessageTypeMilestoneConeRequest {
+		proc.coneRequestWp.TrySubmit(p, data)
+		return
+	}
 	proc.wp.Submit(p, msgType, data)
 }
 
 // ValidateTransactionTrytesAndEmit validates the given transaction trytes which were not received via gossip but
 // through some other mechanism. This function does not run within the Processor's worker pool.
-// Emits a TransactionProcessed and BroadcastTransaction event if the transaction was processed.
+// Emits a TransactionSubmitted, TransactionProcessed and BroadcastTransaction event if the transaction was processed.
 func (proc *Processor) ValidateTransactionTrytesAndEmit(txTrytes trinary.Trytes) error {
 	if !guards.IsTransactionTrytes(txTrytes) {
 		return consts.ErrInvalidTransactionTrytes
@@ -161,12 +197,16 @@ func (proc *Processor) ValidateTransactionTrytesAndEmit(txTrytes trinary.Trytes)
 		return consts.ErrInvalidTransactionHash
 	}
 
-	return proc.CompressAndEmit(tx, txTrits)
+	return proc.compressAndEmit(tx, txTrits, true)
 }
 
 // CompressAndEmit compresses the given transaction and emits TransactionProcessed and BroadcastTransaction events.
 // This function does not run within the Processor's worker pool.
 func (proc *Processor) CompressAndEmit(tx *transaction.Transaction, txTrits trinary.Trits) error {
+	return proc.compressAndEmit(tx, txTrits, false)
+}
+
+func (proc *Processor) compressAndEmit(tx *transaction.Transaction, txTrits trinary.Trits, submitted bool) error {
 	txBytesTruncated := compressed.TruncateTx(trinary.MustTritsToBytes(txTrits))
 	hornetTx := hornet.NewTransactionFromTx(tx, txBytesTruncated)
 
@@ -174,12 +214,37 @@ func (proc *Processor) CompressAndEmit(tx *transaction.Transaction, txTrits trin
 		return ErrInvalidTimestamp
 	}
 
+	if submitted {
+		proc.Events.TransactionSubmitted.Trigger(hornetTx)
+	}
+	proc.emit(hornetTx)
+	return nil
+}
+
+// EmitCompressed emits TransactionProcessed and BroadcastTransaction events for the given compressed transaction data,
+// e.g. to resume the broadcast of a submitted transaction after a restart.
+// This function does not run within the Processor's worker pool.
+func (proc *Processor) EmitCompressed(txData []byte) error {
+	tx, err := compressed.TransactionFromCompressedBytes(txData)
+	if err != nil {
+		return err
+	}
+	hornetTx := hornet.NewTransactionFromTx(tx, txData)
+
+	if timeValid, _ := proc.ValidateTimestamp(hornetTx); !timeValid {
+		return ErrInvalidTimestamp
+	}
+
+	proc.emit(hornetTx)
+	return nil
+}
+
+func (proc *Processor) emit(hornetTx *hornet.Transaction) {
 	proc.Events.TransactionProcessed.Trigger(hornetTx, (*rqueue.Request)(nil), (*peer.Peer)(nil))
 	proc.Events.BroadcastTransaction.Trigger(&bqueue.Broadcast{
-		TxData:          txBytesTruncated,
+		TxData:          hornetTx.RawBytes,
 		RequestedTxHash: hornetTx.GetTxHash(),
 	})
-	return nil
 }
 
 // WorkUnitSize returns the size of WorkUnits currently cached.
@@ -190,10 +255,10 @@ func (proc *Processor) WorkUnitsSize() int {
 // gets a CachedWorkUnit or creates a new one if it not existent.
 func (proc *Processor) workUnitFor(receivedTxBytes []byte) *CachedWorkUnit {
 	return &CachedWorkUnit{
-		proc.workUnits.ComputeIfAbsent(receivedTxBytes, func(key []byte) objectstorage.StorableObject { // cachedWorkUnit +1
+		tangle.LimitCacheSize(proc.workUnits, proc.workUnitsMaxSize, proc.workUnits.ComputeIfAbsent(receivedTxBytes, func(key []byte) objectstorage.StorableObject { // cachedWorkUnit +1
 			cachedWorkUnit, _, _ := workUnitFactory(receivedTxBytes)
 			return cachedWorkUnit
-		}),
+		})),
 	}
 }
 
@@ -203,8 +268,8 @@ func (proc *Processor) processMilestoneRequest(p *peer.Peer, data []byte) {
 	if err != nil {
 		metrics.SharedServerMetrics.InvalidRequests.Inc()
 
-		// drop the connection to the peer
-		proc.pm.Remove(p.ID)
+		// lower the reputation of the peer
+		proc.pm.Penalize(p, peering.MisbehaviorProtocolViolation)
 		return
 	}
 
@@ -219,6 +284,14 @@ func (proc *Processor) processMilestoneRequest(p *peer.Peer, data []byte) {
 		return
 	}
 
+	cachedReqMsTail := cachedReqMs.GetBundle().GetTail() // tx +1
+	allowed := proc.allowReply(cachedReqMsTail.GetTransaction())
+	cachedReqMsTail.Release(true) // tx -1
+	if !allowed {
+		cachedReqMs.Release(true) // bundle -1
+		return
+	}
+
 	cachedTxs := cachedReqMs.GetBundle().GetTransactions() // txs +1
 	for _, cachedTxToSend := range cachedTxs {
 		transactionMsg, _ := sting.NewTransactionMessage(cachedTxToSend.GetTransaction().RawBytes)
@@ -228,9 +301,107 @@ func (proc *Processor) processMilestoneRequest(p *peer.Peer, data []byte) {
 	cachedReqMs.Release(true) // bundle -1
 }
 
+// processes the given milestone cone request by parsing it and then replying to the peer with the transactions
+// confirmed by the requested milestones. only milestones up to the solid milestone are served.
+func (proc *Processor) processMilestoneConeRequest(p *peer.Peer, data []byte) {
+	start, end, err := bulksync.ExtractRequestedMilestoneRange(data)
+	if err != nil {
+		metrics.SharedServerMetrics.InvalidRequests.Inc()
+
+		// lower the reputation of the peer
+		proc.pm.Penalize(p, peering.MisbehaviorProtocolViolation)
+		return
+	}
+
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo == nil {
+		return
+	}
+
+	solidMilestoneIndex := tangle.GetSolidMilestoneIndex()
+	for msIndex := start; msIndex <= end && msIndex <= solidMilestoneIndex; msIndex++ {
+		if msIndex <= snapshotInfo.PruningIndex {
+			// can't reply if we already pruned the cone of the milestone
+			continue
+		}
+
+		if !proc.sendMilestoneCone(p, msIndex) {
+			// stop replying if the peer doesn't keep up, the cone is incomplete or the bandwidth budget throttles the replies
+			return
+		}
+	}
+}
+
+// sends all transactions confirmed by the given milestone to the peer.
+// the approvees are sent before the transactions approving them, the milestone tail transaction is sent last.
+// returns false if the cone couldn't be sent completely.
+func (proc *Processor) sendMilestoneCone(p *peer.Peer, msIndex milestone.Index) bool {
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		return false
+	}
+	msTailHash := cachedMs.GetBundle().GetTailHash()
+	cachedMsTail := cachedMs.GetBundle().GetTail() // tx +1
+	allowed := proc.allowReply(cachedMsTail.GetTransaction())
+	cachedMsTail.Release(true) // tx -1
+	cachedMs.Release(true)     // bundle -1
+
+	if !allowed {
+		return false
+	}
+
+	var coneTxHashes hornet.Hashes
+	err := dag.TraverseApprovees(msTailHash,
+		// traversal stops at transactions which were confirmed by older milestones
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+			return confirmed && at == msIndex, nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			coneTxHashes = append(coneTxHashes, cachedTxMeta.GetMetadata().GetTxHash())
+			return nil
+		},
+		// called on missing approvees
+		// return error on missing approvees
+		nil,
+		// called on solid entry points
+		// Ignore solid entry points (snapshot milestone included)
+		nil,
+		true, false, false, nil)
+	if err != nil {
+		return false
+	}
+
+	for _, txHash := range coneTxHashes {
+		if p.Disconnected {
+			return false
+		}
+
+		cachedTx := tangle.GetCachedTransactionOrNil(txHash) // tx +1
+		if cachedTx == nil {
+			return false
+		}
+		transactionMsg, _ := sting.NewTransactionMessage(cachedTx.GetTransaction().RawBytes)
+		cachedTx.Release(true) // tx -1
+
+		if !p.EnqueueForSendingTimeout(transactionMsg, ConeRequestSendTimeout) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // processes the given transaction request by parsing it and then replying to the peer with it.
 func (proc *Processor) processTransactionRequest(p *peer.Peer, data []byte) {
 	if len(data) != 49 {
+		metrics.SharedServerMetrics.InvalidRequests.Inc()
+
+		// lower the reputation of the peer
+		proc.pm.Penalize(p, peering.MisbehaviorProtocolViolation)
 		return
 	}
 
@@ -241,10 +412,24 @@ func (proc *Processor) processTransactionRequest(p *peer.Peer, data []byte) {
 	}
 	defer cachedTx.Release()
 
+	if !proc.allowReply(cachedTx.GetTransaction()) {
+		return
+	}
+
 	transactionMsg, _ := sting.NewTransactionMessage(cachedTx.GetTransaction().RawBytes)
 	p.EnqueueForSending(transactionMsg)
 }
 
+// checks whether the reply with the given transaction may be sent.
+// replies to stale requests, i.e. requests of transactions which are no longer broadcasted, are throttled by the
+// bandwidth budget. replies to requests of recent transactions are needed by the neighbors to stay in sync.
+func (proc *Processor) allowReply(tx *hornet.Transaction) bool {
+	if tx.GetTimestamp() >= time.Now().Add(-10*time.Minute).Unix() {
+		return true
+	}
+	return proc.pm.Bandwidth().Allow(bandwidth.ClassReply)
+}
+
 // gets or creates a new WorkUnit for the given transaction and then processes the WorkUnit.
 func (proc *Processor) processTransaction(p *peer.Peer, data []byte) {
 	cachedWorkUnit := proc.workUnitFor(data) // workUnit +1
@@ -270,8 +455,8 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 
 		metrics.SharedServerMetrics.InvalidTransactions.Inc()
 
-		// drop the connection to the peer
-		proc.pm.Remove(p.ID)
+		// lower the reputation of the peer
+		proc.pm.Penalize(p, peering.MisbehaviorInvalidTransaction)
 
 		return
 	case wu.Is(Hashed):
@@ -306,14 +491,14 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 	tx, err := compressed.TransactionFromCompressedBytes(wu.receivedTxBytes)
 	if err != nil {
 		wu.UpdateState(Invalid)
-		wu.punish()
+		wu.punish(proc.pm)
 		return
 	}
 
 	// validate minimum weight magnitude requirement
 	if !transaction.HasValidNonce(tx, proc.opts.ValidMWM) {
 		wu.UpdateState(Invalid)
-		wu.punish()
+		wu.punish(proc.pm)
 		return
 	}
 
@@ -349,7 +534,7 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 
 	// broadcast the transaction if it wasn't requested and the timestamp is
 	// within what we consider a sensible delta from now
-	if request == nil && broadcast && !containsTx {
+	if request == nil && broadcast && !containsTx && proc.pm.Bandwidth().Allow(bandwidth.ClassRelay) {
 		proc.Events.BroadcastTransaction.Trigger(wu.broadcast())
 	}
 }
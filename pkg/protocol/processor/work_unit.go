@@ -6,9 +6,9 @@ import (
 
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/protocol/bqueue"
-	"github.com/gohornet/hornet/plugins/peering"
 )
 
 // WorkUnitState defines the state which a WorkUnit is in.
@@ -105,15 +105,15 @@ func (wu *WorkUnit) addReceivedFrom(p *peer.Peer, requestedTxHash hornet.Hash) {
 
 // punishes, respectively increases the invalid transaction metric of all peers
 // which sent the given underlying transaction of this WorkUnit.
-// it also closes the connection to these peers.
-func (wu *WorkUnit) punish() {
+// it also lowers the reputation of these peers.
+func (wu *WorkUnit) punish(pm *peering.Manager) {
 	wu.receivedFromLock.Lock()
 	defer wu.receivedFromLock.Unlock()
 	for _, p := range wu.receivedFrom {
 		metrics.SharedServerMetrics.InvalidTransactions.Inc()
 
-		// drop the connection to the peer
-		peering.Manager().Remove(p.ID)
+		// lower the reputation of the peer
+		pm.Penalize(p, peering.MisbehaviorInvalidTransaction)
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/syncutils"
 
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
 	"github.com/gohornet/hornet/pkg/protocol/handshake"
 	"github.com/gohornet/hornet/pkg/protocol/message"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
@@ -35,6 +36,9 @@ var (
 
 	// supported protocol messages/feature sets
 	SupportedFeatureSets = bitset.From([]uint64{sting.FeatureSet})
+
+	// supported capabilities which are announced in addition to the protocol version
+	SupportedCapabilities byte = bulksync.Capability
 )
 
 var (
@@ -80,6 +84,9 @@ type Protocol struct {
 	// The protocol features this instance supports.
 	// This variable is only usable after protocol handshake.
 	FeatureSet byte
+	// The capabilities supported by both this node and the peer.
+	// This variable is only usable after protocol handshake.
+	Capabilities byte
 	// Holds events for sent and received messages, handshake completion and generic errors.
 	Events Events
 	// the underlying connection
@@ -134,12 +141,20 @@ func (p *Protocol) Supports(featureSet byte) bool {
 	return p.FeatureSet&featureSet > 0
 }
 
-// SupportedFeatureSets returns a slice of named supported feature sets.
+// HasCapability tells whether the protocol supports the given capability.
+func (p *Protocol) HasCapability(capability byte) bool {
+	return p.Capabilities&capability > 0
+}
+
+// SupportedFeatureSets returns a slice of named supported feature sets and capabilities.
 func (p *Protocol) SupportedFeatureSets() []string {
 	var features []string
 	if p.Supports(sting.FeatureSet) {
 		features = append(features, sting.FeatureSetName)
 	}
+	if p.HasCapability(bulksync.Capability) {
+		features = append(features, bulksync.CapabilityName)
+	}
 	return features
 }
 
@@ -147,7 +162,7 @@ func (p *Protocol) SupportedFeatureSets() []string {
 // the connection.
 func (p *Protocol) Start() {
 	// kick off protocol by sending a handshake message
-	handshakeMsg, err := handshake.NewHandshakeMessage(SupportedFeatureSets, ownSrvSocketPort, ownByteEncodedCooAddress, byte(ownMWM))
+	handshakeMsg, err := handshake.NewHandshakeMessage(SupportedFeatureSets, SupportedCapabilities, ownSrvSocketPort, ownByteEncodedCooAddress, byte(ownMWM))
 	if err != nil {
 		fmt.Println("creating handshake message error: ", err)
 		_ = p.conn.Close()
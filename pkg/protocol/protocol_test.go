@@ -1,15 +1,20 @@
 package protocol_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"io"
 	"sync"
 	"testing"
 
 	"github.com/gohornet/hornet/pkg/protocol"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
 	"github.com/gohornet/hornet/pkg/protocol/handshake"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
+	"github.com/gohornet/hornet/pkg/protocol/tlv"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/willf/bitset"
 )
 
 type fakeconn struct {
@@ -79,7 +84,7 @@ func TestProtocol_Receive(t *testing.T) {
 		handshakeMessageReceived = true
 	}))
 
-	handshakeMsg, err := handshake.NewHandshakeMessage(protocol.SupportedFeatureSets, 100, make([]byte, 49), 14)
+	handshakeMsg, err := handshake.NewHandshakeMessage(protocol.SupportedFeatureSets, protocol.SupportedCapabilities, 100, make([]byte, 49), 14)
 	assert.NoError(t, err)
 
 	wg := consume(t, p, conn, len(handshakeMsg))
@@ -100,7 +105,7 @@ func TestProtocol_Send(t *testing.T) {
 		handshakeMessageSent = true
 	}))
 
-	handshakeMsg, err := handshake.NewHandshakeMessage(protocol.SupportedFeatureSets, 100, make([]byte, 49), 14)
+	handshakeMsg, err := handshake.NewHandshakeMessage(protocol.SupportedFeatureSets, protocol.SupportedCapabilities, 100, make([]byte, 49), 14)
 	assert.NoError(t, err)
 
 	wg := consume(t, p, conn, len(handshakeMsg))
@@ -116,3 +121,62 @@ func TestProtocol_Supports(t *testing.T) {
 	assert.True(t, p.Supports(sting.FeatureSet))
 	assert.False(t, p.Supports(243))
 }
+
+// baselineHandshakeMessage creates a handshake message like nodes do which don't know about capabilities.
+func baselineHandshakeMessage(t *testing.T, ownSupportedMessagesBitset *bitset.BitSet) []byte {
+	supportedMessageTypes, err := ownSupportedMessagesBitset.MarshalBinary()
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, tlv.WriteHeader(buf, handshake.MessageTypeHandshake, 60+uint16(len(supportedMessageTypes))))
+	assert.NoError(t, binary.Write(buf, binary.BigEndian, uint16(100)))
+	assert.NoError(t, binary.Write(buf, binary.BigEndian, uint64(0)))
+	assert.NoError(t, binary.Write(buf, binary.BigEndian, make([]byte, handshake.ByteEncodedCooAddressBytesLength)))
+	assert.NoError(t, binary.Write(buf, binary.BigEndian, byte(14)))
+	assert.NoError(t, binary.Write(buf, binary.BigEndian, supportedMessageTypes))
+	return buf.Bytes()
+}
+
+// baselineParseHandshake parses a handshake message like nodes do which don't know about capabilities.
+func baselineParseHandshake(t *testing.T, msg []byte) *handshake.Handshake {
+	header, err := tlv.ParseHeader(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, int(header.MessageBytesLength), len(msg)-3)
+
+	// the port, the timestamp, the coo address and the MWM precede the supported versions
+	return &handshake.Handshake{SupportedVersions: msg[3+60 : 3+60+8]}
+}
+
+func TestHandshake_MixedVersions(t *testing.T) {
+	baselineFeatureSets := bitset.From([]uint64{sting.FeatureSet})
+
+	upgradedMsg, err := handshake.NewHandshakeMessage(protocol.SupportedFeatureSets, protocol.SupportedCapabilities, 100, make([]byte, 49), 14)
+	assert.NoError(t, err)
+	baselineMsg := baselineHandshakeMessage(t, baselineFeatureSets)
+
+	// the baseline node negotiates STING with the upgraded node
+	version, err := baselineParseHandshake(t, upgradedMsg).SupportedVersion(baselineFeatureSets)
+	assert.NoError(t, err)
+	baselinePeer := &protocol.Protocol{FeatureSet: byte(version)}
+	assert.True(t, baselinePeer.Supports(sting.FeatureSet))
+
+	// the upgraded node negotiates STING with the baseline node, but no capabilities
+	hs, err := handshake.ParseHandshake(baselineMsg[3:])
+	assert.NoError(t, err)
+	version, err = hs.SupportedVersion(protocol.SupportedFeatureSets)
+	assert.NoError(t, err)
+	upgradedPeer := &protocol.Protocol{FeatureSet: byte(version), Capabilities: hs.Capabilities & protocol.SupportedCapabilities}
+	assert.True(t, upgradedPeer.Supports(sting.FeatureSet))
+	assert.False(t, upgradedPeer.HasCapability(bulksync.Capability))
+	assert.Equal(t, baselinePeer.FeatureSet, upgradedPeer.FeatureSet)
+
+	// two upgraded nodes negotiate the same protocol version and the bulk sync capability
+	hs, err = handshake.ParseHandshake(upgradedMsg[3:])
+	assert.NoError(t, err)
+	version, err = hs.SupportedVersion(protocol.SupportedFeatureSets)
+	assert.NoError(t, err)
+	upgradedPeer = &protocol.Protocol{FeatureSet: byte(version), Capabilities: hs.Capabilities & protocol.SupportedCapabilities}
+	assert.Equal(t, baselinePeer.FeatureSet, upgradedPeer.FeatureSet)
+	assert.True(t, upgradedPeer.HasCapability(bulksync.Capability))
+	assert.Equal(t, []string{sting.FeatureSetName, bulksync.CapabilityName}, upgradedPeer.SupportedFeatureSets())
+}
@@ -72,7 +72,7 @@ func ParseHeader(buf []byte) (*Header, error) {
 	advMsgBytesLength := binary.BigEndian.Uint16(buf[1:3])
 
 	if (advMsgBytesLength > def.MaxBytesLength) || (!def.VariableLength && (advMsgBytesLength < def.MaxBytesLength)) {
-		return nil, fmt.Errorf("%s: advertised length: %d bytes; max length: %d bytes", ErrInvalidMessageLength.Error(), advMsgBytesLength, def.MaxBytesLength)
+		return nil, fmt.Errorf("%w: advertised length: %d bytes; max length: %d bytes", ErrInvalidMessageLength, advMsgBytesLength, def.MaxBytesLength)
 	}
 
 	return &Header{Definition: def, MessageBytesLength: advMsgBytesLength}, nil
@@ -0,0 +1,207 @@
+// Package proxy routes outbound connections of the node through a SOCKS5 or HTTP proxy.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	netproxy "golang.org/x/net/proxy"
+)
+
+var (
+	// ErrUnsupportedScheme is returned if the scheme of the proxy URL is not supported.
+	ErrUnsupportedScheme = errors.New("unsupported proxy scheme")
+	// ErrConnectFailed is returned if the HTTP proxy refused to open a tunnel.
+	ErrConnectFailed = errors.New("proxy CONNECT failed")
+
+	// the dialer which is used by the package level functions
+	defaultDialer = &Dialer{}
+)
+
+// Dialer opens outbound connections either directly or through a proxy.
+type Dialer struct {
+	// the URL of the proxy, nil if connections are opened directly
+	proxyURL *url.URL
+}
+
+// New creates a dialer for the proxy with the given URL.
+// Supported schemes are "socks5", "socks5h", "http" and "https". Connections are opened directly if the URL is empty.
+func New(proxyURL string) (*Dialer, error) {
+	if proxyURL == "" {
+		return &Dialer{}, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h", "http", "https":
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL: missing host")
+	}
+
+	return &Dialer{proxyURL: u}, nil
+}
+
+// Configure sets the proxy which is used for all outbound connections of the node.
+func Configure(proxyURL string) error {
+	d, err := New(proxyURL)
+	if err != nil {
+		return err
+	}
+	defaultDialer = d
+	return nil
+}
+
+// Direct tells whether the outbound connections of the node are opened directly without a proxy.
+func Direct() bool {
+	return defaultDialer.proxyURL == nil
+}
+
+// DialTimeout connects to the given address through the configured proxy.
+func DialTimeout(network string, addr string, timeout time.Duration) (net.Conn, error) {
+	return defaultDialer.DialTimeout(network, addr, timeout)
+}
+
+// HTTPClient returns an HTTP client which sends its requests through the configured proxy.
+func HTTPClient(timeout time.Duration) *http.Client {
+	return defaultDialer.HTTPClient(timeout)
+}
+
+// DialTimeout connects to the given address through the proxy.
+// The timeout includes the connection to the proxy and the handshake with it.
+func (d *Dialer) DialTimeout(network string, addr string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, addr)
+}
+
+// DialContext connects to the given address through the proxy.
+func (d *Dialer) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	if d.proxyURL == nil {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	switch d.proxyURL.Scheme {
+	case "socks5", "socks5h":
+		return d.dialSOCKS5(ctx, network, addr)
+	default:
+		return d.dialHTTPConnect(ctx, addr)
+	}
+}
+
+// HTTPClient returns an HTTP client which sends its requests through the proxy.
+func (d *Dialer) HTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if d.proxyURL != nil {
+		switch d.proxyURL.Scheme {
+		case "socks5", "socks5h":
+			transport.Proxy = nil
+			transport.DialContext = d.DialContext
+		default:
+			transport.Proxy = http.ProxyURL(d.proxyURL)
+		}
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// dialSOCKS5 connects to the given address through the SOCKS5 proxy.
+// Host names are resolved by the proxy.
+func (d *Dialer) dialSOCKS5(ctx context.Context, network string, addr string) (net.Conn, error) {
+	var auth *netproxy.Auth
+	if d.proxyURL.User != nil {
+		password, _ := d.proxyURL.User.Password()
+		auth = &netproxy.Auth{User: d.proxyURL.User.Username(), Password: password}
+	}
+
+	socks, err := netproxy.SOCKS5("tcp", d.proxyURL.Host, auth, &net.Dialer{})
+	if err != nil {
+		return nil, err
+	}
+	return socks.(netproxy.ContextDialer).DialContext(ctx, network, addr)
+}
+
+// dialHTTPConnect opens a tunnel to the given address with a CONNECT request to the HTTP proxy.
+func (d *Dialer) dialHTTPConnect(ctx context.Context, addr string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", d.proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// abort the handshake with the proxy if the context is done
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if d.proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: d.proxyURL.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if d.proxyURL.User != nil {
+		password, _ := d.proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: %s", ErrConnectFailed, res.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	// the remote side may already have sent data which was buffered while reading the response
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn is a connection which first returns the data of its buffered reader.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
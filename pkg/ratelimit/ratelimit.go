@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+type keyedLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// KeyedLimiter is a token bucket rate limiter which keeps a separate bucket per key (e.g. per IP address).
+type KeyedLimiter struct {
+	syncutils.Mutex
+
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*keyedLimiterEntry
+}
+
+// NewKeyedLimiter creates a new KeyedLimiter which allows eventsPerSecond events
+// per key with bursts of at most burst events.
+func NewKeyedLimiter(eventsPerSecond float64, burst int) *KeyedLimiter {
+	return &KeyedLimiter{
+		limit:    rate.Limit(eventsPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*keyedLimiterEntry),
+	}
+}
+
+// Allow reports whether an event for the given key may happen now.
+func (l *KeyedLimiter) Allow(key string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	entry, exists := l.limiters[key]
+	if !exists {
+		entry = &keyedLimiterEntry{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = time.Now()
+
+	return entry.limiter.Allow()
+}
+
+// SetLimit changes the allowed events per second and the burst of all keys.
+// The buckets of all keys are reset.
+func (l *KeyedLimiter) SetLimit(eventsPerSecond float64, burst int) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.limit = rate.Limit(eventsPerSecond)
+	l.burst = burst
+	l.limiters = make(map[string]*keyedLimiterEntry)
+}
+
+// Cleanup removes the buckets of all keys which were not seen for at least maxIdle.
+func (l *KeyedLimiter) Cleanup(maxIdle time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	for key, entry := range l.limiters {
+		if time.Since(entry.lastSeen) >= maxIdle {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// Size returns the amount of tracked keys.
+func (l *KeyedLimiter) Size() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return len(l.limiters)
+}
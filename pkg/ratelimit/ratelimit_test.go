@@ -0,0 +1,46 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/ratelimit"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	limiter := ratelimit.NewKeyedLimiter(1, 2)
+
+	// the burst is available per key
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+
+	assert.True(t, limiter.Allow("b"))
+	assert.Equal(t, 2, limiter.Size())
+
+	limiter.Cleanup(time.Hour)
+	assert.Equal(t, 2, limiter.Size())
+
+	limiter.Cleanup(0)
+	assert.Equal(t, 0, limiter.Size())
+
+	// a dropped key starts with a full bucket again
+	assert.True(t, limiter.Allow("a"))
+}
+
+func TestKeyedLimiterSetLimit(t *testing.T) {
+	limiter := ratelimit.NewKeyedLimiter(1, 1)
+
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+
+	// the buckets are reset with the new burst
+	limiter.SetLimit(1, 3)
+	assert.Equal(t, 0, limiter.Size())
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+}
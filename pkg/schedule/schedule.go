@@ -0,0 +1,244 @@
+// Package schedule implements schedules of cron expressions and times of day.
+//
+// A cron expression consists of the five fields minute, hour, day of month, month and day of week,
+// e.g. "30 2 * * 6,0" for 02:30 on weekends. Every field accepts "*", single values, ranges ("1-5"),
+// lists ("1,15") and steps ("*/15", "0-30/10"). The day of week is 0-7, where 0 and 7 are Sunday.
+// If the day of month and the day of week are both restricted, a day matches if either of them matches.
+// A time of day is given as "HH:MM", e.g. "03:00" for every day at 03:00.
+// All times are in the local time zone of the node.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSchedule is returned if a schedule entry can't be parsed.
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+const (
+	// the maximum amount of years searched for the next time of an entry, e.g. "0 0 29 2 1" may take decades to match
+	maxSearchYears = 30
+)
+
+// the bounds of the fields of a cron expression
+var fieldBounds = []struct {
+	name string
+	min  int
+	max  int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a list of cron expressions and times of day.
+type Schedule struct {
+	specs   []string
+	entries []*entry
+}
+
+// entry is a parsed cron expression, every field is a bit set of the matching values.
+type entry struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// whether the day of month or the day of week is "*"
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// Parse parses the given cron expressions and times of day. Empty entries are ignored.
+func Parse(specs []string) (*Schedule, error) {
+	s := &Schedule{}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		e, err := parseEntry(spec)
+		if err != nil {
+			return nil, err
+		}
+		s.specs = append(s.specs, spec)
+		s.entries = append(s.entries, e)
+	}
+	return s, nil
+}
+
+// IsEmpty returns whether the schedule doesn't contain any entries.
+func (s *Schedule) IsEmpty() bool {
+	return len(s.entries) == 0
+}
+
+// String returns the entries of the schedule.
+func (s *Schedule) String() string {
+	return strings.Join(s.specs, ", ")
+}
+
+// Next returns the first time of the schedule after the given time, which is truncated to full minutes.
+// The zero time is returned if the schedule is empty or never matches.
+func (s *Schedule) Next(after time.Time) time.Time {
+	var next time.Time
+	for _, e := range s.entries {
+		if t := e.next(after); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+func parseEntry(spec string) (*entry, error) {
+	if strings.Contains(spec, ":") {
+		return parseTimeOfDay(spec)
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("%w '%s': expected a time of day (HH:MM) or a cron expression with %d fields", ErrInvalidSchedule, spec, len(fieldBounds))
+	}
+
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		bits, err := parseField(field, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("%w '%s': the %s %v", ErrInvalidSchedule, spec, fieldBounds[i].name, err)
+		}
+		values[i] = bits
+	}
+
+	// Sunday is 0 and 7
+	dayOfWeek := values[4]
+	if dayOfWeek&(1<<7) != 0 {
+		dayOfWeek |= 1
+	}
+
+	return &entry{
+		minute:        values[0],
+		hour:          values[1],
+		dayOfMonth:    values[2],
+		month:         values[3],
+		dayOfWeek:     dayOfWeek,
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+func parseTimeOfDay(spec string) (*entry, error) {
+	t, err := time.Parse("15:04", spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': expected a time of day (HH:MM) or a cron expression", ErrInvalidSchedule, spec)
+	}
+
+	return &entry{
+		minute:        1 << uint(t.Minute()),
+		hour:          1 << uint(t.Hour()),
+		dayOfMonth:    bitRange(1, 31, 1),
+		month:         bitRange(1, 12, 1),
+		dayOfWeek:     bitRange(0, 7, 1),
+		anyDayOfMonth: true,
+		anyDayOfWeek:  true,
+	}, nil
+}
+
+// parseField parses a field of a cron expression into a bit set of the matching values.
+func parseField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("has an invalid step in '%s'", part)
+			}
+			rangePart = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("has an invalid range '%s'", part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("has an invalid value '%s'", part)
+			}
+			start, end = value, value
+			if strings.Contains(part, "/") {
+				// "5/15" means from 5 to the maximum in steps of 15
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("'%s' is out of range, it must be between %d and %d", part, min, max)
+		}
+		bits |= bitRange(start, end, step)
+	}
+	return bits, nil
+}
+
+func bitRange(start int, end int, step int) uint64 {
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+func (e *entry) matchesDay(t time.Time) bool {
+	dayOfMonth := has(e.dayOfMonth, t.Day())
+	dayOfWeek := has(e.dayOfWeek, int(t.Weekday()))
+	if e.anyDayOfMonth || e.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+func (e *entry) next(after time.Time) time.Time {
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, after.Location())
+	maxYear := t.Year() + maxSearchYears
+
+	for t.Year() <= maxYear {
+		if !has(e.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !e.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(e.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(e.minute, t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
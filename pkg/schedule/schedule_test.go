@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestNext(t *testing.T) {
+	// Thursday
+	now := time.Date(2020, time.October, 15, 14, 20, 33, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"03:00", date(2020, time.October, 16, 3, 0)},
+		{"14:21", date(2020, time.October, 15, 14, 21)},
+		{"14:20", date(2020, time.October, 16, 14, 20)},
+		{"*/15 * * * *", date(2020, time.October, 15, 14, 30)},
+		{"0 */6 * * *", date(2020, time.October, 15, 18, 0)},
+		{"30 2 * * 6,0", date(2020, time.October, 17, 2, 30)},
+		{"0 0 * * 7", date(2020, time.October, 18, 0, 0)},
+		{"0 4 1 * *", date(2020, time.November, 1, 4, 0)},
+		{"0 1-3 * * 1-5", date(2020, time.October, 16, 1, 0)},
+		{"5/20 14 * * *", date(2020, time.October, 15, 14, 25)},
+		{"0 0 31 * *", date(2020, time.October, 31, 0, 0)},
+		{"0 0 30 2 *", time.Time{}},
+		// the day of month or the day of week has to match
+		{"0 12 1 * 5", date(2020, time.October, 16, 12, 0)},
+	}
+
+	for _, test := range tests {
+		s, err := Parse([]string{test.spec})
+		require.NoError(t, err, test.spec)
+		assert.Equal(t, test.next, s.Next(now), test.spec)
+	}
+}
+
+func TestNextMultipleEntries(t *testing.T) {
+	s, err := Parse([]string{"23:00", "", "0 12 * * *"})
+	require.NoError(t, err)
+	assert.False(t, s.IsEmpty())
+	assert.Equal(t, "23:00, 0 12 * * *", s.String())
+
+	assert.Equal(t, date(2020, time.October, 15, 12, 0), s.Next(date(2020, time.October, 15, 8, 0)))
+	assert.Equal(t, date(2020, time.October, 15, 23, 0), s.Next(date(2020, time.October, 15, 12, 0)))
+
+	empty, err := Parse(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+	assert.True(t, empty.Next(time.Now()).IsZero())
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"24:00",
+		"3pm",
+		"* * * *",
+		"60 * * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := Parse([]string{spec})
+		assert.True(t, errors.Is(err, ErrInvalidSchedule), spec)
+	}
+}
@@ -0,0 +1,20 @@
+package shutdown
+
+import (
+	"go.uber.org/atomic"
+)
+
+var (
+	requested atomic.Bool
+)
+
+// SetRequested marks that the shutdown of the node was requested.
+func SetRequested() {
+	requested.Store(true)
+}
+
+// Requested returns whether the shutdown of the node was requested.
+// The node doesn't report to be ready anymore once the shutdown was requested.
+func Requested() bool {
+	return requested.Load()
+}
@@ -2,6 +2,8 @@ package shutdown
 
 const (
 	PriorityCloseDatabase = iota
+	PriorityDatabaseCompaction
+	PriorityDatabaseBackup
 	PriorityFlushToDatabase
 	PriorityRequestsProcessor
 	PriorityTipselection
@@ -10,6 +12,8 @@ const (
 	PrioritySolidifierGossip
 	PriorityReceiveTxWorker
 	PriorityBroadcastQueue
+	PriorityPendingBroadcasts
+	PriorityBandwidthAccounting
 	PriorityMessageProcessor
 	PriorityPeerSendQueue
 	PriorityPeeringTCPServer
@@ -22,10 +26,15 @@ const (
 	PriorityPoWHandler
 	PriorityAPI
 	PriorityMetricsPublishers
+	PriorityExternalPlugins
 	PrioritySpammer
+	PriorityFaucet
 	PriorityStatusReport
 	PriorityAutopeering
 	PriorityCoordinator
 	PriorityUpdateCheck
+	PriorityConfigReload
+	PrioritySystemd
 	PriorityPrometheus
+	PriorityContinuousProfiling
 )
@@ -0,0 +1,293 @@
+// Package subscription keeps the events which match the addresses and tags of registered subscriptions,
+// so that clients are able to fetch the events they missed while they were disconnected.
+package subscription
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+var (
+	// ErrTooManySubscriptions is returned if the maximum number of subscriptions is reached.
+	ErrTooManySubscriptions = errors.New("maximum number of subscriptions reached")
+	// ErrTooManyFilters is returned if a subscription has more addresses and tags than allowed.
+	ErrTooManyFilters = errors.New("too many filters")
+	// ErrNoFilters is returned if a subscription has neither addresses nor tags.
+	ErrNoFilters = errors.New("no addresses or tags given")
+	// ErrSubscriptionNotFound is returned if there is no subscription with the given ID.
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+)
+
+// Event is an event which matched a subscription.
+type Event struct {
+	// The position of the event in the events of the subscription.
+	Cursor uint64 `json:"cursor"`
+	// The topic of the event.
+	Topic string `json:"topic"`
+	// The event specific data.
+	Data interface{} `json:"data"`
+}
+
+// Subscription keeps the latest events which matched its addresses or tags.
+type Subscription struct {
+	syncutils.Mutex
+
+	id        string
+	addresses []string
+	tags      []string
+
+	// the retained events, ordered by their cursor
+	events     []*Event
+	maxEvents  int
+	nextCursor uint64
+	lastSeen   time.Time
+}
+
+// ID returns the ID of the subscription.
+func (s *Subscription) ID() string {
+	return s.id
+}
+
+// Cursor returns the cursor of the next event of the subscription.
+func (s *Subscription) Cursor() uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.nextCursor
+}
+
+func (s *Subscription) add(topic string, data interface{}) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.events = append(s.events, &Event{Cursor: s.nextCursor, Topic: topic, Data: data})
+	s.nextCursor++
+
+	if len(s.events) > s.maxEvents {
+		// the oldest event is dropped
+		s.events[0] = nil
+		s.events = s.events[1:]
+	}
+}
+
+// Events returns at most limit events starting at the given cursor and the cursor to fetch the following events.
+// missed tells whether events after the given cursor were already dropped, because the subscription
+// retains only a limited amount of events.
+func (s *Subscription) Events(cursor uint64, limit int) (events []*Event, next uint64, missed bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.lastSeen = time.Now()
+
+	if cursor >= s.nextCursor {
+		return nil, s.nextCursor, false
+	}
+
+	// cursors start at 1, so there are no events below the first cursor which could have been dropped
+	oldest := s.nextCursor
+	if len(s.events) > 0 {
+		oldest = s.events[0].Cursor
+	}
+
+	start := 0
+	switch {
+	case cursor >= oldest:
+		start = int(cursor - oldest)
+	case oldest > 1:
+		missed = true
+	}
+
+	end := len(s.events)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	events = make([]*Event, end-start)
+	copy(events, s.events[start:end])
+
+	next = s.nextCursor
+	if end < len(s.events) {
+		next = s.events[end].Cursor
+	}
+
+	return events, next, missed
+}
+
+// Manager manages the subscriptions and dispatches events to them.
+type Manager struct {
+	syncutils.RWMutex
+
+	maxSubscriptions int
+	maxFilters       int
+	maxEvents        int
+
+	subscriptions map[string]*Subscription
+	byAddress     map[string]map[*Subscription]struct{}
+	byTag         map[string]map[*Subscription]struct{}
+}
+
+// NewManager creates a new Manager which allows maxSubscriptions subscriptions with maxFilters addresses and tags each.
+// Every subscription retains at most maxEvents events.
+func NewManager(maxSubscriptions int, maxFilters int, maxEvents int) *Manager {
+	return &Manager{
+		maxSubscriptions: maxSubscriptions,
+		maxFilters:       maxFilters,
+		maxEvents:        maxEvents,
+		subscriptions:    make(map[string]*Subscription),
+		byAddress:        make(map[string]map[*Subscription]struct{}),
+		byTag:            make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Register creates a new subscription for the events of the given addresses and tags.
+func (m *Manager) Register(addresses []string, tags []string) (*Subscription, error) {
+	if len(addresses) == 0 && len(tags) == 0 {
+		return nil, ErrNoFilters
+	}
+
+	if len(addresses)+len(tags) > m.maxFilters {
+		return nil, ErrTooManyFilters
+	}
+
+	id, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Subscription{
+		id:         id,
+		addresses:  addresses,
+		tags:       tags,
+		maxEvents:  m.maxEvents,
+		nextCursor: 1,
+		lastSeen:   time.Now(),
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if len(m.subscriptions) >= m.maxSubscriptions {
+		return nil, ErrTooManySubscriptions
+	}
+
+	m.subscriptions[id] = s
+	for _, addr := range addresses {
+		index(m.byAddress, addr, s)
+	}
+	for _, tag := range tags {
+		index(m.byTag, tag, s)
+	}
+
+	return s, nil
+}
+
+// Get returns the subscription with the given ID.
+func (m *Manager) Get(id string) (*Subscription, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	s, exists := m.subscriptions[id]
+	if !exists {
+		return nil, ErrSubscriptionNotFound
+	}
+	return s, nil
+}
+
+// Remove removes the subscription with the given ID.
+func (m *Manager) Remove(id string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	s, exists := m.subscriptions[id]
+	if !exists {
+		return ErrSubscriptionNotFound
+	}
+	m.remove(s)
+	return nil
+}
+
+// Cleanup removes all subscriptions whose events were not fetched for at least maxIdle.
+func (m *Manager) Cleanup(maxIdle time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, s := range m.subscriptions {
+		s.Lock()
+		idle := time.Since(s.lastSeen) >= maxIdle
+		s.Unlock()
+
+		if idle {
+			m.remove(s)
+		}
+	}
+}
+
+// Size returns the amount of subscriptions.
+func (m *Manager) Size() int {
+	m.RLock()
+	defer m.RUnlock()
+
+	return len(m.subscriptions)
+}
+
+// Publish adds the event to all subscriptions which are interested in the given address or tag.
+func (m *Manager) Publish(topic string, address string, tag string, data interface{}) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for s := range m.byAddress[address] {
+		s.add(topic, data)
+	}
+
+	for s := range m.byTag[tag] {
+		// the event was already added to subscriptions which match the address as well
+		if _, matchedAddress := m.byAddress[address][s]; matchedAddress {
+			continue
+		}
+		s.add(topic, data)
+	}
+}
+
+func (m *Manager) remove(s *Subscription) {
+	delete(m.subscriptions, s.id)
+	for _, addr := range s.addresses {
+		unindex(m.byAddress, addr, s)
+	}
+	for _, tag := range s.tags {
+		unindex(m.byTag, tag, s)
+	}
+}
+
+func index(indexMap map[string]map[*Subscription]struct{}, key string, s *Subscription) {
+	subscriptions, exists := indexMap[key]
+	if !exists {
+		subscriptions = make(map[*Subscription]struct{})
+		indexMap[key] = subscriptions
+	}
+	subscriptions[s] = struct{}{}
+}
+
+func unindex(indexMap map[string]map[*Subscription]struct{}, key string, s *Subscription) {
+	subscriptions, exists := indexMap[key]
+	if !exists {
+		return
+	}
+	delete(subscriptions, s)
+	if len(subscriptions) == 0 {
+		delete(indexMap, key)
+	}
+}
+
+// randomID creates a random ID for a subscription.
+// The ID is the only secret which protects the events of a subscription, so it must not be guessable.
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,138 @@
+package subscription_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/subscription"
+)
+
+func TestManager_Register(t *testing.T) {
+	manager := subscription.NewManager(1, 2, 10)
+
+	_, err := manager.Register(nil, nil)
+	assert.Equal(t, subscription.ErrNoFilters, err)
+
+	_, err = manager.Register([]string{"A", "B"}, []string{"C"})
+	assert.Equal(t, subscription.ErrTooManyFilters, err)
+
+	s, err := manager.Register([]string{"A"}, []string{"C"})
+	require.NoError(t, err)
+	assert.Len(t, s.ID(), 32)
+	assert.Equal(t, uint64(1), s.Cursor())
+
+	_, err = manager.Register([]string{"B"}, nil)
+	assert.Equal(t, subscription.ErrTooManySubscriptions, err)
+
+	found, err := manager.Get(s.ID())
+	require.NoError(t, err)
+	assert.Equal(t, s, found)
+
+	require.NoError(t, manager.Remove(s.ID()))
+	assert.Equal(t, subscription.ErrSubscriptionNotFound, manager.Remove(s.ID()))
+	_, err = manager.Get(s.ID())
+	assert.Equal(t, subscription.ErrSubscriptionNotFound, err)
+}
+
+func TestManager_Publish(t *testing.T) {
+	manager := subscription.NewManager(10, 10, 10)
+
+	byAddress, err := manager.Register([]string{"A"}, nil)
+	require.NoError(t, err)
+	byAddressAndTag, err := manager.Register([]string{"A"}, []string{"T"})
+	require.NoError(t, err)
+
+	manager.Publish("confirmed", "A", "T", 1)
+	manager.Publish("confirmed", "B", "T", 2)
+	manager.Publish("confirmed", "B", "U", 3)
+
+	events, next, missed := byAddress.Events(1, 0)
+	assert.False(t, missed)
+	assert.Equal(t, uint64(2), next)
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, events[0].Data)
+
+	// the first event matched the address and the tag, but is only added once
+	events, next, missed = byAddressAndTag.Events(1, 0)
+	assert.False(t, missed)
+	assert.Equal(t, uint64(3), next)
+	require.Len(t, events, 2)
+	assert.Equal(t, 1, events[0].Data)
+	assert.Equal(t, 2, events[1].Data)
+	assert.Equal(t, "confirmed", events[1].Topic)
+
+	// removed subscriptions don't receive events anymore
+	require.NoError(t, manager.Remove(byAddress.ID()))
+	manager.Publish("confirmed", "A", "", 4)
+	assert.Equal(t, uint64(2), byAddress.Cursor())
+	assert.Equal(t, uint64(4), byAddressAndTag.Cursor())
+}
+
+func TestSubscription_Events(t *testing.T) {
+	manager := subscription.NewManager(10, 10, 3)
+
+	s, err := manager.Register([]string{"A"}, nil)
+	require.NoError(t, err)
+
+	events, next, missed := s.Events(1, 0)
+	assert.Empty(t, events)
+	assert.Equal(t, uint64(1), next)
+	assert.False(t, missed)
+
+	manager.Publish("transactions", "A", "", 0)
+
+	// cursor 0 starts at the first event
+	events, next, missed = s.Events(0, 0)
+	assert.Len(t, events, 1)
+	assert.Equal(t, uint64(2), next)
+	assert.False(t, missed)
+
+	for i := 2; i <= 5; i++ {
+		manager.Publish("transactions", "A", "", i)
+	}
+
+	// only the last three events are retained
+	events, next, missed = s.Events(1, 0)
+	assert.True(t, missed)
+	assert.Equal(t, uint64(6), next)
+	require.Len(t, events, 3)
+	assert.Equal(t, uint64(3), events[0].Cursor)
+	assert.Equal(t, 3, events[0].Data)
+
+	// fetch page wise
+	events, next, missed = s.Events(3, 2)
+	assert.False(t, missed)
+	assert.Equal(t, uint64(5), next)
+	require.Len(t, events, 2)
+	assert.Equal(t, 4, events[1].Data)
+
+	events, next, missed = s.Events(next, 2)
+	assert.False(t, missed)
+	assert.Equal(t, uint64(6), next)
+	require.Len(t, events, 1)
+	assert.Equal(t, 5, events[0].Data)
+
+	events, next, _ = s.Events(next, 2)
+	assert.Empty(t, events)
+	assert.Equal(t, uint64(6), next)
+}
+
+func TestManager_Cleanup(t *testing.T) {
+	manager := subscription.NewManager(10, 10, 10)
+
+	s, err := manager.Register([]string{"A"}, nil)
+	require.NoError(t, err)
+
+	manager.Cleanup(time.Hour)
+	assert.Equal(t, 1, manager.Size())
+
+	manager.Cleanup(0)
+	assert.Equal(t, 0, manager.Size())
+
+	// the events of removed subscriptions are not tracked anymore
+	manager.Publish("confirmed", "A", "", 1)
+	assert.Equal(t, uint64(1), s.Cursor())
+}
@@ -0,0 +1,63 @@
+// Package systemd implements the notification protocol of the systemd service manager.
+package systemd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// StateReady tells the service manager that the startup is finished.
+	StateReady = "READY=1"
+	// StateStopping tells the service manager that the shutdown started.
+	StateStopping = "STOPPING=1"
+	// StateWatchdog pets the watchdog of the service manager.
+	StateWatchdog = "WATCHDOG=1"
+	// StateStatus is the prefix of a free-form status text, which is shown by "systemctl status".
+	StateStatus = "STATUS="
+)
+
+// Notify sends the given state to the service manager.
+// It returns false if the node was not started by systemd with notify support.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+
+	// names starting with '@' are abstract sockets, which are handled by the net package
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// WatchdogInterval returns the interval in which the service manager expects the watchdog to be petted.
+// It returns 0 if the watchdog is disabled for this process.
+func WatchdogInterval() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+
+	// the watchdog is only meant for the given process
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	interval, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || interval <= 0 {
+		return 0, err
+	}
+
+	return time.Duration(interval) * time.Microsecond, nil
+}
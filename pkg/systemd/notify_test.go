@@ -0,0 +1,64 @@
+//go:build linux
+// +build linux
+
+package systemd_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/systemd"
+)
+
+func TestNotify(t *testing.T) {
+	os.Unsetenv("NOTIFY_SOCKET")
+	notified, err := systemd.Notify(systemd.StateReady)
+	require.NoError(t, err)
+	assert.False(t, notified)
+
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	os.Setenv("NOTIFY_SOCKET", socketPath)
+	defer os.Unsetenv("NOTIFY_SOCKET")
+
+	notified, err = systemd.Notify(systemd.StateReady)
+	require.NoError(t, err)
+	assert.True(t, notified)
+
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, systemd.StateReady, string(buf[:n]))
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	defer os.Unsetenv("WATCHDOG_USEC")
+	defer os.Unsetenv("WATCHDOG_PID")
+
+	os.Unsetenv("WATCHDOG_USEC")
+	interval, err := systemd.WatchdogInterval()
+	require.NoError(t, err)
+	assert.Zero(t, interval)
+
+	os.Setenv("WATCHDOG_USEC", "30000000")
+	os.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	interval, err = systemd.WatchdogInterval()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, interval)
+
+	// the watchdog of another process
+	os.Setenv("WATCHDOG_PID", "1")
+	interval, err = systemd.WatchdogInterval()
+	require.NoError(t, err)
+	assert.Zero(t, interval)
+}
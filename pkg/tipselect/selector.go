@@ -0,0 +1,23 @@
+package tipselect
+
+import (
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+const (
+	// AlgorithmURTS selects uniformly random tips from the pool of non-lazy tips.
+	AlgorithmURTS = "urts"
+	// AlgorithmWalk selects tips by uniformly random walks from an older milestone towards the tips.
+	AlgorithmWalk = "walk"
+)
+
+// Selector selects the tips new transactions approve.
+type Selector interface {
+	// SelectTips selects two tips.
+	// The depth is the amount of milestones below the LSMI the tip-selection may reach (0 = default of the algorithm).
+	SelectTips(depth int) (hornet.Hashes, error)
+	// TipSelPerformedEvent returns the event which is fired when a tipselection was performed.
+	TipSelPerformedEvent() *events.Event
+}
@@ -348,6 +348,17 @@ func (ts *TipSelector) SelectNonLazyTips() (hornet.Hashes, error) {
 	return ts.selectTips(ts.nonLazyTipsMap)
 }
 
+// SelectTips selects two non-lazy tips.
+// The depth is ignored, because the pool only contains tips within the configured depth.
+func (ts *TipSelector) SelectTips(_ int) (hornet.Hashes, error) {
+	return ts.SelectNonLazyTips()
+}
+
+// TipSelPerformedEvent returns the event which is fired when a tipselection was performed.
+func (ts *TipSelector) TipSelPerformedEvent() *events.Event {
+	return ts.Events.TipSelPerformed
+}
+
 // CleanUpReferencedTips checks if tips were referenced before
 // and removes them if they reached their maximum age.
 func (ts *TipSelector) CleanUpReferencedTips() int {
@@ -0,0 +1,166 @@
+package tipselect
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+var (
+	// ErrDepthTooBig is returned when the requested depth is above the below max depth.
+	ErrDepthTooBig = errors.New("depth too big")
+	// ErrEntryPointNotFound is returned when the milestone to start the walk from is unknown.
+	ErrEntryPointNotFound = errors.New("entry point milestone not found")
+)
+
+// Walker selects tips by uniformly random walks from an older milestone towards the tips.
+// Every step of the walk chooses one of the valid approving bundles with the same probability.
+type Walker struct {
+	// defaultDepth is the amount of milestones below the LSMI the walk starts at if no depth is given.
+	defaultDepth milestone.Index
+	// belowMaxDepth is the maximum allowed delta
+	// value between OTRSI of a given transaction in relation to the current LSMI before it gets lazy.
+	belowMaxDepth milestone.Index
+	// Events are the events that are triggered by the Walker.
+	Events Events
+}
+
+// NewWalker creates a new walk based tip-selector.
+func NewWalker(defaultDepth int, belowMaxDepth int) *Walker {
+	return &Walker{
+		defaultDepth:  milestone.Index(defaultDepth),
+		belowMaxDepth: milestone.Index(belowMaxDepth),
+		Events: Events{
+			TipAdded:        events.NewEvent(TipCaller),
+			TipRemoved:      events.NewEvent(TipCaller),
+			TipSelPerformed: events.NewEvent(WalkerStatsCaller),
+		},
+	}
+}
+
+// TipSelPerformedEvent returns the event which is fired when a tipselection was performed.
+func (w *Walker) TipSelPerformedEvent() *events.Event {
+	return w.Events.TipSelPerformed
+}
+
+// SelectTips selects two tips by walking from the milestone the given depth below the LSMI towards the tips.
+func (w *Walker) SelectTips(depth int) (hornet.Hashes, error) {
+
+	if !tangle.IsNodeSyncedWithThreshold() {
+		return nil, tangle.ErrNodeNotSynced
+	}
+
+	walkDepth := w.defaultDepth
+	if depth > 0 {
+		walkDepth = milestone.Index(depth)
+	}
+
+	if walkDepth > w.belowMaxDepth {
+		return nil, ErrDepthTooBig
+	}
+
+	lsmi := tangle.GetSolidMilestoneIndex()
+
+	entryIndex := milestone.Index(1)
+	if lsmi > walkDepth {
+		entryIndex = lsmi - walkDepth
+	}
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil && entryIndex <= snapshotInfo.PruningIndex {
+		entryIndex = snapshotInfo.PruningIndex + 1
+	}
+
+	cachedMsBndl := tangle.GetMilestoneOrNil(entryIndex) // bundle +1
+	if cachedMsBndl == nil {
+		return nil, ErrEntryPointNotFound
+	}
+	entryPoint := cachedMsBndl.GetBundle().GetTailHash()
+	cachedMsBndl.Release(true) // bundle -1
+
+	trunk := w.walk(entryPoint, lsmi)
+
+	// retry the walk several times if trunk and branch are equal
+	for i := 0; i < 10; i++ {
+		if branch := w.walk(entryPoint, lsmi); !bytes.Equal(trunk, branch) {
+			return hornet.Hashes{trunk, branch}, nil
+		}
+	}
+
+	// no second tip found, use the same again
+	return hornet.Hashes{trunk, trunk}, nil
+}
+
+// walk walks from the given tail transaction towards the tips and returns the tail of the reached tip.
+func (w *Walker) walk(tailTxHash hornet.Hash, lsmi milestone.Index) hornet.Hash {
+
+	// record stats
+	start := time.Now()
+	defer func() {
+		w.Events.TipSelPerformed.Trigger(&TipSelStats{Duration: time.Since(start)})
+	}()
+
+	for {
+		approvers := w.approverTails(tailTxHash, lsmi)
+		if len(approvers) == 0 {
+			return tailTxHash
+		}
+
+		tailTxHash = approvers[utils.RandomInsecure(0, len(approvers)-1)]
+	}
+}
+
+// approverTails returns the tail transactions of the valid bundles which approve the given tail transaction.
+func (w *Walker) approverTails(tailTxHash hornet.Hash, lsmi milestone.Index) hornet.Hashes {
+
+	seen := make(map[string]struct{})
+	var approvers hornet.Hashes
+
+	for _, approverHash := range tangle.GetApproverHashes(tailTxHash) {
+		cachedBndls := tangle.GetBundlesOfTransactionOrNil(approverHash, true) // bundle +1
+		if cachedBndls == nil {
+			continue
+		}
+
+		for _, cachedBndl := range cachedBndls {
+			bndl := cachedBndl.GetBundle()
+			approverTailTxHash := bndl.GetTailHash()
+
+			if _, exists := seen[string(approverTailTxHash)]; exists {
+				continue
+			}
+			seen[string(approverTailTxHash)] = struct{}{}
+
+			if !bndl.IsSolid() || bndl.IsInvalidPastCone() || !bndl.IsValid() || !bndl.ValidStrictSemantics() {
+				continue
+			}
+
+			if w.isBelowMaxDepth(approverTailTxHash, lsmi) {
+				continue
+			}
+
+			approvers = append(approvers, approverTailTxHash)
+		}
+		cachedBndls.Release(true) // bundle -1
+	}
+
+	return approvers
+}
+
+// isBelowMaxDepth checks whether the past cone of the given transaction reaches below the max depth.
+func (w *Walker) isBelowMaxDepth(txHash hornet.Hash, lsmi milestone.Index) bool {
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+	if cachedTxMeta == nil {
+		return true
+	}
+	defer cachedTxMeta.Release(true) // meta -1
+
+	_, ortsi := dag.GetTransactionRootSnapshotIndexes(cachedTxMeta.Retain(), lsmi) // meta +1
+	return (lsmi - ortsi) > w.belowMaxDepth
+}
@@ -0,0 +1,182 @@
+package toolset
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// backupStatus is the progress of the online database backup as returned by the HTTP API.
+type backupStatus struct {
+	Running bool               `json:"running"`
+	Start   int64              `json:"start"`
+	End     int64              `json:"end"`
+	Path    string             `json:"path"`
+	Info    *tangle.BackupInfo `json:"info"`
+	Error   string             `json:"error"`
+}
+
+// backupResponse is the response of the backup commands of the HTTP API.
+type backupResponse struct {
+	Status backupStatus `json:"status"`
+	Error  string       `json:"error"`
+}
+
+// dbBackup triggers a backup of the databases and the local snapshot files of a running node via its HTTP API
+// and waits until the backup finished.
+func dbBackup(args []string) error {
+
+	if len(args) > 2 {
+		return errors.New("too many arguments for 'backup'")
+	}
+
+	apiURL := localAPIURL()
+	if len(args) > 0 {
+		apiURL = args[0]
+	}
+
+	jwt := ""
+	if len(args) > 1 {
+		jwt = args[1]
+	}
+
+	status, err := callBackupCommand(apiURL, jwt, "createDatabaseBackup")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("database backup started, writing to %s\n", status.Path)
+
+	for status.Running {
+		time.Sleep(printStatusInterval)
+
+		if status, err = callBackupCommand(apiURL, jwt, "getDatabaseBackupStatus"); err != nil {
+			return err
+		}
+
+		if status.Running {
+			fmt.Printf("backup running for %v...\n", time.Since(time.Unix(status.Start, 0)).Truncate(time.Second))
+		}
+	}
+
+	if status.Error != "" {
+		return fmt.Errorf("database backup failed: %s", status.Error)
+	}
+
+	fmt.Printf("database backup finished at solid milestone %d, %d bytes written to %s (the path is relative to the working directory of the node). took %v\n",
+		status.Info.SolidMilestoneIndex, status.Info.Size, status.Path, time.Duration(status.End-status.Start)*time.Second)
+
+	return nil
+}
+
+func callBackupCommand(apiURL string, jwt string, command string) (*backupStatus, error) {
+	response := &backupResponse{}
+	if err := callAPICommand(apiURL, jwt, command, response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("'%s' failed: %s", command, response.Error)
+	}
+
+	return &response.Status, nil
+}
+
+// dbRestore restores the databases and the local snapshot files of a backup.
+// The node must not be running and the database directory must not contain any databases.
+func dbRestore(args []string) error {
+
+	if len(args) == 0 {
+		return errors.New("the directory of the backup is missing")
+	}
+	if len(args) > 2 {
+		return errors.New("too many arguments for 'restore'")
+	}
+
+	backupDirectory := args[0]
+	databaseDirectory := config.NodeConfig.GetString(config.CfgDatabasePath)
+	if len(args) > 1 {
+		databaseDirectory = args[1]
+	}
+
+	info, err := tangle.ReadBackupInfo(backupDirectory)
+	if err != nil {
+		return err
+	}
+
+	if engine := strings.ToLower(config.NodeConfig.GetString(config.CfgDatabaseEngine)); engine != info.Engine {
+		return fmt.Errorf("the backup was taken with the database engine '%s', but '%s' is configured in '%s'", info.Engine, engine, config.CfgDatabaseEngine)
+	}
+
+	fmt.Printf("restoring the backup of solid milestone %d to %s...\n", info.SolidMilestoneIndex, databaseDirectory)
+
+	start := time.Now()
+	if _, err := tangle.RestoreDatabases(backupDirectory, databaseDirectory, strings.ToLower(config.NodeConfig.GetString(config.CfgDatabaseStorageMedium))); err != nil {
+		return err
+	}
+
+	// the local snapshot files are only restored if they don't exist, to not overwrite newer ones
+	for _, target := range []string{
+		config.NodeConfig.GetString(config.CfgLocalSnapshotsPath),
+		config.NodeConfig.GetString(config.CfgLocalSnapshotsDeltaPath),
+	} {
+		name := path.Base(target)
+		if !containsString(info.Files, name) {
+			continue
+		}
+
+		if _, err := os.Stat(target); err == nil {
+			fmt.Printf("keeping the existing file %s, the one of the backup was not restored\n", target)
+			continue
+		}
+
+		if err := copyFile(path.Join(backupDirectory, name), target); err != nil {
+			return fmt.Errorf("restoring %s failed: %w", target, err)
+		}
+	}
+
+	fmt.Printf("database restore finished. took %v\n", time.Since(start).Truncate(time.Millisecond))
+	fmt.Println("the ledger of the restored databases is revalidated at the first start of the node")
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// copyFile copies the source file to the target path, the target must not exist.
+func copyFile(source string, target string) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	if err := os.MkdirAll(path.Dir(target), 0700); err != nil {
+		return err
+	}
+
+	targetFile, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer targetFile.Close()
+
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		return err
+	}
+
+	return targetFile.Sync()
+}
@@ -0,0 +1,246 @@
+package toolset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+// configConvert converts a config file between the JSON, YAML and TOML format, which are detected by the file extensions.
+// The order of the settings is kept, except for TOML files, whose settings are sorted.
+func configConvert(args []string) error {
+
+	if len(args) != 2 {
+		return errors.New("wrong amount of arguments for 'configconvert', expected '<inputFile> <outputFile>'")
+	}
+
+	inputFile, outputFile := args[0], args[1]
+	for _, file := range []string{inputFile, outputFile} {
+		if !config.HasConfigExtension(file) {
+			return fmt.Errorf("the format of '%s' is not supported, the file extension has to be .json, .yaml, .yml or .toml", file)
+		}
+	}
+
+	if _, err := os.Stat(outputFile); err == nil {
+		return fmt.Errorf("'%s' already exists", outputFile)
+	}
+
+	content, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	document, err := parseConfigDocument(configFormat(inputFile), content)
+	if err != nil {
+		return fmt.Errorf("parsing '%s' failed: %w", inputFile, err)
+	}
+
+	converted, err := encodeConfigDocument(configFormat(outputFile), document)
+	if err != nil {
+		return fmt.Errorf("converting '%s' failed: %w", inputFile, err)
+	}
+
+	if err := ioutil.WriteFile(outputFile, converted, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("converted '%s' to '%s'\n", inputFile, outputFile)
+	return nil
+}
+
+// configFormat returns the format of the given config file.
+func configFormat(file string) string {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	if format == "yml" {
+		return "yaml"
+	}
+	return format
+}
+
+// parseConfigDocument parses the content of a config file into a YAML node, which keeps the order of the settings.
+// JSON is parsed as YAML, since it is a subset of YAML.
+func parseConfigDocument(format string, content []byte) (*yaml.Node, error) {
+	if format == "toml" {
+		tree, err := toml.LoadBytes(content)
+		if err != nil {
+			return nil, err
+		}
+
+		// the settings of TOML files are unordered, so they are encoded as YAML with sorted keys
+		if content, err = yaml.Marshal(tree.ToMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(content, document); err != nil {
+		return nil, err
+	}
+
+	root := document
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("the config file doesn't contain settings")
+	}
+
+	return root, nil
+}
+
+// encodeConfigDocument encodes the settings of the YAML node in the given format.
+func encodeConfigDocument(format string, document *yaml.Node) ([]byte, error) {
+	switch format {
+	case "json":
+		var compact bytes.Buffer
+		if err := writeJSONNode(&compact, document); err != nil {
+			return nil, err
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+			return nil, err
+		}
+		indented.WriteString("\n")
+		return indented.Bytes(), nil
+
+	case "yaml":
+		resetNodeStyle(document)
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case "toml":
+		settings := make(map[string]interface{})
+		if err := document.Decode(&settings); err != nil {
+			return nil, err
+		}
+		if err := checkTOMLValues("", settings); err != nil {
+			return nil, err
+		}
+
+		tree, err := toml.TreeFromMap(settings)
+		if err != nil {
+			return nil, err
+		}
+		content, err := tree.ToTomlString()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(content), nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// writeJSONNode writes the YAML node as JSON in the order of the settings.
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			if err := writeJSONNode(buf, content); err != nil {
+				return err
+			}
+		}
+
+	case yaml.MappingNode:
+		buf.WriteString("{")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteString(":")
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("}")
+
+	case yaml.SequenceNode:
+		buf.WriteString("[")
+		for i, content := range node.Content {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			if err := writeJSONNode(buf, content); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("]")
+
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+
+	default:
+		// numbers are kept as written, e.g. 50.0 isn't shortened to 50
+		if (node.Tag == "!!int" || node.Tag == "!!float") && json.Valid([]byte(node.Value)) {
+			buf.WriteString(node.Value)
+			return nil
+		}
+
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		buf.Write(encoded)
+	}
+
+	return nil
+}
+
+// resetNodeStyle removes the flow style and the quotes of JSON input, so that the YAML output uses the block style.
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, content := range node.Content {
+		resetNodeStyle(content)
+	}
+}
+
+// checkTOMLValues checks that the settings contain no values which can't be represented in TOML.
+func checkTOMLValues(key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return fmt.Errorf("'%s' is null, which is not supported by TOML", key)
+	case map[string]interface{}:
+		for k, child := range v {
+			if err := checkTOMLValues(strings.TrimPrefix(key+"."+k, "."), child); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := checkTOMLValues(key, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
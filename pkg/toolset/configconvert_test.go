@@ -0,0 +1,107 @@
+package toolset
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSONConfig = `{
+  "node": {
+    "alias": "hornet",
+    "enablePlugins": [
+      "Spammer",
+      "MQTT"
+    ]
+  },
+  "spammer": {
+    "tpsRateLimit": 0.10,
+    "cpuMaxUsage": 50.0,
+    "workers": 1
+  },
+  "httpAPI": {
+    "bindAddress": "0.0.0.0:14265"
+  }
+}
+`
+
+func TestConfigConvert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configconvert")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(input, []byte(testJSONConfig), 0600))
+
+	require.NoError(t, configConvert([]string{input, filepath.Join(dir, "config.yml")}))
+	converted, err := ioutil.ReadFile(filepath.Join(dir, "config.yml"))
+	require.NoError(t, err)
+
+	// the order of the settings and the numbers are kept as written
+	assert.Equal(t, `node:
+  alias: hornet
+  enablePlugins:
+    - Spammer
+    - MQTT
+spammer:
+  tpsRateLimit: 0.10
+  cpuMaxUsage: 50.0
+  workers: 1
+httpAPI:
+  bindAddress: 0.0.0.0:14265
+`, string(converted))
+
+	// converting back results in the same file
+	require.NoError(t, configConvert([]string{filepath.Join(dir, "config.yml"), filepath.Join(dir, "roundtrip.json")}))
+	converted, err = ioutil.ReadFile(filepath.Join(dir, "roundtrip.json"))
+	require.NoError(t, err)
+	assert.Equal(t, testJSONConfig, string(converted))
+
+	// the settings of TOML files are sorted
+	require.NoError(t, configConvert([]string{input, filepath.Join(dir, "config.toml")}))
+	require.NoError(t, configConvert([]string{filepath.Join(dir, "config.toml"), filepath.Join(dir, "fromtoml.json")}))
+	converted, err = ioutil.ReadFile(filepath.Join(dir, "fromtoml.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, testJSONConfig, string(converted))
+	assert.True(t, strings.Index(string(converted), `"httpAPI"`) < strings.Index(string(converted), `"node"`))
+
+	// existing files are never overwritten
+	assert.Error(t, configConvert([]string{input, filepath.Join(dir, "config.yml")}))
+}
+
+func TestConfigConvertErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configconvert")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Error(t, configConvert([]string{filepath.Join(dir, "config.json")}))
+	assert.Error(t, configConvert([]string{filepath.Join(dir, "config.ini"), filepath.Join(dir, "config.json")}))
+	assert.Error(t, configConvert([]string{filepath.Join(dir, "missing.json"), filepath.Join(dir, "config.yaml")}))
+
+	input := filepath.Join(dir, "list.json")
+	require.NoError(t, ioutil.WriteFile(input, []byte(`["a", "b"]`), 0600))
+	err = configConvert([]string{input, filepath.Join(dir, "list.yaml")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't contain settings")
+
+	// TOML has no null values
+	input = filepath.Join(dir, "null.json")
+	require.NoError(t, ioutil.WriteFile(input, []byte(`{"profiling": {"bindAddress": null}}`), 0600))
+	err = configConvert([]string{input, filepath.Join(dir, "null.toml")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'profiling.bindAddress' is null")
+	_, err = os.Stat(filepath.Join(dir, "null.toml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestConfigFormat(t *testing.T) {
+	assert.Equal(t, "json", configFormat("config.json"))
+	assert.Equal(t, "yaml", configFormat("config.yml"))
+	assert.Equal(t, "yaml", configFormat("CONFIG.YAML"))
+	assert.Equal(t, "toml", configFormat("/etc/hornet/config.toml"))
+}
@@ -0,0 +1,65 @@
+package toolset
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/model/coordinator"
+)
+
+// cooSigner runs a remote signer for the milestones of the coordinator with the seed in the environment variable COO_SEED,
+// so that the seed does not have to reside on the coordinator node.
+// If COO_SIGNER_TOKEN is set, the coordinator has to authenticate with it.
+func cooSigner(args []string) error {
+
+	if len(args) != 2 && len(args) != 4 {
+		return errors.New("usage: 'coosigner <bindAddress> <stateFile> [tlsCertificateFile tlsKeyFile]'")
+	}
+
+	seed, err := config.LoadHashFromEnvironment("COO_SEED")
+	if err != nil {
+		return err
+	}
+
+	signerServer, err := coordinator.NewSignerServer(seed, os.Getenv("COO_SIGNER_TOKEN"), args[1])
+	if err != nil {
+		return err
+	}
+
+	opts := []grpc.ServerOption{}
+	if len(args) == 4 {
+		creds, err := credentials.NewServerTLSFromFile(args[2], args[3])
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", args[0])
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(opts...)
+	grpcapi.RegisterMilestoneSignerServer(server, signerServer)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		server.GracefulStop()
+	}()
+
+	fmt.Printf("milestone signer listening on %s, latest signed milestone index: %d\n", args[0], signerServer.LatestIndex())
+
+	return server.Serve(listener)
+}
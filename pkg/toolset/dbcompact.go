@@ -0,0 +1,135 @@
+package toolset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+// compactionStatus is the progress of the online database compaction as returned by the HTTP API.
+type compactionStatus struct {
+	Running    bool   `json:"running"`
+	Start      int64  `json:"start"`
+	End        int64  `json:"end"`
+	Database   string `json:"database"`
+	Rounds     int    `json:"rounds"`
+	SizeBefore int64  `json:"sizeBefore"`
+	Size       int64  `json:"size"`
+	Error      string `json:"error"`
+}
+
+// compactionResponse is the response of the compaction commands of the HTTP API.
+type compactionResponse struct {
+	Status compactionStatus `json:"status"`
+	Error  string           `json:"error"`
+}
+
+// dbCompact triggers the online compaction of the databases of a running node via its HTTP API
+// and prints the progress until the compaction finished.
+func dbCompact(args []string) error {
+
+	if len(args) > 2 {
+		return errors.New("too many arguments for 'dbcompact'")
+	}
+
+	apiURL := localAPIURL()
+	if len(args) > 0 {
+		apiURL = args[0]
+	}
+
+	jwt := ""
+	if len(args) > 1 {
+		jwt = args[1]
+	}
+
+	status, err := callCompactionCommand(apiURL, jwt, "compactDatabase")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("database compaction started, size: %d bytes\n", status.SizeBefore)
+
+	for status.Running {
+		time.Sleep(printStatusInterval)
+
+		if status, err = callCompactionCommand(apiURL, jwt, "getDatabaseCompactionStatus"); err != nil {
+			return err
+		}
+
+		if status.Running {
+			fmt.Printf("compacting %s, rounds: %d, size: %d bytes...\n", status.Database, status.Rounds, status.Size)
+		}
+	}
+
+	if status.Error != "" {
+		return fmt.Errorf("database compaction failed: %s", status.Error)
+	}
+
+	fmt.Printf("database compaction finished, reclaimed %d bytes. took %v\n", status.SizeBefore-status.Size, time.Duration(status.End-status.Start)*time.Second)
+
+	return nil
+}
+
+// localAPIURL returns the URL of the HTTP API of the local node.
+func localAPIURL() string {
+	bindAddr := config.NodeConfig.GetString(config.CfgWebAPIBindAddress)
+
+	host, port, err := net.SplitHostPort(bindAddr)
+	if err != nil || host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	if port == "" {
+		port = "14265"
+	}
+
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+func callCompactionCommand(apiURL string, jwt string, command string) (*compactionStatus, error) {
+	response := &compactionResponse{}
+	if err := callAPICommand(apiURL, jwt, command, response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("'%s' failed: %s", command, response.Error)
+	}
+
+	return &response.Status, nil
+}
+
+// callAPICommand calls the given command without parameters on the HTTP API and decodes the response.
+func callAPICommand(apiURL string, jwt string, command string, response interface{}) error {
+	body, err := json.Marshal(map[string]string{"command": command})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(apiURL, "/"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-IOTA-API-Version", "1")
+	if jwt != "" {
+		req.Header.Set("Authorization", "Bearer "+jwt)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling '%s' failed: %w", command, err)
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return fmt.Errorf("decoding the response of '%s' failed: %w", command, err)
+	}
+
+	return nil
+}
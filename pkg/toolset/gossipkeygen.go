@@ -0,0 +1,27 @@
+package toolset
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// gossipKeyGen generates an ed25519 key pair for the node identity which secures the connections to neighbors.
+// The private key belongs into the node config, the public key is pinned in the peering config of the neighbors.
+func gossipKeyGen(args []string) error {
+
+	if len(args) > 0 {
+		return errors.New("too many arguments for 'gossipkeygen'")
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Your gossip private key: ", hex.EncodeToString(privateKey))
+	fmt.Println("Your gossip public key:  ", hex.EncodeToString(publicKey))
+
+	return nil
+}
@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/merkle"
+	"github.com/iotaledger/iota.go/trinary"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/merkletree"
 	"github.com/gohornet/hornet/pkg/utils"
 )
 
@@ -39,52 +42,88 @@ func merkleTreeCreate(args []string) error {
 		return fmt.Errorf("Merkle tree file already exists. %v", merkleFilePath)
 	}
 
-	count := 1 << depth
+	ts := time.Now()
+
+	root, err := createMerkleTreeFile(seed, secLvl, depth, merkleFilePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Merkle tree root: %v\n", root)
+
+	fmt.Printf("successfully created Merkle tree (took %v).\n", time.Since(ts).Truncate(time.Second))
+
+	return nil
+}
+
+// createMerkleTreeFile creates the Merkle tree of the coordinator with the given seed and stores it in the given file.
+// The calculated addresses are checkpointed next to the file, so an interrupted run resumes where it stopped.
+func createMerkleTreeFile(seed trinary.Hash, secLvl int, depth int, merkleFilePath string) (trinary.Hash, error) {
+
+	checkpointFilePath := merkleFilePath + ".checkpoint"
 
 	ts := time.Now()
-	lastStatusTime := time.Now()
+	lastStatusTime := time.Time{}
+	var resumed uint32
 
-	calculateAddressesStartCallback := func(count uint32) {
-		fmt.Printf("calculating %d addresses...\n", count)
+	resumeCallback := func(resumedCount uint32, count uint32) {
+		resumed = resumedCount
+		fmt.Printf("resuming from checkpoint %s, %d/%d addresses already calculated\n", checkpointFilePath, resumedCount, count)
 	}
 
-	calculateAddressesCallback := func(index uint32) {
-		if time.Since(lastStatusTime) >= printStatusInterval {
-			lastStatusTime = time.Now()
+	progressCallback := func(calculated uint32, count uint32) {
+		if calculated != count && time.Since(lastStatusTime) < printStatusInterval {
+			return
+		}
+		lastStatusTime = time.Now()
+
+		_, remaining := utils.EstimateRemainingTime(ts, int64(calculated-resumed), int64(count-resumed))
+		percentage := float64(calculated) / float64(count) * 100.0
+		fmt.Printf("\r%s %6.2f%% %d/%d addresses, %v left   ", progressBar(percentage), percentage, calculated, count, remaining.Truncate(time.Second))
 
-			percentage, remaining := utils.EstimateRemainingTime(ts, int64(index), int64(count))
-			fmt.Printf("calculated %d/%d (%0.2f%%) addresses. %v left...\n", index, count, percentage, remaining.Truncate(time.Second))
+		if calculated == count {
+			fmt.Printf("\ncalculated %d addresses (took %v).\n", count-resumed, time.Since(ts).Truncate(time.Second))
 		}
 	}
 
-	calculateAddressesFinishedCallback := func(count uint32) {
-		fmt.Printf("calculated %d/%d (100.00%%) addresses (took %v).\n", count, count, time.Since(ts).Truncate(time.Second))
+	layersCallback := func(level int) {
+		fmt.Printf("calculating nodes for layer %d\n", level)
 	}
 
-	calculateLayersCallback := func(index uint32) {
-		fmt.Printf("calculating nodes for layer %d\n", index)
-	}
+	fmt.Printf("calculating %d addresses using %d CPUs...\n", 1<<uint(depth), runtime.NumCPU())
 
-	mt, err := merkle.CreateMerkleTree(seed, consts.SecurityLevel(secLvl), depth,
-		merkle.MerkleCreateOptions{
-			CalculateAddressesStartCallback:    calculateAddressesStartCallback,
-			CalculateAddressesCallback:         calculateAddressesCallback,
-			CalculateAddressesFinishedCallback: calculateAddressesFinishedCallback,
-			CalculateLayersCallback:            calculateLayersCallback,
-			Parallelism:                        runtime.NumCPU(),
+	mt, err := merkletree.Create(seed, consts.SecurityLevel(secLvl), depth,
+		merkletree.Options{
+			Parallelism:        runtime.NumCPU(),
+			CheckpointFilePath: checkpointFilePath,
+			ResumeCallback:     resumeCallback,
+			ProgressCallback:   progressCallback,
+			LayersCallback:     layersCallback,
 		})
 
 	if err != nil {
-		return fmt.Errorf("error creating Merkle tree: %v", err)
+		return "", fmt.Errorf("error creating Merkle tree: %w", err)
 	}
 
 	if err := merkle.StoreMerkleTreeFile(merkleFilePath, mt); err != nil {
-		return fmt.Errorf("error persisting Merkle tree: %v", err)
+		return "", fmt.Errorf("error persisting Merkle tree: %v", err)
 	}
 
-	fmt.Printf("Merkle tree root: %v\n", mt.Root)
+	// the checkpoint is not needed anymore after the tree was stored
+	if err := os.Remove(checkpointFilePath); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("error removing checkpoint file: %v", err)
+	}
 
-	fmt.Printf("successfully created Merkle tree (took %v).\n", time.Since(ts).Truncate(time.Second))
+	return mt.Root, nil
+}
 
-	return nil
+// progressBar renders a progress bar for the given percentage.
+func progressBar(percentage float64) string {
+	const width = 40
+
+	filled := int(percentage / 100.0 * width)
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
 }
@@ -0,0 +1,224 @@
+package toolset
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+const (
+	// the default depth of the Merkle tree of a private tangle (65536 milestones, ~7 days with 10s interval)
+	privateTangleDefaultMerkleTreeDepth = 16
+	// the security level of the coordinator and the genesis address
+	privateTangleSecurityLevel = consts.SecurityLevelMedium
+	// the minimum weight magnitude of a private tangle
+	privateTangleMWM = 5
+
+	// the directory inside the private tangle directory which holds all data written by the node
+	privateTangleDataDir = "data"
+)
+
+// privateTangleComposeFile is the docker-compose file of a private tangle, which runs the coordinator node.
+const privateTangleComposeFile = `version: '3'
+services:
+  hornet:
+    image: hornet:latest
+    network_mode: host
+    cap_drop:
+      - ALL
+    env_file: .env
+    volumes:
+      - ./config.json:/app/config.json:ro
+      - ./peering.json:/app/peering.json
+      - ./snapshot.txt:/app/snapshot.txt:ro
+      - ./coordinator.tree:/app/coordinator.tree:ro
+      - ./data:/app/data
+`
+
+// privateTangleRunScript starts the coordinator node and bootstraps the network on the first start.
+const privateTangleRunScript = `#!/bin/sh
+# starts the coordinator node of the private tangle, set HORNET to the path of the hornet binary if it is not in the PATH
+cd "$(dirname "$0")"
+set -a
+. ./.env
+set +a
+
+if [ -f data/coordinator.state ]; then
+	exec "${HORNET:-hornet}" "$@"
+fi
+exec "${HORNET:-hornet}" --cooBootstrap "$@"
+`
+
+// privateTangleFile is a file which is written to the directory of the private tangle.
+type privateTangleFile struct {
+	name    string
+	content []byte
+	perm    os.FileMode
+}
+
+func privateTangle(args []string) error {
+
+	usage := errors.New("usage: 'privatetangle <directory> [merkleTreeDepth] [docker]'")
+
+	if len(args) < 1 || len(args) > 3 {
+		return usage
+	}
+
+	directory := args[0]
+	depth := privateTangleDefaultMerkleTreeDepth
+	withDocker := false
+
+	for _, arg := range args[1:] {
+		if arg == "docker" {
+			withDocker = true
+			continue
+		}
+
+		var err error
+		if depth, err = strconv.Atoi(arg); err != nil || depth < 1 || depth > 24 {
+			return fmt.Errorf("invalid Merkle tree depth '%s', has to be between 1 and 24", arg)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, "config.json")); !os.IsNotExist(err) {
+		return fmt.Errorf("%s already contains a private tangle", directory)
+	}
+
+	if err := os.MkdirAll(filepath.Join(directory, privateTangleDataDir), 0700); err != nil {
+		return err
+	}
+
+	ts := time.Now()
+
+	cooSeed, err := randomSeed()
+	if err != nil {
+		return err
+	}
+
+	genesisSeed, err := randomSeed()
+	if err != nil {
+		return err
+	}
+
+	// the whole supply is put on the first address of the genesis seed, which is used by the faucet
+	genesisAddress, err := address.GenerateAddress(genesisSeed, 0, privateTangleSecurityLevel)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("creating the Merkle tree of the coordinator with depth %d...\n", depth)
+	cooAddress, err := createMerkleTreeFile(cooSeed, int(privateTangleSecurityLevel), depth, filepath.Join(directory, "coordinator.tree"))
+	if err != nil {
+		return err
+	}
+
+	files := []privateTangleFile{
+		{"snapshot.txt", []byte(fmt.Sprintf("%s;%d\n", genesisAddress, consts.TotalSupply)), 0644},
+		{".env", []byte(fmt.Sprintf("COO_SEED=%s\nFAUCET_SEED=%s\n", cooSeed, genesisSeed)), 0600},
+		{"run.sh", []byte(privateTangleRunScript), 0755},
+	}
+
+	nodeConfig, err := json.MarshalIndent(privateTangleNodeConfig(cooAddress, depth), "", "  ")
+	if err != nil {
+		return err
+	}
+	files = append(files, privateTangleFile{"config.json", append(nodeConfig, '\n'), 0644})
+
+	peeringConfig, err := json.MarshalIndent(map[string]interface{}{
+		"acceptAnyConnection": true,
+		"maxPeers":            5,
+		"peers":               []interface{}{},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	files = append(files, privateTangleFile{"peering.json", append(peeringConfig, '\n'), 0644})
+
+	if withDocker {
+		files = append(files, privateTangleFile{"docker-compose.yml", []byte(privateTangleComposeFile), 0644})
+	}
+
+	for _, file := range files {
+		if err := ioutil.WriteFile(filepath.Join(directory, file.name), file.content, file.perm); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nsuccessfully created the private tangle in %s (took %v).\n\n", directory, time.Since(ts).Truncate(time.Second))
+	fmt.Printf("coordinator address: %s\n", cooAddress)
+	fmt.Printf("genesis address:     %s\n", genesisAddress)
+	fmt.Println("the seeds of the coordinator and the faucet are stored in .env, keep it secret.")
+	fmt.Println()
+	if withDocker {
+		fmt.Println("the node in the container runs as user 39999:  chown -R 39999:39999 data coordinator.tree")
+		fmt.Println("bootstrap the network on the first start:  docker-compose run --rm hornet --cooBootstrap")
+		fmt.Println("afterwards start the node with:            docker-compose up -d")
+	} else {
+		fmt.Printf("start the coordinator node with:  %s\n", filepath.Join(directory, "run.sh"))
+	}
+
+	return nil
+}
+
+// privateTangleNodeConfig returns the config of the coordinator node of a private tangle.
+// All other settings keep their default values.
+func privateTangleNodeConfig(cooAddress trinary.Hash, merkleTreeDepth int) map[string]interface{} {
+	return map[string]interface{}{
+		"node": map[string]interface{}{
+			"alias":          "private-tangle-coordinator",
+			"enablePlugins":  []string{"Coordinator", "Faucet"},
+			"disablePlugins": []string{"Autopeering"},
+		},
+		"db": map[string]interface{}{
+			"path": filepath.Join(privateTangleDataDir, "privatedb"),
+		},
+		"snapshots": map[string]interface{}{
+			"loadType": "global",
+			"local": map[string]interface{}{
+				"path":      filepath.Join(privateTangleDataDir, "snapshots", "export.bin"),
+				"deltaPath": filepath.Join(privateTangleDataDir, "snapshots", "delta_export.bin"),
+			},
+			"global": map[string]interface{}{
+				"path":                "snapshot.txt",
+				"spentAddressesPaths": []string{},
+				"index":               0,
+			},
+			"pruning": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+		"coordinator": map[string]interface{}{
+			"address":            cooAddress,
+			"securityLevel":      int(privateTangleSecurityLevel),
+			"merkleTreeDepth":    merkleTreeDepth,
+			"mwm":                privateTangleMWM,
+			"stateFilePath":      filepath.Join(privateTangleDataDir, "coordinator.state"),
+			"merkleTreeFilePath": "coordinator.tree",
+			"intervalSeconds":    10,
+		},
+	}
+}
+
+// randomSeed generates a random seed with 81 trytes.
+func randomSeed() (trinary.Hash, error) {
+	seed := make([]byte, consts.HashTrytesSize)
+	for i := range seed {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(consts.TryteAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		seed[i] = consts.TryteAlphabet[n.Int64()]
+	}
+	return string(seed), nil
+}
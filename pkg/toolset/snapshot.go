@@ -0,0 +1,293 @@
+package toolset
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+)
+
+const (
+	// the supported version of the local snapshot files
+	localSnapshotFileVersion = 4
+)
+
+// snapshotHeader is the header of a local snapshot file.
+type snapshotHeader struct {
+	Version               byte   `json:"version"`
+	MilestoneHash         string `json:"milestoneHash"`
+	MilestoneIndex        int32  `json:"milestoneIndex"`
+	MilestoneTimestamp    int64  `json:"milestoneTimestamp"`
+	SolidEntryPointsCount int32  `json:"solidEntryPointsCount"`
+	SeenMilestonesCount   int32  `json:"seenMilestonesCount"`
+	LedgerEntriesCount    int32  `json:"ledgerEntriesCount"`
+	SpentAddressesCount   int32  `json:"spentAddressesCount"`
+}
+
+// snapshotLedgerEntry is a single address with its balance in the ledger state of a local snapshot file.
+type snapshotLedgerEntry struct {
+	Address trinary.Trytes `json:"address"`
+	Balance uint64         `json:"balance"`
+}
+
+func snapshot(args []string) error {
+
+	usage := errors.New("usage: 'snapshot info <file>', 'snapshot diff <fileA> <fileB>' or 'snapshot export <file> <csv|json> [outputFile]'")
+
+	if len(args) < 2 {
+		return usage
+	}
+
+	switch args[0] {
+	case "info":
+		if len(args) != 2 {
+			return usage
+		}
+		return snapshotInfo(args[1])
+
+	case "diff":
+		if len(args) != 3 {
+			return usage
+		}
+		return snapshotDiff(args[1], args[2])
+
+	case "export":
+		if len(args) < 3 || len(args) > 4 {
+			return usage
+		}
+		output := ""
+		if len(args) == 4 {
+			output = args[3]
+		}
+		return snapshotExport(args[1], args[2], output)
+
+	default:
+		return usage
+	}
+}
+
+// readSnapshotFile reads the header and the ledger state of the local snapshot file at the given path.
+// The solid entry points and seen milestones are skipped.
+func readSnapshotFile(filePath string) (*snapshotHeader, map[string]uint64, error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	header := &snapshotHeader{}
+	if err := binary.Read(reader, binary.LittleEndian, &header.Version); err != nil {
+		return nil, nil, err
+	}
+
+	if header.Version != localSnapshotFileVersion {
+		return nil, nil, fmt.Errorf("unsupported local snapshot file version %d", header.Version)
+	}
+
+	msHash := make(hornet.Hash, 49)
+	if _, err := io.ReadFull(reader, msHash); err != nil {
+		return nil, nil, err
+	}
+	header.MilestoneHash = msHash.Trytes()
+
+	for _, field := range []interface{}{
+		&header.MilestoneIndex,
+		&header.MilestoneTimestamp,
+		&header.SolidEntryPointsCount,
+		&header.SeenMilestonesCount,
+		&header.LedgerEntriesCount,
+		&header.SpentAddressesCount,
+	} {
+		if err := binary.Read(reader, binary.LittleEndian, field); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// skip the solid entry points and seen milestones (49 bytes hash + 4 bytes milestone index)
+	if _, err := reader.Discard(int(header.SolidEntryPointsCount+header.SeenMilestonesCount) * (49 + 4)); err != nil {
+		return nil, nil, err
+	}
+
+	ledger := make(map[string]uint64, header.LedgerEntriesCount)
+	for i := int32(0); i < header.LedgerEntriesCount; i++ {
+		addr := make([]byte, 49)
+		var balance uint64
+
+		if _, err := io.ReadFull(reader, addr); err != nil {
+			return nil, nil, fmt.Errorf("ledger entries: %w", err)
+		}
+
+		if err := binary.Read(reader, binary.LittleEndian, &balance); err != nil {
+			return nil, nil, fmt.Errorf("ledger entries: %w", err)
+		}
+
+		ledger[string(addr)] = balance
+	}
+
+	return header, ledger, nil
+}
+
+// verifySnapshotChecksum checks the sha256 checksum at the end of the local snapshot file.
+func verifySnapshotChecksum(filePath string) (bool, error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	if info.Size() < sha256.Size {
+		return false, nil
+	}
+
+	lsHash := sha256.New()
+	if _, err := io.CopyN(lsHash, file, info.Size()-sha256.Size); err != nil {
+		return false, err
+	}
+
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(file, checksum); err != nil {
+		return false, err
+	}
+
+	return string(lsHash.Sum(nil)) == string(checksum), nil
+}
+
+func snapshotInfo(filePath string) error {
+
+	header, ledger, err := readSnapshotFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	checksumValid, err := verifySnapshotChecksum(filePath)
+	if err != nil {
+		return err
+	}
+
+	var total uint64
+	for _, balance := range ledger {
+		total += balance
+	}
+
+	fmt.Printf("version:             %d\n", header.Version)
+	fmt.Printf("milestone hash:      %s\n", header.MilestoneHash)
+	fmt.Printf("milestone index:     %d\n", header.MilestoneIndex)
+	fmt.Printf("milestone timestamp: %s\n", time.Unix(header.MilestoneTimestamp, 0).UTC().Format(time.RFC3339))
+	fmt.Printf("solid entry points:  %d\n", header.SolidEntryPointsCount)
+	fmt.Printf("seen milestones:     %d\n", header.SeenMilestonesCount)
+	fmt.Printf("ledger entries:      %d\n", header.LedgerEntriesCount)
+	fmt.Printf("spent addresses:     %d\n", header.SpentAddressesCount)
+	fmt.Printf("total supply:        %d (valid: %v)\n", total, total == consts.TotalSupply)
+	fmt.Printf("checksum valid:      %v\n", checksumValid)
+
+	return nil
+}
+
+func snapshotDiff(filePathA string, filePathB string) error {
+
+	headerA, ledgerA, err := readSnapshotFile(filePathA)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filePathA, err)
+	}
+
+	headerB, ledgerB, err := readSnapshotFile(filePathB)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filePathB, err)
+	}
+
+	fmt.Printf("milestone index: %d -> %d\n", headerA.MilestoneIndex, headerB.MilestoneIndex)
+	fmt.Printf("ledger entries:  %d -> %d\n", headerA.LedgerEntriesCount, headerB.LedgerEntriesCount)
+	fmt.Printf("spent addresses: %d -> %d\n", headerA.SpentAddressesCount, headerB.SpentAddressesCount)
+
+	addresses := make(map[string]struct{})
+	for addr := range ledgerA {
+		addresses[addr] = struct{}{}
+	}
+	for addr := range ledgerB {
+		addresses[addr] = struct{}{}
+	}
+
+	var changes []string
+	for addr := range addresses {
+		if ledgerA[addr] != ledgerB[addr] {
+			changes = append(changes, addr)
+		}
+	}
+	sort.Strings(changes)
+
+	fmt.Printf("changed balances: %d\n", len(changes))
+	for _, addr := range changes {
+		fmt.Printf("%s: %d -> %d (%+d)\n", hornet.Hash(addr).Trytes(), ledgerA[addr], ledgerB[addr], int64(ledgerB[addr])-int64(ledgerA[addr]))
+	}
+
+	return nil
+}
+
+func snapshotExport(filePath string, format string, outputPath string) error {
+
+	_, ledger, err := readSnapshotFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]snapshotLedgerEntry, 0, len(ledger))
+	for addr, balance := range ledger {
+		entries = append(entries, snapshotLedgerEntry{Address: hornet.Hash(addr).Trytes(), Balance: balance})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+
+	var output io.Writer = os.Stdout
+	if outputPath != "" {
+		outputFile, err := os.Create(outputPath)
+		if err != nil {
+			return err
+		}
+		defer outputFile.Close()
+		output = outputFile
+	}
+
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(output)
+		if err := writer.Write([]string{"address", "balance"}); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := writer.Write([]string{entry.Address, strconv.FormatUint(entry.Balance, 10)}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "json":
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+
+	default:
+		return fmt.Errorf("unknown export format '%s', use csv or json", format)
+	}
+}
@@ -0,0 +1,62 @@
+package toolset
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// snapshotSign signs the checksum of a local snapshot file with the ed25519 private key in the environment variable SNAPSHOT_PRIVATE_KEY
+// and writes the hex encoded signature next to the file, so that nodes can verify it on download.
+// Without arguments, a new key pair is generated.
+func snapshotSign(args []string) error {
+
+	if len(args) > 1 {
+		return errors.New("too many arguments for 'snapshotsign'")
+	}
+
+	if len(args) == 0 {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Your snapshot private key: ", hex.EncodeToString(privateKey))
+		fmt.Println("Your snapshot public key:  ", hex.EncodeToString(publicKey))
+		return nil
+	}
+
+	privateKey, err := hex.DecodeString(strings.TrimSpace(os.Getenv("SNAPSHOT_PRIVATE_KEY")))
+	if err != nil || len(privateKey) != ed25519.PrivateKeySize {
+		return errors.New("environment variable 'SNAPSHOT_PRIVATE_KEY' does not contain a valid ed25519 private key")
+	}
+
+	content, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// every local snapshot file ends with the sha256 checksum of its content
+	if len(content) < sha256.Size {
+		return errors.New("snapshot file too short")
+	}
+	checksum := content[len(content)-sha256.Size:]
+	if hash := sha256.Sum256(content[:len(content)-sha256.Size]); !bytes.Equal(hash[:], checksum) {
+		return errors.New("snapshot file checksum mismatch")
+	}
+
+	signature := ed25519.Sign(privateKey, checksum)
+	if err := ioutil.WriteFile(args[0]+".sig", []byte(hex.EncodeToString(signature)), 0660); err != nil {
+		return err
+	}
+
+	fmt.Printf("Signature written to %s.sig\n", args[0])
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package toolset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	spentAddressesExportRoute = "/api/v1/spentaddresses/export"
+	spentAddressesImportRoute = "/api/v1/spentaddresses/import"
+)
+
+// spentAddressesImportResponse is the response of the spent addresses import route of the HTTP API.
+type spentAddressesImportResponse struct {
+	Imported int32 `json:"imported"`
+	Error    struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// spentAddresses exports the spent addresses of a running node into a file or imports an export into it via its HTTP API.
+func spentAddresses(args []string) error {
+
+	if len(args) < 2 {
+		return errors.New("usage: 'spentaddresses export <outputFile> [apiAddress] [jwt]' or 'spentaddresses import <inputFile> [apiAddress] [jwt]'")
+	}
+	if len(args) > 4 {
+		return errors.New("too many arguments for 'spentaddresses'")
+	}
+
+	filePath := args[1]
+
+	apiURL := localAPIURL()
+	if len(args) > 2 {
+		apiURL = args[2]
+	}
+
+	jwt := ""
+	if len(args) > 3 {
+		jwt = args[3]
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "export":
+		written, err := exportSpentAddresses(apiURL, jwt, filePath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("spent addresses exported to %s (%d bytes)\n", filePath, written)
+
+	case "import":
+		imported, err := importSpentAddresses(apiURL, jwt, filePath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported %d new spent addresses from %s\n", imported, filePath)
+
+	default:
+		return fmt.Errorf("unknown action '%s', use 'export' or 'import'", args[0])
+	}
+
+	return nil
+}
+
+// exportSpentAddresses downloads the spent addresses export of the node into the given file.
+func exportSpentAddresses(apiURL string, jwt string, filePath string) (int64, error) {
+	res, err := callAPIRoute(apiURL, jwt, http.MethodGet, spentAddressesExportRoute, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("exporting the spent addresses failed: %s", routeErrorMessage(res))
+	}
+
+	// the export is written into a temporary file, so that an interrupted download doesn't leave a broken file behind
+	exportFile, err := os.Create(filePath + "_tmp")
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(exportFile, res.Body)
+	if closeErr := exportFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath + "_tmp")
+		return 0, err
+	}
+
+	return written, os.Rename(filePath+"_tmp", filePath)
+}
+
+// importSpentAddresses uploads the spent addresses export in the given file to the node.
+func importSpentAddresses(apiURL string, jwt string, filePath string) (int32, error) {
+	importFile, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer importFile.Close()
+
+	res, err := callAPIRoute(apiURL, jwt, http.MethodPost, spentAddressesImportRoute, importFile)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("importing the spent addresses failed: %s", routeErrorMessage(res))
+	}
+
+	response := &spentAddressesImportResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return 0, fmt.Errorf("decoding the response of the import failed: %w", err)
+	}
+
+	return response.Imported, nil
+}
+
+// callAPIRoute calls the given route of the REST API of the node.
+func callAPIRoute(apiURL string, jwt string, method string, route string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(apiURL, "/")+route, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if jwt != "" {
+		req.Header.Set("Authorization", "Bearer "+jwt)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling '%s' failed: %w", route, err)
+	}
+	return res, nil
+}
+
+// routeErrorMessage returns the error message of a failed call of a REST API route.
+func routeErrorMessage(res *http.Response) string {
+	response := &spentAddressesImportResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil || response.Error.Message == "" {
+		return res.Status
+	}
+	return fmt.Sprintf("%s: %s", res.Status, response.Error.Message)
+}
@@ -0,0 +1,62 @@
+package toolset
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpentAddressesExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	export := []byte("export of the spent addresses")
+	var uploaded []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"unauthorized","message":"route requires a valid JWT"}}`))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == spentAddressesExportRoute:
+			_, _ = w.Write(export)
+		case r.Method == http.MethodPost && r.URL.Path == spentAddressesImportRoute:
+			uploaded, _ = ioutil.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"imported":7}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	filePath := filepath.Join(dir, "spent.bin.gz")
+	require.NoError(t, spentAddresses([]string{"export", filePath, server.URL, "token"}))
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, export, content)
+
+	imported, err := importSpentAddresses(server.URL, "token", filePath)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), imported)
+	assert.Equal(t, export, uploaded)
+
+	// errors of the node are reported and no file is left behind
+	_, err = exportSpentAddresses(server.URL, "", filepath.Join(dir, "failed.bin.gz"))
+	assert.EqualError(t, err, "exporting the spent addresses failed: 401 Unauthorized: route requires a valid JWT")
+	_, err = os.Stat(filepath.Join(dir, "failed.bin.gz"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Error(t, spentAddresses([]string{"delete", filePath}))
+	assert.Error(t, spentAddresses([]string{"export"}))
+}
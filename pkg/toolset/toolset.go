@@ -8,10 +8,20 @@ import (
 
 var (
 	tools = map[string]func([]string) error{
-		"pwdhash": hashPasswordAndSalt,
-		"seedgen": seedGen,
-		"list":    listTools,
-		"merkle":  merkleTreeCreate,
+		"pwdhash":        hashPasswordAndSalt,
+		"seedgen":        seedGen,
+		"list":           listTools,
+		"merkle":         merkleTreeCreate,
+		"dbcompact":      dbCompact,
+		"backup":         dbBackup,
+		"restore":        dbRestore,
+		"snapshotsign":   snapshotSign,
+		"snapshot":       snapshot,
+		"coosigner":      cooSigner,
+		"privatetangle":  privateTangle,
+		"gossipkeygen":   gossipKeyGen,
+		"configconvert":  configConvert,
+		"spentaddresses": spentAddresses,
 	}
 )
 
@@ -57,7 +67,17 @@ func HandleTools() {
 func listTools(args []string) error {
 	fmt.Println("pwdhash: generates a sha265 sum from your password and salt")
 	fmt.Println("seedgen: generates an autopeering seed")
-	fmt.Println("merkle: generates a Merkle tree for coordinator plugin")
+	fmt.Println("merkle: generates a Merkle tree for coordinator plugin (resumes from the checkpoint of an interrupted run)")
+	fmt.Println("dbcompact: compacts the databases of the running node via its HTTP API ([apiAddress] [jwt])")
+	fmt.Println("backup: creates a backup of the databases and local snapshot files of the running node via its HTTP API ([apiAddress] [jwt])")
+	fmt.Println("restore: restores a backup while the node is stopped ('<backupDirectory> [databaseDirectory]')")
+	fmt.Println("snapshot: inspects a local snapshot file ('info <file>'), compares two ('diff <fileA> <fileB>') or exports its ledger ('export <file> <csv|json> [outputFile]')")
+	fmt.Println("coosigner: runs a remote signer for the coordinator milestones with the seed in COO_SEED ('<bindAddress> <stateFile> [tlsCertificateFile tlsKeyFile]')")
+	fmt.Println("privatetangle: creates the seeds, Merkle tree, global snapshot and config files of a private tangle ('<directory> [merkleTreeDepth] [docker]')")
+	fmt.Println("snapshotsign: signs a local snapshot file with the key in SNAPSHOT_PRIVATE_KEY, or generates a key pair without arguments")
+	fmt.Println("configconvert: converts a config file between JSON, YAML and TOML, detected by the file extensions ('<inputFile> <outputFile>')")
+	fmt.Println("spentaddresses: exports the spent addresses of the running node into a file or imports an export via its HTTP API ('<export|import> <file> [apiAddress] [jwt]')")
+	fmt.Println("gossipkeygen: generates a key pair for the node identity which secures the connections to neighbors with a pinned public key")
 
 	return nil
 }
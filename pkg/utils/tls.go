@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"crypto/tls"
+	"os"
+	"time"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+const (
+	// the minimum time between two checks whether the certificate files changed
+	certificateCheckInterval = time.Second
+)
+
+// CertificateReloader serves a certificate and reloads it if its files change or Reload is called.
+type CertificateReloader struct {
+	syncutils.Mutex
+	certPath    string
+	keyPath     string
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	lastCheck   time.Time
+}
+
+// NewCertificateReloader loads the given certificate and key.
+func NewCertificateReloader(certPath string, keyPath string) (*CertificateReloader, error) {
+	r := &CertificateReloader{certPath: certPath, keyPath: keyPath}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate and key again. The current certificate is kept if loading fails.
+func (r *CertificateReloader) Reload() error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.load()
+}
+
+func (r *CertificateReloader) load() error {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return err
+	}
+
+	r.cert = &cert
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	return nil
+}
+
+func (r *CertificateReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certPath)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(r.keyPath)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// GetCertificate returns the current certificate, it is used as tls.Config.GetCertificate.
+// The certificate is reloaded if the modification time of one of its files changed.
+// If the new files can't be loaded (e.g. because only one of them was replaced yet), the old certificate is served.
+func (r *CertificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if time.Since(r.lastCheck) >= certificateCheckInterval {
+		r.lastCheck = time.Now()
+		if certModTime, keyModTime, err := r.modTimes(); err == nil && (!certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime)) {
+			_ = r.load()
+		}
+	}
+
+	return r.cert, nil
+}
+
+// LoadTLSConfig loads the given certificate and key and returns a server TLS config
+// which only accepts TLS 1.2 or higher. The certificate is reloaded if its files change,
+// the returned reloader can be used to reload it explicitly.
+func LoadTLSConfig(certPath string, keyPath string) (*tls.Config, *CertificateReloader, error) {
+	reloader, err := NewCertificateReloader(certPath, keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}, reloader, nil
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate with the given common name and returns its DER encoding.
+func writeCertificate(t *testing.T, certPath string, keyPath string, commonName string, modTime time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certPath, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+	return der
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	modTime := time.Now().Add(-time.Minute)
+	first := writeCertificate(t, certPath, keyPath, "first", modTime)
+
+	tlsConfig, reloader, err := LoadTLSConfig(certPath, keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+
+	cert, err := tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, cert.Certificate[0])
+
+	// replaced files are picked up on the next handshake after the check interval
+	second := writeCertificate(t, certPath, keyPath, "second", modTime.Add(time.Second))
+	reloader.lastCheck = time.Time{}
+	cert, err = tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, cert.Certificate[0])
+
+	// files with the same modification time are only picked up by an explicit reload
+	third := writeCertificate(t, certPath, keyPath, "third", modTime.Add(time.Second))
+	reloader.lastCheck = time.Time{}
+	cert, err = tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, cert.Certificate[0])
+
+	require.NoError(t, reloader.Reload())
+	cert, err = tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, third, cert.Certificate[0])
+
+	// an invalid certificate keeps the old one
+	require.NoError(t, ioutil.WriteFile(certPath, []byte("invalid"), 0600))
+	assert.Error(t, reloader.Reload())
+	reloader.lastCheck = time.Time{}
+	cert, err = tlsConfig.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, third, cert.Certificate[0])
+}
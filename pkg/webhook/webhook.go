@@ -0,0 +1,177 @@
+// Package webhook posts node events as JSON to user supplied URLs.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+)
+
+var (
+	// ErrInvalidURL is returned if the URL of a webhook is not an absolute HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid webhook URL")
+	// ErrInvalidPayload is returned if the payload template of a webhook doesn't render valid JSON.
+	ErrInvalidPayload = errors.New("payload is not valid JSON")
+	// ErrUnexpectedStatus is returned if the receiver of a webhook didn't answer with a 2xx status code.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+)
+
+// StatusError is returned if the receiver of a webhook didn't answer with a 2xx status code.
+type StatusError struct {
+	// The status code of the response.
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrUnexpectedStatus, e.StatusCode)
+}
+
+// Is reports whether the target is ErrUnexpectedStatus.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrUnexpectedStatus
+}
+
+// Retryable tells whether posting an event failed for a reason which may be gone with the next attempt.
+// This is the case for network errors, timeouts and if the receiver is overloaded or failed internally (429 and 5xx).
+func Retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	// the client wraps all errors of the transport
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// Event is a notification about something that happened on the node.
+type Event struct {
+	// The type of the event.
+	Type string `json:"event"`
+	// The unix timestamp of the event.
+	Timestamp int64 `json:"timestamp"`
+	// The event specific data.
+	Data interface{} `json:"data"`
+}
+
+// NewEvent creates a new event of the given type which happened now.
+func NewEvent(eventType string, data interface{}) *Event {
+	return &Event{Type: eventType, Timestamp: time.Now().Unix(), Data: data}
+}
+
+// Webhook posts the events it is subscribed to to an URL.
+type Webhook struct {
+	url     string
+	events  map[string]struct{}
+	headers map[string]string
+	payload *template.Template
+	client  *http.Client
+}
+
+// New creates a webhook which posts to the given URL.
+// If no event types are given, the webhook is subscribed to all events.
+// The payload is a text/template which is executed with the Event to render the body of the request,
+// the "json" function encodes a value as JSON. If no payload is given, the Event itself is posted.
+func New(webhookURL string, eventTypes []string, headers map[string]string, payload string, client *http.Client) (*Webhook, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidURL, webhookURL)
+	}
+
+	w := &Webhook{
+		url:     webhookURL,
+		events:  make(map[string]struct{}, len(eventTypes)),
+		headers: headers,
+		client:  client,
+	}
+
+	for _, eventType := range eventTypes {
+		w.events[eventType] = struct{}{}
+	}
+
+	if payload != "" {
+		if w.payload, err = template.New("payload").Funcs(template.FuncMap{"json": toJSON}).Parse(payload); err != nil {
+			return nil, fmt.Errorf("invalid payload template: %w", err)
+		}
+	}
+
+	return w, nil
+}
+
+// URL returns the URL the webhook posts to.
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+// Subscribed tells whether the webhook is subscribed to events of the given type.
+func (w *Webhook) Subscribed(eventType string) bool {
+	if len(w.events) == 0 {
+		return true
+	}
+	_, subscribed := w.events[eventType]
+	return subscribed
+}
+
+// Body renders the body of the request for the given event.
+func (w *Webhook) Body(event *Event) ([]byte, error) {
+	if w.payload == nil {
+		return json.Marshal(event)
+	}
+
+	var buf bytes.Buffer
+	if err := w.payload.Execute(&buf, event); err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, ErrInvalidPayload
+	}
+	return buf.Bytes(), nil
+}
+
+// Post posts the given event to the URL of the webhook.
+func (w *Webhook) Post(event *Event) error {
+	body, err := w.Body(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// drain the body, so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &StatusError{StatusCode: res.StatusCode}
+	}
+	return nil
+}
+
+// toJSON encodes the given value as JSON, so that it can be embedded in a payload template.
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,119 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/webhook"
+)
+
+type milestoneData struct {
+	Index uint32 `json:"index"`
+}
+
+func TestNew(t *testing.T) {
+	for _, invalidURL := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		_, err := webhook.New(invalidURL, nil, nil, "", http.DefaultClient)
+		assert.True(t, errors.Is(err, webhook.ErrInvalidURL), invalidURL)
+	}
+
+	_, err := webhook.New("http://example.com", nil, nil, "{{ .Type", http.DefaultClient)
+	assert.Error(t, err)
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	all, err := webhook.New("http://example.com", nil, nil, "", http.DefaultClient)
+	require.NoError(t, err)
+	assert.True(t, all.Subscribed("milestoneConfirmed"))
+	assert.True(t, all.Subscribed("unsynced"))
+
+	some, err := webhook.New("http://example.com", []string{"unsynced"}, nil, "", http.DefaultClient)
+	require.NoError(t, err)
+	assert.False(t, some.Subscribed("milestoneConfirmed"))
+	assert.True(t, some.Subscribed("unsynced"))
+}
+
+func TestWebhook_Body(t *testing.T) {
+	event := &webhook.Event{Type: "milestoneConfirmed", Timestamp: 1600000000, Data: &milestoneData{Index: 42}}
+
+	w, err := webhook.New("http://example.com", nil, nil, "", http.DefaultClient)
+	require.NoError(t, err)
+	body, err := w.Body(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"event":"milestoneConfirmed","timestamp":1600000000,"data":{"index":42}}`, string(body))
+
+	w, err = webhook.New("http://example.com", nil, nil, `{"text": {{ printf "Milestone %d \"confirmed\"" .Data.Index | json }}}`, http.DefaultClient)
+	require.NoError(t, err)
+	body, err = w.Body(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Milestone 42 \"confirmed\""}`, string(body))
+
+	w, err = webhook.New("http://example.com", nil, nil, `{"text": {{ .Data.Index }}`, http.DefaultClient)
+	require.NoError(t, err)
+	_, err = w.Body(event)
+	assert.Equal(t, webhook.ErrInvalidPayload, err)
+}
+
+func TestWebhook_Post(t *testing.T) {
+	received := make(chan *webhook.Event, 1)
+	status := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		event := &webhook.Event{}
+		require.NoError(t, json.Unmarshal(body, event))
+		received <- event
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	w, err := webhook.New(server.URL, nil, map[string]string{"Authorization": "secret"}, "", &http.Client{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	require.NoError(t, w.Post(webhook.NewEvent("unsynced", nil)))
+	assert.Equal(t, "unsynced", (<-received).Type)
+
+	status = http.StatusInternalServerError
+	err = w.Post(webhook.NewEvent("synced", nil))
+	assert.True(t, errors.Is(err, webhook.ErrUnexpectedStatus))
+	assert.True(t, webhook.Retryable(err))
+	assert.Equal(t, "synced", (<-received).Type)
+
+	status = http.StatusTooManyRequests
+	err = w.Post(webhook.NewEvent("synced", nil))
+	assert.True(t, webhook.Retryable(err))
+	<-received
+
+	// the request itself is wrong, posting it again doesn't help
+	status = http.StatusBadRequest
+	err = w.Post(webhook.NewEvent("synced", nil))
+	assert.True(t, errors.Is(err, webhook.ErrUnexpectedStatus))
+	assert.False(t, webhook.Retryable(err))
+	<-received
+
+	server.Close()
+	err = w.Post(webhook.NewEvent("synced", nil))
+	assert.Error(t, err)
+	assert.True(t, webhook.Retryable(err))
+}
+
+func TestRetryable(t *testing.T) {
+	assert.False(t, webhook.Retryable(webhook.ErrInvalidPayload))
+	assert.False(t, webhook.Retryable(&webhook.StatusError{StatusCode: http.StatusNotFound}))
+	assert.True(t, webhook.Retryable(&webhook.StatusError{StatusCode: http.StatusBadGateway}))
+}
@@ -74,7 +74,7 @@ func ConfirmMilestone(cachedTxMetas map[string]*tangle.CachedMetadata, cachedMsB
 
 	tc := time.Now()
 
-	err = tangle.ApplyLedgerDiffWithoutLocking(mutations.AddressMutations, milestoneIndex)
+	err = tangle.ApplyLedgerDiffWithoutLocking(mutations.AddressMutations, milestoneIndex, len(mutations.TailsExcludedConflicting))
 	if err != nil {
 		return nil, fmt.Errorf("confirmMilestone: ApplyLedgerDiff failed with Error: %v", err)
 	}
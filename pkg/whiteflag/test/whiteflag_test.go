@@ -122,7 +122,7 @@ func storeBundle(t *testing.T, bndl bundle.Bundle, expectMilestone bool) *tangle
 		cachedTx := tangle.GetCachedTransactionOrNil(tailTx)
 		require.NotNil(t, cachedTx)
 		require.True(t, cachedTx.GetMetadata().IsSolid())
-		tangle.OnTailTransactionSolid(cachedTx.Retain())
+		tangle.OnTailTransactionSolid(cachedTx.Retain(), tangle.ValidateBundles(hornet.Hashes{tailTx}, 1))
 		cachedTx.Release()
 	}
 
@@ -276,9 +276,9 @@ func configureCoordinator(t *testing.T) *coordinator.Coordinator {
 	dirAndFile := fmt.Sprintf("%s/coordinator.state", dir)
 
 	// init pow handler
-	powHandler := hornet_pow.New(nil, "", 30*time.Second)
+	powHandler := hornet_pow.New(nil, hornet_pow.Options{PowsrvInitCooldown: 30 * time.Second})
 
-	coo = coordinator.New(cooSeed, secLevel, merkleTreeDepth, mwm, dirAndFile, 10, powHandler, storeBundleFunc, merkleHashFunc)
+	coo = coordinator.New(coordinator.NewSeedSigner(cooSeed), secLevel, merkleTreeDepth, mwm, dirAndFile, 10, powHandler, storeBundleFunc, merkleHashFunc)
 	require.NotNil(t, coo)
 
 	err = coo.InitMerkleTree("coordinator.tree", cooAddress)
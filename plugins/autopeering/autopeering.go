@@ -44,6 +44,7 @@ func configureAP(local *Local) {
 	if err != nil {
 		log.Warn(err)
 	}
+	rememberEntryNodes(entryNodes)
 
 	gossipServiceKeyHash := fnv.New32a()
 	gossipServiceKeyHash.Write([]byte(services.GossipServiceKey()))
@@ -52,7 +53,11 @@ func configureAP(local *Local) {
 	Discovery = discover.New(local.PeerLocal, protocolVersion, networkID, discover.Logger(log.Named("disc")), discover.MasterPeers(entryNodes))
 
 	// enable peer selection only when gossip is enabled
-	Selection = selection.New(local.PeerLocal, Discovery, selection.Logger(log.Named("sel")), selection.NeighborValidator(selection.ValidatorFunc(isValidPeer)))
+	Selection = selection.New(local.PeerLocal, Discovery,
+		selection.Logger(log.Named("sel")),
+		selection.NeighborValidator(selection.ValidatorFunc(isValidPeer)),
+		selection.DropOnUpdate(config.NodeConfig.GetBool(config.CfgNetAutopeeringDropNeighborsOnSaltUpdate)),
+	)
 }
 
 // isValidPeer checks whether a peer is a valid peer.
@@ -160,13 +165,21 @@ func parseEntryNode(entryNodeDefinition string) (entryNode *peer.Peer, err error
 }
 
 func parseEntryNodes() (result []*peer.Peer, err error) {
-	for _, entryNodeDefinition := range config.NodeConfig.GetStringSlice(config.CfgNetAutopeeringEntryNodes) {
-		entryNode, err := parseEntryNode(entryNodeDefinition)
+	for _, configuredDefinition := range config.NodeConfig.GetStringSlice(config.CfgNetAutopeeringEntryNodes) {
+		entryNodeDefinitions, err := expandEntryNodeDefinition(configuredDefinition)
 		if err != nil {
-			log.Warnf("invalid entry node; ignoring: %v, error: %v", entryNodeDefinition, err)
+			log.Warnf("invalid entry node; ignoring: %v, error: %v", configuredDefinition, err)
 			continue
 		}
-		result = append(result, entryNode)
+
+		for _, entryNodeDefinition := range entryNodeDefinitions {
+			entryNode, err := parseEntryNode(entryNodeDefinition)
+			if err != nil {
+				log.Warnf("invalid entry node; ignoring: %v, error: %v", entryNodeDefinition, err)
+				continue
+			}
+			result = append(result, entryNode)
+		}
 	}
 
 	if len(result) == 0 {
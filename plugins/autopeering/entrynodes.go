@@ -0,0 +1,128 @@
+package autopeering
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+)
+
+const (
+	// entry nodes with this prefix are looked up via the DNS TXT records of the given name.
+	// every TXT record must contain an entry node definition "pubKey@host:port".
+	entryNodeDNSPrefix = "dns:"
+	// entry nodes with this prefix are looked up via the DNS SRV records "_autopeering._udp" of the given name.
+	// the public key is taken from a TXT record "autopeering=pubKey" of each SRV target.
+	entryNodeSRVPrefix = "srv:"
+	// the prefix of the TXT records of SRV targets which contain the public key.
+	entryNodeTXTPublicKeyPrefix = "autopeering="
+)
+
+var (
+	// holds the addresses of the entry nodes at the last resolution, keyed by their ID.
+	entryNodeAddresses   = make(map[string]string)
+	entryNodeAddressesMu sync.Mutex
+)
+
+// expandEntryNodeDefinition returns the entry node definitions "pubKey@host:port" of the given definition,
+// which can also be a DNS TXT or SRV record lookup.
+func expandEntryNodeDefinition(entryNodeDefinition string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(entryNodeDefinition, entryNodeDNSPrefix):
+		name := strings.TrimPrefix(entryNodeDefinition, entryNodeDNSPrefix)
+
+		records, err := net.LookupTXT(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: TXT lookup of %s failed: %s", ErrParsingEntryNode, name, err)
+		}
+
+		var definitions []string
+		for _, record := range records {
+			if record = strings.TrimSpace(record); strings.Contains(record, "@") {
+				definitions = append(definitions, record)
+			}
+		}
+		return definitions, nil
+
+	case strings.HasPrefix(entryNodeDefinition, entryNodeSRVPrefix):
+		name := strings.TrimPrefix(entryNodeDefinition, entryNodeSRVPrefix)
+
+		_, srvRecords, err := net.LookupSRV("autopeering", "udp", name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: SRV lookup of %s failed: %s", ErrParsingEntryNode, name, err)
+		}
+
+		var definitions []string
+		for _, srv := range srvRecords {
+			target := strings.TrimSuffix(srv.Target, ".")
+
+			pubKey, err := lookupEntryNodePublicKey(target)
+			if err != nil {
+				log.Warnf("ignoring entry node %s of %s: %s", target, name, err)
+				continue
+			}
+
+			definitions = append(definitions, fmt.Sprintf("%s@%s", pubKey, net.JoinHostPort(target, fmt.Sprint(srv.Port))))
+		}
+		return definitions, nil
+
+	default:
+		return []string{entryNodeDefinition}, nil
+	}
+}
+
+// lookupEntryNodePublicKey returns the public key contained in the TXT records of the given host.
+func lookupEntryNodePublicKey(host string) (string, error) {
+	records, err := net.LookupTXT(host)
+	if err != nil {
+		return "", fmt.Errorf("TXT lookup failed: %w", err)
+	}
+
+	for _, record := range records {
+		if record = strings.TrimSpace(record); strings.HasPrefix(record, entryNodeTXTPublicKeyPrefix) {
+			return strings.TrimPrefix(record, entryNodeTXTPublicKeyPrefix), nil
+		}
+	}
+
+	return "", fmt.Errorf("no TXT record with prefix '%s' found", entryNodeTXTPublicKeyPrefix)
+}
+
+// rememberEntryNodes stores the addresses of the given entry nodes and returns the ones whose address changed.
+func rememberEntryNodes(entryNodes []*peer.Peer) []*peer.Peer {
+	entryNodeAddressesMu.Lock()
+	defer entryNodeAddressesMu.Unlock()
+
+	var changed []*peer.Peer
+	for _, entryNode := range entryNodes {
+		id := entryNode.ID().String()
+		addr := entryNode.Address().String()
+
+		if entryNodeAddresses[id] != addr {
+			changed = append(changed, entryNode)
+		}
+		entryNodeAddresses[id] = addr
+	}
+	return changed
+}
+
+// reresolveEntryNodes resolves the entry nodes again and pings the ones whose address changed,
+// so that the discovery continues to use them under their new address.
+func reresolveEntryNodes() {
+	entryNodes, err := parseEntryNodes()
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+
+	for _, entryNode := range rememberEntryNodes(entryNodes) {
+		log.Infof("entry node %s resolved to %s", entryNode.ID(), entryNode.Address())
+
+		go func(entryNode *peer.Peer) {
+			if err := Discovery.Ping(entryNode); err != nil {
+				log.Warnf("couldn't ping entry node %s at %s: %s", entryNode.ID(), entryNode.Address(), err)
+			}
+		}(entryNode)
+	}
+}
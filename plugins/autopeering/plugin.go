@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/iputils"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
 
 	"github.com/gohornet/hornet/pkg/autopeering/services"
 	"github.com/gohornet/hornet/pkg/config"
@@ -43,8 +44,14 @@ func configure(p *node.Plugin) {
 		InboundNeighborSize:        config.NodeConfig.GetInt(config.CfgNetAutopeeringInboundPeers),
 		OutboundNeighborSize:       config.NodeConfig.GetInt(config.CfgNetAutopeeringOutboundPeers),
 		SaltLifetime:               time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringSaltLifetime)) * time.Minute,
-		OutboundUpdateInterval:     30 * time.Second,
-		FullOutboundUpdateInterval: 30 * time.Second,
+		OutboundUpdateInterval:     time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringOutboundUpdateIntervalSeconds)) * time.Second,
+		FullOutboundUpdateInterval: time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringFullOutboundUpdateIntervalSeconds)) * time.Second,
+	})
+	discover.SetParameters(discover.Parameters{
+		ReverifyInterval: time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringReverifyIntervalSeconds)) * time.Second,
+		QueryInterval:    time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringQueryIntervalSeconds)) * time.Second,
+		MaxManaged:       config.NodeConfig.GetInt(config.CfgNetAutopeeringMaxManagedPeers),
+		MaxReplacements:  config.NodeConfig.GetInt(config.CfgNetAutopeeringMaxReplacementPeers),
 	})
 	services.GossipServiceKey()
 	log = logger.NewLogger(p.Name)
@@ -59,6 +66,12 @@ func run(p *node.Plugin) {
 		start(local, shutdownSignal)
 		detachEvents()
 	}, shutdown.PriorityAutopeering)
+
+	if resolveInterval := time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringEntryNodesResolveIntervalMinutes)) * time.Minute; resolveInterval > 0 {
+		daemon.BackgroundWorker("Autopeering[EntryNodes]", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(reresolveEntryNodes, resolveInterval, shutdownSignal)
+		}, shutdown.PriorityAutopeering)
+	}
 }
 
 func configureEvents() {
@@ -103,7 +116,7 @@ func configureEvents() {
 			return
 		}
 
-		if err := peering.Manager().Add(gossipAddr, false, "", ev.Peer); err != nil {
+		if err := peering.Manager().Add(gossipAddr, false, "", nil, peer.TransportTCP, ev.Peer); err != nil {
 			log.Warnf("couldn't add autopeering peer %s", err)
 		}
 	})
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/iotaledger/hive.go/logger"
 	flag "github.com/spf13/pflag"
@@ -13,6 +14,9 @@ import (
 	"github.com/iotaledger/hive.go/node"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/logrotate"
+	"github.com/gohornet/hornet/pkg/logsink"
+	"github.com/gohornet/hornet/pkg/proxy"
 )
 
 var (
@@ -21,6 +25,8 @@ var (
 
 	version = flag.BoolP("version", "v", false, "Prints the HORNET version")
 	help    = flag.BoolP("help", "h", false, "Prints the HORNET help")
+
+	checkConfig = flag.Bool("check-config", false, "Validates the config and exits")
 )
 
 func AddPluginStatus(name string, status int) {
@@ -41,15 +47,84 @@ func ParseConfig() {
 	if err := config.FetchConfig(); err != nil {
 		panic(err)
 	}
+
+	// the environment variables with the HORNET_ prefix overwrite the settings of the config file
+	if err := config.LoadEnvironment(); err != nil {
+		panic(err)
+	}
 	parseParameters()
 
+	if err := config.LoadNetworkProfile(); err != nil {
+		panic(err)
+	}
+
+	validateConfig()
+
+	// the output paths of the logger can contain "syslog:" and "journald:" in addition to files
+	if err := logsink.RegisterSinks(); err != nil {
+		panic(err)
+	}
+
+	if err := configureLogRotation(); err != nil {
+		panic(err)
+	}
+
 	if err := logger.InitGlobalLogger(config.NodeConfig); err != nil {
 		panic(err)
 	}
+
+	// outbound connections are routed through the proxy if one is configured
+	if err := proxy.Configure(config.NodeConfig.GetString(config.CfgNetProxyURL)); err != nil {
+		panic(fmt.Errorf("'%s' is invalid: %w", config.CfgNetProxyURL, err))
+	}
+}
+
+// validateConfig checks the config for problems, which would otherwise only surface later during the startup of the node.
+// The node exits if there are any problems, or after the validation if --check-config is set. Warnings are only printed.
+func validateConfig() {
+	var errs []*config.ValidationError
+	for _, err := range config.ValidateConfig(append(append([]string{}, enabledPlugins...), disabledPlugins...)) {
+		if err.Warning {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", err)
+			continue
+		}
+		fmt.Fprintln(os.Stderr, err)
+		errs = append(errs, err)
+	}
+
+	if *checkConfig {
+		if len(errs) > 0 {
+			fmt.Fprintf(os.Stderr, "\nthe config contains %d problems\n", len(errs))
+			os.Exit(1)
+		}
+		fmt.Println("the config is valid")
+		os.Exit(0)
+	}
+
+	if len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "\nthe config contains %d problems, the node is not started. use --check-config to validate the config without starting the node\n", len(errs))
+		os.Exit(1)
+	}
+}
+
+// configureLogRotation replaces the log files in the output paths of the logger with rotating log files.
+func configureLogRotation() error {
+	maxSizeMB := config.NodeConfig.GetInt(config.CfgLoggerRotationMaxSizeMB)
+	intervalHours := config.NodeConfig.GetInt(config.CfgLoggerRotationIntervalHours)
+	if maxSizeMB == 0 && intervalHours == 0 {
+		return nil
+	}
+
+	if err := logrotate.RegisterSink(int64(maxSizeMB)*1024*1024, time.Duration(intervalHours)*time.Hour, config.NodeConfig.GetInt(config.CfgLoggerRotationMaxBackups)); err != nil {
+		return err
+	}
+
+	config.NodeConfig.Set(logger.ViperKeyOutputPaths, logrotate.RotatedOutputPaths(config.NodeConfig.GetStringSlice(logger.ViperKeyOutputPaths)))
+	return nil
 }
 
 func PrintConfig() {
-	config.PrintConfig([]string{config.CfgWebAPIBasicAuthPasswordHash, config.CfgWebAPIBasicAuthPasswordSalt, config.CfgDashboardBasicAuthPasswordHash, config.CfgDashboardBasicAuthPasswordSalt})
+	config.PrintConfig([]string{config.CfgWebAPIBasicAuthPasswordHash, config.CfgWebAPIBasicAuthPasswordSalt, config.CfgWebAPIJWTAuthSecret, config.CfgDashboardBasicAuthPasswordHash, config.CfgDashboardBasicAuthPasswordSalt, config.CfgDashboardAuthPasswordHash, config.CfgDashboardAuthPasswordSalt, config.CfgMQTTAuthPasswordHash, config.CfgMQTTAuthPasswordSalt, config.CfgMQTTBridgePassword, config.CfgNetProxyURL, config.CfgNetGossipTLSPrivateKey, config.CfgNotificationsWebhooks, config.CfgPrometheusPushInfluxDBPassword, config.CfgPrometheusPushRemoteWritePassword})
 }
 
 // HideConfigFlags hides all non essential flags from the help/usage text.
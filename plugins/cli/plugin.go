@@ -4,30 +4,22 @@ import (
 	"flag"
 	"fmt"
 	"strings"
-	"time"
 
-	"github.com/tcnksm/go-latest"
-
-	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
-	"github.com/iotaledger/hive.go/timeutil"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
 	"github.com/gohornet/hornet/pkg/profile"
-	"github.com/gohornet/hornet/pkg/shutdown"
 )
 
 var (
 	// AppVersion version number
-	AppVersion          = "0.5.0"
-	LatestGithubVersion = AppVersion
+	AppVersion = "0.5.0"
 
 	// AppName app code name
 	AppName = "HORNET"
-
-	githubTag *latest.GithubTag
 )
 
 var (
@@ -56,19 +48,18 @@ func parseParameters() {
 	for _, pluginName := range config.NodeConfig.GetStringSlice(node.CFG_ENABLE_PLUGINS) {
 		node.EnabledPlugins[strings.ToLower(pluginName)] = true
 	}
+
+	// the plugins which were started or stopped at runtime keep their state across restarts
+	statePath := config.NodeConfig.GetString(config.CfgNodePluginStatePath)
+	if err := plugincontrol.LoadState(statePath); err != nil {
+		panic(fmt.Errorf("loading the plugin state from '%s' failed: %w", statePath, err))
+	}
 }
 
 func configure(plugin *node.Plugin) {
 
 	log = logger.NewLogger(plugin.Name)
 
-	githubTag = &latest.GithubTag{
-		Owner:             "gohornet",
-		Repository:        "hornet",
-		FixVersionStrFunc: fixVersion,
-		TagFilterFunc:     includeVersionInCheck,
-	}
-
 	fmt.Printf(`
               ██╗  ██╗ ██████╗ ██████╗ ███╗   ██╗███████╗████████╗
               ██║  ██║██╔═══██╗██╔══██╗████╗  ██║██╔════╝╚══██╔══╝
@@ -79,7 +70,7 @@ func configure(plugin *node.Plugin) {
                                    v%s
 `+"\n\n", AppVersion)
 
-	checkLatestVersion()
+	configureUpdateCheck()
 
 	if config.NodeConfig.GetString(profile.CfgUseProfile) == profile.AutoProfileName {
 		log.Infof("Profile mode 'auto', Using profile '%s'", profile.LoadProfile().Name)
@@ -87,48 +78,22 @@ func configure(plugin *node.Plugin) {
 		log.Infof("Using profile '%s'", profile.LoadProfile().Name)
 	}
 
-	log.Info("Loading plugins ...")
-}
-
-func fixVersion(version string) string {
-	ver := strings.Replace(version, "v", "", 1)
-	if !strings.Contains(ver, "-rc.") {
-		ver = strings.Replace(ver, "-rc", "-rc.", 1)
-	}
-	return ver
-}
+	configureConfigReload()
 
-func includeVersionInCheck(version string) bool {
-	isPrerelease := func(ver string) bool {
-		return strings.Contains(ver, "-rc")
+	for name, running := range plugincontrol.Overrides() {
+		if running {
+			log.Warnf("Plugin '%s' is enabled by the plugin state in '%s', which overrides the config", name, config.NodeConfig.GetString(config.CfgNodePluginStatePath))
+			continue
+		}
+		log.Warnf("Plugin '%s' is disabled by the plugin state in '%s', which overrides the config", name, config.NodeConfig.GetString(config.CfgNodePluginStatePath))
 	}
 
-	if isPrerelease(AppVersion) {
-		// When using pre-release versions, check for any updates
-		return true
-	}
-
-	return !isPrerelease(version)
-}
-
-func checkLatestVersion() {
-
-	res, err := latest.Check(githubTag, fixVersion(AppVersion))
-	if err != nil {
-		log.Warnf("Update check failed: %s", err.Error())
-		return
-	}
-
-	if res.Outdated {
-		log.Infof("Update to %s available on https://github.com/gohornet/hornet/releases/latest", res.Current)
-		LatestGithubVersion = res.Current
-	}
+	log.Info("Loading plugins ...")
 }
 
 func run(_ *node.Plugin) {
 
-	// create a background worker that checks for latest version every hour
-	daemon.BackgroundWorker("Version update checker", func(shutdownSignal <-chan struct{}) {
-		timeutil.Ticker(checkLatestVersion, 1*time.Hour, shutdownSignal)
-	}, shutdown.PriorityUpdateCheck)
+	runUpdateCheck()
+
+	runConfigReloadSignalHandler()
 }
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+func configureConfigReload() {
+	config.Events.Reloaded.Attach(events.NewClosure(func() {
+		var level logger.Level
+		if err := level.UnmarshalText([]byte(config.ReloadableNodeConfig().GetString(logger.ViperKeyLevel))); err != nil {
+			log.Warnf("invalid log level under config option '%s': %s", logger.ViperKeyLevel, err)
+			return
+		}
+		logger.SetLevel(level)
+		log.Infof("set the log level to '%s' due to config reload", level)
+	}))
+}
+
+// runConfigReloadSignalHandler reloads the config if the node receives a SIGHUP.
+func runConfigReloadSignalHandler() {
+	daemon.BackgroundWorker("Config reload signal handler", func(shutdownSignal <-chan struct{}) {
+		reloadSignal := make(chan os.Signal, 1)
+		signal.Notify(reloadSignal, syscall.SIGHUP)
+		defer signal.Stop(reloadSignal)
+
+		for {
+			select {
+			case <-shutdownSignal:
+				return
+			case <-reloadSignal:
+				log.Info("Received SIGHUP, reloading the config ...")
+				if err := config.Reload(); err != nil {
+					log.Warnf("Reloading the config failed: %s", err)
+					continue
+				}
+				log.Info("Received SIGHUP, reloading the config ... done")
+			}
+		}
+	}, shutdown.PriorityConfigReload)
+}
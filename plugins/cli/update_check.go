@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/tcnksm/go-latest"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/proxy"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+const (
+	releasesURL = "https://github.com/gohornet/hornet/releases/latest"
+
+	// the timeout of the requests of the update check
+	updateCheckTimeout = 30 * time.Second
+)
+
+var (
+	githubTag *githubTagSource
+
+	// the latest released version, empty if the update check is disabled or no check succeeded yet
+	latestVersion = atomic.NewString("")
+	// whether a newer version than the running one was released
+	outdated = atomic.NewBool(false)
+)
+
+// LatestVersion returns the latest released version found by the update check.
+// Returns an empty string if the update check is disabled or no check succeeded yet.
+func LatestVersion() string {
+	return latestVersion.Load()
+}
+
+// IsOutdated tells whether a newer version than the running one was released.
+func IsOutdated() bool {
+	return outdated.Load()
+}
+
+func configureUpdateCheck() {
+	if !config.NodeConfig.GetBool(config.CfgNodeUpdateCheckEnabled) {
+		return
+	}
+
+	githubTag = &githubTagSource{
+		GithubTag: &latest.GithubTag{
+			Owner:             "gohornet",
+			Repository:        "hornet",
+			FixVersionStrFunc: fixVersion,
+			TagFilterFunc:     includeVersionInCheck,
+		},
+		client: proxy.HTTPClient(updateCheckTimeout),
+	}
+
+	checkLatestVersion()
+}
+
+func runUpdateCheck() {
+	if !config.NodeConfig.GetBool(config.CfgNodeUpdateCheckEnabled) {
+		return
+	}
+
+	// create a background worker that checks for the latest version in the configured interval
+	interval := time.Duration(config.NodeConfig.GetInt(config.CfgNodeUpdateCheckIntervalMinutes)) * time.Minute
+	daemon.BackgroundWorker("Version update checker", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(checkLatestVersion, interval, shutdownSignal)
+	}, shutdown.PriorityUpdateCheck)
+}
+
+// githubTagSource fetches the tags of the repository like latest.GithubTag,
+// but with the given HTTP client, so that the update check uses the configured proxy.
+type githubTagSource struct {
+	*latest.GithubTag
+	client *http.Client
+}
+
+func (s *githubTagSource) Fetch() (*latest.FetchResponse, error) {
+	fr := &latest.FetchResponse{Meta: &latest.Meta{}}
+
+	client := github.NewClient(s.client)
+	if s.URL != "" {
+		baseURL, err := url.Parse(s.URL)
+		if err != nil {
+			return fr, err
+		}
+		client.BaseURL = baseURL
+	}
+
+	tags, res, err := client.Repositories.ListTags(context.Background(), s.Owner, s.Repository, nil)
+	if err != nil {
+		return fr, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fr, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	for _, tag := range tags {
+		if !s.TagFilterFunc(tag.GetName()) {
+			fr.Malformeds = append(fr.Malformeds, tag.GetName())
+			continue
+		}
+		v, err := goversion.NewVersion(s.FixVersionStrFunc(tag.GetName()))
+		if err != nil {
+			fr.Malformeds = append(fr.Malformeds, s.FixVersionStrFunc(tag.GetName()))
+			continue
+		}
+		fr.Versions = append(fr.Versions, v)
+	}
+
+	return fr, nil
+}
+
+func fixVersion(version string) string {
+	ver := strings.Replace(version, "v", "", 1)
+	if !strings.Contains(ver, "-rc.") {
+		ver = strings.Replace(ver, "-rc", "-rc.", 1)
+	}
+	return ver
+}
+
+func includeVersionInCheck(version string) bool {
+	isPrerelease := func(ver string) bool {
+		return strings.Contains(ver, "-rc")
+	}
+
+	if isPrerelease(AppVersion) {
+		// When using pre-release versions, check for any updates
+		return true
+	}
+
+	return !isPrerelease(version)
+}
+
+// isBreakingUpdate tells whether the given release changes the major version,
+// or the minor version while the major version is zero, and may therefore be incompatible with the running version.
+func isBreakingUpdate(current string, release string) bool {
+	currentVer, err := goversion.NewVersion(fixVersion(current))
+	if err != nil {
+		return false
+	}
+	releaseVer, err := goversion.NewVersion(fixVersion(release))
+	if err != nil {
+		return false
+	}
+
+	currentSegments, releaseSegments := currentVer.Segments(), releaseVer.Segments()
+	if currentSegments[0] != releaseSegments[0] {
+		return true
+	}
+	return currentSegments[0] == 0 && currentSegments[1] != releaseSegments[1]
+}
+
+func checkLatestVersion() {
+
+	res, err := latest.Check(githubTag, fixVersion(AppVersion))
+	if err != nil {
+		log.Warnf("Update check failed: %s", err.Error())
+		return
+	}
+
+	latestVersion.Store(res.Current)
+	outdated.Store(res.Outdated)
+
+	if !res.Outdated {
+		return
+	}
+
+	if isBreakingUpdate(AppVersion, res.Current) {
+		log.Warnf("Running outdated version %s, the release %s may be incompatible with it, update on %s", AppVersion, res.Current, releasesURL)
+		return
+	}
+	log.Warnf("Running outdated version %s, update to %s available on %s", AppVersion, res.Current, releasesURL)
+}
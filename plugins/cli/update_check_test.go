@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tcnksm/go-latest"
+)
+
+func TestIsBreakingUpdate(t *testing.T) {
+	for _, test := range []struct {
+		current  string
+		release  string
+		breaking bool
+	}{
+		{"0.4.2", "0.4.3", false},
+		{"0.4.2", "v0.4.3", false},
+		{"0.4.2", "0.5.0", true},
+		{"0.4.2", "1.0.0", true},
+		{"1.2.0", "1.3.0", false},
+		{"1.2.0", "2.0.0", true},
+		{"0.4.2-rc1", "0.4.2", false},
+		{"0.4.2-rc.1", "0.5.0-rc2", true},
+		// versions which can't be parsed are never reported as breaking
+		{"dev", "0.5.0", false},
+		{"0.4.2", "latest", false},
+	} {
+		assert.Equal(t, test.breaking, isBreakingUpdate(test.current, test.release), "%s -> %s", test.current, test.release)
+	}
+}
+
+func TestGithubTagSourceFetch(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/repos/gohornet/hornet/tags", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "v0.4.3"}, {"name": "v0.5.0-rc1"}, {"name": "nightly"}]`))
+	}))
+	defer server.Close()
+
+	// release candidates are only included in the check if a release candidate is running
+	defer func(version string) {
+		AppVersion = version
+	}(AppVersion)
+	AppVersion = "0.4.2"
+
+	source := &githubTagSource{
+		GithubTag: &latest.GithubTag{
+			Owner:             "gohornet",
+			Repository:        "hornet",
+			FixVersionStrFunc: fixVersion,
+			TagFilterFunc:     includeVersionInCheck,
+			URL:               server.URL + "/",
+		},
+		client: server.Client(),
+	}
+
+	res, err := latest.Check(source, "0.4.2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	assert.True(t, res.Outdated)
+	assert.Equal(t, "0.4.3", res.Current)
+	assert.Equal(t, []string{"v0.5.0-rc1", "nightly"}, res.Malformeds)
+}
@@ -0,0 +1,123 @@
+package continuousprofiling
+
+import (
+	"os"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/profiling"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+const (
+	// the timeout of pushing a single profile to the remote endpoint
+	pushTimeout = 30 * time.Second
+)
+
+// PLUGIN ContinuousProfiling
+var (
+	// ContinuousProfiling is disabled by default
+	PLUGIN = node.NewPlugin("ContinuousProfiling", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	interval    time.Duration
+	cpuDuration time.Duration
+	profiles    []string
+
+	// either the storage or the pusher is set
+	storage *profiling.Storage
+	pusher  *profiling.Pusher
+)
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	interval = time.Duration(config.NodeConfig.GetInt(config.CfgProfilingContinuousIntervalSeconds)) * time.Second
+	cpuDuration = time.Duration(config.NodeConfig.GetInt(config.CfgProfilingContinuousCPUDurationSeconds)) * time.Second
+	profiles = config.NodeConfig.GetStringSlice(config.CfgProfilingContinuousProfiles)
+
+	if interval <= 0 {
+		log.Fatalf("'%s' must be greater than 0", config.CfgProfilingContinuousIntervalSeconds)
+	}
+
+	for _, name := range profiles {
+		if err := profiling.ValidateProfile(name); err != nil {
+			log.Fatalf("invalid '%s': %s", config.CfgProfilingContinuousProfiles, err)
+		}
+		if name == profiling.ProfileCPU && (cpuDuration <= 0 || cpuDuration >= interval) {
+			log.Fatalf("'%s' must be greater than 0 and less than '%s'", config.CfgProfilingContinuousCPUDurationSeconds, config.CfgProfilingContinuousIntervalSeconds)
+		}
+	}
+
+	if endpoint := config.NodeConfig.GetString(config.CfgProfilingContinuousPushEndpoint); endpoint != "" {
+		pusher = profiling.NewPusher(endpoint, os.Getenv("PROFILING_PUSH_API_KEY"), config.NodeConfig.GetString(config.CfgNodeAlias), pushTimeout)
+		log.Infof("Pushing the profiles %v every %v to %s", profiles, interval, endpoint)
+		return
+	}
+
+	directory := config.NodeConfig.GetString(config.CfgProfilingContinuousDirectory)
+	retention := time.Duration(config.NodeConfig.GetInt(config.CfgProfilingContinuousRetentionHours)) * time.Hour
+
+	var err error
+	if storage, err = profiling.NewStorage(directory, retention); err != nil {
+		log.Fatalf("unable to create the profiles directory: %s", err)
+	}
+	log.Infof("Storing the profiles %v every %v in %s", profiles, interval, directory)
+}
+
+// captureProfiles captures all configured profiles and stores or pushes them.
+func captureProfiles(shutdownSignal <-chan struct{}) {
+	for _, name := range profiles {
+		select {
+		case <-shutdownSignal:
+			return
+		default:
+		}
+
+		ts := time.Now()
+		data, err := profiling.Capture(name, cpuDuration, shutdownSignal)
+		if err != nil {
+			log.Warnf("Capturing the %s profile failed: %s", name, err)
+			continue
+		}
+
+		if pusher != nil {
+			if err := pusher.Push(name, ts, data); err != nil {
+				log.Warnf("Pushing the %s profile failed: %s", name, err)
+				continue
+			}
+			log.Debugf("Pushed the %s profile (%d bytes)", name, len(data))
+			continue
+		}
+
+		filePath, err := storage.Store(name, ts, data)
+		if err != nil {
+			log.Warnf("Storing the %s profile failed: %s", name, err)
+			continue
+		}
+		log.Debugf("Stored the %s profile in %s", name, filePath)
+	}
+
+	if storage != nil {
+		removed, err := storage.RemoveExpired(time.Now())
+		if err != nil {
+			log.Warnf("Removing expired profiles failed: %s", err)
+		}
+		if removed > 0 {
+			log.Debugf("Removed %d expired profiles", removed)
+		}
+	}
+}
+
+func run(_ *node.Plugin) {
+	daemon.BackgroundWorker("ContinuousProfiling", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting ContinuousProfiling ... done")
+		timeutil.Ticker(func() { captureProfiles(shutdownSignal) }, interval, shutdownSignal)
+		log.Info("Stopping ContinuousProfiling ... done")
+	}, shutdown.PriorityContinuousProfiling)
+}
@@ -1,7 +1,11 @@
 package coordinator
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -61,6 +65,7 @@ var (
 	onMilestoneConfirmed          *events.Closure
 	onIssuedCheckpointTransaction *events.Closure
 	onIssuedMilestone             *events.Closure
+	onQuorumNodeError             *events.Closure
 
 	ErrDatabaseTainted = errors.New("database is tainted. delete the coordinator database and start again with a local snapshot")
 )
@@ -86,7 +91,7 @@ func initCoordinator(bootstrap bool, startIndex uint32, powHandler *powpackage.H
 		return nil, ErrDatabaseTainted
 	}
 
-	seed, err := config.LoadHashFromEnvironment("COO_SEED")
+	signer, err := initSigner()
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +115,7 @@ func initCoordinator(bootstrap bool, startIndex uint32, powHandler *powpackage.H
 	belowMaxDepth = milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth))
 
 	coo := coordinator.New(
-		seed,
+		signer,
 		consts.SecurityLevel(config.NodeConfig.GetInt(config.CfgCoordinatorSecurityLevel)),
 		config.NodeConfig.GetInt(config.CfgCoordinatorMerkleTreeDepth),
 		config.NodeConfig.GetInt(config.CfgCoordinatorMWM),
@@ -125,6 +130,17 @@ func initCoordinator(bootstrap bool, startIndex uint32, powHandler *powpackage.H
 		return nil, err
 	}
 
+	coo.InitQuorum(
+		config.NodeConfig.GetStringSlice(config.CfgCoordinatorQuorumNodes),
+		config.NodeConfig.GetString(config.CfgCoordinatorQuorumJWT),
+		config.NodeConfig.GetInt(config.CfgCoordinatorQuorumMinAgreements),
+		time.Duration(config.NodeConfig.GetInt(config.CfgCoordinatorQuorumTimeoutMilliseconds))*time.Millisecond,
+	)
+
+	coo.Events.RecoveredState.Attach(events.NewClosure(func(index milestone.Index, tailTxHash hornet.Hash) {
+		log.Warnf("state file was outdated, recovered state from milestone (%d) in the database: %v", index, tailTxHash.Trytes())
+	}))
+
 	if err := coo.InitState(bootstrap, milestone.Index(startIndex)); err != nil {
 		return nil, err
 	}
@@ -132,6 +148,30 @@ func initCoordinator(bootstrap bool, startIndex uint32, powHandler *powpackage.H
 	return coo, nil
 }
 
+// initSigner creates the configured signer of the milestones.
+func initSigner() (coordinator.MilestoneSigner, error) {
+
+	switch strings.ToLower(config.NodeConfig.GetString(config.CfgCoordinatorSignerType)) {
+	case "local":
+		seed, err := config.LoadHashFromEnvironment("COO_SEED")
+		if err != nil {
+			return nil, err
+		}
+		return coordinator.NewSeedSigner(seed), nil
+
+	case "remote":
+		// the token is optional, it is only needed if the remote signer requires one
+		return coordinator.NewRemoteSigner(
+			config.NodeConfig.GetString(config.CfgCoordinatorSignerRemoteAddress),
+			config.NodeConfig.GetString(config.CfgCoordinatorSignerTLSCertificatePath),
+			os.Getenv("COO_SIGNER_TOKEN"),
+		)
+
+	default:
+		return nil, fmt.Errorf("invalid signer type under config option '%s': %s", config.CfgCoordinatorSignerType, config.NodeConfig.GetString(config.CfgCoordinatorSignerType))
+	}
+}
+
 func run(plugin *node.Plugin) {
 
 	// create a background worker that signals to issue new milestones
@@ -161,9 +201,12 @@ func run(plugin *node.Plugin) {
 		// init the last milestone hash
 		lastMilestoneHash = milestoneHash
 
-		// init the checkpoints
-		lastCheckpointHash = milestoneHash
+		// init the checkpoints, continue the chain of checkpoints of the persisted state
+		lastCheckpointHash = coo.State().LatestCheckpointHash
 		lastCheckpointIndex = 0
+		if !bytes.Equal(lastCheckpointHash, milestoneHash) {
+			lastCheckpointIndex = int(coo.State().LatestCheckpointIndex) + 1
+		}
 
 	coordinatorLoop:
 		for {
@@ -372,6 +415,10 @@ func configureEvents() {
 	onIssuedMilestone = events.NewClosure(func(index milestone.Index, tailTxHash hornet.Hash) {
 		log.Infof("milestone issued (%d): %v", index, tailTxHash.Trytes())
 	})
+
+	onQuorumNodeError = events.NewClosure(func(nodeURL string, err error) {
+		log.Warnf("quorum node %s did not answer: %v", nodeURL, err)
+	})
 }
 
 func attachEvents() {
@@ -379,10 +426,12 @@ func attachEvents() {
 	tangleplugin.Events.MilestoneConfirmed.Attach(onMilestoneConfirmed)
 	coo.Events.IssuedCheckpointTransaction.Attach(onIssuedCheckpointTransaction)
 	coo.Events.IssuedMilestone.Attach(onIssuedMilestone)
+	coo.Events.QuorumNodeError.Attach(onQuorumNodeError)
 }
 
 func detachEvents() {
 	tangleplugin.Events.BundleSolid.Detach(onBundleSolid)
 	tangleplugin.Events.MilestoneConfirmed.Detach(onMilestoneConfirmed)
 	coo.Events.IssuedMilestone.Detach(onIssuedMilestone)
+	coo.Events.QuorumNodeError.Detach(onQuorumNodeError)
 }
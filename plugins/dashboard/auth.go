@@ -0,0 +1,298 @@
+package dashboard
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/basicauth"
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+const (
+	// the environment variable which contains the token that can be used instead of the username and password
+	dashboardAuthTokenEnvKey = "DASHBOARD_AUTH_TOKEN"
+
+	sessionCookieName = "hornet_dashboard_session"
+	bearerAuthPrefix  = "Bearer "
+	loginRoute        = "/login"
+)
+
+var (
+	authEnabled      bool
+	publicReadAccess bool
+	authUsername     string
+	authPasswordHash string
+	authPasswordSalt string
+	authToken        string
+	sessionTimeout   time.Duration
+
+	// the login sessions with their expiry time, keyed by the session ID
+	sessions     = make(map[string]time.Time)
+	sessionsLock sync.Mutex
+)
+
+// Login is the request to the login route.
+// Either the username and password, or the token have to be given.
+type Login struct {
+	Username string `json:"username" form:"username"`
+	Password string `json:"password" form:"password"`
+	Token    string `json:"token" form:"token"`
+}
+
+// AuthStatus tells whether the dashboard login is enabled and whether the request is authenticated.
+type AuthStatus struct {
+	AuthEnabled      bool `json:"authEnabled"`
+	PublicReadAccess bool `json:"publicReadAccess"`
+	Authenticated    bool `json:"authenticated"`
+}
+
+func configureAuth() {
+	authEnabled = config.NodeConfig.GetBool(config.CfgDashboardAuthEnabled)
+	if !authEnabled {
+		return
+	}
+
+	publicReadAccess = config.NodeConfig.GetBool(config.CfgDashboardAuthPublicReadAccess)
+	authUsername = config.NodeConfig.GetString(config.CfgDashboardAuthUsername)
+	authPasswordHash = config.NodeConfig.GetString(config.CfgDashboardAuthPasswordHash)
+	authPasswordSalt = config.NodeConfig.GetString(config.CfgDashboardAuthPasswordSalt)
+	authToken = os.Getenv(dashboardAuthTokenEnvKey)
+	sessionTimeout = time.Duration(config.NodeConfig.GetInt(config.CfgDashboardAuthSessionTimeoutMinutes)) * time.Minute
+
+	if len(authUsername) == 0 && len(authToken) == 0 {
+		log.Fatalf("'%s' or the environment variable %s must be set if the dashboard login is enabled", config.CfgDashboardAuthUsername, dashboardAuthTokenEnvKey)
+	}
+
+	if len(authUsername) != 0 && len(authPasswordHash) != 64 {
+		log.Fatalf("'%s' must be 64 (sha256 hash) in length if the dashboard login is enabled", config.CfgDashboardAuthPasswordHash)
+	}
+
+	if len(authToken) != 0 && len(authToken) < 32 {
+		log.Fatalf("the environment variable %s must be at least 32 characters long", dashboardAuthTokenEnvKey)
+	}
+}
+
+func runAuth() {
+	if !authEnabled {
+		return
+	}
+
+	daemon.BackgroundWorker("Dashboard[SessionCleanup]", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(cleanupSessions, time.Minute, shutdownSignal)
+	}, shutdown.PriorityDashboard)
+}
+
+// createSession creates a new login session and returns its ID.
+func createSession() (string, error) {
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	sessionID := hex.EncodeToString(id)
+
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	sessions[sessionID] = time.Now().Add(sessionTimeout)
+	return sessionID, nil
+}
+
+// validSession returns whether the session exists and is not expired.
+// Every use of a session extends its lifetime.
+func validSession(sessionID string) bool {
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	expiry, exists := sessions[sessionID]
+	if !exists {
+		return false
+	}
+
+	if time.Now().After(expiry) {
+		delete(sessions, sessionID)
+		return false
+	}
+
+	sessions[sessionID] = time.Now().Add(sessionTimeout)
+	return true
+}
+
+func deleteSession(sessionID string) {
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	delete(sessions, sessionID)
+}
+
+// cleanupSessions removes the expired sessions.
+func cleanupSessions() {
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	now := time.Now()
+	for sessionID, expiry := range sessions {
+		if now.After(expiry) {
+			delete(sessions, sessionID)
+		}
+	}
+}
+
+// sessionID returns the session ID of the request, either from the session cookie or from the authorization header.
+func sessionID(c echo.Context) string {
+	if cookie, err := c.Cookie(sessionCookieName); err == nil {
+		return cookie.Value
+	}
+
+	if authHeader := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(authHeader, bearerAuthPrefix) {
+		return strings.TrimPrefix(authHeader, bearerAuthPrefix)
+	}
+	return ""
+}
+
+// isAuthenticated returns whether the request carries a valid session or the token.
+// All requests are authenticated if the dashboard login is disabled.
+func isAuthenticated(c echo.Context) bool {
+	if !authEnabled {
+		return true
+	}
+
+	if authToken != "" {
+		authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+		if strings.HasPrefix(authHeader, bearerAuthPrefix) &&
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(authHeader, bearerAuthPrefix)), []byte(authToken)) == 1 {
+			return true
+		}
+	}
+
+	id := sessionID(c)
+	return id != "" && validSession(id)
+}
+
+// hasWriteAccess returns whether the request may use the write actions of the dashboard.
+// They are only allowed if the dashboard is protected by basic auth or the request is logged in.
+func hasWriteAccess(c echo.Context) bool {
+	return config.NodeConfig.GetBool(config.CfgDashboardBasicAuthEnabled) || (authEnabled && isAuthenticated(c))
+}
+
+// requireWriteAccess guards the write actions of the dashboard.
+func requireWriteAccess(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !hasWriteAccess(c) {
+			if authEnabled {
+				return echo.ErrUnauthorized
+			}
+			return errors.Wrap(ErrForbidden, "dashboard basic auth or login must be enabled for write actions")
+		}
+		return next(c)
+	}
+}
+
+// requireReadAccess protects all routes except the login if the read-only panels are not public.
+// Unauthenticated page requests are redirected to the login page.
+func requireReadAccess(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !authEnabled || publicReadAccess {
+			return next(c)
+		}
+
+		path := c.Request().URL.Path
+		if path == loginRoute || strings.HasPrefix(path, "/api/auth/") || strings.HasPrefix(path, "/assets/") || strings.HasPrefix(path, "/app/") {
+			return next(c)
+		}
+
+		if isAuthenticated(c) {
+			return next(c)
+		}
+
+		if path == "/ws" || strings.HasPrefix(path, "/api/") {
+			return echo.ErrUnauthorized
+		}
+
+		return c.Redirect(http.StatusSeeOther, loginRoute)
+	}
+}
+
+func setupAuthRoutes(e *echo.Echo) {
+
+	e.GET(loginRoute, func(c echo.Context) error {
+		return c.HTML(http.StatusOK, loginPage)
+	})
+
+	e.GET("/api/auth/status", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &AuthStatus{
+			AuthEnabled:      authEnabled,
+			PublicReadAccess: !authEnabled || publicReadAccess,
+			Authenticated:    authEnabled && isAuthenticated(c),
+		})
+	})
+
+	e.POST("/api/auth/login", func(c echo.Context) error {
+		if !authEnabled {
+			return errors.Wrap(ErrForbidden, "the dashboard login is disabled")
+		}
+
+		login := &Login{}
+		if err := c.Bind(login); err != nil {
+			return errors.Wrap(ErrInvalidParameter, err.Error())
+		}
+
+		if !verifyLogin(login) {
+			return errors.Wrap(echo.ErrUnauthorized, "invalid credentials")
+		}
+
+		id, err := createSession()
+		if err != nil {
+			return errors.Wrap(ErrInternalError, err.Error())
+		}
+
+		c.SetCookie(&http.Cookie{
+			Name:     sessionCookieName,
+			Value:    id,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   config.NodeConfig.GetBool(config.CfgDashboardTLSEnabled),
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	e.POST("/api/auth/logout", func(c echo.Context) error {
+		if id := sessionID(c); id != "" {
+			deleteSession(id)
+		}
+
+		c.SetCookie(&http.Cookie{
+			Name:     sessionCookieName,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+
+		return c.NoContent(http.StatusNoContent)
+	})
+}
+
+// verifyLogin checks the username and password, or the token of the login.
+func verifyLogin(login *Login) bool {
+	if login.Token != "" {
+		return authToken != "" && subtle.ConstantTimeCompare([]byte(login.Token), []byte(authToken)) == 1
+	}
+
+	return authUsername != "" && login.Username == authUsername &&
+		basicauth.VerifyPassword(login.Password, authPasswordSalt, authPasswordHash)
+}
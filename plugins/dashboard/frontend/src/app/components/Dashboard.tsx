@@ -18,6 +18,7 @@ import ListGroup from "react-bootstrap/ListGroup";
 import Card from "react-bootstrap/Card";
 import Badge from "react-bootstrap/Badge";
 import MemChart from "app/components/MemChart";
+import {Plugins} from "app/components/Plugins";
 import {Choose, Otherwise, When} from 'tsx-control-statements/components';
 import * as style from '../../assets/main.css';
 
@@ -87,6 +88,11 @@ export class Dashboard extends React.Component<Props, any> {
                         <MemChart/>
                     </Col>
                 </Row>
+                <Row className={"mb-3"}>
+                    <Col>
+                        <Plugins/>
+                    </Col>
+                </Row>
             </Container>
         );
     }
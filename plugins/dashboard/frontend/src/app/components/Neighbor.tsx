@@ -117,6 +117,10 @@ export class Neighbor extends React.Component<Props, any> {
                                                 <Badge variant="success">Synced</Badge>
                                             </Otherwise>
                                         </Choose>
+                                        <If condition={last.info.persistentlyStale}>
+                                            {' '}
+                                            <Badge variant="danger">Stale</Badge>
+                                        </If>
                                     </small>
                                 </h5>
                             </Card.Title>
@@ -130,6 +134,19 @@ export class Neighbor extends React.Component<Props, any> {
                                             {last.connection_origin === 0 ? "Inbound" : "Outbound"}
                                             {!!last.info.autopeeringId ? " / autopeered)" : ")"}
                                         </ListGroup.Item>
+                                        <If condition={!!last.info.latencyMilliseconds}>
+                                            <ListGroup.Item>
+                                                Latency: {last.info.latencyMilliseconds.toFixed(1)} ms
+                                            </ListGroup.Item>
+                                        </If>
+                                        <If condition={!!last.info.latestHeartbeatAgeSeconds}>
+                                            <ListGroup.Item>
+                                                Latest Heartbeat: {last.info.latestHeartbeatAgeSeconds.toFixed(0)} s ago
+                                            </ListGroup.Item>
+                                        </If>
+                                        <ListGroup.Item>
+                                            New Transactions: {(last.info.newTransactionsRatio * 100).toFixed(1)} %
+                                        </ListGroup.Item>
                                         <If condition={!!last.heartbeat}>
                                             <ListGroup.Item>
                                                 Latest Solid Milestone Index: {' '}
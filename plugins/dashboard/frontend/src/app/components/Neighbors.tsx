@@ -3,6 +3,7 @@ import Container from "react-bootstrap/Container";
 import NodeStore from "app/stores/NodeStore";
 import {inject, observer} from "mobx-react";
 import {Neighbor} from "app/components/Neighbor";
+import {StaticNeighbors} from "app/components/StaticNeighbors";
 
 interface Props {
     nodeStore?: NodeStore;
@@ -52,6 +53,7 @@ export class Neighbors extends React.Component<Props, any> {
                 <p>
                     Currently connected and disconnected neighbors known to the node.
                 </p>
+                <StaticNeighbors/>
                 {neighborsEle}
             </Container>
         );
@@ -0,0 +1,101 @@
+import * as React from 'react';
+import Card from "react-bootstrap/Card";
+import Table from "react-bootstrap/Table";
+import Button from "react-bootstrap/Button";
+import Badge from "react-bootstrap/Badge";
+import Alert from "react-bootstrap/Alert";
+import {If} from 'tsx-control-statements/components';
+
+class Plugin {
+    name: string;
+    identifier: string;
+    running: boolean;
+}
+
+interface State {
+    plugins: Array<Plugin>;
+    editable: boolean;
+    pending: string;
+    error: string;
+}
+
+export class Plugins extends React.Component<any, State> {
+
+    constructor(props: Readonly<any>) {
+        super(props);
+        this.state = {
+            plugins: [],
+            editable: false,
+            pending: null,
+            error: null,
+        };
+    }
+
+    componentDidMount(): void {
+        this.load();
+    }
+
+    load = async () => {
+        try {
+            let res = await fetch(`/api/plugins`);
+            let result = await res.json();
+            this.setState({plugins: result.plugins || [], editable: result.editable});
+        } catch (err) {
+            this.setState({error: `${err}`});
+        }
+    };
+
+    toggle = async (plugin: Plugin) => {
+        this.setState({pending: plugin.identifier});
+        let action = plugin.running ? "stop" : "start";
+        let res = await fetch(`/api/plugins/${encodeURIComponent(plugin.identifier)}/${action}`, {method: "POST"});
+        if (!res.ok) {
+            this.setState({pending: null, error: await res.text()});
+            return;
+        }
+        this.setState({pending: null, error: null});
+        await this.load();
+    };
+
+    render() {
+        return (
+            <Card>
+                <Card.Body>
+                    <Card.Title>Plugins</Card.Title>
+                    <small>
+                        Plugins which can be started and stopped without restarting the node. The state is kept across restarts.
+                        {!this.state.editable && " Enable the dashboard basic auth to start or stop plugins."}
+                    </small>
+                    <If condition={!!this.state.error}>
+                        <Alert variant="danger" className="mt-2">{this.state.error}</Alert>
+                    </If>
+                    <Table size="sm" className="mt-2">
+                        <tbody>
+                        {this.state.plugins.map(p =>
+                            <tr key={p.identifier}>
+                                <td>{p.name}</td>
+                                <td>
+                                    {
+                                        p.running ?
+                                            <Badge variant="success">Running</Badge>
+                                            :
+                                            <Badge variant="secondary">Stopped</Badge>
+                                    }
+                                </td>
+                                {this.state.editable &&
+                                <td>
+                                    <Button size="sm" variant={p.running ? "outline-danger" : "outline-success"}
+                                            disabled={this.state.pending !== null}
+                                            onClick={() => this.toggle(p)}>
+                                        {p.running ? "Stop" : "Start"}
+                                    </Button>
+                                </td>}
+                            </tr>
+                        )}
+                        </tbody>
+                    </Table>
+                </Card.Body>
+            </Card>
+        );
+    }
+}
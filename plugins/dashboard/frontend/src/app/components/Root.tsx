@@ -17,6 +17,7 @@ import { ExplorerTagQueryResult } from "app/components/ExplorerTagResult";
 import { Explorer404 } from "app/components/Explorer404";
 import { Misc } from "app/components/Misc";
 import { Neighbors } from "app/components/Neighbors";
+import { Topology } from "app/components/Topology";
 import { Visualizer } from "app/components/Visualizer";
 import { Explorer420 } from "app/components/Explorer420";
 import { Helmet } from 'react-helmet'
@@ -64,6 +65,9 @@ export class Root extends React.Component<Props, any> {
                             <LinkContainer to="/neighbors">
                                 <Nav.Link>Neighbors</Nav.Link>
                             </LinkContainer>
+                            <LinkContainer to="/topology">
+                                <Nav.Link>Topology</Nav.Link>
+                            </LinkContainer>
                             <LinkContainer to="/explorer">
                                 <Nav.Link>Tangle Explorer</Nav.Link>
                             </LinkContainer>
@@ -101,6 +105,7 @@ export class Root extends React.Component<Props, any> {
                     <Route exact path="/dashboard" component={Dashboard} />
                     <Route exact path="/debug" component={Misc} />
                     <Route exact path="/neighbors" component={Neighbors} />
+                    <Route exact path="/topology" component={Topology} />
                     <Route exact path="/explorer/tx/:hash" component={ExplorerTransactionQueryResult} />
                     <Route exact path="/explorer/bundle/:hash" component={ExplorerBundleQueryResult} />
                     <Route exact path="/explorer/addr/:hash" component={ExplorerAddressQueryResult} />
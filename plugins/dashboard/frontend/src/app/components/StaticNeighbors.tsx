@@ -0,0 +1,147 @@
+import * as React from 'react';
+import Row from "react-bootstrap/Row";
+import Col from "react-bootstrap/Col";
+import Card from "react-bootstrap/Card";
+import Table from "react-bootstrap/Table";
+import Form from "react-bootstrap/Form";
+import FormControl from "react-bootstrap/FormControl";
+import Button from "react-bootstrap/Button";
+import Alert from "react-bootstrap/Alert";
+import {If} from 'tsx-control-statements/components';
+
+class StaticNeighbor {
+    identity: string;
+    alias: string;
+    preferIPv6: boolean;
+}
+
+interface State {
+    neighbors: Array<StaticNeighbor>;
+    editable: boolean;
+    identity: string;
+    alias: string;
+    preferIPv6: boolean;
+    error: string;
+}
+
+export class StaticNeighbors extends React.Component<any, State> {
+
+    constructor(props: Readonly<any>) {
+        super(props);
+        this.state = {
+            neighbors: [],
+            editable: false,
+            identity: "",
+            alias: "",
+            preferIPv6: false,
+            error: null,
+        };
+    }
+
+    componentDidMount(): void {
+        this.load();
+    }
+
+    load = async () => {
+        try {
+            let res = await fetch(`/api/neighbors`);
+            let result = await res.json();
+            this.setState({neighbors: result.neighbors || [], editable: result.editable});
+        } catch (err) {
+            this.setState({error: `${err}`});
+        }
+    };
+
+    add = async (e) => {
+        e.preventDefault();
+        let res = await fetch(`/api/neighbors`, {
+            method: "POST",
+            headers: {"Content-Type": "application/json"},
+            body: JSON.stringify({
+                identity: this.state.identity,
+                alias: this.state.alias,
+                preferIPv6: this.state.preferIPv6,
+            }),
+        });
+        if (!res.ok) {
+            this.setState({error: await res.text()});
+            return;
+        }
+        this.setState({identity: "", alias: "", preferIPv6: false, error: null});
+        await this.load();
+    };
+
+    remove = async (identity: string) => {
+        let res = await fetch(`/api/neighbors/${encodeURIComponent(identity)}`, {method: "DELETE"});
+        if (!res.ok) {
+            this.setState({error: await res.text()});
+            return;
+        }
+        this.setState({error: null});
+        await this.load();
+    };
+
+    render() {
+        return (
+            <Card className="mb-3">
+                <Card.Body>
+                    <Card.Title>Static Neighbors</Card.Title>
+                    <small>
+                        Neighbors of the peering config. Changes are applied immediately and persisted.
+                        {!this.state.editable && " Enable the dashboard basic auth to add or remove neighbors."}
+                    </small>
+                    <If condition={!!this.state.error}>
+                        <Alert variant="danger" className="mt-2">{this.state.error}</Alert>
+                    </If>
+                    <Table size="sm" className="mt-2">
+                        <thead>
+                        <tr>
+                            <th>Identity</th>
+                            <th>Alias</th>
+                            <th>Prefer IPv6</th>
+                            {this.state.editable && <th/>}
+                        </tr>
+                        </thead>
+                        <tbody>
+                        {this.state.neighbors.map(n =>
+                            <tr key={n.identity}>
+                                <td>{n.identity}</td>
+                                <td>{n.alias}</td>
+                                <td>{n.preferIPv6 ? "yes" : "no"}</td>
+                                {this.state.editable &&
+                                <td>
+                                    <Button size="sm" variant="outline-danger"
+                                            onClick={() => this.remove(n.identity)}>
+                                        Remove
+                                    </Button>
+                                </td>}
+                            </tr>
+                        )}
+                        </tbody>
+                    </Table>
+                    <If condition={this.state.editable}>
+                        <Form onSubmit={this.add}>
+                            <Row>
+                                <Col>
+                                    <FormControl placeholder="host:port" value={this.state.identity}
+                                                 onChange={(e: any) => this.setState({identity: e.target.value})}/>
+                                </Col>
+                                <Col>
+                                    <FormControl placeholder="alias" value={this.state.alias}
+                                                 onChange={(e: any) => this.setState({alias: e.target.value})}/>
+                                </Col>
+                                <Col xs="auto">
+                                    <Form.Check type="checkbox" label="prefer IPv6" checked={this.state.preferIPv6}
+                                                onChange={(e: any) => this.setState({preferIPv6: e.target.checked})}/>
+                                </Col>
+                                <Col xs="auto">
+                                    <Button type="submit" size="sm" disabled={!this.state.identity}>Add</Button>
+                                </Col>
+                            </Row>
+                        </Form>
+                    </If>
+                </Card.Body>
+            </Card>
+        );
+    }
+}
@@ -0,0 +1,163 @@
+import * as React from 'react';
+import Container from "react-bootstrap/Container";
+import Row from "react-bootstrap/Row";
+import Col from "react-bootstrap/Col";
+import Card from "react-bootstrap/Card";
+import Table from "react-bootstrap/Table";
+import Badge from "react-bootstrap/Badge";
+import NodeStore from "app/stores/NodeStore";
+import {inject, observer} from "mobx-react";
+
+interface Props {
+    nodeStore?: NodeStore;
+}
+
+const graphSize = 500;
+const graphRadius = 190;
+const nodeRadius = 22;
+const peerRadius = 14;
+
+const colorAutopeered = "#35b4db";
+const colorStatic = "#3a3cab";
+const colorDisconnected = "#c8c8c8";
+
+@inject("nodeStore")
+@observer
+export class Topology extends React.Component<Props, any> {
+    updateInterval: any;
+
+    constructor(props: Readonly<Props>) {
+        super(props);
+        this.state = {
+            topicsRegistered: false,
+        };
+    }
+
+    componentDidMount(): void {
+        this.updateInterval = setInterval(() => this.updateTick(), 500);
+        this.props.nodeStore.registerNeighborTopics();
+    }
+
+    componentWillUnmount(): void {
+        clearInterval(this.updateInterval);
+        this.props.nodeStore.unregisterNeighborTopics();
+    }
+
+    updateTick = () => {
+        if (this.props.nodeStore.websocketConnected && !this.state.topicsRegistered) {
+            this.props.nodeStore.registerNeighborTopics();
+            this.setState({topicsRegistered: true})
+        }
+
+        if (!this.props.nodeStore.websocketConnected && this.state.topicsRegistered) {
+            this.setState({topicsRegistered: false})
+        }
+    }
+
+    render() {
+        let peers = [];
+        this.props.nodeStore.neighbor_metrics.forEach((metrics, identity) => {
+            let last = metrics.current;
+            if (!last) {
+                return;
+            }
+            peers.push({
+                identity: identity,
+                name: last.alias || last.origin_addr || identity,
+                connected: last.connected,
+                autopeered: !!last.info && (last.info.autopeered || !!last.info.autopeeringId),
+                latency: last.info ? last.info.latencyMilliseconds : 0,
+                newTxs: metrics.newTxPerSecond,
+                sentTxs: metrics.sentTxPerSecond,
+            });
+        });
+        peers.sort((a, b) => a.name.localeCompare(b.name));
+
+        let center = graphSize / 2;
+        let edges = [];
+        let vertices = [];
+        peers.forEach((peer, i) => {
+            let angle = (2 * Math.PI * i) / peers.length - Math.PI / 2;
+            let x = center + graphRadius * Math.cos(angle);
+            let y = center + graphRadius * Math.sin(angle);
+            let color = !peer.connected ? colorDisconnected : peer.autopeered ? colorAutopeered : colorStatic;
+
+            // the width of the edge scales with the amount of exchanged transactions
+            let width = 1 + Math.min(8, Math.log2(1 + Math.max(0, peer.newTxs + peer.sentTxs)));
+
+            edges.push(
+                <line key={peer.identity} x1={center} y1={center} x2={x} y2={y}
+                      stroke={color} strokeWidth={width}
+                      strokeDasharray={peer.connected ? undefined : "4 4"}/>
+            );
+            vertices.push(
+                <g key={peer.identity}>
+                    <circle cx={x} cy={y} r={peerRadius} fill={color}>
+                        <title>{peer.identity}</title>
+                    </circle>
+                    <text x={x} y={y + peerRadius + 12} textAnchor="middle" fontSize={11}>
+                        {peer.name}
+                    </text>
+                </g>
+            );
+        });
+
+        return (
+            <Container fluid>
+                <h3>Topology</h3>
+                <p>
+                    The neighbors of the node. Autopeered neighbors are shown in light blue,
+                    static ones in dark blue, disconnected ones in grey.
+                    The width of a connection reflects the amount of exchanged transactions.
+                </p>
+                <Row className={"mb-3"}>
+                    <Col xs={12} lg={6}>
+                        <Card>
+                            <Card.Body>
+                                <svg viewBox={`0 0 ${graphSize} ${graphSize}`} width="100%">
+                                    {edges}
+                                    <circle cx={center} cy={center} r={nodeRadius} fill="#eb8634"/>
+                                    <text x={center} y={center + 4} textAnchor="middle" fontSize={11} fill="#fff">
+                                        Node
+                                    </text>
+                                    {vertices}
+                                </svg>
+                            </Card.Body>
+                        </Card>
+                    </Col>
+                    <Col xs={12} lg={6}>
+                        <Card>
+                            <Card.Body>
+                                <Table responsive size="sm">
+                                    <thead>
+                                    <tr>
+                                        <th>Neighbor</th>
+                                        <th>Type</th>
+                                        <th>Latency</th>
+                                        <th>New Txs/s</th>
+                                        <th>Sent Txs/s</th>
+                                    </tr>
+                                    </thead>
+                                    <tbody>
+                                    {peers.map(peer => (
+                                        <tr key={peer.identity}>
+                                            <td>
+                                                {peer.name}
+                                                {!peer.connected && <> <Badge variant="secondary">Not Connected</Badge></>}
+                                            </td>
+                                            <td>{peer.autopeered ? "Autopeered" : "Static"}</td>
+                                            <td>{peer.latency ? `${peer.latency.toFixed(1)} ms` : "-"}</td>
+                                            <td>{peer.newTxs}</td>
+                                            <td>{peer.sentTxs}</td>
+                                        </tr>
+                                    ))}
+                                    </tbody>
+                                </Table>
+                            </Card.Body>
+                        </Card>
+                    </Col>
+                </Row>
+            </Container>
+        );
+    }
+}
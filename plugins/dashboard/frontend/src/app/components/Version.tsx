@@ -19,7 +19,7 @@ export default class Version extends React.Component<Props, any> {
                     {' '}
                     <Button href="https://github.com/gohornet/hornet/releases/latest"
                             size="sm"
-                            variant="outline-info">Update available</Button>
+                            variant="outline-info">Update to {this.props.nodeStore.status.latest_version} available</Button>
                 </If>
             </React.Fragment>
         );
@@ -141,6 +141,22 @@ class NeighborMetrics {
         return this.collected[index];
     }
 
+    // the amount of new transactions received from the neighbor since the last metric (one per second)
+    get newTxPerSecond(): number {
+        if (!this.current || !this.secondLast) {
+            return 0;
+        }
+        return this.current.info.numberOfNewTransactions - this.secondLast.info.numberOfNewTransactions;
+    }
+
+    // the amount of transactions sent to the neighbor since the last metric (one per second)
+    get sentTxPerSecond(): number {
+        if (!this.current || !this.secondLast) {
+            return 0;
+        }
+        return this.current.info.numberOfSentTransactions - this.secondLast.info.numberOfSentTransactions;
+    }
+
     get currentNetIO(): NetworkIO {
         if (this.current && this.secondLast) {
             return {
@@ -254,6 +270,12 @@ class NeighborInfo {
     numberOfDroppedSentPackets: number;
     connectionType: string;
     autopeeringId: string;
+    autopeered: boolean;
+    latencyMilliseconds: number;
+    latestHeartbeatAgeSeconds: number;
+    newTransactionsRatio: number;
+    staleChecks: number;
+    persistentlyStale: boolean;
     connected: boolean;
 }
 
@@ -0,0 +1,47 @@
+package dashboard
+
+// loginPage is the login form of the dashboard.
+const loginPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>HORNET Dashboard Login</title>
+  <style>
+    body { font-family: sans-serif; max-width: 360px; margin: 80px auto; padding: 0 16px; }
+    input { width: 100%; padding: 8px; margin-bottom: 12px; box-sizing: border-box; }
+    button { padding: 8px 16px; }
+    #error { color: #c00; margin-top: 12px; }
+  </style>
+</head>
+<body>
+  <h1>HORNET Dashboard</h1>
+  <form id="form">
+    <input id="username" name="username" placeholder="Username" autocomplete="username">
+    <input id="password" name="password" type="password" placeholder="Password" autocomplete="current-password">
+    <input id="token" name="token" type="password" placeholder="or Token">
+    <button type="submit">Login</button>
+  </form>
+  <div id="error"></div>
+  <script>
+    document.getElementById("form").addEventListener("submit", e => {
+      e.preventDefault();
+      fetch("/api/auth/login", {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({
+          username: document.getElementById("username").value,
+          password: document.getElementById("password").value,
+          token: document.getElementById("token").value
+        })
+      }).then(r => {
+        if (r.ok) {
+          window.location = "/";
+          return;
+        }
+        document.getElementById("error").textContent = r.status === 401 ? "Invalid credentials" : "Login failed";
+      });
+    });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/peering"
+	peeringplugin "github.com/gohornet/hornet/plugins/peering"
+)
+
+// StaticNeighbors contains the static neighbors of the peering config.
+type StaticNeighbors struct {
+	Neighbors []config.PeerConfig `json:"neighbors"`
+	// whether neighbors can be added and removed via the dashboard
+	Editable bool `json:"editable"`
+}
+
+func setupNeighborRoutes(routeGroup *echo.Group) {
+
+	routeGroup.GET("/neighbors", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &StaticNeighbors{
+			Neighbors: peeringplugin.StaticPeers(),
+			Editable:  hasWriteAccess(c),
+		})
+	})
+
+	routeGroup.POST("/neighbors", func(c echo.Context) error {
+		neighbor := &config.PeerConfig{}
+		if err := c.Bind(neighbor); err != nil {
+			return errors.Wrap(ErrInvalidParameter, err.Error())
+		}
+
+		neighbor.ID = strings.TrimPrefix(strings.TrimSpace(neighbor.ID), "tcp://")
+		if neighbor.ID == "" {
+			return errors.Wrap(ErrInvalidParameter, "identity must not be empty")
+		}
+
+		if err := peeringplugin.AddStaticPeer(neighbor.ID, neighbor.Alias, neighbor.PreferIPv6); err != nil {
+			if !errors.Is(err, peering.ErrPeerAlreadyConnected) && !errors.Is(err, peering.ErrPeerAlreadyInReconnect) {
+				return errors.Wrap(ErrInvalidParameter, err.Error())
+			}
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+
+	routeGroup.DELETE("/neighbors/:identity", func(c echo.Context) error {
+		identity, err := url.PathUnescape(c.Param("identity"))
+		if err != nil {
+			return errors.Wrap(ErrInvalidParameter, err.Error())
+		}
+
+		removed, err := peeringplugin.RemoveStaticPeer(identity)
+		if err != nil {
+			return errors.Wrap(ErrInternalError, err.Error())
+		}
+		if !removed {
+			return errors.Wrapf(ErrNotFound, "neighbor %s", identity)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+}
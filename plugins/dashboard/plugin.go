@@ -1,6 +1,8 @@
 package dashboard
 
 import (
+	"crypto/tls"
+	"net"
 	"net/http"
 	"runtime"
 	"time"
@@ -18,6 +20,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/basicauth"
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ipfilter"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
@@ -25,6 +28,7 @@ import (
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
 	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/utils"
 	"github.com/gohornet/hornet/plugins/autopeering"
 	"github.com/gohornet/hornet/plugins/cli"
 	databaseplugin "github.com/gohornet/hornet/plugins/database"
@@ -86,6 +90,7 @@ func configure(plugin *node.Plugin) {
 		task.Return(nil)
 	}, workerpool.WorkerCount(wsSendWorkerCount), workerpool.QueueSize(wsSendWorkerQueueSize))
 
+	configureAuth()
 	configureLiveFeed()
 	configureVisualizer()
 	configureTipSelMetric()
@@ -121,10 +126,44 @@ func run(_ *node.Plugin) {
 		}))
 	}
 
+	e.Use(requireReadAccess)
+
 	setupRoutes(e)
 	bindAddr := config.NodeConfig.GetString(config.CfgDashboardBindAddress)
-	log.Infof("You can now access the dashboard using: http://%s", bindAddr)
-	go e.Start(bindAddr)
+
+	ipFilter, err := ipfilter.NewFromConfig(config.CfgDashboardIPFilterAllowedNetworks, config.CfgDashboardIPFilterDeniedNetworks, log)
+	if err != nil {
+		log.Fatalf("Invalid IP filter of the dashboard: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		log.Fatalf("Unable to listen on %s: %s", bindAddr, err)
+	}
+
+	if config.NodeConfig.GetBool(config.CfgDashboardTLSEnabled) {
+		tlsConfig, certReloader, err := utils.LoadTLSConfig(config.NodeConfig.GetString(config.CfgDashboardTLSCertPath), config.NodeConfig.GetString(config.CfgDashboardTLSKeyPath))
+		if err != nil {
+			log.Fatalf("Loading the TLS certificate of the dashboard failed: %s", err)
+		}
+
+		// the certificate is also reloaded on SIGHUP, in case the files were replaced with the same modification time
+		config.Events.Reloaded.Attach(events.NewClosure(func() {
+			if err := certReloader.Reload(); err != nil {
+				log.Warnf("Reloading the TLS certificate of the dashboard failed: %s", err)
+			}
+		}))
+
+		e.TLSServer.TLSConfig = tlsConfig
+		e.TLSServer.Addr = bindAddr
+		e.TLSListener = tls.NewListener(ipFilter.Listener(listener), tlsConfig)
+		log.Infof("You can now access the dashboard using: https://%s", bindAddr)
+		go e.StartServer(e.TLSServer)
+	} else {
+		e.Listener = ipFilter.Listener(listener)
+		log.Infof("You can now access the dashboard using: http://%s", bindAddr)
+		go e.Start(bindAddr)
+	}
 
 	onTPSMetricsUpdated := events.NewClosure(func(tpsMetrics *metricsplugin.TPSMetrics) {
 		wsSendWorkerPool.TrySubmit(tpsMetrics)
@@ -174,6 +213,8 @@ func run(_ *node.Plugin) {
 	runDatabaseSizeCollector()
 	// run the spammer feed
 	runSpammerMetricWorker()
+	// run the cleanup of the expired login sessions
+	runAuth()
 }
 
 func getMilestoneTailHash(index milestone.Index) hornet.Hash {
@@ -359,7 +400,7 @@ func currentNodeStatus() *nodestatus {
 		requestedMilestone = peekedRequest.MilestoneIndex
 	}
 	status.Version = cli.AppVersion
-	status.LatestVersion = cli.LatestGithubVersion
+	status.LatestVersion = cli.LatestVersion()
 	status.Uptime = time.Since(nodeStartAt).Milliseconds()
 	if !node.IsSkipped(autopeering.PLUGIN) {
 		status.AutopeeringID = autopeering.ID
@@ -0,0 +1,52 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/plugincontrol"
+)
+
+// Plugins contains the plugins which can be started and stopped at runtime.
+type Plugins struct {
+	Plugins []plugincontrol.Status `json:"plugins"`
+	// whether the plugins can be started and stopped via the dashboard
+	Editable bool `json:"editable"`
+}
+
+func setupPluginRoutes(routeGroup *echo.Group) {
+
+	routeGroup.GET("/plugins", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, &Plugins{
+			Plugins:  plugincontrol.Plugins(),
+			Editable: hasWriteAccess(c),
+		})
+	})
+
+	routeGroup.POST("/plugins/:plugin/start", func(c echo.Context) error {
+		if err := plugincontrol.Start(c.Param("plugin")); err != nil {
+			return wrapPluginControlError(err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+
+	routeGroup.POST("/plugins/:plugin/stop", func(c echo.Context) error {
+		if err := plugincontrol.Stop(c.Param("plugin")); err != nil {
+			return wrapPluginControlError(err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+}
+
+func wrapPluginControlError(err error) error {
+	switch err {
+	case plugincontrol.ErrPluginNotControllable:
+		return errors.Wrap(ErrNotFound, err.Error())
+	case plugincontrol.ErrPluginRunning, plugincontrol.ErrPluginNotRunning, plugincontrol.ErrNodeShuttingDown:
+		return errors.Wrap(ErrInvalidParameter, err.Error())
+	default:
+		return errors.Wrap(ErrInternalError, err.Error())
+	}
+}
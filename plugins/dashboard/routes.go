@@ -83,6 +83,8 @@ func setupRoutes(e *echo.Echo) {
 		e.GET("/assets/*", echo.WrapHandler(http.StripPrefix("/assets", http.FileServer(assetsBox))))
 	}
 
+	setupAuthRoutes(e)
+
 	e.GET("/ws", websocketRoute)
 	e.GET("/", indexRoute)
 
@@ -92,6 +94,9 @@ func setupRoutes(e *echo.Echo) {
 	apiRoutes := e.Group("/api")
 
 	setupExplorerRoutes(apiRoutes)
+	setupNeighborRoutes(apiRoutes)
+	setupSpammerRoutes(apiRoutes)
+	setupPluginRoutes(apiRoutes)
 
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
 		c.Logger().Error(err)
@@ -162,6 +167,9 @@ func websocketRoute(ctx echo.Context) error {
 		case MsgTypeDatabaseCleanupEvent:
 			client.Send(&msg{MsgTypeDatabaseCleanupEvent, lastDbCleanup})
 
+		case MsgTypeVertex:
+			sendVisualizerHistory(client)
+
 		case MsgTypeMs:
 			start := tangle.GetLatestMilestoneIndex()
 			for i := start - 10; i <= start; i++ {
@@ -226,6 +234,11 @@ func websocketRoute(ctx echo.Context) error {
 								topicsLock.Lock()
 								delete(registeredTopics, topic)
 								topicsLock.Unlock()
+
+								if topic == MsgTypeVertex {
+									// the visualizer is reset if it is left, so the history has to be sent again
+									delete(initValuesSent, topic)
+								}
 							}
 						}
 					}
@@ -1,6 +1,11 @@
 package dashboard
 
 import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/workerpool"
@@ -44,3 +49,41 @@ func runSpammerMetricWorker() {
 		log.Info("Stopping Dashboard[SpammerMetricUpdater] ... done")
 	}, shutdown.PriorityDashboard)
 }
+
+func setupSpammerRoutes(routeGroup *echo.Group) {
+
+	routeGroup.GET("/spammer", func(c echo.Context) error {
+		status, err := spammerPlugin.GetStatus()
+		if err != nil {
+			return errors.Wrap(ErrNotFound, err.Error())
+		}
+		return c.JSON(http.StatusOK, status)
+	})
+
+	// the settings of the request replace the current settings, missing fields keep their current value
+	routeGroup.POST("/spammer/start", func(c echo.Context) error {
+		status, err := spammerPlugin.GetStatus()
+		if err != nil {
+			return errors.Wrap(ErrNotFound, err.Error())
+		}
+
+		settings := status.Settings
+		if c.Request().ContentLength != 0 {
+			if err := c.Bind(&settings); err != nil {
+				return errors.Wrap(ErrInvalidParameter, err.Error())
+			}
+		}
+
+		if err := spammerPlugin.Start(&settings); err != nil {
+			return errors.Wrap(ErrInvalidParameter, err.Error())
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+
+	routeGroup.POST("/spammer/stop", func(c echo.Context) error {
+		if err := spammerPlugin.Stop(); err != nil {
+			return errors.Wrap(ErrNotFound, err.Error())
+		}
+		return c.NoContent(http.StatusNoContent)
+	}, requireWriteAccess)
+}
@@ -42,11 +42,11 @@ func runTipSelMetricWorker() {
 	})
 
 	daemon.BackgroundWorker("Dashboard[TipSelMetricUpdater]", func(shutdownSignal <-chan struct{}) {
-		urts.TipSelector.Events.TipSelPerformed.Attach(onTipSelPerformed)
+		urts.Selector.TipSelPerformedEvent().Attach(onTipSelPerformed)
 		tipSelMetricWorkerPool.Start()
 		<-shutdownSignal
 		log.Info("Stopping Dashboard[TipSelMetricUpdater] ...")
-		urts.TipSelector.Events.TipSelPerformed.Detach(onTipSelPerformed)
+		urts.Selector.TipSelPerformedEvent().Detach(onTipSelPerformed)
 		tipSelMetricWorkerPool.StopAndWait()
 		log.Info("Stopping Dashboard[TipSelMetricUpdater] ... done")
 	}, shutdown.PriorityDashboard)
@@ -6,6 +6,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/workerpool"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
@@ -26,6 +27,7 @@ var (
 	visualizerWorkerCount     = 1
 	visualizerWorkerQueueSize = 500
 	visualizerWorkerPool      *workerpool.WorkerPool
+	visualizerVertexHistory   *visualizerHistory
 )
 
 // vertex defines a vertex in a DAG.
@@ -58,7 +60,10 @@ type tipinfo struct {
 }
 
 func configureVisualizer() {
+	visualizerVertexHistory = newVisualizerHistory(config.NodeConfig.GetInt(config.CfgDashboardVisualizerHistorySize))
+
 	visualizerWorkerPool = workerpool.New(func(task workerpool.Task) {
+		visualizerVertexHistory.record(task.Param(0).(*msg))
 		hub.BroadcastMsg(task.Param(0), task.Param(1).(bool))
 		task.Return(nil)
 	}, workerpool.WorkerCount(visualizerWorkerCount), workerpool.QueueSize(visualizerWorkerQueueSize))
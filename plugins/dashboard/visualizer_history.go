@@ -0,0 +1,102 @@
+package dashboard
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/websockethub"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	tanglemodel "github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// visualizerHistory keeps the most recent vertices of the visualizer,
+// so that newly connected clients don't start with an empty graph.
+type visualizerHistory struct {
+	sync.Mutex
+	size int
+	// the short IDs of the vertices in the order they were received
+	order []string
+	// the vertices keyed by their short ID
+	vertices map[string]*vertex
+}
+
+func newVisualizerHistory(size int) *visualizerHistory {
+	return &visualizerHistory{
+		size:     size,
+		order:    make([]string, 0, size),
+		vertices: make(map[string]*vertex, size),
+	}
+}
+
+// record applies a visualizer message to the history.
+func (h *visualizerHistory) record(m *msg) {
+	if h.size <= 0 {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	switch m.Type {
+	case MsgTypeVertex:
+		v := *m.Data.(*vertex)
+		id := v.ID[:VisualizerIdLength]
+		if existing, exists := h.vertices[id]; exists {
+			// keep the state that was already signaled for the vertex
+			v.IsSolid = v.IsSolid || existing.IsSolid
+			v.IsMilestone = existing.IsMilestone
+			v.IsTip = existing.IsTip
+			h.vertices[id] = &v
+			return
+		}
+
+		if len(h.order) >= h.size {
+			delete(h.vertices, h.order[0])
+			h.order = h.order[1:]
+		}
+		h.order = append(h.order, id)
+		h.vertices[id] = &v
+
+	case MsgTypeSolidInfo:
+		if v, exists := h.vertices[m.Data.(*metainfo).ID]; exists {
+			v.IsSolid = true
+		}
+
+	case MsgTypeMilestoneInfo:
+		if v, exists := h.vertices[m.Data.(*metainfo).ID]; exists {
+			v.IsMilestone = true
+		}
+
+	case MsgTypeTipInfo:
+		info := m.Data.(*tipinfo)
+		if v, exists := h.vertices[info.ID]; exists {
+			v.IsTip = info.IsTip
+		}
+	}
+}
+
+// snapshot returns copies of the vertices in the history in the order they were received.
+func (h *visualizerHistory) snapshot() []*vertex {
+	h.Lock()
+	defer h.Unlock()
+
+	vertices := make([]*vertex, 0, len(h.order))
+	for _, id := range h.order {
+		v := *h.vertices[id]
+		vertices = append(vertices, &v)
+	}
+	return vertices
+}
+
+// sendVisualizerHistory sends the recent vertices to the client.
+// The confirmation state is refreshed from the database, since confirmations are only signaled for the milestones.
+func sendVisualizerHistory(client *websockethub.Client) {
+	for _, v := range visualizerVertexHistory.snapshot() {
+		if cachedTxMeta := tanglemodel.GetCachedTxMetadataOrNil(hornet.HashFromHashTrytes(v.ID)); cachedTxMeta != nil { // meta +1
+			v.IsSolid = cachedTxMeta.GetMetadata().IsSolid()
+			v.IsConfirmed = cachedTxMeta.GetMetadata().IsConfirmed()
+			cachedTxMeta.Release(true) // meta -1
+		}
+		client.Send(&msg{MsgTypeVertex, v}, true)
+	}
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+var (
+	// ErrBackupRunning is returned if a database backup is already running.
+	ErrBackupRunning = errors.New("database backup is already running")
+
+	backupStatus     BackupStatus
+	backupStatusLock sync.Mutex
+)
+
+// BackupStatus is the progress of the online database backup.
+type BackupStatus struct {
+	// Whether the backup is currently running.
+	Running bool `json:"running"`
+	// The unix timestamp the last backup was started.
+	Start int64 `json:"start"`
+	// The unix timestamp the last backup finished.
+	End int64 `json:"end"`
+	// The directory of the last backup.
+	Path string `json:"path"`
+	// The description of the last backup, if it succeeded.
+	Info *tangle.BackupInfo `json:"info,omitempty"`
+	// The error of the last backup, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// GetBackupStatus returns the progress of the online database backup.
+func GetBackupStatus() BackupStatus {
+	backupStatusLock.Lock()
+	defer backupStatusLock.Unlock()
+	return backupStatus
+}
+
+// StartBackup starts a backup of the databases and the local snapshot files while the node is running.
+// Every backup is written to its own directory in the backup path, the progress can be queried with GetBackupStatus.
+func StartBackup() error {
+	backupStatusLock.Lock()
+	defer backupStatusLock.Unlock()
+
+	if backupStatus.Running {
+		return ErrBackupRunning
+	}
+
+	start := time.Now()
+	backupStatus = BackupStatus{
+		Running: true,
+		Start:   start.Unix(),
+		Path:    path.Join(config.NodeConfig.GetString(config.CfgDatabaseBackupPath), fmt.Sprintf("backup-%d", start.Unix())),
+	}
+
+	backupPath := backupStatus.Path
+	if err := daemon.BackgroundWorker("Database[Backup]", func(shutdownSignal <-chan struct{}) {
+		runBackup(backupPath, shutdownSignal)
+	}, shutdown.PriorityDatabaseBackup); err != nil {
+		backupStatus.Running = false
+		return err
+	}
+
+	return nil
+}
+
+func runBackup(backupPath string, shutdownSignal <-chan struct{}) {
+	log.Infof("running online database backup to %s...", backupPath)
+
+	start := time.Now()
+	info, err := tangle.BackupDatabases(backupPath, []string{
+		config.NodeConfig.GetString(config.CfgLocalSnapshotsPath),
+		config.NodeConfig.GetString(config.CfgLocalSnapshotsDeltaPath),
+	}, shutdownSignal)
+	end := time.Now()
+
+	backupStatusLock.Lock()
+	backupStatus.Running = false
+	backupStatus.End = end.Unix()
+	backupStatus.Info = info
+	if err != nil {
+		backupStatus.Error = err.Error()
+	}
+	backupStatusLock.Unlock()
+
+	if err != nil {
+		log.Warnf("online database backup failed with error: %s. took: %v", err.Error(), end.Sub(start).Truncate(time.Millisecond))
+		return
+	}
+
+	log.Infof("online database backup finished at solid milestone %d, %d bytes. took %v", info.SolidMilestoneIndex, info.Size, end.Sub(start).Truncate(time.Millisecond))
+}
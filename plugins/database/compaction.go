@@ -0,0 +1,153 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+var (
+	// ErrCompactionRunning is returned if a database compaction is already running.
+	ErrCompactionRunning = errors.New("database compaction is already running")
+	// ErrCompactionNotSupported is returned if the database engine does not support a compaction.
+	ErrCompactionNotSupported = errors.New("database engine does not support a compaction")
+
+	compactionStatus     CompactionStatus
+	compactionStatusLock sync.Mutex
+)
+
+// CompactionStatus is the progress of the online database compaction.
+type CompactionStatus struct {
+	// Whether the compaction is currently running.
+	Running bool `json:"running"`
+	// The unix timestamp the last compaction was started.
+	Start int64 `json:"start"`
+	// The unix timestamp the last compaction finished.
+	End int64 `json:"end"`
+	// The name of the database which is currently compacted.
+	Database string `json:"database"`
+	// The amount of garbage collection rounds that were started.
+	Rounds int `json:"rounds"`
+	// The size of all databases in bytes before the compaction.
+	SizeBefore int64 `json:"sizeBefore"`
+	// The size of all databases in bytes after the last round.
+	Size int64 `json:"size"`
+	// The error of the last compaction, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// GetCompactionStatus returns the progress of the online database compaction.
+func GetCompactionStatus() CompactionStatus {
+	compactionStatusLock.Lock()
+	defer compactionStatusLock.Unlock()
+	return compactionStatus
+}
+
+func databaseSize() int64 {
+	tangleSize, snapshotSize, spentSize := tangle.GetDatabaseSizes()
+	return tangleSize + snapshotSize + spentSize
+}
+
+// StartCompaction starts the compaction of the databases while the node is running.
+// The garbage collection rounds are throttled to limit the I/O load, the progress can be queried with GetCompactionStatus.
+func StartCompaction() error {
+	if !tangle.DatabaseSupportsCleanup() {
+		return ErrCompactionNotSupported
+	}
+
+	compactionStatusLock.Lock()
+	defer compactionStatusLock.Unlock()
+
+	if compactionStatus.Running {
+		return ErrCompactionRunning
+	}
+
+	size := databaseSize()
+	compactionStatus = CompactionStatus{
+		Running:    true,
+		Start:      time.Now().Unix(),
+		SizeBefore: size,
+		Size:       size,
+	}
+
+	if err := daemon.BackgroundWorker("Database[Compaction]", func(shutdownSignal <-chan struct{}) {
+		runCompaction(shutdownSignal)
+	}, shutdown.PriorityDatabaseCompaction); err != nil {
+		compactionStatus.Running = false
+		return err
+	}
+
+	return nil
+}
+
+func runCompaction(shutdownSignal <-chan struct{}) {
+	garbageCollectionLock.Lock()
+	defer garbageCollectionLock.Unlock()
+
+	throttle := time.Duration(config.NodeConfig.GetInt(config.CfgDatabaseCompactionThrottleMilliseconds)) * time.Millisecond
+
+	log.Info("running online database compaction...")
+
+	start := time.Now()
+	Events.DatabaseCleanup.Trigger(&DatabaseCleanup{
+		Start: start,
+	})
+
+	rounds := 0
+	err := tangle.CleanupDatabasesStepwise(func(database string) bool {
+		if rounds > 0 {
+			// pause between the rounds to leave I/O capacity for the node
+			select {
+			case <-shutdownSignal:
+				return false
+			case <-time.After(throttle):
+			}
+		}
+
+		select {
+		case <-shutdownSignal:
+			return false
+		default:
+		}
+
+		compactionStatusLock.Lock()
+		compactionStatus.Database = database
+		compactionStatus.Rounds = rounds
+		compactionStatus.Size = databaseSize()
+		compactionStatusLock.Unlock()
+
+		rounds++
+		return true
+	})
+
+	end := time.Now()
+	Events.DatabaseCleanup.Trigger(&DatabaseCleanup{
+		Start: start,
+		End:   end,
+	})
+
+	compactionStatusLock.Lock()
+	compactionStatus.Running = false
+	compactionStatus.End = end.Unix()
+	compactionStatus.Database = ""
+	compactionStatus.Rounds = rounds
+	compactionStatus.Size = databaseSize()
+	if err != nil && err != tangle.ErrNothingToCleanUp {
+		compactionStatus.Error = err.Error()
+	}
+	reclaimed := compactionStatus.SizeBefore - compactionStatus.Size
+	compactionStatusLock.Unlock()
+
+	if err != nil && err != tangle.ErrNothingToCleanUp {
+		log.Warnf("online database compaction failed with error: %s. took: %v", err.Error(), end.Sub(start).Truncate(time.Millisecond))
+		return
+	}
+
+	log.Infof("online database compaction finished, reclaimed %d bytes. took %v", reclaimed, end.Sub(start).Truncate(time.Millisecond))
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -35,7 +36,11 @@ func configure(plugin *node.Plugin) {
 		runtime.GOMAXPROCS(128)
 	}
 
-	tangle.ConfigureDatabases(config.NodeConfig.GetString(config.CfgDatabasePath))
+	tangle.ConfigureDatabases(
+		config.NodeConfig.GetString(config.CfgDatabasePath),
+		strings.ToLower(config.NodeConfig.GetString(config.CfgDatabaseEngine)),
+		strings.ToLower(config.NodeConfig.GetString(config.CfgDatabaseStorageMedium)),
+	)
 
 	if !tangle.IsCorrectDatabaseVersion() {
 		if !tangle.UpdateDatabaseVersion() {
@@ -47,7 +52,14 @@ func configure(plugin *node.Plugin) {
 		<-shutdownSignal
 		tangle.MarkDatabaseHealthy()
 		log.Info("Syncing databases to disk...")
-		tangle.CloseDatabases()
+		// the caches were flushed to the databases already, write everything to disk
+		// before closing, so that a failing close doesn't lose the flushed state
+		if err := tangle.SyncDatabases(); err != nil {
+			log.Errorf("Syncing databases to disk failed: %s", err)
+		}
+		if err := tangle.CloseDatabases(); err != nil {
+			log.Errorf("Closing databases failed: %s", err)
+		}
 		log.Info("Syncing databases to disk... done")
 	}, shutdown.PriorityCloseDatabase)
 }
@@ -0,0 +1,125 @@
+package externalplugins
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/transaction"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+var (
+	onReceivedNewTransaction = events.NewClosure(func(cachedTx *tanglePackage.CachedTransaction, _ milestone.Index, _ milestone.Index) {
+		defer cachedTx.Release(true) // tx -1
+
+		trytes, err := transaction.TransactionToTrytes(cachedTx.GetTransaction().Tx)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+
+		publish(&grpcapi.PluginEvent{
+			Type:      grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_NEW_TRANSACTION,
+			Hash:      cachedTx.GetTransaction().GetTxHash().Trytes(),
+			Trytes:    trytes,
+			Timestamp: cachedTx.GetTransaction().GetTimestamp(),
+		})
+	})
+
+	onTransactionConfirmed = events.NewClosure(func(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index, confTime int64) {
+		defer cachedMeta.Release(true) // meta -1
+
+		if cachedMeta.GetMetadata().IsConflicting() {
+			return
+		}
+
+		publish(&grpcapi.PluginEvent{
+			Type:           grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_CONFIRMED_TRANSACTION,
+			Hash:           cachedMeta.GetMetadata().GetTxHash().Trytes(),
+			MilestoneIndex: uint32(msIndex),
+			Timestamp:      confTime,
+		})
+	})
+
+	onLatestMilestoneChanged = events.NewClosure(func(cachedBndl *tanglePackage.CachedBundle) {
+		publishMilestone(grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_LATEST_MILESTONE, cachedBndl) // bundle pass +1
+	})
+
+	onSolidMilestoneChanged = events.NewClosure(func(cachedBndl *tanglePackage.CachedBundle) {
+		publishMilestone(grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_SOLID_MILESTONE, cachedBndl) // bundle pass +1
+	})
+)
+
+// pluginEvents are the tangle events the plugins can subscribe to.
+var pluginEvents = map[grpcapi.PluginEventType]struct {
+	event   *events.Event
+	closure *events.Closure
+}{
+	grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_NEW_TRANSACTION:       {tangle.Events.ReceivedNewTransaction, onReceivedNewTransaction},
+	grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_CONFIRMED_TRANSACTION: {tangle.Events.TransactionConfirmed, onTransactionConfirmed},
+	grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_LATEST_MILESTONE:      {tangle.Events.LatestMilestoneChanged, onLatestMilestoneChanged},
+	grpcapi.PluginEventType_PLUGIN_EVENT_TYPE_SOLID_MILESTONE:       {tangle.Events.SolidMilestoneChanged, onSolidMilestoneChanged},
+}
+
+// attachEvents only attaches to the events at least one plugin subscribed to.
+func attachEvents() {
+	for eventType, pluginEvent := range pluginEvents {
+		for _, p := range plugins {
+			if p.subscribed(eventType) {
+				pluginEvent.event.Attach(pluginEvent.closure)
+				break
+			}
+		}
+	}
+}
+
+func detachEvents() {
+	for _, pluginEvent := range pluginEvents {
+		pluginEvent.event.Detach(pluginEvent.closure)
+	}
+}
+
+func publishMilestone(eventType grpcapi.PluginEventType, cachedBndl *tanglePackage.CachedBundle) {
+	defer cachedBndl.Release(true) // bundle -1
+
+	cachedTailTx := cachedBndl.GetBundle().GetTail() // tx +1
+	defer cachedTailTx.Release(true)                 // tx -1
+
+	publish(&grpcapi.PluginEvent{
+		Type:           eventType,
+		Hash:           cachedBndl.GetBundle().GetMilestoneHash().Trytes(),
+		MilestoneIndex: uint32(cachedBndl.GetBundle().GetMilestoneIndex()),
+		Timestamp:      cachedTailTx.GetTransaction().GetTimestamp(),
+	})
+}
+
+// publish queues the event for all plugins which subscribed to it.
+// Plugins whose queue is full miss the event.
+func publish(event *grpcapi.PluginEvent) {
+	for _, p := range plugins {
+		if !p.subscribed(event.GetType()) {
+			continue
+		}
+
+		if _, added := p.eventWorkerPool.TrySubmit(event); !added {
+			p.log.Warnf("dropped event %s %s, event queue is full", event.GetType(), event.GetHash())
+		}
+	}
+}
+
+func (p *externalPlugin) handleEvent(event *grpcapi.PluginEvent) {
+	if p.exited() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if _, err := p.client.HandleEvent(ctx, event); err != nil {
+		p.log.Warnf("delivering event %s %s failed: %s", event.GetType(), event.GetHash(), err)
+	}
+}
@@ -0,0 +1,175 @@
+package externalplugins
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/externalplugin"
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/cli"
+	"github.com/gohornet/hornet/plugins/webapi"
+)
+
+const (
+	// the time the plugins get to shut down before they are killed
+	pluginShutdownTimeout = 5 * time.Second
+)
+
+var (
+	// out-of-process plugins are disabled by default
+	PLUGIN = node.NewPlugin("ExternalPlugins", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	requestTimeout time.Duration
+	plugins        []*externalPlugin
+)
+
+// externalPlugin is a running out-of-process plugin.
+type externalPlugin struct {
+	client          *externalplugin.Client
+	description     *grpcapi.PluginDescription
+	log             *logger.Logger
+	events          map[grpcapi.PluginEventType]struct{}
+	eventWorkerPool *workerpool.WorkerPool
+}
+
+// subscribed returns whether the plugin subscribed to the given event type.
+func (p *externalPlugin) subscribed(eventType grpcapi.PluginEventType) bool {
+	_, subscribed := p.events[eventType]
+	return subscribed
+}
+
+// exited returns whether the plugin process exited.
+func (p *externalPlugin) exited() bool {
+	select {
+	case <-p.client.Exited():
+		return true
+	default:
+		return false
+	}
+}
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	requestTimeout = time.Duration(config.NodeConfig.GetInt(config.CfgExternalPluginsRequestTimeoutMilliseconds)) * time.Millisecond
+
+	for _, executable := range config.NodeConfig.GetStringSlice(config.CfgExternalPluginsExecutables) {
+		p, err := startPlugin(executable)
+		if err != nil {
+			log.Errorf("Starting plugin %s failed: %s", executable, err)
+			continue
+		}
+		plugins = append(plugins, p)
+
+		log.Infof("Started plugin %s %s (%s)", p.description.GetName(), p.description.GetVersion(), executable)
+	}
+}
+
+// startPlugin starts the plugin executable, asks it for its description and registers its API routes.
+func startPlugin(executable string) (*externalPlugin, error) {
+	startupTimeout := time.Duration(config.NodeConfig.GetInt(config.CfgExternalPluginsStartupTimeoutSeconds)) * time.Second
+
+	pluginLog := logger.NewLogger(fmt.Sprintf("ExternalPlugins[%s]", filepath.Base(executable)))
+	client, err := externalplugin.Start(executable, startupTimeout, func(line string) {
+		pluginLog.Info(line)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	description, err := client.Describe(ctx, &grpcapi.DescribeRequest{NodeAppName: cli.AppName, NodeAppVersion: cli.AppVersion})
+	if err != nil {
+		client.Close(pluginShutdownTimeout)
+		return nil, err
+	}
+
+	if description.GetName() == "" {
+		client.Close(pluginShutdownTimeout)
+		return nil, fmt.Errorf("plugin has no name")
+	}
+
+	for _, p := range plugins {
+		if p.description.GetName() == description.GetName() {
+			client.Close(pluginShutdownTimeout)
+			return nil, fmt.Errorf("a plugin with the name %s is already running", description.GetName())
+		}
+	}
+
+	p := &externalPlugin{
+		client:      client,
+		description: description,
+		log:         pluginLog,
+		events:      make(map[grpcapi.PluginEventType]struct{}),
+	}
+
+	for _, eventType := range description.GetEvents() {
+		p.events[eventType] = struct{}{}
+	}
+
+	p.eventWorkerPool = workerpool.New(func(task workerpool.Task) {
+		p.handleEvent(task.Param(0).(*grpcapi.PluginEvent))
+		task.Return(nil)
+	}, workerpool.WorkerCount(1), workerpool.QueueSize(config.NodeConfig.GetInt(config.CfgExternalPluginsEventQueueSize)))
+
+	for _, route := range description.GetRoutes() {
+		if err := webapi.RegisterPluginRoute(description.GetName(), route.GetMethod(), route.GetPath(), p.routeHandler(route)); err != nil {
+			pluginLog.Warnf("Registering route %s %s failed: %s", route.GetMethod(), route.GetPath(), err)
+			continue
+		}
+		pluginLog.Infof("Registered route %s %s, its command for the API permissions is '%s'", route.GetMethod(), route.GetPath(), webapi.PluginRouteCommand(description.GetName(), route.GetMethod(), route.GetPath()))
+	}
+
+	return p, nil
+}
+
+func run(_ *node.Plugin) {
+	if len(plugins) == 0 {
+		return
+	}
+
+	daemon.BackgroundWorker("ExternalPlugins", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting ExternalPlugins ... done")
+
+		for _, p := range plugins {
+			p.eventWorkerPool.Start()
+		}
+		attachEvents()
+
+		<-shutdownSignal
+		log.Info("Stopping ExternalPlugins ...")
+
+		detachEvents()
+		for _, p := range plugins {
+			p.eventWorkerPool.StopAndWait()
+			if err := p.client.Close(pluginShutdownTimeout); err != nil {
+				p.log.Warn(err)
+			}
+		}
+
+		log.Info("Stopping ExternalPlugins ... done")
+	}, shutdown.PriorityExternalPlugins)
+
+	for _, p := range plugins {
+		p := p
+		daemon.BackgroundWorker(fmt.Sprintf("ExternalPlugins[%s]", p.description.GetName()), func(shutdownSignal <-chan struct{}) {
+			select {
+			case <-shutdownSignal:
+			case <-p.client.Exited():
+				p.log.Warn("Plugin exited unexpectedly")
+			}
+		}, shutdown.PriorityExternalPlugins)
+	}
+}
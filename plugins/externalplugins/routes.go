@@ -0,0 +1,73 @@
+package externalplugins
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/plugins/webapi"
+)
+
+var (
+	// ErrPluginExited is returned if a request is passed to a plugin which is not running anymore.
+	ErrPluginExited = errors.New("plugin exited")
+)
+
+// routeHandler passes the requests to the given route to the plugin and writes its response.
+func (p *externalPlugin) routeHandler(route *grpcapi.PluginRoute) webapi.PluginRouteHandler {
+	return func(c *gin.Context) error {
+		if p.exited() {
+			return ErrPluginExited
+		}
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+
+		headers := make(map[string]string, len(c.Request.Header))
+		for key := range c.Request.Header {
+			headers[key] = c.Request.Header.Get(key)
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), requestTimeout)
+		defer cancel()
+
+		res, err := p.client.HandleRequest(ctx, &grpcapi.PluginRequest{
+			Route:   route,
+			Params:  params,
+			Query:   c.Request.URL.RawQuery,
+			Headers: headers,
+			Body:    body,
+		})
+		if err != nil {
+			return err
+		}
+
+		for key, value := range res.GetHeaders() {
+			c.Header(key, value)
+		}
+
+		statusCode := int(res.GetStatusCode())
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+
+		contentType := c.Writer.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		c.Data(statusCode, contentType, res.GetBody())
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+package faucet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/batchhasher"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/kerl"
+	"github.com/iotaledger/iota.go/signing"
+	"github.com/iotaledger/iota.go/signing/key"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/pow"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+// createBundle creates a signed bundle which pays out the given amount to every target address.
+// The whole balance of the input address is spent, the remainder is sent to the remainder address.
+func createBundle(targets []trinary.Hash, inputIndex uint64, inputAddress trinary.Hash, balance uint64, remainderAddress trinary.Hash) (bundle.Bundle, error) {
+
+	tag, err := trinary.NewTrytes(trinary.MustPad(faucetTag, consts.TagTrinarySize/3))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := uint64(time.Now().Unix())
+
+	var b bundle.Bundle
+
+	sum := uint64(0)
+	for _, target := range targets {
+		b = bundle.AddEntry(b, bundle.BundleEntry{
+			Address:   target,
+			Value:     amount,
+			Tag:       tag,
+			Timestamp: timestamp,
+			Length:    1,
+		})
+		sum += uint64(amount)
+	}
+
+	if sum > balance {
+		return nil, fmt.Errorf("%w: balance %d, needed %d", ErrNotEnoughFunds, balance, sum)
+	}
+
+	indexFirstInputTx := len(b)
+	b = bundle.AddEntry(b, bundle.BundleEntry{
+		Address:   inputAddress,
+		Value:     -int64(balance),
+		Tag:       tag,
+		Timestamp: timestamp,
+		Length:    uint64(securityLevel),
+	})
+
+	if remainder := balance - sum; remainder > 0 {
+		b = bundle.AddEntry(b, bundle.BundleEntry{
+			Address:   remainderAddress,
+			Value:     int64(remainder),
+			Tag:       tag,
+			Timestamp: timestamp,
+			Length:    1,
+		})
+	}
+
+	// the bundle hash is changed until it is secure to sign
+	b, err = bundle.Finalize(b)
+	if err != nil {
+		return nil, err
+	}
+
+	subseed, err := signing.Subseed(seed, inputIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	prvKey, err := key.Sponge(subseed, securityLevel, kerl.NewKerl())
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedBundleHash := signing.NormalizedBundleHash(b[0].Bundle)
+
+	signedFrags := make([]trinary.Trytes, securityLevel)
+	for i := 0; i < int(securityLevel); i++ {
+		signedFragTrits, err := signing.SignatureFragment(
+			normalizedBundleHash[i*consts.HashTrytesSize/3:(i+1)*consts.HashTrytesSize/3],
+			prvKey[i*consts.KeyFragmentLength:(i+1)*consts.KeyFragmentLength],
+		)
+		if err != nil {
+			return nil, err
+		}
+		signedFrags[i] = trinary.MustTritsToTrytes(signedFragTrits)
+	}
+
+	return bundle.AddTrytes(b, signedFrags, indexFirstInputTx), nil
+}
+
+// attachBundle selects tips, does the PoW for the transactions of the bundle and broadcasts them.
+// It returns the hash of the tail transaction.
+func attachBundle(b bundle.Bundle, shutdownSignal <-chan struct{}) (hornet.Hash, error) {
+
+	tips, err := urts.Selector.SelectTips(0)
+	if err != nil {
+		return nil, err
+	}
+
+	trunk := tips[0].Trytes()
+	branch := tips[1].Trytes()
+
+	var prev trinary.Hash
+	for i := len(b) - 1; i >= 0; i-- {
+		switch {
+		case i == len(b)-1:
+			// Last tx in the bundle
+			b[i].TrunkTransaction = trunk
+			b[i].BranchTransaction = branch
+		default:
+			b[i].TrunkTransaction = prev
+			b[i].BranchTransaction = trunk
+		}
+
+		b[i].AttachmentTimestamp = time.Now().UnixNano() / int64(time.Millisecond)
+		b[i].AttachmentTimestampLowerBound = consts.LowerBoundAttachmentTimestamp
+		b[i].AttachmentTimestampUpperBound = consts.UpperBoundAttachmentTimestamp
+
+		trytes, err := transaction.TransactionToTrytes(&b[i])
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-shutdownSignal:
+			return nil, tangle.ErrOperationAborted
+		default:
+		}
+
+		nonce, err := pow.Handler().DoPoW(trytes, mwm, 1)
+		if err != nil {
+			return nil, err
+		}
+		b[i].Nonce = nonce
+
+		txTrits, _ := transaction.TransactionToTrits(&b[i])
+		b[i].Hash = trinary.MustTritsToTrytes(batchhasher.CURLP81.Hash(txTrits))
+		prev = b[i].Hash
+	}
+
+	for i := range b {
+		tx := b[i] // assign to new variable, otherwise it would be overwritten by the loop before processed
+		txTrits, _ := transaction.TransactionToTrits(&tx)
+		if err := gossip.Processor().CompressAndEmit(&tx, txTrits); err != nil {
+			return nil, err
+		}
+	}
+
+	return hornet.HashFromHashTrytes(b[0].Hash), nil
+}
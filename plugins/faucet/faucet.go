@@ -0,0 +1,428 @@
+package faucet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+const (
+	// the maximum amount of addresses which are checked for the funds of the faucet
+	addressScanLimit = 10000
+)
+
+var (
+	// ErrInvalidAddress is returned if the requested address is not a valid address.
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrFaucetAddress is returned if the tokens are requested for an address of the faucet itself.
+	ErrFaucetAddress = errors.New("address belongs to the faucet")
+	// ErrAddressSpent is returned if the requested address was already spent from.
+	ErrAddressSpent = errors.New("address was already spent from")
+	// ErrAlreadyRequested is returned if the tokens for the address were already requested and not paid out yet.
+	ErrAlreadyRequested = errors.New("tokens for the address were already requested")
+	// ErrAddressCooldown is returned if the address received tokens recently.
+	ErrAddressCooldown = errors.New("address received tokens recently")
+	// ErrTooManyRequests is returned if too many requests are waiting to be paid out.
+	ErrTooManyRequests = errors.New("too many pending requests")
+	// ErrNotEnoughFunds is returned if the faucet has not enough funds left.
+	ErrNotEnoughFunds = errors.New("faucet has not enough funds")
+
+	// the requests which are not paid out yet
+	queue []trinary.Hash
+	// the addresses in the queue and in the pending bundle
+	requestedAddresses = make(map[string]struct{})
+	// the time of the latest payout to an address
+	lastPayouts = make(map[string]time.Time)
+	// the current address of the faucet which holds the funds
+	faucetAddressIndex uint64
+	faucetAddress      trinary.Hash
+	faucetLock         sync.Mutex
+
+	// the bundle which is attached but not confirmed yet, it is only accessed by the faucet worker
+	pending *pendingBundle
+)
+
+// pendingBundle is a faucet bundle which was attached but is not confirmed yet.
+type pendingBundle struct {
+	bundle         bundle.Bundle
+	targets        []trinary.Hash
+	inputIndex     uint64
+	remainderIndex uint64
+	tailHashes     hornet.Hashes
+	attachedAt     milestone.Index
+}
+
+// Info is the current state of the faucet.
+type Info struct {
+	Address         trinary.Hash `json:"address"`
+	Balance         uint64       `json:"balance"`
+	Amount          int64        `json:"amount"`
+	PendingRequests int          `json:"pendingRequests"`
+}
+
+// GetInfo returns the current state of the faucet.
+func GetInfo() (*Info, error) {
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	balance, _, err := tangle.GetBalanceForAddress(hornet.HashFromAddressTrytes(faucetAddress))
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := address.Checksum(faucetAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Info{
+		Address:         faucetAddress + checksum,
+		Balance:         balance,
+		Amount:          amount,
+		PendingRequests: len(requestedAddresses),
+	}, nil
+}
+
+// Enqueue queues a payout to the given address, which has to contain a valid checksum.
+func Enqueue(addr trinary.Hash) error {
+	if len(addr) != consts.AddressWithChecksumTrytesSize {
+		return fmt.Errorf("%w: the checksum is missing", ErrInvalidAddress)
+	}
+
+	if err := address.ValidAddress(addr); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	addr = addr[:consts.HashTrytesSize]
+
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	if addr == faucetAddress {
+		return ErrFaucetAddress
+	}
+
+	if tangle.WasAddressSpentFrom(hornet.HashFromAddressTrytes(addr)) {
+		return ErrAddressSpent
+	}
+
+	if _, requested := requestedAddresses[addr]; requested {
+		return ErrAlreadyRequested
+	}
+
+	if lastPayout, exists := lastPayouts[addr]; exists && time.Since(lastPayout) < addressCooldown {
+		return fmt.Errorf("%w: try again in %v", ErrAddressCooldown, (addressCooldown - time.Since(lastPayout)).Truncate(time.Minute))
+	}
+
+	if len(requestedAddresses) >= maxPendingRequests {
+		return ErrTooManyRequests
+	}
+
+	queue = append(queue, addr)
+	requestedAddresses[addr] = struct{}{}
+
+	return nil
+}
+
+// loadFaucetState restores the pending bundle from the state file, or searches the faucet address if there is none.
+// The faucet keeps the input address of a restored bundle until the bundle is confirmed.
+func loadFaucetState() error {
+	p, err := loadPendingBundle(stateFilePath)
+	if err != nil {
+		return fmt.Errorf("loading the faucet state file failed: %w", err)
+	}
+
+	if p == nil {
+		return findFaucetAddress()
+	}
+
+	inputAddress, err := address.GenerateAddress(seed, p.inputIndex, securityLevel)
+	if err != nil {
+		return err
+	}
+
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	faucetAddressIndex = p.inputIndex
+	faucetAddress = inputAddress
+	for _, target := range p.targets {
+		requestedAddresses[target] = struct{}{}
+	}
+	pending = p
+
+	log.Infof("Restored the pending faucet bundle of %d addresses", len(p.targets))
+	return nil
+}
+
+// findFaucetAddress searches the first address of the faucet seed which was not spent from.
+// Funds on addresses which were spent from are never used again, since signing another bundle
+// with the key of such an address would weaken the W-OTS key.
+func findFaucetAddress() error {
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	index, addr, err := nextUnspentAddress(0)
+	if err != nil {
+		return err
+	}
+
+	balance, _, err := tangle.GetBalanceForAddress(hornet.HashFromAddressTrytes(addr))
+	if err != nil {
+		return err
+	}
+
+	faucetAddressIndex = index
+	faucetAddress = addr
+
+	if balance == 0 {
+		log.Warnf("The faucet address %s has no funds", addr)
+	}
+	return nil
+}
+
+// nextUnspentAddress returns the first address of the faucet seed starting at the given index which was not spent from.
+// The funds which were left on spent addresses are logged, since they can't be moved safely.
+func nextUnspentAddress(startIndex uint64) (uint64, trinary.Hash, error) {
+	for index := startIndex; index < startIndex+addressScanLimit; index++ {
+		addr, err := address.GenerateAddress(seed, index, securityLevel)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if !tangle.WasAddressSpentFrom(hornet.HashFromAddressTrytes(addr)) {
+			return index, addr, nil
+		}
+
+		balance, _, err := tangle.GetBalanceForAddress(hornet.HashFromAddressTrytes(addr))
+		if err != nil {
+			return 0, "", err
+		}
+
+		if balance > 0 {
+			log.Warnf("Skipping the faucet address %s with %d tokens, it was already spent from", addr, balance)
+		}
+	}
+
+	return 0, "", fmt.Errorf("no unspent address found within %d addresses of the faucet seed starting at index %d", addressScanLimit, startIndex)
+}
+
+// processRequests checks the state of the pending bundle, or pays out the queued requests if there is none.
+// It is only called by the faucet worker.
+func processRequests(shutdownSignal <-chan struct{}) {
+	if !tangle.IsNodeSyncedWithThreshold() {
+		return
+	}
+
+	if pending != nil {
+		checkPendingBundle(shutdownSignal)
+		return
+	}
+
+	faucetLock.Lock()
+	if len(queue) == 0 {
+		faucetLock.Unlock()
+		return
+	}
+
+	inputIndex := faucetAddressIndex
+	inputAddress := faucetAddress
+
+	if tangle.WasAddressSpentFrom(hornet.HashFromAddressTrytes(inputAddress)) {
+		// the funds were moved by another bundle signed with the faucet seed
+		faucetLock.Unlock()
+		log.Warnf("The faucet address %s was spent from, searching the faucet address again", inputAddress)
+		if err := findFaucetAddress(); err != nil {
+			log.Warn(err)
+		}
+		return
+	}
+
+	balance, _, err := tangle.GetBalanceForAddress(hornet.HashFromAddressTrytes(inputAddress))
+	if err != nil {
+		faucetLock.Unlock()
+		log.Warnf("reading the balance of the faucet address failed: %s", err)
+		return
+	}
+
+	count := len(queue)
+	if count > maxOutputsPerBundle {
+		count = maxOutputsPerBundle
+	}
+	if funded := int(balance / uint64(amount)); count > funded {
+		count = funded
+	}
+
+	if count == 0 {
+		faucetLock.Unlock()
+		log.Warnf("%s: balance %d, requested %d", ErrNotEnoughFunds, balance, amount)
+		return
+	}
+
+	targets := append([]trinary.Hash{}, queue[:count]...)
+	queue = queue[count:]
+	faucetLock.Unlock()
+
+	remainderIndex, remainderAddress, err := nextUnspentAddress(inputIndex + 1)
+	if err != nil {
+		requeue(targets)
+		log.Warnf("searching the remainder address failed: %s", err)
+		return
+	}
+
+	b, err := createBundle(targets, inputIndex, inputAddress, balance, remainderAddress)
+	if err != nil {
+		requeue(targets)
+		log.Warnf("creating the faucet bundle failed: %s", err)
+		return
+	}
+
+	p := &pendingBundle{
+		bundle:         b,
+		targets:        targets,
+		inputIndex:     inputIndex,
+		remainderIndex: remainderIndex,
+	}
+
+	// the signed bundle is persisted before it is sent, so that it is reattached instead of signing a new one after a restart
+	if err := storePendingBundle(stateFilePath, p); err != nil {
+		requeue(targets)
+		log.Warnf("storing the faucet bundle failed: %s", err)
+		return
+	}
+	pending = p
+
+	// the signature of the input address gets public with the bundle
+	tangle.MarkAddressAsSpent(hornet.HashFromAddressTrytes(inputAddress))
+
+	tailHash, err := attachBundle(b, shutdownSignal)
+	if err != nil {
+		// the signed bundle stays pending and is reattached with the next milestones
+		if err != tangle.ErrOperationAborted {
+			log.Warnf("attaching the faucet bundle failed: %s", err)
+		}
+		return
+	}
+
+	addPendingAttachment(tailHash)
+
+	log.Infof("Sent %d tokens to %d addresses, tail transaction: %s", uint64(amount)*uint64(len(targets)), len(targets), tailHash.Trytes())
+}
+
+// addPendingAttachment adds the tail transaction of a new attachment to the pending bundle and persists it.
+func addPendingAttachment(tailHash hornet.Hash) {
+	pending.tailHashes = append(pending.tailHashes, tailHash)
+	pending.attachedAt = tangle.GetSolidMilestoneIndex()
+
+	if err := storePendingBundle(stateFilePath, pending); err != nil {
+		log.Warnf("storing the faucet bundle failed: %s", err)
+	}
+}
+
+// checkPendingBundle finishes the pending bundle if one of its attachments was confirmed,
+// and reattaches it if it was not confirmed in time or was never attached successfully.
+func checkPendingBundle(shutdownSignal <-chan struct{}) {
+	conflicting := 0
+	for _, tailHash := range pending.tailHashes {
+		cachedTxMeta := tangle.GetCachedTxMetadataOrNil(tailHash) // meta +1
+		if cachedTxMeta == nil {
+			continue
+		}
+
+		confirmed, _ := cachedTxMeta.GetMetadata().GetConfirmed()
+		isConflicting := cachedTxMeta.GetMetadata().IsConflicting()
+		cachedTxMeta.Release(true) // meta -1
+
+		if confirmed && !isConflicting {
+			finishPendingBundle()
+			return
+		}
+
+		if confirmed && isConflicting {
+			conflicting++
+		}
+	}
+
+	if len(pending.tailHashes) > 0 && conflicting == len(pending.tailHashes) {
+		// the funds of the faucet were moved by another bundle, so the faucet address has to be searched again
+		log.Warn("The faucet bundle was confirmed as conflicting, searching the faucet address again")
+		requeue(pending.targets)
+		pending = nil
+
+		if err := removePendingBundle(stateFilePath); err != nil {
+			log.Warnf("removing the faucet state file failed: %s", err)
+		}
+
+		if err := findFaucetAddress(); err != nil {
+			log.Warn(err)
+		}
+		return
+	}
+
+	if len(pending.tailHashes) > 0 && tangle.GetSolidMilestoneIndex() < pending.attachedAt+milestone.Index(reattachAfterMilestones) {
+		return
+	}
+
+	tailHash, err := attachBundle(pending.bundle, shutdownSignal)
+	if err != nil {
+		if err != tangle.ErrOperationAborted {
+			log.Warnf("reattaching the faucet bundle failed: %s", err)
+		}
+		return
+	}
+
+	addPendingAttachment(tailHash)
+
+	log.Infof("Reattached the faucet bundle, tail transaction: %s", tailHash.Trytes())
+}
+
+// finishPendingBundle moves the faucet to the remainder address and starts the cooldown of the paid out addresses.
+func finishPendingBundle() {
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	remainderAddress, err := address.GenerateAddress(seed, pending.remainderIndex, securityLevel)
+	if err != nil {
+		log.Warnf("generating the remainder address failed: %s", err)
+		return
+	}
+
+	faucetAddressIndex = pending.remainderIndex
+	faucetAddress = remainderAddress
+
+	now := time.Now()
+	for addr, lastPayout := range lastPayouts {
+		if now.Sub(lastPayout) >= addressCooldown {
+			delete(lastPayouts, addr)
+		}
+	}
+
+	for _, target := range pending.targets {
+		lastPayouts[target] = now
+		delete(requestedAddresses, target)
+	}
+
+	log.Infof("Faucet bundle confirmed, paid out %d addresses", len(pending.targets))
+	pending = nil
+
+	if err := removePendingBundle(stateFilePath); err != nil {
+		log.Warnf("removing the faucet state file failed: %s", err)
+	}
+}
+
+// requeue puts the requests of a failed payout back to the front of the queue.
+func requeue(targets []trinary.Hash) {
+	faucetLock.Lock()
+	defer faucetLock.Unlock()
+
+	queue = append(append([]trinary.Hash{}, targets...), queue...)
+}
@@ -0,0 +1,155 @@
+package faucet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+// the solid entry points can only be loaded once per process
+var loadInitialValuesOnce sync.Once
+
+var testTarget = strings.Repeat("A", consts.HashTrytesSize)
+
+func testFaucetAddress(t *testing.T, index uint64) trinary.Hash {
+	addr, err := address.GenerateAddress(seed, index, securityLevel)
+	require.NoError(t, err)
+	return addr
+}
+
+// configureTestFaucet sets up in-memory storages with the given balances of the addresses of the faucet seed by index.
+// The addresses with the given indexes are marked as spent.
+func configureTestFaucet(t *testing.T, balances map[uint64]uint64, spent ...uint64) {
+	log = zap.NewNop().Sugar()
+
+	seed = strings.Repeat("S", consts.HashTrytesSize)
+	securityLevel = consts.SecurityLevelMedium
+	amount = 100
+	faucetTag = "FAUCET"
+	stateFilePath = filepath.Join(os.TempDir(), "faucet-test-missing.state")
+
+	queue = nil
+	requestedAddresses = make(map[string]struct{})
+	lastPayouts = make(map[string]time.Time)
+	faucetAddressIndex = 0
+	faucetAddress = ""
+	pending = nil
+
+	store := mapdb.NewMapDB()
+	tangle.ConfigureStorages(
+		store.WithRealm([]byte("tangle")),
+		store.WithRealm([]byte("snapshot")),
+		store.WithRealm([]byte("spent")),
+		profile.Profile2GB.Caches,
+	)
+	loadInitialValuesOnce.Do(tangle.LoadInitialValuesFromDatabase)
+
+	ledger := map[string]uint64{string(hornet.HashFromAddressTrytes(testTarget)): consts.TotalSupply}
+	for index, balance := range balances {
+		ledger[string(hornet.HashFromAddressTrytes(testFaucetAddress(t, index)))] = balance
+		ledger[string(hornet.HashFromAddressTrytes(testTarget))] -= balance
+	}
+	require.NoError(t, tangle.StoreLedgerBalancesInDatabase(ledger, 1))
+
+	for _, index := range spent {
+		tangle.MarkAddressAsSpent(hornet.HashFromAddressTrytes(testFaucetAddress(t, index)))
+	}
+}
+
+func TestFindFaucetAddressSkipsSpentAddresses(t *testing.T) {
+	// the funds left on the spent address must not be used, the key was already used to sign a bundle
+	configureTestFaucet(t, map[uint64]uint64{0: 500, 2: 1000}, 0, 1)
+
+	require.NoError(t, findFaucetAddress())
+	assert.Equal(t, uint64(2), faucetAddressIndex)
+	assert.Equal(t, testFaucetAddress(t, 2), faucetAddress)
+
+	// the remainder of a bundle is never sent to a spent address
+	tangle.MarkAddressAsSpent(hornet.HashFromAddressTrytes(testFaucetAddress(t, 3)))
+	index, addr, err := nextUnspentAddress(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), index)
+	assert.Equal(t, testFaucetAddress(t, 4), addr)
+}
+
+func TestFindFaucetAddressWithoutFunds(t *testing.T) {
+	configureTestFaucet(t, map[uint64]uint64{0: 500}, 0)
+
+	require.NoError(t, findFaucetAddress())
+	assert.Equal(t, uint64(1), faucetAddressIndex)
+}
+
+func TestLoadFaucetStateRestoresPendingBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faucet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configureTestFaucet(t, map[uint64]uint64{0: 1000})
+	stateFilePath = filepath.Join(dir, "faucet.state")
+
+	b, err := createBundle([]trinary.Hash{testTarget}, 0, testFaucetAddress(t, 0), 1000, testFaucetAddress(t, 1))
+	require.NoError(t, err)
+
+	p := &pendingBundle{
+		bundle:         b,
+		targets:        []trinary.Hash{testTarget},
+		inputIndex:     0,
+		remainderIndex: 1,
+		tailHashes:     hornet.Hashes{hornet.HashFromHashTrytes(strings.Repeat("T", consts.HashTrytesSize))},
+		attachedAt:     7,
+	}
+	require.NoError(t, storePendingBundle(stateFilePath, p))
+
+	// the node restarts before the bundle was confirmed, the input address is only marked as spent by the faucet itself
+	configureTestFaucet(t, map[uint64]uint64{0: 1000}, 0)
+	stateFilePath = filepath.Join(dir, "faucet.state")
+
+	require.NoError(t, loadFaucetState())
+	require.NotNil(t, pending)
+	assert.Equal(t, uint64(0), faucetAddressIndex)
+	assert.Equal(t, uint64(0), pending.inputIndex)
+	assert.Equal(t, uint64(1), pending.remainderIndex)
+	assert.Equal(t, p.targets, pending.targets)
+	assert.Equal(t, p.tailHashes, pending.tailHashes)
+	assert.EqualValues(t, 7, pending.attachedAt)
+
+	// the restored bundle is the one which was signed before the restart
+	require.Len(t, pending.bundle, len(b))
+	for i := range b {
+		assert.Equal(t, b[i].Bundle, pending.bundle[i].Bundle)
+		assert.Equal(t, b[i].SignatureMessageFragment, pending.bundle[i].SignatureMessageFragment)
+	}
+
+	// the targets of the pending bundle can't be requested again
+	checksum, err := address.Checksum(testTarget)
+	require.NoError(t, err)
+	assert.Equal(t, ErrAlreadyRequested, Enqueue(testTarget+checksum))
+
+	// the state file is removed after the bundle was confirmed
+	finishPendingBundle()
+	assert.Nil(t, pending)
+	assert.Equal(t, uint64(1), faucetAddressIndex)
+	_, err = os.Stat(stateFilePath)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, loadFaucetState())
+	assert.Nil(t, pending)
+	assert.Equal(t, uint64(1), faucetAddressIndex)
+}
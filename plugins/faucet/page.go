@@ -0,0 +1,46 @@
+package faucet
+
+// faucetPage is the website of the faucet with a form to request tokens.
+const faucetPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>HORNET Faucet</title>
+  <style>
+    body { font-family: sans-serif; max-width: 720px; margin: 40px auto; padding: 0 16px; }
+    input { width: 100%; font-family: monospace; padding: 8px; box-sizing: border-box; }
+    button { margin-top: 12px; padding: 8px 16px; }
+    #info, #result { margin-top: 16px; word-break: break-all; }
+  </style>
+</head>
+<body>
+  <h1>HORNET Faucet</h1>
+  <div id="info"></div>
+  <form id="form">
+    <label for="address">Address (with checksum)</label>
+    <input id="address" name="address" maxlength="90" required>
+    <button type="submit">Request tokens</button>
+  </form>
+  <div id="result"></div>
+  <script>
+    fetch("api/info").then(r => r.json()).then(info => {
+      document.getElementById("info").textContent =
+        "Every request receives " + info.amount + " tokens. Balance of the faucet: " + info.balance + ", pending requests: " + info.pendingRequests;
+    });
+
+    document.getElementById("form").addEventListener("submit", e => {
+      e.preventDefault();
+      fetch("api/enqueue", {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ address: document.getElementById("address").value.trim() })
+      }).then(r => r.json()).then(res => {
+        document.getElementById("result").textContent = res.error
+          ? "Error: " + res.error
+          : res.amount + " tokens will be sent to " + res.address + " with one of the next milestones.";
+      });
+    });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,200 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/tangle"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+const (
+	// the environment variable which contains the seed of the faucet
+	faucetSeedEnvKey = "FAUCET_SEED"
+)
+
+var (
+	// the faucet is disabled by default
+	PLUGIN = node.NewPlugin("Faucet", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	seed                    trinary.Trytes
+	securityLevel           consts.SecurityLevel
+	amount                  int64
+	addressCooldown         time.Duration
+	maxPendingRequests      int
+	maxOutputsPerBundle     int
+	reattachAfterMilestones int
+	faucetTag               trinary.Trytes
+	mwm                     int
+	stateFilePath           string
+
+	server *http.Server
+)
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	// the faucet needs the tip selection to attach its bundles
+	if node.IsSkipped(urts.PLUGIN) {
+		log.Fatal("the faucet needs the URTS plugin")
+	}
+
+	seed = os.Getenv(faucetSeedEnvKey)
+	if !guards.IsTrytesOfExactLength(seed, consts.HashTrytesSize) {
+		log.Fatalf("the faucet seed in the environment variable %s is missing or invalid", faucetSeedEnvKey)
+	}
+
+	securityLevel = consts.SecurityLevel(config.NodeConfig.GetInt(config.CfgFaucetSecurityLevel))
+	if securityLevel < consts.SecurityLevelLow || securityLevel > consts.SecurityLevelHigh {
+		log.Fatalf("invalid faucet security level: %d", securityLevel)
+	}
+
+	amount = config.NodeConfig.GetInt64(config.CfgFaucetAmount)
+	if amount <= 0 {
+		log.Fatalf("invalid faucet amount: %d", amount)
+	}
+
+	addressCooldown = time.Duration(config.NodeConfig.GetInt(config.CfgFaucetAddressCooldownMinutes)) * time.Minute
+	maxPendingRequests = config.NodeConfig.GetInt(config.CfgFaucetMaxPendingRequests)
+	maxOutputsPerBundle = config.NodeConfig.GetInt(config.CfgFaucetMaxOutputsPerBundle)
+	if maxOutputsPerBundle < 1 {
+		maxOutputsPerBundle = 1
+	}
+	reattachAfterMilestones = config.NodeConfig.GetInt(config.CfgFaucetReattachAfterMilestones)
+	faucetTag = config.NodeConfig.GetString(config.CfgFaucetTag)
+	if !guards.IsTrytes(faucetTag) || len(faucetTag) > consts.TagTrinarySize/3 {
+		log.Fatalf("invalid faucet tag: %s", faucetTag)
+	}
+	mwm = config.NodeConfig.GetInt(config.CfgCoordinatorMWM)
+
+	stateFilePath = config.NodeConfig.GetString(config.CfgFaucetStateFilePath)
+
+	if err := loadFaucetState(); err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("Faucet address: %s", faucetAddress)
+}
+
+func run(_ *node.Plugin) {
+
+	daemon.BackgroundWorker("Faucet", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting Faucet ... done")
+
+		// the requests are processed with every new solid milestone, since the balances only change with milestones
+		solidMilestoneChanged := make(chan struct{}, 1)
+		onSolidMilestoneIndexChanged := events.NewClosure(func(_ milestone.Index) {
+			select {
+			case solidMilestoneChanged <- struct{}{}:
+			default:
+			}
+		})
+
+		tangle.Events.SolidMilestoneIndexChanged.Attach(onSolidMilestoneIndexChanged)
+		defer tangle.Events.SolidMilestoneIndexChanged.Detach(onSolidMilestoneIndexChanged)
+
+		for {
+			select {
+			case <-shutdownSignal:
+				log.Info("Stopping Faucet ... done")
+				return
+			case <-solidMilestoneChanged:
+				processRequests(shutdownSignal)
+			}
+		}
+	}, shutdown.PriorityFaucet)
+
+	daemon.BackgroundWorker("Faucet server", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting Faucet server ... done")
+
+		gin.SetMode(gin.ReleaseMode)
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+		setupRoutes(engine)
+
+		bindAddr := config.NodeConfig.GetString(config.CfgFaucetBindAddress)
+		server = &http.Server{Addr: bindAddr, Handler: engine}
+
+		go func() {
+			log.Infof("You can now access the faucet using: http://%s", bindAddr)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Warnf("Stopping Faucet server due to an error (%s) ... done", err)
+			}
+		}()
+
+		<-shutdownSignal
+		log.Info("Stopping Faucet server ...")
+
+		if server != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := server.Shutdown(ctx)
+			if err != nil {
+				log.Warn(err.Error())
+			}
+			cancel()
+		}
+		log.Info("Stopping Faucet server ... done")
+	}, shutdown.PriorityAPI)
+}
+
+// enqueueRequest is the request to the enqueue route.
+type enqueueRequest struct {
+	Address trinary.Hash `json:"address" form:"address"`
+}
+
+func setupRoutes(engine *gin.Engine) {
+
+	// GET /
+	engine.GET("/", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(faucetPage))
+	})
+
+	// GET /api/info
+	engine.GET("/api/info", func(c *gin.Context) {
+		info, err := GetInfo()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, info)
+	})
+
+	// POST /api/enqueue
+	engine.POST("/api/enqueue", func(c *gin.Context) {
+		request := &enqueueRequest{}
+		if err := c.ShouldBind(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := Enqueue(request.Address); err != nil {
+			switch {
+			case errors.Is(err, ErrAddressCooldown), errors.Is(err, ErrAlreadyRequested):
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			case errors.Is(err, ErrTooManyRequests):
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			}
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"address": request.Address, "amount": amount})
+	})
+}
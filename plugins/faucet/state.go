@@ -0,0 +1,128 @@
+package faucet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+// pendingBundleState is the persisted state of the pending bundle.
+// The signed bundle is persisted before it is attached, so that the faucet never signs
+// a second bundle with the key of the input address after a restart.
+type pendingBundleState struct {
+	InputIndex     uint64           `json:"inputIndex"`
+	RemainderIndex uint64           `json:"remainderIndex"`
+	Targets        []trinary.Hash   `json:"targets"`
+	Transactions   []trinary.Trytes `json:"transactions"`
+	TailHashes     []trinary.Hash   `json:"tailHashes"`
+	AttachedAt     milestone.Index  `json:"attachedAt"`
+}
+
+// loadPendingBundle loads the pending bundle from the state file.
+// It returns nil if there is no state file.
+func loadPendingBundle(filePath string) (*pendingBundle, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	state := &pendingBundleState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+
+	b := make(bundle.Bundle, 0, len(state.Transactions))
+	for _, trytes := range state.Transactions {
+		tx, err := transaction.AsTransactionObject(trytes)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, *tx)
+	}
+
+	tailHashes := make(hornet.Hashes, 0, len(state.TailHashes))
+	for _, tailHash := range state.TailHashes {
+		tailHashes = append(tailHashes, hornet.HashFromHashTrytes(tailHash))
+	}
+
+	return &pendingBundle{
+		bundle:         b,
+		targets:        state.Targets,
+		inputIndex:     state.InputIndex,
+		remainderIndex: state.RemainderIndex,
+		tailHashes:     tailHashes,
+		attachedAt:     state.AttachedAt,
+	}, nil
+}
+
+// storePendingBundle stores the pending bundle in the state file.
+// The state is written to a temporary file first, which replaces the state file afterwards,
+// so that a crash while writing never leaves a corrupted state file behind.
+func storePendingBundle(filePath string, p *pendingBundle) error {
+	state := &pendingBundleState{
+		InputIndex:     p.inputIndex,
+		RemainderIndex: p.remainderIndex,
+		Targets:        p.targets,
+		Transactions:   make([]trinary.Trytes, 0, len(p.bundle)),
+		TailHashes:     make([]trinary.Hash, 0, len(p.tailHashes)),
+		AttachedAt:     p.attachedAt,
+	}
+
+	for i := range p.bundle {
+		trytes, err := transaction.TransactionToTrytes(&p.bundle[i])
+		if err != nil {
+			return err
+		}
+		state.Transactions = append(state.Transactions, trytes)
+	}
+
+	for _, tailHash := range p.tailHashes {
+		state.TailHashes = append(state.TailHashes, tailHash.Trytes())
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tempFilePath := filePath + "_tmp"
+
+	stateFile, err := os.OpenFile(tempFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stateFile.Write(data); err != nil {
+		stateFile.Close()
+		return err
+	}
+
+	if err := stateFile.Sync(); err != nil {
+		stateFile.Close()
+		return err
+	}
+
+	if err := stateFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
+
+// removePendingBundle removes the state file after the pending bundle was confirmed.
+func removePendingBundle(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package gossip
+
+import (
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
+	"github.com/gohornet/hornet/pkg/protocol/helpers"
+	"github.com/gohornet/hornet/pkg/protocol/sting"
+)
+
+var (
+	// used to distribute milestone cone requests over the peers
+	coneRequestCounter uint32
+)
+
+// sets up the event handlers which propagate milestone cone requests.
+func addBulkSyncMessageEventHandlers(p *peer.Peer) {
+
+	p.Protocol.Events.Received[bulksync.MessageTypeMilestoneConeRequest].Attach(events.NewClosure(func(data []byte) {
+		p.Metrics.ReceivedMilestoneRequests.Inc()
+		metrics.SharedServerMetrics.ReceivedMilestoneRequests.Inc()
+		msgProcessor.Process(p, bulksync.MessageTypeMilestoneConeRequest, data)
+	}))
+
+	p.Protocol.Events.Sent[bulksync.MessageTypeMilestoneConeRequest].Attach(events.NewClosure(func() {
+		p.Metrics.SentPackets.Inc()
+		p.Metrics.SentMilestoneRequests.Inc()
+		metrics.SharedServerMetrics.SentMilestoneRequests.Inc()
+	}))
+}
+
+// RequestMilestoneCones requests the cones of the milestones of the given batch from one of the connected peers
+// which support milestone cone requests and have the data for the whole batch. The requests are distributed over
+// all eligible peers. Returns false if no peer is able to serve the batch.
+func RequestMilestoneCones(b *bulksync.Batch) bool {
+	var eligible []*peer.Peer
+	manager.ForAllConnected(func(p *peer.Peer) bool {
+		if !p.Protocol.Supports(sting.FeatureSet) || !p.Protocol.HasCapability(bulksync.Capability) {
+			return true
+		}
+		if !p.HasDataFor(b.Start) || !p.HasDataFor(b.End) {
+			return true
+		}
+		eligible = append(eligible, p)
+		return true
+	})
+
+	if len(eligible) == 0 {
+		return false
+	}
+
+	p := eligible[atomic.AddUint32(&coneRequestCounter, 1)%uint32(len(eligible))]
+	helpers.SendMilestoneConeRequest(p, b.Start, b.End)
+	return true
+}
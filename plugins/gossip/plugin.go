@@ -16,6 +16,8 @@ import (
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/profile"
 	"github.com/gohornet/hornet/pkg/protocol/bqueue"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
+	"github.com/gohornet/hornet/pkg/protocol/message"
 	"github.com/gohornet/hornet/pkg/protocol/processor"
 	"github.com/gohornet/hornet/pkg/protocol/rqueue"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
@@ -84,6 +86,10 @@ func configure(plugin *node.Plugin) {
 		if p.Protocol.Supports(sting.FeatureSet) {
 			addSTINGMessageEventHandlers(p)
 
+			if p.Protocol.HasCapability(bulksync.Capability) {
+				addBulkSyncMessageEventHandlers(p)
+			}
+
 			// send heartbeat and latest milestone request
 			if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil {
 				connected, synced := manager.ConnectedAndSyncedPeerCount()
@@ -107,9 +113,22 @@ func configure(plugin *node.Plugin) {
 				case <-shutdownSignal:
 					return
 				case data := <-p.SendQueue:
+					// drop the transaction if the neighbor would exceed its outbound transaction rate
+					if message.Type(data[0]) == sting.MessageTypeTransaction && !p.Limiter.AllowOutboundTransaction() {
+						p.Metrics.RateLimitedSentTransactions.Inc()
+						continue
+					}
+
+					// wait until the message fits into the outbound bandwidth of the neighbor
+					if !p.Limiter.WaitOutbound(len(data), disconnectSignal) {
+						return
+					}
+
 					if err := p.Protocol.Send(data); err != nil {
 						p.Protocol.Events.Error.Trigger(err)
+						continue
 					}
+					manager.AccountSent(len(data))
 				}
 			}
 		}, shutdown.PriorityPeerSendQueue)
@@ -4,7 +4,9 @@ import (
 	"github.com/iotaledger/hive.go/events"
 
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
+	peeringpackage "github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
 	"github.com/gohornet/hornet/plugins/peering"
@@ -16,6 +18,13 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 	p.Protocol.Events.Received[sting.MessageTypeTransaction].Attach(events.NewClosure(func(data []byte) {
 		p.Metrics.ReceivedTransactions.Inc()
 		metrics.SharedServerMetrics.Transactions.Inc()
+
+		// drop the transaction if the neighbor exceeds its inbound transaction rate
+		if !p.Limiter.AllowInboundTransaction() {
+			p.Metrics.RateLimitedReceivedTransactions.Inc()
+			return
+		}
+
 		msgProcessor.Process(p, sting.MessageTypeTransaction, data)
 	}))
 
@@ -53,7 +62,7 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 		p.Metrics.ReceivedHeartbeats.Inc()
 		metrics.SharedServerMetrics.ReceivedHeartbeats.Inc()
 
-		p.LatestHeartbeat = sting.ParseHeartbeat(data)
+		p.SetLatestHeartbeat(sting.ParseHeartbeat(data))
 
 		if p.Autopeering != nil && p.LatestHeartbeat.SolidMilestoneIndex < tangle.GetSnapshotInfo().PruningIndex {
 			// peer is connected via autopeering and its latest solid milestone index is below our pruning index.
@@ -63,6 +72,12 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 			return
 		}
 
+		// a synced node penalizes neighbors which advertise milestones far behind its own
+		staleMilestoneThreshold := milestone.Index(peering.Manager().Opts.Reputation.StaleMilestoneThreshold)
+		if solidMilestoneIndex := tangle.GetSolidMilestoneIndex(); tangle.IsNodeSynced() && p.LatestHeartbeat.LatestMilestoneIndex+staleMilestoneThreshold < solidMilestoneIndex {
+			peering.Manager().Penalize(p, peeringpackage.MisbehaviorStaleMilestone)
+		}
+
 		p.Events.HeartbeatUpdated.Trigger(p.LatestHeartbeat)
 	}))
 
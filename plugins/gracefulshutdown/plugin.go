@@ -10,6 +10,9 @@ import (
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/shutdown"
 )
 
 // the maximum amount of time to wait for background processes to terminate. After that the process is killed.
@@ -31,6 +34,18 @@ func configure(plugin *node.Plugin) {
 	go func() {
 		<-gracefulStop
 
+		shutdown.SetRequested()
+
+		// give load balancers the time to notice that the node is not ready anymore
+		if readinessDelay := time.Duration(config.NodeConfig.GetInt(config.CfgNodeShutdownReadinessDelaySeconds)) * time.Second; readinessDelay > 0 {
+			log.Warnf("Received shutdown request - reporting not ready for %v before shutting down ...", readinessDelay)
+			select {
+			case <-time.After(readinessDelay):
+			case <-gracefulStop:
+				log.Warn("Received second shutdown request - skipping the readiness delay ...")
+			}
+		}
+
 		log.Warnf("Received shutdown request - waiting (max %d seconds) to finish processing ...", waitToKillTimeInSeconds)
 
 		go func() {
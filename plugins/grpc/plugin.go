@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+var (
+	// gRPC is disabled by default
+	PLUGIN = node.NewPlugin("GRPC", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	confirmedTxWorkerCount     = 1
+	confirmedTxWorkerQueueSize = 10000
+	confirmedTxWorkerPool      *workerpool.WorkerPool
+
+	server  *grpc.Server
+	streams *confirmedTxStreams
+)
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	streams = newConfirmedTxStreams(config.NodeConfig.GetInt(config.CfgGRPCStreamBufferSize))
+
+	confirmedTxWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onConfirmedTx(task.Param(0).(*tanglePackage.CachedMetadata), task.Param(1).(milestone.Index), task.Param(2).(int64)) // meta pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(confirmedTxWorkerCount), workerpool.QueueSize(confirmedTxWorkerQueueSize))
+
+	server = grpc.NewServer()
+	grpcapi.RegisterNodeServer(server, &nodeServer{})
+}
+
+func run(_ *node.Plugin) {
+	log.Info("Starting gRPC server ...")
+
+	onTransactionConfirmed := events.NewClosure(func(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index, confTime int64) {
+		if !streams.HasSubscribers() || cachedMeta.GetMetadata().IsConflicting() {
+			cachedMeta.Release(true) // meta -1
+			return
+		}
+
+		if _, added := confirmedTxWorkerPool.TrySubmit(cachedMeta, msIndex, confTime); added { // meta pass +1
+			return // Avoid meta -1 (done inside workerpool task)
+		}
+		cachedMeta.Release(true) // meta -1
+	})
+
+	daemon.BackgroundWorker("gRPC server", func(shutdownSignal <-chan struct{}) {
+		bindAddr := config.NodeConfig.GetString(config.CfgGRPCBindAddress)
+
+		listener, err := net.Listen("tcp", bindAddr)
+		if err != nil {
+			log.Errorf("Stopping gRPC server due to an error: %s", err)
+			return
+		}
+
+		log.Info("Starting gRPC server ... done")
+
+		go func() {
+			log.Infof("You can now access the gRPC API using: %s", bindAddr)
+			if err := server.Serve(listener); err != nil {
+				log.Warnf("Stopping gRPC server due to an error: %s", err)
+			}
+		}()
+
+		<-shutdownSignal
+		log.Info("Stopping gRPC server ...")
+
+		// close the open streams, otherwise the graceful stop would wait for them forever
+		streams.CloseAll()
+
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			server.Stop()
+		}
+
+		log.Info("Stopping gRPC server ... done")
+	}, shutdown.PriorityAPI)
+
+	daemon.BackgroundWorker("gRPC[ConfirmedTxWorker]", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting gRPC[ConfirmedTxWorker] ... done")
+		tangle.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		confirmedTxWorkerPool.Start()
+		<-shutdownSignal
+		log.Info("Stopping gRPC[ConfirmedTxWorker] ...")
+		tangle.Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+		confirmedTxWorkerPool.StopAndWait()
+		log.Info("Stopping gRPC[ConfirmedTxWorker] ... done")
+	}, shutdown.PriorityAPI)
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/grpcapi"
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/cli"
+	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/peering"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
+)
+
+// nodeServer implements the grpcapi.NodeServer interface.
+type nodeServer struct{}
+
+func (s *nodeServer) GetNodeInfo(_ context.Context, _ *grpcapi.GetNodeInfoRequest) (*grpcapi.NodeInfo, error) {
+	result := &grpcapi.NodeInfo{
+		AppName:    cli.AppName,
+		AppVersion: cli.AppVersion,
+		Features:   []string{},
+	}
+
+	if config.NodeConfig.GetBool(config.CfgNodeShowAliasInGetNodeInfo) {
+		result.NodeAlias = config.NodeConfig.GetString(config.CfgNodeAlias)
+	}
+
+	result.Neighbors = uint32(peering.Manager().PeerCount())
+
+	lmi := tangle.GetLatestMilestoneIndex()
+	result.LatestMilestoneIndex = uint32(lmi)
+	result.LatestMilestone = consts.NullHashTrytes
+	cachedLatestMs := tangle.GetMilestoneOrNil(lmi) // bundle +1
+	if cachedLatestMs != nil {
+		result.LatestMilestone = cachedLatestMs.GetBundle().GetMilestoneHash().Trytes()
+		cachedLatestMs.Release(true) // bundle -1
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+	result.LatestSolidMilestoneIndex = uint32(smi)
+	result.LatestSolidMilestone = consts.NullHashTrytes
+	cachedSolidMs := tangle.GetMilestoneOrNil(smi) // bundle +1
+	if cachedSolidMs != nil {
+		result.LatestSolidMilestone = cachedSolidMs.GetBundle().GetMilestoneHash().Trytes()
+		cachedSolidMs.Release(true) // bundle -1
+	}
+
+	result.IsSynced = tangle.IsNodeSyncedWithThreshold()
+	result.IsHealthy = tangleplugin.IsNodeHealthy()
+
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo != nil {
+		result.MilestoneStartIndex = uint32(snapshotInfo.PruningIndex)
+		result.LastSnapshottedMilestoneIndex = uint32(snapshotInfo.SnapshotIndex)
+		if snapshotInfo.IsSpentAddressesEnabled() {
+			result.Features = append(result.Features, "WereAddressesSpentFrom")
+		}
+	}
+
+	result.Time = time.Now().Unix() * 1000
+	result.Tips = metrics.SharedServerMetrics.TipsNonLazy.Load() + metrics.SharedServerMetrics.TipsSemiLazy.Load()
+
+	queued, pending, _ := gossip.RequestQueue().Size()
+	result.TransactionsToRequest = uint32(queued + pending)
+
+	result.CoordinatorAddress = config.NodeConfig.GetString(config.CfgCoordinatorAddress)
+
+	return result, nil
+}
+
+func (s *nodeServer) GetTransactions(_ context.Context, req *grpcapi.GetTransactionsRequest) (*grpcapi.GetTransactionsResponse, error) {
+
+	maxGetTrytes := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxGetTrytes)
+	if len(req.GetHashes()) > maxGetTrytes {
+		return nil, status.Errorf(codes.InvalidArgument, "too many hashes, max. allowed: %d", maxGetTrytes)
+	}
+
+	for _, hash := range req.GetHashes() {
+		if !guards.IsTransactionHash(hash) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid hash supplied: %s", hash)
+		}
+	}
+
+	result := &grpcapi.GetTransactionsResponse{}
+	for _, hash := range req.GetHashes() {
+		tx := &grpcapi.Transaction{Hash: hash}
+		result.Transactions = append(result.Transactions, tx)
+
+		cachedTx := tangle.GetCachedTransactionOrNil(hornet.HashFromHashTrytes(hash)) // tx +1
+		if cachedTx == nil {
+			continue
+		}
+
+		trytes, err := transaction.TransactionToTrytes(cachedTx.GetTransaction().Tx)
+		cachedTx.Release(true) // tx -1
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		tx.Trytes = trytes
+	}
+
+	return result, nil
+}
+
+func (s *nodeServer) BroadcastTransactions(_ context.Context, req *grpcapi.BroadcastTransactionsRequest) (*grpcapi.BroadcastTransactionsResponse, error) {
+
+	if len(req.GetTrytes()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no trytes provided")
+	}
+
+	for _, trytes := range req.GetTrytes() {
+		if err := trinary.ValidTrytes(trytes); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	for _, trytes := range req.GetTrytes() {
+		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return &grpcapi.BroadcastTransactionsResponse{}, nil
+}
+
+func (s *nodeServer) ListenToConfirmedTransactions(req *grpcapi.ListenToConfirmedTransactionsRequest, srv grpcapi.Node_ListenToConfirmedTransactionsServer) error {
+	stream := streams.Subscribe(req.GetIncludeTrytes())
+	defer streams.Unsubscribe(stream)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-stream.closed:
+			return status.Error(codes.Unavailable, "node is shutting down")
+		case tx := <-stream.txs:
+			if err := srv.Send(tx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func onConfirmedTx(cachedMeta *tangle.CachedMetadata, msIndex milestone.Index, confTime int64) {
+
+	cachedMeta.ConsumeMetadata(func(metadata *hornet.TransactionMetadata) { // meta -1
+
+		cachedTx := tangle.GetCachedTransactionOrNil(metadata.GetTxHash()) // tx +1
+		if cachedTx == nil {
+			log.Warnf("%v hash: %s", tangle.ErrTransactionNotFound, metadata.GetTxHash().Trytes())
+			return
+		}
+
+		cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) { // tx -1
+			trytes, err := transaction.TransactionToTrytes(tx.Tx)
+			if err != nil {
+				log.Warn(err.Error())
+				return
+			}
+
+			streams.Publish(&grpcapi.ConfirmedTransaction{
+				Hash:             tx.Tx.Hash,
+				MilestoneIndex:   uint32(msIndex),
+				ConfirmationTime: confTime,
+				Trytes:           trytes,
+			})
+		})
+	})
+}
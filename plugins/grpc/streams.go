@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"github.com/iotaledger/hive.go/syncutils"
+
+	"github.com/gohornet/hornet/pkg/grpcapi"
+)
+
+// confirmedTxStream is the send buffer of a single confirmed transactions stream.
+type confirmedTxStream struct {
+	includeTrytes bool
+	txs           chan *grpcapi.ConfirmedTransaction
+	closed        chan struct{}
+}
+
+// confirmedTxStreams keeps track of all open confirmed transactions streams.
+type confirmedTxStreams struct {
+	syncutils.RWMutex
+
+	bufferSize int
+	streams    map[*confirmedTxStream]struct{}
+	closed     bool
+}
+
+func newConfirmedTxStreams(bufferSize int) *confirmedTxStreams {
+	return &confirmedTxStreams{
+		bufferSize: bufferSize,
+		streams:    make(map[*confirmedTxStream]struct{}),
+	}
+}
+
+// Subscribe registers a new stream. The returned stream is already closed if the node is shutting down.
+func (s *confirmedTxStreams) Subscribe(includeTrytes bool) *confirmedTxStream {
+	s.Lock()
+	defer s.Unlock()
+
+	stream := &confirmedTxStream{
+		includeTrytes: includeTrytes,
+		txs:           make(chan *grpcapi.ConfirmedTransaction, s.bufferSize),
+		closed:        make(chan struct{}),
+	}
+
+	if s.closed {
+		close(stream.closed)
+		return stream
+	}
+
+	s.streams[stream] = struct{}{}
+	return stream
+}
+
+// Unsubscribe removes the given stream.
+func (s *confirmedTxStreams) Unsubscribe(stream *confirmedTxStream) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.streams, stream)
+}
+
+// HasSubscribers returns whether there is at least one open stream.
+func (s *confirmedTxStreams) HasSubscribers() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.streams) > 0
+}
+
+// Publish passes the confirmed transaction to all open streams.
+// Streams whose send buffer is full miss the transaction.
+func (s *confirmedTxStreams) Publish(tx *grpcapi.ConfirmedTransaction) {
+	s.RLock()
+	defer s.RUnlock()
+
+	var txWithoutTrytes *grpcapi.ConfirmedTransaction
+	for stream := range s.streams {
+		streamTx := tx
+		if !stream.includeTrytes {
+			if txWithoutTrytes == nil {
+				txWithoutTrytes = &grpcapi.ConfirmedTransaction{
+					Hash:             tx.GetHash(),
+					MilestoneIndex:   tx.GetMilestoneIndex(),
+					ConfirmationTime: tx.GetConfirmationTime(),
+				}
+			}
+			streamTx = txWithoutTrytes
+		}
+
+		select {
+		case stream.txs <- streamTx:
+		default:
+			log.Warnf("dropped confirmed transaction %s, stream buffer is full", tx.GetHash())
+		}
+	}
+}
+
+// CloseAll closes all open streams and rejects new ones.
+func (s *confirmedTxStreams) CloseAll() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.closed = true
+	for stream := range s.streams {
+		close(stream.closed)
+		delete(s.streams, stream)
+	}
+}
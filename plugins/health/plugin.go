@@ -0,0 +1,133 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/peering"
+)
+
+const (
+	healthzRoute = "/healthz"
+	readyzRoute  = "/readyz"
+)
+
+// PLUGIN Health
+var (
+	// Health is disabled by default
+	PLUGIN = node.NewPlugin("Health", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	server                *http.Server
+	minConnectedNeighbors int
+)
+
+// Status is the response of the health and readiness endpoints.
+type Status struct {
+	Healthy              bool            `json:"healthy"`
+	Ready                bool            `json:"ready"`
+	Synced               bool            `json:"synced"`
+	DatabaseHealthy      bool            `json:"databaseHealthy"`
+	ConnectedNeighbors   int             `json:"connectedNeighbors"`
+	LatestMilestoneIndex milestone.Index `json:"latestMilestoneIndex"`
+	SolidMilestoneIndex  milestone.Index `json:"solidMilestoneIndex"`
+}
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	minConnectedNeighbors = config.NodeConfig.GetInt(config.CfgHealthMinConnectedNeighbors)
+}
+
+// isDatabaseHealthy returns whether the database is not tainted and the snapshot info can be read.
+func isDatabaseHealthy() bool {
+	return !tangle.IsDatabaseTainted() && tangle.GetSnapshotInfo() != nil
+}
+
+// status collects the current health and readiness of the node.
+// The node is healthy as long as its database is healthy. It is ready if it is additionally synced,
+// has enough connected neighbors and no shutdown was requested.
+func status() *Status {
+	if config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
+		// autopeering entry nodes have no tangle
+		return &Status{Healthy: true, Ready: !shutdown.Requested(), Synced: true, DatabaseHealthy: true}
+	}
+
+	s := &Status{
+		Synced:               tangle.IsNodeSyncedWithThreshold(),
+		DatabaseHealthy:      isDatabaseHealthy(),
+		ConnectedNeighbors:   peering.Manager().ConnectedPeerCount(),
+		LatestMilestoneIndex: tangle.GetLatestMilestoneIndex(),
+		SolidMilestoneIndex:  tangle.GetSolidMilestoneIndex(),
+	}
+	s.Healthy = s.DatabaseHealthy
+	s.Ready = s.Healthy && s.Synced && s.ConnectedNeighbors >= minConnectedNeighbors && !shutdown.Requested()
+
+	return s
+}
+
+func run(_ *node.Plugin) {
+	log.Info("Starting Health server ...")
+
+	daemon.BackgroundWorker("Health server", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting Health server ... done")
+
+		gin.SetMode(gin.ReleaseMode)
+		engine := gin.New()
+		engine.Use(gin.Recovery())
+
+		// GET /healthz
+		engine.GET(healthzRoute, func(c *gin.Context) {
+			s := status()
+			if !s.Healthy {
+				c.JSON(http.StatusServiceUnavailable, s)
+				return
+			}
+			c.JSON(http.StatusOK, s)
+		})
+
+		// GET /readyz
+		engine.GET(readyzRoute, func(c *gin.Context) {
+			s := status()
+			if !s.Ready {
+				c.JSON(http.StatusServiceUnavailable, s)
+				return
+			}
+			c.JSON(http.StatusOK, s)
+		})
+
+		bindAddr := config.NodeConfig.GetString(config.CfgHealthBindAddress)
+		server = &http.Server{Addr: bindAddr, Handler: engine}
+
+		go func() {
+			log.Infof("You can now access the health endpoints using: http://%s%s and http://%s%s", bindAddr, healthzRoute, bindAddr, readyzRoute)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Warnf("Stopping Health server due to an error (%s) ... done", err)
+			}
+		}()
+
+		<-shutdownSignal
+		log.Info("Stopping Health server ...")
+
+		if server != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := server.Shutdown(ctx)
+			if err != nil {
+				log.Warn(err.Error())
+			}
+			cancel()
+		}
+		log.Info("Stopping Health server ... done")
+	}, shutdown.PriorityAPI)
+}
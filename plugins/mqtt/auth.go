@@ -0,0 +1,23 @@
+package mqtt
+
+import (
+	"github.com/fhmq/hmq/broker"
+
+	"github.com/gohornet/hornet/pkg/basicauth"
+)
+
+// clientAuth only allows clients with valid credentials to connect to the broker.
+// Clients are only allowed to subscribe, the topics are exclusively published by the node.
+type clientAuth struct {
+	username     string
+	passwordHash string
+	passwordSalt string
+}
+
+func (a *clientAuth) CheckConnect(_ string, username string, password string) bool {
+	return username == a.username && basicauth.VerifyPassword(password, a.passwordSalt, a.passwordHash)
+}
+
+func (a *clientAuth) CheckACL(action string, _ string, _ string, _ string, _ string) bool {
+	return action == broker.SUB
+}
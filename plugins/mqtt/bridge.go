@@ -0,0 +1,62 @@
+package mqtt
+
+import (
+	"time"
+
+	mqttclient "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	bridgeConnectTimeout       = 10 * time.Second
+	bridgeMaxReconnectInterval = time.Minute
+	bridgeDisconnectQuiesceMs  = 250
+)
+
+// bridge forwards all published messages to an external MQTT broker.
+type bridge struct {
+	client      mqttclient.Client
+	topicPrefix string
+	qos         byte
+}
+
+func newBridge(brokerURL string, clientID string, username string, password string, topicPrefix string, qos byte) *bridge {
+	opts := mqttclient.NewClientOptions().
+		AddBroker(brokerURL).
+		SetClientID(clientID).
+		SetUsername(username).
+		SetPassword(password).
+		SetConnectTimeout(bridgeConnectTimeout).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetMaxReconnectInterval(bridgeMaxReconnectInterval).
+		SetOnConnectHandler(func(_ mqttclient.Client) {
+			log.Infof("Connected to external MQTT broker %s", brokerURL)
+		}).
+		SetConnectionLostHandler(func(_ mqttclient.Client, err error) {
+			log.Warnf("Lost connection to external MQTT broker %s: %s", brokerURL, err)
+		})
+
+	return &bridge{
+		client:      mqttclient.NewClient(opts),
+		topicPrefix: topicPrefix,
+		qos:         qos,
+	}
+}
+
+// Connect starts connecting to the external broker. Failed attempts are retried in the background.
+func (b *bridge) Connect() {
+	b.client.Connect()
+}
+
+// Publish forwards the message without waiting for the acknowledgement of the external broker.
+func (b *bridge) Publish(topic string, message string) {
+	if !b.client.IsConnectionOpen() {
+		return
+	}
+	b.client.Publish(b.topicPrefix+topic, b.qos, false, message)
+}
+
+// Disconnect closes the connection to the external broker.
+func (b *bridge) Disconnect() {
+	b.client.Disconnect(bridgeDisconnectQuiesceMs)
+}
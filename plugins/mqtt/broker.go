@@ -2,16 +2,29 @@ package mqtt
 
 import (
 	"fmt"
+	"net"
+	"sync"
 
 	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/fhmq/hmq/broker"
+
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ipfilter"
 )
 
 // Simple mqtt publisher abstraction
 type Broker struct {
 	broker *broker.Broker
 	config *broker.Config
+	bridge *bridge
+
+	// the public addresses of the broker, which are served by the filtered proxies
+	bindAddress    string
+	tlsBindAddress string
+	proxies        []*filteredProxy
+
+	// the listeners of the broker can't be closed, so the broker is only started once
+	startOnce sync.Once
 }
 
 // Create a new publisher.
@@ -22,27 +35,137 @@ func NewBroker() (*Broker, error) {
 		log.Fatal("configure broker config error: ", err)
 	}
 
+	if config.NodeConfig.GetBool(config.CfgMQTTTLSEnabled) {
+		bindAddr := config.NodeConfig.GetString(config.CfgMQTTTLSBindAddress)
+		c.TlsHost, c.TlsPort, err = net.SplitHostPort(bindAddr)
+		if err != nil {
+			log.Fatalf("'%s' is invalid: %s", config.CfgMQTTTLSBindAddress, err)
+		}
+
+		caPath := config.NodeConfig.GetString(config.CfgMQTTTLSCAPath)
+		c.TlsInfo = broker.TLSInfo{
+			Verify:   caPath != "",
+			CaFile:   caPath,
+			CertFile: config.NodeConfig.GetString(config.CfgMQTTTLSCertPath),
+			KeyFile:  config.NodeConfig.GetString(config.CfgMQTTTLSKeyPath),
+		}
+	}
+
+	if config.NodeConfig.GetBool(config.CfgMQTTAuthEnabled) {
+		auth := &clientAuth{
+			username:     config.NodeConfig.GetString(config.CfgMQTTAuthUsername),
+			passwordHash: config.NodeConfig.GetString(config.CfgMQTTAuthPasswordHash),
+			passwordSalt: config.NodeConfig.GetString(config.CfgMQTTAuthPasswordSalt),
+		}
+
+		if len(auth.username) == 0 {
+			log.Fatalf("'%s' must not be empty if MQTT auth is enabled", config.CfgMQTTAuthUsername)
+		}
+
+		if len(auth.passwordHash) != 64 {
+			log.Fatalf("'%s' must be 64 (sha256 hash) in length if MQTT auth is enabled", config.CfgMQTTAuthPasswordHash)
+		}
+
+		c.Plugin.Auth = auth
+	}
+
+	ipFilter, err := ipfilter.NewFromConfig(config.CfgMQTTIPFilterAllowedNetworks, config.CfgMQTTIPFilterDeniedNetworks, log)
+	if err != nil {
+		log.Fatalf("Invalid IP filter of the MQTT broker: %s", err)
+	}
+
+	// the clients connect to the filtered proxies on the public addresses,
+	// the broker itself only listens on the loopback interface
+	var bindAddress, tlsBindAddress string
+	var proxies []*filteredProxy
+	addProxy := func(host *string, port *string) string {
+		publicAddress := net.JoinHostPort(*host, *port)
+		proxy, err := newFilteredProxy(publicAddress, ipFilter)
+		if err != nil {
+			log.Fatalf("Creating the MQTT listener on %s failed: %s", publicAddress, err)
+		}
+		*host, *port, _ = net.SplitHostPort(proxy.target)
+		proxies = append(proxies, proxy)
+		return publicAddress
+	}
+
+	if c.Port != "" {
+		bindAddress = addProxy(&c.Host, &c.Port)
+	}
+	if c.TlsPort != "" {
+		tlsBindAddress = addProxy(&c.TlsHost, &c.TlsPort)
+	}
+	if c.WsPort != "" {
+		log.Warnf("The websocket listener of the MQTT broker on port %s is not covered by the IP filter", c.WsPort)
+	}
+
 	b, err := broker.NewBroker(c)
 	if err != nil {
 		log.Fatal("New Broker error: ", err)
 	}
 
+	var br *bridge
+	if config.NodeConfig.GetBool(config.CfgMQTTBridgeEnabled) {
+		brokerURL := config.NodeConfig.GetString(config.CfgMQTTBridgeBrokerURL)
+		if len(brokerURL) == 0 {
+			log.Fatalf("'%s' must not be empty if the MQTT bridge is enabled", config.CfgMQTTBridgeBrokerURL)
+		}
+
+		qos := config.NodeConfig.GetInt(config.CfgMQTTBridgeQoS)
+		if qos < 0 || qos > 2 {
+			log.Fatalf("'%s' must be 0, 1 or 2", config.CfgMQTTBridgeQoS)
+		}
+
+		br = newBridge(brokerURL,
+			config.NodeConfig.GetString(config.CfgMQTTBridgeClientID),
+			config.NodeConfig.GetString(config.CfgMQTTBridgeUsername),
+			config.NodeConfig.GetString(config.CfgMQTTBridgePassword),
+			config.NodeConfig.GetString(config.CfgMQTTBridgeTopicPrefix),
+			byte(qos))
+	}
+
 	return &Broker{
-		broker: b,
-		config: c,
+		broker:         b,
+		config:         c,
+		bridge:         br,
+		bindAddress:    bindAddress,
+		tlsBindAddress: tlsBindAddress,
+		proxies:        proxies,
 	}, nil
 }
 
-// Start the broker
+// Start the broker.
+// The listeners of the broker itself can't be closed, so the broker is only started once,
+// but the clients can only connect while the proxies on the public addresses are started.
 func (b *Broker) Start() error {
-	b.broker.Start()
+	b.startOnce.Do(b.broker.Start)
+
+	for _, proxy := range b.proxies {
+		if err := proxy.Start(); err != nil {
+			return err
+		}
+	}
+
+	if b.bridge != nil {
+		b.bridge.Connect()
+	}
 	return nil
 }
 
 // Stop the broker.
+// The proxies on the public addresses are closed, which disconnects all clients.
 func (b *Broker) Shutdown() error {
-	//return b.broker.Close()
-	return nil
+	if b.bridge != nil {
+		b.bridge.Disconnect()
+	}
+
+	var err error
+	for _, proxy := range b.proxies {
+		if closeErr := proxy.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 // Publish a new list of messages.
@@ -55,5 +178,9 @@ func (b *Broker) Send(topic string, message string) error {
 
 	b.broker.PublishMessage(packet)
 
+	if b.bridge != nil {
+		b.bridge.Publish(topic, message)
+	}
+
 	return nil
 }
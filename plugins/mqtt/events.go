@@ -21,7 +21,7 @@ func onNewTx(cachedTx *tangle.CachedTransaction) {
 
 	cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) {
 
-		// tx topic
+		// tx, addresses/{address} and tags/{tag} topics
 		err := publishTx(tx.Tx)
 		if err != nil {
 			log.Warn(err.Error())
@@ -51,10 +51,17 @@ func onConfirmedTx(cachedMeta *tangle.CachedMetadata, msIndex milestone.Index, _
 				log.Warn(err.Error())
 			}
 
-			// sn topic
+			// sn and addresses/{address}/confirmed topics
 			if err := publishConfTx(tx.Tx, msIndex); err != nil {
 				log.Warn(err.Error())
 			}
+
+			// confirmed_bundle and bundles/{bundle}/confirmed topics
+			if tx.IsTail() {
+				if err := publishConfBundle(tx.Tx, msIndex); err != nil {
+					log.Warn(err.Error())
+				}
+			}
 		})
 	})
 }
@@ -147,14 +154,37 @@ func publishLSM(bndl *tangle.Bundle) error {
 // Publish confirmed transaction
 func publishConfTx(iotaTx *transaction.Transaction, msIndex milestone.Index) error {
 
-	return mqttBroker.Send(topicSN, fmt.Sprintf(`{"msIndex":%d,"txHash":"%v","address":"%v","trunk":"%v","branch":"%v","bundle":"%v","timestamp":"%s"}`,
+	payload := fmt.Sprintf(`{"msIndex":%d,"txHash":"%v","address":"%v","trunk":"%v","branch":"%v","bundle":"%v","timestamp":"%s"}`,
 		msIndex,                  // Index of the milestone that confirmed the transaction
 		iotaTx.Hash,              // Transaction hash
 		iotaTx.Address,           // Address
 		iotaTx.TrunkTransaction,  // Trunk transaction hash
 		iotaTx.BranchTransaction, // Branch transaction hash
 		iotaTx.Bundle,            // Bundle hash
-		time.Now().UTC().Format(time.RFC3339)))
+		time.Now().UTC().Format(time.RFC3339))
+
+	if err := mqttBroker.Send(topicSN, payload); err != nil {
+		return err
+	}
+
+	return mqttBroker.Send(topicAddressConfirmed(iotaTx.Address), payload)
+}
+
+// Publish confirmed bundle (triggered by the confirmation of the tail transaction)
+func publishConfBundle(tailTx *transaction.Transaction, msIndex milestone.Index) error {
+
+	payload := fmt.Sprintf(`{"msIndex":%d,"bundle":"%v","tailTxHash":"%v","lastIndex":%d,"timestamp":"%s"}`,
+		msIndex,          // Index of the milestone that confirmed the bundle
+		tailTx.Bundle,    // Bundle hash
+		tailTx.Hash,      // Tail transaction hash
+		tailTx.LastIndex, // Last transaction index of the bundle
+		time.Now().UTC().Format(time.RFC3339))
+
+	if err := mqttBroker.Send(topicConfBundle, payload); err != nil {
+		return err
+	}
+
+	return mqttBroker.Send(topicBundleConfirmed(tailTx.Bundle), payload)
 }
 
 // Publish confirmed transaction trytes
@@ -191,7 +221,7 @@ func publishTxTrytes(iotaTx *transaction.Transaction) error {
 // Publish a transaction that has recently been added to the ledger
 func publishTx(iotaTx *transaction.Transaction) error {
 
-	return mqttBroker.Send(topicTX, fmt.Sprintf(`{"txHash":"%v","address":"%v","value":%d,"obsoleteTag":"%v","txTimestamp":%d,"currentIndex":%d,"lastIndex":%d,"bundle":"%v","trunk":"%v","branch":"%v","recTimestamp":%d,"tag":"%v","timestamp":"%s"}`,
+	payload := fmt.Sprintf(`{"txHash":"%v","address":"%v","value":%d,"obsoleteTag":"%v","txTimestamp":%d,"currentIndex":%d,"lastIndex":%d,"bundle":"%v","trunk":"%v","branch":"%v","recTimestamp":%d,"tag":"%v","timestamp":"%s"}`,
 		iotaTx.Hash,              // Transaction hash
 		iotaTx.Address,           // Address
 		iotaTx.Value,             // Value
@@ -204,7 +234,17 @@ func publishTx(iotaTx *transaction.Transaction) error {
 		iotaTx.BranchTransaction, // Branch transaction hash
 		time.Now().Unix(),        // Unix timestamp for when the transaction was received
 		iotaTx.Tag,               // Tag
-		time.Now().UTC().Format(time.RFC3339)))
+		time.Now().UTC().Format(time.RFC3339))
+
+	if err := mqttBroker.Send(topicTX, payload); err != nil {
+		return err
+	}
+
+	if err := mqttBroker.Send(topicAddress(iotaTx.Address), payload); err != nil {
+		return err
+	}
+
+	return mqttBroker.Send(topicTag(iotaTx.Tag), payload)
 }
 
 func publishSpentAddress(addr trinary.Hash) error {
@@ -3,7 +3,6 @@ package mqtt
 import (
 	"github.com/iotaledger/iota.go/trinary"
 
-	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/plugins/tangle"
 )
@@ -20,6 +20,9 @@ var (
 	PLUGIN = node.NewPlugin("MQTT", node.Disabled, configure, run)
 	log    *logger.Logger
 
+	// the plugin can be started and stopped at runtime
+	control *plugincontrol.Plugin
+
 	newTxWorkerCount     = 1
 	newTxWorkerQueueSize = 10000
 	newTxWorkerPool      *workerpool.WorkerPool
@@ -46,9 +49,22 @@ var (
 )
 
 // Configure the MQTT plugin
+func init() {
+	control = plugincontrol.Register(PLUGIN)
+}
+
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 
+	var err error
+	mqttBroker, err = NewBroker()
+	if err != nil {
+		log.Fatalf("MQTT broker init failed! %v", err)
+	}
+}
+
+// configureWorkerPools creates new worker pools at every start of the plugin, since stopped worker pools can't be restarted.
+func configureWorkerPools() {
 	newTxWorkerPool = workerpool.New(func(task workerpool.Task) {
 		onNewTx(task.Param(0).(*tanglePackage.CachedTransaction)) // tx pass +1
 		task.Return(nil)
@@ -73,18 +89,14 @@ func configure(plugin *node.Plugin) {
 		onSpentAddress(task.Param(0).(trinary.Hash))
 		task.Return(nil)
 	}, workerpool.WorkerCount(spentAddressWorkerCount), workerpool.QueueSize(spentAddressWorkerQueueSize))
-
-	var err error
-	mqttBroker, err = NewBroker()
-	if err != nil {
-		log.Fatalf("MQTT broker init failed! %v", err)
-	}
 }
 
 // Start the MQTT plugin
 func run(plugin *node.Plugin) {
 
-	log.Infof("Starting MQTT Broker (port %s) ...", mqttBroker.config.Port)
+	configureWorkerPools()
+
+	log.Infof("Starting MQTT Broker (%s) ...", mqttBroker.bindAddress)
 
 	onReceivedNewTransaction := events.NewClosure(func(cachedTx *tanglePackage.CachedTransaction, latestMilestoneIndex milestone.Index, latestSolidMilestoneIndex milestone.Index) {
 		if !wasSyncBefore {
@@ -146,26 +158,30 @@ func run(plugin *node.Plugin) {
 		spentAddressWorkerPool.TrySubmit(addr)
 	})
 
-	daemon.BackgroundWorker("MQTT Broker", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT Broker", func(shutdownSignal <-chan struct{}) {
+		// the broker is only shut down after it was started, otherwise the listeners would stay open
+		started := make(chan struct{})
 		go func() {
+			defer close(started)
 			if err := startBroker(plugin); err != nil {
 				log.Errorf("Stopping MQTT Broker: %s", err.Error())
 			} else {
-				log.Infof("Starting MQTT Broker (port %s) ... done", mqttBroker.config.Port)
+				log.Infof("Starting MQTT Broker (%s) ... done", mqttBroker.bindAddress)
 			}
 
 		}()
 
-		if mqttBroker.config.Port != "" {
-			log.Infof("You can now listen to MQTT via: http://%s:%s", mqttBroker.config.Host, mqttBroker.config.Port)
+		if mqttBroker.bindAddress != "" {
+			log.Infof("You can now listen to MQTT via: http://%s", mqttBroker.bindAddress)
 		}
 
-		if mqttBroker.config.TlsPort != "" {
-			log.Infof("You can now listen to MQTT via: https://%s:%s", mqttBroker.config.TlsHost, mqttBroker.config.TlsPort)
+		if mqttBroker.tlsBindAddress != "" {
+			log.Infof("You can now listen to MQTT via: https://%s", mqttBroker.tlsBindAddress)
 		}
 
 		<-shutdownSignal
 		log.Info("Stopping MQTT Broker ...")
+		<-started
 
 		if err := mqttBroker.Shutdown(); err != nil {
 			log.Errorf("Stopping MQTT Broker: %s", err.Error())
@@ -174,13 +190,7 @@ func run(plugin *node.Plugin) {
 		}
 	}, shutdown.PriorityMetricsPublishers)
 
-	/*
-		daemon.BackgroundWorker("MQTT address topic updater", func(shutdownSignal <-chan struct{}) {
-			timeutil.Ticker(updateAddressTopics, 5*time.Second, shutdownSignal)
-		}, shutdown.PriorityMetricsPublishers)
-	*/
-
-	daemon.BackgroundWorker("MQTT[NewTxWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT[NewTxWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting MQTT[NewTxWorker] ... done")
 		tangle.Events.ReceivedNewTransaction.Attach(onReceivedNewTransaction)
 		newTxWorkerPool.Start()
@@ -190,7 +200,7 @@ func run(plugin *node.Plugin) {
 		log.Info("Stopping MQTT[NewTxWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("MQTT[ConfirmedTxWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT[ConfirmedTxWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting MQTT[ConfirmedTxWorker] ... done")
 		tangle.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
 		confirmedTxWorkerPool.Start()
@@ -200,7 +210,7 @@ func run(plugin *node.Plugin) {
 		log.Info("Stopping MQTT[ConfirmedTxWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("MQTT[NewLatestMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT[NewLatestMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting MQTT[NewLatestMilestoneWorker] ... done")
 		tangle.Events.LatestMilestoneChanged.Attach(onLatestMilestoneChanged)
 		newLatestMilestoneWorkerPool.Start()
@@ -210,7 +220,7 @@ func run(plugin *node.Plugin) {
 		log.Info("Stopping MQTT[NewLatestMilestoneWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("MQTT[NewSolidMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT[NewSolidMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting MQTT[NewSolidMilestoneWorker] ... done")
 		tangle.Events.SolidMilestoneChanged.Attach(onSolidMilestoneChanged)
 		newSolidMilestoneWorkerPool.Start()
@@ -220,7 +230,7 @@ func run(plugin *node.Plugin) {
 		log.Info("Stopping MQTT[NewSolidMilestoneWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("MQTT[SpentAddress]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("MQTT[SpentAddress]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting MQTT[SpentAddress] ... done")
 		tanglePackage.Events.AddressSpent.Attach(onAddressSpent)
 		spentAddressWorkerPool.Start()
@@ -0,0 +1,152 @@
+package mqtt
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"github.com/gohornet/hornet/pkg/ipfilter"
+)
+
+// filteredProxy accepts the clients on a public address and forwards their connections to a listener of the broker
+// on the loopback interface. The broker doesn't allow to wrap its listeners, so the proxy is the only way to reject
+// clients of denied addresses before they connect, and to disconnect them once their addresses are denied.
+// The proxy can be closed and started again, so that the clients can't connect while the plugin is stopped.
+type filteredProxy struct {
+	bindAddress string
+	target      string
+	filter      *ipfilter.Filter
+
+	// the listener on the public address and the connections of the clients, nil while the proxy is closed
+	lock     sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+}
+
+// newFilteredProxy creates a proxy for the given public address with a free target address on the loopback interface.
+func newFilteredProxy(bindAddress string, filter *ipfilter.Filter) (*filteredProxy, error) {
+	target, err := reserveLoopbackAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return &filteredProxy{
+		bindAddress: bindAddress,
+		target:      target,
+		filter:      filter,
+		conns:       make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// reserveLoopbackAddress returns an address on the loopback interface with a free port.
+func reserveLoopbackAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+
+	return listener.Addr().String(), nil
+}
+
+// Start listens on the public address and forwards the connections of allowed clients.
+func (p *filteredProxy) Start() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.listener != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", p.bindAddress)
+	if err != nil {
+		return err
+	}
+	p.listener = p.filter.Listener(listener)
+
+	go p.serve(p.listener)
+	return nil
+}
+
+// Close stops listening on the public address and disconnects all clients.
+func (p *filteredProxy) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.listener == nil {
+		return nil
+	}
+
+	err := p.listener.Close()
+	p.listener = nil
+
+	for conn := range p.conns {
+		_ = conn.Close()
+		delete(p.conns, conn)
+	}
+	return err
+}
+
+// track adds the connection of a client, it returns false if the proxy was closed in the meantime.
+func (p *filteredProxy) track(conn net.Conn) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.listener == nil {
+		return false
+	}
+	p.conns[conn] = struct{}{}
+	return true
+}
+
+func (p *filteredProxy) untrack(conn net.Conn) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.conns, conn)
+}
+
+func (p *filteredProxy) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				continue
+			}
+
+			p.lock.Lock()
+			closed := p.listener != listener
+			p.lock.Unlock()
+			if !closed {
+				log.Warnf("Stopping MQTT listener on %s: %s", p.bindAddress, err)
+			}
+			return
+		}
+
+		if !p.track(conn) {
+			_ = conn.Close()
+			return
+		}
+		go p.forward(conn)
+	}
+}
+
+// forward copies the data between the client and the broker until one of both closes the connection.
+func (p *filteredProxy) forward(conn net.Conn) {
+	defer p.untrack(conn)
+
+	target, err := net.Dial("tcp", p.target)
+	if err != nil {
+		log.Warnf("Forwarding the MQTT client %s to the broker failed: %s", conn.RemoteAddr(), err)
+		_ = conn.Close()
+		return
+	}
+
+	go func() {
+		_, _ = io.Copy(target, conn)
+		_ = target.Close()
+	}()
+
+	_, _ = io.Copy(conn, target)
+	_ = conn.Close()
+}
@@ -0,0 +1,83 @@
+package mqtt
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gohornet/hornet/pkg/ipfilter"
+)
+
+// startEchoServer starts a listener on the target address of the proxy which echoes all data.
+func startEchoServer(t *testing.T, address string) net.Listener {
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+	return listener
+}
+
+func TestFilteredProxyStartClose(t *testing.T) {
+	log = zap.NewNop().Sugar()
+
+	filter, err := ipfilter.New(nil, nil)
+	require.NoError(t, err)
+
+	bindAddress, err := reserveLoopbackAddress()
+	require.NoError(t, err)
+	proxy, err := newFilteredProxy(bindAddress, filter)
+	require.NoError(t, err)
+
+	echo := startEchoServer(t, proxy.target)
+	defer echo.Close()
+
+	ping := func(conn net.Conn) error {
+		if _, err := conn.Write([]byte("ping\n")); err != nil {
+			return err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "ping\n", line)
+		return nil
+	}
+
+	// the proxy can be started again after it was closed
+	for i := 0; i < 2; i++ {
+		require.NoError(t, proxy.Start())
+		require.NoError(t, proxy.Start())
+
+		conn, err := net.Dial("tcp", bindAddress)
+		require.NoError(t, err)
+		require.NoError(t, ping(conn))
+
+		// closing the proxy disconnects the clients and stops accepting new ones
+		require.NoError(t, proxy.Close())
+		assert.Error(t, ping(conn))
+		_ = conn.Close()
+
+		_, err = net.Dial("tcp", bindAddress)
+		assert.Error(t, err)
+	}
+
+	require.NoError(t, proxy.Close())
+}
@@ -1,5 +1,9 @@
 package mqtt
 
+import (
+	"github.com/iotaledger/iota.go/trinary"
+)
+
 // Topic names
 const (
 	topicLMI          = "lmi"
@@ -12,59 +16,31 @@ const (
 	topicTxTrytes     = "trytes"
 	topicTX           = "tx"
 	topicSpentAddress = "spent_address"
-	//topicPrefixAddress = "addr/"
-)
+	topicConfBundle   = "confirmed_bundle"
 
-/*
-var (
-	addressTopics AddressTopics
+	// parameterized topics, e.g. "addresses/{address}" or "tags/{tag}"
+	topicPrefixAddresses = "addresses/"
+	topicPrefixTags      = "tags/"
+	topicPrefixBundles   = "bundles/"
+	topicSuffixConfirmed = "/confirmed"
 )
 
-// AddressTopics stuct
-type AddressTopics struct {
-	mu         sync.Mutex
-	Addressses []string
+// topicAddress is the topic for new transactions of the given address.
+func topicAddress(addr trinary.Hash) string {
+	return topicPrefixAddresses + addr
 }
 
-// GetAddressTopics returns subscribed addresses
-func GetAddressTopics() []string {
-	addressTopics.mu.Lock()
-	defer addressTopics.mu.Unlock()
-	return addressTopics.Addressses
+// topicAddressConfirmed is the topic for confirmed transactions of the given address.
+func topicAddressConfirmed(addr trinary.Hash) string {
+	return topicPrefixAddresses + addr + topicSuffixConfirmed
 }
 
-// updateAddressTopics filters Subscribers for address topics
-func updateAddressTopics() {
-
-	addrTopic := []string{}
-	if mqttBroker != nil {
-
-		var subs []interface{}
-		var qoss []byte
-
-		err := mqttBroker.Subscribers([]byte(topicPrefixAddress), packet.Qos, &subs, &qoss)
-		if err != nil {
-			log.Errorf("search sub client error, %v", err)
-		}
-
-		for _, sub := range subs {
-			if s, ok := sub.(*subscription); ok {
-				err := address.ValidAddress(s)
-				if err == nil {
-					if len(s) == 90 {
-						addrTopic = append(addrTopic, s[:81])
-					} else if len(s) == 81 {
-						addrTopic = append(addrTopic, s)
-					}
-				}
-			}
-		}
-	}
-
-	sort.Strings(addrTopic)
+// topicTag is the topic for new transactions with the given tag.
+func topicTag(tag trinary.Trytes) string {
+	return topicPrefixTags + tag
+}
 
-	addressTopics.mu.Lock()
-	addressTopics.Addressses = addrTopic
-	addressTopics.mu.Unlock()
+// topicBundleConfirmed is the topic for the confirmation of the given bundle.
+func topicBundleConfirmed(bundle trinary.Hash) string {
+	return topicPrefixBundles + bundle + topicSuffixConfirmed
 }
-*/
@@ -0,0 +1,283 @@
+package notifications
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/workerpool"
+	"github.com/iotaledger/iota.go/address"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/proxy"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/webhook"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	"github.com/gohornet/hornet/plugins/peering"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+const (
+	// EventMilestoneConfirmed is posted when a milestone got confirmed while the node is synced.
+	EventMilestoneConfirmed = "milestoneConfirmed"
+	// EventUnsynced is posted when the node lost its sync.
+	EventUnsynced = "unsynced"
+	// EventSynced is posted when the node is synced again.
+	EventSynced = "synced"
+	// EventNeighborDropped is posted when the connection to a neighbor was lost or the neighbor was removed.
+	EventNeighborDropped = "neighborDropped"
+	// EventAddressActivity is posted when a milestone changed the balance of a watched address.
+	EventAddressActivity = "addressActivity"
+
+	postWorkerCount     = 2
+	postWorkerQueueSize = 1000
+)
+
+var (
+	PLUGIN = node.NewPlugin("Notifications", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	eventTypes = map[string]struct{}{
+		EventMilestoneConfirmed: {},
+		EventUnsynced:           {},
+		EventSynced:             {},
+		EventNeighborDropped:    {},
+		EventAddressActivity:    {},
+	}
+
+	webhooks       []*webhook.Webhook
+	watchedAddrs   = make(map[string]struct{})
+	postWorkerPool *workerpool.WorkerPool
+	retries        int
+	retryDelay     time.Duration
+
+	// whether the node was synced at the last milestone index change
+	wasSynced   bool
+	wasSyncedMu sync.Mutex
+
+	onMilestoneConfirmed       *events.Closure
+	onMilestoneIndexChanged    *events.Closure
+	onPeerMovedToReconnectPool *events.Closure
+	onPeerDisconnected         *events.Closure
+)
+
+// MilestoneData is the data of the milestoneConfirmed event.
+type MilestoneData struct {
+	Index              milestone.Index `json:"index"`
+	MilestoneHash      string          `json:"milestoneHash"`
+	BundlesReferenced  int             `json:"bundlesReferenced"`
+	BundlesIncluded    int             `json:"bundlesIncluded"`
+	BundlesConflicting int             `json:"bundlesConflicting"`
+	BundlesZeroValue   int             `json:"bundlesZeroValue"`
+}
+
+// SyncData is the data of the unsynced and synced events.
+type SyncData struct {
+	LatestMilestoneIndex milestone.Index `json:"latestMilestoneIndex"`
+	SolidMilestoneIndex  milestone.Index `json:"solidMilestoneIndex"`
+}
+
+// NeighborData is the data of the neighborDropped event.
+type NeighborData struct {
+	Address    string `json:"address"`
+	Domain     string `json:"domain,omitempty"`
+	Alias      string `json:"alias,omitempty"`
+	Autopeered bool   `json:"autopeered"`
+}
+
+// AddressData is the data of the addressActivity event.
+type AddressData struct {
+	Address        string          `json:"address"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Change         int64           `json:"change"`
+	Balance        int64           `json:"balance"`
+}
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	var webhookConfigs []config.WebhookConfig
+	if err := config.NodeConfig.UnmarshalKey(config.CfgNotificationsWebhooks, &webhookConfigs); err != nil {
+		log.Fatalf("'%s' is invalid: %s", config.CfgNotificationsWebhooks, err)
+	}
+
+	client := proxy.HTTPClient(time.Duration(config.NodeConfig.GetInt(config.CfgNotificationsTimeoutSeconds)) * time.Second)
+	for _, webhookConfig := range webhookConfigs {
+		for _, eventType := range webhookConfig.Events {
+			if _, known := eventTypes[eventType]; !known {
+				log.Fatalf("unknown event '%s' of webhook %s", eventType, webhookConfig.URL)
+			}
+		}
+
+		w, err := webhook.New(webhookConfig.URL, webhookConfig.Events, webhookConfig.Headers, webhookConfig.Payload, client)
+		if err != nil {
+			log.Fatalf("invalid webhook: %s", err)
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	for _, addr := range config.NodeConfig.GetStringSlice(config.CfgNotificationsAddresses) {
+		if err := address.ValidAddress(addr); err != nil {
+			log.Fatalf("invalid address '%s' in '%s'", addr, config.CfgNotificationsAddresses)
+		}
+		watchedAddrs[string(hornet.HashFromAddressTrytes(addr[:81]))] = struct{}{}
+	}
+
+	retries = config.NodeConfig.GetInt(config.CfgNotificationsRetries)
+	retryDelay = time.Duration(config.NodeConfig.GetInt(config.CfgNotificationsRetryDelaySeconds)) * time.Second
+
+	postWorkerPool = workerpool.New(func(task workerpool.Task) {
+		w := task.Param(0).(*webhook.Webhook)
+		event := task.Param(1).(*webhook.Event)
+		attempt := task.Param(2).(int)
+		if err := w.Post(event); err != nil {
+			if !webhook.Retryable(err) || attempt >= retries || shutdown.Requested() {
+				log.Warnf("posting event '%s' to %s failed: %s", event.Type, w.URL(), err)
+				task.Return(nil)
+				return
+			}
+
+			// don't block the worker while waiting, the delay doubles with every attempt
+			delay := retryDelay << uint(attempt)
+			log.Debugf("posting event '%s' to %s failed, retrying in %v: %s", event.Type, w.URL(), delay, err)
+			time.AfterFunc(delay, func() {
+				if _, added := postWorkerPool.TrySubmit(w, event, attempt+1); !added {
+					log.Warnf("dropped retry of event '%s' for %s", event.Type, w.URL())
+				}
+			})
+		}
+		task.Return(nil)
+	}, workerpool.WorkerCount(postWorkerCount), workerpool.QueueSize(postWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	configureEvents()
+}
+
+func run(_ *node.Plugin) {
+	if len(webhooks) == 0 {
+		log.Warnf("no webhooks configured in '%s'", config.CfgNotificationsWebhooks)
+		return
+	}
+
+	daemon.BackgroundWorker("Notifications", func(shutdownSignal <-chan struct{}) {
+		log.Infof("Starting Notifications ... done, posting to %d webhooks", len(webhooks))
+		attachEvents()
+		postWorkerPool.Start()
+		<-shutdownSignal
+		log.Info("Stopping Notifications ...")
+		detachEvents()
+		postWorkerPool.StopAndWait()
+		log.Info("Stopping Notifications ... done")
+	}, shutdown.PriorityMetricsPublishers)
+}
+
+// post posts the given event to all webhooks which are subscribed to it.
+func post(event *webhook.Event) {
+	for _, w := range webhooks {
+		if !w.Subscribed(event.Type) {
+			continue
+		}
+		if _, added := postWorkerPool.TrySubmit(w, event, 0); !added {
+			log.Warnf("dropped event '%s' for %s, too many pending requests", event.Type, w.URL())
+		}
+	}
+}
+
+func configureEvents() {
+
+	onMilestoneConfirmed = events.NewClosure(func(confirmation *whiteflag.Confirmation) {
+		for addr, change := range confirmation.Mutations.AddressMutations {
+			if _, watched := watchedAddrs[addr]; !watched {
+				continue
+			}
+			post(webhook.NewEvent(EventAddressActivity, &AddressData{
+				Address:        hornet.Hash(addr).Trytes(),
+				MilestoneIndex: confirmation.MilestoneIndex,
+				Change:         change,
+				Balance:        confirmation.Mutations.NewAddressState[addr],
+			}))
+		}
+
+		// don't flood the webhooks while the node is syncing
+		if !tanglePackage.IsNodeSyncedWithThreshold() {
+			return
+		}
+
+		post(webhook.NewEvent(EventMilestoneConfirmed, &MilestoneData{
+			Index:              confirmation.MilestoneIndex,
+			MilestoneHash:      confirmation.MilestoneHash.Trytes(),
+			BundlesReferenced:  len(confirmation.Mutations.TailsReferenced),
+			BundlesIncluded:    len(confirmation.Mutations.TailsIncluded),
+			BundlesConflicting: len(confirmation.Mutations.TailsExcludedConflicting),
+			BundlesZeroValue:   len(confirmation.Mutations.TailsExcludedZeroValue),
+		}))
+	})
+
+	onMilestoneIndexChanged = events.NewClosure(func(_ milestone.Index) {
+		wasSyncedMu.Lock()
+		synced := tanglePackage.IsNodeSyncedWithThreshold()
+		changed := synced != wasSynced
+		wasSynced = synced
+		wasSyncedMu.Unlock()
+
+		if !changed {
+			return
+		}
+
+		data := &SyncData{
+			LatestMilestoneIndex: tanglePackage.GetLatestMilestoneIndex(),
+			SolidMilestoneIndex:  tanglePackage.GetSolidMilestoneIndex(),
+		}
+		if synced {
+			post(webhook.NewEvent(EventSynced, data))
+			return
+		}
+		post(webhook.NewEvent(EventUnsynced, data))
+	})
+
+	onPeerMovedToReconnectPool = events.NewClosure(onNeighborDropped)
+	onPeerDisconnected = events.NewClosure(onNeighborDropped)
+}
+
+func onNeighborDropped(p *peer.Peer) {
+	// only neighbors which were connected are of interest, not failed connection attempts
+	if p.Protocol == nil || !p.Handshaked() || shutdown.Requested() {
+		return
+	}
+
+	data := &NeighborData{
+		Address:    p.ID,
+		Autopeered: p.Autopeering != nil,
+	}
+	if p.InitAddress != nil {
+		data.Domain = p.InitAddress.Addr
+		data.Alias = p.InitAddress.Alias
+	}
+	post(webhook.NewEvent(EventNeighborDropped, data))
+}
+
+func attachEvents() {
+	wasSyncedMu.Lock()
+	wasSynced = tanglePackage.IsNodeSyncedWithThreshold()
+	wasSyncedMu.Unlock()
+
+	tangle.Events.MilestoneConfirmed.Attach(onMilestoneConfirmed)
+	tangle.Events.LatestMilestoneIndexChanged.Attach(onMilestoneIndexChanged)
+	tangle.Events.SolidMilestoneIndexChanged.Attach(onMilestoneIndexChanged)
+	peering.Manager().Events.PeerMovedFromConnectedToReconnectPool.Attach(onPeerMovedToReconnectPool)
+	peering.Manager().Events.PeerDisconnected.Attach(onPeerDisconnected)
+}
+
+func detachEvents() {
+	tangle.Events.MilestoneConfirmed.Detach(onMilestoneConfirmed)
+	tangle.Events.LatestMilestoneIndexChanged.Detach(onMilestoneIndexChanged)
+	tangle.Events.SolidMilestoneIndexChanged.Detach(onMilestoneIndexChanged)
+	peering.Manager().Events.PeerMovedFromConnectedToReconnectPool.Detach(onPeerMovedToReconnectPool)
+	peering.Manager().Events.PeerDisconnected.Detach(onPeerDisconnected)
+}
@@ -0,0 +1,55 @@
+package peering
+
+import (
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+// runBandwidthAccounting restores the gossip traffic of the current accounting period
+// and stores it in the database in regular intervals, so that the monthly budget survives restarts.
+func runBandwidthAccounting() {
+	periodStart, received, sent, err := tangle.GetBandwidthUsage()
+	if err != nil {
+		log.Warnf("loading the bandwidth usage failed: %s", err)
+	} else if !periodStart.IsZero() {
+		manager.Bandwidth().Restore(periodStart, received, sent)
+	}
+
+	var throttling, exhausted bool
+	storeBandwidthUsage := func() {
+		usage := manager.Bandwidth().Usage()
+		if err := tangle.StoreBandwidthUsage(usage.PeriodStart, usage.Received, usage.Sent); err != nil {
+			log.Warnf("storing the bandwidth usage failed: %s", err)
+		}
+
+		if usage.Budget == 0 {
+			return
+		}
+
+		usedPercentage := usage.Ratio() * 100
+		switch {
+		case usedPercentage >= 100:
+			if !exhausted {
+				log.Warnf("the monthly bandwidth budget is used up until %s, only essential gossip traffic is sent", usage.PeriodEnd.Format("2006-01-02"))
+			}
+			throttling, exhausted = true, true
+
+		case usedPercentage >= float64(manager.Bandwidth().Opts.ThrottleThresholdPercentage):
+			if !throttling {
+				log.Warnf("%0.2f%% of the monthly bandwidth budget are used, replies to stale requests of neighbors are throttled", usedPercentage)
+			}
+			throttling, exhausted = true, false
+
+		default:
+			throttling, exhausted = false, false
+		}
+	}
+
+	daemon.BackgroundWorker("Peering Bandwidth", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(storeBandwidthUsage, bandwidthUsageStoreInterval, shutdownSignal)
+		storeBandwidthUsage()
+	}, shutdown.PriorityBandwidthAccounting)
+}
@@ -4,7 +4,10 @@ import (
 	"strings"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/iotaledger/hive.go/events"
+
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/peering/peer"
 )
 
 func configurePeerConfigWatcher() {
@@ -17,58 +20,80 @@ func runConfigWatcher() {
 			return
 		}
 
-		// whether to accept any incoming peer connection
-		acceptAnyPeer := config.PeeringConfig.GetBool(config.CfgPeeringAcceptAnyConnection)
-		if Manager().Opts.AcceptAnyPeer != acceptAnyPeer {
-			log.Infof("set '%s' to <%v> due to config change", config.CfgPeeringAcceptAnyConnection, acceptAnyPeer)
-			Manager().Opts.AcceptAnyPeer = acceptAnyPeer
+		if err := config.ReloadPeeringConfig(); err != nil {
+			log.Warnf("reloading the peering config failed: %s", err)
+			return
 		}
+		applyPeeringConfig()
+	})
 
-		modified, added, removed := getPeerConfigDiff()
+	// the peering config is also read again if the node config is reloaded
+	config.Events.Reloaded.Attach(events.NewClosure(applyPeeringConfig))
+}
 
-		// remove peers if we do not accept connections from unknown peers
-		if !acceptAnyPeer && len(removed) > 0 {
-			for _, p := range removed {
-				if err := Manager().Remove(p.ID); err != nil {
-					log.Warnf("removing peer due to config change failed with: %v", err)
-					continue
-				}
-				log.Infof("removed peer %s due to config change", p.ID)
+// applyPeeringConfig adds, modifies and removes the neighbors according to the peering config.
+func applyPeeringConfig() {
+	// whether to accept any incoming peer connection
+	acceptAnyPeer := config.ReloadablePeeringConfig().GetBool(config.CfgPeeringAcceptAnyConnection)
+	if Manager().Opts.AcceptAnyPeer != acceptAnyPeer {
+		log.Infof("set '%s' to <%v> due to config change", config.CfgPeeringAcceptAnyConnection, acceptAnyPeer)
+		Manager().Opts.AcceptAnyPeer = acceptAnyPeer
+	}
+
+	modified, added, removed := getPeerConfigDiff()
+
+	// remove peers if we do not accept connections from unknown peers
+	if !acceptAnyPeer && len(removed) > 0 {
+		for _, p := range removed {
+			if err := Manager().Remove(p.ID); err != nil {
+				log.Warnf("removing peer due to config change failed with: %v", err)
+				continue
 			}
+			log.Infof("removed peer %s due to config change", p.ID)
 		}
+	}
 
-		// modify peers
-		if len(modified) > 0 {
-			log.Infof("modifying peers due to config change")
-			for _, p := range modified {
-				// remove the peer
-				if err := Manager().Remove(p.ID); err != nil {
-					log.Warn(err)
-				}
-				// and re-add it with the updated info
-				if err := Manager().Add(p.ID, p.PreferIPv6, p.Alias); err != nil {
-					log.Warn("was unable to re-add modified peer %s", p.ID)
-				}
+	// modify peers
+	if len(modified) > 0 {
+		log.Infof("modifying peers due to config change")
+		for _, p := range modified {
+			publicKey, err := parsePublicKey(p)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+			// remove the peer
+			if err := Manager().Remove(p.ID); err != nil {
+				log.Warn(err)
+			}
+			// and re-add it with the updated info
+			if err := Manager().Add(p.ID, p.PreferIPv6, p.Alias, publicKey, p.Transport); err != nil {
+				log.Warn("was unable to re-add modified peer %s", p.ID)
 			}
 		}
+	}
 
-		// add peers
-		if len(added) > 0 {
-			log.Infof("adding peers due to config change")
-			for _, p := range added {
-				if err := Manager().Add(p.ID, p.PreferIPv6, p.Alias); err != nil {
-					log.Warn("was unable to re-add modified peer %s", p.ID)
-				}
+	// add peers
+	if len(added) > 0 {
+		log.Infof("adding peers due to config change")
+		for _, p := range added {
+			publicKey, err := parsePublicKey(p)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+			if err := Manager().Add(p.ID, p.PreferIPv6, p.Alias, publicKey, p.Transport); err != nil {
+				log.Warn("was unable to re-add modified peer %s", p.ID)
 			}
 		}
-	})
+	}
 }
 
 // calculates the diffs between the loaded peers and the modified config.
 func getPeerConfigDiff() (modified, added, removed []config.PeerConfig) {
 	currentPeers := Manager().PeerInfos()
 	var configPeers []config.PeerConfig
-	if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
+	if err := config.ReloadablePeeringConfig().UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
 		log.Warn(err)
 		return
 	}
@@ -83,7 +108,8 @@ func getPeerConfigDiff() (modified, added, removed []config.PeerConfig) {
 		for _, configPeer := range configPeers {
 			if strings.EqualFold(currentPeer.Address, configPeer.ID) || strings.EqualFold(currentPeer.DomainWithPort, configPeer.ID) {
 				found = true
-				if (currentPeer.PreferIPv6 != configPeer.PreferIPv6) || (currentPeer.Alias != configPeer.Alias) {
+				if (currentPeer.PreferIPv6 != configPeer.PreferIPv6) || (currentPeer.Alias != configPeer.Alias) || !strings.EqualFold(currentPeer.PublicKey, configPeer.PublicKey) ||
+					(currentPeer.ConnectionType == peer.TransportQUIC) != strings.EqualFold(configPeer.Transport, peer.TransportQUIC) {
 					modified = append(modified, configPeer)
 				}
 				break
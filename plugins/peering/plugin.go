@@ -1,6 +1,7 @@
 package peering
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"sync"
@@ -13,10 +14,13 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/timeutil"
 
+	"github.com/gohornet/hornet/pkg/bandwidth"
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ipfilter"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/peering/secure"
 	"github.com/gohornet/hornet/pkg/protocol"
 	"github.com/gohornet/hornet/pkg/protocol/handshake"
 	"github.com/gohornet/hornet/pkg/shutdown"
@@ -24,6 +28,9 @@ import (
 
 const (
 	ExamplePeerURI = "example.neighbor.com:15600"
+
+	// the interval in which the bandwidth usage is stored in the database.
+	bandwidthUsageStoreInterval = time.Minute
 )
 
 var (
@@ -59,6 +66,24 @@ func Manager() *peering.Manager {
 			}
 		}
 
+		ipFilter, err := ipfilter.NewFromConfig(config.CfgNetGossipIPFilterAllowedNetworks, config.CfgNetGossipIPFilterDeniedNetworks, logger.NewLogger("Peering"))
+		if err != nil {
+			log.Fatalf("invalid IP filter of the gossip: %s", err)
+		}
+
+		var identity *secure.Identity
+		if config.NodeConfig.GetBool(config.CfgNetGossipTLSEnabled) {
+			privateKey, err := secure.ParsePrivateKey(config.NodeConfig.GetString(config.CfgNetGossipTLSPrivateKey))
+			if err != nil {
+				log.Fatalf("'%s' is invalid: %s", config.CfgNetGossipTLSPrivateKey, err)
+			}
+
+			if identity, err = secure.NewIdentity(privateKey); err != nil {
+				log.Fatalf("couldn't create the identity to secure the gossip: %s", err)
+			}
+			log.Infof("securing the gossip with peers with a pinned public key, own public key: %s", hex.EncodeToString(identity.PublicKey()))
+		}
+
 		// init peer manager
 		manager = peering.NewManager(peering.Options{
 			BindAddress: config.NodeConfig.GetString(config.CfgNetGossipBindAddress),
@@ -68,6 +93,35 @@ func Manager() *peering.Manager {
 			},
 			MaxConnected:  config.PeeringConfig.GetInt(config.CfgPeeringMaxPeers),
 			AcceptAnyPeer: config.PeeringConfig.GetBool(config.CfgPeeringAcceptAnyConnection),
+			Reputation: peering.ReputationOptions{
+				Enabled:                   config.NodeConfig.GetBool(config.CfgNetGossipReputationEnabled),
+				InvalidTransactionPenalty: config.NodeConfig.GetInt(config.CfgNetGossipReputationInvalidTransactionPenalty),
+				StaleMilestonePenalty:     config.NodeConfig.GetInt(config.CfgNetGossipReputationStaleMilestonePenalty),
+				ProtocolViolationPenalty:  config.NodeConfig.GetInt(config.CfgNetGossipReputationProtocolViolationPenalty),
+				StaleMilestoneThreshold:   config.NodeConfig.GetInt(config.CfgNetGossipReputationStaleMilestoneThreshold),
+				BanThreshold:              config.NodeConfig.GetInt(config.CfgNetGossipReputationBanThreshold),
+				BanDuration:               time.Duration(config.NodeConfig.GetInt(config.CfgNetGossipReputationBanDurationMinutes)) * time.Minute,
+				RecoveryPerMinute:         config.NodeConfig.GetInt(config.CfgNetGossipReputationRecoveryPerMinute),
+			},
+			Limits: peer.LimitOptions{
+				InboundTransactionsPerSecond:  config.NodeConfig.GetFloat64(config.CfgNetGossipLimitsInboundTransactionsPerSecond),
+				InboundBytesPerSecond:         config.NodeConfig.GetInt(config.CfgNetGossipLimitsInboundBytesPerSecond),
+				OutboundTransactionsPerSecond: config.NodeConfig.GetFloat64(config.CfgNetGossipLimitsOutboundTransactionsPerSecond),
+				OutboundBytesPerSecond:        config.NodeConfig.GetInt(config.CfgNetGossipLimitsOutboundBytesPerSecond),
+			},
+			Staleness: peer.StalenessOptions{
+				MaxHeartbeatAge:                time.Duration(config.NodeConfig.GetInt(config.CfgNetGossipStalenessMaxHeartbeatAgeSeconds)) * time.Second,
+				MaxStaleTransactionsPercentage: config.NodeConfig.GetInt(config.CfgNetGossipStalenessMaxStaleTransactionsPercentage),
+				WarnAfterChecks:                config.NodeConfig.GetInt(config.CfgNetGossipStalenessWarnAfterChecks),
+			},
+			Bandwidth: bandwidth.Options{
+				MonthlyBudgetBytes:          uint64(config.NodeConfig.GetInt(config.CfgNetGossipBandwidthMonthlyBudgetMegabytes)) * 1024 * 1024,
+				ResetDay:                    config.NodeConfig.GetInt(config.CfgNetGossipBandwidthResetDay),
+				ThrottleThresholdPercentage: config.NodeConfig.GetInt(config.CfgNetGossipBandwidthThrottleThresholdPercentage),
+			},
+			IPFilter: ipFilter,
+			Identity: identity,
+			QUIC:     config.NodeConfig.GetBool(config.CfgNetGossipQUICEnabled),
 		}, peers...)
 	})
 	return manager
@@ -132,6 +186,22 @@ func configureManagerEventHandlers() {
 		log.Infof("removed already connected peer %s from reconnect pool", p.ID)
 	}))
 
+	manager.Events.PeerPenalized.Attach(events.NewClosure(func(p *peer.Peer, mb peering.Misbehavior, score int) {
+		log.Debugf("penalized %s for %s, reputation score: %d", p.ID, mb, score)
+	}))
+
+	manager.Events.PeerBanned.Attach(events.NewClosure(func(p *peer.Peer) {
+		log.Warnf("banned %s for %v because of its bad reputation", p.ID, manager.Opts.Reputation.BanDuration)
+	}))
+
+	manager.Events.PeerStale.Attach(events.NewClosure(func(p *peer.Peer, checks int, reason string) {
+		log.Warnf("neighbor %s is stale since %d checks: %s", p.ID, checks, reason)
+	}))
+
+	manager.Events.PeerNoLongerStale.Attach(events.NewClosure(func(p *peer.Peer) {
+		log.Infof("neighbor %s is no longer stale", p.ID)
+	}))
+
 	manager.Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Warnf("error %s", err)
 	}))
@@ -153,6 +223,11 @@ func run(_ *node.Plugin) {
 		log.Infof("Peering Server (%s) ... done", peeringBindAddr)
 		<-shutdownSignal
 		log.Info("Stopping Peering Server ...")
+		// the send queues are still consumed until the peers are disconnected
+		drainTimeout := time.Duration(config.NodeConfig.GetInt(config.CfgNodeShutdownDrainTimeoutSeconds)) * time.Second
+		if !manager.FlushSendQueues(drainTimeout) {
+			log.Warnf("The send queues of the peers were not flushed within %v", drainTimeout)
+		}
 		manager.Shutdown()
 		log.Info("Stopping Peering Server ... done")
 	}, shutdown.PriorityPeeringTCPServer)
@@ -178,8 +253,24 @@ func run(_ *node.Plugin) {
 		}
 	}, shutdown.PriorityPeerReconnecter)
 
+	if manager.Opts.Reputation.Enabled {
+		// create a background worker that lets the neighbors regain reputation and lifts expired bans every minute
+		daemon.BackgroundWorker("Peering Reputation", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(manager.RecoverReputations, time.Minute, shutdownSignal)
+		}, shutdown.PriorityPeerReconnecter)
+	}
+
+	if manager.Opts.Staleness.WarnAfterChecks > 0 {
+		// create a background worker that warns about persistently stale neighbors
+		daemon.BackgroundWorker("Peering Staleness", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(manager.CheckStaleness, peer.StalenessCheckInterval, shutdownSignal)
+		}, shutdown.PriorityPeerReconnecter)
+	}
+
+	runBandwidthAccounting()
+
 	if config.NodeConfig.GetInt(config.CfgNetAutopeeringMaxDroppedPacketsPercentage) != 0 {
-		// create a background worker that checks for staled autopeers every minute
+		// create a background worker that checks for staled autopeers every stale check interval
 		daemon.BackgroundWorker("Peering StaleCheck", func(shutdownSignal <-chan struct{}) {
 
 			checkStaledPeers := func() {
@@ -196,7 +287,7 @@ func run(_ *node.Plugin) {
 					// it's better to drop the connection and free the slots for other peers.
 					peerIDsToRemove[p.ID] = struct{}{}
 
-					log.Infof("dropping autopeered neighbor %s / %s because %0.2f%% of the messages in the last minute were dropped", p.Autopeering.Address(), p.Autopeering.ID(), droppedPercentage)
+					log.Infof("dropping autopeered neighbor %s / %s because %0.2f%% of the messages in the last stale check interval were dropped", p.Autopeering.Address(), p.Autopeering.ID(), droppedPercentage)
 					return true
 				})
 
@@ -205,7 +296,8 @@ func run(_ *node.Plugin) {
 				}
 			}
 
-			timeutil.Ticker(checkStaledPeers, 60*time.Second, shutdownSignal)
+			staleCheckInterval := time.Duration(config.NodeConfig.GetInt(config.CfgNetAutopeeringStaleCheckIntervalSeconds)) * time.Second
+			timeutil.Ticker(checkStaledPeers, staleCheckInterval, shutdownSignal)
 		}, shutdown.PriorityPeerReconnecter)
 	}
 }
@@ -0,0 +1,176 @@
+package peering
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/iputils"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/peering/secure"
+)
+
+var (
+	// guards the read-modify-write of the peers in the peering config
+	staticPeersLock sync.Mutex
+)
+
+// StaticPeers returns the static peers of the peering config.
+func StaticPeers() []config.PeerConfig {
+	staticPeersLock.Lock()
+	defer staticPeersLock.Unlock()
+
+	return loadStaticPeers()
+}
+
+// AddStaticPeer adds a static peer to the peering manager and persists it in the peering config,
+// so that it survives a restart of the node. The peer is only persisted if the manager accepted it.
+func AddStaticPeer(id string, alias string, preferIPv6 bool) error {
+	if _, err := iputils.ParseOriginAddress(id); err != nil {
+		return fmt.Errorf("invalid peer address '%s': %w", id, err)
+	}
+
+	staticPeersLock.Lock()
+	defer staticPeersLock.Unlock()
+
+	configPeers := loadStaticPeers()
+
+	var publicKey ed25519.PublicKey
+	var transport string
+	contains := false
+	for _, p := range configPeers {
+		if strings.EqualFold(p.ID, id) {
+			contains = true
+
+			// keep the pinned identity key and the transport of the already configured peer
+			var err error
+			if publicKey, err = parsePublicKey(p); err != nil {
+				return err
+			}
+			transport = p.Transport
+			break
+		}
+	}
+
+	if err := Manager().Add(id, preferIPv6, alias, publicKey, transport); err != nil {
+		return err
+	}
+
+	if !contains {
+		storeStaticPeers(append(configPeers, config.PeerConfig{
+			ID:         id,
+			Alias:      alias,
+			PreferIPv6: preferIPv6,
+		}))
+	}
+	return nil
+}
+
+// RemoveStaticPeer removes a static peer from the peering manager and from the peering config.
+// It returns whether a peer with the given ID was known to the node.
+func RemoveStaticPeer(id string) (bool, error) {
+	if _, err := iputils.ParseOriginAddress(id); err != nil {
+		return false, fmt.Errorf("invalid peer address '%s': %w", id, err)
+	}
+
+	staticPeersLock.Lock()
+	configPeers := loadStaticPeers()
+
+	found := false
+	for i, p := range configPeers {
+		if strings.EqualFold(p.ID, id) {
+			found = true
+
+			// delete item
+			configPeers = append(configPeers[:i], configPeers[i+1:]...)
+			storeStaticPeers(configPeers)
+			break
+		}
+	}
+	staticPeersLock.Unlock()
+
+	for _, p := range Manager().PeerInfos() {
+		if strings.EqualFold(p.Address, id) || strings.EqualFold(p.DomainWithPort, id) || (p.Peer != nil && strings.EqualFold(p.Peer.ID, id)) {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if err := Manager().Remove(id); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// parsePublicKey parses the pinned identity key of the given peer, nil if none is pinned.
+func parsePublicKey(p config.PeerConfig) (ed25519.PublicKey, error) {
+	if p.PublicKey == "" {
+		return nil, nil
+	}
+
+	publicKey, err := secure.ParsePublicKey(p.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of peer '%s': %w", p.ID, err)
+	}
+	return publicKey, nil
+}
+
+// loadStaticPeers reads the static peers from the peering config without the example peer.
+func loadStaticPeers() []config.PeerConfig {
+	var configPeers []config.PeerConfig
+	if err := config.ReloadablePeeringConfig().UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
+		log.Warn(err)
+	}
+
+	peers := make([]config.PeerConfig, 0, len(configPeers))
+	for _, p := range configPeers {
+		if p.ID == ExamplePeerURI {
+			continue
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// storeStaticPeers persists the static peers in the peering config.
+// the hot reload is denied while writing, since the peers were already applied to the manager.
+func storeStaticPeers(peers []config.PeerConfig) {
+	config.DenyPeeringConfigHotReload()
+	defer config.AllowPeeringConfigHotReload()
+
+	settings := make([]map[string]interface{}, 0, len(peers))
+	for _, p := range peers {
+		setting, err := peerSetting(p)
+		if err != nil {
+			log.Warnf("couldn't persist the peering config: %s", err)
+			return
+		}
+		settings = append(settings, setting)
+	}
+
+	if err := config.StorePeers(settings); err != nil {
+		log.Warnf("couldn't persist the peering config: %s", err)
+	}
+}
+
+// peerSetting converts the peer into a map with the keys of the config, given by the struct tags of PeerConfig,
+// since the YAML and TOML encoders don't use the JSON names of the struct fields. Empty optional fields are omitted.
+func peerSetting(p config.PeerConfig) (map[string]interface{}, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := make(map[string]interface{})
+	if err := json.Unmarshal(data, &setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
@@ -0,0 +1,37 @@
+package peering
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+func TestPeerSetting(t *testing.T) {
+	peers := []config.PeerConfig{
+		{ID: "example.com:15600", Alias: "example", PreferIPv6: true, PublicKey: "abcd", Transport: "quic"},
+		{ID: "192.0.2.1:15600"},
+	}
+
+	setting, err := peerSetting(peers[1])
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"identity": "192.0.2.1:15600", "alias": "", "preferIPv6": false}, setting)
+
+	// the stored peers are loaded the same way as the peers of the peering config
+	settings := make([]map[string]interface{}, 0, len(peers))
+	for _, p := range peers {
+		setting, err := peerSetting(p)
+		require.NoError(t, err)
+		settings = append(settings, setting)
+	}
+
+	peeringConfig := viper.New()
+	peeringConfig.Set(config.CfgPeers, settings)
+
+	var loaded []config.PeerConfig
+	require.NoError(t, peeringConfig.UnmarshalKey(config.CfgPeers, &loaded))
+	assert.Equal(t, peers, loaded)
+}
@@ -1,6 +1,7 @@
 package pow
 
 import (
+	"os"
 	"sync"
 	"time"
 
@@ -29,7 +30,21 @@ func Handler() *powpackage.Handler {
 	handlerOnce.Do(func() {
 		// init the pow handler with all possible settings
 		powsrvAPIKey, _ := config.LoadHashFromEnvironment("POWSRV_API_KEY", 12)
-		handler = powpackage.New(log, powsrvAPIKey, powsrvInitCooldown)
+
+		var remotePoW *powpackage.RemotePoW
+		if endpoint := config.NodeConfig.GetString(config.CfgPoWRemoteEndpoint); endpoint != "" {
+			remotePoW = powpackage.NewRemotePoW(endpoint, os.Getenv("POW_REMOTE_API_KEY"), time.Duration(config.NodeConfig.GetInt(config.CfgPoWRemoteTimeoutMilliseconds))*time.Millisecond)
+		}
+
+		handler = powpackage.New(log, powpackage.Options{
+			PowsrvAPIKey:        powsrvAPIKey,
+			PowsrvInitCooldown:  powsrvInitCooldown,
+			RemotePoW:           remotePoW,
+			RemoteLocalFallback: config.NodeConfig.GetBool(config.CfgPoWRemoteLocalFallback),
+			WorkerCount:         config.NodeConfig.GetInt(config.CfgPoWWorkers),
+			Parallelism:         config.NodeConfig.GetInt(config.CfgPoWParallelism),
+			MaxQueueSize:        config.NodeConfig.GetInt(config.CfgPoWMaxQueueSize),
+		})
 
 	})
 	return handler
@@ -40,6 +55,10 @@ func configure(plugin *node.Plugin) {
 
 	// init pow handler
 	Handler()
+
+	if endpoint := config.NodeConfig.GetString(config.CfgPoWRemoteEndpoint); endpoint != "" {
+		log.Infof("Using the remote proof-of-work service at %s", endpoint)
+	}
 }
 
 func run(_ *node.Plugin) {
@@ -0,0 +1,55 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gohornet/hornet/plugins/peering"
+)
+
+var (
+	bandwidthPeriodReceivedBytes prometheus.Gauge
+	bandwidthPeriodSentBytes     prometheus.Gauge
+	bandwidthBudgetBytes         prometheus.Gauge
+	bandwidthBudgetUsedRatio     prometheus.Gauge
+	bandwidthThrottledMessages   prometheus.Gauge
+)
+
+func init() {
+	bandwidthPeriodReceivedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_bandwidth_period_received_bytes",
+		Help: "Number of bytes received from neighbors in the current accounting period.",
+	})
+	bandwidthPeriodSentBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_bandwidth_period_sent_bytes",
+		Help: "Number of bytes sent to neighbors in the current accounting period.",
+	})
+	bandwidthBudgetBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_bandwidth_budget_bytes",
+		Help: "Monthly bandwidth budget (0 = disabled).",
+	})
+	bandwidthBudgetUsedRatio = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_bandwidth_budget_used_ratio",
+		Help: "Ratio of the monthly bandwidth budget used in the current accounting period.",
+	})
+	bandwidthThrottledMessages = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_bandwidth_throttled_messages",
+		Help: "Number of messages which were not sent because of the bandwidth budget.",
+	})
+
+	registry.MustRegister(bandwidthPeriodReceivedBytes)
+	registry.MustRegister(bandwidthPeriodSentBytes)
+	registry.MustRegister(bandwidthBudgetBytes)
+	registry.MustRegister(bandwidthBudgetUsedRatio)
+	registry.MustRegister(bandwidthThrottledMessages)
+
+	addCollect(collectBandwidth)
+}
+
+func collectBandwidth() {
+	usage := peering.Manager().Bandwidth().Usage()
+	bandwidthPeriodReceivedBytes.Set(float64(usage.Received))
+	bandwidthPeriodSentBytes.Set(float64(usage.Sent))
+	bandwidthBudgetBytes.Set(float64(usage.Budget))
+	bandwidthBudgetUsedRatio.Set(usage.Ratio())
+	bandwidthThrottledMessages.Set(float64(usage.Throttled))
+}
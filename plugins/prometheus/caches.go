@@ -0,0 +1,61 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+var (
+	cacheSizes     *prometheus.GaugeVec
+	cacheHits      *prometheus.GaugeVec
+	cacheMisses    *prometheus.GaugeVec
+	cacheEvictions *prometheus.GaugeVec
+)
+
+func init() {
+	cacheSizes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_cache_size",
+			Help: "Number of objects in the cache.",
+		},
+		[]string{"cache"},
+	)
+	cacheHits = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_cache_hits",
+			Help: "Number of lookups answered by the cache.",
+		},
+		[]string{"cache"},
+	)
+	cacheMisses = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_cache_misses",
+			Help: "Number of lookups which had to read from the database.",
+		},
+		[]string{"cache"},
+	)
+	cacheEvictions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_cache_evictions",
+			Help: "Number of objects evicted from the cache (not reported by caches with partitioned keys).",
+		},
+		[]string{"cache"},
+	)
+
+	registry.MustRegister(cacheSizes)
+	registry.MustRegister(cacheHits)
+	registry.MustRegister(cacheMisses)
+	registry.MustRegister(cacheEvictions)
+
+	addCollect(collectCaches)
+}
+
+func collectCaches() {
+	for _, metrics := range tangle.GetCacheMetrics() {
+		cacheSizes.WithLabelValues(metrics.Name).Set(float64(metrics.Size))
+		cacheHits.WithLabelValues(metrics.Name).Set(float64(metrics.Hits()))
+		cacheMisses.WithLabelValues(metrics.Name).Set(float64(metrics.Misses))
+		cacheEvictions.WithLabelValues(metrics.Name).Set(float64(metrics.Evictions))
+	}
+}
@@ -0,0 +1,32 @@
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+var (
+	databaseOperationDuration *prometheus.HistogramVec
+)
+
+func init() {
+	databaseOperationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iota_database_operation_duration_seconds",
+			Help:    "Duration of the database operations.",
+			Buckets: []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+		},
+		[]string{"operation"},
+	)
+
+	registry.MustRegister(databaseOperationDuration)
+}
+
+func configureDatabaseMetrics() {
+	tangle.SetDatabaseLatencyObserver(func(operation string, duration time.Duration) {
+		databaseOperationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	})
+}
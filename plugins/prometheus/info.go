@@ -3,6 +3,7 @@ package prometheus
 import (
 	"strconv"
 
+	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/plugins/cli"
 	"github.com/gohornet/hornet/plugins/gossip"
@@ -12,6 +13,7 @@ import (
 
 var (
 	infoApp                   *prometheus.GaugeVec
+	infoUpdateAvailable       *prometheus.GaugeVec
 	infoMilestone             *prometheus.GaugeVec
 	infoMilestoneIndex        prometheus.Gauge
 	infoSolidMilestone        *prometheus.GaugeVec
@@ -20,6 +22,9 @@ var (
 	infoPruningIndex          prometheus.Gauge
 	infoTips                  prometheus.Gauge
 	infoTransactionsToRequest prometheus.Gauge
+	infoTipsByType            *prometheus.GaugeVec
+	infoRequestQueue          *prometheus.GaugeVec
+	infoGossipWorkUnits       prometheus.Gauge
 )
 
 func init() {
@@ -30,6 +35,13 @@ func init() {
 		},
 		[]string{"name", "version"},
 	)
+	infoUpdateAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_info_update_available",
+			Help: "Whether a newer version of the node software was released.",
+		},
+		[]string{"latest_version"},
+	)
 	infoMilestone = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "iota_info_latest_milestone",
@@ -68,10 +80,29 @@ func init() {
 		Name: "iota_info_transactions_to_request",
 		Help: "Number of transactions to request.",
 	})
+	infoTipsByType = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_info_tips_by_type",
+			Help: "Number of tips by type.",
+		},
+		[]string{"type"},
+	)
+	infoRequestQueue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_info_request_queue_size",
+			Help: "Number of requests in the request queue by state.",
+		},
+		[]string{"state"},
+	)
+	infoGossipWorkUnits = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_info_gossip_work_units",
+		Help: "Number of cached work units of the gossip processor.",
+	})
 
 	infoApp.WithLabelValues(cli.AppName, cli.AppVersion).Set(1)
 
 	registry.MustRegister(infoApp)
+	registry.MustRegister(infoUpdateAvailable)
 	registry.MustRegister(infoMilestone)
 	registry.MustRegister(infoMilestoneIndex)
 	registry.MustRegister(infoSolidMilestone)
@@ -80,11 +111,24 @@ func init() {
 	registry.MustRegister(infoPruningIndex)
 	registry.MustRegister(infoTips)
 	registry.MustRegister(infoTransactionsToRequest)
+	registry.MustRegister(infoTipsByType)
+	registry.MustRegister(infoRequestQueue)
+	registry.MustRegister(infoGossipWorkUnits)
 
 	addCollect(collectInfo)
 }
 
 func collectInfo() {
+	// Update check
+	infoUpdateAvailable.Reset()
+	if latestVersion := cli.LatestVersion(); latestVersion != "" {
+		updateAvailable := 0.0
+		if cli.IsOutdated() {
+			updateAvailable = 1
+		}
+		infoUpdateAvailable.WithLabelValues(latestVersion).Set(updateAvailable)
+	}
+
 	// Latest milestone index
 	lmi := tangle.GetLatestMilestoneIndex()
 	infoMilestoneIndex.Set(float64(lmi))
@@ -125,9 +169,19 @@ func collectInfo() {
 	}
 
 	// Tips
-	infoTips.Set(0)
+	tipsNonLazy := metrics.SharedServerMetrics.TipsNonLazy.Load()
+	tipsSemiLazy := metrics.SharedServerMetrics.TipsSemiLazy.Load()
+	infoTips.Set(float64(tipsNonLazy + tipsSemiLazy))
+	infoTipsByType.WithLabelValues("non_lazy").Set(float64(tipsNonLazy))
+	infoTipsByType.WithLabelValues("semi_lazy").Set(float64(tipsSemiLazy))
 
 	// Transactions to request
-	queued, pending, _ := gossip.RequestQueue().Size()
+	queued, pending, processing := gossip.RequestQueue().Size()
 	infoTransactionsToRequest.Set(float64(queued + pending))
+	infoRequestQueue.WithLabelValues("queued").Set(float64(queued))
+	infoRequestQueue.WithLabelValues("pending").Set(float64(pending))
+	infoRequestQueue.WithLabelValues("processing").Set(float64(processing))
+
+	// Gossip
+	infoGossipWorkUnits.Set(float64(gossip.Processor().WorkUnitsSize()))
 }
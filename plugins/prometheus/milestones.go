@@ -0,0 +1,111 @@
+package prometheus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+const (
+	// the maximum amount of latest milestones which are tracked until they become solid
+	maxTrackedMilestones = 1000
+)
+
+var (
+	milestoneSolidificationDuration prometheus.Histogram
+	milestoneTPS                    prometheus.Gauge
+	milestoneCTPS                   prometheus.Gauge
+	milestoneConfirmationRate       prometheus.Gauge
+	milestoneTimeSinceLast          prometheus.Gauge
+
+	// the time at which the latest milestones were received, used to calculate the solidification duration
+	milestoneReceivedTimes     = make(map[milestone.Index]time.Time)
+	milestoneReceivedTimesLock sync.Mutex
+)
+
+func init() {
+	milestoneSolidificationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "iota_milestone_solidification_duration_seconds",
+		Help:    "Duration between receiving a milestone and it becoming solid.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	})
+	milestoneTPS = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_milestone_tps",
+		Help: "Transactions per second between the last two confirmed milestones.",
+	})
+	milestoneCTPS = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_milestone_ctps",
+		Help: "Confirmed transactions per second between the last two confirmed milestones.",
+	})
+	milestoneConfirmationRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_milestone_confirmation_rate",
+		Help: "Confirmation rate of the last confirmed milestone in percent.",
+	})
+	milestoneTimeSinceLast = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_milestone_time_since_last_seconds",
+		Help: "Time between the last two confirmed milestones.",
+	})
+
+	registry.MustRegister(milestoneSolidificationDuration)
+	registry.MustRegister(milestoneTPS)
+	registry.MustRegister(milestoneCTPS)
+	registry.MustRegister(milestoneConfirmationRate)
+	registry.MustRegister(milestoneTimeSinceLast)
+}
+
+func onLatestMilestoneIndexChanged(msIndex milestone.Index) {
+	milestoneReceivedTimesLock.Lock()
+	defer milestoneReceivedTimesLock.Unlock()
+
+	if len(milestoneReceivedTimes) >= maxTrackedMilestones {
+		// the node is far behind, the solidification duration is meaningless while syncing
+		return
+	}
+	milestoneReceivedTimes[msIndex] = time.Now()
+}
+
+func onSolidMilestoneIndexChanged(msIndex milestone.Index) {
+	milestoneReceivedTimesLock.Lock()
+	defer milestoneReceivedTimesLock.Unlock()
+
+	if receivedTime, exists := milestoneReceivedTimes[msIndex]; exists {
+		milestoneSolidificationDuration.Observe(time.Since(receivedTime).Seconds())
+	}
+
+	for index := range milestoneReceivedTimes {
+		if index <= msIndex {
+			delete(milestoneReceivedTimes, index)
+		}
+	}
+}
+
+func onNewConfirmedMilestoneMetric(metric *tangle.ConfirmedMilestoneMetric) {
+	milestoneTPS.Set(metric.TPS)
+	milestoneCTPS.Set(metric.CTPS)
+	milestoneConfirmationRate.Set(metric.ConfirmationRate)
+	milestoneTimeSinceLast.Set(metric.TimeSinceLastMilestone)
+}
+
+func runMilestoneMetrics() {
+	onLatestMilestoneIndexChangedClosure := events.NewClosure(onLatestMilestoneIndexChanged)
+	onSolidMilestoneIndexChangedClosure := events.NewClosure(onSolidMilestoneIndexChanged)
+	onNewConfirmedMilestoneMetricClosure := events.NewClosure(onNewConfirmedMilestoneMetric)
+
+	control.BackgroundWorker("Prometheus[MilestoneMetrics]", func(shutdownSignal <-chan struct{}) {
+		tangle.Events.LatestMilestoneIndexChanged.Attach(onLatestMilestoneIndexChangedClosure)
+		defer tangle.Events.LatestMilestoneIndexChanged.Detach(onLatestMilestoneIndexChangedClosure)
+		tangle.Events.SolidMilestoneIndexChanged.Attach(onSolidMilestoneIndexChangedClosure)
+		defer tangle.Events.SolidMilestoneIndexChanged.Detach(onSolidMilestoneIndexChangedClosure)
+		tangle.Events.NewConfirmedMilestoneMetric.Attach(onNewConfirmedMilestoneMetricClosure)
+		defer tangle.Events.NewConfirmedMilestoneMetric.Detach(onNewConfirmedMilestoneMetricClosure)
+
+		<-shutdownSignal
+	}, shutdown.PriorityPrometheus)
+}
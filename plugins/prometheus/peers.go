@@ -15,11 +15,14 @@ var (
 	peersReceivedTransactionRequests *prometheus.GaugeVec
 	peersReceivedMilestoneRequests   *prometheus.GaugeVec
 	peersReceivedHeartbeats          *prometheus.GaugeVec
+	peersSentPackets                 *prometheus.GaugeVec
 	peersSentTransactions            *prometheus.GaugeVec
 	peersSentTransactionRequests     *prometheus.GaugeVec
 	peersSentMilestoneRequests       *prometheus.GaugeVec
 	peersSentHeartbeats              *prometheus.GaugeVec
 	peersDroppedSentPackets          *prometheus.GaugeVec
+	peersReceivedBytes               *prometheus.GaugeVec
+	peersSentBytes                   *prometheus.GaugeVec
 	peersConnected                   *prometheus.GaugeVec
 )
 
@@ -73,6 +76,13 @@ func init() {
 		},
 		[]string{"address", "port", "domain", "alias", "type", "autopeering_id"},
 	)
+	peersSentPackets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_peers_sent_packets",
+			Help: "Number of sent packets by peer.",
+		},
+		[]string{"address", "port", "domain", "alias", "type", "autopeering_id"},
+	)
 	peersSentTransactions = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "iota_peers_sent_transactions",
@@ -108,6 +118,20 @@ func init() {
 		},
 		[]string{"address", "port", "domain", "alias", "type", "autopeering_id"},
 	)
+	peersReceivedBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_peers_received_bytes",
+			Help: "Number of bytes received by peer over the current connection.",
+		},
+		[]string{"address", "port", "domain", "alias", "type", "autopeering_id"},
+	)
+	peersSentBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_peers_sent_bytes",
+			Help: "Number of bytes sent by peer over the current connection.",
+		},
+		[]string{"address", "port", "domain", "alias", "type", "autopeering_id"},
+	)
 	peersConnected = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "iota_peers_connected",
@@ -123,11 +147,14 @@ func init() {
 	registry.MustRegister(peersReceivedTransactionRequests)
 	registry.MustRegister(peersReceivedMilestoneRequests)
 	registry.MustRegister(peersReceivedHeartbeats)
+	registry.MustRegister(peersSentPackets)
 	registry.MustRegister(peersSentTransactions)
 	registry.MustRegister(peersSentTransactionRequests)
 	registry.MustRegister(peersSentMilestoneRequests)
 	registry.MustRegister(peersSentHeartbeats)
 	registry.MustRegister(peersDroppedSentPackets)
+	registry.MustRegister(peersReceivedBytes)
+	registry.MustRegister(peersSentBytes)
 	registry.MustRegister(peersConnected)
 
 	addCollect(collectPeers)
@@ -141,11 +168,14 @@ func collectPeers() {
 	peersReceivedTransactionRequests.Reset()
 	peersReceivedMilestoneRequests.Reset()
 	peersReceivedHeartbeats.Reset()
+	peersSentPackets.Reset()
 	peersSentTransactions.Reset()
 	peersSentTransactionRequests.Reset()
 	peersSentMilestoneRequests.Reset()
 	peersSentHeartbeats.Reset()
 	peersDroppedSentPackets.Reset()
+	peersReceivedBytes.Reset()
+	peersSentBytes.Reset()
 	peersConnected.Reset()
 
 	for _, peer := range peering.Manager().PeerInfos() {
@@ -166,11 +196,14 @@ func collectPeers() {
 		peersReceivedTransactionRequests.With(labels).Set(float64(peer.NumberOfReceivedTransactionReq))
 		peersReceivedMilestoneRequests.With(labels).Set(float64(peer.NumberOfReceivedMilestoneReq))
 		peersReceivedHeartbeats.With(labels).Set(float64(peer.NumberOfReceivedHeartbeats))
+		peersSentPackets.With(labels).Set(float64(peer.NumberOfSentPackets))
 		peersSentTransactions.With(labels).Set(float64(peer.NumberOfSentTransactions))
 		peersSentTransactionRequests.With(labels).Set(float64(peer.NumberOfSentTransactionsReq))
 		peersSentMilestoneRequests.With(labels).Set(float64(peer.NumberOfSentMilestoneReq))
 		peersSentHeartbeats.With(labels).Set(float64(peer.NumberOfSentHeartbeats))
 		peersDroppedSentPackets.With(labels).Set(float64(peer.NumberOfDroppedSentPackets))
+		peersReceivedBytes.With(labels).Set(float64(peer.NumberOfReceivedBytes))
+		peersSentBytes.With(labels).Set(float64(peer.NumberOfSentBytes))
 		peersConnected.With(labels).Set(0)
 		if peer.Connected {
 			peersConnected.With(labels).Set(1)
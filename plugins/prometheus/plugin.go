@@ -10,12 +10,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 
-	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/syncutils"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
 	"github.com/gohornet/hornet/pkg/shutdown"
 )
 
@@ -24,11 +26,20 @@ var (
 	PLUGIN = node.NewPlugin("Prometheus", node.Disabled, configure, run)
 	log    *logger.Logger
 
+	// the exporter can be started and stopped at runtime, the metrics are only registered once
+	control *plugincontrol.Plugin
+
 	server   *http.Server
 	registry = prometheus.NewRegistry()
 	collects []func()
+	// scrapes and pushes may run concurrently
+	collectLock syncutils.Mutex
 )
 
+func init() {
+	control = plugincontrol.Register(PLUGIN)
+}
+
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 
@@ -38,12 +49,29 @@ func configure(plugin *node.Plugin) {
 	if config.NodeConfig.GetBool(config.CfgPrometheusProcessMetrics) {
 		registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	}
+
+	configureDatabaseMetrics()
 }
 
 func addCollect(collect func()) {
 	collects = append(collects, collect)
 }
 
+func collect() {
+	collectLock.Lock()
+	defer collectLock.Unlock()
+
+	for _, f := range collects {
+		f()
+	}
+}
+
+// gatherMetrics updates all metrics and gathers them from the registry.
+func gatherMetrics() ([]*dto.MetricFamily, error) {
+	collect()
+	return registry.Gather()
+}
+
 type fileservicediscovery struct {
 	Targets []string          `json:"targets"`
 	Labels  map[string]string `json:"labels"`
@@ -76,15 +104,16 @@ func run(plugin *node.Plugin) {
 		writeFileServiceDiscoveryFile()
 	}
 
-	daemon.BackgroundWorker("Prometheus exporter", func(shutdownSignal <-chan struct{}) {
+	runMilestoneMetrics()
+	runMetricsPush()
+
+	control.BackgroundWorker("Prometheus exporter", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting Prometheus exporter ... done")
 
 		engine := gin.New()
 		engine.Use(gin.Recovery())
 		engine.GET("/metrics", func(c *gin.Context) {
-			for _, collect := range collects {
-				collect()
-			}
+			collect()
 			handler := promhttp.HandlerFor(
 				registry,
 				promhttp.HandlerOpts{
@@ -0,0 +1,48 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gohornet/hornet/plugins/pow"
+)
+
+var (
+	powQueueSize       prometheus.Gauge
+	powRunning         prometheus.Gauge
+	powFinished        prometheus.Gauge
+	powAverageDuration prometheus.Gauge
+)
+
+func init() {
+	powQueueSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_pow_queue_size",
+		Help: "Number of local PoW requests waiting for a free worker.",
+	})
+	powRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_pow_running",
+		Help: "Number of running local PoW requests.",
+	})
+	powFinished = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_pow_finished",
+		Help: "Number of finished PoW requests.",
+	})
+	powAverageDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_pow_average_duration_seconds",
+		Help: "Moving average of the duration of the PoW requests.",
+	})
+
+	registry.MustRegister(powQueueSize)
+	registry.MustRegister(powRunning)
+	registry.MustRegister(powFinished)
+	registry.MustRegister(powAverageDuration)
+
+	addCollect(collectPoW)
+}
+
+func collectPoW() {
+	metrics := pow.Handler().Metrics()
+	powQueueSize.Set(float64(metrics.QueueSize))
+	powRunning.Set(float64(metrics.Running))
+	powFinished.Set(float64(metrics.Finished))
+	powAverageDuration.Set(metrics.AverageDuration.Seconds())
+}
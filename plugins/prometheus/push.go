@@ -0,0 +1,124 @@
+package prometheus
+
+import (
+	"os"
+	"time"
+
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/metricspush"
+	"github.com/gohornet/hornet/pkg/proxy"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+// configurePushers creates a pusher for every enabled push target.
+func configurePushers(timeout time.Duration) []*metricspush.Pusher {
+	client := proxy.HTTPClient(timeout)
+
+	var sinks []metricspush.Sink
+	if config.NodeConfig.GetBool(config.CfgPrometheusPushInfluxDBEnabled) {
+		sink, err := metricspush.NewInfluxDB(
+			config.NodeConfig.GetString(config.CfgPrometheusPushInfluxDBURL),
+			config.NodeConfig.GetString(config.CfgPrometheusPushInfluxDBDatabase),
+			config.NodeConfig.GetString(config.CfgPrometheusPushInfluxDBUsername),
+			config.NodeConfig.GetString(config.CfgPrometheusPushInfluxDBPassword),
+			client,
+		)
+		if err != nil {
+			log.Warnf("InfluxDB push disabled: %s", err)
+		} else {
+			sinks = append(sinks, sink)
+		}
+	}
+	if config.NodeConfig.GetBool(config.CfgPrometheusPushRemoteWriteEnabled) {
+		sink, err := metricspush.NewRemoteWrite(
+			config.NodeConfig.GetString(config.CfgPrometheusPushRemoteWriteURL),
+			config.NodeConfig.GetString(config.CfgPrometheusPushRemoteWriteUsername),
+			config.NodeConfig.GetString(config.CfgPrometheusPushRemoteWritePassword),
+			client,
+		)
+		if err != nil {
+			log.Warnf("Prometheus remote write push disabled: %s", err)
+		} else {
+			sinks = append(sinks, sink)
+		}
+	}
+
+	opts := metricspush.Options{
+		BatchSize:          config.NodeConfig.GetInt(config.CfgPrometheusPushBatchSize),
+		MaxBufferedSamples: config.NodeConfig.GetInt(config.CfgPrometheusPushMaxBufferedSamples),
+		MaxRetries:         config.NodeConfig.GetInt(config.CfgPrometheusPushMaxRetries),
+		RetryBackoff:       time.Second,
+	}
+
+	pushers := make([]*metricspush.Pusher, 0, len(sinks))
+	for _, sink := range sinks {
+		pushers = append(pushers, metricspush.NewPusher(sink, opts))
+	}
+	return pushers
+}
+
+// pushInstance returns the value of the "instance" label of the pushed metrics,
+// which is otherwise added by Prometheus while scraping.
+func pushInstance() string {
+	if instance := config.NodeConfig.GetString(config.CfgPrometheusPushInstance); instance != "" {
+		return instance
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "hornet"
+	}
+	return hostname
+}
+
+// pushMetrics gathers the current metrics and flushes them to all push targets.
+func pushMetrics(pushers []*metricspush.Pusher, instanceLabels map[string]string, abortSignal <-chan struct{}) {
+	families, err := gatherMetrics()
+	if err != nil {
+		log.Warnf("Gathering metrics for push failed: %s", err)
+		return
+	}
+	samples := metricspush.SamplesFromMetricFamilies(families, time.Now(), instanceLabels)
+
+	for _, pusher := range pushers {
+		pusher.Add(samples)
+		if err := pusher.Flush(abortSignal); err != nil {
+			log.Warnf("Pushing metrics failed, %d samples buffered: %s", pusher.Buffered(), err)
+		}
+	}
+}
+
+func runMetricsPush() {
+	timeout := time.Duration(config.NodeConfig.GetInt(config.CfgPrometheusPushTimeoutSeconds)) * time.Second
+	pushers := configurePushers(timeout)
+	if len(pushers) == 0 {
+		return
+	}
+
+	instanceLabels := map[string]string{"instance": pushInstance()}
+	interval := time.Duration(config.NodeConfig.GetInt(config.CfgPrometheusPushIntervalSeconds)) * time.Second
+
+	control.BackgroundWorker("Prometheus metrics push", func(shutdownSignal <-chan struct{}) {
+		for _, pusher := range pushers {
+			log.Infof("Pushing metrics to %s every %v", pusher.Sink().Name(), interval)
+		}
+
+		timeutil.Ticker(func() {
+			pushMetrics(pushers, instanceLabels, shutdownSignal)
+		}, interval, shutdownSignal)
+
+		// try to deliver the buffered samples once more,
+		// but don't delay the shutdown of the node for longer than the push timeout
+		flushSignal := make(chan struct{})
+		flushTimer := time.AfterFunc(timeout, func() { close(flushSignal) })
+		for _, pusher := range pushers {
+			if err := pusher.Flush(flushSignal); err != nil {
+				log.Warnf("Pushing remaining metrics failed: %s", err)
+			}
+		}
+		flushTimer.Stop()
+
+		log.Info("Stopping Prometheus metrics push ... done")
+	}, shutdown.PriorityPrometheus)
+}
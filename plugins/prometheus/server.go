@@ -24,6 +24,10 @@ var (
 	serverSentSpamTransactions        prometheus.Gauge
 	serverValidatedBundles            prometheus.Gauge
 	serverSeenSpentAddresses          prometheus.Gauge
+	serverPrunedMilestones            prometheus.Gauge
+	serverPrunedTransactions          prometheus.Gauge
+	serverReceivedBytes               prometheus.Gauge
+	serverSentBytes                   prometheus.Gauge
 )
 
 func init() {
@@ -99,6 +103,22 @@ func init() {
 		Name: "iota_server_seen_spent_addresses",
 		Help: "Number of seen spent addresses.",
 	})
+	serverPrunedMilestones = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_pruned_milestones",
+		Help: "Number of pruned milestones.",
+	})
+	serverPrunedTransactions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_pruned_transactions",
+		Help: "Number of pruned transactions.",
+	})
+	serverReceivedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_received_bytes",
+		Help: "Number of bytes received from neighbors.",
+	})
+	serverSentBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_sent_bytes",
+		Help: "Number of bytes sent to neighbors.",
+	})
 
 	registry.MustRegister(serverAllTransactions)
 	registry.MustRegister(serverNewTransactions)
@@ -118,6 +138,10 @@ func init() {
 	registry.MustRegister(serverSentSpamTransactions)
 	registry.MustRegister(serverValidatedBundles)
 	registry.MustRegister(serverSeenSpentAddresses)
+	registry.MustRegister(serverPrunedMilestones)
+	registry.MustRegister(serverPrunedTransactions)
+	registry.MustRegister(serverReceivedBytes)
+	registry.MustRegister(serverSentBytes)
 
 	addCollect(collectServer)
 }
@@ -141,4 +165,8 @@ func collectServer() {
 	serverSentSpamTransactions.Set(float64(metrics.SharedServerMetrics.SentSpamTransactions.Load()))
 	serverValidatedBundles.Set(float64(metrics.SharedServerMetrics.ValidatedBundles.Load()))
 	serverSeenSpentAddresses.Set(float64(metrics.SharedServerMetrics.SeenSpentAddresses.Load()))
+	serverPrunedMilestones.Set(float64(metrics.SharedServerMetrics.PrunedMilestones.Load()))
+	serverPrunedTransactions.Set(float64(metrics.SharedServerMetrics.PrunedTransactions.Load()))
+	serverReceivedBytes.Set(float64(metrics.SharedServerMetrics.ReceivedBytes.Load()))
+	serverSentBytes.Set(float64(metrics.SharedServerMetrics.SentBytes.Load()))
 }
@@ -0,0 +1,33 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gohornet/hornet/plugins/webapi"
+)
+
+// rateLimitCollector exports the requests rejected by the rate limits of the HTTP API as counters.
+// The counts are kept by the webapi plugin, so they are read on every scrape instead of being set by collect.
+type rateLimitCollector struct {
+	desc *prometheus.Desc
+}
+
+func init() {
+	registry.MustRegister(&rateLimitCollector{
+		desc: prometheus.NewDesc(
+			"iota_webapi_rate_limited_requests_total",
+			"Number of HTTP API requests rejected by the rate limits, by limit (ip or command) and endpoint.",
+			[]string{"limit", "endpoint"}, nil,
+		),
+	})
+}
+
+func (c *rateLimitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *rateLimitCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, hit := range webapi.RateLimitHits() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(hit.Count), hit.Limit, hit.Endpoint)
+	}
+}
@@ -0,0 +1,489 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	tanglePlugin "github.com/gohornet/hornet/plugins/tangle"
+)
+
+var (
+	SupportedDeltaLocalSnapshotFileVersions = []byte{1}
+
+	ErrDeltaSnapshotNotPossible        = errors.New("delta local snapshot not possible")
+	ErrDeltaSnapshotBaseMismatch       = errors.New("delta local snapshot does not belong to the local snapshot file")
+	ErrUnsupportedDeltaLSFileVersion   = errors.New("unsupported delta local snapshot file version")
+	ErrDeltaSnapshotChecksumMismatch   = errors.New("delta local snapshot file checksum mismatch")
+	ErrDeltaSnapshotChangesExceedLimit = errors.New("ledger changes exceed the delta size threshold")
+)
+
+// localSnapshotFileInfo is the header information of a full local snapshot file.
+type localSnapshotFileInfo struct {
+	msHash             hornet.Hash
+	msIndex            milestone.Index
+	ledgerEntriesCount int32
+}
+
+// readLocalSnapshotFileInfo reads the header of the full local snapshot file at the given path.
+func readLocalSnapshotFileInfo(filePath string) (*localSnapshotFileInfo, error) {
+
+	file, err := os.OpenFile(filePath, os.O_RDONLY, 0666)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var fileVersion byte
+	if err := binary.Read(file, binary.LittleEndian, &fileVersion); err != nil {
+		return nil, err
+	}
+
+	if fileVersion != SupportedLocalSnapshotFileVersions[0] {
+		return nil, errors.Wrapf(ErrUnsupportedLSFileVersion, "local snapshot file version is %d but this HORNET version only supports %v", fileVersion, SupportedLocalSnapshotFileVersions)
+	}
+
+	info := &localSnapshotFileInfo{msHash: make(hornet.Hash, 49)}
+	if err := binary.Read(file, binary.LittleEndian, info.msHash); err != nil {
+		return nil, err
+	}
+
+	var msIndex int32
+	var msTimestamp int64
+	var solidEntryPointsCount, seenMilestonesCount int32
+
+	for _, field := range []interface{}{&msIndex, &msTimestamp, &solidEntryPointsCount, &seenMilestonesCount, &info.ledgerEntriesCount} {
+		if err := binary.Read(file, binary.LittleEndian, field); err != nil {
+			return nil, err
+		}
+	}
+	info.msIndex = milestone.Index(msIndex)
+
+	return info, nil
+}
+
+// deltaSnapshot contains the changes of the ledger state between the milestone of a full local snapshot file (base)
+// and the target milestone, together with the solid entry points and seen milestones of the target milestone.
+type deltaSnapshot struct {
+	baseMsHash       hornet.Hash
+	baseMsIndex      milestone.Index
+	msHash           hornet.Hash
+	msIndex          milestone.Index
+	msTimestamp      int64
+	solidEntryPoints map[string]milestone.Index
+	seenMilestones   map[string]milestone.Index
+	ledgerChanges    map[string]int64
+	spentAddresses   hornet.Hashes
+}
+
+func (ds *deltaSnapshot) WriteToBuffer(buf io.Writer, abortSignal <-chan struct{}) error {
+
+	for _, field := range []interface{}{
+		SupportedDeltaLocalSnapshotFileVersions[0],
+		ds.baseMsHash[:49],
+		ds.baseMsIndex,
+		ds.msHash[:49],
+		ds.msIndex,
+		ds.msTimestamp,
+		int32(len(ds.solidEntryPoints)),
+		int32(len(ds.seenMilestones)),
+		int32(len(ds.ledgerChanges)),
+		int32(len(ds.spentAddresses)),
+	} {
+		if err := binary.Write(buf, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+
+	for _, entries := range []map[string]milestone.Index{ds.solidEntryPoints, ds.seenMilestones} {
+		for hash, val := range entries {
+			select {
+			case <-abortSignal:
+				return ErrSnapshotCreationWasAborted
+			default:
+			}
+
+			if err := binary.Write(buf, binary.LittleEndian, hornet.Hash(hash)[:49]); err != nil {
+				return err
+			}
+
+			if err := binary.Write(buf, binary.LittleEndian, val); err != nil {
+				return err
+			}
+		}
+	}
+
+	for addr, change := range ds.ledgerChanges {
+		select {
+		case <-abortSignal:
+			return ErrSnapshotCreationWasAborted
+		default:
+		}
+
+		if err := binary.Write(buf, binary.LittleEndian, hornet.Hash(addr)[:49]); err != nil {
+			return err
+		}
+
+		if err := binary.Write(buf, binary.LittleEndian, change); err != nil {
+			return err
+		}
+	}
+
+	for _, addr := range ds.spentAddresses {
+		select {
+		case <-abortSignal:
+			return ErrSnapshotCreationWasAborted
+		default:
+		}
+
+		if err := binary.Write(buf, binary.LittleEndian, addr[:49]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readDeltaSnapshot(buf io.Reader) (*deltaSnapshot, error) {
+
+	var fileVersion byte
+	if err := binary.Read(buf, binary.LittleEndian, &fileVersion); err != nil {
+		return nil, err
+	}
+
+	if fileVersion != SupportedDeltaLocalSnapshotFileVersions[0] {
+		return nil, errors.Wrapf(ErrUnsupportedDeltaLSFileVersion, "delta local snapshot file version is %d but this HORNET version only supports %v", fileVersion, SupportedDeltaLocalSnapshotFileVersions)
+	}
+
+	ds := &deltaSnapshot{
+		baseMsHash:       make(hornet.Hash, 49),
+		msHash:           make(hornet.Hash, 49),
+		solidEntryPoints: make(map[string]milestone.Index),
+		seenMilestones:   make(map[string]milestone.Index),
+		ledgerChanges:    make(map[string]int64),
+	}
+
+	var solidEntryPointsCount, seenMilestonesCount, ledgerChangesCount, spentAddrsCount int32
+
+	for _, field := range []interface{}{
+		ds.baseMsHash,
+		&ds.baseMsIndex,
+		ds.msHash,
+		&ds.msIndex,
+		&ds.msTimestamp,
+		&solidEntryPointsCount,
+		&seenMilestonesCount,
+		&ledgerChangesCount,
+		&spentAddrsCount,
+	} {
+		if err := binary.Read(buf, binary.LittleEndian, field); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entries := range []struct {
+		count  int32
+		target map[string]milestone.Index
+	}{
+		{solidEntryPointsCount, ds.solidEntryPoints},
+		{seenMilestonesCount, ds.seenMilestones},
+	} {
+		for i := int32(0); i < entries.count; i++ {
+			var val milestone.Index
+			txHashBuf := make(hornet.Hash, 49)
+
+			if err := binary.Read(buf, binary.LittleEndian, txHashBuf); err != nil {
+				return nil, err
+			}
+
+			if err := binary.Read(buf, binary.LittleEndian, &val); err != nil {
+				return nil, err
+			}
+
+			entries.target[string(txHashBuf)] = val
+		}
+	}
+
+	for i := int32(0); i < ledgerChangesCount; i++ {
+		var change int64
+		addrBuf := make(hornet.Hash, 49)
+
+		if err := binary.Read(buf, binary.LittleEndian, addrBuf); err != nil {
+			return nil, err
+		}
+
+		if err := binary.Read(buf, binary.LittleEndian, &change); err != nil {
+			return nil, err
+		}
+
+		ds.ledgerChanges[string(addrBuf)] = change
+	}
+
+	for i := int32(0); i < spentAddrsCount; i++ {
+		spentAddrBuf := make(hornet.Hash, 49)
+
+		if err := binary.Read(buf, binary.LittleEndian, spentAddrBuf); err != nil {
+			return nil, err
+		}
+
+		ds.spentAddresses = append(ds.spentAddresses, spentAddrBuf)
+	}
+
+	return ds, nil
+}
+
+// createDeltaSnapshotFile writes the delta snapshot followed by its sha256 hash into the given file.
+func createDeltaSnapshotFile(filePath string, ds *deltaSnapshot, abortSignal <-chan struct{}) ([]byte, error) {
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return nil, err
+	}
+
+	exportFile, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return nil, err
+	}
+	defer exportFile.Close()
+
+	fileBufWriter := bufio.NewWriterSize(exportFile, 4096*2)
+	lsHash := sha256.New()
+
+	if err := ds.WriteToBuffer(io.MultiWriter(fileBufWriter, lsHash), abortSignal); err != nil {
+		return nil, err
+	}
+
+	sha256Hash := lsHash.Sum(nil)
+	if err := binary.Write(fileBufWriter, binary.LittleEndian, sha256Hash); err != nil {
+		return nil, err
+	}
+
+	if err := fileBufWriter.Flush(); err != nil {
+		return nil, err
+	}
+
+	return sha256Hash, nil
+}
+
+// loadDeltaSnapshotFile loads the delta local snapshot file at the given path.
+// It returns nil if the file doesn't exist.
+func loadDeltaSnapshotFile(filePath string, baseMsHash hornet.Hash, baseMsIndex milestone.Index) (*deltaSnapshot, error) {
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(content) < sha256.Size {
+		return nil, errors.Wrap(ErrDeltaSnapshotChecksumMismatch, "file too short")
+	}
+
+	data, checksum := content[:len(content)-sha256.Size], content[len(content)-sha256.Size:]
+	if hash := sha256.Sum256(data); !bytes.Equal(hash[:], checksum) {
+		return nil, ErrDeltaSnapshotChecksumMismatch
+	}
+
+	ds, err := readDeltaSnapshot(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if ds.baseMsIndex != baseMsIndex || !bytes.Equal(ds.baseMsHash, baseMsHash[:49]) {
+		return nil, errors.Wrapf(ErrDeltaSnapshotBaseMismatch, "base milestone %d, local snapshot milestone %d", ds.baseMsIndex, baseMsIndex)
+	}
+
+	return ds, nil
+}
+
+// collectDeltaLedgerChanges sums up the ledger diffs of the milestones after the base milestone up to the target milestone.
+// The changes since the base milestone are written into the delta file,
+// the changes since the snapshot index are applied to the snapshot balances in the database.
+func collectDeltaLedgerChanges(baseIndex milestone.Index, snapshotIndex milestone.Index, targetIndex milestone.Index, abortSignal <-chan struct{}) (ledgerChanges map[string]int64, snapshotChanges map[string]int64, err error) {
+
+	ledgerChanges = make(map[string]int64)
+	snapshotChanges = make(map[string]int64)
+
+	for milestoneIndex := baseIndex + 1; milestoneIndex <= targetIndex; milestoneIndex++ {
+		diff, err := tangle.GetLedgerDiffForMilestone(milestoneIndex, abortSignal)
+		if err != nil {
+			if err == tangle.ErrOperationAborted {
+				return nil, nil, err
+			}
+			return nil, nil, errors.Wrap(ErrCritical, err.Error())
+		}
+
+		for address, change := range diff {
+			ledgerChanges[address] += change
+			if milestoneIndex > snapshotIndex {
+				snapshotChanges[address] += change
+			}
+		}
+	}
+
+	return ledgerChanges, snapshotChanges, nil
+}
+
+// deltaSnapshotsEnabled tells whether delta local snapshots are created on top of the local snapshot file.
+func deltaSnapshotsEnabled() bool {
+	return deltaSizeThresholdPercentage > 0 && config.NodeConfig.GetString(config.CfgLocalSnapshotsDeltaPath) != ""
+}
+
+// createDeltaLocalSnapshotWithoutLocking creates a delta local snapshot for the target index on top of the full local snapshot file.
+// It returns ErrDeltaSnapshotNotPossible if a full local snapshot has to be created instead.
+func createDeltaLocalSnapshotWithoutLocking(targetIndex milestone.Index, fullSnapshotPath string, deltaSnapshotPath string, abortSignal <-chan struct{}) error {
+
+	log.Infof("creating delta local snapshot for targetIndex %d", targetIndex)
+
+	ts := time.Now()
+
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo == nil {
+		return errors.Wrap(ErrCritical, "no snapshot info found")
+	}
+
+	if err := checkSnapshotLimits(targetIndex, snapshotInfo, true); err != nil {
+		return err
+	}
+
+	base, err := readLocalSnapshotFileInfo(fullSnapshotPath)
+	if err != nil {
+		return errors.Wrapf(ErrDeltaSnapshotNotPossible, "reading local snapshot file failed: %s", err)
+	}
+
+	if base.msIndex >= targetIndex || base.msIndex > snapshotInfo.SnapshotIndex {
+		return errors.Wrapf(ErrDeltaSnapshotNotPossible, "local snapshot file milestone %d doesn't precede the target %d", base.msIndex, targetIndex)
+	}
+
+	if base.msIndex < snapshotInfo.PruningIndex {
+		return errors.Wrapf(ErrDeltaSnapshotNotPossible, "ledger changes since the local snapshot file milestone %d were pruned", base.msIndex)
+	}
+
+	setIsSnapshotting(true)
+	defer setIsSnapshotting(false)
+
+	cachedTargetMs := tangle.GetMilestoneOrNil(targetIndex) // bundle +1
+	if cachedTargetMs == nil {
+		return errors.Wrapf(ErrCritical, "target milestone (%d) not found", targetIndex)
+	}
+	defer cachedTargetMs.Release(true) // bundle -1
+
+	ledgerChanges, snapshotChanges, err := collectDeltaLedgerChanges(base.msIndex, snapshotInfo.SnapshotIndex, targetIndex, abortSignal)
+	if err != nil {
+		return err
+	}
+
+	var spentAddresses hornet.Hashes
+	spentAddressesEnabled := snapshotInfo.IsSpentAddressesEnabled() && config.NodeConfig.GetBool(config.CfgSpentAddressesEnabled)
+
+	for address, change := range ledgerChanges {
+		if spentAddressesEnabled && tangle.WasAddressSpentFrom(hornet.Hash(address)) {
+			spentAddresses = append(spentAddresses, hornet.Hash(address))
+		}
+
+		if change == 0 {
+			delete(ledgerChanges, address)
+		}
+	}
+
+	if float64(len(ledgerChanges))*100 > deltaSizeThresholdPercentage*float64(base.ledgerEntriesCount) {
+		return errors.Wrapf(ErrDeltaSnapshotNotPossible, "%s: %d changes, %d ledger entries", ErrDeltaSnapshotChangesExceedLimit, len(ledgerChanges), base.ledgerEntriesCount)
+	}
+
+	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal)
+	if err != nil {
+		return err
+	}
+
+	seenMilestones, err := getSeenMilestones(targetIndex, abortSignal)
+	if err != nil {
+		return err
+	}
+
+	cachedTargetMsTail := cachedTargetMs.GetBundle().GetTail() // tx +1
+	defer cachedTargetMsTail.Release(true)                     // tx -1
+
+	ds := &deltaSnapshot{
+		baseMsHash:       base.msHash,
+		baseMsIndex:      base.msIndex,
+		msHash:           cachedTargetMs.GetBundle().GetTailHash(),
+		msIndex:          targetIndex,
+		msTimestamp:      cachedTargetMsTail.GetTransaction().GetTimestamp(),
+		solidEntryPoints: newSolidEntryPoints,
+		seenMilestones:   seenMilestones,
+		ledgerChanges:    ledgerChanges,
+		spentAddresses:   spentAddresses,
+	}
+
+	filePathTmp := deltaSnapshotPath + "_tmp"
+
+	// Remove old temp file
+	os.Remove(filePathTmp)
+
+	hash, err := createDeltaSnapshotFile(filePathTmp, ds, abortSignal)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(filePathTmp, deltaSnapshotPath); err != nil {
+		return err
+	}
+
+	// This has to be done before acquiring the SolidEntryPoints Lock, otherwise there is a race condition with "solidifyMilestone"
+	// In "solidifyMilestone" the LedgerLock is acquired, but by traversing the tangle, the SolidEntryPoint Lock is also acquired.
+	if err := tangle.ApplyLedgerDiffToSnapshotBalances(snapshotChanges, targetIndex); err != nil {
+		return errors.Wrap(ErrCritical, err.Error())
+	}
+
+	snapshotInfo.Hash = cachedTargetMs.GetBundle().GetMilestoneHash()
+	snapshotInfo.SnapshotIndex = targetIndex
+	snapshotInfo.Timestamp = cachedTargetMsTail.GetTransaction().GetTimestamp()
+	tangle.SetSnapshotInfo(snapshotInfo)
+
+	tanglePlugin.Events.SnapshotMilestoneIndexChanged.Trigger(targetIndex)
+
+	log.Infof("created delta local snapshot for target index %d on top of %d with %d ledger changes (sha256: %x), took %v", targetIndex, base.msIndex, len(ledgerChanges), hash, time.Since(ts))
+
+	return nil
+}
+
+// createLocalSnapshotOrDeltaWithoutLocking creates a delta local snapshot if possible, otherwise a full local snapshot.
+func createLocalSnapshotOrDeltaWithoutLocking(targetIndex milestone.Index, abortSignal <-chan struct{}) error {
+
+	localSnapshotPath := config.NodeConfig.GetString(config.CfgLocalSnapshotsPath)
+	deltaSnapshotPath := config.NodeConfig.GetString(config.CfgLocalSnapshotsDeltaPath)
+
+	if deltaSnapshotsEnabled() {
+		err := createDeltaLocalSnapshotWithoutLocking(targetIndex, localSnapshotPath, deltaSnapshotPath, abortSignal)
+		if err == nil || !errors.Is(err, ErrDeltaSnapshotNotPossible) {
+			return err
+		}
+		log.Infof("creating full local snapshot instead: %s", err)
+	}
+
+	if err := createLocalSnapshotWithoutLocking(targetIndex, localSnapshotPath, true, abortSignal); err != nil {
+		return err
+	}
+
+	if deltaSnapshotPath != "" {
+		// the delta of the previous local snapshot file is obsolete
+		if err := os.Remove(deltaSnapshotPath); err != nil && !os.IsNotExist(err) {
+			log.Warnf("removing obsolete delta local snapshot file failed: %s", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/profile"
+)
+
+// the solid entry points can only be loaded once per process
+var loadInitialValuesOnce sync.Once
+
+func testHash(b byte) hornet.Hash {
+	return bytes.Repeat([]byte{b}, 49)
+}
+
+// configureTestTangle sets up in-memory storages with the given balances as the snapshot and ledger state at the given index.
+func configureTestTangle(t *testing.T, balances map[string]uint64, index milestone.Index) {
+	log = zap.NewNop().Sugar()
+
+	store := mapdb.NewMapDB()
+	tangle.ConfigureStorages(
+		store.WithRealm([]byte("tangle")),
+		store.WithRealm([]byte("snapshot")),
+		store.WithRealm([]byte("spent")),
+		profile.Profile2GB.Caches,
+	)
+	loadInitialValuesOnce.Do(tangle.LoadInitialValuesFromDatabase)
+	tangle.OverwriteSolidMilestoneIndex(0)
+	tangle.SetSnapshotMilestone(testHash(9), testHash(byte(index)), index, index, index, 0, false)
+
+	require.NoError(t, tangle.StoreSnapshotBalancesInDatabase(balances, index))
+	require.NoError(t, tangle.StoreLedgerBalancesInDatabase(balances, index))
+}
+
+// writeTestSnapshotFile writes a full local snapshot file of the given balances at the given index.
+func writeTestSnapshotFile(t *testing.T, filePath string, balances map[string]uint64, index milestone.Index) {
+	_, err := createSnapshotFile(filePath, &localSnapshotHeader{
+		msHash:           testHash(byte(index)),
+		msIndex:          index,
+		msTimestamp:      1600000000,
+		solidEntryPoints: map[string]milestone.Index{string(testHash(byte(index))): index},
+		seenMilestones:   map[string]milestone.Index{},
+		balances:         balances,
+	}, nil)
+	require.NoError(t, err)
+}
+
+// applyTestDiff applies a ledger diff of a milestone like the confirmation of the milestone.
+func applyTestDiff(t *testing.T, diff map[string]int64, index milestone.Index) {
+	tangle.WriteLockLedger()
+	defer tangle.WriteUnlockLedger()
+	require.NoError(t, tangle.ApplyLedgerDiffWithoutLocking(diff, index, 0))
+}
+
+func testBalances() map[string]uint64 {
+	return map[string]uint64{
+		string(testHash(1)): consts.TotalSupply - 100,
+		string(testHash(2)): 100,
+	}
+}
+
+func TestDeltaSnapshotCreateAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fullPath := filepath.Join(dir, "export.bin")
+	deltaPath := filepath.Join(dir, "export_delta.bin")
+
+	configureTestTangle(t, testBalances(), 10)
+	writeTestSnapshotFile(t, fullPath, testBalances(), 10)
+
+	applyTestDiff(t, map[string]int64{string(testHash(2)): -100, string(testHash(3)): 100}, 11)
+	applyTestDiff(t, map[string]int64{string(testHash(1)): -50, string(testHash(3)): 50}, 12)
+	applyTestDiff(t, map[string]int64{string(testHash(3)): -150, string(testHash(2)): 150}, 13)
+
+	base, err := readLocalSnapshotFileInfo(fullPath)
+	require.NoError(t, err)
+	assert.Equal(t, &localSnapshotFileInfo{msHash: testHash(10), msIndex: 10, ledgerEntriesCount: 2}, base)
+
+	// the snapshot balances were already moved to milestone 11 by an earlier delta
+	ledgerChanges, snapshotChanges, err := collectDeltaLedgerChanges(base.msIndex, 11, 13, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		string(testHash(1)): -50,
+		string(testHash(2)): 50,
+		string(testHash(3)): 0,
+	}, ledgerChanges)
+	assert.Equal(t, map[string]int64{
+		string(testHash(1)): -50,
+		string(testHash(2)): 150,
+		string(testHash(3)): -100,
+	}, snapshotChanges)
+
+	delete(ledgerChanges, string(testHash(3)))
+	ds := &deltaSnapshot{
+		baseMsHash:       base.msHash,
+		baseMsIndex:      base.msIndex,
+		msHash:           testHash(13),
+		msIndex:          13,
+		msTimestamp:      1600000300,
+		solidEntryPoints: map[string]milestone.Index{string(testHash(13)): 13, string(testHash(20)): 12},
+		seenMilestones:   map[string]milestone.Index{},
+		ledgerChanges:    ledgerChanges,
+		spentAddresses:   hornet.Hashes{testHash(2)},
+	}
+	_, err = createDeltaSnapshotFile(deltaPath, ds, nil)
+	require.NoError(t, err)
+
+	loaded, err := loadDeltaSnapshotFile(deltaPath, base.msHash, base.msIndex)
+	require.NoError(t, err)
+	assert.Equal(t, ds, loaded)
+
+	// a node started from the files has the ledger state of the delta
+	config.NodeConfig.Set(config.CfgLocalSnapshotsDeltaPath, deltaPath)
+	config.NodeConfig.Set(config.CfgCoordinatorAddress, strings.Repeat("9", 81))
+	config.NodeConfig.Set(config.CfgSpentAddressesEnabled, false)
+	defer config.NodeConfig.Set(config.CfgLocalSnapshotsDeltaPath, "")
+
+	configureTestTangle(t, map[string]uint64{}, 0)
+	require.NoError(t, LoadSnapshotFromFile(fullPath))
+
+	assert.Equal(t, milestone.Index(13), tangle.GetSnapshotInfo().SnapshotIndex)
+	assert.Equal(t, testHash(13), tangle.GetSnapshotInfo().Hash)
+	assert.Equal(t, milestone.Index(13), tangle.GetSolidMilestoneIndex())
+	assert.True(t, tangle.SolidEntryPointsContain(testHash(20)))
+	assert.False(t, tangle.SolidEntryPointsContain(testHash(10)))
+
+	ledgerState, ledgerIndex, err := tangle.GetLedgerStateForLSMI(nil)
+	require.NoError(t, err)
+	assert.Equal(t, milestone.Index(13), ledgerIndex)
+	assert.Equal(t, map[string]uint64{
+		string(testHash(1)): consts.TotalSupply - 150,
+		string(testHash(2)): 150,
+	}, ledgerState)
+}
+
+func TestDeltaSnapshotBaseMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fullPath := filepath.Join(dir, "export.bin")
+	deltaPath := filepath.Join(dir, "export_delta.bin")
+
+	// the delta was created on top of an older local snapshot file than the current one
+	_, err = createDeltaSnapshotFile(deltaPath, &deltaSnapshot{
+		baseMsHash:       testHash(5),
+		baseMsIndex:      5,
+		msHash:           testHash(12),
+		msIndex:          12,
+		solidEntryPoints: map[string]milestone.Index{},
+		seenMilestones:   map[string]milestone.Index{},
+		ledgerChanges:    map[string]int64{string(testHash(1)): -100, string(testHash(2)): 100},
+	}, nil)
+	require.NoError(t, err)
+
+	_, err = loadDeltaSnapshotFile(deltaPath, testHash(10), 10)
+	assert.True(t, errors.Is(err, ErrDeltaSnapshotBaseMismatch))
+
+	// same index, but a different milestone
+	_, err = loadDeltaSnapshotFile(deltaPath, testHash(6), 5)
+	assert.True(t, errors.Is(err, ErrDeltaSnapshotBaseMismatch))
+
+	// the mismatching delta is ignored, the node starts from the local snapshot file
+	configureTestTangle(t, testBalances(), 10)
+	writeTestSnapshotFile(t, fullPath, testBalances(), 10)
+
+	config.NodeConfig.Set(config.CfgLocalSnapshotsDeltaPath, deltaPath)
+	config.NodeConfig.Set(config.CfgCoordinatorAddress, strings.Repeat("9", 81))
+	config.NodeConfig.Set(config.CfgSpentAddressesEnabled, false)
+	defer config.NodeConfig.Set(config.CfgLocalSnapshotsDeltaPath, "")
+
+	configureTestTangle(t, map[string]uint64{}, 0)
+	require.NoError(t, LoadSnapshotFromFile(fullPath))
+
+	assert.Equal(t, milestone.Index(10), tangle.GetSnapshotInfo().SnapshotIndex)
+	ledgerState, ledgerIndex, err := tangle.GetLedgerStateForLSMI(nil)
+	require.NoError(t, err)
+	assert.Equal(t, milestone.Index(10), ledgerIndex)
+	assert.Equal(t, testBalances(), ledgerState)
+}
+
+func TestDeltaSnapshotChecksumMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	deltaPath := filepath.Join(dir, "export_delta.bin")
+
+	loaded, err := loadDeltaSnapshotFile(deltaPath, testHash(10), 10)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	_, err = createDeltaSnapshotFile(deltaPath, &deltaSnapshot{
+		baseMsHash:       testHash(10),
+		baseMsIndex:      10,
+		msHash:           testHash(12),
+		msIndex:          12,
+		solidEntryPoints: map[string]milestone.Index{},
+		seenMilestones:   map[string]milestone.Index{},
+		ledgerChanges:    map[string]int64{string(testHash(1)): -100, string(testHash(2)): 100},
+	}, nil)
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(deltaPath)
+	require.NoError(t, err)
+	content[len(content)-sha256.Size-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(deltaPath, content, 0600))
+
+	_, err = loadDeltaSnapshotFile(deltaPath, testHash(10), 10)
+	assert.True(t, errors.Is(err, ErrDeltaSnapshotChecksumMismatch))
+
+	require.NoError(t, ioutil.WriteFile(deltaPath, content[:10], 0600))
+	_, err = loadDeltaSnapshotFile(deltaPath, testHash(10), 10)
+	assert.True(t, errors.Is(err, ErrDeltaSnapshotChecksumMismatch))
+}
@@ -1,8 +1,13 @@
 package snapshot
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
@@ -10,6 +15,9 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/iotaledger/hive.go/daemon"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/proxy"
 )
 
 // WriteCounter counts the number of bytes written to it. It implements to the io.Writer interface
@@ -53,50 +61,26 @@ func (wc *WriteCounter) PrintProgress() {
 
 func downloadSnapshotFile(filepath string, urls []string) error {
 
-	// Try to download a snapshot from one of the provided sources, break if download was successful
+	publicKeys, err := snapshotPublicKeys()
+	if err != nil {
+		return err
+	}
+
+	// Try to download a snapshot from one of the provided sources, break if download and verification were successful
 	downloadOK := false
 	for _, url := range urls {
 		log.Infof("Downloading snapshot from %s", url)
 
-		// Create the file, but give it a tmp file extension, this means we won't overwrite a
-		// file until it's downloaded, but we'll remove the tmp extension once downloaded.
-		out, err := os.Create(filepath + ".tmp")
-		if err != nil {
-			return err
-		}
-
-		// Get the data
-		resp, err := http.Get(url)
-		if err != nil {
+		if err := downloadAndVerifySnapshotFile(filepath+".tmp", url, publicKeys); err != nil {
 			log.Warnf("Downloading snapshot from %s failed with %v", url, err)
-			out.Close()
-			continue
-		}
-		if resp.StatusCode != http.StatusOK {
-			log.Warnf("Downloading snapshot from %s failed. Server returned %d", url, resp.StatusCode)
-			out.Close()
+			os.Remove(filepath + ".tmp")
+			if err == ErrSnapshotDownloadWasAborted {
+				return err
+			}
 			continue
 		}
 
-		defer resp.Body.Close()
-
-		// Create our progress reporter and pass it to be used alongside our writer
-		counter := &WriteCounter{
-			Expected: uint64(resp.ContentLength),
-		}
-		if _, err = io.Copy(out, io.TeeReader(resp.Body, counter)); err != nil {
-			log.Warnf("Downloading snapshot from %s failed with %v", url, err)
-			out.Close()
-			continue
-		}
-
-		// The progress use the same line so print a new line once it's finished downloading
-		fmt.Print("\n")
-
 		downloadOK = true
-
-		// Close the file without defer so it can happen before Rename()
-		out.Close()
 		break
 	}
 
@@ -110,3 +94,141 @@ func downloadSnapshotFile(filepath string, urls []string) error {
 	}
 	return nil
 }
+
+// snapshotPublicKeys parses the public keys which are allowed to sign downloaded snapshot files.
+func snapshotPublicKeys() ([]ed25519.PublicKey, error) {
+	var publicKeys []ed25519.PublicKey
+	for _, key := range config.NodeConfig.GetStringSlice(config.CfgLocalSnapshotsDownloadPublicKeys) {
+		publicKey, err := hex.DecodeString(strings.TrimSpace(key))
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid snapshot public key '%s' under config option '%s'", key, config.CfgLocalSnapshotsDownloadPublicKeys)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// downloadAndVerifySnapshotFile downloads the snapshot file from the given URL into the given path
+// and verifies the SHA256 checksum at the end of the file.
+// If public keys are given, the checksum has to be signed by one of them. The signature is downloaded from the URL with the suffix ".sig".
+func downloadAndVerifySnapshotFile(filepath string, url string, publicKeys []ed25519.PublicKey) error {
+
+	// Create the file, but give it a tmp file extension, this means we won't overwrite a
+	// file until it's downloaded, but we'll remove the tmp extension once downloaded.
+	out, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// Get the data
+	resp, err := proxy.HTTPClient(0).Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %d", resp.StatusCode)
+	}
+
+	// Create our progress reporter and pass it to be used alongside our writer
+	counter := &WriteCounter{
+		Expected: uint64(resp.ContentLength),
+	}
+	_, err = io.Copy(out, io.TeeReader(resp.Body, counter))
+
+	// The progress use the same line so print a new line once it's finished downloading
+	fmt.Print("\n")
+
+	if err != nil {
+		return err
+	}
+
+	checksum, err := verifySnapshotFileChecksum(out)
+	if err != nil {
+		return err
+	}
+	log.Infof("Snapshot file checksum verified (sha256: %x)", checksum)
+
+	if len(publicKeys) == 0 {
+		return nil
+	}
+
+	signature, err := downloadSnapshotSignature(url + ".sig")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSnapshotSignatureInvalid, err)
+	}
+
+	for _, publicKey := range publicKeys {
+		if ed25519.Verify(publicKey, checksum, signature) {
+			log.Infof("Snapshot file signature verified (public key: %x)", []byte(publicKey))
+			return nil
+		}
+	}
+
+	return ErrSnapshotSignatureInvalid
+}
+
+// verifySnapshotFileChecksum checks the SHA256 checksum which is appended to every local snapshot file and returns it.
+func verifySnapshotFileChecksum(file *os.File) ([]byte, error) {
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Size() < sha256.Size {
+		return nil, ErrSnapshotChecksumMismatch
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	lsHash := sha256.New()
+	if _, err := io.CopyN(lsHash, file, info.Size()-sha256.Size); err != nil {
+		return nil, err
+	}
+
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(file, checksum); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(lsHash.Sum(nil), checksum) {
+		return nil, ErrSnapshotChecksumMismatch
+	}
+
+	return checksum, nil
+}
+
+// downloadSnapshotSignature downloads the hex encoded ed25519 signature of a snapshot file.
+func downloadSnapshotSignature(url string) ([]byte, error) {
+
+	resp, err := proxy.HTTPClient(0).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %d", resp.StatusCode)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+
+	return signature, nil
+}
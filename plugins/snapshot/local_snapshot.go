@@ -151,20 +151,23 @@ func getMilestoneApprovees(milestoneIndex milestone.Index, msTailTxHash hornet.H
 	return approvees, nil
 }
 
-func shouldTakeSnapshot(solidMilestoneIndex milestone.Index) bool {
+// currentSnapshotInterval returns the interval of the local snapshots, which depends on whether the node is synced.
+func currentSnapshotInterval() milestone.Index {
+	if tangle.IsNodeSynced() {
+		return snapshotIntervalSynced
+	}
+	return snapshotIntervalUnsynced
+}
+
+// shouldTakeSnapshot returns whether the given interval passed since the last local snapshot
+// and there is enough history to calculate the solid entry points.
+func shouldTakeSnapshot(solidMilestoneIndex milestone.Index, snapshotInterval milestone.Index) bool {
 
 	snapshotInfo := tangle.GetSnapshotInfo()
 	if snapshotInfo == nil {
 		log.Panic("No snapshotInfo found!")
 	}
 
-	var snapshotInterval milestone.Index
-	if tangle.IsNodeSynced() {
-		snapshotInterval = snapshotIntervalSynced
-	} else {
-		snapshotInterval = snapshotIntervalUnsynced
-	}
-
 	if (solidMilestoneIndex < snapshotDepth+snapshotInterval) || (solidMilestoneIndex-snapshotDepth) < snapshotInfo.PruningIndex+1+SolidEntryPointCheckThresholdPast {
 		// Not enough history to calculate solid entry points
 		return false
@@ -635,10 +638,26 @@ func LoadSnapshotFromFile(filePath string) error {
 		return err
 	}
 
+	// the delta local snapshot file contains the changes since the milestone of the local snapshot file
+	snapshotMsHash, snapshotMsIndex, snapshotMsTimestamp := msHash, milestone.Index(msIndex), msTimestamp
+
+	var delta *deltaSnapshot
+	if deltaPath := config.NodeConfig.GetString(config.CfgLocalSnapshotsDeltaPath); deltaPath != "" {
+		if delta, err = loadDeltaSnapshotFile(deltaPath, msHash, milestone.Index(msIndex)); err != nil {
+			log.Warnf("ignoring delta local snapshot file: %s", err)
+			delta = nil
+		}
+
+		if delta != nil {
+			log.Infof("applying delta local snapshot file on top of milestone %d up to milestone %d", msIndex, delta.msIndex)
+			snapshotMsHash, snapshotMsIndex, snapshotMsTimestamp = delta.msHash, delta.msIndex, delta.msTimestamp
+		}
+	}
+
 	coordinatorAddress := hornet.HashFromAddressTrytes(config.NodeConfig.GetString(config.CfgCoordinatorAddress))
-	tangle.SetSnapshotMilestone(coordinatorAddress, msHash, milestone.Index(msIndex), milestone.Index(msIndex), milestone.Index(msIndex), msTimestamp, spentAddrsCount != 0 && config.NodeConfig.GetBool("spentAddresses.enabled"))
-	tangle.SolidEntryPointsAdd(msHash, milestone.Index(msIndex))
-	tangle.SetLatestSeenMilestoneIndexFromSnapshot(milestone.Index(msIndex))
+	tangle.SetSnapshotMilestone(coordinatorAddress, snapshotMsHash, snapshotMsIndex, snapshotMsIndex, snapshotMsIndex, snapshotMsTimestamp, spentAddrsCount != 0 && config.NodeConfig.GetBool("spentAddresses.enabled"))
+	tangle.SolidEntryPointsAdd(snapshotMsHash, snapshotMsIndex)
+	tangle.SetLatestSeenMilestoneIndexFromSnapshot(snapshotMsIndex)
 
 	log.Info("importing solid entry points")
 
@@ -658,9 +677,20 @@ func LoadSnapshotFromFile(filePath string) error {
 			return errors.Wrapf(ErrSnapshotImportFailed, "solidEntryPoints: %v", err)
 		}
 
+		if delta != nil {
+			// the solid entry points of the delta supersede the ones of the local snapshot file
+			continue
+		}
+
 		tangle.SolidEntryPointsAdd(txHashBuf, milestone.Index(val))
 	}
 
+	if delta != nil {
+		for txHash, val := range delta.solidEntryPoints {
+			tangle.SolidEntryPointsAdd(hornet.Hash(txHash), val)
+		}
+	}
+
 	tangle.StoreSolidEntryPoints()
 	tangle.WriteUnlockSolidEntryPoints()
 
@@ -682,11 +712,24 @@ func LoadSnapshotFromFile(filePath string) error {
 			return errors.Wrapf(ErrSnapshotImportFailed, "seenMilestones: %v", err)
 		}
 
+		if delta != nil {
+			// the seen milestones of the delta supersede the ones of the local snapshot file
+			continue
+		}
+
 		tangle.SetLatestSeenMilestoneIndexFromSnapshot(milestone.Index(val))
 		// request the milestone and prevent the request from being discarded from the request queue
 		gossip.Request(txHashBuf, milestone.Index(val), true)
 	}
 
+	if delta != nil {
+		for txHash, val := range delta.seenMilestones {
+			tangle.SetLatestSeenMilestoneIndexFromSnapshot(val)
+			// request the milestone and prevent the request from being discarded from the request queue
+			gossip.Request(hornet.Hash(txHash), val, true)
+		}
+	}
+
 	log.Info("importing ledger state")
 
 	ledgerState := make(map[string]uint64)
@@ -709,6 +752,20 @@ func LoadSnapshotFromFile(filePath string) error {
 		ledgerState[string(addrBuf)] = val
 	}
 
+	if delta != nil {
+		for address, change := range delta.ledgerChanges {
+			newBalance := int64(ledgerState[address]) + change
+
+			if newBalance < 0 {
+				return errors.Wrapf(ErrSnapshotImportFailed, "delta ledger changes create negative balance for address %s: current %d, change %d", hornet.Hash(address).Trytes(), ledgerState[address], change)
+			} else if newBalance == 0 {
+				delete(ledgerState, address)
+			} else {
+				ledgerState[address] = uint64(newBalance)
+			}
+		}
+	}
+
 	var total uint64
 	for _, value := range ledgerState {
 		total += value
@@ -718,12 +775,12 @@ func LoadSnapshotFromFile(filePath string) error {
 		return errors.Wrapf(ErrInvalidBalance, "%d != %d", total, consts.TotalSupply)
 	}
 
-	err = tangle.StoreSnapshotBalancesInDatabase(ledgerState, milestone.Index(msIndex))
+	err = tangle.StoreSnapshotBalancesInDatabase(ledgerState, snapshotMsIndex)
 	if err != nil {
 		return errors.Wrapf(ErrSnapshotImportFailed, "snapshot ledgerEntries: %s", err)
 	}
 
-	err = tangle.StoreLedgerBalancesInDatabase(ledgerState, milestone.Index(msIndex))
+	err = tangle.StoreLedgerBalancesInDatabase(ledgerState, snapshotMsIndex)
 	if err != nil {
 		return errors.Wrapf(ErrSnapshotImportFailed, "ledgerEntries: %v", err)
 	}
@@ -757,14 +814,21 @@ func LoadSnapshotFromFile(filePath string) error {
 
 			log.Infof("processed %d/%d spent addresses", batchEnd, spentAddrsCount)
 		}
+
+		if delta != nil {
+			for _, spentAddr := range delta.spentAddresses {
+				tangle.MarkAddressAsSpentWithoutLocking(spentAddr)
+			}
+			log.Infof("processed %d spent addresses of the delta", len(delta.spentAddresses))
+		}
 	}
 
 	// set the solid milestone index based on the snapshot milestone
-	tangle.SetSolidMilestoneIndex(milestone.Index(msIndex), false)
+	tangle.SetSolidMilestoneIndex(snapshotMsIndex, false)
 
 	log.Info("finished loading snapshot")
 
-	tanglePlugin.Events.SnapshotMilestoneIndexChanged.Trigger(milestone.Index(msIndex))
+	tanglePlugin.Events.SnapshotMilestoneIndexChanged.Trigger(snapshotMsIndex)
 
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -36,6 +37,8 @@ var (
 	ErrNoSnapshotDownloadURL           = fmt.Errorf("no download URL given for local snapshot under config option '%s", config.CfgLocalSnapshotsDownloadURLs)
 	ErrSnapshotDownloadWasAborted      = errors.New("snapshot download was aborted")
 	ErrSnapshotDownloadNoValidSource   = errors.New("no valid source found, snapshot download not possible")
+	ErrSnapshotChecksumMismatch        = errors.New("snapshot file checksum mismatch")
+	ErrSnapshotSignatureInvalid        = errors.New("snapshot file signature invalid")
 	ErrSnapshotImportWasAborted        = errors.New("snapshot import was aborted")
 	ErrSnapshotImportFailed            = errors.New("snapshot import failed")
 	ErrSnapshotCreationWasAborted      = errors.New("operation was aborted")
@@ -56,8 +59,13 @@ var (
 	snapshotIntervalSynced   milestone.Index
 	snapshotIntervalUnsynced milestone.Index
 
-	pruningEnabled bool
-	pruningDelay   milestone.Index
+	deltaSizeThresholdPercentage float64
+
+	pruningEnabled            bool
+	pruningDelay              milestone.Index
+	pruningMaxAge             time.Duration
+	pruningTargetDatabaseSize int64
+	pruningMinDelay           milestone.Index
 
 	statusLock     syncutils.RWMutex
 	isSnapshotting bool
@@ -74,14 +82,24 @@ func configure(plugin *node.Plugin) {
 	}
 	snapshotIntervalSynced = milestone.Index(config.NodeConfig.GetInt(config.CfgLocalSnapshotsIntervalSynced))
 	snapshotIntervalUnsynced = milestone.Index(config.NodeConfig.GetInt(config.CfgLocalSnapshotsIntervalUnsynced))
+	deltaSizeThresholdPercentage = config.NodeConfig.GetFloat64(config.CfgLocalSnapshotsDeltaSizeThresholdPercentage)
 
 	pruningEnabled = config.NodeConfig.GetBool(config.CfgPruningEnabled)
 	pruningDelay = milestone.Index(config.NodeConfig.GetInt(config.CfgPruningDelay))
+	pruningMaxAge = time.Duration(config.NodeConfig.GetInt(config.CfgPruningMaxAgeHours)) * time.Hour
+	pruningTargetDatabaseSize = int64(config.NodeConfig.GetInt(config.CfgPruningTargetDatabaseSizeMB)) << 20
+	pruningMinDelay = milestone.Index(config.NodeConfig.GetInt(config.CfgPruningMinDelay))
 	pruningDelayMin := snapshotDepth + SolidEntryPointCheckThresholdPast + AdditionalPruningThreshold + 1
-	if pruningDelay < pruningDelayMin {
+	if pruningDelay != 0 && pruningDelay < pruningDelayMin {
 		log.Warnf("Parameter '%s' is too small (%d). Value was changed to %d", config.CfgPruningDelay, pruningDelay, pruningDelayMin)
 		pruningDelay = pruningDelayMin
 	}
+	if pruningMinDelay < pruningDelayMin {
+		log.Warnf("Parameter '%s' is too small (%d). Value was changed to %d", config.CfgPruningMinDelay, pruningMinDelay, pruningDelayMin)
+		pruningMinDelay = pruningDelayMin
+	}
+
+	configureSchedules()
 
 	gossip.AddRequestBackpressureSignal(isSnapshottingOrPruning)
 
@@ -156,6 +174,20 @@ func configure(plugin *node.Plugin) {
 	}
 }
 
+// IsSnapshotting tells whether a local snapshot is currently created.
+func IsSnapshotting() bool {
+	statusLock.RLock()
+	defer statusLock.RUnlock()
+	return isSnapshotting
+}
+
+// IsPruning tells whether the database is currently pruned.
+func IsPruning() bool {
+	statusLock.RLock()
+	defer statusLock.RUnlock()
+	return isPruning
+}
+
 func isSnapshottingOrPruning() bool {
 	statusLock.RLock()
 	defer statusLock.RUnlock()
@@ -188,33 +220,60 @@ func run(_ *node.Plugin) {
 			case solidMilestoneIndex := <-newSolidMilestoneSignal:
 				localSnapshotLock.Lock()
 
-				if shouldTakeSnapshot(solidMilestoneIndex) {
-					localSnapshotPath := config.NodeConfig.GetString(config.CfgLocalSnapshotsPath)
-					if err := createLocalSnapshotWithoutLocking(solidMilestoneIndex-snapshotDepth, localSnapshotPath, true, shutdownSignal); err != nil {
-						if errors.Is(err, ErrCritical) {
-							log.Panic(errors.Wrap(ErrSnapshotCreationFailed, err.Error()))
-						}
-						log.Warn(errors.Wrap(ErrSnapshotCreationFailed, err.Error()))
-					}
+				// the milestone based triggers are replaced by the schedules
+				if snapshotSchedule.IsEmpty() && shouldTakeSnapshot(solidMilestoneIndex, currentSnapshotInterval()) {
+					createLocalSnapshotOrDeltaForSolidMilestone(solidMilestoneIndex, shutdownSignal)
 				}
 
-				if pruningEnabled {
-					if solidMilestoneIndex <= pruningDelay {
-						// Not enough history
-						localSnapshotLock.Unlock()
-						return
+				if pruningEnabled && pruningSchedule.IsEmpty() {
+					if targetIndex := getPruningTargetIndex(solidMilestoneIndex); targetIndex != 0 {
+						pruneDatabase(targetIndex, shutdownSignal)
 					}
+				}
+
+				localSnapshotLock.Unlock()
+
+			case <-scheduledSnapshotSignal:
+				localSnapshotLock.Lock()
+
+				// a scheduled snapshot is taken as soon as there is any new history below the snapshot depth
+				if solidMilestoneIndex := tangle.GetSolidMilestoneIndex(); shouldTakeSnapshot(solidMilestoneIndex, 1) {
+					log.Info("Creating scheduled local snapshot ...")
+					createLocalSnapshotOrDeltaForSolidMilestone(solidMilestoneIndex, shutdownSignal)
+				} else {
+					log.Info("Skipping scheduled local snapshot, there is not enough new history")
+				}
 
-					pruneDatabase(solidMilestoneIndex-pruningDelay, shutdownSignal)
+				localSnapshotLock.Unlock()
+
+			case <-scheduledPruningSignal:
+				localSnapshotLock.Lock()
+
+				if targetIndex := getPruningTargetIndex(tangle.GetSolidMilestoneIndex()); targetIndex != 0 {
+					log.Info("Starting scheduled pruning ...")
+					pruneDatabase(targetIndex, shutdownSignal)
 				}
 
 				localSnapshotLock.Unlock()
 			}
 		}
 	}, shutdown.PriorityLocalSnapshots)
+
+	runSchedules()
 }
 
-func PruneDatabaseByDepth(depth milestone.Index) error {
+// createLocalSnapshotOrDeltaForSolidMilestone creates a local snapshot at the snapshot depth below the given solid milestone.
+// The caller has to hold the localSnapshotLock.
+func createLocalSnapshotOrDeltaForSolidMilestone(solidMilestoneIndex milestone.Index, abortSignal <-chan struct{}) {
+	if err := createLocalSnapshotOrDeltaWithoutLocking(solidMilestoneIndex-snapshotDepth, abortSignal); err != nil {
+		if errors.Is(err, ErrCritical) {
+			log.Panic(errors.Wrap(ErrSnapshotCreationFailed, err.Error()))
+		}
+		log.Warn(errors.Wrap(ErrSnapshotCreationFailed, err.Error()))
+	}
+}
+
+func PruneDatabaseByDepth(depth milestone.Index) (*PruningResult, error) {
 	localSnapshotLock.Lock()
 	defer localSnapshotLock.Unlock()
 
@@ -222,15 +281,33 @@ func PruneDatabaseByDepth(depth milestone.Index) error {
 
 	if solidMilestoneIndex <= depth {
 		// Not enough history
-		return ErrNotEnoughHistory
+		return nil, ErrNotEnoughHistory
 	}
 
 	return pruneDatabase(solidMilestoneIndex-depth, nil)
 }
 
-func PruneDatabaseByTargetIndex(targetIndex milestone.Index) error {
+func PruneDatabaseByTargetIndex(targetIndex milestone.Index) (*PruningResult, error) {
+	localSnapshotLock.Lock()
+	defer localSnapshotLock.Unlock()
+
+	return pruneDatabase(targetIndex, nil)
+}
+
+// PruneDatabaseByAge prunes all milestones which are older than the given age.
+func PruneDatabaseByAge(maxAge time.Duration) (*PruningResult, error) {
 	localSnapshotLock.Lock()
 	defer localSnapshotLock.Unlock()
 
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo == nil {
+		return nil, ErrNotEnoughHistory
+	}
+
+	targetIndex := getPruningTargetIndexByAge(maxAge, snapshotInfo.PruningIndex, tangle.GetSolidMilestoneIndex())
+	if targetIndex == 0 {
+		return nil, ErrNoPruningNeeded
+	}
+
 	return pruneDatabase(targetIndex, nil)
 }
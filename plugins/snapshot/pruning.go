@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
@@ -128,7 +129,98 @@ func setIsPruning(value bool) {
 	statusLock.Unlock()
 }
 
-func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) error {
+// PruningResult contains the amount of data that was removed by a pruning run.
+type PruningResult struct {
+	// The pruning index after the run.
+	PruningIndex milestone.Index `json:"pruningIndex"`
+	// The amount of pruned milestones.
+	Milestones int `json:"milestones"`
+	// The amount of pruned transactions.
+	Transactions int `json:"transactions"`
+}
+
+// getPruningTargetIndexByAge returns the index of the newest milestone which is older than the given age.
+func getPruningTargetIndexByAge(maxAge time.Duration, pruningIndex milestone.Index, solidMilestoneIndex milestone.Index) milestone.Index {
+
+	cutoff := time.Now().Add(-maxAge).Unix()
+
+	targetIndex := milestone.Index(0)
+	for msIndex := pruningIndex + 1; msIndex <= solidMilestoneIndex; msIndex++ {
+		cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+		if cachedMs == nil {
+			continue
+		}
+
+		cachedMsTail := cachedMs.GetBundle().GetTail() // tx +1
+		timestamp := cachedMsTail.GetTransaction().GetTimestamp()
+		cachedMsTail.Release(true) // tx -1
+		cachedMs.Release(true)     // bundle -1
+
+		if timestamp >= cutoff {
+			break
+		}
+		targetIndex = msIndex
+	}
+
+	return targetIndex
+}
+
+// getPruningTargetIndex returns the target index for the automatic pruning, based on the configured retention.
+// If several retention rules are configured, the one that prunes the most data is used.
+func getPruningTargetIndex(solidMilestoneIndex milestone.Index) milestone.Index {
+
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo == nil {
+		return 0
+	}
+
+	targetIndex := milestone.Index(0)
+
+	if pruningDelay != 0 && solidMilestoneIndex > pruningDelay {
+		targetIndex = solidMilestoneIndex - pruningDelay
+	}
+
+	// the retention by age and size never prunes the newest milestones
+	maxTargetIndex := milestone.Index(0)
+	if solidMilestoneIndex > pruningMinDelay {
+		maxTargetIndex = solidMilestoneIndex - pruningMinDelay
+	}
+
+	if pruningMaxAge != 0 {
+		ageTargetIndex := getPruningTargetIndexByAge(pruningMaxAge, snapshotInfo.PruningIndex, solidMilestoneIndex)
+		if ageTargetIndex > maxTargetIndex {
+			ageTargetIndex = maxTargetIndex
+		}
+		if ageTargetIndex > targetIndex {
+			targetIndex = ageTargetIndex
+		}
+	}
+
+	if pruningTargetDatabaseSize != 0 {
+		if sizeTargetIndex := getPruningTargetIndexBySize(tangle.GetDatabaseUsedSize(), snapshotInfo.PruningIndex, maxTargetIndex); sizeTargetIndex > targetIndex {
+			targetIndex = sizeTargetIndex
+		}
+	}
+
+	return targetIndex
+}
+
+// getPruningTargetIndexBySize returns the target index for the retention by size, 0 if the database is small enough.
+// The size of the data only decreases after the garbage collection, so the history is pruned step by step,
+// but never beyond the given maximum target index.
+func getPruningTargetIndexBySize(databaseSize int64, pruningIndex milestone.Index, maxTargetIndex milestone.Index) milestone.Index {
+	if databaseSize <= pruningTargetDatabaseSize {
+		return 0
+	}
+
+	targetIndex := pruningIndex + AdditionalPruningThreshold + 1
+	if targetIndex > maxTargetIndex {
+		targetIndex = maxTargetIndex
+	}
+	return targetIndex
+}
+
+func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) (*PruningResult, error) {
 
 	snapshotInfo := tangle.GetSnapshotInfo()
 	if snapshotInfo == nil {
@@ -137,7 +229,7 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 
 	if snapshotInfo.SnapshotIndex < SolidEntryPointCheckThresholdPast+AdditionalPruningThreshold+1 {
 		// Not enough history
-		return errors.Wrapf(ErrNotEnoughHistory, "minimum index: %d", SolidEntryPointCheckThresholdPast+AdditionalPruningThreshold+1)
+		return nil, errors.Wrapf(ErrNotEnoughHistory, "minimum index: %d", SolidEntryPointCheckThresholdPast+AdditionalPruningThreshold+1)
 	}
 
 	targetIndexMax := snapshotInfo.SnapshotIndex - SolidEntryPointCheckThresholdPast - AdditionalPruningThreshold - 1
@@ -147,12 +239,12 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 
 	if snapshotInfo.PruningIndex >= targetIndex {
 		// no pruning needed
-		return ErrNoPruningNeeded
+		return nil, ErrNoPruningNeeded
 	}
 
 	if snapshotInfo.EntryPointIndex+AdditionalPruningThreshold+1 > targetIndex {
 		// we prune in "AdditionalPruningThreshold" steps to recalculate the solidEntryPoints
-		return errors.Wrapf(ErrNotEnoughHistory, "minimum index: %d", snapshotInfo.EntryPointIndex+AdditionalPruningThreshold+1)
+		return nil, errors.Wrapf(ErrNotEnoughHistory, "minimum index: %d", snapshotInfo.EntryPointIndex+AdditionalPruningThreshold+1)
 	}
 
 	setIsPruning(true)
@@ -161,7 +253,7 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 	// calculate solid entry points for the new end of the tangle history
 	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	tangle.WriteLockSolidEntryPoints()
@@ -180,12 +272,14 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 	// unconfirmed txs have to be pruned for PruningIndex as well, since this could be LSI at startup of the node
 	pruneUnconfirmedTransactions(snapshotInfo.PruningIndex)
 
+	result := &PruningResult{PruningIndex: snapshotInfo.PruningIndex}
+
 	// Iterate through all milestones that have to be pruned
 	for milestoneIndex := snapshotInfo.PruningIndex + 1; milestoneIndex <= targetIndex; milestoneIndex++ {
 		select {
 		case <-abortSignal:
 			// Stop pruning the next milestone
-			return ErrPruningAborted
+			return result, ErrPruningAborted
 		default:
 		}
 
@@ -246,6 +340,12 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 		snapshotInfo.PruningIndex = milestoneIndex
 		tangle.SetSnapshotInfo(snapshotInfo)
 
+		result.PruningIndex = milestoneIndex
+		result.Milestones++
+		result.Transactions += txCount
+		metrics.SharedServerMetrics.PrunedMilestones.Inc()
+		metrics.SharedServerMetrics.PrunedTransactions.Add(uint32(txCount))
+
 		log.Infof("Pruning milestone (%d) took %v. Pruned %d/%d transactions. ", milestoneIndex, time.Since(ts), txCount, len(txsToCheckMap))
 
 		tanglePlugin.Events.PruningMilestoneIndexChanged.Trigger(milestoneIndex)
@@ -253,5 +353,5 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 
 	database.RunGarbageCollection()
 
-	return nil
+	return result, nil
 }
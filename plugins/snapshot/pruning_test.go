@@ -0,0 +1,40 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+func TestGetPruningTargetIndexBySize(t *testing.T) {
+	defer func(size int64) { pruningTargetDatabaseSize = size }(pruningTargetDatabaseSize)
+	pruningTargetDatabaseSize = 100 << 20
+
+	assert.Equal(t, milestone.Index(0), getPruningTargetIndexBySize(100<<20, 500, 9000))
+	assert.Equal(t, milestone.Index(551), getPruningTargetIndexBySize(101<<20, 500, 9000))
+	assert.Equal(t, milestone.Index(9000), getPruningTargetIndexBySize(101<<20, 8990, 9000))
+}
+
+func TestPruningBySizeStopsAtMinDelay(t *testing.T) {
+	defer func(size int64) { pruningTargetDatabaseSize = size }(pruningTargetDatabaseSize)
+	pruningTargetDatabaseSize = 100 << 20
+
+	// the size of the database stays the same after every pruning run, e.g. because the file of the database never shrinks
+	const databaseSize = 200 << 20
+	const solidMilestoneIndex = 10000
+	const minDelay = 1000
+
+	pruningIndex := milestone.Index(0)
+	for run := 0; run < 1000; run++ {
+		targetIndex := getPruningTargetIndexBySize(databaseSize, pruningIndex, solidMilestoneIndex-minDelay)
+		if targetIndex <= pruningIndex {
+			break
+		}
+		pruningIndex = targetIndex
+	}
+
+	// the newest milestones are kept although the target size was never reached
+	assert.Equal(t, milestone.Index(solidMilestoneIndex-minDelay), pruningIndex)
+}
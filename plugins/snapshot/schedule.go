@@ -0,0 +1,74 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/schedule"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+var (
+	// the schedules of the local snapshots and the pruning, an empty schedule uses the milestone based triggers
+	snapshotSchedule *schedule.Schedule
+	pruningSchedule  *schedule.Schedule
+
+	// signal the LocalSnapshots worker that a scheduled local snapshot or pruning is due
+	scheduledSnapshotSignal = make(chan struct{}, 1)
+	scheduledPruningSignal  = make(chan struct{}, 1)
+)
+
+func configureSchedules() {
+	var err error
+	if snapshotSchedule, err = schedule.Parse(config.NodeConfig.GetStringSlice(config.CfgLocalSnapshotsSchedule)); err != nil {
+		log.Fatalf("'%s' is invalid: %s", config.CfgLocalSnapshotsSchedule, err)
+	}
+	if pruningSchedule, err = schedule.Parse(config.NodeConfig.GetStringSlice(config.CfgPruningSchedule)); err != nil {
+		log.Fatalf("'%s' is invalid: %s", config.CfgPruningSchedule, err)
+	}
+
+	if !snapshotSchedule.IsEmpty() {
+		log.Infof("Local snapshots are scheduled at %s", snapshotSchedule)
+	}
+	if pruningEnabled && !pruningSchedule.IsEmpty() {
+		log.Infof("Pruning is scheduled at %s", pruningSchedule)
+	}
+}
+
+func runSchedules() {
+	if !snapshotSchedule.IsEmpty() {
+		runSchedule("LocalSnapshots[Schedule]", snapshotSchedule, scheduledSnapshotSignal)
+	}
+	if pruningEnabled && !pruningSchedule.IsEmpty() {
+		runSchedule("Pruning[Schedule]", pruningSchedule, scheduledPruningSignal)
+	}
+}
+
+// runSchedule signals the given channel at the times of the schedule.
+// A signal is dropped if the previous one was not handled yet, e.g. because a snapshot is still created.
+func runSchedule(name string, s *schedule.Schedule, signal chan struct{}) {
+	daemon.BackgroundWorker(name, func(shutdownSignal <-chan struct{}) {
+		for {
+			next := s.Next(time.Now())
+			if next.IsZero() {
+				log.Warnf("The schedule %s never matches", s)
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-shutdownSignal:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			select {
+			case signal <- struct{}{}:
+			default:
+			}
+		}
+	}, shutdown.PriorityLocalSnapshots)
+}
@@ -0,0 +1,232 @@
+package spammer
+
+import (
+	"errors"
+	"math"
+
+	"github.com/iotaledger/hive.go/syncutils"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
+	"go.uber.org/atomic"
+
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+const (
+	// the factor the rate is reduced with if the node is unhealthy
+	rateLimitDecreaseFactor = 0.5
+	// the part of the rate limit ceiling the rate is increased by every second if the node is healthy
+	rateLimitIncreaseStep = 0.1
+	// the minimum rate of the adaptive rate limit relative to the ceiling
+	rateLimitMinimumFactor = 0.01
+)
+
+var (
+	// ErrSpammerDisabled is returned if the spammer plugin is disabled.
+	ErrSpammerDisabled = errors.New("spammer plugin disabled")
+	// ErrInvalidSettings is returned if the given spammer settings are invalid.
+	ErrInvalidSettings = errors.New("invalid spammer settings")
+
+	settings     *Settings
+	settingsLock syncutils.RWMutex
+
+	running            = atomic.NewBool(false)
+	effectiveRateLimit = atomic.NewFloat64(0)
+	throttleReason     = atomic.NewString("")
+)
+
+// Settings are the parameters of the spammer, which can be changed while the node is running.
+type Settings struct {
+	// the target address of the spam
+	Address trinary.Hash `json:"address"`
+	// the message to embed within the spam transactions
+	Message string `json:"message"`
+	// the tag of the transaction
+	Tag trinary.Trytes `json:"tag"`
+	// the tag of the transaction if the semi-lazy pool is used
+	TagSemiLazy trinary.Trytes `json:"tagSemiLazy"`
+	// workers remain idle for a while when the cpu usage gets over this limit (0 = disable)
+	CPUMaxUsage float64 `json:"cpuMaxUsage"`
+	// the ceiling of the adaptive rate limit (0 = no limit)
+	TPSRateLimit float64 `json:"tpsRateLimit"`
+	// the size of the spam bundles
+	BundleSize int `json:"bundleSize"`
+	// should be spammed with value bundles
+	ValueSpam bool `json:"valueSpam"`
+	// the size of the request queue above which the rate is reduced (0 = disable)
+	MaxRequestQueueSize int `json:"maxRequestQueueSize"`
+}
+
+// Status is the current state of the spammer.
+type Status struct {
+	Settings
+	// whether the spammer is running
+	Running bool `json:"running"`
+	// the current rate limit of the spammer, which adapts to the health of the node
+	EffectiveTPSRateLimit float64 `json:"effectiveTpsRateLimit"`
+	// the reason why the rate is currently reduced, if any
+	ThrottleReason string `json:"throttleReason,omitempty"`
+}
+
+// normalize fixes the padding of the trytes and the bundle size of the settings.
+func (s *Settings) normalize() error {
+
+	if !guards.IsTrytes(s.Address) || !guards.IsTrytes(s.Tag) || (s.TagSemiLazy != "" && !guards.IsTrytes(s.TagSemiLazy)) {
+		return ErrInvalidSettings
+	}
+
+	if s.TPSRateLimit < 0 || s.CPUMaxUsage < 0 || s.MaxRequestQueueSize < 0 {
+		return ErrInvalidSettings
+	}
+
+	s.Address = trinary.MustPad(s.Address, consts.AddressTrinarySize/3)[:consts.AddressTrinarySize/3]
+
+	if s.TagSemiLazy == "" {
+		s.TagSemiLazy = s.Tag
+	}
+
+	s.Tag = trinary.MustPad(s.Tag, consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
+	s.TagSemiLazy = trinary.MustPad(s.TagSemiLazy, consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
+
+	if len(s.Tag) > 20 {
+		s.Tag = s.Tag[:20]
+	}
+	if len(s.TagSemiLazy) > 20 {
+		s.TagSemiLazy = s.TagSemiLazy[:20]
+	}
+
+	if s.BundleSize < 1 {
+		s.BundleSize = 1
+	}
+
+	if s.ValueSpam && s.BundleSize < 2 {
+		// minimum size for a value tx with SecurityLevelLow
+		s.BundleSize = 2
+	}
+
+	if s.CPUMaxUsage > 0.0 && !cpuUsageSupported() {
+		s.CPUMaxUsage = 0.0
+	}
+
+	return nil
+}
+
+// getSettings returns a copy of the current settings.
+func getSettings() Settings {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return *settings
+}
+
+// GetStatus returns the current state of the spammer.
+func GetStatus() (*Status, error) {
+	if plugincontrol.IsSkipped(PLUGIN) {
+		return nil, ErrSpammerDisabled
+	}
+
+	return &Status{
+		Settings:              getSettings(),
+		Running:               running.Load(),
+		EffectiveTPSRateLimit: effectiveRateLimit.Load(),
+		ThrottleReason:        throttleReason.Load(),
+	}, nil
+}
+
+// Start starts the spammer with the given settings.
+// If the spammer is already running, the settings are applied to the running spammer.
+func Start(newSettings *Settings) error {
+	if plugincontrol.IsSkipped(PLUGIN) {
+		return ErrSpammerDisabled
+	}
+
+	if newSettings != nil {
+		if err := newSettings.normalize(); err != nil {
+			return err
+		}
+
+		applySettings(newSettings)
+	}
+
+	if !running.Swap(true) {
+		log.Info("Spammer started")
+	}
+
+	return nil
+}
+
+// Stop stops the spammer. The settings are kept for the next start.
+func Stop() error {
+	if plugincontrol.IsSkipped(PLUGIN) {
+		return ErrSpammerDisabled
+	}
+
+	if running.Swap(false) {
+		log.Info("Spammer stopped")
+	}
+
+	return nil
+}
+
+// applySettings replaces the current settings with the given normalized settings.
+func applySettings(newSettings *Settings) {
+	settingsLock.Lock()
+	settings = newSettings
+	settingsLock.Unlock()
+
+	// start again at the new ceiling
+	effectiveRateLimit.Store(newSettings.TPSRateLimit)
+}
+
+// unhealthyReason returns why the node can't handle more spam at the moment, or an empty string if it can.
+func unhealthyReason(s *Settings) string {
+
+	if !tangle.IsNodeSyncedWithThreshold() {
+		return "node not synced"
+	}
+
+	if s.CPUMaxUsage > 0.0 {
+		if usage, err := cpuUsage(); err == nil && usage >= s.CPUMaxUsage {
+			return "cpu usage too high"
+		}
+	}
+
+	if s.MaxRequestQueueSize > 0 {
+		if queued, pending, _ := gossip.RequestQueue().Size(); queued+pending > s.MaxRequestQueueSize {
+			return "request queue too big"
+		}
+	}
+
+	return ""
+}
+
+// adaptRateLimit reduces the rate limit multiplicatively as long as the node is unhealthy,
+// and increases it again step by step up to the configured ceiling if the node is healthy.
+func adaptRateLimit() {
+	s := getSettings()
+
+	if s.TPSRateLimit == 0 {
+		// no limit
+		effectiveRateLimit.Store(0)
+		throttleReason.Store("")
+		return
+	}
+
+	rate := effectiveRateLimit.Load()
+	if rate == 0 || rate > s.TPSRateLimit {
+		rate = s.TPSRateLimit
+	}
+
+	reason := unhealthyReason(&s)
+	throttleReason.Store(reason)
+
+	if reason != "" {
+		rate = math.Max(rate*rateLimitDecreaseFactor, s.TPSRateLimit*rateLimitMinimumFactor)
+	} else {
+		rate = math.Min(rate+s.TPSRateLimit*rateLimitIncreaseStep, s.TPSRateLimit)
+	}
+
+	effectiveRateLimit.Store(rate)
+}
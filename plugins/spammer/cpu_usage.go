@@ -65,8 +65,13 @@ func cpuUsageGuessWithAdditionalWorker() (float64, error) {
 	return cpuUsage + (1.0 / float64(runtime.NumCPU())), nil
 }
 
+// cpuUsageSupported returns whether the cpu usage can be measured on this machine.
+func cpuUsageSupported() bool {
+	return runtime.GOOS != "windows" && runtime.NumCPU() > 1
+}
+
 // waitForLowerCPUUsage waits until the cpu usage drops below cpuMaxUsage.
-func waitForLowerCPUUsage(shutdownSignal <-chan struct{}) error {
+func waitForLowerCPUUsage(cpuMaxUsage float64, shutdownSignal <-chan struct{}) error {
 	if cpuMaxUsage == 0.0 {
 		return nil
 	}
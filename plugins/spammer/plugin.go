@@ -5,16 +5,15 @@ import (
 	"runtime"
 	"time"
 
-	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/timeutil"
-	"github.com/iotaledger/iota.go/consts"
-	"github.com/iotaledger/iota.go/trinary"
 	"go.uber.org/atomic"
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/pkg/utils"
 	"github.com/gohornet/hornet/plugins/coordinator"
@@ -25,23 +24,22 @@ var (
 	PLUGIN = node.NewPlugin("Spammer", node.Disabled, configure, run)
 	log    *logger.Logger
 
-	txAddress            string
-	message              string
-	tagSubstring         string
-	tagSemiLazySubstring string
-	cpuMaxUsage          float64
-	rateLimit            float64
-	mwm                  int
-	bundleSize           int
-	valueSpam            bool
-	spammerWorkerCount   int
-	semiLazyTipsLimit    uint32
-	checkPeersConnected  bool
-	spammerAvgHeap       *utils.TimeHeap
-	spammerStartTime     time.Time
-	lastSentSpamTxsCnt   uint32
+	// the plugin can be started and stopped at runtime, the spam settings and whether it is spamming are kept
+	control *plugincontrol.Plugin
+
+	spammerWorkerCount  int
+	semiLazyTipsLimit   uint32
+	checkPeersConnected bool
+	mwm                 int
+	spammerAvgHeap      *utils.TimeHeap
+	spammerStartTime    time.Time
+	lastSentSpamTxsCnt  uint32
 )
 
+func init() {
+	control = plugincontrol.Register(PLUGIN)
+}
+
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 
@@ -51,32 +49,27 @@ func configure(plugin *node.Plugin) {
 		return
 	}
 
-	txAddress = trinary.MustPad(config.NodeConfig.GetString(config.CfgSpammerAddress), consts.AddressTrinarySize/3)[:consts.AddressTrinarySize/3]
-	message = config.NodeConfig.GetString(config.CfgSpammerMessage)
-	tagSubstring = trinary.MustPad(config.NodeConfig.GetString(config.CfgSpammerTag), consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
-	tagSemiLazySubstring = trinary.MustPad(config.NodeConfig.GetString(config.CfgSpammerTag), consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
-	if config.NodeConfig.GetString(config.CfgSpammerTagSemiLazy) != "" {
-		tagSemiLazySubstring = trinary.MustPad(config.NodeConfig.GetString(config.CfgSpammerTagSemiLazy), consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
+	settings = settingsFromConfig()
+
+	if settings.CPUMaxUsage > 0.0 && runtime.GOOS == "windows" {
+		log.Warn("spammer.cpuMaxUsage not supported on Windows. will be deactivated")
+	}
+
+	if settings.CPUMaxUsage > 0.0 && runtime.NumCPU() == 1 {
+		log.Warn("spammer.cpuMaxUsage not supported on single core machines. will be deactivated")
+	}
+
+	if err := settings.normalize(); err != nil {
+		log.Fatalf("%v: check the config options of the spammer", err)
 	}
-	cpuMaxUsage = config.NodeConfig.GetFloat64(config.CfgSpammerCPUMaxUsage)
-	rateLimit = config.NodeConfig.GetFloat64(config.CfgSpammerTPSRateLimit)
+	effectiveRateLimit.Store(settings.TPSRateLimit)
+
 	mwm = config.NodeConfig.GetInt(config.CfgCoordinatorMWM)
-	bundleSize = config.NodeConfig.GetInt(config.CfgSpammerBundleSize)
-	valueSpam = config.NodeConfig.GetBool(config.CfgSpammerValueSpam)
 	spammerWorkerCount = int(config.NodeConfig.GetUint(config.CfgSpammerWorkers))
 	semiLazyTipsLimit = config.NodeConfig.GetUint32(config.CfgSpammerSemiLazyTipsLimit)
 	checkPeersConnected = node.IsSkipped(coordinator.PLUGIN)
 	spammerAvgHeap = utils.NewTimeHeap()
 
-	if bundleSize < 1 {
-		bundleSize = 1
-	}
-
-	if valueSpam && bundleSize < 2 {
-		// minimum size for a value tx with SecurityLevelLow
-		bundleSize = 2
-	}
-
 	if spammerWorkerCount >= runtime.NumCPU() || spammerWorkerCount == 0 {
 		spammerWorkerCount = runtime.NumCPU() - 1
 	}
@@ -84,44 +77,41 @@ func configure(plugin *node.Plugin) {
 		spammerWorkerCount = 1
 	}
 
-	if cpuMaxUsage > 0.0 && runtime.GOOS == "windows" {
-		log.Warn("spammer.cpuMaxUsage not supported on Windows. will be deactivated")
-		cpuMaxUsage = 0.0
+	if cpuUsageSupported() {
+		// the cpu usage is always measured, since the limit can be changed at runtime
+		cpuUsageUpdater()
 	}
 
-	if cpuMaxUsage > 0.0 && runtime.NumCPU() == 1 {
-		log.Warn("spammer.cpuMaxUsage not supported on single core machines. will be deactivated")
-		cpuMaxUsage = 0.0
-	}
+	// the channel only buffers a few signals, so the spammer doesn't burst after it was throttled
+	rateLimitChannel = make(chan struct{}, 2)
 
-	if cpuMaxUsage > 0.0 {
-		cpuUsageUpdater()
-	}
+	running.Store(config.NodeConfig.GetBool(config.CfgSpammerAutostart))
 
-	if rateLimit != 0 {
-		rateLimitChannelSize := int64(rateLimit) * 2
-		if rateLimitChannelSize < 2 {
-			rateLimitChannelSize = 2
+	config.Events.Reloaded.Attach(events.NewClosure(func() {
+		// the spammer keeps running or stays stopped, only the settings are applied
+		newSettings := settingsFromConfig()
+		if err := newSettings.normalize(); err != nil {
+			log.Warnf("%v: the spammer settings were not changed by the config reload", err)
+			return
 		}
-		rateLimitChannel = make(chan struct{}, rateLimitChannelSize)
-
-		// create a background worker that fills rateLimitChannel every second
-		daemon.BackgroundWorker("Spammer rate limit channel", func(shutdownSignal <-chan struct{}) {
-			timeutil.Ticker(func() {
-				select {
-				case rateLimitChannel <- struct{}{}:
-				default:
-					// Channel full
-				}
-			}, time.Duration(int64(float64(time.Second)/rateLimit)), shutdownSignal)
-		}, shutdown.PrioritySpammer)
-	}
+		applySettings(newSettings)
+		log.Info("applied the spammer settings due to config reload")
+	}))
+}
 
-	if len(tagSubstring) > 20 {
-		tagSubstring = string([]rune(tagSubstring)[:20])
-	}
-	if len(tagSemiLazySubstring) > 20 {
-		tagSemiLazySubstring = string([]rune(tagSemiLazySubstring)[:20])
+// settingsFromConfig returns the spammer settings of the node config.
+func settingsFromConfig() *Settings {
+	settings := config.ReloadableNodeConfig()
+	return &Settings{
+		Address:             settings.GetString(config.CfgSpammerAddress),
+		Message:             settings.GetString(config.CfgSpammerMessage),
+		Tag:                 settings.GetString(config.CfgSpammerTag),
+		TagSemiLazy:         settings.GetString(config.CfgSpammerTagSemiLazy),
+		CPUMaxUsage:         settings.GetFloat64(config.CfgSpammerCPUMaxUsage),
+		TPSRateLimit:        settings.GetFloat64(config.CfgSpammerTPSRateLimit),
+		BundleSize:          settings.GetInt(config.CfgSpammerBundleSize),
+		ValueSpam:           settings.GetBool(config.CfgSpammerValueSpam),
+		MaxRequestQueueSize: settings.GetInt(config.CfgSpammerMaxRequestQueueSize),
 	}
 }
 
@@ -132,15 +122,42 @@ func run(_ *node.Plugin) {
 		return
 	}
 
+	// create a background worker that fills rateLimitChannel according to the adaptive rate limit
+	control.BackgroundWorker("Spammer rate limit channel", func(shutdownSignal <-chan struct{}) {
+		for {
+			interval := time.Second
+			if rate := effectiveRateLimit.Load(); rate != 0 {
+				interval = time.Duration(float64(time.Second) / rate)
+			}
+
+			select {
+			case <-shutdownSignal:
+				return
+			case <-time.After(interval):
+			}
+
+			select {
+			case rateLimitChannel <- struct{}{}:
+			default:
+				// Channel full
+			}
+		}
+	}, shutdown.PrioritySpammer)
+
+	// create a background worker that adapts the rate limit to the health of the node every second
+	control.BackgroundWorker("Spammer[Throttling]", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(adaptRateLimit, 1*time.Second, shutdownSignal)
+	}, shutdown.PrioritySpammer)
+
 	// create a background worker that "measures" the spammer averages values every second
-	daemon.BackgroundWorker("Spammer Metrics Updater", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("Spammer Metrics Updater", func(shutdownSignal <-chan struct{}) {
 		timeutil.Ticker(measureSpammerMetrics, 1*time.Second, shutdownSignal)
 	}, shutdown.PrioritySpammer)
 
 	spammerCnt := atomic.NewInt32(0)
 
 	for i := 0; i < spammerWorkerCount; i++ {
-		daemon.BackgroundWorker(fmt.Sprintf("Spammer_%d", i), func(shutdownSignal <-chan struct{}) {
+		control.BackgroundWorker(fmt.Sprintf("Spammer_%d", i), func(shutdownSignal <-chan struct{}) {
 			spammerIndex := spammerCnt.Inc()
 			log.Infof("Starting Spammer %d... done", spammerIndex)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/batchhasher"
 
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/utils"
 	"github.com/gohornet/hornet/plugins/gossip"
@@ -32,7 +33,17 @@ var (
 
 func doSpam(shutdownSignal <-chan struct{}) {
 
-	if rateLimit != 0 {
+	if !running.Load() {
+		select {
+		case <-shutdownSignal:
+		case <-time.After(time.Second):
+		}
+		return
+	}
+
+	s := getSettings()
+
+	if s.TPSRateLimit != 0 {
 		select {
 		case <-shutdownSignal:
 			return
@@ -50,7 +61,7 @@ func doSpam(shutdownSignal <-chan struct{}) {
 		return
 	}
 
-	if err := waitForLowerCPUUsage(shutdownSignal); err != nil {
+	if err := waitForLowerCPUUsage(s.CPUMaxUsage, shutdownSignal); err != nil {
 		if err != tangle.ErrOperationAborted {
 			log.Warn(err.Error())
 		}
@@ -64,13 +75,13 @@ func doSpam(shutdownSignal <-chan struct{}) {
 
 	timeStart := time.Now()
 
-	tipselFunc := urts.TipSelector.SelectNonLazyTips
-	tag := tagSubstring
+	tipselFunc := func() (hornet.Hashes, error) { return urts.Selector.SelectTips(0) }
+	tag := s.Tag
 
 	reduceSemiLazyTips := semiLazyTipsLimit != 0 && metrics.SharedServerMetrics.TipsSemiLazy.Load() > semiLazyTipsLimit
 	if reduceSemiLazyTips {
 		tipselFunc = urts.TipSelector.SelectSemiLazyTips
-		tag = tagSemiLazySubstring
+		tag = s.TagSemiLazy
 	}
 
 	tips, err := tipselFunc()
@@ -85,10 +96,10 @@ func doSpam(shutdownSignal <-chan struct{}) {
 
 	durationGTTA := time.Since(timeStart)
 
-	txCountValue := int(txCount.Add(int32(bundleSize)))
+	txCountValue := int(txCount.Add(int32(s.BundleSize)))
 	infoMsg := fmt.Sprintf("gTTA took %v", durationGTTA.Truncate(time.Millisecond))
 
-	b, err := createBundle(txAddress, message, tag, bundleSize, valueSpam, txCountValue, infoMsg)
+	b, err := createBundle(s.Address, s.Message, tag, s.BundleSize, s.ValueSpam, txCountValue, infoMsg)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,115 @@
+package systemd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/systemd"
+)
+
+const (
+	// the time after which the node is considered stalled if its solid milestone doesn't change while it is not synced
+	solidificationStallTimeout = 15 * time.Minute
+)
+
+var (
+	PLUGIN = node.NewPlugin("Systemd", node.Enabled, configure, run)
+	log    *logger.Logger
+
+	watchdogInterval time.Duration
+	solidification   = &progressMonitor{}
+)
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	interval, err := systemd.WatchdogInterval()
+	if err != nil {
+		log.Warnf("invalid systemd watchdog interval: %s", err)
+	}
+	watchdogInterval = interval
+}
+
+func run(_ *node.Plugin) {
+
+	// this plugin runs after all other plugins, so the node is up as soon as the daemon starts the worker
+	daemon.BackgroundWorker("Systemd notifier", func(shutdownSignal <-chan struct{}) {
+		notified, err := systemd.Notify(systemd.StateReady)
+		if err != nil {
+			log.Warnf("notifying systemd failed: %s", err)
+			return
+		}
+		if !notified {
+			// not started by systemd with notify support
+			return
+		}
+		log.Info("Notified systemd that the node is ready")
+
+		if watchdogInterval != 0 {
+			log.Infof("Petting the systemd watchdog every %v", watchdogInterval/2)
+
+			// the watchdog is petted twice per interval, as recommended by systemd
+			timeutil.Ticker(petWatchdog, watchdogInterval/2, shutdownSignal)
+		} else {
+			<-shutdownSignal
+		}
+
+		if _, err := systemd.Notify(systemd.StateStopping); err != nil {
+			log.Warnf("notifying systemd failed: %s", err)
+		}
+	}, shutdown.PrioritySystemd)
+}
+
+// petWatchdog pets the systemd watchdog and updates the status of the service, as long as the node is healthy.
+// The milestone indexes are read with the locks of the tangle, so a deadlock in the tangle stops the watchdog.
+// The watchdog is also not petted if the database is tainted or the solidification doesn't make progress,
+// so that systemd restarts the node.
+func petWatchdog() {
+	state := systemd.StateWatchdog
+	if !config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
+		solidMilestoneIndex, latestMilestoneIndex := tangle.GetSolidMilestoneIndex(), tangle.GetLatestMilestoneIndex()
+
+		if tangle.IsDatabaseTainted() {
+			log.Warn("Not petting the systemd watchdog, the database is tainted")
+			return
+		}
+
+		if !solidification.progressing(solidMilestoneIndex, latestMilestoneIndex, time.Now()) {
+			log.Warnf("Not petting the systemd watchdog, the solid milestone %d didn't change for %v (latest milestone %d)", solidMilestoneIndex, solidificationStallTimeout, latestMilestoneIndex)
+			return
+		}
+
+		state += fmt.Sprintf("\n%ssolid milestone %d, latest milestone %d", systemd.StateStatus, solidMilestoneIndex, latestMilestoneIndex)
+	}
+
+	if _, err := systemd.Notify(state); err != nil {
+		log.Warnf("petting the systemd watchdog failed: %s", err)
+	}
+}
+
+// progressMonitor tracks whether the solid milestone index advances.
+type progressMonitor struct {
+	solidMilestoneIndex milestone.Index
+	lastProgress        time.Time
+}
+
+// progressing returns whether the node is synced or its solid milestone changed within the stall timeout.
+// A node which is synced with the latest known milestone is not stalled, even if no new milestones arrive.
+func (m *progressMonitor) progressing(solidMilestoneIndex milestone.Index, latestMilestoneIndex milestone.Index, now time.Time) bool {
+	if m.lastProgress.IsZero() || solidMilestoneIndex != m.solidMilestoneIndex || solidMilestoneIndex >= latestMilestoneIndex {
+		m.solidMilestoneIndex = solidMilestoneIndex
+		m.lastProgress = now
+		return true
+	}
+
+	return now.Sub(m.lastProgress) < solidificationStallTimeout
+}
@@ -0,0 +1,27 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressMonitor(t *testing.T) {
+	m := &progressMonitor{}
+	start := time.Now()
+
+	assert.True(t, m.progressing(10, 20, start))
+
+	// the solid milestone doesn't change while the node is not synced
+	assert.True(t, m.progressing(10, 20, start.Add(solidificationStallTimeout-time.Second)))
+	assert.False(t, m.progressing(10, 20, start.Add(solidificationStallTimeout)))
+
+	// the solidification advances again
+	assert.True(t, m.progressing(11, 20, start.Add(solidificationStallTimeout+time.Second)))
+	assert.False(t, m.progressing(11, 21, start.Add(2*solidificationStallTimeout+time.Second)))
+
+	// a synced node is not stalled if no new milestones arrive
+	assert.True(t, m.progressing(21, 21, start.Add(3*solidificationStallTimeout)))
+	assert.True(t, m.progressing(21, 21, start.Add(5*solidificationStallTimeout)))
+}
@@ -0,0 +1,143 @@
+package tangle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/protocol/bqueue"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+var (
+	// the hashes of the locally submitted transactions which are not solid yet
+	pendingBroadcasts     = make(map[string]struct{})
+	pendingBroadcastsLock sync.Mutex
+)
+
+type pendingBroadcast struct {
+	txHash    hornet.Hash
+	submitted time.Time
+	txData    []byte
+}
+
+// runPendingBroadcasts keeps the locally submitted transactions in the database until they are solid,
+// and broadcasts them again in the rebroadcast interval, so that they are not lost if the node is restarted
+// before they were gossiped.
+func runPendingBroadcasts() {
+
+	if err := tangle.ForEachPendingBroadcast(func(txHash hornet.Hash, _ time.Time, _ []byte) bool {
+		pendingBroadcasts[string(txHash)] = struct{}{}
+		return true
+	}); err != nil {
+		log.Warnf("loading the pending broadcasts failed: %s", err)
+	}
+
+	if len(pendingBroadcasts) > 0 {
+		log.Infof("resuming the broadcast of %d submitted transactions", len(pendingBroadcasts))
+	}
+
+	onTransactionSubmitted := events.NewClosure(func(tx *hornet.Transaction) {
+		pendingBroadcastsLock.Lock()
+		defer pendingBroadcastsLock.Unlock()
+
+		if err := tangle.StorePendingBroadcast(tx.GetTxHash(), time.Now(), tx.RawBytes); err != nil {
+			log.Warnf("storing the pending broadcast of %s failed: %s", tx.GetTxHash().Trytes(), err)
+			return
+		}
+		pendingBroadcasts[string(tx.GetTxHash())] = struct{}{}
+	})
+
+	onTransactionSolid := events.NewClosure(func(txHash hornet.Hash) {
+		pendingBroadcastsLock.Lock()
+		defer pendingBroadcastsLock.Unlock()
+
+		if _, pending := pendingBroadcasts[string(txHash)]; !pending {
+			return
+		}
+		removePendingBroadcast(txHash)
+	})
+
+	rebroadcastInterval := time.Duration(config.NodeConfig.GetInt(config.CfgNetGossipPendingBroadcastsRebroadcastIntervalSeconds)) * time.Second
+	maxAge := time.Duration(config.NodeConfig.GetInt(config.CfgNetGossipPendingBroadcastsMaxAgeMinutes)) * time.Minute
+
+	daemon.BackgroundWorker("Tangle[PendingBroadcasts]", func(shutdownSignal <-chan struct{}) {
+		gossip.Processor().Events.TransactionSubmitted.Attach(onTransactionSubmitted)
+		Events.TransactionSolid.Attach(onTransactionSolid)
+		timeutil.Ticker(func() { rebroadcastPendingTransactions(maxAge) }, rebroadcastInterval, shutdownSignal)
+		gossip.Processor().Events.TransactionSubmitted.Detach(onTransactionSubmitted)
+		Events.TransactionSolid.Detach(onTransactionSolid)
+	}, shutdown.PriorityPendingBroadcasts)
+}
+
+// removePendingBroadcast removes the given transaction from the pending broadcasts.
+// pendingBroadcastsLock must be held.
+func removePendingBroadcast(txHash hornet.Hash) {
+	if err := tangle.DeletePendingBroadcast(txHash); err != nil {
+		log.Warnf("removing the pending broadcast of %s failed: %s", txHash.Trytes(), err)
+		return
+	}
+	delete(pendingBroadcasts, string(txHash))
+}
+
+// rebroadcastPendingTransactions broadcasts the pending transactions again which are neither solid nor expired.
+func rebroadcastPendingTransactions(maxAge time.Duration) {
+	pendingBroadcastsLock.Lock()
+	if len(pendingBroadcasts) == 0 {
+		pendingBroadcastsLock.Unlock()
+		return
+	}
+
+	// collect the pending broadcasts first, the store can't be modified while iterating over it
+	var pending []*pendingBroadcast
+	if err := tangle.ForEachPendingBroadcast(func(txHash hornet.Hash, submitted time.Time, txData []byte) bool {
+		pending = append(pending, &pendingBroadcast{txHash: txHash, submitted: submitted, txData: txData})
+		return true
+	}); err != nil {
+		log.Warnf("loading the pending broadcasts failed: %s", err)
+	}
+
+	var rebroadcast []*pendingBroadcast
+	for _, b := range pending {
+		if time.Since(b.submitted) > maxAge {
+			log.Warnf("submitted transaction %s did not become solid within %v, it is no longer broadcasted", b.txHash.Trytes(), maxAge)
+			removePendingBroadcast(b.txHash)
+			continue
+		}
+
+		if cachedTxMeta := tangle.GetCachedTxMetadataOrNil(b.txHash); cachedTxMeta != nil { // meta +1
+			solid := cachedTxMeta.GetMetadata().IsSolid()
+			cachedTxMeta.Release(true) // meta -1
+
+			if solid {
+				removePendingBroadcast(b.txHash)
+				continue
+			}
+		}
+		rebroadcast = append(rebroadcast, b)
+	}
+	pendingBroadcastsLock.Unlock()
+
+	for _, b := range rebroadcast {
+		if tangle.ContainsTransaction(b.txHash) {
+			gossip.BroadcastQueue().EnqueueForBroadcast(&bqueue.Broadcast{TxData: b.txData, RequestedTxHash: b.txHash})
+			continue
+		}
+
+		// the transaction was submitted right before the node was shut down and wasn't stored yet
+		if err := gossip.Processor().EmitCompressed(b.txData); err != nil {
+			log.Warnf("resuming the broadcast of %s failed: %s", b.txHash.Trytes(), err)
+
+			pendingBroadcastsLock.Lock()
+			removePendingBroadcast(b.txHash)
+			pendingBroadcastsLock.Unlock()
+		}
+	}
+}
@@ -125,6 +125,8 @@ func run(plugin *node.Plugin) {
 
 	runTangleProcessor(plugin)
 
+	runPendingBroadcasts()
+
 	// create a background worker that prints a status message every second
 	daemon.BackgroundWorker("Tangle status reporter", func(shutdownSignal <-chan struct{}) {
 		timeutil.Ticker(printStatus, 1*time.Second, shutdownSignal)
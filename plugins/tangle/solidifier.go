@@ -3,6 +3,7 @@ package tangle
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/iotaledger/hive.go/daemon"
@@ -28,6 +29,9 @@ var (
 	milestoneSolidifierQueueSize   = 2
 	milestoneSolidifierWorkerPool  *workerpool.WorkerPool
 
+	// the amount of workers which check the signatures of the bundles of a solidified milestone cone
+	bundleValidationWorkerCount = runtime.NumCPU()
+
 	signalChanMilestoneStopSolidification     chan struct{}
 	signalChanMilestoneStopSolidificationLock syncutils.Mutex
 
@@ -59,7 +63,8 @@ func TriggerSolidifier() {
 	milestoneSolidifierWorkerPool.TrySubmit(milestone.Index(0), true)
 }
 
-func markTransactionAsSolid(cachedTxMeta *tangle.CachedMetadata) {
+// validatedBundles holds the results of the semantics and signature checks done ahead by tangle.ValidateBundles and may be nil.
+func markTransactionAsSolid(cachedTxMeta *tangle.CachedMetadata, validatedBundles tangle.ValidatedBundles) {
 	defer cachedTxMeta.Release(true)
 
 	// Construct the complete bundle if the tail got solid (before setting solid flag => otherwise not threadsafe)
@@ -68,7 +73,7 @@ func markTransactionAsSolid(cachedTxMeta *tangle.CachedMetadata) {
 		if cachedTx == nil {
 			log.Panicf("markTransactionAsSolid: Transaction not found: %v", cachedTxMeta.GetMetadata().GetTxHash().Trytes())
 		}
-		tangle.OnTailTransactionSolid(cachedTx) // tx pass +1
+		tangle.OnTailTransactionSolid(cachedTx, validatedBundles) // tx pass +1
 	}
 
 	// update the solidity flags of this transaction
@@ -189,14 +194,25 @@ func solidQueueCheck(cachedTxMetas map[string]*tangle.CachedMetadata, milestoneI
 	}
 
 	// no transactions to request => the whole cone is solid
-	// we mark all transactions as solid in order from oldest to latest (needed for the tip pool)
+	// the signatures of the bundles don't depend on each other, so they are checked in parallel upfront.
+	var tailTxHashes hornet.Hashes
 	for _, txHash := range txsToSolidify {
 		cachedTxMeta, exists := cachedTxMetas[string(txHash)]
 		if !exists {
 			log.Panicf("solidQueueCheck: Tx not found: %v", txHash.Trytes())
 		}
 
-		markTransactionAsSolid(cachedTxMeta.Retain())
+		if cachedTxMeta.GetMetadata().IsTail() {
+			tailTxHashes = append(tailTxHashes, txHash)
+		}
+	}
+	validatedBundles := tangle.ValidateBundles(tailTxHashes, bundleValidationWorkerCount)
+
+	tValidate := time.Now()
+
+	// we mark all transactions as solid in order from oldest to latest (needed for the tip pool)
+	for _, txHash := range txsToSolidify {
+		markTransactionAsSolid(cachedTxMetas[string(txHash)].Retain(), validatedBundles)
 	}
 
 	tSolid := time.Now()
@@ -206,7 +222,7 @@ func solidQueueCheck(cachedTxMetas map[string]*tangle.CachedMetadata, milestoneI
 		solidifyFutureCone(cachedTxMetas, txsToSolidify, false, abortSignal)
 	}
 
-	log.Infof("Solidifier finished: txs: %d, collect: %v, solidity %v, propagation: %v, total: %v", txsChecked, tCollect.Sub(ts).Truncate(time.Millisecond), tSolid.Sub(tCollect).Truncate(time.Millisecond), time.Since(tSolid).Truncate(time.Millisecond), time.Since(ts).Truncate(time.Millisecond))
+	log.Infof("Solidifier finished: txs: %d, collect: %v, validation: %v, solidity %v, propagation: %v, total: %v", txsChecked, tCollect.Sub(ts).Truncate(time.Millisecond), tValidate.Sub(tCollect).Truncate(time.Millisecond), tSolid.Sub(tValidate).Truncate(time.Millisecond), time.Since(tSolid).Truncate(time.Millisecond), time.Since(ts).Truncate(time.Millisecond))
 	return true, false
 }
 
@@ -335,7 +351,7 @@ func solidifyPastCone(cachedTxMetas map[string]*tangle.CachedMetadata, nonSolidT
 			defer cachedTxMeta.Release(true) // meta -1
 
 			// we can mark all consumed txs as solid, since we do a DFS for non-solid txs and return an error at onMissingApprovee
-			markTransactionAsSolid(cachedTxMeta.Retain())
+			markTransactionAsSolid(cachedTxMeta.Retain(), nil)
 
 			return nil
 		},
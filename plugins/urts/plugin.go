@@ -21,7 +21,10 @@ var (
 	PLUGIN = node.NewPlugin("URTS", node.Enabled, configure, run)
 	log    *logger.Logger
 
+	// TipSelector manages the tip pools of the node.
 	TipSelector *tipselect.TipSelector
+	// Selector performs the tip-selection with the configured algorithm.
+	Selector tipselect.Selector
 
 	// Closures
 	onBundleSolid        *events.Closure
@@ -45,6 +48,19 @@ func configure(plugin *node.Plugin) {
 		config.NodeConfig.GetUint32(config.CfgTipSelSemiLazy+config.CfgTipSelMaxApprovers),
 	)
 
+	switch algorithm := config.NodeConfig.GetString(config.CfgTipSelAlgorithm); algorithm {
+	case tipselect.AlgorithmURTS:
+		Selector = TipSelector
+	case tipselect.AlgorithmWalk:
+		Selector = tipselect.NewWalker(
+			config.NodeConfig.GetInt(config.CfgTipSelWalkDefaultDepth),
+			config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth),
+		)
+	default:
+		log.Fatalf("unknown tip-selection algorithm '%s', use '%s' or '%s'", algorithm, tipselect.AlgorithmURTS, tipselect.AlgorithmWalk)
+	}
+	log.Infof("Using the tip-selection algorithm '%s'", config.NodeConfig.GetString(config.CfgTipSelAlgorithm))
+
 	configureEvents()
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/protocol/bulksync"
 	"github.com/gohornet/hornet/pkg/protocol/rqueue"
 	"github.com/gohornet/hornet/pkg/protocol/sting"
 	"github.com/gohornet/hornet/pkg/protocol/warpsync"
@@ -18,12 +19,15 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
 )
 
 var (
 	PLUGIN   = node.NewPlugin("WarpSync", node.Enabled, configure, run)
 	log      *logger.Logger
 	warpSync *warpsync.WarpSync
+	// requests milestone cones from the neighbors, nil if bulk requests are disabled
+	coneRequester *bulksync.Requester
 
 	onPeerConnected              *events.Closure
 	onSolidMilestoneIndexChanged *events.Closure
@@ -37,6 +41,22 @@ func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 	warpSync = warpsync.New(config.NodeConfig.GetInt(config.CfgWarpSyncAdvancementRange))
 
+	if config.NodeConfig.GetBool(config.CfgWarpSyncBulkRequestsEnabled) {
+		batchSize := config.NodeConfig.GetInt(config.CfgWarpSyncBulkRequestsBatchSize)
+		if batchSize < 1 || batchSize > bulksync.MaxRequestedMilestones {
+			log.Fatalf("'%s' must be between 1 and %d", config.CfgWarpSyncBulkRequestsBatchSize, bulksync.MaxRequestedMilestones)
+		}
+
+		coneRequester = bulksync.NewRequester(bulksync.Options{
+			BatchSize:   batchSize,
+			MaxInFlight: config.NodeConfig.GetInt(config.CfgWarpSyncBulkRequestsParallelRequests),
+			Timeout:     time.Duration(config.NodeConfig.GetInt(config.CfgWarpSyncBulkRequestsTimeoutSeconds)) * time.Second,
+		}, gossip.RequestMilestoneCones, func(b *bulksync.Batch) {
+			// fall back to requesting the milestones and their cones transaction by transaction
+			gossip.BroadcastMilestoneRequests(int(b.End-b.Start+1), gossip.MemoizedRequestMissingMilestoneApprovees(), b.Start-1)
+		}, tangle.ContainsMilestone)
+	}
+
 	configureEvents()
 }
 
@@ -47,6 +67,33 @@ func run(plugin *node.Plugin) {
 		<-shutdownSignal
 		detachEvents()
 	}, shutdown.PriorityWarpSync)
+
+	if coneRequester == nil {
+		return
+	}
+
+	daemon.BackgroundWorker("WarpSync[ConeRequests]", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(func() {
+			coneRequester.Update(time.Now())
+		}, time.Second, shutdownSignal)
+	}, shutdown.PriorityWarpSync)
+}
+
+// requests the milestones of the given range after the given milestone, either as milestone cones
+// or via milestone requests. Returns the number of milestones requested.
+func requestMilestones(advRange int32, from milestone.Index) int {
+	requestMissingMilestoneApprovees := gossip.MemoizedRequestMissingMilestoneApprovees()
+	if coneRequester == nil {
+		return gossip.BroadcastMilestoneRequests(int(advRange), requestMissingMilestoneApprovees, from)
+	}
+
+	// the cones of the milestones we already have are requested transaction by transaction
+	for index := from + 1; index <= from+milestone.Index(advRange); index++ {
+		if tangle.ContainsMilestone(index) {
+			requestMissingMilestoneApprovees(index)
+		}
+	}
+	return coneRequester.Add(from+1, from+milestone.Index(advRange))
 }
 
 func configureEvents() {
@@ -72,8 +119,7 @@ func configureEvents() {
 		gossip.RequestQueue().Filter(func(r *rqueue.Request) bool {
 			return r.MilestoneIndex <= nextCheckpoint
 		})
-		requestMissingMilestoneApprovees := gossip.MemoizedRequestMissingMilestoneApprovees()
-		gossip.BroadcastMilestoneRequests(int(advRange), requestMissingMilestoneApprovees, oldCheckpoint)
+		requestMilestones(advRange, oldCheckpoint)
 	})
 
 	onTargetUpdated = events.NewClosure(func(newTarget milestone.Index) {
@@ -85,8 +131,7 @@ func configureEvents() {
 		gossip.RequestQueue().Filter(func(r *rqueue.Request) bool {
 			return r.MilestoneIndex <= nextCheckpoint
 		})
-		requestMissingMilestoneApprovees := gossip.MemoizedRequestMissingMilestoneApprovees()
-		msRequested := gossip.BroadcastMilestoneRequests(int(advRange), requestMissingMilestoneApprovees)
+		msRequested := requestMilestones(advRange, tangle.GetSolidMilestoneIndex())
 		// if the amount of requested milestones doesn't correspond to the range,
 		// it means we already had the milestones in the database, which suggests
 		// that we should manually kick start the milestone solidifier.
@@ -99,6 +144,9 @@ func configureEvents() {
 	onDone = events.NewClosure(func(deltaSynced int, took time.Duration) {
 		log.Infof("Synchronized %d milestones in %v", deltaSynced, took)
 		gossip.RequestQueue().Filter(nil)
+		if coneRequester != nil {
+			coneRequester.Clear()
+		}
 	})
 }
 
@@ -40,20 +40,20 @@ func webAPIRoute() {
 
 		implementation, apiCallExists := implementedAPIcalls[cmd]
 
-		whitelisted := false
-		remoteHost, _, _ := net.SplitHostPort(c.Request.RemoteAddr)
-		remoteAddress := net.ParseIP(remoteHost)
-		for _, whitelistedNet := range whitelistedNetworks {
-			if whitelistedNet.Contains(remoteAddress) {
-				whitelisted = true
-				break
+		// requests from whitelisted addresses may call all commands without a JWT
+		if !isWhitelisted(c) {
+			validJWT := hasValidJWT(c)
+			if isJWTProtected(cmd) && !validJWT {
+				e := ErrorReturn{
+					Error: fmt.Sprintf("Command [%v] requires a valid JWT", originCommand),
+				}
+				c.JSON(http.StatusUnauthorized, e)
+				return
 			}
-		}
 
-		if !whitelisted {
 			// Check if command is permitted. If it's not permited and the request does not come from localhost, deny it.
 			_, permited := permitedEndpoints[cmd]
-			if apiCallExists && !permited {
+			if apiCallExists && !permited && !validJWT {
 				e := ErrorReturn{
 					Error: fmt.Sprintf("Command [%v] is protected", originCommand),
 				}
@@ -62,6 +62,11 @@ func webAPIRoute() {
 			}
 		}
 
+		if isCommandRateLimited(c, cmd) {
+			abortTooManyRequests(c)
+			return
+		}
+
 		if !apiCallExists {
 			e := ErrorReturn{
 				Error: fmt.Sprintf("Command [%v] is unknown", originCommand),
@@ -70,10 +75,21 @@ func webAPIRoute() {
 			return
 		}
 
-		implementation(&request, c, serverShutdownSignal)
+		implementation(&request, c, handlerAbortSignal)
 	})
 }
 
+// isWhitelisted returns whether the request comes from a whitelisted address.
+func isWhitelisted(c *gin.Context) bool {
+	remoteAddress := net.ParseIP(remoteIP(c))
+	for _, whitelistedNet := range whitelistedNetworks {
+		if whitelistedNet.Contains(remoteAddress) {
+			return true
+		}
+	}
+	return false
+}
+
 // health check
 func restAPIRoute() {
 
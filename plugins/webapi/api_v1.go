@@ -0,0 +1,180 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+const (
+	apiV1Route = "/api/v1"
+
+	// machine-readable error codes of the REST API
+	restErrCodeBadRequest         = "bad_request"
+	restErrCodeUnauthorized       = "unauthorized"
+	restErrCodeForbidden          = "forbidden"
+	restErrCodeNotFound           = "not_found"
+	restErrCodeTooManyRequests    = "too_many_requests"
+	restErrCodeServiceUnavailable = "service_unavailable"
+	restErrCodeInternalError      = "internal_error"
+	restErrCodeBadGateway         = "bad_gateway"
+)
+
+// abortWithRESTError aborts the request and writes a machine-readable error.
+func abortWithRESTError(c *gin.Context, httpStatus int, code string, message string) {
+	c.AbortWithStatusJSON(httpStatus, RESTErrorReturn{Error: RESTError{Code: code, Message: message}})
+}
+
+// restPermitted only lets requests pass which come from a whitelisted address, carry a valid JWT
+// or which are allowed to call the equivalent command of the command API remotely.
+func restPermitted(command string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isWhitelisted(c) {
+			validJWT := hasValidJWT(c)
+			if isJWTProtected(command) && !validJWT {
+				abortWithRESTError(c, http.StatusUnauthorized, restErrCodeUnauthorized, "route requires a valid JWT")
+				return
+			}
+
+			if _, permitted := permitedEndpoints[command]; !permitted && !validJWT {
+				abortWithRESTError(c, http.StatusForbidden, restErrCodeForbidden, "route is protected")
+				return
+			}
+		}
+
+		if isCommandRateLimited(c, command) {
+			c.Header("Retry-After", "1")
+			abortWithRESTError(c, http.StatusTooManyRequests, restErrCodeTooManyRequests, "too many requests")
+			return
+		}
+		c.Next()
+	}
+}
+
+// REST API v1 with one route per resource.
+// The routes share the remote access permissions of the equivalent commands.
+func restAPIV1Route() {
+	v1 := api.Group(apiV1Route)
+
+	// GET /api/v1/info
+	v1.GET("/info", restPermitted("getnodeinfo"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, nodeInfo())
+	})
+
+	// GET /api/v1/transactions/:hash
+	v1.GET("/transactions/:hash", restPermitted("gettrytes"), getTransactionV1)
+
+	// POST /api/v1/transactions
+	v1.POST("/transactions", restPermitted("broadcasttransactions"), broadcastTransactionsV1)
+
+	// GET /api/v1/addresses/:address/balance
+	v1.GET("/addresses/:address/balance", restPermitted("getbalances"), getAddressBalanceV1)
+
+	// GET, POST /api/v1/neighbors and DELETE /api/v1/neighbors/:identity
+	neighborsV1Routes(v1)
+
+	// GET /api/v1/spentaddresses/export
+	v1.GET("/spentaddresses/export", restPermitted("exportspentaddresses"), exportSpentAddressesV1)
+
+	// POST /api/v1/spentaddresses/import
+	v1.POST("/spentaddresses/import", restPermitted("importspentaddresses"), importSpentAddressesV1)
+}
+
+func getTransactionV1(c *gin.Context) {
+	hash := c.Param("hash")
+	if !guards.IsTransactionHash(hash) {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, "invalid transaction hash: "+hash)
+		return
+	}
+
+	cachedTx := tangle.GetCachedTransactionOrNil(hornet.HashFromHashTrytes(hash)) // tx +1
+	if cachedTx == nil {
+		abortWithRESTError(c, http.StatusNotFound, restErrCodeNotFound, "transaction not found: "+hash)
+		return
+	}
+	defer cachedTx.Release(true) // tx -1
+
+	trytes, err := transaction.TransactionToTrytes(cachedTx.GetTransaction().Tx)
+	if err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
+	}
+
+	metadata := cachedTx.GetMetadata()
+	confirmed, confirmationIndex := metadata.GetConfirmed()
+
+	c.JSON(http.StatusOK, TransactionV1Return{
+		Hash:                       hash,
+		Trytes:                     trytes,
+		Solid:                      metadata.IsSolid(),
+		Confirmed:                  confirmed,
+		ConfirmationMilestoneIndex: confirmationIndex,
+		Conflicting:                metadata.IsConflicting(),
+	})
+}
+
+func broadcastTransactionsV1(c *gin.Context) {
+	request := &BroadcastTransactionsV1{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
+	}
+
+	if len(request.Trytes) == 0 {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, "no trytes provided")
+		return
+	}
+
+	for _, trytes := range request.Trytes {
+		if err := trinary.ValidTrytes(trytes); err != nil {
+			abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+			return
+		}
+	}
+
+	for _, trytes := range request.Trytes {
+		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+			abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+			return
+		}
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func getAddressBalanceV1(c *gin.Context) {
+	addr := c.Param("address")
+	if err := address.ValidAddress(addr); err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, "invalid address: "+addr)
+		return
+	}
+
+	tangle.ReadLockLedger()
+	defer tangle.ReadUnlockLedger()
+
+	if !tangle.IsNodeSynced() {
+		abortWithRESTError(c, http.StatusServiceUnavailable, restErrCodeServiceUnavailable, ErrNodeNotSync.Error())
+		return
+	}
+
+	balance, index, err := tangle.GetBalanceForAddressWithoutLocking(hornet.HashFromAddressTrytes(addr))
+	if err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, "ledger state invalid")
+		return
+	}
+
+	c.JSON(http.StatusOK, AddressBalanceV1Return{
+		Address:        addr[:81],
+		Balance:        balance,
+		MilestoneIndex: index,
+	})
+}
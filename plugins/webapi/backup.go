@@ -0,0 +1,35 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/plugins/database"
+)
+
+func init() {
+	addEndpoint("createDatabaseBackup", createDatabaseBackup, implementedAPIcalls)
+	addEndpoint("getDatabaseBackupStatus", getDatabaseBackupStatus, implementedAPIcalls)
+}
+
+func createDatabaseBackup(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	if err := database.StartBackup(); err != nil {
+		e.Error = err.Error()
+		switch err {
+		case database.ErrBackupRunning:
+			c.JSON(http.StatusConflict, e)
+		default:
+			c.JSON(http.StatusInternalServerError, e)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, CreateDatabaseBackupReturn{Status: database.GetBackupStatus()})
+}
+
+func getDatabaseBackupStatus(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, GetDatabaseBackupStatusReturn{Status: database.GetBackupStatus()})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/iotaledger/iota.go/address"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 )
@@ -34,6 +35,13 @@ func getBalances(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
+	maxAddresses := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+	if len(query.Addresses) > maxAddresses {
+		e.Error = "Too many addresses. Max. allowed: " + strconv.Itoa(maxAddresses)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
 	for _, addr := range query.Addresses {
 		// Check if address is valid
 		if err := address.ValidAddress(addr); err != nil {
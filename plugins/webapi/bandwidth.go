@@ -0,0 +1,45 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/plugins/peering"
+)
+
+func init() {
+	addEndpoint("getBandwidthUsage", getBandwidthUsage, implementedAPIcalls)
+}
+
+func getBandwidthUsage(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	usage := peering.Manager().Bandwidth().Usage()
+
+	result := GetBandwidthUsageReturn{
+		ReceivedBytes:       metrics.SharedServerMetrics.ReceivedBytes.Load(),
+		SentBytes:           metrics.SharedServerMetrics.SentBytes.Load(),
+		PeriodStart:         usage.PeriodStart.Unix(),
+		PeriodEnd:           usage.PeriodEnd.Unix(),
+		PeriodReceivedBytes: usage.Received,
+		PeriodSentBytes:     usage.Sent,
+		MonthlyBudgetBytes:  usage.Budget,
+		UsedPercentage:      usage.Ratio() * 100,
+		ThrottledMessages:   usage.Throttled,
+		Neighbors:           make([]*NeighborBandwidthUsage, 0),
+	}
+
+	for _, info := range peering.Manager().PeerInfos() {
+		if !info.Connected {
+			continue
+		}
+		result.Neighbors = append(result.Neighbors, &NeighborBandwidthUsage{
+			Address:       info.Address,
+			Alias:         info.Alias,
+			ReceivedBytes: info.NumberOfReceivedBytes,
+			SentBytes:     info.NumberOfSentBytes,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,37 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/plugins/database"
+)
+
+func init() {
+	addEndpoint("compactDatabase", compactDatabase, implementedAPIcalls)
+	addEndpoint("getDatabaseCompactionStatus", getDatabaseCompactionStatus, implementedAPIcalls)
+}
+
+func compactDatabase(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	if err := database.StartCompaction(); err != nil {
+		e.Error = err.Error()
+		switch err {
+		case database.ErrCompactionRunning:
+			c.JSON(http.StatusConflict, e)
+		case database.ErrCompactionNotSupported:
+			c.JSON(http.StatusBadRequest, e)
+		default:
+			c.JSON(http.StatusInternalServerError, e)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, CompactDatabaseReturn{Status: database.GetCompactionStatus()})
+}
+
+func getDatabaseCompactionStatus(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, GetDatabaseCompactionStatusReturn{Status: database.GetCompactionStatus()})
+}
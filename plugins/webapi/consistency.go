@@ -1,15 +1,52 @@
 package webapi
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
 )
 
 func init() {
 	addEndpoint("checkConsistency", checkConsistency, implementedAPIcalls)
 }
 
+// checkConsistency checks whether the given tails are solid, not conflicting and not below max depth,
+// which means that they can be approved by new transactions, e.g. promotions.
 func checkConsistency(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &CheckConsistency{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if len(query.Tails) == 0 {
+		e.Error = "no tails provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	for _, tail := range query.Tails {
+		cachedTxMeta, err := getSolidTailMetadata(tail) // meta +1
+		if err != nil {
+			c.JSON(http.StatusOK, CheckConsistencyReturn{State: false, Info: fmt.Sprintf("tail %s: %s", tail, err)})
+			return
+		}
+
+		info := tipInfo(cachedTxMeta) // meta -1
+		if info.Conflicting {
+			c.JSON(http.StatusOK, CheckConsistencyReturn{State: false, Info: fmt.Sprintf("tail %s is conflicting", tail)})
+			return
+		}
+		if info.ShouldReattach {
+			c.JSON(http.StatusOK, CheckConsistencyReturn{State: false, Info: fmt.Sprintf("tail %s is below max depth", tail)})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, CheckConsistencyReturn{State: true})
 }
@@ -23,10 +23,17 @@ func init() {
 }
 
 func getNodeInfo(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, nodeInfo())
+}
+
+// nodeInfo collects the current information about the node.
+func nodeInfo() *GetNodeInfoReturn {
 	// Basic info data
-	result := GetNodeInfoReturn{
-		AppName:    cli.AppName,
-		AppVersion: cli.AppVersion,
+	result := &GetNodeInfoReturn{
+		AppName:       cli.AppName,
+		AppVersion:    cli.AppVersion,
+		LatestVersion: cli.LatestVersion(),
+		IsOutdated:    cli.IsOutdated(),
 	}
 
 	// Node Alias
@@ -91,17 +98,17 @@ func getNodeInfo(_ interface{}, c *gin.Context, _ <-chan struct{}) {
 	// Coo addr
 	result.CoordinatorAddress = config.NodeConfig.GetString(config.CfgCoordinatorAddress)
 
-	// Return node info
-	c.JSON(http.StatusOK, result)
+	return result
 }
 
 func getNodeAPIConfiguration(_ interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	result := GetNodeAPIConfigurationReturn{
-		MaxFindTransactions: config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions),
-		MaxRequestsList:     config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList),
-		MaxGetTrytes:        config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxGetTrytes),
-		MaxBodyLength:       config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxBodyLengthBytes),
+		MaxFindTransactions:       config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions),
+		MaxFindTransactionsStream: config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactionsStream),
+		MaxRequestsList:           config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList),
+		MaxGetTrytes:              config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxGetTrytes),
+		MaxBodyLength:             config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxBodyLengthBytes),
 	}
 
 	// Milestone start index
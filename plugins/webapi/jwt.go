@@ -0,0 +1,112 @@
+package webapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/basicauth"
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/jwt"
+	"github.com/gohornet/hornet/plugins/cli"
+)
+
+const (
+	authRoute = "auth"
+
+	bearerAuthPrefix = "Bearer "
+)
+
+var (
+	jwtAuth              *jwt.JWTAuth
+	jwtProtectedCommands = make(map[string]struct{})
+	jwtLoginUsername     string
+	jwtLoginPasswordHash string
+	jwtLoginPasswordSalt string
+)
+
+// configureJWTAuth sets up the JWT auth and the login route if it is enabled.
+// The login uses the credentials of the HTTP basic auth.
+func configureJWTAuth() {
+	if !config.NodeConfig.GetBool(config.CfgWebAPIJWTAuthEnabled) {
+		return
+	}
+
+	secret := config.NodeConfig.GetString(config.CfgWebAPIJWTAuthSecret)
+	if len(secret) < 32 {
+		log.Fatalf("'%s' must be at least 32 characters long if JWT auth is enabled", config.CfgWebAPIJWTAuthSecret)
+	}
+
+	jwtLoginUsername = config.NodeConfig.GetString(config.CfgWebAPIBasicAuthUsername)
+	jwtLoginPasswordHash = config.NodeConfig.GetString(config.CfgWebAPIBasicAuthPasswordHash)
+	jwtLoginPasswordSalt = config.NodeConfig.GetString(config.CfgWebAPIBasicAuthPasswordSalt)
+
+	if len(jwtLoginUsername) == 0 {
+		log.Fatalf("'%s' must not be empty if JWT auth is enabled", config.CfgWebAPIBasicAuthUsername)
+	}
+
+	if len(jwtLoginPasswordHash) != 64 {
+		log.Fatalf("'%s' must be 64 (sha256 hash) in length if JWT auth is enabled", config.CfgWebAPIBasicAuthPasswordHash)
+	}
+
+	for _, command := range config.NodeConfig.GetStringSlice(config.CfgWebAPIJWTAuthProtectedCommands) {
+		jwtProtectedCommands[strings.ToLower(command)] = struct{}{}
+	}
+
+	sessionTimeout := time.Duration(config.NodeConfig.GetInt(config.CfgWebAPIJWTAuthSessionTimeoutMinutes)) * time.Minute
+	jwtAuth = jwt.New(cli.AppName, sessionTimeout, secret)
+
+	// POST /auth
+	api.POST(authRoute, func(c *gin.Context) {
+		e := ErrorReturn{}
+		request := &Login{}
+
+		if err := c.ShouldBindJSON(request); err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		if request.Username != jwtLoginUsername || !basicauth.VerifyPassword(request.Password, jwtLoginPasswordSalt, jwtLoginPasswordHash) {
+			e.Error = "Invalid username or password"
+			c.JSON(http.StatusUnauthorized, e)
+			return
+		}
+
+		token, err := jwtAuth.IssueJWT(request.Username)
+		if err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+
+		c.JSON(http.StatusOK, LoginReturn{JWT: token})
+	})
+}
+
+// hasValidJWT returns whether the request carries a valid JWT in its authorization header.
+func hasValidJWT(c *gin.Context) bool {
+	if jwtAuth == nil {
+		return false
+	}
+
+	authVal := c.Request.Header.Get("Authorization")
+	if !strings.HasPrefix(authVal, bearerAuthPrefix) {
+		return false
+	}
+
+	_, err := jwtAuth.VerifyJWT(strings.TrimPrefix(authVal, bearerAuthPrefix))
+	return err == nil
+}
+
+// isJWTProtected returns whether the given (lower cased) command can only be called with a valid JWT by non whitelisted addresses.
+func isJWTProtected(command string) bool {
+	if jwtAuth == nil {
+		return false
+	}
+
+	_, protected := jwtProtectedCommands[command]
+	return protected
+}
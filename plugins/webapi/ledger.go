@@ -45,12 +45,24 @@ func getLedgerDiff(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
 		return
 	}
 
-	diffTrytes := make(map[trinary.Trytes]int64)
-	for address, balance := range diff {
-		diffTrytes[hornet.Hash(address).Trytes()] = balance
+	addresses := make([]string, 0, len(diff))
+	for address := range diff {
+		addresses = append(addresses, address)
 	}
 
-	c.JSON(http.StatusOK, GetLedgerDiffReturn{Diff: diffTrytes, MilestoneIndex: query.MilestoneIndex})
+	addresses, partialResult, err := pageAddresses(addresses, query.ContinuationToken, resultLimit("getLedgerDiff", 0), requestedIndex)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	diffTrytes := make(map[trinary.Trytes]int64, len(addresses))
+	for _, address := range addresses {
+		diffTrytes[hornet.Hash(address).Trytes()] = diff[address]
+	}
+
+	c.JSON(http.StatusOK, GetLedgerDiffReturn{Diff: diffTrytes, MilestoneIndex: query.MilestoneIndex, PartialResult: partialResult})
 }
 
 func getLedgerDiffExt(i interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -212,17 +224,41 @@ func getLedgerState(i interface{}, c *gin.Context, abortSignal <-chan struct{})
 		return
 	}
 
-	balances, index, err := tangle.GetLedgerStateForMilestone(query.TargetIndex, abortSignal)
+	targetIndex := query.TargetIndex
+	if targetIndex == 0 {
+		// the following pages of the current ledger state are fetched at the index of the first page
+		tokenIndex, _, err := parseLedgerToken(query.ContinuationToken)
+		if err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+		targetIndex = tokenIndex
+	}
+
+	balances, index, err := tangle.GetLedgerStateForMilestone(targetIndex, abortSignal)
 	if err != nil {
 		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
 		c.JSON(http.StatusInternalServerError, e)
 		return
 	}
 
-	balancesTrytes := make(map[trinary.Trytes]uint64)
-	for address, balance := range balances {
-		balancesTrytes[hornet.Hash(address).Trytes()] = balance
+	addresses := make([]string, 0, len(balances))
+	for address := range balances {
+		addresses = append(addresses, address)
+	}
+
+	addresses, partialResult, err := pageAddresses(addresses, query.ContinuationToken, resultLimit("getLedgerState", 0), index)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	balancesTrytes := make(map[trinary.Trytes]uint64, len(addresses))
+	for _, address := range addresses {
+		balancesTrytes[hornet.Hash(address).Trytes()] = balances[address]
 	}
 
-	c.JSON(http.StatusOK, GetLedgerStateReturn{Balances: balancesTrytes, MilestoneIndex: index})
+	c.JSON(http.StatusOK, GetLedgerStateReturn{Balances: balancesTrytes, MilestoneIndex: index, PartialResult: partialResult})
 }
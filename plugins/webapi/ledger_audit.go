@@ -0,0 +1,110 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("auditLedger", auditLedger, implementedAPIcalls)
+}
+
+func auditLedger(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &AuditLedger{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	maxAddresses := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+	if len(query.Addresses) > maxAddresses {
+		e.Error = "Too many addresses. Max. allowed: " + strconv.Itoa(maxAddresses)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	addresses := make(hornet.Hashes, 0, len(query.Addresses))
+	for _, addr := range query.Addresses {
+		// Check if address is valid
+		if err := address.ValidAddress(addr); err != nil {
+			e.Error = fmt.Sprintf("%v: %v", err, addr)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+		addresses = append(addresses, hornet.HashFromAddressTrytes(addr))
+	}
+
+	audit, err := tangle.AuditLedger(addresses, abortSignal)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	result := AuditLedgerReturn{
+		Valid:                     audit.Valid(),
+		SnapshotIndex:             audit.SnapshotIndex,
+		LedgerIndex:               audit.LedgerIndex,
+		TotalSupply:               consts.TotalSupply,
+		SnapshotTotal:             audit.SnapshotTotal,
+		LedgerTotal:               audit.LedgerTotal,
+		TotalSupplyValid:          audit.TotalSupplyValid(),
+		AddressesChecked:          audit.AddressesChecked,
+		InvalidDiffs:              make([]milestone.Index, 0, len(audit.InvalidDiffs)),
+		Mismatches:                make([]*LedgerAuditMismatch, 0, len(audit.Mismatches)),
+		ConflictingBundles:        make(map[milestone.Index]int),
+		UnknownConflictingBundles: make([]milestone.Index, 0),
+	}
+
+	result.InvalidDiffs = append(result.InvalidDiffs, audit.InvalidDiffs...)
+	for _, mismatch := range audit.Mismatches {
+		result.Mismatches = append(result.Mismatches, &LedgerAuditMismatch{
+			Address:           mismatch.Address.Trytes(),
+			Balance:           mismatch.Balance,
+			RecomputedBalance: mismatch.RecomputedBalance,
+		})
+	}
+
+	tangle.ReadLockLedger()
+	for msIndex := audit.SnapshotIndex + 1; msIndex <= audit.LedgerIndex; msIndex++ {
+		var count int
+		var exists bool
+		count, exists, err = tangle.GetConflictingBundlesCountWithoutLocking(msIndex)
+		if err != nil {
+			break
+		}
+		if !exists {
+			// the milestone was confirmed before the count was stored
+			result.UnknownConflictingBundles = append(result.UnknownConflictingBundles, msIndex)
+			continue
+		}
+		if count != 0 {
+			result.ConflictingBundles[msIndex] = count
+			result.TotalConflictingBundles += count
+		}
+	}
+	tangle.ReadUnlockLedger()
+
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
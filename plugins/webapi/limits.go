@@ -0,0 +1,98 @@
+package webapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+var (
+	// ErrInvalidContinuationToken is returned when the continuation token of a request can't be parsed.
+	ErrInvalidContinuationToken = errors.New("invalid continuation token")
+	// ErrContinuationTokenIndexChanged is returned when the continuation token belongs to the ledger at another milestone index.
+	ErrContinuationTokenIndexChanged = errors.New("the continuation token belongs to another ledger index")
+)
+
+// resultLimit returns the maximum number of results of the given command.
+// The limit can be configured per command in httpAPI.limits.results, otherwise the given default is used.
+// A limit of 0 means that the results are not limited.
+func resultLimit(command string, defaultLimit int) int {
+	// the keys of the config maps are lower cased
+	for cmd, limit := range config.NodeConfig.GetStringMapString(config.CfgWebAPILimitsResults) {
+		if !strings.EqualFold(cmd, command) {
+			continue
+		}
+
+		maxResults, err := strconv.Atoi(limit)
+		if err != nil || maxResults < 0 {
+			log.Warnf("Invalid result limit for command %s: %s", command, limit)
+			return defaultLimit
+		}
+		return maxResults
+	}
+
+	return defaultLimit
+}
+
+// parseLedgerToken parses the continuation token of the ledger commands, which is "<ledger index>.<address>".
+// An empty token returns the index 0 and no address.
+func parseLedgerToken(continuationToken string) (milestone.Index, trinary.Hash, error) {
+	if continuationToken == "" {
+		return 0, "", nil
+	}
+
+	parts := strings.Split(continuationToken, ".")
+	if len(parts) != 2 {
+		return 0, "", ErrInvalidContinuationToken
+	}
+
+	index, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil || index == 0 {
+		return 0, "", ErrInvalidContinuationToken
+	}
+
+	if len(parts[1]) != 81 || trinary.ValidTrytes(parts[1]) != nil {
+		return 0, "", ErrInvalidContinuationToken
+	}
+
+	return milestone.Index(index), parts[1], nil
+}
+
+// pageAddresses sorts the given addresses of the ledger at the given index and returns the page which follows
+// the address of the continuation token. The token is rejected if it belongs to the ledger at another index.
+// If more addresses are left, the result is marked as truncated and the token to fetch the next page is set.
+func pageAddresses(addresses []string, continuationToken string, limit int, index milestone.Index) ([]string, PartialResult, error) {
+	sort.Strings(addresses)
+
+	tokenIndex, lastAddress, err := parseLedgerToken(continuationToken)
+	if err != nil {
+		return nil, PartialResult{}, err
+	}
+
+	if continuationToken != "" {
+		if tokenIndex != index {
+			return nil, PartialResult{}, errors.Wrapf(ErrContinuationTokenIndexChanged, "token of index %d, ledger index %d", tokenIndex, index)
+		}
+
+		lastAddressBytes := string(hornet.HashFromAddressTrytes(lastAddress))
+		addresses = addresses[sort.Search(len(addresses), func(i int) bool { return addresses[i] > lastAddressBytes }):]
+	}
+
+	if limit == 0 || len(addresses) <= limit {
+		return addresses, PartialResult{}, nil
+	}
+
+	addresses = addresses[:limit]
+	return addresses, PartialResult{
+		Truncated:         true,
+		ContinuationToken: fmt.Sprintf("%d.%s", index, hornet.Hash(addresses[limit-1]).Trytes()),
+	}, nil
+}
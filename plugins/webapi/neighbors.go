@@ -1,6 +1,7 @@
 package webapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,7 +10,8 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/gohornet/hornet/pkg/config"
-	"github.com/gohornet/hornet/plugins/peering"
+	"github.com/gohornet/hornet/pkg/peering"
+	peeringplugin "github.com/gohornet/hornet/plugins/peering"
 )
 
 func init() {
@@ -18,6 +20,18 @@ func init() {
 	addEndpoint("getNeighbors", getNeighbors, implementedAPIcalls)
 }
 
+// normalizeNeighborURI strips the "tcp://" scheme of a neighbor URI.
+// URIs with other schemes are not supported.
+func normalizeNeighborURI(uri string) (string, error) {
+	if strings.HasPrefix(uri, "tcp://") {
+		return uri[6:], nil
+	}
+	if strings.Contains(uri, "://") {
+		return "", fmt.Errorf("unsupported neighbor URI scheme: %s", uri)
+	}
+	return uri, nil
+}
+
 func addNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	// Check if HORNET style addNeighbors call was made
@@ -31,9 +45,6 @@ func addNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	e := ErrorReturn{}
 	query := &AddNeighbors{}
-	addedPeers := 0
-
-	preferIPv6 := config.NodeConfig.GetBool(config.CfgNetPreferIPv6)
 
 	if err := mapstructure.Decode(i, query); err != nil {
 		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
@@ -41,180 +52,151 @@ func addNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
-	added := false
+	preferIPv6 := config.NodeConfig.GetBool(config.CfgNetPreferIPv6)
 
-	var configPeers []config.PeerConfig
-	if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
-		log.Warn(err)
+	neighbors := make([]Neighbor, 0, len(query.Uris))
+	for _, uri := range query.Uris {
+		neighbors = append(neighbors, Neighbor{Identity: uri, Alias: uri, PreferIPv6: preferIPv6})
 	}
 
-	for _, uri := range query.Uris {
+	c.JSON(http.StatusOK, AddNeighborsResponse{AddedNeighbors: addStaticNeighbors(neighbors)})
+}
 
-		if strings.Contains(uri, "tcp://") {
-			uri = uri[6:]
-		} else if strings.Contains(uri, "://") {
-			continue
-		}
+func addNeighborsWithAlias(s *AddNeighborsHornet, c *gin.Context) {
+	c.JSON(http.StatusOK, AddNeighborsResponse{AddedNeighbors: addStaticNeighbors(s.Neighbors)})
+}
 
-		contains := false
-		for _, cn := range configPeers {
-			if cn.ID == uri {
-				contains = true
-				break
-			}
+// addStaticNeighbors adds the given neighbors and returns the amount of neighbors which were added.
+func addStaticNeighbors(neighbors []Neighbor) int {
+	addedNeighbors := 0
+
+	for _, neighbor := range neighbors {
+		identity, err := normalizeNeighborURI(neighbor.Identity)
+		if err != nil {
+			log.Warn(err)
+			continue
 		}
 
-		if !contains {
-			configPeers = append(configPeers, config.PeerConfig{
-				ID:         uri,
-				Alias:      uri,
-				PreferIPv6: preferIPv6,
-			})
-			added = true
+		// strip the scheme of legacy style aliases as well
+		alias := neighbor.Alias
+		if alias == neighbor.Identity {
+			alias = identity
 		}
 
-		if err := peering.Manager().Add(uri, preferIPv6, uri); err != nil {
-			log.Warnf("can't add peer %s, Error: %s", uri, err)
+		if err := peeringplugin.AddStaticPeer(identity, alias, neighbor.PreferIPv6); err != nil {
+			log.Warnf("can't add peer %s, Error: %s", identity, err)
 			continue
 		}
-		addedPeers++
+		addedNeighbors++
 	}
 
-	if added {
-		config.DenyPeeringConfigHotReload()
-		config.PeeringConfig.Set(config.CfgPeers, configPeers)
-		config.PeeringConfig.WriteConfig()
-		config.AllowPeeringConfigHotReload()
-	}
-
-	c.JSON(http.StatusOK, AddNeighborsResponse{AddedNeighbors: addedPeers})
+	return addedNeighbors
 }
 
-func addNeighborsWithAlias(s *AddNeighborsHornet, c *gin.Context) {
-	addedPeers := 0
+func removeNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &RemoveNeighbors{}
 
-	added := false
+	removedNeighbors := 0
 
-	var configPeers []config.PeerConfig
-	if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
-		log.Warn(err)
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
 	}
 
-	for _, peer := range s.Neighbors {
-
-		if strings.Contains(peer.Identity, "tcp://") {
-			peer.Identity = peer.Identity[6:]
-		} else if strings.Contains(peer.Identity, "://") {
+	for _, uri := range query.Uris {
+		identity, err := normalizeNeighborURI(uri)
+		if err != nil {
+			log.Warn(err)
 			continue
 		}
 
-		contains := false
-		for _, cn := range configPeers {
-			if cn.ID == peer.Identity {
-				contains = true
-				break
-			}
-		}
-
-		if !contains {
-			configPeers = append(configPeers, config.PeerConfig{
-				ID:         peer.Identity,
-				Alias:      peer.Alias,
-				PreferIPv6: peer.PreferIPv6,
-			})
-			added = true
+		removed, err := peeringplugin.RemoveStaticPeer(identity)
+		if err != nil {
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
 		}
 
-		if err := peering.Manager().Add(peer.Identity, peer.PreferIPv6, peer.Alias); err != nil {
-			log.Warnf("Can't add peer %s, Error: %s", peer.Identity, err)
-			continue
+		if removed {
+			removedNeighbors++
 		}
-		addedPeers++
 	}
 
-	if added {
-		config.DenyPeeringConfigHotReload()
-		config.PeeringConfig.Set(config.CfgPeers, configPeers)
-		config.PeeringConfig.WriteConfig()
-		config.AllowPeeringConfigHotReload()
-	}
+	c.JSON(http.StatusOK, RemoveNeighborsReturn{RemovedNeighbors: uint(removedNeighbors)})
+}
 
-	c.JSON(http.StatusOK, AddNeighborsResponse{AddedNeighbors: addedPeers})
+func getNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, GetNeighborsReturn{Neighbors: peeringplugin.Manager().PeerInfos()})
 }
 
-func removeNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
-	e := ErrorReturn{}
-	query := &RemoveNeighbors{}
+// REST API v1 routes for the static neighbors.
+func neighborsV1Routes(v1 *gin.RouterGroup) {
 
-	removedNeighbors := 0
+	// GET /api/v1/neighbors
+	v1.GET("/neighbors", restPermitted("getneighbors"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, NeighborsV1Return{
+			Neighbors:       peeringplugin.Manager().PeerInfos(),
+			StaticNeighbors: peeringplugin.StaticPeers(),
+		})
+	})
 
-	if err := mapstructure.Decode(i, query); err != nil {
-		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
-		c.JSON(http.StatusInternalServerError, e)
+	// POST /api/v1/neighbors
+	v1.POST("/neighbors", restPermitted("addneighbors"), addNeighborV1)
+
+	// DELETE /api/v1/neighbors/:identity
+	v1.DELETE("/neighbors/:identity", restPermitted("removeneighbors"), removeNeighborV1)
+}
+
+func addNeighborV1(c *gin.Context) {
+	request := &NeighborV1{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
 		return
 	}
 
-	removed := false
+	identity, err := normalizeNeighborURI(request.Identity)
+	if err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
+	}
 
-	var configNeighbors []config.PeerConfig
-	if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configNeighbors); err != nil {
-		log.Warn(err)
+	if request.PreferIPv6 == nil {
+		preferIPv6 := config.NodeConfig.GetBool(config.CfgNetPreferIPv6)
+		request.PreferIPv6 = &preferIPv6
 	}
 
-	peers := peering.Manager().PeerInfos()
-	for _, uri := range query.Uris {
-		if strings.Contains(uri, "tcp://") {
-			uri = uri[6:]
-		}
-		for _, p := range peers {
-
-			for i, cn := range configNeighbors {
-				if strings.EqualFold(cn.ID, uri) {
-					removed = true
-
-					// Delete item
-					configNeighbors[i] = configNeighbors[len(configNeighbors)-1]
-					configNeighbors = configNeighbors[:len(configNeighbors)-1]
-					break
-				}
-			}
-
-			// Remove connected neighbor
-			if p.Peer != nil {
-				if strings.EqualFold(p.Peer.ID, uri) || strings.EqualFold(p.DomainWithPort, uri) {
-					err := peering.Manager().Remove(uri)
-					if err != nil {
-						e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
-						c.JSON(http.StatusInternalServerError, e)
-						return
-					}
-					removedNeighbors++
-				}
-			} else {
-				// Remove unconnected neighbor
-				if strings.EqualFold(p.Address, uri) {
-					err := peering.Manager().Remove(uri)
-					if err != nil {
-						e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
-						c.JSON(http.StatusInternalServerError, e)
-						return
-					}
-					removedNeighbors++
-				}
-			}
+	if err := peeringplugin.AddStaticPeer(identity, request.Alias, *request.PreferIPv6); err != nil {
+		if errors.Is(err, peering.ErrPeerAlreadyConnected) || errors.Is(err, peering.ErrPeerAlreadyInReconnect) {
+			// the neighbor was persisted nevertheless
+			c.Status(http.StatusOK)
+			return
 		}
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func removeNeighborV1(c *gin.Context) {
+	identity, err := normalizeNeighborURI(c.Param("identity"))
+	if err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
 	}
 
-	if removed {
-		config.DenyPeeringConfigHotReload()
-		config.PeeringConfig.Set(config.CfgPeers, configNeighbors)
-		config.PeeringConfig.WriteConfig()
-		config.AllowPeeringConfigHotReload()
+	removed, err := peeringplugin.RemoveStaticPeer(identity)
+	if err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
 	}
 
-	c.JSON(http.StatusOK, RemoveNeighborsReturn{RemovedNeighbors: uint(removedNeighbors)})
-}
+	if !removed {
+		abortWithRESTError(c, http.StatusNotFound, restErrCodeNotFound, "neighbor not found: "+identity)
+		return
+	}
 
-func getNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
-	c.JSON(http.StatusOK, GetNeighborsReturn{Neighbors: peering.Manager().PeerInfos()})
+	c.Status(http.StatusNoContent)
 }
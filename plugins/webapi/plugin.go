@@ -2,6 +2,7 @@ package webapi
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"net"
 	"net/http"
@@ -14,12 +15,15 @@ import (
 	cnet "github.com/projectcalico/libcalico-go/lib/net"
 
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ipfilter"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/utils"
 )
 
 // PLUGIN WebAPI
@@ -27,19 +31,44 @@ var (
 	PLUGIN = node.NewPlugin("WebAPI", node.Enabled, configure, run)
 	log    *logger.Logger
 
-	server               *http.Server
-	permitedEndpoints    = make(map[string]string)
-	whitelistedNetworks  []net.IPNet
-	implementedAPIcalls  = make(map[string]apiEndpoint)
-	features             []string
-	api                  *gin.Engine
-	webAPIBase           = ""
-	serverShutdownSignal <-chan struct{}
+	server              *http.Server
+	ipFilter            *ipfilter.Filter
+	tlsConfig           *tls.Config
+	permitedEndpoints   = make(map[string]string)
+	whitelistedNetworks []net.IPNet
+	implementedAPIcalls = make(map[string]apiEndpoint)
+	features            []string
+	api                 *gin.Engine
+	webAPIBase          = ""
+
+	// closed once the drain timeout on shutdown expired, aborts the long running API calls
+	handlerAbortSignal = make(chan struct{})
 )
 
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 
+	if config.NodeConfig.GetBool(config.CfgWebAPITLSEnabled) {
+		var err error
+		var certReloader *utils.CertificateReloader
+		tlsConfig, certReloader, err = utils.LoadTLSConfig(config.NodeConfig.GetString(config.CfgWebAPITLSCertPath), config.NodeConfig.GetString(config.CfgWebAPITLSKeyPath))
+		if err != nil {
+			log.Fatalf("Loading the TLS certificate of the WebAPI failed: %s", err)
+		}
+
+		// the certificate is also reloaded on SIGHUP, in case the files were replaced with the same modification time
+		config.Events.Reloaded.Attach(events.NewClosure(func() {
+			if err := certReloader.Reload(); err != nil {
+				log.Warnf("Reloading the TLS certificate of the WebAPI failed: %s", err)
+			}
+		}))
+	}
+
+	var err error
+	if ipFilter, err = ipfilter.NewFromConfig(config.CfgWebAPIIPFilterAllowedNetworks, config.CfgWebAPIIPFilterDeniedNetworks, log); err != nil {
+		log.Fatalf("Invalid IP filter of the WebAPI: %s", err)
+	}
+
 	// Release mode
 	gin.SetMode(gin.ReleaseMode)
 	api = gin.New()
@@ -66,6 +95,9 @@ func configure(plugin *node.Plugin) {
 	// GZIP
 	api.Use(gzip.Gzip(gzip.DefaultCompression))
 
+	// Rate limit
+	configureRateLimit()
+
 	// Load allowed remote access to specific HTTP API commands
 	pae := config.NodeConfig.GetStringSlice(config.CfgWebAPIPermitRemoteAccess)
 	if len(pae) > 0 {
@@ -93,6 +125,15 @@ func configure(plugin *node.Plugin) {
 		restAPIRoute()
 	}
 
+	// JWT login route (without basic auth)
+	configureJWTAuth()
+
+	// WebSocket event stream route (without basic auth)
+	configureWebSocket()
+
+	// address and tag subscriptions with retained events
+	configureSubscriptions()
+
 	// set basic auth if enabled
 	// TODO: replace gin with echo so we don't have to write this middleware ourselves
 	if config.NodeConfig.GetBool(config.CfgWebAPIBasicAuthEnabled) {
@@ -115,6 +156,11 @@ func configure(plugin *node.Plugin) {
 		}
 
 		api.Use(func(c *gin.Context) {
+			if hasValidJWT(c) {
+				// a valid JWT replaces the basic auth credentials
+				return
+			}
+
 			authVal := c.Request.Header.Get("Authorization")
 			if len(authVal) <= len(basicAuthPrefix) {
 				unauthorizedReq(c)
@@ -148,6 +194,9 @@ func configure(plugin *node.Plugin) {
 	if !config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
 		// WebAPI route
 		webAPIRoute()
+
+		// REST API v1 routes
+		restAPIV1Route()
 	}
 
 	// Handle route with auth
@@ -176,18 +225,33 @@ func run(_ *node.Plugin) {
 		}
 	}
 
-	daemon.BackgroundWorker("WebAPI server", func(shutdownSignal <-chan struct{}) {
-		serverShutdownSignal = shutdownSignal
+	runRateLimitCleanup()
+	runWebSocket()
+	runSubscriptions()
 
+	daemon.BackgroundWorker("WebAPI server", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting WebAPI server ... done")
 
 		bindAddr := config.NodeConfig.GetString(config.CfgWebAPIBindAddress)
-		server = &http.Server{Addr: bindAddr, Handler: api}
+		server = &http.Server{Addr: bindAddr, Handler: api, TLSConfig: tlsConfig}
 
 		go func() {
-			log.Infof("You can now access the API using: http://%s", bindAddr)
-			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				log.Warn("Stopping WebAPI server due to an error ... done")
+			listener, err := net.Listen("tcp", bindAddr)
+			if err != nil {
+				log.Warnf("Stopping WebAPI server due to an error (%s) ... done", err)
+				return
+			}
+			listener = ipFilter.Listener(listener)
+
+			if tlsConfig != nil {
+				log.Infof("You can now access the API using: https://%s", bindAddr)
+				err = server.ServeTLS(listener, "", "")
+			} else {
+				log.Infof("You can now access the API using: http://%s", bindAddr)
+				err = server.Serve(listener)
+			}
+			if err != nil && err != http.ErrServerClosed {
+				log.Warnf("Stopping WebAPI server due to an error (%s) ... done", err)
 			}
 		}()
 
@@ -195,12 +259,21 @@ func run(_ *node.Plugin) {
 		log.Info("Stopping WebAPI server ...")
 
 		if server != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			err := server.Shutdown(ctx)
-			if err != nil {
-				log.Warn(err.Error())
+			// stop accepting new requests and wait for the in-flight ones
+			// the in-flight requests are only aborted after the drain timeout expired
+			drainTimeout := time.Duration(config.NodeConfig.GetInt(config.CfgNodeShutdownDrainTimeoutSeconds)) * time.Second
+			ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			go func() {
+				<-ctx.Done()
+				close(handlerAbortSignal)
+			}()
+			if err := server.Shutdown(ctx); err != nil {
+				log.Warnf("In-flight requests did not finish within %v (%s), closing their connections", drainTimeout, err)
+				_ = server.Close()
 			}
 			cancel()
+		} else {
+			close(handlerAbortSignal)
 		}
 		log.Info("Stopping WebAPI server ... done")
 	}, shutdown.PriorityAPI)
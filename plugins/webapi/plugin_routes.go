@@ -0,0 +1,58 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	pluginsRoute = "/api/plugins"
+)
+
+var (
+	// ErrWebAPIDisabled is returned if a route is registered while the WebAPI is disabled.
+	ErrWebAPIDisabled = errors.New("WebAPI is disabled")
+)
+
+// PluginRouteHandler handles a request to a route of an out-of-process plugin.
+// If it returns an error, the plugin could not be reached and the request is answered with a bad gateway error.
+type PluginRouteHandler func(c *gin.Context) error
+
+// RegisterPluginRoute registers a route of an out-of-process plugin below /api/plugins/<pluginName>.
+// Every route shares the remote access permissions, JWT protection and rate limits of its own command,
+// which is returned by PluginRouteCommand, e.g. "myplugin get /items/:id".
+// It has to be called after the WebAPI was configured and before it is started.
+func RegisterPluginRoute(pluginName string, method string, path string, handler PluginRouteHandler) (err error) {
+	if api == nil {
+		return ErrWebAPIDisabled
+	}
+
+	// gin panics on invalid or conflicting routes
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid route %s %s: %v", method, path, r)
+		}
+	}()
+
+	api.Group(pluginsRoute+"/"+strings.ToLower(pluginName)).Handle(strings.ToUpper(method), path, restPermitted(PluginRouteCommand(pluginName, method, path)), func(c *gin.Context) {
+		if err := handler(c); err != nil {
+			abortWithRESTError(c, http.StatusBadGateway, restErrCodeBadGateway, err.Error())
+		}
+	})
+
+	return nil
+}
+
+// PluginRouteCommand returns the command of a route of an out-of-process plugin, which is used in the
+// permitRemoteAccess, jwtAuth.protectedCommands and rateLimit.commands settings of the HTTP API.
+// It consists of the lower cased plugin name, HTTP method and path of the route, separated by spaces.
+func PluginRouteCommand(pluginName string, method string, path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return strings.ToLower(pluginName + " " + method + " " + path)
+}
@@ -0,0 +1,62 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/pkg/plugincontrol"
+)
+
+func init() {
+	addEndpoint("getPlugins", getPlugins, implementedAPIcalls)
+	addEndpoint("startPlugin", startPlugin, implementedAPIcalls)
+	addEndpoint("stopPlugin", stopPlugin, implementedAPIcalls)
+}
+
+func getPlugins(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, GetPluginsReturn{Plugins: plugincontrol.Plugins()})
+}
+
+func startPlugin(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	controlPlugin(i, c, plugincontrol.Start)
+}
+
+func stopPlugin(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	controlPlugin(i, c, plugincontrol.Stop)
+}
+
+// controlPlugin starts or stops the plugin of the request and returns the state of all plugins which can be started and stopped at runtime.
+func controlPlugin(i interface{}, c *gin.Context, control func(name string) error) {
+	e := ErrorReturn{}
+	query := &ControlPlugin{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if query.Plugin == "" {
+		e.Error = "plugin is missing"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if err := control(query.Plugin); err != nil {
+		e.Error = err.Error()
+		switch err {
+		case plugincontrol.ErrPluginNotControllable:
+			c.JSON(http.StatusBadRequest, e)
+		case plugincontrol.ErrPluginRunning, plugincontrol.ErrPluginNotRunning, plugincontrol.ErrNodeShuttingDown:
+			c.JSON(http.StatusConflict, e)
+		default:
+			c.JSON(http.StatusInternalServerError, e)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, GetPluginsReturn{Plugins: plugincontrol.Plugins()})
+}
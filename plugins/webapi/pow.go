@@ -1,6 +1,7 @@
 package webapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotaledger/hive.go/batchhasher"
 
 	"github.com/gohornet/hornet/pkg/config"
+	powpackage "github.com/gohornet/hornet/pkg/pow"
 	"github.com/gohornet/hornet/plugins/pow"
 )
 
@@ -82,16 +84,39 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		}
 	}
 
+	if err := doPoW(txs, query.TrunkTransaction, query.BranchTransaction, query.MinWeightMagnitude); err != nil {
+		e.Error = err.Error()
+		if errors.Is(err, powpackage.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	// Reverse the transactions the same way IRI does (for whatever reason)
+	for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+		txs[i], txs[j] = txs[j], txs[i]
+	}
+
+	powedTxTrytes := transaction.MustTransactionsToTrytes(txs)
+
+	c.JSON(http.StatusOK, AttachToTangleReturn{Trytes: powedTxTrytes})
+}
+
+// doPoW attaches the given transactions, sorted from the highest to the lowest index,
+// to the given trunk and branch and does the PoW for each of them.
+func doPoW(txs []transaction.Transaction, trunk trinary.Hash, branch trinary.Hash, mwm int) error {
 	var prev trinary.Hash
 	for i := 0; i < len(txs); i++ {
 
 		switch {
 		case i == 0:
-			txs[i].TrunkTransaction = query.TrunkTransaction
-			txs[i].BranchTransaction = query.BranchTransaction
+			txs[i].TrunkTransaction = trunk
+			txs[i].BranchTransaction = branch
 		default:
 			txs[i].TrunkTransaction = prev
-			txs[i].BranchTransaction = query.TrunkTransaction
+			txs[i].BranchTransaction = trunk
 		}
 
 		txs[i].AttachmentTimestamp = time.Now().UnixNano() / int64(time.Millisecond)
@@ -101,27 +126,21 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		// Convert tx to trytes
 		trytes, err := transaction.TransactionToTrytes(&txs[i])
 		if err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
+			return err
 		}
 
 		// Do the PoW
 		ts := time.Now()
-		txs[i].Nonce, err = pow.Handler().DoPoW(trytes, query.MinWeightMagnitude)
+		txs[i].Nonce, err = pow.Handler().DoAPIPoW(trytes, mwm)
 		if err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
+			return err
 		}
 		log.Debugf("PoW method: \"%s\", MWM: %d, took %v", pow.Handler().GetPoWType(), mwm, time.Since(ts).Truncate(time.Millisecond))
 
 		// Convert tx to trits
 		txTrits, err := transaction.TransactionToTrits(&txs[i])
 		if err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
+			return err
 		}
 
 		// Calculate the transaction hash with the batched hasher
@@ -131,19 +150,10 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		prev = txs[i].Hash
 
 		// Check tx
-		if !transaction.HasValidNonce(&txs[i], uint64(query.MinWeightMagnitude)) {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
+		if !transaction.HasValidNonce(&txs[i], uint64(mwm)) {
+			return fmt.Errorf("invalid nonce of transaction %s", txs[i].Hash)
 		}
 	}
 
-	// Reverse the transactions the same way IRI does (for whatever reason)
-	for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
-		txs[i], txs[j] = txs[j], txs[i]
-	}
-
-	powedTxTrytes := transaction.MustTransactionsToTrytes(txs)
-
-	c.JSON(http.StatusOK, AttachToTangleReturn{Trytes: powedTxTrytes})
+	return nil
 }
@@ -0,0 +1,204 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	powpackage "github.com/gohornet/hornet/pkg/pow"
+	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+const (
+	// the tag of the zero value transactions issued by promoteTransaction
+	promotionTag = "HORNET99PROMOTION"
+)
+
+func init() {
+	addEndpoint("promoteTransaction", promoteTransaction, implementedAPIcalls)
+	addEndpoint("reattachTransaction", reattachTransaction, implementedAPIcalls)
+}
+
+// promoteTransaction issues a zero value transaction which approves the given tail
+// and a tip selected by the node, to increase the chance of the tail to get confirmed.
+func promoteTransaction(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &PromoteTransaction{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if status, err := checkTailIssuable(query.TailTransaction, true); err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	tips, status, err := selectTips(0)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	b := bundle.AddEntry(bundle.Bundle{}, bundle.BundleEntry{
+		Length:    1,
+		Address:   consts.NullHashTrytes,
+		Value:     0,
+		Tag:       promotionTag,
+		Timestamp: uint64(time.Now().Unix()),
+	})
+
+	b, err = bundle.Finalize(b)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	// the promotion approves the tail, the same way as a transaction which uses the tail as the reference in getTransactionsToApprove
+	trytes, status, err := attachAndBroadcast(b, tips[0].Trytes(), query.TailTransaction)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, PromoteTransactionReturn{Transaction: b[0].Hash, Trytes: trytes})
+}
+
+// reattachTransaction attaches the bundle of the given tail again on top of tips selected by the node.
+func reattachTransaction(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ReattachTransaction{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if status, err := checkTailIssuable(query.TailTransaction, false); err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	cachedBndl := tangle.GetCachedBundleOrNil(hornet.HashFromHashTrytes(query.TailTransaction)) // bundle +1
+	if cachedBndl == nil {
+		e.Error = "bundle of the tail transaction not found"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	var b bundle.Bundle
+	cachedTxs := cachedBndl.GetBundle().GetTransactions() // tx +1
+	for _, cachedTx := range cachedTxs {
+		b = append(b, *cachedTx.GetTransaction().Tx)
+	}
+	cachedTxs.Release(true)  // tx -1
+	cachedBndl.Release(true) // bundle -1
+
+	sort.Slice(b, func(i, j int) bool {
+		return b[i].CurrentIndex < b[j].CurrentIndex
+	})
+
+	tips, status, err := selectTips(0)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	trytes, status, err := attachAndBroadcast(b, tips[0].Trytes(), tips[1].Trytes())
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(status, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, ReattachTransactionReturn{TailTransaction: b[0].Hash, Trytes: trytes})
+}
+
+// checkTailIssuable checks whether the node is able to promote or reattach the given tail,
+// and whether the tail is neither confirmed nor conflicting.
+// Promotions of tails which are below max depth are rejected, since they have to be reattached.
+func checkTailIssuable(tailTransaction trinary.Hash, promotion bool) (int, error) {
+
+	// do not issue transactions if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		return http.StatusServiceUnavailable, errors.New("tipselection plugin disabled in this node")
+	}
+
+	if !tangle.IsNodeSyncedWithThreshold() {
+		return http.StatusBadRequest, errors.New("node is not synced")
+	}
+
+	cachedTxMeta, err := getSolidTailMetadata(tailTransaction) // meta +1
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	info := tipInfo(cachedTxMeta) // meta -1
+	switch {
+	case info.Confirmed:
+		return http.StatusBadRequest, errors.New("transaction is already confirmed")
+	case info.Conflicting:
+		return http.StatusBadRequest, errors.New("transaction is conflicting")
+	case promotion && info.ShouldReattach:
+		return http.StatusBadRequest, errors.New("transaction is below max depth and has to be reattached")
+	}
+
+	return http.StatusOK, nil
+}
+
+// attachAndBroadcast does the PoW for the given bundle, sorted from the lowest to the highest index,
+// and broadcasts its transactions to the neighbors.
+func attachAndBroadcast(b bundle.Bundle, trunk trinary.Hash, branch trinary.Hash) ([]trinary.Trytes, int, error) {
+
+	// the PoW is done from the highest to the lowest index
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	if err := doPoW(b, trunk, branch, config.NodeConfig.GetInt(config.CfgCoordinatorMWM)); err != nil {
+		if errors.Is(err, powpackage.ErrQueueFull) {
+			return nil, http.StatusServiceUnavailable, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	trytes, err := transaction.TransactionsToTrytes(b)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	for _, txTrytes := range trytes {
+		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(txTrytes); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	return trytes, http.StatusOK, nil
+}
@@ -3,6 +3,7 @@ package webapi
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
@@ -24,25 +25,40 @@ func pruneDatabase(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
 		return
 	}
 
-	if (query.Depth != 0 && query.TargetIndex != 0) || (query.Depth == 0 && query.TargetIndex == 0) {
-		e.Error = "Either depth or targetIndex has to be specified"
+	criteria := 0
+	for _, set := range []bool{query.Depth != 0, query.TargetIndex != 0, query.MaxAgeHours != 0} {
+		if set {
+			criteria++
+		}
+	}
+
+	if criteria != 1 {
+		e.Error = "Either depth, targetIndex or maxAgeHours has to be specified"
 		c.JSON(http.StatusBadRequest, e)
 		return
 	}
 
-	if query.Depth != 0 {
-		if err := snapshot.PruneDatabaseByDepth(query.Depth); err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
-		}
-	} else {
-		if err := snapshot.PruneDatabaseByTargetIndex(query.TargetIndex); err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
-		}
+	var result *snapshot.PruningResult
+	var err error
+
+	switch {
+	case query.Depth != 0:
+		result, err = snapshot.PruneDatabaseByDepth(query.Depth)
+	case query.TargetIndex != 0:
+		result, err = snapshot.PruneDatabaseByTargetIndex(query.TargetIndex)
+	default:
+		result, err = snapshot.PruneDatabaseByAge(time.Duration(query.MaxAgeHours) * time.Hour)
+	}
+
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
 	}
 
-	c.JSON(http.StatusOK, PruneDatabaseReturn{})
+	c.JSON(http.StatusOK, PruneDatabaseReturn{
+		PruningIndex: result.PruningIndex,
+		Milestones:   result.Milestones,
+		Transactions: result.Transactions,
+	})
 }
@@ -0,0 +1,203 @@
+package webapi
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/syncutils"
+	"github.com/iotaledger/hive.go/timeutil"
+	"go.uber.org/atomic"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/ratelimit"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+const (
+	// the time after which the rate limit state of an inactive IP address is dropped
+	rateLimitMaxIdleTime = 5 * time.Minute
+)
+
+var (
+	ipRateLimiter           *ratelimit.KeyedLimiter
+	commandRateLimiters     = make(map[string]*ratelimit.KeyedLimiter)
+	commandRateLimitersLock syncutils.RWMutex
+	rateLimitEnabled        = atomic.NewBool(false)
+
+	// the amount of requests rejected by the rate limits by limit and endpoint
+	rateLimitHits     = make(map[rateLimitHitKey]*atomic.Uint64)
+	rateLimitHitsLock syncutils.RWMutex
+)
+
+const (
+	// RateLimitIP is the limit of all requests of an IP address.
+	RateLimitIP = "ip"
+	// RateLimitCommand is the limit of the requests of an IP address per command.
+	RateLimitCommand = "command"
+)
+
+type rateLimitHitKey struct {
+	limit    string
+	endpoint string
+}
+
+// RateLimitHit is the amount of requests to an endpoint which were rejected by a rate limit.
+type RateLimitHit struct {
+	// The limit which rejected the requests (RateLimitIP or RateLimitCommand).
+	Limit string
+	// The route of the request for the IP limit, the command for the command limit.
+	Endpoint string
+	Count    uint64
+}
+
+// configureRateLimit sets up the per IP rate limit for all requests and the per IP and command limits.
+func configureRateLimit() {
+	if !config.NodeConfig.GetBool(config.CfgWebAPIRateLimitEnabled) {
+		return
+	}
+
+	rateLimitEnabled.Store(true)
+	burst := config.NodeConfig.GetInt(config.CfgWebAPIRateLimitBurst)
+	ipRateLimiter = ratelimit.NewKeyedLimiter(config.NodeConfig.GetFloat64(config.CfgWebAPIRateLimitRequestsPerSecond), burst)
+	loadCommandRateLimiters()
+
+	config.Events.Reloaded.Attach(events.NewClosure(reloadRateLimits))
+
+	api.Use(func(c *gin.Context) {
+		if !rateLimitEnabled.Load() || isWhitelisted(c) {
+			return
+		}
+
+		if !ipRateLimiter.Allow(remoteIP(c)) {
+			endpoint := c.FullPath()
+			if endpoint == "" {
+				endpoint = "unknown"
+			}
+			countRateLimitHit(RateLimitIP, endpoint)
+			abortTooManyRequests(c)
+		}
+	})
+}
+
+// loadCommandRateLimiters creates the per IP and command limits from the config.
+func loadCommandRateLimiters() {
+	limiters := make(map[string]*ratelimit.KeyedLimiter)
+	for command, limit := range config.ReloadableNodeConfig().GetStringMapString(config.CfgWebAPIRateLimitCommands) {
+		callsPerSecond, err := strconv.ParseFloat(limit, 64)
+		if err != nil || callsPerSecond <= 0 {
+			log.Warnf("Invalid rate limit for command %s: %s", command, limit)
+			continue
+		}
+		limiters[strings.ToLower(command)] = ratelimit.NewKeyedLimiter(callsPerSecond, int(math.Max(1, math.Ceil(callsPerSecond))))
+	}
+
+	commandRateLimitersLock.Lock()
+	defer commandRateLimitersLock.Unlock()
+	commandRateLimiters = limiters
+}
+
+// reloadRateLimits applies the rate limits of the reloaded config.
+// The rate limit can only be enabled at startup, afterwards it can be disabled and enabled again.
+func reloadRateLimits() {
+	settings := config.ReloadableNodeConfig()
+	rateLimitEnabled.Store(settings.GetBool(config.CfgWebAPIRateLimitEnabled))
+	ipRateLimiter.SetLimit(settings.GetFloat64(config.CfgWebAPIRateLimitRequestsPerSecond), settings.GetInt(config.CfgWebAPIRateLimitBurst))
+	loadCommandRateLimiters()
+
+	log.Infof("applied the HTTP API rate limits due to config reload (enabled: %v)", rateLimitEnabled.Load())
+}
+
+// runRateLimitCleanup periodically drops the state of inactive IP addresses.
+func runRateLimitCleanup() {
+	if ipRateLimiter == nil {
+		return
+	}
+
+	daemon.BackgroundWorker("WebAPI rate limit cleanup", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(func() {
+			ipRateLimiter.Cleanup(rateLimitMaxIdleTime)
+
+			commandRateLimitersLock.RLock()
+			defer commandRateLimitersLock.RUnlock()
+			for _, limiter := range commandRateLimiters {
+				limiter.Cleanup(rateLimitMaxIdleTime)
+			}
+		}, time.Minute, shutdownSignal)
+	}, shutdown.PriorityAPI)
+}
+
+// isCommandRateLimited checks the rate limit of the given (lower cased) command for the requesting IP address.
+func isCommandRateLimited(c *gin.Context, command string) bool {
+	if !rateLimitEnabled.Load() {
+		return false
+	}
+
+	commandRateLimitersLock.RLock()
+	limiter, exists := commandRateLimiters[command]
+	commandRateLimitersLock.RUnlock()
+
+	if !exists || isWhitelisted(c) {
+		return false
+	}
+
+	if limiter.Allow(remoteIP(c)) {
+		return false
+	}
+
+	countRateLimitHit(RateLimitCommand, command)
+	return true
+}
+
+// countRateLimitHit counts a request to the given endpoint which was rejected by the given limit.
+func countRateLimitHit(limit string, endpoint string) {
+	key := rateLimitHitKey{limit: limit, endpoint: endpoint}
+
+	rateLimitHitsLock.RLock()
+	counter, exists := rateLimitHits[key]
+	rateLimitHitsLock.RUnlock()
+
+	if !exists {
+		rateLimitHitsLock.Lock()
+		if counter, exists = rateLimitHits[key]; !exists {
+			counter = atomic.NewUint64(0)
+			rateLimitHits[key] = counter
+		}
+		rateLimitHitsLock.Unlock()
+	}
+
+	counter.Inc()
+}
+
+// RateLimitHits returns the amount of rejected requests per limit and endpoint since the start of the node.
+func RateLimitHits() []*RateLimitHit {
+	rateLimitHitsLock.RLock()
+	defer rateLimitHitsLock.RUnlock()
+
+	hits := make([]*RateLimitHit, 0, len(rateLimitHits))
+	for key, counter := range rateLimitHits {
+		hits = append(hits, &RateLimitHit{Limit: key.limit, Endpoint: key.endpoint, Count: counter.Load()})
+	}
+	return hits
+}
+
+func abortTooManyRequests(c *gin.Context) {
+	c.Header("Retry-After", "1")
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorReturn{Error: fmt.Sprintf("Too many requests from %s", remoteIP(c))})
+}
+
+func remoteIP(c *gin.Context) string {
+	remoteHost, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+	return remoteHost
+}
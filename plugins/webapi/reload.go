@@ -0,0 +1,26 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+func init() {
+	addEndpoint("reloadConfig", reloadConfig, implementedAPIcalls)
+}
+
+func reloadConfig(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	if err := config.Reload(); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	log.Info("Reloaded the config via the HTTP API")
+	c.JSON(http.StatusOK, ReloadConfigReturn{})
+}
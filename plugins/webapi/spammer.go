@@ -0,0 +1,95 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/plugins/spammer"
+)
+
+func init() {
+	addEndpoint("spammer", controlSpammer, implementedAPIcalls)
+}
+
+func controlSpammer(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &Spammer{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	status, err := spammer.GetStatus()
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	switch query.Cmd {
+	case "start":
+		// only the given parameters are changed, the others are kept
+		settings := status.Settings
+		if query.Address != nil {
+			settings.Address = *query.Address
+		}
+		if query.Message != nil {
+			settings.Message = *query.Message
+		}
+		if query.Tag != nil {
+			settings.Tag = *query.Tag
+			settings.TagSemiLazy = ""
+		}
+		if query.TagSemiLazy != nil {
+			settings.TagSemiLazy = *query.TagSemiLazy
+		}
+		if query.CPUMaxUsage != nil {
+			settings.CPUMaxUsage = *query.CPUMaxUsage
+		}
+		if query.TPSRateLimit != nil {
+			settings.TPSRateLimit = *query.TPSRateLimit
+		}
+		if query.BundleSize != nil {
+			settings.BundleSize = *query.BundleSize
+		}
+		if query.ValueSpam != nil {
+			settings.ValueSpam = *query.ValueSpam
+		}
+		if query.MaxRequestQueueSize != nil {
+			settings.MaxRequestQueueSize = *query.MaxRequestQueueSize
+		}
+
+		if err := spammer.Start(&settings); err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+	case "stop":
+		if err := spammer.Stop(); err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+	case "status":
+
+	default:
+		e.Error = fmt.Sprintf("unknown cmd '%s', use start, stop or status", query.Cmd)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if status, err = spammer.GetStatus(); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpammerReturn{Status: *status})
+}
@@ -0,0 +1,190 @@
+package webapi
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+const (
+	// the default file name of spent addresses exports in the snapshot directory
+	spentAddressesExportFileName = "spent_addresses.bin.gz"
+)
+
+func init() {
+	addEndpoint("exportSpentAddresses", exportSpentAddresses, implementedAPIcalls)
+	addEndpoint("importSpentAddresses", importSpentAddresses, implementedAPIcalls)
+}
+
+// spentAddressesExportPath returns the path of the given spent addresses export file in the snapshot directory.
+// only the base name of the given file name is used, so that no files outside of the snapshot directory can be accessed.
+func spentAddressesExportPath(fileName string) string {
+	if fileName == "" {
+		fileName = spentAddressesExportFileName
+	}
+	return filepath.Join(filepath.Dir(config.NodeConfig.GetString(config.CfgLocalSnapshotsPath)), filepath.Base(fileName))
+}
+
+func exportSpentAddresses(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ExportSpentAddresses{}
+
+	if !tangle.GetSnapshotInfo().IsSpentAddressesEnabled() {
+		e.Error = "spent addresses not available in this node"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	filePath := spentAddressesExportPath(query.FileName)
+	count, err := writeSpentAddressesExport(filePath, abortSignal)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, ExportSpentAddressesReturn{FileName: filepath.Base(filePath), Count: count})
+}
+
+// writeSpentAddressesExport writes the spent addresses export into a temporary file and renames it afterwards.
+func writeSpentAddressesExport(filePath string, abortSignal <-chan struct{}) (int32, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return 0, err
+	}
+
+	exportFile, err := os.Create(filePath + "_tmp")
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := tangle.ExportSpentAddresses(exportFile, abortSignal)
+	if closeErr := exportFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath + "_tmp")
+		return 0, err
+	}
+
+	return count, os.Rename(filePath+"_tmp", filePath)
+}
+
+func importSpentAddresses(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ImportSpentAddresses{}
+
+	if !tangle.GetSnapshotInfo().IsSpentAddressesEnabled() || !config.NodeConfig.GetBool(config.CfgSpentAddressesEnabled) {
+		e.Error = "spent addresses not available in this node"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	imported, err := tangle.ImportSpentAddressesFromFile(spentAddressesExportPath(query.FileName), abortSignal)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, ImportSpentAddressesReturn{Imported: imported})
+}
+
+// exportSpentAddressesV1 sends the spent addresses export, so that other nodes can import it.
+// The export is written into a temporary file first, since the spent addresses are locked while they are exported
+// and a slow client must not block the confirmation of milestones.
+func exportSpentAddressesV1(c *gin.Context) {
+	if !tangle.GetSnapshotInfo().IsSpentAddressesEnabled() {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, "spent addresses not available in this node")
+		return
+	}
+
+	exportFile, err := createSpentAddressesTempFile()
+	if err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
+	}
+	defer os.Remove(exportFile.Name())
+	defer exportFile.Close()
+
+	if _, err := tangle.ExportSpentAddresses(exportFile, c.Request.Context().Done()); err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
+	}
+
+	if _, err := exportFile.Seek(0, io.SeekStart); err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", "attachment; filename="+spentAddressesExportFileName)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, exportFile); err != nil {
+		// the headers were already sent, so the error can't be reported to the client anymore
+		_ = c.Error(err)
+	}
+}
+
+// importSpentAddressesV1 imports the spent addresses export in the body of the request,
+// e.g. the export of another node which was downloaded from its "GET /api/v1/spentaddresses/export" route.
+func importSpentAddressesV1(c *gin.Context) {
+	if !tangle.GetSnapshotInfo().IsSpentAddressesEnabled() || !config.NodeConfig.GetBool(config.CfgSpentAddressesEnabled) {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, "spent addresses not available in this node")
+		return
+	}
+
+	// the export is stored first, since it is read twice: once to verify it and once to import it
+	importFile, err := createSpentAddressesTempFile()
+	if err != nil {
+		abortWithRESTError(c, http.StatusInternalServerError, restErrCodeInternalError, err.Error())
+		return
+	}
+	defer os.Remove(importFile.Name())
+
+	_, err = io.Copy(importFile, c.Request.Body)
+	if closeErr := importFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
+	}
+
+	imported, err := tangle.ImportSpentAddressesFromFile(importFile.Name(), c.Request.Context().Done())
+	if err != nil {
+		abortWithRESTError(c, http.StatusBadRequest, restErrCodeBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ImportSpentAddressesReturn{Imported: imported})
+}
+
+// createSpentAddressesTempFile creates a temporary file for a spent addresses export in the snapshot directory.
+func createSpentAddressesTempFile() (*os.File, error) {
+	dir := filepath.Dir(config.NodeConfig.GetString(config.CfgLocalSnapshotsPath))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return ioutil.TempFile(dir, spentAddressesExportFileName+"_tmp")
+}
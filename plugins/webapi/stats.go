@@ -0,0 +1,84 @@
+package webapi
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
+	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/snapshot"
+)
+
+var (
+	nodeStartAt = time.Now()
+)
+
+func init() {
+	addEndpoint("getNodeStats", getNodeStats, implementedAPIcalls)
+}
+
+// getNodeStats returns operational statistics of the node, which are not part of getNodeInfo
+// since they reveal details about the setup of the node.
+func getNodeStats(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+
+	result := GetNodeStatsReturn{
+		Uptime: time.Since(nodeStartAt).Milliseconds(),
+	}
+
+	tangleSize, snapshotSize, spentSize := tangle.GetDatabaseSizes()
+	result.DatabaseSize = DatabaseSize{
+		Tangle:   tangleSize,
+		Snapshot: snapshotSize,
+		Spent:    spentSize,
+		Total:    tangleSize + snapshotSize + spentSize,
+	}
+
+	result.Snapshot.SolidMilestoneIndex = tangle.GetSolidMilestoneIndex()
+	snapshotInfo := tangle.GetSnapshotInfo()
+	if snapshotInfo != nil {
+		result.Snapshot.SnapshotIndex = snapshotInfo.SnapshotIndex
+		result.Snapshot.EntryPointIndex = snapshotInfo.EntryPointIndex
+		result.Snapshot.PruningIndex = snapshotInfo.PruningIndex
+	}
+	result.Snapshot.IsSnapshotting = snapshot.IsSnapshotting()
+
+	result.Pruning = PruningStatus{
+		Enabled:              config.NodeConfig.GetBool(config.CfgPruningEnabled),
+		Delay:                config.NodeConfig.GetInt(config.CfgPruningDelay),
+		MaxAgeHours:          config.NodeConfig.GetInt(config.CfgPruningMaxAgeHours),
+		TargetDatabaseSizeMB: config.NodeConfig.GetInt(config.CfgPruningTargetDatabaseSizeMB),
+		IsPruning:            snapshot.IsPruning(),
+	}
+
+	queued, pending, _ := gossip.RequestQueue().Size()
+	result.Caches = map[string]int{
+		"approvers":                    tangle.GetApproversStorageSize(),
+		"addresses":                    tangle.GetAddressesStorageSize(),
+		"bundles":                      tangle.GetBundleStorageSize(),
+		"bundleTransactions":           tangle.GetBundleTransactionsStorageSize(),
+		"milestones":                   tangle.GetMilestoneStorageSize(),
+		"spentAddresses":               tangle.GetSpentAddressesStorageSize(),
+		"tags":                         tangle.GetTagsStorageSize(),
+		"transactions":                 tangle.GetTransactionStorageSize(),
+		"unconfirmedTransactions":      tangle.GetUnconfirmedTxStorageSize(),
+		"requestQueue":                 queued + pending,
+		"incomingTransactionWorkUnits": gossip.Processor().WorkUnitsSize(),
+	}
+
+	result.Plugins = []string{}
+	for _, plugin := range node.GetPlugins() {
+		if plugincontrol.IsSkipped(plugin) {
+			continue
+		}
+		result.Plugins = append(result.Plugins, plugin.Name)
+	}
+	sort.Strings(result.Plugins)
+
+	c.JSON(http.StatusOK, result)
+}
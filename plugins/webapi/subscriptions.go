@@ -0,0 +1,231 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/timeutil"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/subscription"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+var (
+	subscriptions *subscription.Manager
+
+	subscriptionsExpiry time.Duration
+
+	subscriptionsNewTxWorkerCount     = 1
+	subscriptionsNewTxWorkerQueueSize = 10000
+	subscriptionsNewTxWorkerPool      *workerpool.WorkerPool
+
+	subscriptionsConfirmedTxWorkerCount     = 1
+	subscriptionsConfirmedTxWorkerQueueSize = 10000
+	subscriptionsConfirmedTxWorkerPool      *workerpool.WorkerPool
+)
+
+func init() {
+	addEndpoint("registerSubscription", registerSubscription, implementedAPIcalls)
+	addEndpoint("getSubscriptionEvents", getSubscriptionEvents, implementedAPIcalls)
+	addEndpoint("removeSubscription", removeSubscription, implementedAPIcalls)
+}
+
+// registerSubscription registers a subscription for the new and confirmed transactions of the given addresses and tags.
+// The events are retained by the node, so that the client is able to fetch the events it missed while it was disconnected.
+func registerSubscription(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &RegisterSubscription{}
+
+	if subscriptions == nil {
+		e.Error = "subscriptions are disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	addresses, tags, err := parseFilters(query.Addresses, query.Tags, config.NodeConfig.GetInt(config.CfgWebAPISubscriptionsMaxFilters))
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	s, err := subscriptions.Register(addresses, tags)
+	if err != nil {
+		e.Error = err.Error()
+		if errors.Is(err, subscription.ErrTooManySubscriptions) {
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, RegisterSubscriptionReturn{ID: s.ID(), Cursor: s.Cursor()})
+}
+
+// getSubscriptionEvents returns the events of a subscription starting at the given cursor.
+func getSubscriptionEvents(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetSubscriptionEvents{}
+
+	if subscriptions == nil {
+		e.Error = "subscriptions are disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	s, err := subscriptions.Get(query.ID)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+
+	events, next, missed := s.Events(query.Cursor, query.Limit)
+	if events == nil {
+		events = []*subscription.Event{}
+	}
+
+	c.JSON(http.StatusOK, GetSubscriptionEventsReturn{Events: events, Cursor: next, MissedEvents: missed})
+}
+
+// removeSubscription removes a subscription and its retained events.
+func removeSubscription(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &RemoveSubscription{}
+
+	if subscriptions == nil {
+		e.Error = "subscriptions are disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if err := subscriptions.Remove(query.ID); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, RemoveSubscriptionReturn{})
+}
+
+// configureSubscriptions sets up the subscription manager and its worker pools if subscriptions are enabled.
+func configureSubscriptions() {
+	if !config.NodeConfig.GetBool(config.CfgWebAPISubscriptionsEnabled) || config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
+		return
+	}
+
+	subscriptionsExpiry = time.Duration(config.NodeConfig.GetInt(config.CfgWebAPISubscriptionsExpiryMinutes)) * time.Minute
+
+	subscriptions = subscription.NewManager(
+		config.NodeConfig.GetInt(config.CfgWebAPISubscriptionsMaxSubscriptions),
+		config.NodeConfig.GetInt(config.CfgWebAPISubscriptionsMaxFilters),
+		config.NodeConfig.GetInt(config.CfgWebAPISubscriptionsMaxEvents),
+	)
+
+	subscriptionsNewTxWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onSubscriptionsNewTx(task.Param(0).(*tanglePackage.CachedTransaction)) // tx pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(subscriptionsNewTxWorkerCount), workerpool.QueueSize(subscriptionsNewTxWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	subscriptionsConfirmedTxWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onSubscriptionsConfirmedTx(task.Param(0).(*tanglePackage.CachedMetadata), task.Param(1).(milestone.Index)) // meta pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(subscriptionsConfirmedTxWorkerCount), workerpool.QueueSize(subscriptionsConfirmedTxWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+}
+
+func onSubscriptionsNewTx(cachedTx *tanglePackage.CachedTransaction) {
+	cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) { // tx -1
+		subscriptions.Publish(wsTopicTransactions, tx.Tx.Address, tx.Tx.Tag, wsTransaction(tx.Tx, 0))
+	})
+}
+
+func onSubscriptionsConfirmedTx(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index) {
+	cachedMeta.ConsumeMetadata(func(metadata *hornet.TransactionMetadata) { // meta -1
+		cachedTx := tanglePackage.GetCachedTransactionOrNil(metadata.GetTxHash()) // tx +1
+		if cachedTx == nil {
+			return
+		}
+
+		cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) { // tx -1
+			subscriptions.Publish(wsTopicConfirmed, tx.Tx.Address, tx.Tx.Tag, wsTransaction(tx.Tx, msIndex))
+		})
+	})
+}
+
+// runSubscriptions attaches the subscriptions to the tangle events and periodically removes expired subscriptions.
+func runSubscriptions() {
+	if subscriptions == nil {
+		return
+	}
+
+	onReceivedNewTransaction := events.NewClosure(func(cachedTx *tanglePackage.CachedTransaction, _ milestone.Index, _ milestone.Index) {
+		if subscriptions.Size() > 0 {
+			if _, added := subscriptionsNewTxWorkerPool.TrySubmit(cachedTx); added { // tx pass +1
+				return // Avoid tx -1 (done inside workerpool task)
+			}
+		}
+		cachedTx.Release(true) // tx -1
+	})
+
+	onTransactionConfirmed := events.NewClosure(func(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index, _ int64) {
+		// Avoid notifying for conflicting txs
+		if !cachedMeta.GetMetadata().IsConflicting() && subscriptions.Size() > 0 {
+			if _, added := subscriptionsConfirmedTxWorkerPool.TrySubmit(cachedMeta, msIndex); added { // meta pass +1
+				return // Avoid meta -1 (done inside workerpool task)
+			}
+		}
+		cachedMeta.Release(true) // meta -1
+	})
+
+	daemon.BackgroundWorker("WebAPI[Subscriptions]", func(shutdownSignal <-chan struct{}) {
+		tangle.Events.ReceivedNewTransaction.Attach(onReceivedNewTransaction)
+		tangle.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		subscriptionsNewTxWorkerPool.Start()
+		subscriptionsConfirmedTxWorkerPool.Start()
+
+		timeutil.Ticker(func() {
+			subscriptions.Cleanup(subscriptionsExpiry)
+		}, time.Minute, shutdownSignal)
+
+		log.Info("Stopping WebAPI[Subscriptions] ...")
+
+		tangle.Events.ReceivedNewTransaction.Detach(onReceivedNewTransaction)
+		tangle.Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+		subscriptionsNewTxWorkerPool.StopAndWait()
+		subscriptionsConfirmedTxWorkerPool.StopAndWait()
+
+		log.Info("Stopping WebAPI[Subscriptions] ... done")
+	}, shutdown.PriorityAPI)
+}
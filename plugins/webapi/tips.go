@@ -1,12 +1,14 @@
 package webapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/gohornet/hornet/pkg/config"
@@ -47,45 +49,59 @@ func getTipInfo(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
-	if !guards.IsTransactionHash(query.TailTransaction) {
-		e.Error = "invalid tail hash supplied"
+	cachedTxMeta, err := getSolidTailMetadata(query.TailTransaction) // meta +1
+	if err != nil {
+		e.Error = err.Error()
 		c.JSON(http.StatusBadRequest, e)
 		return
 	}
+	defer cachedTxMeta.Release(true) // meta -1
+
+	c.JSON(http.StatusOK, tipInfo(cachedTxMeta.Retain()))
+}
+
+// getSolidTailMetadata returns the metadata of the given tail transaction
+// if the transaction is known, a tail and solid.
+func getSolidTailMetadata(tailTransaction trinary.Hash) (*tangle.CachedMetadata, error) {
+	if !guards.IsTransactionHash(tailTransaction) {
+		return nil, errors.New("invalid tail hash supplied")
+	}
 
-	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(hornet.HashFromHashTrytes(query.TailTransaction)) // meta +1
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(hornet.HashFromHashTrytes(tailTransaction)) // meta +1
 	if cachedTxMeta == nil {
-		e.Error = "unknown tail transaction"
-		c.JSON(http.StatusBadRequest, e)
-		return
+		return nil, errors.New("unknown tail transaction")
 	}
-	defer cachedTxMeta.Release(true)
 
 	if !cachedTxMeta.GetMetadata().IsTail() {
-		e.Error = "transaction is not a tail"
-		c.JSON(http.StatusBadRequest, e)
-		return
+		cachedTxMeta.Release(true) // meta -1
+		return nil, errors.New("transaction is not a tail")
 	}
 
 	if !cachedTxMeta.GetMetadata().IsSolid() {
-		e.Error = "transaction is not solid"
-		c.JSON(http.StatusBadRequest, e)
-		return
+		cachedTxMeta.Release(true) // meta -1
+		return nil, errors.New("transaction is not solid")
 	}
 
+	return cachedTxMeta, nil
+}
+
+// tipInfo tells whether the given solid tail is confirmed or conflicting,
+// or whether it has to be promoted or reattached to get confirmed.
+func tipInfo(cachedTxMeta *tangle.CachedMetadata) *GetTipInfoReturn {
+	defer cachedTxMeta.Release(true) // meta -1
+
 	conflicting := cachedTxMeta.GetMetadata().IsConflicting()
 
 	// check if tx is set as confirmed. Avoid passing true for conflicting tx to be backwards compatible
 	confirmed := cachedTxMeta.GetMetadata().IsConfirmed() && !conflicting
 
 	if confirmed || conflicting {
-		c.JSON(http.StatusOK, GetTipInfoReturn{
+		return &GetTipInfoReturn{
 			Confirmed:      confirmed,
 			Conflicting:    conflicting,
 			ShouldPromote:  false,
 			ShouldReattach: false,
-		})
-		return
+		}
 	}
 
 	lsmi := tangle.GetSolidMilestoneIndex()
@@ -93,44 +109,56 @@ func getTipInfo(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	// if the OTRSI to LSMI delta is over BelowMaxDepth/below-max-depth, then the tip is lazy and should be reattached
 	if (lsmi - ortsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth)) {
-		c.JSON(http.StatusOK, GetTipInfoReturn{
+		return &GetTipInfoReturn{
 			Confirmed:      false,
 			Conflicting:    false,
 			ShouldPromote:  false,
 			ShouldReattach: true,
-		})
-		return
+		}
 	}
 
 	// if the LSMI to YTRSI delta is over MaxDeltaTxYoungestRootSnapshotIndexToLSMI, then the tip is lazy and should be promoted
 	if (lsmi - ytrsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelMaxDeltaTxYoungestRootSnapshotIndexToLSMI)) {
-		c.JSON(http.StatusOK, GetTipInfoReturn{
+		return &GetTipInfoReturn{
 			Confirmed:      false,
 			Conflicting:    false,
 			ShouldPromote:  true,
 			ShouldReattach: false,
-		})
-		return
+		}
 	}
 
 	// if the OTRSI to LSMI delta is over MaxDeltaTxOldestRootSnapshotIndexToLSMI, the tip is semi-lazy and should be promoted
 	if (lsmi - ortsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelMaxDeltaTxOldestRootSnapshotIndexToLSMI)) {
-		c.JSON(http.StatusOK, GetTipInfoReturn{
+		return &GetTipInfoReturn{
 			Confirmed:      false,
 			Conflicting:    false,
 			ShouldPromote:  true,
 			ShouldReattach: false,
-		})
-		return
+		}
 	}
 
 	// tip is non-lazy, no need to promote or reattach
-	c.JSON(http.StatusOK, GetTipInfoReturn{
+	return &GetTipInfoReturn{
 		Confirmed:      false,
 		Conflicting:    false,
 		ShouldPromote:  false,
 		ShouldReattach: false,
-	})
+	}
+}
+
+// selectTips selects tips via URTS and maps the errors of the tipselection to HTTP status codes.
+func selectTips(depth int) (hornet.Hashes, int, error) {
+	tips, err := urts.Selector.SelectTips(depth)
+	if err != nil {
+		if err == tangle.ErrNodeNotSynced || err == tipselect.ErrNoTipsAvailable || err == tipselect.ErrEntryPointNotFound {
+			return nil, http.StatusServiceUnavailable, err
+		}
+		if err == tipselect.ErrDepthTooBig {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("%v: %v", ErrInternalError, err)
+	}
+	return tips, http.StatusOK, nil
 }
 
 func getTransactionsToApprove(i interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -151,15 +179,10 @@ func getTransactionsToApprove(i interface{}, c *gin.Context, _ <-chan struct{})
 		return
 	}
 
-	tips, err := urts.TipSelector.SelectNonLazyTips()
+	tips, status, err := selectTips(int(query.Depth))
 	if err != nil {
-		if err == tangle.ErrNodeNotSynced || err == tipselect.ErrNoTipsAvailable {
-			e.Error = err.Error()
-			c.JSON(http.StatusServiceUnavailable, e)
-			return
-		}
-		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
-		c.JSON(http.StatusInternalServerError, e)
+		e.Error = err.Error()
+		c.JSON(status, e)
 		return
 	}
 
@@ -1,9 +1,12 @@
 package webapi
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
@@ -58,7 +61,7 @@ func broadcastTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	c.JSON(http.StatusOK, BradcastTransactionsReturn{})
 }
 
-func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
+func findTransactions(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &FindTransactions{}
 
@@ -68,24 +71,31 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
-	maxResults := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)
-	if (query.MaxResults != 0) && (query.MaxResults < maxResults) {
-		maxResults = query.MaxResults
-	}
-
-	if (len(query.Bundles) + len(query.Addresses) + len(query.Approvees) + len(query.Tags)) > maxResults {
-		e.Error = "Too many bundle, address, approvee or tag hashes. Max. allowed: " + strconv.Itoa(maxResults)
+	maxQueries := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)
+	if (len(query.Bundles) + len(query.Addresses) + len(query.Approvees) + len(query.Tags)) > maxQueries {
+		e.Error = "Too many bundle, address, approvee or tag hashes. Max. allowed: " + strconv.Itoa(maxQueries)
 		c.JSON(http.StatusBadRequest, e)
 		return
 	}
 
-	txHashes := []string{}
+	maxResults := resultLimit("findTransactions", maxQueries)
+	if query.Stream {
+		// streamed results are not held in memory, so a higher limit applies
+		maxResults = config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactionsStream)
+	}
+
+	if (query.MaxResults != 0) && (maxResults == 0 || query.MaxResults < maxResults) {
+		maxResults = query.MaxResults
+	}
 
 	if len(query.Bundles) == 0 && len(query.Addresses) == 0 && len(query.Approvees) == 0 && len(query.Tags) == 0 {
 		c.JSON(http.StatusOK, FindTransactionsReturn{Hashes: []string{}})
 		return
 	}
 
+	// the queries are validated first, since the results may already be streamed while they are searched
+	var searches []findTransactionsSearch
+
 	// Searching for transactions that contains the given bundle hash
 	for _, bdl := range query.Bundles {
 		if err := trinary.ValidTrytes(bdl); err != nil {
@@ -94,7 +104,10 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 			return
 		}
 
-		txHashes = append(txHashes, tangle.GetBundleTransactionHashes(hornet.HashFromHashTrytes(bdl), true, maxResults-len(txHashes)).Trytes()...)
+		bundleHash := hornet.HashFromHashTrytes(bdl)
+		searches = append(searches, func(startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+			tangle.ForEachBundleTransactionHash(bundleHash, startAfter, consumer)
+		})
 	}
 
 	// Searching for transactions that contains the given address
@@ -109,7 +122,10 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 			addr = addr[:81]
 		}
 
-		txHashes = append(txHashes, tangle.GetTransactionHashesForAddress(hornet.HashFromAddressTrytes(addr), query.ValueOnly, true, maxResults-len(txHashes)).Trytes()...)
+		addressHash := hornet.HashFromAddressTrytes(addr)
+		searches = append(searches, func(startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+			tangle.ForEachTransactionHashForAddress(addressHash, query.ValueOnly, startAfter, consumer)
+		})
 	}
 
 	// Searching for all approvers of the given transactions
@@ -120,7 +136,10 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 			return
 		}
 
-		txHashes = append(txHashes, tangle.GetApproverHashes(hornet.HashFromHashTrytes(approveeHash), maxResults-len(txHashes)).Trytes()...)
+		txHash := hornet.HashFromHashTrytes(approveeHash)
+		searches = append(searches, func(startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+			tangle.ForEachApproverHash(txHash, startAfter, consumer)
+		})
 	}
 
 	// Searching for transactions that contain the given tag
@@ -137,10 +156,193 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 			return
 		}
 
-		txHashes = append(txHashes, tangle.GetTagHashes(hornet.HashFromTagTrytes(tag), true, maxResults-len(txHashes)).Trytes()...)
+		tagHash := hornet.HashFromTagTrytes(tag)
+		searches = append(searches, func(startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool) {
+			tangle.ForEachTagHash(tagHash, startAfter, consumer)
+		})
+	}
+
+	position, err := parseFindTransactionsToken(query.ContinuationToken, len(searches))
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %s", err, query.ContinuationToken)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// forEachResult passes the results within the requested page to the consumer
+	// and returns whether the results were truncated or the consumer aborted.
+	forEachResult := func(consumer func(txHash hornet.Hash) bool) (PartialResult, bool) {
+		found := 0
+		hasMore, aborted := false, false
+		last := position
+
+		for searchIndex := position.searchIndex; searchIndex < len(searches); searchIndex++ {
+			var startAfter []byte
+			if searchIndex == position.searchIndex {
+				startAfter = position.cursor
+			}
+
+			searches[searchIndex](startAfter, func(txHash hornet.Hash, cursor []byte) bool {
+				if maxResults != 0 && found == maxResults {
+					hasMore = true
+					return false
+				}
+
+				if !consumer(txHash) {
+					aborted = true
+					return false
+				}
+				found++
+				last = findTransactionsPosition{searchIndex: searchIndex, cursor: append([]byte{}, cursor...)}
+				return true
+			})
+
+			if hasMore || aborted {
+				break
+			}
+		}
+
+		if !hasMore {
+			return PartialResult{}, aborted
+		}
+		return PartialResult{Truncated: true, ContinuationToken: last.token()}, aborted
+	}
+
+	if query.Stream {
+		streamFindTransactions(c, abortSignal, forEachResult)
+		return
+	}
+
+	txHashes := []string{}
+	partialResult, _ := forEachResult(func(txHash hornet.Hash) bool {
+		txHashes = append(txHashes, txHash.Trytes())
+		return true
+	})
+
+	c.JSON(http.StatusOK, FindTransactionsReturn{Hashes: txHashes, PartialResult: partialResult})
+}
+
+// findTransactionsSearch loops over the results of one of the queries of findTransactions, starting after the given cursor.
+type findTransactionsSearch func(startAfter []byte, consumer func(txHash hornet.Hash, cursor []byte) bool)
+
+// findTransactionsPosition is the position of the last returned result of findTransactions.
+// The results of every query are returned in the order of the database keys, so the key of the last result
+// is a stable position, even if new transactions were added or pruned between the pages.
+type findTransactionsPosition struct {
+	// the index of the query of the last result
+	searchIndex int
+	// the cursor of the last result within the query, nil if no result of the query was returned yet
+	cursor []byte
+}
+
+// token returns the continuation token of the position, in the format "<query index>.<hex encoded cursor>".
+func (p findTransactionsPosition) token() string {
+	return strconv.Itoa(p.searchIndex) + "." + hex.EncodeToString(p.cursor)
+}
+
+// parseFindTransactionsToken parses the continuation token of findTransactions.
+// An empty token is the position before the first result.
+func parseFindTransactionsToken(token string, searchesCount int) (findTransactionsPosition, error) {
+	if token == "" {
+		return findTransactionsPosition{}, nil
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return findTransactionsPosition{}, ErrInvalidContinuationToken
+	}
+
+	searchIndex, err := strconv.Atoi(parts[0])
+	if err != nil || searchIndex < 0 || searchIndex >= searchesCount {
+		return findTransactionsPosition{}, ErrInvalidContinuationToken
+	}
+
+	cursor, err := hex.DecodeString(parts[1])
+	if err != nil || len(cursor) == 0 {
+		return findTransactionsPosition{}, ErrInvalidContinuationToken
+	}
+
+	return findTransactionsPosition{searchIndex: searchIndex, cursor: cursor}, nil
+}
+
+// streamFindTransactions writes the results of findTransactions with chunked transfer encoding,
+// so that big results don't have to be kept in memory.
+// The JSON document has the same layout as the non-streamed response.
+// If the stream is aborted, an error is written instead of the end of the result and the connection is dropped,
+// so that a client never takes an incomplete result as complete.
+func streamFindTransactions(c *gin.Context, abortSignal <-chan struct{}, forEachResult func(consumer func(txHash hornet.Hash) bool) (PartialResult, bool)) {
+	// flush the written results to the client after this amount of hashes
+	const flushInterval = 1000
+
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := c.Writer
+	if _, err := writer.WriteString(`{"hashes":[`); err != nil {
+		return
+	}
+
+	written := 0
+	var abortErr error
+	partialResult, aborted := forEachResult(func(txHash hornet.Hash) bool {
+		select {
+		case <-abortSignal:
+			abortErr = tangle.ErrOperationAborted
+			return false
+		case <-c.Request.Context().Done():
+			// the client disconnected
+			abortErr = c.Request.Context().Err()
+			return false
+		default:
+		}
+
+		separator := ","
+		if written == 0 {
+			separator = ""
+		}
+
+		if _, err := writer.WriteString(separator + `"` + txHash.Trytes() + `"`); err != nil {
+			abortErr = err
+			return false
+		}
+
+		written++
+		if written%flushInterval == 0 {
+			writer.Flush()
+		}
+		return true
+	})
+
+	if aborted {
+		abortStream(c, abortErr)
+		return
 	}
 
-	c.JSON(http.StatusOK, FindTransactionsReturn{Hashes: txHashes})
+	if partialResult.Truncated {
+		writer.WriteString(`],"truncated":true,"continuationToken":"` + partialResult.ContinuationToken + `"}`)
+	} else {
+		writer.WriteString(`]}`)
+	}
+	writer.Flush()
+}
+
+// abortStream ends a streamed JSON result with an error instead of the end of the result
+// and drops the connection, so that the chunked transfer encoding is not terminated properly.
+func abortStream(c *gin.Context, err error) {
+	if err == nil {
+		err = tangle.ErrOperationAborted
+	}
+
+	errJSON, _ := json.Marshal(err.Error())
+	c.Writer.WriteString(`],"error":` + string(errJSON) + `}`)
+	c.Writer.Flush()
+
+	// HTTP/2 connections can't be hijacked, there the error is the only marker
+	conn, _, hijackErr := c.Writer.Hijack()
+	if hijackErr != nil {
+		return
+	}
+	conn.Close()
 }
 
 // redirect to broadcastTransactions
@@ -3,8 +3,13 @@ package webapi
 import (
 	"github.com/iotaledger/iota.go/trinary"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
+	"github.com/gohornet/hornet/pkg/subscription"
+	"github.com/gohornet/hornet/plugins/database"
+	"github.com/gohornet/hornet/plugins/spammer"
 )
 
 //////////////////// addNeighbors /////////////////////////////////
@@ -66,10 +71,17 @@ type BradcastTransactionsReturn struct {
 
 /////////////////// checkConsistency //////////////////////////////
 
+// CheckConsistency struct
+type CheckConsistency struct {
+	Command string         `mapstructure:"command"`
+	Tails   []trinary.Hash `mapstructure:"tails"`
+}
+
 // CheckConsistencyReturn struct
 type CheckConsistencyReturn struct {
-	State    bool `json:"state"`
-	Duration int  `json:"duration"`
+	State    bool   `json:"state"`
+	Info     string `json:"info,omitempty"`
+	Duration int    `json:"duration"`
 }
 
 //////////////////////// error ////////////////////////////////////
@@ -79,6 +91,14 @@ type ErrorReturn struct {
 	Error string `json:"error"`
 }
 
+// PartialResult marks a result that was truncated because of the result limit of the command.
+type PartialResult struct {
+	// whether the result was truncated
+	Truncated bool `json:"truncated,omitempty"`
+	// the token to pass with the next request to continue after the truncated result
+	ContinuationToken string `json:"continuationToken,omitempty"`
+}
+
 /////////////////// findTransactions //////////////////////////////
 
 // FindTransactions struct
@@ -90,12 +110,17 @@ type FindTransactions struct {
 	Approvees  []trinary.Hash `mapstructure:"approvees"`
 	MaxResults int            `mapstructure:"maxresults"`
 	ValueOnly  bool           `json:"valueOnly"`
+	// the continuation token of a truncated result, used to fetch the next page of the results
+	ContinuationToken string `mapstructure:"continuationToken"`
+	// whether the results are streamed with chunked transfer encoding instead of being collected first
+	Stream bool `mapstructure:"stream"`
 }
 
 // FindTransactionsReturn struct
 type FindTransactionsReturn struct {
-	Hashes   []trinary.Hash `json:"hashes"`
-	Duration int            `json:"duration"`
+	Hashes []trinary.Hash `json:"hashes"`
+	PartialResult
+	Duration int `json:"duration"`
 }
 
 ///////////////////// getBalances /////////////////////////////////
@@ -152,6 +177,8 @@ type GetNodeInfo struct {
 type GetNodeInfoReturn struct {
 	AppName                            string          `json:"appName"`
 	AppVersion                         string          `json:"appVersion"`
+	LatestVersion                      string          `json:"latestVersion,omitempty"`
+	IsOutdated                         bool            `json:"isOutdated"`
 	NodeAlias                          string          `json:"nodeAlias,omitempty"`
 	LatestMilestone                    trinary.Hash    `json:"latestMilestone"`
 	LatestMilestoneIndex               milestone.Index `json:"latestMilestoneIndex"`
@@ -170,6 +197,52 @@ type GetNodeInfoReturn struct {
 	Duration                           int             `json:"duration"`
 }
 
+////////////////// getNodeStats //////////////////////////
+
+// GetNodeStats struct
+type GetNodeStats struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetNodeStatsReturn struct
+type GetNodeStatsReturn struct {
+	// The uptime of the node in milliseconds.
+	Uptime       int64          `json:"uptime"`
+	DatabaseSize DatabaseSize   `json:"databaseSize"`
+	Snapshot     SnapshotStatus `json:"snapshot"`
+	Pruning      PruningStatus  `json:"pruning"`
+	// The amount of objects in the caches of the node.
+	Caches   map[string]int `json:"caches"`
+	Plugins  []string       `json:"plugins"`
+	Duration int            `json:"duration"`
+}
+
+// DatabaseSize contains the sizes of the databases on disk in bytes.
+type DatabaseSize struct {
+	Tangle   int64 `json:"tangle"`
+	Snapshot int64 `json:"snapshot"`
+	Spent    int64 `json:"spent"`
+	Total    int64 `json:"total"`
+}
+
+// SnapshotStatus contains the range of milestones which is retained in the database.
+type SnapshotStatus struct {
+	SnapshotIndex       milestone.Index `json:"snapshotIndex"`
+	EntryPointIndex     milestone.Index `json:"entryPointIndex"`
+	PruningIndex        milestone.Index `json:"pruningIndex"`
+	SolidMilestoneIndex milestone.Index `json:"solidMilestoneIndex"`
+	IsSnapshotting      bool            `json:"isSnapshotting"`
+}
+
+// PruningStatus contains the pruning settings of the node and whether it is pruning right now.
+type PruningStatus struct {
+	Enabled              bool `json:"enabled"`
+	Delay                int  `json:"delay"`
+	MaxAgeHours          int  `json:"maxAgeHours"`
+	TargetDatabaseSizeMB int  `json:"targetDatabaseSizeMB"`
+	IsPruning            bool `json:"isPruning"`
+}
+
 ////////////////// getNodeAPIConfiguration //////////////////////////
 
 // GetNodeAPIConfiguration struct
@@ -179,12 +252,13 @@ type GetNodeAPIConfiguration struct {
 
 // GetNodeAPIConfigurationReturn struct
 type GetNodeAPIConfigurationReturn struct {
-	MaxFindTransactions int             `json:"maxFindTransactions"`
-	MaxRequestsList     int             `json:"maxRequestsList"`
-	MaxGetTrytes        int             `json:"maxGetTrytes"`
-	MaxBodyLength       int             `json:"maxBodyLength"`
-	MilestoneStartIndex milestone.Index `json:"milestoneStartIndex"`
-	Duration            int             `json:"duration"`
+	MaxFindTransactions       int             `json:"maxFindTransactions"`
+	MaxFindTransactionsStream int             `json:"maxFindTransactionsStream"`
+	MaxRequestsList           int             `json:"maxRequestsList"`
+	MaxGetTrytes              int             `json:"maxGetTrytes"`
+	MaxBodyLength             int             `json:"maxBodyLength"`
+	MilestoneStartIndex       milestone.Index `json:"milestoneStartIndex"`
+	Duration                  int             `json:"duration"`
 }
 
 ///////////////// getTipInfo ////////////////////////
@@ -204,6 +278,36 @@ type GetTipInfoReturn struct {
 	Duration       int  `json:"duration"`
 }
 
+///////////////// promoteTransaction ////////////////////////
+
+// PromoteTransaction struct
+type PromoteTransaction struct {
+	Command         string       `mapstructure:"command"`
+	TailTransaction trinary.Hash `mapstructure:"tailTransaction"`
+}
+
+// PromoteTransactionReturn struct
+type PromoteTransactionReturn struct {
+	Transaction trinary.Hash     `json:"transaction"`
+	Trytes      []trinary.Trytes `json:"trytes"`
+	Duration    int              `json:"duration"`
+}
+
+///////////////// reattachTransaction ////////////////////////
+
+// ReattachTransaction struct
+type ReattachTransaction struct {
+	Command         string       `mapstructure:"command"`
+	TailTransaction trinary.Hash `mapstructure:"tailTransaction"`
+}
+
+// ReattachTransactionReturn struct
+type ReattachTransactionReturn struct {
+	TailTransaction trinary.Hash     `json:"tailTransaction"`
+	Trytes          []trinary.Trytes `json:"trytes"`
+	Duration        int              `json:"duration"`
+}
+
 ///////////////// getTransactionsToApprove ////////////////////////
 
 // GetTransactionsToApprove struct
@@ -276,6 +380,8 @@ type WereAddressesSpentFromReturn struct {
 type GetLedgerDiff struct {
 	Command        string          `mapstructure:"command"`
 	MilestoneIndex milestone.Index `mapstructure:"milestoneIndex"`
+	// the continuation token of a truncated result
+	ContinuationToken string `mapstructure:"continuationToken"`
 }
 
 // GetLedgerDiffExt struct
@@ -288,7 +394,8 @@ type GetLedgerDiffExt struct {
 type GetLedgerDiffReturn struct {
 	Diff           map[trinary.Hash]int64 `json:"diff"`
 	MilestoneIndex milestone.Index        `json:"milestoneIndex"`
-	Duration       int                    `json:"duration"`
+	PartialResult
+	Duration int `json:"duration"`
 }
 
 // TxHashWithValue struct
@@ -331,13 +438,81 @@ type GetLedgerDiffExtReturn struct {
 type GetLedgerState struct {
 	Command     string          `mapstructure:"command"`
 	TargetIndex milestone.Index `mapstructure:"targetIndex,omitempty"`
+	// the continuation token of a truncated result
+	ContinuationToken string `mapstructure:"continuationToken"`
 }
 
 // GetLedgerStateReturn struct
 type GetLedgerStateReturn struct {
 	Balances       map[trinary.Hash]uint64 `json:"balances"`
 	MilestoneIndex milestone.Index         `json:"milestoneIndex"`
-	Duration       int                     `json:"duration"`
+	PartialResult
+	Duration int `json:"duration"`
+}
+
+/////////////////// getBandwidthUsage ////////////////////////
+
+// NeighborBandwidthUsage struct
+type NeighborBandwidthUsage struct {
+	Address       string `json:"address"`
+	Alias         string `json:"alias,omitempty"`
+	ReceivedBytes uint64 `json:"receivedBytes"`
+	SentBytes     uint64 `json:"sentBytes"`
+}
+
+// GetBandwidthUsageReturn struct
+type GetBandwidthUsageReturn struct {
+	// the traffic since the start of the node
+	ReceivedBytes uint64 `json:"receivedBytes"`
+	SentBytes     uint64 `json:"sentBytes"`
+	// the traffic of the current accounting period
+	PeriodStart         int64   `json:"periodStart"`
+	PeriodEnd           int64   `json:"periodEnd"`
+	PeriodReceivedBytes uint64  `json:"periodReceivedBytes"`
+	PeriodSentBytes     uint64  `json:"periodSentBytes"`
+	MonthlyBudgetBytes  uint64  `json:"monthlyBudgetBytes"`
+	UsedPercentage      float64 `json:"usedPercentage"`
+	// the amount of messages which were not sent because of the budget since the start of the node
+	ThrottledMessages uint64 `json:"throttledMessages"`
+	// the traffic of the current connections to the neighbors
+	Neighbors []*NeighborBandwidthUsage `json:"neighbors"`
+	Duration  int                       `json:"duration"`
+}
+
+/////////////////// auditLedger ////////////////////////
+
+// AuditLedger struct
+type AuditLedger struct {
+	Command string `mapstructure:"command"`
+	// the addresses to recompute, all addresses are recomputed if empty
+	Addresses []trinary.Hash `mapstructure:"addresses"`
+}
+
+// LedgerAuditMismatch struct
+type LedgerAuditMismatch struct {
+	Address           trinary.Hash `json:"address"`
+	Balance           uint64       `json:"balance"`
+	RecomputedBalance uint64       `json:"recomputedBalance"`
+}
+
+// AuditLedgerReturn struct
+type AuditLedgerReturn struct {
+	Valid            bool                   `json:"valid"`
+	SnapshotIndex    milestone.Index        `json:"snapshotIndex"`
+	LedgerIndex      milestone.Index        `json:"ledgerIndex"`
+	TotalSupply      uint64                 `json:"totalSupply"`
+	SnapshotTotal    uint64                 `json:"snapshotTotal"`
+	LedgerTotal      uint64                 `json:"ledgerTotal"`
+	TotalSupplyValid bool                   `json:"totalSupplyValid"`
+	AddressesChecked int                    `json:"addressesChecked"`
+	InvalidDiffs     []milestone.Index      `json:"invalidDiffs"`
+	Mismatches       []*LedgerAuditMismatch `json:"mismatches"`
+	// the amount of ignored conflicting bundles per milestone, milestones without conflicts are omitted
+	ConflictingBundles map[milestone.Index]int `json:"conflictingBundles"`
+	// the milestones which were confirmed before the count was stored, they are not part of the total
+	UnknownConflictingBundles []milestone.Index `json:"conflictingBundlesUnknown"`
+	TotalConflictingBundles   int               `json:"totalConflictingBundles"`
+	Duration                  int               `json:"duration"`
 }
 
 /////////////////// createSnapshotFile ////////////////////////
@@ -360,10 +535,139 @@ type PruneDatabase struct {
 	Command     string          `mapstructure:"command"`
 	TargetIndex milestone.Index `mapstructure:"targetIndex"`
 	Depth       milestone.Index `mapstructure:"depth"`
+	MaxAgeHours int             `mapstructure:"maxAgeHours"`
 }
 
 // PruneDatabaseReturn struct
 type PruneDatabaseReturn struct {
+	PruningIndex milestone.Index `json:"pruningIndex"`
+	Milestones   int             `json:"milestones"`
+	Transactions int             `json:"transactions"`
+	Duration     int             `json:"duration"`
+}
+
+/////////////////// whiteFlag ////////////////////////
+
+// WhiteFlag struct
+type WhiteFlag struct {
+	Command             string          `mapstructure:"command"`
+	TrunkTransaction    trinary.Hash    `mapstructure:"trunkTransaction"`
+	BranchTransaction   trinary.Hash    `mapstructure:"branchTransaction"`
+	MilestoneIndex      milestone.Index `mapstructure:"milestoneIndex"`
+	TimeoutMilliseconds int             `mapstructure:"timeoutMilliseconds"`
+}
+
+// WhiteFlagReturn struct
+type WhiteFlagReturn struct {
+	MilestoneIndex  milestone.Index `json:"milestoneIndex"`
+	MerkleTreeHash  string          `json:"merkleTreeHash"`
+	TailsIncluded   int             `json:"tailsIncluded"`
+	TailsReferenced int             `json:"tailsReferenced"`
+	Duration        int             `json:"duration"`
+}
+
+/////////////////// exportSpentAddresses ////////////////////////
+
+// ExportSpentAddresses struct
+type ExportSpentAddresses struct {
+	Command  string `mapstructure:"command"`
+	FileName string `mapstructure:"fileName"`
+}
+
+// ExportSpentAddressesReturn struct
+type ExportSpentAddressesReturn struct {
+	FileName string `json:"fileName"`
+	Count    int32  `json:"count"`
+	Duration int    `json:"duration"`
+}
+
+/////////////////// importSpentAddresses ////////////////////////
+
+// ImportSpentAddresses struct
+type ImportSpentAddresses struct {
+	Command  string `mapstructure:"command"`
+	FileName string `mapstructure:"fileName"`
+}
+
+// ImportSpentAddressesReturn struct
+type ImportSpentAddressesReturn struct {
+	Imported int32 `json:"imported"`
+	Duration int   `json:"duration"`
+}
+
+/////////////////// compactDatabase ////////////////////////
+
+// CompactDatabaseReturn struct
+type CompactDatabaseReturn struct {
+	Status   database.CompactionStatus `json:"status"`
+	Duration int                       `json:"duration"`
+}
+
+/////////////////// getDatabaseCompactionStatus ////////////////////////
+
+// GetDatabaseCompactionStatusReturn struct
+type GetDatabaseCompactionStatusReturn struct {
+	Status   database.CompactionStatus `json:"status"`
+	Duration int                       `json:"duration"`
+}
+
+/////////////////// createDatabaseBackup ////////////////////////
+
+// CreateDatabaseBackupReturn struct
+type CreateDatabaseBackupReturn struct {
+	Status   database.BackupStatus `json:"status"`
+	Duration int                   `json:"duration"`
+}
+
+/////////////////// getDatabaseBackupStatus ////////////////////////
+
+// GetDatabaseBackupStatusReturn struct
+type GetDatabaseBackupStatusReturn struct {
+	Status   database.BackupStatus `json:"status"`
+	Duration int                   `json:"duration"`
+}
+
+/////////////////// spammer ////////////////////////
+
+// Spammer struct
+type Spammer struct {
+	Command             string          `mapstructure:"command"`
+	Cmd                 string          `mapstructure:"cmd"`
+	Address             *trinary.Hash   `mapstructure:"address"`
+	Message             *string         `mapstructure:"message"`
+	Tag                 *trinary.Trytes `mapstructure:"tag"`
+	TagSemiLazy         *trinary.Trytes `mapstructure:"tagSemiLazy"`
+	CPUMaxUsage         *float64        `mapstructure:"cpuMaxUsage"`
+	TPSRateLimit        *float64        `mapstructure:"tpsRateLimit"`
+	BundleSize          *int            `mapstructure:"bundleSize"`
+	ValueSpam           *bool           `mapstructure:"valueSpam"`
+	MaxRequestQueueSize *int            `mapstructure:"maxRequestQueueSize"`
+}
+
+// SpammerReturn struct
+type SpammerReturn struct {
+	Status   spammer.Status `json:"status"`
+	Duration int            `json:"duration"`
+}
+
+/////////////////// plugins ////////////////////////
+
+// ControlPlugin struct
+type ControlPlugin struct {
+	Command string `mapstructure:"command"`
+	Plugin  string `mapstructure:"plugin"`
+}
+
+// GetPluginsReturn struct
+type GetPluginsReturn struct {
+	Plugins  []plugincontrol.Status `json:"plugins"`
+	Duration int                    `json:"duration"`
+}
+
+/////////////////// reloadConfig ////////////////////////
+
+// ReloadConfigReturn struct
+type ReloadConfigReturn struct {
 	Duration int `json:"duration"`
 }
 
@@ -456,3 +760,147 @@ type AddressWithBalance struct {
 	Address trinary.Hash `mapstructure:"address"`
 	Balance uint64       `mapstructure:"balance"`
 }
+
+///////////////////////////// auth //////////////////////////////
+
+// Login struct
+type Login struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginReturn struct
+type LoginReturn struct {
+	JWT string `json:"jwt"`
+}
+
+//////////////////////// REST API v1 //////////////////////////////
+
+// RESTError struct
+type RESTError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RESTErrorReturn struct
+type RESTErrorReturn struct {
+	Error RESTError `json:"error"`
+}
+
+// TransactionV1Return struct
+type TransactionV1Return struct {
+	Hash                       trinary.Hash    `json:"hash"`
+	Trytes                     trinary.Trytes  `json:"trytes"`
+	Solid                      bool            `json:"solid"`
+	Confirmed                  bool            `json:"confirmed"`
+	ConfirmationMilestoneIndex milestone.Index `json:"confirmationMilestoneIndex,omitempty"`
+	Conflicting                bool            `json:"conflicting"`
+}
+
+// BroadcastTransactionsV1 struct
+type BroadcastTransactionsV1 struct {
+	Trytes []trinary.Trytes `json:"trytes"`
+}
+
+// AddressBalanceV1Return struct
+type AddressBalanceV1Return struct {
+	Address        trinary.Hash    `json:"address"`
+	Balance        uint64          `json:"balance"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+}
+
+// NeighborV1 struct
+type NeighborV1 struct {
+	Identity   string `json:"identity" binding:"required"`
+	Alias      string `json:"alias"`
+	PreferIPv6 *bool  `json:"preferIPv6"`
+}
+
+// NeighborsV1Return struct
+type NeighborsV1Return struct {
+	Neighbors       []*peer.Info        `json:"neighbors"`
+	StaticNeighbors []config.PeerConfig `json:"staticNeighbors"`
+}
+
+/////////////////////////// websocket /////////////////////////////
+
+// WSRequest struct
+type WSRequest struct {
+	Type      string           `json:"type"`
+	Topic     string           `json:"topic"`
+	Addresses []trinary.Hash   `json:"addresses,omitempty"`
+	Tags      []trinary.Trytes `json:"tags,omitempty"`
+}
+
+// WSMessage struct
+type WSMessage struct {
+	Type  string      `json:"type"`
+	Topic string      `json:"topic,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// WSTransaction struct
+type WSTransaction struct {
+	Hash           trinary.Hash    `json:"hash"`
+	Address        trinary.Hash    `json:"address"`
+	Value          int64           `json:"value"`
+	ObsoleteTag    trinary.Trytes  `json:"obsoleteTag"`
+	Timestamp      uint64          `json:"timestamp"`
+	CurrentIndex   uint64          `json:"currentIndex"`
+	LastIndex      uint64          `json:"lastIndex"`
+	Bundle         trinary.Hash    `json:"bundle"`
+	Trunk          trinary.Hash    `json:"trunk"`
+	Branch         trinary.Hash    `json:"branch"`
+	Tag            trinary.Trytes  `json:"tag"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+}
+
+// WSMilestone struct
+type WSMilestone struct {
+	Index milestone.Index `json:"index"`
+	Hash  trinary.Hash    `json:"hash"`
+}
+
+/////////////////////////// subscriptions /////////////////////////////
+
+// RegisterSubscription struct
+type RegisterSubscription struct {
+	Command   string           `mapstructure:"command"`
+	Addresses []trinary.Hash   `mapstructure:"addresses"`
+	Tags      []trinary.Trytes `mapstructure:"tags"`
+}
+
+// RegisterSubscriptionReturn struct
+type RegisterSubscriptionReturn struct {
+	ID       string `json:"id"`
+	Cursor   uint64 `json:"cursor"`
+	Duration int    `json:"duration"`
+}
+
+// GetSubscriptionEvents struct
+type GetSubscriptionEvents struct {
+	Command string `mapstructure:"command"`
+	ID      string `mapstructure:"id"`
+	Cursor  uint64 `mapstructure:"cursor"`
+	Limit   int    `mapstructure:"limit"`
+}
+
+// GetSubscriptionEventsReturn struct
+type GetSubscriptionEventsReturn struct {
+	Events       []*subscription.Event `json:"events"`
+	Cursor       uint64                `json:"cursor"`
+	MissedEvents bool                  `json:"missedEvents"`
+	Duration     int                   `json:"duration"`
+}
+
+// RemoveSubscription struct
+type RemoveSubscription struct {
+	Command string `mapstructure:"command"`
+	ID      string `mapstructure:"id"`
+}
+
+// RemoveSubscriptionReturn struct
+type RemoveSubscriptionReturn struct {
+	Duration int `json:"duration"`
+}
@@ -0,0 +1,495 @@
+package webapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/workerpool"
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/tangle"
+)
+
+const (
+	webSocketRoute = "ws"
+
+	// wsTopicTransactions streams all new transactions, optionally filtered by address or tag
+	wsTopicTransactions = "transactions"
+	// wsTopicConfirmed streams all confirmed transactions, optionally filtered by address or tag
+	wsTopicConfirmed = "confirmed"
+	// wsTopicMilestones streams all new latest milestones
+	wsTopicMilestones = "milestones"
+	// wsTopicSolidMilestones streams all new solid milestones
+	wsTopicSolidMilestones = "solidMilestones"
+
+	wsTypeSubscribe    = "subscribe"
+	wsTypeUnsubscribe  = "unsubscribe"
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypeEvent        = "event"
+	wsTypeError        = "error"
+
+	wsWriteTimeout   = 5 * time.Second
+	wsPongTimeout    = 60 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsMaxRequestSize = 64 * 1024
+)
+
+var (
+	wsUpgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		// the event stream is public, so every origin is allowed (same as the CORS policy of the API)
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+	wsTopics = map[string]bool{
+		wsTopicTransactions:    true,
+		wsTopicConfirmed:       true,
+		wsTopicMilestones:      false,
+		wsTopicSolidMilestones: false,
+	}
+
+	wsClients     = make(map[*wsClient]struct{})
+	wsClientsLock sync.RWMutex
+
+	wsMaxClients    int
+	wsMaxFilters    int
+	wsSendQueueSize int
+
+	wsNewTxWorkerCount     = 1
+	wsNewTxWorkerQueueSize = 10000
+	wsNewTxWorkerPool      *workerpool.WorkerPool
+
+	wsConfirmedTxWorkerCount     = 1
+	wsConfirmedTxWorkerQueueSize = 10000
+	wsConfirmedTxWorkerPool      *workerpool.WorkerPool
+
+	wsMilestoneWorkerCount     = 1
+	wsMilestoneWorkerQueueSize = 100
+	wsMilestoneWorkerPool      *workerpool.WorkerPool
+)
+
+// wsFilter restricts the transactions of a subscription to the given addresses and tags.
+// A filter without any addresses and tags matches all transactions.
+type wsFilter struct {
+	addresses map[trinary.Hash]struct{}
+	tags      map[trinary.Trytes]struct{}
+}
+
+func (f *wsFilter) matches(tx *transaction.Transaction) bool {
+	if len(f.addresses) == 0 && len(f.tags) == 0 {
+		return true
+	}
+
+	if _, exists := f.addresses[tx.Address]; exists {
+		return true
+	}
+
+	_, exists := f.tags[tx.Tag]
+	return exists
+}
+
+// parseFilters validates the given address and tag filters and brings them into the form
+// of the address and tag of transactions (addresses without checksum, padded tags).
+func parseFilters(addresses []trinary.Hash, tags []trinary.Trytes, maxFilters int) ([]trinary.Hash, []trinary.Trytes, error) {
+	if len(addresses)+len(tags) > maxFilters {
+		return nil, nil, fmt.Errorf("too many filters, max. %d allowed", maxFilters)
+	}
+
+	parsedAddresses := make([]trinary.Hash, 0, len(addresses))
+	for _, addr := range addresses {
+		if err := address.ValidAddress(addr); err != nil {
+			return nil, nil, fmt.Errorf("address hash invalid: %s", addr)
+		}
+		parsedAddresses = append(parsedAddresses, addr[:81])
+	}
+
+	parsedTags := make([]trinary.Trytes, 0, len(tags))
+	for _, tag := range tags {
+		if len(tag) > 27 {
+			return nil, nil, fmt.Errorf("tag invalid length: %s", tag)
+		}
+		paddedTag, err := trinary.Pad(tag, 27)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tag invalid: %s", tag)
+		}
+		parsedTags = append(parsedTags, paddedTag)
+	}
+
+	return parsedAddresses, parsedTags, nil
+}
+
+// wsClient is a connected WebSocket client and its subscriptions.
+type wsClient struct {
+	conn      *websocket.Conn
+	sendQueue chan *WSMessage
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	subscriptionsLock sync.RWMutex
+	subscriptions     map[string]*wsFilter
+}
+
+// send queues the message for the client. The message is dropped if the client is too slow.
+func (c *wsClient) send(msg *WSMessage) {
+	select {
+	case <-c.closed:
+	case c.sendQueue <- msg:
+	default:
+	}
+}
+
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+// isSubscribed returns whether the client is subscribed to the topic and the transaction passes its filter.
+// tx can be nil for topics without filters.
+func (c *wsClient) isSubscribed(topic string, tx *transaction.Transaction) bool {
+	c.subscriptionsLock.RLock()
+	defer c.subscriptionsLock.RUnlock()
+
+	filter, subscribed := c.subscriptions[topic]
+	if !subscribed {
+		return false
+	}
+
+	return tx == nil || filter.matches(tx)
+}
+
+func (c *wsClient) handleRequest(req *WSRequest) error {
+	filterable, exists := wsTopics[req.Topic]
+	if !exists {
+		return fmt.Errorf("unknown topic: %s", req.Topic)
+	}
+
+	switch req.Type {
+	case wsTypeSubscribe:
+		if !filterable && (len(req.Addresses) > 0 || len(req.Tags) > 0) {
+			return fmt.Errorf("topic %s can not be filtered", req.Topic)
+		}
+
+		addresses, tags, err := parseFilters(req.Addresses, req.Tags, wsMaxFilters)
+		if err != nil {
+			return err
+		}
+
+		filter := &wsFilter{
+			addresses: make(map[trinary.Hash]struct{}),
+			tags:      make(map[trinary.Trytes]struct{}),
+		}
+
+		for _, addr := range addresses {
+			filter.addresses[addr] = struct{}{}
+		}
+
+		for _, tag := range tags {
+			filter.tags[tag] = struct{}{}
+		}
+
+		c.subscriptionsLock.Lock()
+		c.subscriptions[req.Topic] = filter
+		c.subscriptionsLock.Unlock()
+
+		c.send(&WSMessage{Type: wsTypeSubscribed, Topic: req.Topic})
+
+	case wsTypeUnsubscribe:
+		c.subscriptionsLock.Lock()
+		delete(c.subscriptions, req.Topic)
+		c.subscriptionsLock.Unlock()
+
+		c.send(&WSMessage{Type: wsTypeUnsubscribed, Topic: req.Topic})
+
+	default:
+		return fmt.Errorf("unknown request type: %s", req.Type)
+	}
+
+	return nil
+}
+
+// readLoop handles the subscription requests of the client until the connection is closed.
+func (c *wsClient) readLoop() {
+	defer c.close()
+
+	c.conn.SetReadLimit(wsMaxRequestSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		req := &WSRequest{}
+		if err := json.Unmarshal(data, req); err != nil {
+			c.send(&WSMessage{Type: wsTypeError, Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+
+		if err := c.handleRequest(req); err != nil {
+			c.send(&WSMessage{Type: wsTypeError, Topic: req.Topic, Error: err.Error()})
+		}
+	}
+}
+
+// writeLoop writes the queued messages and keep-alive pings to the client until the connection is closed.
+func (c *wsClient) writeLoop() {
+	defer c.close()
+
+	pingTicker := time.NewTicker(wsPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+
+		case msg := <-c.sendQueue:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+
+		case <-pingTicker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// configureWebSocket sets up the public WebSocket event stream route and its worker pools if it is enabled.
+// The route is registered without auth, since browsers are not able to set custom headers for WebSocket connections.
+func configureWebSocket() {
+	if !config.NodeConfig.GetBool(config.CfgWebAPIWebSocketEnabled) || config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
+		return
+	}
+
+	wsMaxClients = config.NodeConfig.GetInt(config.CfgWebAPIWebSocketMaxClients)
+	wsMaxFilters = config.NodeConfig.GetInt(config.CfgWebAPIWebSocketMaxFilters)
+	wsSendQueueSize = config.NodeConfig.GetInt(config.CfgWebAPIWebSocketSendQueueSize)
+
+	wsNewTxWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onWSNewTx(task.Param(0).(*tanglePackage.CachedTransaction)) // tx pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(wsNewTxWorkerCount), workerpool.QueueSize(wsNewTxWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	wsConfirmedTxWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onWSConfirmedTx(task.Param(0).(*tanglePackage.CachedMetadata), task.Param(1).(milestone.Index)) // meta pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(wsConfirmedTxWorkerCount), workerpool.QueueSize(wsConfirmedTxWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	wsMilestoneWorkerPool = workerpool.New(func(task workerpool.Task) {
+		onWSMilestone(task.Param(0).(string), task.Param(1).(*tanglePackage.CachedBundle)) // bundle pass +1
+		task.Return(nil)
+	}, workerpool.WorkerCount(wsMilestoneWorkerCount), workerpool.QueueSize(wsMilestoneWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+
+	api.GET(webSocketRoute, func(c *gin.Context) {
+		wsClientsLock.RLock()
+		clientCount := len(wsClients)
+		wsClientsLock.RUnlock()
+
+		if clientCount >= wsMaxClients {
+			c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: "maximum number of WebSocket clients reached"})
+			return
+		}
+
+		conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+
+		client := &wsClient{
+			conn:          conn,
+			sendQueue:     make(chan *WSMessage, wsSendQueueSize),
+			closed:        make(chan struct{}),
+			subscriptions: make(map[string]*wsFilter),
+		}
+
+		wsClientsLock.Lock()
+		wsClients[client] = struct{}{}
+		wsClientsLock.Unlock()
+
+		go client.writeLoop()
+		client.readLoop()
+
+		wsClientsLock.Lock()
+		delete(wsClients, client)
+		wsClientsLock.Unlock()
+	})
+}
+
+// hasWSSubscribers returns whether at least one client is subscribed to the topic.
+func hasWSSubscribers(topic string) bool {
+	wsClientsLock.RLock()
+	defer wsClientsLock.RUnlock()
+
+	for client := range wsClients {
+		if client.isSubscribed(topic, nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// publishWS sends the event to all clients which are subscribed to the topic and whose filter matches the transaction.
+// tx can be nil for topics without filters.
+func publishWS(topic string, tx *transaction.Transaction, data interface{}) {
+	msg := &WSMessage{Type: wsTypeEvent, Topic: topic, Data: data}
+
+	wsClientsLock.RLock()
+	defer wsClientsLock.RUnlock()
+
+	for client := range wsClients {
+		if client.isSubscribed(topic, tx) {
+			client.send(msg)
+		}
+	}
+}
+
+func closeWSClients() {
+	wsClientsLock.RLock()
+	defer wsClientsLock.RUnlock()
+
+	for client := range wsClients {
+		client.close()
+	}
+}
+
+func wsTransaction(tx *transaction.Transaction, msIndex milestone.Index) *WSTransaction {
+	return &WSTransaction{
+		Hash:           tx.Hash,
+		Address:        tx.Address,
+		Value:          tx.Value,
+		ObsoleteTag:    tx.ObsoleteTag,
+		Timestamp:      tx.Timestamp,
+		CurrentIndex:   tx.CurrentIndex,
+		LastIndex:      tx.LastIndex,
+		Bundle:         tx.Bundle,
+		Trunk:          tx.TrunkTransaction,
+		Branch:         tx.BranchTransaction,
+		Tag:            tx.Tag,
+		MilestoneIndex: msIndex,
+	}
+}
+
+func onWSNewTx(cachedTx *tanglePackage.CachedTransaction) {
+	cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) { // tx -1
+		publishWS(wsTopicTransactions, tx.Tx, wsTransaction(tx.Tx, 0))
+	})
+}
+
+func onWSConfirmedTx(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index) {
+	cachedMeta.ConsumeMetadata(func(metadata *hornet.TransactionMetadata) { // meta -1
+		cachedTx := tanglePackage.GetCachedTransactionOrNil(metadata.GetTxHash()) // tx +1
+		if cachedTx == nil {
+			return
+		}
+
+		cachedTx.ConsumeTransaction(func(tx *hornet.Transaction) { // tx -1
+			publishWS(wsTopicConfirmed, tx.Tx, wsTransaction(tx.Tx, msIndex))
+		})
+	})
+}
+
+func onWSMilestone(topic string, cachedBndl *tanglePackage.CachedBundle) {
+	defer cachedBndl.Release(true) // bundle -1
+
+	publishWS(topic, nil, &WSMilestone{
+		Index: cachedBndl.GetBundle().GetMilestoneIndex(),
+		Hash:  cachedBndl.GetBundle().GetMilestoneHash().Trytes(),
+	})
+}
+
+// runWebSocket attaches the WebSocket event stream to the tangle events.
+func runWebSocket() {
+	if wsNewTxWorkerPool == nil {
+		return
+	}
+
+	onReceivedNewTransaction := events.NewClosure(func(cachedTx *tanglePackage.CachedTransaction, _ milestone.Index, _ milestone.Index) {
+		if hasWSSubscribers(wsTopicTransactions) {
+			if _, added := wsNewTxWorkerPool.TrySubmit(cachedTx); added { // tx pass +1
+				return // Avoid tx -1 (done inside workerpool task)
+			}
+		}
+		cachedTx.Release(true) // tx -1
+	})
+
+	onTransactionConfirmed := events.NewClosure(func(cachedMeta *tanglePackage.CachedMetadata, msIndex milestone.Index, _ int64) {
+		// Avoid notifying for conflicting txs
+		if !cachedMeta.GetMetadata().IsConflicting() && hasWSSubscribers(wsTopicConfirmed) {
+			if _, added := wsConfirmedTxWorkerPool.TrySubmit(cachedMeta, msIndex); added { // meta pass +1
+				return // Avoid meta -1 (done inside workerpool task)
+			}
+		}
+		cachedMeta.Release(true) // meta -1
+	})
+
+	onLatestMilestoneChanged := events.NewClosure(func(cachedBndl *tanglePackage.CachedBundle) {
+		if hasWSSubscribers(wsTopicMilestones) {
+			if _, added := wsMilestoneWorkerPool.TrySubmit(wsTopicMilestones, cachedBndl); added { // bundle pass +1
+				return // Avoid bundle -1 (done inside workerpool task)
+			}
+		}
+		cachedBndl.Release(true) // bundle -1
+	})
+
+	onSolidMilestoneChanged := events.NewClosure(func(cachedBndl *tanglePackage.CachedBundle) {
+		if hasWSSubscribers(wsTopicSolidMilestones) {
+			if _, added := wsMilestoneWorkerPool.TrySubmit(wsTopicSolidMilestones, cachedBndl); added { // bundle pass +1
+				return // Avoid bundle -1 (done inside workerpool task)
+			}
+		}
+		cachedBndl.Release(true) // bundle -1
+	})
+
+	daemon.BackgroundWorker("WebAPI[WebSocket]", func(shutdownSignal <-chan struct{}) {
+		tangle.Events.ReceivedNewTransaction.Attach(onReceivedNewTransaction)
+		tangle.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		tangle.Events.LatestMilestoneChanged.Attach(onLatestMilestoneChanged)
+		tangle.Events.SolidMilestoneChanged.Attach(onSolidMilestoneChanged)
+		wsNewTxWorkerPool.Start()
+		wsConfirmedTxWorkerPool.Start()
+		wsMilestoneWorkerPool.Start()
+
+		<-shutdownSignal
+		log.Info("Stopping WebAPI[WebSocket] ...")
+
+		tangle.Events.ReceivedNewTransaction.Detach(onReceivedNewTransaction)
+		tangle.Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+		tangle.Events.LatestMilestoneChanged.Detach(onLatestMilestoneChanged)
+		tangle.Events.SolidMilestoneChanged.Detach(onSolidMilestoneChanged)
+		wsNewTxWorkerPool.StopAndWait()
+		wsConfirmedTxWorkerPool.StopAndWait()
+		wsMilestoneWorkerPool.StopAndWait()
+
+		// hijacked connections are not closed by the shutdown of the HTTP server
+		closeWSClients()
+
+		log.Info("Stopping WebAPI[WebSocket] ... done")
+	}, shutdown.PriorityAPI)
+}
@@ -0,0 +1,124 @@
+package webapi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/guards"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+)
+
+const (
+	// the maximum time to wait for the solidification of the requested cone
+	whiteFlagMaxTimeout = 10 * time.Second
+	// the interval in which the solidification of the requested cone is checked
+	whiteFlagSolidCheckInterval = 50 * time.Millisecond
+)
+
+func init() {
+	addEndpoint("whiteFlag", computeWhiteFlag, implementedAPIcalls)
+}
+
+// isSolidOrSolidEntryPoint returns whether the transaction is solid or a solid entry point.
+func isSolidOrSolidEntryPoint(txHash hornet.Hash) bool {
+	if tangle.SolidEntryPointsContain(txHash) {
+		return true
+	}
+
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+	if cachedTxMeta == nil {
+		return false
+	}
+	defer cachedTxMeta.Release(true) // meta -1
+
+	return cachedTxMeta.GetMetadata().IsSolid()
+}
+
+// computeWhiteFlag computes the merkle tree hash of the white-flag mutations of the cone referenced by trunk and branch,
+// which would be confirmed by the next milestone. It is used by the coordinator to verify its ledger state with other nodes.
+func computeWhiteFlag(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &WhiteFlag{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TrunkTransaction) || !guards.IsTransactionHash(query.BranchTransaction) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	timeout := time.Duration(query.TimeoutMilliseconds) * time.Millisecond
+	if timeout <= 0 || timeout > whiteFlagMaxTimeout {
+		timeout = whiteFlagMaxTimeout
+	}
+	deadline := time.After(timeout)
+
+	trunkHash := hornet.HashFromHashTrytes(query.TrunkTransaction)
+	branchHash := hornet.HashFromHashTrytes(query.BranchTransaction)
+
+	// the previous milestone has to be solid and the cone has to be solid, otherwise the result would differ.
+	// the transactions of the cone could still be on their way to this node, so wait for them until the timeout.
+	for tangle.GetSolidMilestoneIndex() != query.MilestoneIndex-1 || !isSolidOrSolidEntryPoint(trunkHash) || !isSolidOrSolidEntryPoint(branchHash) {
+		if tangle.GetSolidMilestoneIndex() >= query.MilestoneIndex {
+			e.Error = fmt.Sprintf("milestone %d is already solid", query.MilestoneIndex)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		select {
+		case <-abortSignal:
+			e.Error = "operation aborted"
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		case <-deadline:
+			e.Error = fmt.Sprintf("cone of milestone %d did not become solid in time", query.MilestoneIndex)
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		case <-time.After(whiteFlagSolidCheckInterval):
+		}
+	}
+
+	cachedTxMetas := make(map[string]*tangle.CachedMetadata)
+	cachedBundles := make(map[string]*tangle.CachedBundle)
+
+	defer func() {
+		// release all bundles at the end
+		for _, cachedBundle := range cachedBundles {
+			cachedBundle.Release(true) // bundle -1
+		}
+
+		// Release all tx metadata at the end
+		for _, cachedTxMeta := range cachedTxMetas {
+			cachedTxMeta.Release(true) // meta -1
+		}
+	}()
+
+	tangle.ReadLockLedger()
+	mutations, err := whiteflag.ComputeWhiteFlagMutations(cachedTxMetas, cachedBundles, tangle.GetMilestoneMerkleHashFunc(), trunkHash, branchHash)
+	tangle.ReadUnlockLedger()
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, WhiteFlagReturn{
+		MilestoneIndex:  query.MilestoneIndex,
+		MerkleTreeHash:  hex.EncodeToString(mutations.MerkleTreeHash),
+		TailsIncluded:   len(mutations.TailsIncluded),
+		TailsReferenced: len(mutations.TailsReferenced),
+	})
+}
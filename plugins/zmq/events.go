@@ -103,6 +103,9 @@ func onSpentAddress(addr trinary.Hash) {
 
 // Publish latest milestone index
 func publishLMI(lmi milestone.Index) error {
+	if !isTopicEnabled(topicLMI) {
+		return nil
+	}
 
 	messages := []string{
 		strconv.FormatInt(int64(prevLMI), 10), // Index of the previous solid subtangle milestone
@@ -117,6 +120,9 @@ func publishLMI(lmi milestone.Index) error {
 
 // Publish latest solid subtangle milestone index
 func publishLMSI(smi milestone.Index) error {
+	if !isTopicEnabled(topicLMSI) {
+		return nil
+	}
 
 	messages := []string{
 		strconv.FormatInt(int64(prevSMI), 10), // Index of the previous solid subtangle milestone
@@ -131,6 +137,10 @@ func publishLMSI(smi milestone.Index) error {
 
 // Publish latest solid subtangle milestone hash
 func publishLMHS(solidMilestoneHash trinary.Hash) error {
+	if !isTopicEnabled(topicLMHS) {
+		return nil
+	}
+
 	messages := []string{
 		solidMilestoneHash, // Solid milestone transaction hash
 	}
@@ -140,6 +150,10 @@ func publishLMHS(solidMilestoneHash trinary.Hash) error {
 
 // Publish latest milestone
 func publishLM(bndl *tangle.Bundle) error {
+	if !isTopicEnabled(topicLM) {
+		return nil
+	}
+
 	messages := []string{
 		strconv.FormatUint(uint64(bndl.GetMilestoneIndex()), 10),
 		bndl.GetMilestoneHash().Trytes(),
@@ -150,6 +164,10 @@ func publishLM(bndl *tangle.Bundle) error {
 
 // Publish latest solid subtangle milestone
 func publishLSM(bndl *tangle.Bundle) error {
+	if !isTopicEnabled(topicLSM) {
+		return nil
+	}
+
 	messages := []string{
 		strconv.FormatUint(uint64(bndl.GetMilestoneIndex()), 10),
 		bndl.GetMilestoneHash().Trytes(),
@@ -160,6 +178,9 @@ func publishLSM(bndl *tangle.Bundle) error {
 
 // Publish confirmed transaction
 func publishConfTx(iotaTx *transaction.Transaction, msIndex milestone.Index) error {
+	if !isTopicEnabled(topicSN) {
+		return nil
+	}
 
 	messages := []string{
 		strconv.FormatInt(int64(msIndex), 10), // Index of the milestone that confirmed the transaction
@@ -175,6 +196,9 @@ func publishConfTx(iotaTx *transaction.Transaction, msIndex milestone.Index) err
 
 // Publish confirmed trytes
 func publishConfTrytes(iotaTx *transaction.Transaction, msIndex milestone.Index) error {
+	if !isTopicEnabled(topicConfTrytes) {
+		return nil
+	}
 
 	trytes, err := transaction.TransactionToTrytes(iotaTx)
 	if err != nil {
@@ -192,6 +216,9 @@ func publishConfTrytes(iotaTx *transaction.Transaction, msIndex milestone.Index)
 
 // Publish transaction trytes of an tx that has recently been added to the ledger
 func publishTxTrytes(iotaTx *transaction.Transaction) error {
+	if !isTopicEnabled(topicTxTrytes) {
+		return nil
+	}
 
 	trytes, err := transaction.TransactionToTrytes(iotaTx)
 	if err != nil {
@@ -208,6 +235,9 @@ func publishTxTrytes(iotaTx *transaction.Transaction) error {
 
 // Publish a transaction that has recently been added to the ledger
 func publishTx(iotaTx *transaction.Transaction) error {
+	if !isTopicEnabled(topicTX) {
+		return nil
+	}
 
 	messages := []string{
 		iotaTx.Hash,                         // Transaction hash
@@ -239,5 +269,9 @@ func publishConfTxForAddress(iotaTx *transaction.Transaction, msIndex milestone.
 }
 
 func publishSpentAddress(addr trinary.Hash) error {
+	if !isTopicEnabled(topicSpentAddress) {
+		return nil
+	}
+
 	return publisher.Send(topicSpentAddress, []string{addr})
 }
@@ -5,7 +5,6 @@ import (
 
 	"github.com/iotaledger/iota.go/trinary"
 
-	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
@@ -15,6 +14,7 @@ import (
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	tanglePackage "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/plugincontrol"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/plugins/tangle"
 )
@@ -23,6 +23,9 @@ var (
 	PLUGIN = node.NewPlugin("ZMQ", node.Disabled, configure, run)
 	log    *logger.Logger
 
+	// the plugin can be started and stopped at runtime
+	control *plugincontrol.Plugin
+
 	newTxWorkerCount     = 1
 	newTxWorkerQueueSize = 10000
 	newTxWorkerPool      *workerpool.WorkerPool
@@ -49,9 +52,19 @@ var (
 )
 
 // Configure the zmq plugin
+func init() {
+	control = plugincontrol.Register(PLUGIN)
+}
+
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(plugin.Name)
 
+	configureTopics()
+}
+
+// configureWorkerPools creates the worker pools of the publishers.
+// The worker pools can't be started again after they were stopped, so they are created at every start of the plugin.
+func configureWorkerPools() {
 	newTxWorkerPool = workerpool.New(func(task workerpool.Task) {
 		onNewTx(task.Param(0).(*tanglePackage.CachedTransaction)) // tx pass +1
 		task.Return(nil)
@@ -80,6 +93,8 @@ func configure(plugin *node.Plugin) {
 
 // Start the zmq plugin
 func run(_ *node.Plugin) {
+	configureWorkerPools()
+
 	log.Info("Starting ZMQ Publisher ...")
 
 	onReceivedNewTransaction := events.NewClosure(func(cachedTx *tanglePackage.CachedTransaction, latestMilestoneIndex milestone.Index, latestSolidMilestoneIndex milestone.Index) {
@@ -91,6 +106,11 @@ func run(_ *node.Plugin) {
 			wasSyncBefore = true
 		}
 
+		if !isTopicEnabled(topicTX) && !isTopicEnabled(topicTxTrytes) {
+			cachedTx.Release(true) // tx -1
+			return
+		}
+
 		if _, added := newTxWorkerPool.TrySubmit(cachedTx); added { // tx pass +1
 			return // Avoid tx -1 (done inside workerpool task)
 		}
@@ -142,7 +162,7 @@ func run(_ *node.Plugin) {
 		spentAddressWorkerPool.TrySubmit(addr)
 	})
 
-	daemon.BackgroundWorker("ZMQ Publisher", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ Publisher", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ Publisher ... done")
 		log.Infof("You can now listen to ZMQ via: %s://%s", config.NodeConfig.GetString(config.CfgZMQProtocol), config.NodeConfig.GetString(config.CfgZMQBindAddress))
 
@@ -162,11 +182,11 @@ func run(_ *node.Plugin) {
 		}
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ address topic updater", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ address topic updater", func(shutdownSignal <-chan struct{}) {
 		timeutil.Ticker(updateAddressTopics, 5*time.Second, shutdownSignal)
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ[NewTxWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ[NewTxWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ[NewTxWorker] ... done")
 		tangle.Events.ReceivedNewTransaction.Attach(onReceivedNewTransaction)
 		newTxWorkerPool.Start()
@@ -177,7 +197,7 @@ func run(_ *node.Plugin) {
 		log.Info("Stopping ZMQ[NewTxWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ[ConfirmedTxWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ[ConfirmedTxWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ[ConfirmedTxWorker] ... done")
 		tangle.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
 		confirmedTxWorkerPool.Start()
@@ -188,7 +208,7 @@ func run(_ *node.Plugin) {
 		log.Info("Stopping ZMQ[ConfirmedTxWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ[NewLatestMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ[NewLatestMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ[NewLatestMilestoneWorker] ... done")
 		tangle.Events.LatestMilestoneChanged.Attach(onLatestMilestoneChanged)
 		newLatestMilestoneWorkerPool.Start()
@@ -199,7 +219,7 @@ func run(_ *node.Plugin) {
 		log.Info("Stopping ZMQ[NewLatestMilestoneWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ[NewSolidMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ[NewSolidMilestoneWorker]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ[NewSolidMilestoneWorker] ... done")
 		tangle.Events.SolidMilestoneChanged.Attach(onSolidMilestoneChanged)
 		newSolidMilestoneWorkerPool.Start()
@@ -210,7 +230,7 @@ func run(_ *node.Plugin) {
 		log.Info("Stopping ZMQ[NewSolidMilestoneWorker] ... done")
 	}, shutdown.PriorityMetricsPublishers)
 
-	daemon.BackgroundWorker("ZMQ[SpentAddress]", func(shutdownSignal <-chan struct{}) {
+	control.BackgroundWorker("ZMQ[SpentAddress]", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting ZMQ[SpentAddress] ... done")
 		tanglePackage.Events.AddressSpent.Attach(onAddressSpent)
 		spentAddressWorkerPool.Start()
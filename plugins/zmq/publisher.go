@@ -16,10 +16,15 @@ type Publisher struct {
 }
 
 // NewPublisher creates a new publisher.
+// If the high water mark is reached because of slow subscribers, new messages are dropped
+// instead of being queued in memory.
 func NewPublisher() (*Publisher, error) {
 
-	//socket := zmq.NewPub(context.Background())
 	socket := zmq.NewPub(context.Background())
+	if err := socket.SetOption(zmq.OptionHWM, config.NodeConfig.GetInt(config.CfgZMQHighWaterMark)); err != nil {
+		return nil, err
+	}
+
 	return &Publisher{
 		socket: socket,
 	}, nil
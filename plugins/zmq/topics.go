@@ -2,10 +2,13 @@ package zmq
 
 import (
 	"sort"
+	"strings"
 	"sync"
 
 	zmq "github.com/go-zeromq/zmq4"
 	"github.com/iotaledger/iota.go/address"
+
+	"github.com/gohornet/hornet/pkg/config"
 )
 
 // Topic names
@@ -37,9 +40,39 @@ var (
 		topicSpentAddress,
 	}
 
+	// enabledTopics are the registered topics which are published
+	enabledTopics = make(map[string]struct{})
+
 	addressTopics AddressTopics
 )
 
+// configureTopics loads the enabled topics.
+func configureTopics() {
+	for _, topic := range config.NodeConfig.GetStringSlice(config.CfgZMQTopics) {
+		topic = strings.ToLower(topic)
+
+		registered := false
+		for _, rt := range RegisteredZMQTopics {
+			if topic == rt {
+				registered = true
+				break
+			}
+		}
+
+		if !registered {
+			log.Warnf("Unknown ZMQ topic: %s", topic)
+			continue
+		}
+		enabledTopics[topic] = struct{}{}
+	}
+}
+
+// isTopicEnabled returns whether the given registered topic is published.
+func isTopicEnabled(topic string) bool {
+	_, enabled := enabledTopics[topic]
+	return enabled
+}
+
 // SpecialTopics struct
 type SpecialTopics struct {
 	Topics []string